@@ -82,6 +82,18 @@ export const CODE_MONITOR_EVENTS = gql`
                         ...MonitorActionEvents
                     }
                 }
+                ... on MonitorTeamsWebhook {
+                    __typename
+                    events {
+                        ...MonitorActionEvents
+                    }
+                }
+                ... on MonitorIncidentWebhook {
+                    __typename
+                    events {
+                        ...MonitorActionEvents
+                    }
+                }
             }
         }
     }
@@ -73,6 +73,12 @@ export const CodeMonitorNode: React.FunctionComponent<React.PropsWithChildren<Co
                         case 'MonitorSlackWebhook': {
                             return 'Sends Slack notification'
                         }
+                        case 'MonitorTeamsWebhook': {
+                            return 'Sends Microsoft Teams notification'
+                        }
+                        case 'MonitorIncidentWebhook': {
+                            return 'Creates incident'
+                        }
                         case 'MonitorWebhook': {
                             return 'Calls webhook'
                         }
@@ -3,16 +3,25 @@ import type { AuthenticatedUser } from '@sourcegraph/shared/src/auth'
 import {
     type CodeMonitorFields,
     type MonitorActionInput,
+    type MonitorDigestInput,
     type MonitorEditActionInput,
     type MonitorEmailInput,
     MonitorEmailPriority,
     type MonitorWebhookInput,
     type MonitorSlackWebhookInput,
+    type MonitorTeamsWebhookInput,
+    type MonitorIncidentWebhookInput,
     type MonitorWebhookFields,
     type MonitorSlackWebhookFields,
+    type MonitorTeamsWebhookFields,
+    type MonitorIncidentWebhookFields,
     type MonitorEmailFields,
 } from '../../graphql-operations'
 
+function convertDigest(digest: MonitorEmailFields['digest']): MonitorDigestInput | null {
+    return digest ? { interval: digest.interval, maxResults: digest.maxResults } : null
+}
+
 function convertEmailAction(
     action: MonitorEmailFields,
     authenticatedUserId: AuthenticatedUser['id']
@@ -23,6 +32,7 @@ function convertEmailAction(
         priority: MonitorEmailPriority.NORMAL,
         recipients: [authenticatedUserId],
         header: '',
+        digest: convertDigest(action.digest),
     }
 }
 
@@ -31,6 +41,23 @@ function convertSlackWebhookAction(action: MonitorSlackWebhookFields): MonitorSl
         enabled: action.enabled,
         includeResults: action.includeResults,
         url: action.url,
+        digest: convertDigest(action.digest),
+    }
+}
+
+function convertTeamsWebhookAction(action: MonitorTeamsWebhookFields): MonitorTeamsWebhookInput {
+    return {
+        enabled: action.enabled,
+        includeResults: action.includeResults,
+        url: action.url,
+    }
+}
+
+function convertIncidentWebhookAction(action: MonitorIncidentWebhookFields): MonitorIncidentWebhookInput {
+    return {
+        enabled: action.enabled,
+        url: action.url,
+        payloadTemplate: action.payloadTemplate,
     }
 }
 
@@ -39,6 +66,7 @@ function convertWebhookAction(action: MonitorWebhookFields): MonitorWebhookInput
         enabled: action.enabled,
         includeResults: action.includeResults,
         url: action.url,
+        digest: convertDigest(action.digest),
     }
 }
 
@@ -58,6 +86,16 @@ export function convertActionsForCreate(
                     slackWebhook: convertSlackWebhookAction(action),
                 }
             }
+            case 'MonitorTeamsWebhook': {
+                return {
+                    teamsWebhook: convertTeamsWebhookAction(action),
+                }
+            }
+            case 'MonitorIncidentWebhook': {
+                return {
+                    incidentWebhook: convertIncidentWebhookAction(action),
+                }
+            }
             case 'MonitorWebhook': {
                 return {
                     webhook: convertWebhookAction(action),
@@ -90,6 +128,22 @@ export function convertActionsForUpdate(
                     },
                 }
             }
+            case 'MonitorTeamsWebhook': {
+                return {
+                    teamsWebhook: {
+                        id: action.id || null,
+                        update: convertTeamsWebhookAction(action),
+                    },
+                }
+            }
+            case 'MonitorIncidentWebhook': {
+                return {
+                    incidentWebhook: {
+                        id: action.id || null,
+                        update: convertIncidentWebhookAction(action),
+                    },
+                }
+            }
             case 'MonitorWebhook': {
                 return {
                     webhook: {
@@ -35,6 +35,10 @@ const MonitorEmailFragment = gql`
         id
         enabled
         includeResults
+        digest {
+            interval
+            maxResults
+        }
         recipients {
             nodes {
                 id
@@ -49,6 +53,10 @@ const MonitorWebhookFragment = gql`
         id
         enabled
         includeResults
+        digest {
+            interval
+            maxResults
+        }
         url
     }
 `
@@ -59,7 +67,31 @@ const MonitorSlackWebhookFragment = gql`
         id
         enabled
         includeResults
+        digest {
+            interval
+            maxResults
+        }
+        url
+    }
+`
+
+const MonitorTeamsWebhookFragment = gql`
+    fragment MonitorTeamsWebhookFields on MonitorTeamsWebhook {
+        __typename
+        id
+        enabled
+        includeResults
+        url
+    }
+`
+
+const MonitorIncidentWebhookFragment = gql`
+    fragment MonitorIncidentWebhookFields on MonitorIncidentWebhook {
+        __typename
+        id
+        enabled
         url
+        payloadTemplate
     }
 `
 
@@ -80,6 +112,8 @@ const CodeMonitorFragment = gql`
                 ...MonitorEmailFields
                 ...MonitorWebhookFields
                 ...MonitorSlackWebhookFields
+                ...MonitorTeamsWebhookFields
+                ...MonitorIncidentWebhookFields
             }
         }
         owner {
@@ -91,6 +125,8 @@ const CodeMonitorFragment = gql`
     ${MonitorEmailFragment}
     ${MonitorWebhookFragment}
     ${MonitorSlackWebhookFragment}
+    ${MonitorTeamsWebhookFragment}
+    ${MonitorIncidentWebhookFragment}
 `
 
 const ListCodeMonitorsFragment = gql`
@@ -242,18 +278,42 @@ export const fetchCodeMonitor = (id: string): Observable<FetchCodeMonitorResult>
                                 }
                                 enabled
                                 includeResults
+                                digest {
+                                    interval
+                                    maxResults
+                                }
                             }
                             ... on MonitorWebhook {
                                 id
                                 enabled
                                 includeResults
+                                digest {
+                                    interval
+                                    maxResults
+                                }
                                 url
                             }
                             ... on MonitorSlackWebhook {
                                 id
                                 enabled
                                 includeResults
+                                digest {
+                                    interval
+                                    maxResults
+                                }
+                                url
+                            }
+                            ... on MonitorTeamsWebhook {
+                                id
+                                enabled
+                                includeResults
+                                url
+                            }
+                            ... on MonitorIncidentWebhook {
+                                id
+                                enabled
                                 url
+                                payloadTemplate
                             }
                         }
                     }
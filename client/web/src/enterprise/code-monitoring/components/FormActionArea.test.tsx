@@ -30,6 +30,7 @@ describe('FormActionArea', () => {
                 recipients: { nodes: [{ id: mockAuthenticatedUser.id }] },
                 enabled: true,
                 includeResults: false,
+                digest: null,
             },
         ],
     }
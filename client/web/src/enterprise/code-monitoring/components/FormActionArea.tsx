@@ -57,10 +57,18 @@ export const FormActionArea: React.FunctionComponent<React.PropsWithChildren<Act
         actions.nodes.find(action => action.__typename === 'MonitorWebhook')
     )
 
+    // Microsoft Teams and incident webhook actions can only be configured through the API.
+    // Keep them so that they are not deleted when the monitor is saved.
+    const [apiOnlyActions] = useState<MonitorAction[]>(
+        actions.nodes.filter(
+            action => action.__typename === 'MonitorTeamsWebhook' || action.__typename === 'MonitorIncidentWebhook'
+        )
+    )
+
     // Form is completed if there is at least one action
     useEffect(() => {
-        setActionsCompleted(!!emailAction || !!slackWebhookAction || !!webhookAction)
-    }, [emailAction, setActionsCompleted, slackWebhookAction, webhookAction])
+        setActionsCompleted(!!emailAction || !!slackWebhookAction || !!webhookAction || apiOnlyActions.length > 0)
+    }, [apiOnlyActions, emailAction, setActionsCompleted, slackWebhookAction, webhookAction])
 
     useEffect(() => {
         const actions: CodeMonitorFields['actions'] = { nodes: [] }
@@ -73,8 +81,9 @@ export const FormActionArea: React.FunctionComponent<React.PropsWithChildren<Act
         if (webhookAction) {
             actions.nodes.push(webhookAction)
         }
+        actions.nodes.push(...apiOnlyActions)
         onActionsChange(actions)
-    }, [emailAction, onActionsChange, slackWebhookAction, webhookAction])
+    }, [apiOnlyActions, emailAction, onActionsChange, slackWebhookAction, webhookAction])
 
     const showWebhooks = useExperimentalFeatures(features => features.codeMonitoringWebHooks)
 
@@ -27,7 +27,7 @@ interface TriggerAreaProps {
     isSourcegraphDotCom: boolean
 }
 
-const isDiffOrCommit = (value: string): boolean => value === 'diff' || value === 'commit'
+const isDiffCommitOrFile = (value: string): boolean => value === 'diff' || value === 'commit' || value === 'file'
 const isLiteralOrRegexp = (value: string): boolean => value === 'literal' || value === 'regexp'
 
 const ValidQueryChecklistItem: React.FunctionComponent<
@@ -143,7 +143,7 @@ export const FormTriggerArea: React.FunctionComponent<React.PropsWithChildren<Tr
                     filter.type === 'filter' &&
                     resolveFilter(filter.field.value)?.type === FilterType.type &&
                     filter.value &&
-                    isDiffOrCommit(filter.value.value)
+                    isDiffCommitOrFile(filter.value.value)
             )
 
             hasRepoFilter = filters.some(
@@ -274,10 +274,11 @@ export const FormTriggerArea: React.FunctionComponent<React.PropsWithChildren<Tr
                             <li>
                                 <ValidQueryChecklistItem
                                     checked={hasTypeDiffOrCommitFilter}
-                                    hint="type:diff targets code present in new commits, while type:commit targets commit messages"
+                                    hint="type:diff targets code present in new commits, type:commit targets commit messages, and type:file targets matches that newly appear in file contents"
                                     dataTestid="type-checkbox"
                                 >
-                                    Contains a <Code>type:diff</Code> or <Code>type:commit</Code> filter
+                                    Contains a <Code>type:diff</Code>, <Code>type:commit</Code> or{' '}
+                                    <Code>type:file</Code> filter
                                 </ValidQueryChecklistItem>
                             </li>
                             {/* Enforce repo filter on sourcegraph.com because otherwise it's too easy to generate a lot of load */}
@@ -32,6 +32,7 @@ const action: ActionProps['action'] = {
     recipients: { nodes: [{ id: 'userID' }] },
     enabled: true,
     includeResults: false,
+    digest: null,
 }
 window.context.emailEnabled = true
 
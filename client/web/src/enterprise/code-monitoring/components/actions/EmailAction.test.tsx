@@ -51,6 +51,7 @@ describe('EmailAction', () => {
             __typename: 'MonitorEmail',
             enabled: true,
             includeResults: false,
+            digest: null,
             id: '',
             recipients: { nodes: [{ id: 'userID' }] },
         })
@@ -72,6 +73,7 @@ describe('EmailAction', () => {
             __typename: 'MonitorEmail',
             enabled: true,
             includeResults: true,
+            digest: null,
             id: '',
             recipients: { nodes: [{ id: 'userID' }] },
         })
@@ -87,6 +89,7 @@ describe('EmailAction', () => {
                         __typename: 'MonitorEmail',
                         enabled: true,
                         includeResults: false,
+                        digest: null,
                         id: '',
                         recipients: { nodes: [{ id: 'userID' }] },
                     }}
@@ -111,6 +114,7 @@ describe('EmailAction', () => {
                         __typename: 'MonitorEmail',
                         enabled: false,
                         includeResults: false,
+                        digest: null,
                         id: '1',
                         recipients: { nodes: [{ id: 'userID' }] },
                     }}
@@ -127,6 +131,7 @@ describe('EmailAction', () => {
             __typename: 'MonitorEmail',
             enabled: true,
             includeResults: false,
+            digest: null,
             id: '1',
             recipients: { nodes: [{ id: 'userID' }] },
         })
@@ -139,6 +144,7 @@ describe('EmailAction', () => {
             __typename: 'MonitorEmail',
             enabled: false,
             includeResults: false,
+            digest: null,
             id: '1',
             recipients: { nodes: [{ id: 'userID' }] },
         })
@@ -155,6 +161,7 @@ describe('EmailAction', () => {
                         __typename: 'MonitorEmail',
                         enabled: true,
                         includeResults: false,
+                        digest: null,
                         id: '1',
                         recipients: { nodes: [{ id: 'userID' }] },
                     }}
@@ -56,6 +56,8 @@ export const EmailAction: React.FunctionComponent<React.PropsWithChildren<Action
                 recipients: { nodes: [{ id: authenticatedUser.id }] },
                 enabled,
                 includeResults,
+                // The digest settings can only be changed through the API, so keep them as they are.
+                digest: action && action.__typename === 'MonitorEmail' ? action.digest : null,
             })
         },
         [action, authenticatedUser.id, enabled, includeResults, setAction]
@@ -47,6 +47,7 @@ describe('SlackWebhookAction', () => {
             __typename: 'MonitorSlackWebhook',
             enabled: true,
             includeResults: true,
+            digest: null,
             id: '',
             url: SLACK_URL,
         })
@@ -63,6 +64,7 @@ describe('SlackWebhookAction', () => {
                         __typename: 'MonitorSlackWebhook',
                         enabled: true,
                         includeResults: false,
+                        digest: null,
                         id: '1',
                         url: SLACK_URL,
                     }}
@@ -85,6 +87,7 @@ describe('SlackWebhookAction', () => {
             __typename: 'MonitorSlackWebhook',
             enabled: true,
             includeResults: false,
+            digest: null,
             id: '1',
             url: SLACK_URL,
         })
@@ -100,6 +103,7 @@ describe('SlackWebhookAction', () => {
                         __typename: 'MonitorSlackWebhook',
                         enabled: true,
                         includeResults: false,
+                        digest: null,
                         id: '2',
                         url: SLACK_URL,
                     }}
@@ -124,6 +128,7 @@ describe('SlackWebhookAction', () => {
                         __typename: 'MonitorSlackWebhook',
                         enabled: false,
                         includeResults: false,
+                        digest: null,
                         id: '5',
                         url: SLACK_URL,
                     }}
@@ -140,6 +145,7 @@ describe('SlackWebhookAction', () => {
             __typename: 'MonitorSlackWebhook',
             enabled: true,
             includeResults: false,
+            digest: null,
             id: '5',
             url: SLACK_URL,
         })
@@ -152,6 +158,7 @@ describe('SlackWebhookAction', () => {
             __typename: 'MonitorSlackWebhook',
             enabled: false,
             includeResults: false,
+            digest: null,
             id: '5',
             url: SLACK_URL,
         })
@@ -167,6 +174,7 @@ describe('SlackWebhookAction', () => {
                         __typename: 'MonitorSlackWebhook',
                         enabled: true,
                         includeResults: false,
+                        digest: null,
                         id: '5',
                         url: 'https://example.com',
                     }}
@@ -197,6 +205,7 @@ describe('SlackWebhookAction', () => {
             __typename: 'MonitorSlackWebhook',
             enabled: false,
             includeResults: false,
+            digest: null,
             id: '5',
             url: SLACK_URL,
         }
@@ -55,6 +55,8 @@ export const SlackWebhookAction: React.FunctionComponent<React.PropsWithChildren
                 url,
                 enabled,
                 includeResults,
+                // The digest settings can only be changed through the API, so keep them as they are.
+                digest: action && action.__typename === 'MonitorSlackWebhook' ? action.digest : null,
             })
         },
         [action, includeResults, setAction, url, enabled]
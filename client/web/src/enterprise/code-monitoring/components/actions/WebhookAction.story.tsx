@@ -32,6 +32,7 @@ const action: ActionProps['action'] = {
     url: 'https://example.com',
     enabled: true,
     includeResults: false,
+    digest: null,
 }
 
 export const WebhookActionStory: StoryFn = () => (
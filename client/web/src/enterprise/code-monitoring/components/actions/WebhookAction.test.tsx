@@ -45,6 +45,7 @@ describe('WebhookAction', () => {
             __typename: 'MonitorWebhook',
             enabled: true,
             includeResults: true,
+            digest: null,
             id: '',
             url: 'https://example.com',
         })
@@ -61,6 +62,7 @@ describe('WebhookAction', () => {
                         __typename: 'MonitorWebhook',
                         enabled: true,
                         includeResults: false,
+                        digest: null,
                         id: '1',
                         url: 'https://example.com',
                     }}
@@ -83,6 +85,7 @@ describe('WebhookAction', () => {
             __typename: 'MonitorWebhook',
             enabled: true,
             includeResults: false,
+            digest: null,
             id: '1',
             url: 'https://example2.com',
         })
@@ -98,6 +101,7 @@ describe('WebhookAction', () => {
                         __typename: 'MonitorWebhook',
                         enabled: true,
                         includeResults: false,
+                        digest: null,
                         id: '2',
                         url: 'https://example.com',
                     }}
@@ -122,6 +126,7 @@ describe('WebhookAction', () => {
                         __typename: 'MonitorWebhook',
                         enabled: false,
                         includeResults: false,
+                        digest: null,
                         id: '5',
                         url: 'https://example.com',
                     }}
@@ -138,6 +143,7 @@ describe('WebhookAction', () => {
             __typename: 'MonitorWebhook',
             enabled: true,
             includeResults: false,
+            digest: null,
             id: '5',
             url: 'https://example.com',
         })
@@ -150,6 +156,7 @@ describe('WebhookAction', () => {
             __typename: 'MonitorWebhook',
             enabled: false,
             includeResults: false,
+            digest: null,
             id: '5',
             url: 'https://example.com',
         })
@@ -165,6 +172,7 @@ describe('WebhookAction', () => {
                         __typename: 'MonitorWebhook',
                         enabled: true,
                         includeResults: false,
+                        digest: null,
                         id: '5',
                         url: 'https://example.com',
                     }}
@@ -195,6 +203,7 @@ describe('WebhookAction', () => {
             __typename: 'MonitorWebhook',
             enabled: false,
             includeResults: false,
+            digest: null,
             id: '5',
             url: 'https://example.com',
         }
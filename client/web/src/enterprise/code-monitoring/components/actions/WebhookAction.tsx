@@ -55,6 +55,8 @@ export const WebhookAction: React.FunctionComponent<React.PropsWithChildren<Acti
                 url,
                 enabled,
                 includeResults,
+                // The digest settings can only be changed through the API, so keep them as they are.
+                digest: action && action.__typename === 'MonitorWebhook' ? action.digest : null,
             })
         },
         [action, includeResults, setAction, url, enabled]
@@ -159,6 +159,12 @@ function getActionEventTitle(action: MonitorTriggerEventWithActions['actions']['
         case 'MonitorSlackWebhook': {
             return 'Slack'
         }
+        case 'MonitorTeamsWebhook': {
+            return 'Microsoft Teams'
+        }
+        case 'MonitorIncidentWebhook': {
+            return 'Incident'
+        }
         case 'MonitorWebhook': {
             return 'Webhook'
         }
@@ -28,7 +28,11 @@ export const createInsightView = (insight: InsightViewNode): Insight => {
 
             const { defaultFilters, defaultSeriesDisplayOptions } = insight
             // We do not support different time scope for different series at the moment
-            const step = getDurationFromStep(insight.dataSeriesDefinitions[0].timeScope)
+            const { timeScope } = insight.dataSeriesDefinitions[0]
+            // Series sampled at repository tags are displayed at the dates of their tags, they
+            // don't have a step that can be edited in the UI.
+            const step =
+                timeScope.__typename === 'InsightIntervalTimeScope' ? getDurationFromStep(timeScope) : { days: 1 }
             const { repositories, repoSearch } = getInsightRepositories(insight.repositoryDefinition)
             const filters = getParsedFilters(defaultFilters, defaultSeriesDisplayOptions)
 
@@ -17,7 +17,7 @@ import { AGGREGATION_MODE_URL_KEY, AGGREGATION_UI_MODE_URL_KEY } from './constan
 import { GroupResultsPing } from './pings'
 import { AggregationUIMode } from './types'
 
-type SerializedAggregationMode = 'repo' | 'path' | 'author' | 'group' | 'repo-metadata' | ''
+type SerializedAggregationMode = 'repo' | 'path' | 'author' | 'group' | 'repo-metadata' | 'owner' | ''
 
 const aggregationModeSerializer = (mode: SearchAggregationMode | null): SerializedAggregationMode => {
     switch (mode) {
@@ -36,6 +36,9 @@ const aggregationModeSerializer = (mode: SearchAggregationMode | null): Serializ
         case SearchAggregationMode.REPO_METADATA: {
             return 'repo-metadata'
         }
+        case SearchAggregationMode.OWNER: {
+            return 'owner'
+        }
         default: {
             return ''
         }
@@ -61,6 +64,9 @@ const aggregationModeDeserializer = (
         case 'repo-metadata': {
             return SearchAggregationMode.REPO_METADATA
         }
+        case 'owner': {
+            return SearchAggregationMode.OWNER
+        }
 
         default: {
             return null
@@ -44,6 +44,24 @@ type CloseBatchChangeArgs struct {
 	CloseChangesets bool
 }
 
+type RollbackBatchChangeArgs struct {
+	BatchChange graphql.ID
+	Publish     bool
+}
+
+type SetBatchChangeAutoRebaseArgs struct {
+	BatchChange      graphql.ID
+	Enabled          bool
+	MaxCommitsBehind *int32
+}
+
+type SetBatchChangeAutoMergePolicyArgs struct {
+	BatchChange      graphql.ID
+	Enabled          bool
+	MaxMergesPerHour *int32
+	Squash           *bool
+}
+
 type MoveBatchChangeArgs struct {
 	BatchChange  graphql.ID
 	NewName      *string
@@ -97,6 +115,34 @@ type CreateBatchSpecFromRawArgs struct {
 	BatchChange      graphql.ID
 }
 
+type CreateBatchSpecFromTemplateArgs struct {
+	BatchSpecTemplate graphql.ID
+	Values            *JSONValue
+	AllowIgnored      bool
+	AllowUnsupported  bool
+	NoCache           bool
+	Namespace         graphql.ID
+	BatchChange       graphql.ID
+}
+
+type CreateBatchSpecTemplateArgs struct {
+	Template string
+}
+
+type UpdateBatchSpecTemplateArgs struct {
+	BatchSpecTemplate graphql.ID
+	Template          string
+}
+
+type DeleteBatchSpecTemplateArgs struct {
+	BatchSpecTemplate graphql.ID
+}
+
+type ListBatchSpecTemplatesArgs struct {
+	First int32
+	After *string
+}
+
 type ReplaceBatchSpecInputArgs struct {
 	PreviousSpec     graphql.ID
 	BatchSpec        string
@@ -118,6 +164,12 @@ type ExecuteBatchSpecArgs struct {
 	AutoApply bool
 }
 
+type PreviewBatchSpecExecutionArgs struct {
+	BatchSpec  graphql.ID
+	SampleSize int32
+	NoCache    *bool
+}
+
 type CancelBatchSpecExecutionArgs struct {
 	BatchSpec graphql.ID
 }
@@ -256,10 +308,15 @@ type BatchChangesResolver interface {
 	CreateEmptyBatchChange(ctx context.Context, args *CreateEmptyBatchChangeArgs) (BatchChangeResolver, error)
 	UpsertEmptyBatchChange(ctx context.Context, args *UpsertEmptyBatchChangeArgs) (BatchChangeResolver, error)
 	CreateBatchSpecFromRaw(ctx context.Context, args *CreateBatchSpecFromRawArgs) (BatchSpecResolver, error)
+	CreateBatchSpecFromTemplate(ctx context.Context, args *CreateBatchSpecFromTemplateArgs) (BatchSpecResolver, error)
+	CreateBatchSpecTemplate(ctx context.Context, args *CreateBatchSpecTemplateArgs) (BatchSpecTemplateResolver, error)
+	UpdateBatchSpecTemplate(ctx context.Context, args *UpdateBatchSpecTemplateArgs) (BatchSpecTemplateResolver, error)
+	DeleteBatchSpecTemplate(ctx context.Context, args *DeleteBatchSpecTemplateArgs) (*EmptyResponse, error)
 	ReplaceBatchSpecInput(ctx context.Context, args *ReplaceBatchSpecInputArgs) (BatchSpecResolver, error)
 	UpsertBatchSpecInput(ctx context.Context, args *UpsertBatchSpecInputArgs) (BatchSpecResolver, error)
 	DeleteBatchSpec(ctx context.Context, args *DeleteBatchSpecArgs) (*EmptyResponse, error)
 	ExecuteBatchSpec(ctx context.Context, args *ExecuteBatchSpecArgs) (BatchSpecResolver, error)
+	PreviewBatchSpecExecution(ctx context.Context, args *PreviewBatchSpecExecutionArgs) (BatchSpecResolver, error)
 	CancelBatchSpecExecution(ctx context.Context, args *CancelBatchSpecExecutionArgs) (BatchSpecResolver, error)
 	CancelBatchSpecWorkspaceExecution(ctx context.Context, args *CancelBatchSpecWorkspaceExecutionArgs) (*EmptyResponse, error)
 	RetryBatchSpecWorkspaceExecution(ctx context.Context, args *RetryBatchSpecWorkspaceExecutionArgs) (*EmptyResponse, error)
@@ -269,6 +326,9 @@ type BatchChangesResolver interface {
 
 	ApplyBatchChange(ctx context.Context, args *ApplyBatchChangeArgs) (BatchChangeResolver, error)
 	CloseBatchChange(ctx context.Context, args *CloseBatchChangeArgs) (BatchChangeResolver, error)
+	SetBatchChangeAutoRebase(ctx context.Context, args *SetBatchChangeAutoRebaseArgs) (BatchChangeResolver, error)
+	SetBatchChangeAutoMergePolicy(ctx context.Context, args *SetBatchChangeAutoMergePolicyArgs) (BatchChangeResolver, error)
+	RollbackBatchChange(ctx context.Context, args *RollbackBatchChangeArgs) (BatchChangeResolver, error)
 	MoveBatchChange(ctx context.Context, args *MoveBatchChangeArgs) (BatchChangeResolver, error)
 	DeleteBatchChange(ctx context.Context, args *DeleteBatchChangeArgs) (*EmptyResponse, error)
 	CreateBatchChangesCredential(ctx context.Context, args *CreateBatchChangesCredentialArgs) (BatchChangesCredentialResolver, error)
@@ -296,6 +356,7 @@ type BatchChangesResolver interface {
 	RepoDiffStat(ctx context.Context, repo *graphql.ID) (*DiffStat, error)
 
 	BatchSpecs(cx context.Context, args *ListBatchSpecArgs) (BatchSpecConnectionResolver, error)
+	BatchSpecTemplates(ctx context.Context, args *ListBatchSpecTemplatesArgs) (BatchSpecTemplateConnectionResolver, error)
 	AvailableBulkOperations(ctx context.Context, args *AvailableBulkOperationsArgs) ([]string, error)
 
 	ResolveWorkspacesForBatchSpec(ctx context.Context, args *ResolveWorkspacesForBatchSpecArgs) ([]ResolvedBatchSpecWorkspaceResolver, error)
@@ -327,6 +388,32 @@ type BulkOperationResolver interface {
 	FinishedAt() *gqlutil.DateTime
 }
 
+type BatchSpecTemplateResolver interface {
+	ID() graphql.ID
+	Name() string
+	Description() string
+	Parameters() []BatchSpecTemplateParameterResolver
+	Spec() string
+	RawTemplate() string
+	Creator(ctx context.Context) (*UserResolver, error)
+	CreatedAt() gqlutil.DateTime
+	UpdatedAt() gqlutil.DateTime
+}
+
+type BatchSpecTemplateParameterResolver interface {
+	Name() string
+	Type() string
+	Description() string
+	Required() bool
+	Default() *JSONValue
+}
+
+type BatchSpecTemplateConnectionResolver interface {
+	TotalCount(ctx context.Context) (int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+	Nodes(ctx context.Context) ([]BatchSpecTemplateResolver, error)
+}
+
 type ChangesetJobErrorResolver interface {
 	Changeset() ChangesetResolver
 	Error() *string
@@ -377,6 +464,24 @@ type BatchSpecResolver interface {
 	Source() string
 
 	Files(ctx context.Context, args *ListBatchSpecWorkspaceFilesArgs) (BatchSpecWorkspaceFileConnectionResolver, error)
+
+	ExecutionPreview(ctx context.Context) (BatchSpecExecutionPreviewResolver, error)
+}
+
+type BatchSpecExecutionPreviewResolver interface {
+	SampleSize() int32
+	TotalWorkspaces() int32
+	CompletedWorkspaces() int32
+	FailedWorkspaces() int32
+	DiffStat() *DiffStat
+	FileExtensions() []BatchSpecExecutionPreviewFileExtensionResolver
+	EstimatedExecutionTime() *int32
+}
+
+type BatchSpecExecutionPreviewFileExtensionResolver interface {
+	Extension() string
+	Files() int32
+	DiffStat() *DiffStat
 }
 
 type BatchChangeDescriptionResolver interface {
@@ -489,6 +594,7 @@ type VisibleChangesetSpecResolver interface {
 
 	Description(ctx context.Context) (ChangesetDescription, error)
 	Workspace(ctx context.Context) (BatchSpecWorkspaceResolver, error)
+	RevertedChangeset(ctx context.Context) (ChangesetResolver, error)
 
 	ForkTarget() ForkTargetInterface
 }
@@ -598,7 +704,9 @@ type ListChangesetsArgs struct {
 	// ReviewState is a value of type *btypes.ChangesetReviewState.
 	ReviewState *string
 	// CheckState is a value of type *btypes.ChangesetCheckState.
-	CheckState                     *string
+	CheckState *string
+	// FailingCheck is the name of a check that must have failed.
+	FailingCheck                   *string
 	OnlyPublishedByThisBatchChange *bool
 	Search                         *string
 
@@ -670,6 +778,9 @@ type BatchChangeResolver interface {
 	Changesets(ctx context.Context, args *ListChangesetsArgs) (ChangesetsConnectionResolver, error)
 	ChangesetCountsOverTime(ctx context.Context, args *ChangesetCountsArgs) ([]ChangesetCountsResolver, error)
 	ClosedAt() *gqlutil.DateTime
+	AutoRebase() bool
+	AutoRebaseMaxCommitsBehind() int32
+	AutoMergePolicy() BatchChangeAutoMergePolicyResolver
 	DiffStat(ctx context.Context) (*DiffStat, error)
 	CurrentSpec(ctx context.Context) (BatchSpecResolver, error)
 	BulkOperations(ctx context.Context, args *ListBatchChangeBulkOperationArgs) (BulkOperationConnectionResolver, error)
@@ -755,6 +866,32 @@ type ChangesetsConnectionResolver interface {
 	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
 }
 
+type BatchChangeAutoMergePolicyResolver interface {
+	Enabled() bool
+	MaxMergesPerHour() int32
+	Squash() bool
+}
+
+type ChangesetAutoMergeResolver interface {
+	State() string
+	Message() *string
+	MergedAt() *gqlutil.DateTime
+	UpdatedAt() gqlutil.DateTime
+}
+
+type ChangesetCheckRunResolver interface {
+	Name() string
+	// State returns a value of type *btypes.ChangesetCheckState.
+	State() *string
+	URL() *string
+}
+
+type ChangesetReviewerDecisionResolver interface {
+	Reviewer() string
+	// State returns a value of type btypes.ChangesetReviewState.
+	State() string
+}
+
 type ChangesetLabelResolver interface {
 	Text() string
 	Color() string
@@ -811,6 +948,8 @@ type ExternalChangesetResolver interface {
 	ReviewState(context.Context) *string
 	// CheckState returns a value of type *btypes.ChangesetCheckState.
 	CheckState() *string
+	CheckRuns() []ChangesetCheckRunResolver
+	ReviewerDecisions() []ChangesetReviewerDecisionResolver
 	Repository(ctx context.Context) *RepositoryResolver
 
 	Events(ctx context.Context, args *ChangesetEventsConnectionArgs) (ChangesetEventsConnectionResolver, error)
@@ -820,6 +959,8 @@ type ExternalChangesetResolver interface {
 
 	Error() *string
 	SyncerError() *string
+	RebaseConflicts(ctx context.Context) ([]string, error)
+	AutoMerge(ctx context.Context) (ChangesetAutoMergeResolver, error)
 	ScheduleEstimateAt(ctx context.Context) (*gqlutil.DateTime, error)
 
 	CurrentSpec(ctx context.Context) (VisibleChangesetSpecResolver, error)
@@ -32,6 +32,8 @@ type CodeMonitorsResolver interface {
 	TriggerTestEmailAction(ctx context.Context, args *TriggerTestEmailActionArgs) (*EmptyResponse, error)
 	TriggerTestWebhookAction(ctx context.Context, args *TriggerTestWebhookActionArgs) (*EmptyResponse, error)
 	TriggerTestSlackWebhookAction(ctx context.Context, args *TriggerTestSlackWebhookActionArgs) (*EmptyResponse, error)
+	TriggerTestTeamsWebhookAction(ctx context.Context, args *TriggerTestTeamsWebhookActionArgs) (*EmptyResponse, error)
+	TriggerTestIncidentWebhookAction(ctx context.Context, args *TriggerTestIncidentWebhookActionArgs) (*EmptyResponse, error)
 
 	NodeResolvers() map[string]NodeByIDFunc
 }
@@ -90,6 +92,8 @@ type MonitorAction interface {
 	ToMonitorEmail() (MonitorEmailResolver, bool)
 	ToMonitorWebhook() (MonitorWebhookResolver, bool)
 	ToMonitorSlackWebhook() (MonitorSlackWebhookResolver, bool)
+	ToMonitorTeamsWebhook() (MonitorTeamsWebhookResolver, bool)
+	ToMonitorIncidentWebhook() (MonitorIncidentWebhookResolver, bool)
 }
 
 type MonitorEmailResolver interface {
@@ -98,6 +102,7 @@ type MonitorEmailResolver interface {
 	IncludeResults() bool
 	Priority() string
 	Header() string
+	Digest() MonitorDigestResolver
 	Recipients(ctx context.Context, args *ListRecipientsArgs) (MonitorActionEmailRecipientsConnectionResolver, error)
 	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
 }
@@ -107,6 +112,7 @@ type MonitorWebhookResolver interface {
 	Enabled() bool
 	IncludeResults() bool
 	URL() string
+	Digest() MonitorDigestResolver
 	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
 }
 
@@ -115,6 +121,28 @@ type MonitorSlackWebhookResolver interface {
 	Enabled() bool
 	IncludeResults() bool
 	URL() string
+	Digest() MonitorDigestResolver
+	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
+}
+
+type MonitorDigestResolver interface {
+	Interval() string
+	MaxResults() int32
+}
+
+type MonitorTeamsWebhookResolver interface {
+	ID() graphql.ID
+	Enabled() bool
+	IncludeResults() bool
+	URL() string
+	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
+}
+
+type MonitorIncidentWebhookResolver interface {
+	ID() graphql.ID
+	Enabled() bool
+	URL() string
+	PayloadTemplate() string
 	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
 }
 
@@ -172,9 +200,11 @@ type CreateTriggerArgs struct {
 }
 
 type CreateActionArgs struct {
-	Email        *CreateActionEmailArgs
-	Webhook      *CreateActionWebhookArgs
-	SlackWebhook *CreateActionSlackWebhookArgs
+	Email           *CreateActionEmailArgs
+	Webhook         *CreateActionWebhookArgs
+	SlackWebhook    *CreateActionSlackWebhookArgs
+	TeamsWebhook    *CreateActionTeamsWebhookArgs
+	IncidentWebhook *CreateActionIncidentWebhookArgs
 }
 
 type CreateActionEmailArgs struct {
@@ -183,18 +213,38 @@ type CreateActionEmailArgs struct {
 	Priority       string
 	Recipients     []graphql.ID
 	Header         string
+	Digest         *CreateActionDigestArgs
 }
 
 type CreateActionWebhookArgs struct {
 	Enabled        bool
 	IncludeResults bool
 	URL            string
+	Digest         *CreateActionDigestArgs
 }
 
 type CreateActionSlackWebhookArgs struct {
 	Enabled        bool
 	IncludeResults bool
 	URL            string
+	Digest         *CreateActionDigestArgs
+}
+
+type CreateActionDigestArgs struct {
+	Interval   string
+	MaxResults *int32
+}
+
+type CreateActionTeamsWebhookArgs struct {
+	Enabled        bool
+	IncludeResults bool
+	URL            string
+}
+
+type CreateActionIncidentWebhookArgs struct {
+	Enabled         bool
+	URL             string
+	PayloadTemplate string
 }
 
 type ToggleCodeMonitorArgs struct {
@@ -228,6 +278,18 @@ type TriggerTestSlackWebhookActionArgs struct {
 	SlackWebhook *CreateActionSlackWebhookArgs
 }
 
+type TriggerTestTeamsWebhookActionArgs struct {
+	Namespace    graphql.ID
+	Description  string
+	TeamsWebhook *CreateActionTeamsWebhookArgs
+}
+
+type TriggerTestIncidentWebhookActionArgs struct {
+	Namespace       graphql.ID
+	Description     string
+	IncidentWebhook *CreateActionIncidentWebhookArgs
+}
+
 type CreateMonitorArgs struct {
 	Namespace   graphql.ID
 	Description string
@@ -249,10 +311,22 @@ type EditActionSlackWebhookArgs struct {
 	Update *CreateActionSlackWebhookArgs
 }
 
+type EditActionTeamsWebhookArgs struct {
+	Id     *graphql.ID
+	Update *CreateActionTeamsWebhookArgs
+}
+
+type EditActionIncidentWebhookArgs struct {
+	Id     *graphql.ID
+	Update *CreateActionIncidentWebhookArgs
+}
+
 type EditActionArgs struct {
-	Email        *EditActionEmailArgs
-	Webhook      *EditActionWebhookArgs
-	SlackWebhook *EditActionSlackWebhookArgs
+	Email           *EditActionEmailArgs
+	Webhook         *EditActionWebhookArgs
+	SlackWebhook    *EditActionSlackWebhookArgs
+	TeamsWebhook    *EditActionTeamsWebhookArgs
+	IncidentWebhook *EditActionIncidentWebhookArgs
 }
 
 type EditTriggerArgs struct {
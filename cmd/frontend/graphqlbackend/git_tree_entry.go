@@ -441,11 +441,18 @@ func (r *GitTreeEntryResolver) IsSingleChild(ctx context.Context) (bool, error)
 	return len(entries) == 1, nil
 }
 
-func (r *GitTreeEntryResolver) LSIF(ctx context.Context, args *struct{ ToolName *string }) (resolverstubs.GitBlobLSIFDataResolver, error) {
+func (r *GitTreeEntryResolver) LSIF(ctx context.Context, args *struct {
+	ToolName *string
+	Diff     *string
+}) (resolverstubs.GitBlobLSIFDataResolver, error) {
 	var toolName string
 	if args.ToolName != nil {
 		toolName = *args.ToolName
 	}
+	var diff string
+	if args.Diff != nil {
+		diff = *args.Diff
+	}
 
 	repo, err := r.commit.repoResolver.getRepo(ctx)
 	if err != nil {
@@ -458,6 +465,7 @@ func (r *GitTreeEntryResolver) LSIF(ctx context.Context, args *struct{ ToolName
 		Path:      r.Path(),
 		ExactPath: !r.stat.IsDir(),
 		ToolName:  toolName,
+		Diff:      diff,
 	})
 }
 
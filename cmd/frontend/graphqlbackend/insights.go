@@ -25,6 +25,9 @@ type InsightsResolver interface {
 
 	ValidateScopedInsightQuery(ctx context.Context, args ValidateScopedInsightQueryArgs) (ScopedInsightQueryPayloadResolver, error)
 	PreviewRepositoriesFromQuery(ctx context.Context, args PreviewRepositoriesFromQueryArgs) (RepositoryPreviewPayloadResolver, error)
+	ExportInsightsDashboards(ctx context.Context, args *ExportInsightsDashboardsArgs) (string, error)
+	InsightHistoricalQuery(ctx context.Context, args *InsightHistoricalQueryArgs) (InsightHistoricalQueryResolver, error)
+	InsightSeriesAlertRules(ctx context.Context, args *InsightSeriesAlertRulesArgs) ([]InsightSeriesAlertRuleResolver, error)
 
 	// Mutations
 	CreateInsightsDashboard(ctx context.Context, args *CreateInsightsDashboardArgs) (InsightsDashboardPayloadResolver, error)
@@ -32,6 +35,7 @@ type InsightsResolver interface {
 	DeleteInsightsDashboard(ctx context.Context, args *DeleteInsightsDashboardArgs) (*EmptyResponse, error)
 	RemoveInsightViewFromDashboard(ctx context.Context, args *RemoveInsightViewFromDashboardArgs) (InsightsDashboardPayloadResolver, error)
 	AddInsightViewToDashboard(ctx context.Context, args *AddInsightViewToDashboardArgs) (InsightsDashboardPayloadResolver, error)
+	ImportInsightsDashboards(ctx context.Context, args *ImportInsightsDashboardsArgs) (ImportInsightsDashboardsPayloadResolver, error)
 
 	CreateLineChartSearchInsight(ctx context.Context, args *CreateLineChartSearchInsightArgs) (InsightViewPayloadResolver, error)
 	UpdateLineChartSearchInsight(ctx context.Context, args *UpdateLineChartSearchInsightArgs) (InsightViewPayloadResolver, error)
@@ -40,6 +44,9 @@ type InsightsResolver interface {
 
 	DeleteInsightView(ctx context.Context, args *DeleteInsightViewArgs) (*EmptyResponse, error)
 	SaveInsightAsNewView(ctx context.Context, args SaveInsightAsNewViewArgs) (InsightViewPayloadResolver, error)
+	RunInsightHistoricalQuery(ctx context.Context, args *RunInsightHistoricalQueryArgs) (InsightHistoricalQueryResolver, error)
+	CreateInsightSeriesAlertRule(ctx context.Context, args *CreateInsightSeriesAlertRuleArgs) (InsightSeriesAlertRuleResolver, error)
+	DeleteInsightSeriesAlertRule(ctx context.Context, args *DeleteInsightSeriesAlertRuleArgs) (*EmptyResponse, error)
 
 	// Admin Management
 	InsightSeriesQueryStatus(ctx context.Context) ([]InsightSeriesQueryStatusResolver, error)
@@ -93,6 +100,7 @@ type InsightsDataPointResolver interface {
 	DateTime() gqlutil.DateTime
 	Value() float64
 	DiffQuery() (*string, error)
+	Revision() *string
 }
 
 type InsightViewDebugResolver interface {
@@ -120,6 +128,28 @@ type InsightSeriesResolver interface {
 	Label() string
 	Points(ctx context.Context, args *InsightsPointsArgs) ([]InsightsDataPointResolver, error)
 	Status(ctx context.Context) (InsightStatusResolver, error)
+	RepositoryBreakdown(ctx context.Context, args *InsightRepositoryBreakdownArgs) (InsightRepositoryBreakdownConnectionResolver, error)
+}
+
+type InsightRepositoryBreakdownArgs struct {
+	First        int32
+	After        *string
+	OrderBy      string
+	Descending   bool
+	ChangeWindow *TimeIntervalStepInput
+}
+
+type InsightRepositoryBreakdownConnectionResolver interface {
+	Nodes(ctx context.Context) ([]InsightRepositorySeriesResolver, error)
+	TotalCount(ctx context.Context) (int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+}
+
+type InsightRepositorySeriesResolver interface {
+	RepositoryName() string
+	LatestValue() float64
+	Change() float64
+	Points() []InsightsDataPointResolver
 }
 
 type InsightResolver interface {
@@ -237,6 +267,7 @@ type SearchInsightDataSeriesDefinitionResolver interface {
 	RepositoryDefinition(ctx context.Context) (InsightRepositoryDefinition, error)
 	TimeScope(ctx context.Context) (InsightTimeScope, error)
 	GeneratedFromCaptureGroups() (bool, error)
+	GeneratedFromPreciseCodeIntel() (bool, error)
 	IsCalculated() (bool, error)
 	GroupBy() (*string, error)
 }
@@ -248,6 +279,7 @@ type InsightPresentation interface {
 
 type InsightTimeScope interface {
 	ToInsightIntervalTimeScope() (InsightIntervalTimeScope, bool)
+	ToInsightRevisionTimeScope() (InsightRevisionTimeScope, bool)
 }
 
 type InsightIntervalTimeScope interface {
@@ -255,6 +287,10 @@ type InsightIntervalTimeScope interface {
 	Value(ctx context.Context) (int32, error)
 }
 
+type InsightRevisionTimeScope interface {
+	Pattern() string
+}
+
 type InsightRepositoryScopeResolver interface {
 	Repositories(ctx context.Context) ([]string, error)
 }
@@ -273,6 +309,97 @@ type InsightsDashboardPayloadResolver interface {
 	Dashboard(ctx context.Context) (InsightsDashboardResolver, error)
 }
 
+type ExportInsightsDashboardsArgs struct {
+	Dashboards []graphql.ID
+	Format     string
+}
+
+type ImportInsightsDashboardsArgs struct {
+	Input ImportInsightsDashboardsInput
+}
+
+type ImportInsightsDashboardsInput struct {
+	Document string
+	Grants   *InsightsPermissionGrants
+	DryRun   bool
+}
+
+type ImportInsightsDashboardsPayloadResolver interface {
+	DryRun() bool
+	Dashboards(ctx context.Context) ([]InsightsDashboardResolver, error)
+	CreatedDashboards() int32
+	CreatedInsights() int32
+	UpdatedInsights() int32
+	UnchangedInsights() int32
+}
+
+type InsightHistoricalQueryArgs struct {
+	ID graphql.ID
+}
+
+type RunInsightHistoricalQueryArgs struct {
+	Input InsightHistoricalQueryInput
+}
+
+type InsightHistoricalQueryInput struct {
+	Query        string
+	Repositories []string
+	TimeScope    TimeScopeInput
+	NumSamples   int32
+}
+
+type InsightHistoricalQueryResolver interface {
+	ID() graphql.ID
+	Query() string
+	State() string
+	RepositoriesTotal() int32
+	RepositoriesCompleted() int32
+	Points(ctx context.Context) ([]InsightsDataPointResolver, error)
+	FailureMessage() *string
+	ExpiresAt() gqlutil.DateTime
+}
+
+type InsightSeriesAlertRulesArgs struct {
+	InsightViewID graphql.ID
+	SeriesID      *string
+}
+
+type CreateInsightSeriesAlertRuleArgs struct {
+	Input CreateInsightSeriesAlertRuleInput
+}
+
+type CreateInsightSeriesAlertRuleInput struct {
+	InsightViewID   graphql.ID
+	SeriesID        string
+	Kind            string
+	Direction       string
+	Threshold       float64
+	WindowSize      int32
+	NotifyByEmail   bool
+	SlackWebhookURL *string
+	WebhookURL      *string
+}
+
+type DeleteInsightSeriesAlertRuleArgs struct {
+	ID graphql.ID
+}
+
+type InsightSeriesAlertRuleResolver interface {
+	ID() graphql.ID
+	SeriesID() string
+	Kind() string
+	Direction() string
+	Threshold() float64
+	WindowSize() int32
+	NotifyByEmail() bool
+	SlackWebhookURL() *string
+	WebhookURL() *string
+	Firing() bool
+	LastEvaluatedAt() *gqlutil.DateTime
+	LastTriggeredAt() *gqlutil.DateTime
+	CreatedAt() gqlutil.DateTime
+}
+
 type AddInsightViewToDashboardArgs struct {
 	Input AddInsightViewToDashboardInput
 }
@@ -422,13 +549,14 @@ type InsightViewFiltersInput struct {
 }
 
 type LineChartSearchInsightDataSeriesInput struct {
-	SeriesId                   *string
-	Query                      string
-	TimeScope                  *TimeScopeInput
-	RepositoryScope            *RepositoryScopeInput
-	Options                    LineChartDataSeriesOptionsInput
-	GeneratedFromCaptureGroups *bool
-	GroupBy                    *string
+	SeriesId                      *string
+	Query                         string
+	TimeScope                     *TimeScopeInput
+	RepositoryScope               *RepositoryScopeInput
+	Options                       LineChartDataSeriesOptionsInput
+	GeneratedFromCaptureGroups    *bool
+	GroupBy                       *string
+	GeneratedFromPreciseCodeIntel *bool
 }
 
 type LineChartDataSeriesOptionsInput struct {
@@ -443,6 +571,11 @@ type RepositoryScopeInput struct {
 
 type TimeScopeInput struct {
 	StepInterval *TimeIntervalStepInput
+	Revisions    *RevisionsTimeScopeInput
+}
+
+type RevisionsTimeScopeInput struct {
+	Pattern string
 }
 
 type TimeIntervalStepInput struct {
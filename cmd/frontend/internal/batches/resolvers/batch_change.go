@@ -183,6 +183,18 @@ func (r *batchChangeResolver) ClosedAt() *gqlutil.DateTime {
 	return &gqlutil.DateTime{Time: r.batchChange.ClosedAt}
 }
 
+func (r *batchChangeResolver) AutoRebase() bool {
+	return r.batchChange.AutoRebase
+}
+
+func (r *batchChangeResolver) AutoRebaseMaxCommitsBehind() int32 {
+	return r.batchChange.AutoRebaseMaxBehind
+}
+
+func (r *batchChangeResolver) AutoMergePolicy() graphqlbackend.BatchChangeAutoMergePolicyResolver {
+	return &batchChangeAutoMergePolicyResolver{batchChange: r.batchChange}
+}
+
 func (r *batchChangeResolver) ChangesetsStats(ctx context.Context) (graphqlbackend.ChangesetsStatsResolver, error) {
 	stats, err := r.store.GetChangesetsStats(ctx, r.batchChange.ID)
 	if err != nil {
@@ -359,3 +371,19 @@ func (r *batchChangeResolver) BatchSpecs(
 
 	return &batchSpecConnectionResolver{store: r.store, logger: r.logger, opts: opts}, nil
 }
+
+type batchChangeAutoMergePolicyResolver struct {
+	batchChange *btypes.BatchChange
+}
+
+func (r *batchChangeAutoMergePolicyResolver) Enabled() bool {
+	return r.batchChange.AutoMerge
+}
+
+func (r *batchChangeAutoMergePolicyResolver) MaxMergesPerHour() int32 {
+	return r.batchChange.AutoMergeMaxPerHour
+}
+
+func (r *batchChangeAutoMergePolicyResolver) Squash() bool {
+	return r.batchChange.AutoMergeSquash
+}
@@ -655,3 +655,20 @@ func (r *batchSpecResolver) Files(ctx context.Context, args *graphqlbackend.List
 
 	return &batchSpecWorkspaceFileConnectionResolver{store: r.store, opts: opts}, nil
 }
+
+func (r *batchSpecResolver) ExecutionPreview(ctx context.Context) (graphqlbackend.BatchSpecExecutionPreviewResolver, error) {
+	preview, err := r.store.GetBatchSpecExecutionPreview(ctx, r.batchSpec.ID)
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	summary, err := service.New(r.store).GetBatchSpecExecutionPreviewSummary(ctx, preview)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchSpecExecutionPreviewResolver{preview: preview, summary: summary}, nil
+}
@@ -0,0 +1,66 @@
+package resolvers
+
+import (
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/batches/service"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+)
+
+var _ graphqlbackend.BatchSpecExecutionPreviewResolver = &batchSpecExecutionPreviewResolver{}
+
+type batchSpecExecutionPreviewResolver struct {
+	preview *btypes.BatchSpecExecutionPreview
+	summary *service.BatchSpecExecutionPreviewSummary
+}
+
+func (r *batchSpecExecutionPreviewResolver) SampleSize() int32 {
+	return r.preview.SampleSize
+}
+
+func (r *batchSpecExecutionPreviewResolver) TotalWorkspaces() int32 {
+	return r.preview.Workspaces
+}
+
+func (r *batchSpecExecutionPreviewResolver) CompletedWorkspaces() int32 {
+	return int32(r.summary.CompletedWorkspaces)
+}
+
+func (r *batchSpecExecutionPreviewResolver) FailedWorkspaces() int32 {
+	return int32(r.summary.FailedWorkspaces)
+}
+
+func (r *batchSpecExecutionPreviewResolver) DiffStat() *graphqlbackend.DiffStat {
+	return graphqlbackend.NewDiffStat(r.summary.DiffStat)
+}
+
+func (r *batchSpecExecutionPreviewResolver) FileExtensions() []graphqlbackend.BatchSpecExecutionPreviewFileExtensionResolver {
+	resolvers := make([]graphqlbackend.BatchSpecExecutionPreviewFileExtensionResolver, 0, len(r.summary.FileExtensions))
+	for _, e := range r.summary.FileExtensions {
+		resolvers = append(resolvers, &batchSpecExecutionPreviewFileExtensionResolver{stat: e})
+	}
+	return resolvers
+}
+
+func (r *batchSpecExecutionPreviewResolver) EstimatedExecutionTime() *int32 {
+	if r.summary.EstimatedExecutionTime == nil {
+		return nil
+	}
+	seconds := int32(r.summary.EstimatedExecutionTime.Seconds())
+	return &seconds
+}
+
+type batchSpecExecutionPreviewFileExtensionResolver struct {
+	stat service.FileExtensionDiffStat
+}
+
+func (r *batchSpecExecutionPreviewFileExtensionResolver) Extension() string {
+	return r.stat.Extension
+}
+
+func (r *batchSpecExecutionPreviewFileExtensionResolver) Files() int32 {
+	return int32(r.stat.Files)
+}
+
+func (r *batchSpecExecutionPreviewFileExtensionResolver) DiffStat() *graphqlbackend.DiffStat {
+	return graphqlbackend.NewDiffStat(r.stat.DiffStat)
+}
@@ -0,0 +1,115 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+const batchSpecTemplateIDKind = "BatchSpecTemplate"
+
+func marshalBatchSpecTemplateID(id int64) graphql.ID {
+	return relay.MarshalID(batchSpecTemplateIDKind, id)
+}
+
+func unmarshalBatchSpecTemplateID(id graphql.ID) (batchSpecTemplateID int64, err error) {
+	err = relay.UnmarshalSpec(id, &batchSpecTemplateID)
+	return
+}
+
+type batchSpecTemplateResolver struct {
+	store    *store.Store
+	template *btypes.BatchSpecTemplate
+}
+
+var _ graphqlbackend.BatchSpecTemplateResolver = &batchSpecTemplateResolver{}
+
+func (r *batchSpecTemplateResolver) ID() graphql.ID {
+	return marshalBatchSpecTemplateID(r.template.ID)
+}
+
+func (r *batchSpecTemplateResolver) Name() string {
+	return r.template.Template.Name
+}
+
+func (r *batchSpecTemplateResolver) Description() string {
+	return r.template.Template.Description
+}
+
+func (r *batchSpecTemplateResolver) Parameters() []graphqlbackend.BatchSpecTemplateParameterResolver {
+	resolvers := make([]graphqlbackend.BatchSpecTemplateParameterResolver, 0, len(r.template.Template.Parameters))
+	for _, p := range r.template.Template.Parameters {
+		resolvers = append(resolvers, &batchSpecTemplateParameterResolver{parameter: p})
+	}
+	return resolvers
+}
+
+func (r *batchSpecTemplateResolver) Spec() string {
+	return r.template.Template.Spec
+}
+
+func (r *batchSpecTemplateResolver) RawTemplate() string {
+	return r.template.RawTemplate
+}
+
+func (r *batchSpecTemplateResolver) Creator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	if r.template.CreatorID == 0 {
+		return nil, nil
+	}
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.store.DatabaseDB(), r.template.CreatorID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *batchSpecTemplateResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.template.CreatedAt}
+}
+
+func (r *batchSpecTemplateResolver) UpdatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.template.UpdatedAt}
+}
+
+type batchSpecTemplateParameterResolver struct {
+	parameter batcheslib.BatchSpecTemplateParameter
+}
+
+var _ graphqlbackend.BatchSpecTemplateParameterResolver = &batchSpecTemplateParameterResolver{}
+
+func (r *batchSpecTemplateParameterResolver) Name() string {
+	return r.parameter.Name
+}
+
+func (r *batchSpecTemplateParameterResolver) Type() string {
+	switch r.parameter.Type {
+	case batcheslib.BatchSpecTemplateParameterTypeRepositoryQuery:
+		return "REPOSITORY_QUERY"
+	default:
+		return strings.ToUpper(string(r.parameter.Type))
+	}
+}
+
+func (r *batchSpecTemplateParameterResolver) Description() string {
+	return r.parameter.Description
+}
+
+func (r *batchSpecTemplateParameterResolver) Required() bool {
+	return r.parameter.Required
+}
+
+func (r *batchSpecTemplateParameterResolver) Default() *graphqlbackend.JSONValue {
+	if r.parameter.Default == nil {
+		return nil
+	}
+	return &graphqlbackend.JSONValue{Value: r.parameter.Default}
+}
@@ -126,7 +126,9 @@ func (r *batchSpecWorkspaceResolver) computeStepResolvers() ([]graphqlbackend.Ba
 		return nil, nil
 	}
 
-	if r.execution != nil && r.execution.Version == 2 {
+	// Rewrite steps are executed by the worker, which always logs them like
+	// V2 executions.
+	if r.execution != nil && (r.execution.Version == 2 || r.batchSpec.Containerless()) {
 		skippedSteps, err := batcheslib.SkippedStepsForRepo(r.batchSpec, r.repoResolver.Name(), r.workspace.FileMatches)
 		if err != nil {
 			return nil, err
@@ -153,7 +155,7 @@ func (r *batchSpecWorkspaceResolver) computeStepResolvers() ([]graphqlbackend.Ba
 			}
 
 			// Get the log from the run step.
-			logKeyRegex, err := regexp.Compile(fmt.Sprintf("^step\\.(docker|kubernetes)\\.step\\.%d\\.run$", idx))
+			logKeyRegex, err := regexp.Compile(fmt.Sprintf("^step\\.(docker|kubernetes|rewrite)\\.step\\.%d\\.run$", idx))
 			if err != nil {
 				return nil, err
 			}
@@ -179,7 +181,7 @@ func (r *batchSpecWorkspaceResolver) computeStepResolvers() ([]graphqlbackend.Ba
 			// to get the step info and or skipped status.
 			if !resolver.skipped && resolver.cachedResult == nil {
 				// The skip log will be in the pre step.
-				logKeyPreRegex, err := regexp.Compile(fmt.Sprintf("^step\\.(docker|kubernetes)\\.step\\.%d\\.pre$", idx))
+				logKeyPreRegex, err := regexp.Compile(fmt.Sprintf("^step\\.(docker|kubernetes|rewrite)\\.step\\.%d\\.pre$", idx))
 				if err != nil {
 					return nil, err
 				}
@@ -193,7 +195,7 @@ func (r *batchSpecWorkspaceResolver) computeStepResolvers() ([]graphqlbackend.Ba
 					}
 				}
 
-				logKeyPreRegex, err = regexp.Compile(fmt.Sprintf("^step\\.(docker|kubernetes)\\.step\\.%d\\.post$", idx))
+				logKeyPreRegex, err = regexp.Compile(fmt.Sprintf("^step\\.(docker|kubernetes|rewrite)\\.step\\.%d\\.post$", idx))
 				if err != nil {
 					return nil, err
 				}
@@ -568,20 +570,18 @@ func (r *batchSpecWorkspaceStagesResolver) SrcExec() []graphqlbackend.ExecutionL
 		return []graphqlbackend.ExecutionLogEntryResolver{graphqlbackend.NewExecutionLogEntryResolver(r.store.DatabaseDB(), entry)}
 	}
 
-	if r.execution.Version == 2 {
-		// V2 execution: There are multiple execution steps involved in running
-		// a spec now: For each step N {N-pre, N, N-post}.
-		return r.executionLogEntryResolversWithPrefix(logKeyPrefixStep)
-	}
-
-	return nil
+	// V2 execution: There are multiple execution steps involved in running
+	// a spec now: For each step N {N-pre, N, N-post}. Specs that only consist
+	// of rewrite steps log their steps the same way, regardless of the
+	// execution version.
+	return r.executionLogEntryResolversWithPrefix(logKeyPrefixStep)
 }
 
 var (
 	// V1 execution uses a single `step.src.batch-exec` step, for backcompat we return just that
 	// here.
 	logKeySrc        = regexp.MustCompile("^step\\.src\\.(batch-exec|0)$")
-	logKeyPrefixStep = regexp.MustCompile("^step\\.(docker|kubernetes)\\.step\\.")
+	logKeyPrefixStep = regexp.MustCompile("^step\\.(docker|kubernetes|rewrite)\\.step\\.")
 )
 
 func (r *batchSpecWorkspaceStagesResolver) Teardown() []graphqlbackend.ExecutionLogEntryResolver {
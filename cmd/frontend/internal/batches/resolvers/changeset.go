@@ -387,6 +387,30 @@ func (r *changesetResolver) CheckState() *string {
 	return &checkState
 }
 
+func (r *changesetResolver) CheckRuns() []graphqlbackend.ChangesetCheckRunResolver {
+	if !r.changeset.Published() {
+		return []graphqlbackend.ChangesetCheckRunResolver{}
+	}
+
+	resolvers := make([]graphqlbackend.ChangesetCheckRunResolver, 0, len(r.changeset.ExternalCheckRuns))
+	for _, run := range r.changeset.ExternalCheckRuns {
+		resolvers = append(resolvers, &changesetCheckRunResolver{run: run})
+	}
+	return resolvers
+}
+
+func (r *changesetResolver) ReviewerDecisions() []graphqlbackend.ChangesetReviewerDecisionResolver {
+	if !r.changeset.Published() {
+		return []graphqlbackend.ChangesetReviewerDecisionResolver{}
+	}
+
+	resolvers := make([]graphqlbackend.ChangesetReviewerDecisionResolver, 0, len(r.changeset.ExternalReviewerDecisions))
+	for _, d := range r.changeset.ExternalReviewerDecisions {
+		resolvers = append(resolvers, &changesetReviewerDecisionResolver{decision: d})
+	}
+	return resolvers
+}
+
 // Error: `FailureMessage` is set by the reconciler worker if it fails when processing
 // a changeset job. However, for most reconciler operations, we automatically retry the
 // operation a number of times. When the reconciler worker picks up a failed changeset job
@@ -407,6 +431,32 @@ func (r *changesetResolver) Error() *string {
 
 func (r *changesetResolver) SyncerError() *string { return r.changeset.SyncErrorMessage }
 
+func (r *changesetResolver) RebaseConflicts(ctx context.Context) ([]string, error) {
+	rebase, err := r.store.GetChangesetRebase(ctx, r.changeset.ID)
+	if err != nil {
+		if err == store.ErrNoResults {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+	// Conflicts of an earlier spec are resolved by applying a new one.
+	if rebase.ChangesetSpecID != r.changeset.CurrentSpecID || !rebase.HasConflicts() {
+		return []string{}, nil
+	}
+	return rebase.ConflictingFiles, nil
+}
+
+func (r *changesetResolver) AutoMerge(ctx context.Context) (graphqlbackend.ChangesetAutoMergeResolver, error) {
+	m, err := r.store.GetChangesetAutoMerge(ctx, r.changeset.ID)
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &changesetAutoMergeResolver{autoMerge: m}, nil
+}
+
 func (r *changesetResolver) ScheduleEstimateAt(ctx context.Context) (*gqlutil.DateTime, error) {
 	// We need to find out how deep in the queue this changeset is.
 	place, err := r.store.GetChangesetPlaceInSchedulerQueue(ctx, r.changeset.ID)
@@ -573,6 +623,67 @@ func (r *changesetLabelResolver) Description() *string {
 	return &r.label.Description
 }
 
+type changesetAutoMergeResolver struct {
+	autoMerge *btypes.ChangesetAutoMerge
+}
+
+func (r *changesetAutoMergeResolver) State() string {
+	return string(r.autoMerge.State)
+}
+
+func (r *changesetAutoMergeResolver) Message() *string {
+	if r.autoMerge.Message == "" {
+		return nil
+	}
+	return &r.autoMerge.Message
+}
+
+func (r *changesetAutoMergeResolver) MergedAt() *gqlutil.DateTime {
+	if r.autoMerge.MergedAt.IsZero() {
+		return nil
+	}
+	return &gqlutil.DateTime{Time: r.autoMerge.MergedAt}
+}
+
+func (r *changesetAutoMergeResolver) UpdatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.autoMerge.UpdatedAt}
+}
+
+type changesetCheckRunResolver struct {
+	run btypes.ChangesetCheckRun
+}
+
+func (r *changesetCheckRunResolver) Name() string {
+	return r.run.Name
+}
+
+func (r *changesetCheckRunResolver) State() *string {
+	if r.run.State == btypes.ChangesetCheckStateUnknown {
+		return nil
+	}
+	state := string(r.run.State)
+	return &state
+}
+
+func (r *changesetCheckRunResolver) URL() *string {
+	if r.run.URL == "" {
+		return nil
+	}
+	return &r.run.URL
+}
+
+type changesetReviewerDecisionResolver struct {
+	decision btypes.ChangesetReviewerDecision
+}
+
+func (r *changesetReviewerDecisionResolver) Reviewer() string {
+	return r.decision.Reviewer
+}
+
+func (r *changesetReviewerDecisionResolver) State() string {
+	return string(r.decision.State)
+}
+
 var _ graphqlbackend.CommitVerificationResolver = &commitVerificationResolver{}
 
 type commitVerificationResolver struct {
@@ -7,10 +7,12 @@ import (
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
 	"github.com/sourcegraph/go-diff/diff"
+	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
@@ -112,6 +114,29 @@ func (r *changesetSpecResolver) Workspace(ctx context.Context) (graphqlbackend.B
 	return nil, errors.New("not implemented")
 }
 
+func (r *changesetSpecResolver) RevertedChangeset(ctx context.Context) (graphqlbackend.ChangesetResolver, error) {
+	if r.changesetSpec.RevertedChangesetID == 0 {
+		return nil, nil
+	}
+
+	changeset, err := r.store.GetChangeset(ctx, store.GetChangesetOpts{ID: r.changesetSpec.RevertedChangesetID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// 🚨 SECURITY: database.Repos.Get uses the authzFilter under the hood and
+	// filters out repositories that the user doesn't have access to.
+	repo, err := r.store.Repos().Get(ctx, changeset.RepoID)
+	if err != nil && !errcode.IsNotFound(err) {
+		return nil, err
+	}
+
+	return NewChangesetResolver(r.store, gitserver.NewClient("graphql.batches.revertedchangeset"), log.Scoped("RevertedChangeset"), changeset, repo), nil
+}
+
 func (r *changesetSpecResolver) ToHiddenChangesetSpec() (graphqlbackend.HiddenChangesetSpecResolver, bool) {
 	if r.repoAccessible() {
 		return nil, false
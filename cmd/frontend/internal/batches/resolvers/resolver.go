@@ -130,6 +130,9 @@ func (r *Resolver) NodeResolvers() map[string]graphqlbackend.NodeByIDFunc {
 		workspaceFileIDKind: func(ctx context.Context, id graphql.ID) (graphqlbackend.Node, error) {
 			return r.batchSpecWorkspaceFileByID(ctx, id)
 		},
+		batchSpecTemplateIDKind: func(ctx context.Context, id graphql.ID) (graphqlbackend.Node, error) {
+			return r.batchSpecTemplateByID(ctx, id)
+		},
 	}
 }
 
@@ -386,6 +389,30 @@ func (r *Resolver) bulkOperationByIDString(ctx context.Context, id string) (grap
 	return &bulkOperationResolver{store: r.store, gitserverClient: r.gitserverClient, bulkOperation: bulkOperation, logger: r.logger}, nil
 }
 
+func (r *Resolver) batchSpecTemplateByID(ctx context.Context, id graphql.ID) (graphqlbackend.BatchSpecTemplateResolver, error) {
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	dbID, err := unmarshalBatchSpecTemplateID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if dbID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	tmpl, err := r.store.GetBatchSpecTemplate(ctx, store.GetBatchSpecTemplateOpts{ID: dbID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &batchSpecTemplateResolver{store: r.store, template: tmpl}, nil
+}
+
 func (r *Resolver) batchSpecWorkspaceByID(ctx context.Context, gqlID graphql.ID) (graphqlbackend.BatchSpecWorkspaceResolver, error) {
 	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
@@ -676,6 +703,42 @@ func (r *Resolver) CreateChangesetSpecs(ctx context.Context, args *graphqlbacken
 	return resolvers, nil
 }
 
+func (r *Resolver) RollbackBatchChange(ctx context.Context, args *graphqlbackend.RollbackBatchChangeArgs) (_ graphqlbackend.BatchChangeResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.RollbackBatchChange",
+		attribute.String("batchChange", string(args.BatchChange)),
+		attribute.Bool("publish", args.Publish))
+	defer tr.EndWithErr(&err)
+
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, err := unmarshalBatchChangeID(args.BatchChange)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling batch change id")
+	}
+
+	if batchChangeID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	svc := service.New(r.store)
+	// 🚨 SECURITY: RollbackBatchChange checks whether current user is authorized.
+	batchChange, err := svc.RollbackBatchChange(ctx, r.gitserverClient, service.RollbackBatchChangeOpts{
+		BatchChangeID: batchChangeID,
+		Publish:       args.Publish,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "rolling back batch change")
+	}
+
+	return &batchChangeResolver{store: r.store, gitserverClient: r.gitserverClient, batchChange: batchChange, logger: r.logger}, nil
+}
+
 func (r *Resolver) MoveBatchChange(ctx context.Context, args *graphqlbackend.MoveBatchChangeArgs) (_ graphqlbackend.BatchChangeResolver, err error) {
 	tr, ctx := trace.New(ctx, "Resolver.MoveBatchChange", attribute.String("batchChange", string(args.BatchChange)))
 	defer tr.EndWithErr(&err)
@@ -967,6 +1030,12 @@ func listChangesetOptsFromArgs(args *graphqlbackend.ListChangesetsArgs, batchCha
 		// changesets, since that would leak information.
 		safe = false
 	}
+	if args.FailingCheck != nil && *args.FailingCheck != "" {
+		opts.FailingCheck = *args.FailingCheck
+		// If the user filters by a failing check we cannot include hidden
+		// changesets, since that would leak information.
+		safe = false
+	}
 	if args.OnlyPublishedByThisBatchChange != nil {
 		published := btypes.ChangesetPublicationStatePublished
 
@@ -1034,6 +1103,77 @@ func (r *Resolver) CloseBatchChange(ctx context.Context, args *graphqlbackend.Cl
 	return &batchChangeResolver{store: r.store, gitserverClient: r.gitserverClient, batchChange: batchChange, logger: r.logger}, nil
 }
 
+func (r *Resolver) SetBatchChangeAutoRebase(ctx context.Context, args *graphqlbackend.SetBatchChangeAutoRebaseArgs) (_ graphqlbackend.BatchChangeResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.SetBatchChangeAutoRebase",
+		attribute.String("batchChange", string(args.BatchChange)),
+		attribute.Bool("enabled", args.Enabled))
+	defer tr.EndWithErr(&err)
+
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, err := unmarshalBatchChangeID(args.BatchChange)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling batch change id")
+	}
+
+	if batchChangeID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	svc := service.New(r.store)
+	// 🚨 SECURITY: SetBatchChangeAutoRebase checks whether current user is authorized.
+	batchChange, err := svc.SetBatchChangeAutoRebase(ctx, batchChangeID, args.Enabled, args.MaxCommitsBehind)
+	if err != nil {
+		return nil, errors.Wrap(err, "updating batch change")
+	}
+
+	return &batchChangeResolver{store: r.store, gitserverClient: r.gitserverClient, batchChange: batchChange, logger: r.logger}, nil
+}
+
+func (r *Resolver) SetBatchChangeAutoMergePolicy(ctx context.Context, args *graphqlbackend.SetBatchChangeAutoMergePolicyArgs) (_ graphqlbackend.BatchChangeResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.SetBatchChangeAutoMergePolicy",
+		attribute.String("batchChange", string(args.BatchChange)),
+		attribute.Bool("enabled", args.Enabled))
+	defer tr.EndWithErr(&err)
+
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, err := unmarshalBatchChangeID(args.BatchChange)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling batch change id")
+	}
+
+	if batchChangeID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	svc := service.New(r.store)
+	// 🚨 SECURITY: SetBatchChangeAutoMergePolicy checks whether current user is authorized.
+	batchChange, err := svc.SetBatchChangeAutoMergePolicy(ctx, service.SetBatchChangeAutoMergePolicyOpts{
+		BatchChangeID:    batchChangeID,
+		Enabled:          args.Enabled,
+		MaxMergesPerHour: args.MaxMergesPerHour,
+		Squash:           args.Squash,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "updating batch change")
+	}
+
+	return &batchChangeResolver{store: r.store, gitserverClient: r.gitserverClient, batchChange: batchChange, logger: r.logger}, nil
+}
+
 func (r *Resolver) SyncChangeset(ctx context.Context, args *graphqlbackend.SyncChangesetArgs) (_ *graphqlbackend.EmptyResponse, err error) {
 	tr, ctx := trace.New(ctx, "Resolver.SyncChangeset", attribute.String("changeset", string(args.Changeset)))
 	defer tr.EndWithErr(&err)
@@ -1605,6 +1745,37 @@ func (r *Resolver) BatchSpecs(ctx context.Context, args *graphqlbackend.ListBatc
 	return &batchSpecConnectionResolver{store: r.store, logger: r.logger, opts: opts}, nil
 }
 
+func (r *Resolver) BatchSpecTemplates(ctx context.Context, args *graphqlbackend.ListBatchSpecTemplatesArgs) (_ graphqlbackend.BatchSpecTemplateConnectionResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.BatchSpecTemplates",
+		attribute.Int("first", int(args.First)),
+		attribute.String("after", fmt.Sprintf("%v", args.After)))
+	defer tr.EndWithErr(&err)
+
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	if err := validateFirstParamDefaults(args.First); err != nil {
+		return nil, err
+	}
+
+	opts := store.ListBatchSpecTemplatesOpts{
+		LimitOpts: store.LimitOpts{
+			Limit: int(args.First),
+		},
+	}
+
+	if args.After != nil {
+		id, err := strconv.Atoi(*args.After)
+		if err != nil {
+			return nil, err
+		}
+		opts.Cursor = int64(id)
+	}
+
+	return &batchSpecTemplateConnectionResolver{store: r.store, opts: opts}, nil
+}
+
 func (r *Resolver) CreateEmptyBatchChange(ctx context.Context, args *graphqlbackend.CreateEmptyBatchChangeArgs) (_ graphqlbackend.BatchChangeResolver, err error) {
 	tr, ctx := trace.New(ctx, "Resolver.CreateEmptyBatchChange",
 		attribute.String("namespace", string(args.Namespace)))
@@ -1714,6 +1885,135 @@ func (r *Resolver) CreateBatchSpecFromRaw(ctx context.Context, args *graphqlback
 	return &batchSpecResolver{store: r.store, logger: r.logger, batchSpec: batchSpec}, nil
 }
 
+func (r *Resolver) CreateBatchSpecFromTemplate(ctx context.Context, args *graphqlbackend.CreateBatchSpecFromTemplateArgs) (_ graphqlbackend.BatchSpecResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CreateBatchSpecFromTemplate",
+		attribute.String("batchSpecTemplate", string(args.BatchSpecTemplate)),
+		attribute.String("namespace", string(args.Namespace)))
+	defer tr.EndWithErr(&err)
+
+	if err := batchChangesCreateAccess(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+		return nil, err
+	}
+
+	templateID, err := unmarshalBatchSpecTemplateID(args.BatchSpecTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	if templateID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	var values map[string]any
+	if args.Values != nil && args.Values.Value != nil {
+		var ok bool
+		if values, ok = args.Values.Value.(map[string]any); !ok {
+			return nil, errors.New("values must be an object keyed by the names of the parameters")
+		}
+	}
+
+	var uid, oid int32
+	if err := graphqlbackend.UnmarshalNamespaceID(args.Namespace, &uid, &oid); err != nil {
+		return nil, err
+	}
+
+	bid, err := unmarshalBatchChangeID(args.BatchChange)
+	if err != nil {
+		return nil, err
+	}
+
+	svc := service.New(r.store)
+	batchSpec, err := svc.CreateBatchSpecFromTemplate(ctx, service.CreateBatchSpecFromTemplateOpts{
+		TemplateID:       templateID,
+		Values:           values,
+		NamespaceUserID:  uid,
+		NamespaceOrgID:   oid,
+		AllowIgnored:     args.AllowIgnored,
+		AllowUnsupported: args.AllowUnsupported,
+		NoCache:          args.NoCache,
+		BatchChange:      bid,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchSpecResolver{store: r.store, logger: r.logger, batchSpec: batchSpec}, nil
+}
+
+func (r *Resolver) CreateBatchSpecTemplate(ctx context.Context, args *graphqlbackend.CreateBatchSpecTemplateArgs) (_ graphqlbackend.BatchSpecTemplateResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CreateBatchSpecTemplate")
+	defer tr.EndWithErr(&err)
+
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: CreateBatchSpecTemplate checks whether the current user is a site admin.
+	tmpl, err := service.New(r.store).CreateBatchSpecTemplate(ctx, args.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchSpecTemplateResolver{store: r.store, template: tmpl}, nil
+}
+
+func (r *Resolver) UpdateBatchSpecTemplate(ctx context.Context, args *graphqlbackend.UpdateBatchSpecTemplateArgs) (_ graphqlbackend.BatchSpecTemplateResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.UpdateBatchSpecTemplate",
+		attribute.String("batchSpecTemplate", string(args.BatchSpecTemplate)))
+	defer tr.EndWithErr(&err)
+
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	templateID, err := unmarshalBatchSpecTemplateID(args.BatchSpecTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	if templateID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	// 🚨 SECURITY: UpdateBatchSpecTemplate checks whether the current user is a site admin.
+	tmpl, err := service.New(r.store).UpdateBatchSpecTemplate(ctx, templateID, args.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchSpecTemplateResolver{store: r.store, template: tmpl}, nil
+}
+
+func (r *Resolver) DeleteBatchSpecTemplate(ctx context.Context, args *graphqlbackend.DeleteBatchSpecTemplateArgs) (_ *graphqlbackend.EmptyResponse, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.DeleteBatchSpecTemplate",
+		attribute.String("batchSpecTemplate", string(args.BatchSpecTemplate)))
+	defer tr.EndWithErr(&err)
+
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	templateID, err := unmarshalBatchSpecTemplateID(args.BatchSpecTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	if templateID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	// 🚨 SECURITY: DeleteBatchSpecTemplate checks whether the current user is a site admin.
+	if err := service.New(r.store).DeleteBatchSpecTemplate(ctx, templateID); err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
 func (r *Resolver) ExecuteBatchSpec(ctx context.Context, args *graphqlbackend.ExecuteBatchSpecArgs) (_ graphqlbackend.BatchSpecResolver, err error) {
 	tr, ctx := trace.New(ctx, "Resolver.ExecuteBatchSpec",
 		attribute.String("batchSpec", string(args.BatchSpec)))
@@ -1753,6 +2053,43 @@ func (r *Resolver) ExecuteBatchSpec(ctx context.Context, args *graphqlbackend.Ex
 	return &batchSpecResolver{store: r.store, logger: r.logger, batchSpec: batchSpec}, nil
 }
 
+func (r *Resolver) PreviewBatchSpecExecution(ctx context.Context, args *graphqlbackend.PreviewBatchSpecExecutionArgs) (_ graphqlbackend.BatchSpecResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.PreviewBatchSpecExecution",
+		attribute.String("batchSpec", string(args.BatchSpec)),
+		attribute.Int("sampleSize", int(args.SampleSize)))
+	defer tr.EndWithErr(&err)
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+		return nil, err
+	}
+
+	batchSpecRandID, err := unmarshalBatchSpecID(args.BatchSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	if batchSpecRandID == "" {
+		return nil, ErrIDIsZero{}
+	}
+
+	// 🚨 SECURITY: PreviewBatchSpecExecution checks whether current user is
+	// authorized and has access to namespace.
+	svc := service.New(r.store)
+	batchSpec, err := svc.PreviewBatchSpecExecution(ctx, service.PreviewBatchSpecExecutionOpts{
+		BatchSpecRandID: batchSpecRandID,
+		SampleSize:      int(args.SampleSize),
+		NoCache:         args.NoCache,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchSpecResolver{store: r.store, logger: r.logger, batchSpec: batchSpec}, nil
+}
+
 func (r *Resolver) CancelBatchSpecExecution(ctx context.Context, args *graphqlbackend.CancelBatchSpecExecutionArgs) (_ graphqlbackend.BatchSpecResolver, err error) {
 	tr, ctx := trace.New(ctx, "Resolver.CancelBatchSpecExecution",
 		attribute.String("batchSpec", string(args.BatchSpec)))
@@ -586,6 +586,7 @@ func (h *GitHubWebhook) commitStatusEvent(e *gh.StatusEvent) *github.CommitStatu
 		SHA:        e.GetSHA(),
 		State:      e.GetState(),
 		Context:    e.GetContext(),
+		TargetURL:  e.GetTargetURL(),
 		ReceivedAt: h.Store.Clock()(),
 	}
 }
@@ -602,8 +603,10 @@ func (h *GitHubWebhook) checkSuiteEvent(cs *gh.CheckSuite) *github.CheckSuite {
 func (h *GitHubWebhook) checkRunEvent(cr *gh.CheckRun) *github.CheckRun {
 	return &github.CheckRun{
 		ID:         cr.GetNodeID(),
+		Name:       cr.GetName(),
 		Status:     cr.GetStatus(),
 		Conclusion: cr.GetConclusion(),
+		DetailsURL: cr.GetDetailsURL(),
 		ReceivedAt: h.Store.Clock()(),
 	}
 }
@@ -172,11 +172,19 @@ func (h webhook) upsertChangesetEvent(
 	events, _, err := tx.ListChangesetEvents(ctx, store.ListChangesetEventsOpts{
 		ChangesetIDs: []int64{cs.ID},
 	})
+	wasMerged := cs.ExternalState == btypes.ChangesetExternalStateMerged
 	state.SetDerivedState(ctx, tx.Repos(), h.gitserverClient, cs, events)
 	if err := tx.UpdateChangesetCodeHostState(ctx, cs); err != nil {
 		return err
 	}
 
+	// Changesets stacked on top of this one are waiting for it to be merged.
+	if !wasMerged && cs.ExternalState == btypes.ChangesetExternalStateMerged {
+		if err := tx.EnqueueStackedChangesets(ctx, cs); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -122,6 +122,7 @@ func Init(
 		scopedContext("ranking"),
 		codeIntelServices.RankingService,
 		siteAdminChecker,
+		repoStore,
 	)
 
 	enterpriseServices.CodeIntelResolver = graphqlbackend.NewCodeIntelResolver(resolvers.NewCodeIntelResolver(
@@ -56,9 +56,11 @@ type ActionConnection struct {
 }
 
 type Action struct {
-	Email        *ActionEmail
-	Webhook      *ActionWebhook
-	SlackWebhook *ActionSlackWebhook
+	Email           *ActionEmail
+	Webhook         *ActionWebhook
+	SlackWebhook    *ActionSlackWebhook
+	TeamsWebhook    *ActionTeamsWebhook
+	IncidentWebhook *ActionIncidentWebhook
 }
 
 func (a *Action) UnmarshalJSON(b []byte) error {
@@ -80,6 +82,12 @@ func (a *Action) UnmarshalJSON(b []byte) error {
 	case "MonitorSlackWebhook":
 		a.SlackWebhook = &ActionSlackWebhook{}
 		return json.Unmarshal(b, &a.SlackWebhook)
+	case "MonitorTeamsWebhook":
+		a.TeamsWebhook = &ActionTeamsWebhook{}
+		return json.Unmarshal(b, &a.TeamsWebhook)
+	case "MonitorIncidentWebhook":
+		a.IncidentWebhook = &ActionIncidentWebhook{}
+		return json.Unmarshal(b, &a.IncidentWebhook)
 	default:
 		return errors.Errorf("unexpected typename %q", t.TypeName)
 	}
@@ -108,6 +116,21 @@ type ActionSlackWebhook struct {
 	Events  ActionEventConnection
 }
 
+type ActionTeamsWebhook struct {
+	Id      string
+	Enabled bool
+	URL     string
+	Events  ActionEventConnection
+}
+
+type ActionIncidentWebhook struct {
+	Id              string
+	Enabled         bool
+	URL             string
+	PayloadTemplate string
+	Events          ActionEventConnection
+}
+
 type RecipientsConnection struct {
 	Nodes      []UserOrg
 	TotalCount int
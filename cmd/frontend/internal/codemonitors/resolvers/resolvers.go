@@ -130,7 +130,7 @@ func (r *Resolver) CreateCodeMonitor(ctx context.Context, args *graphqlbackend.C
 	// Snapshot the state of the searched repos when the monitor is created so that
 	// we can distinguish new repos. We run the snapshot outside the transaction because
 	// search requires that the DB handle is not a transaction.
-	resolvedRevisions, err := codemonitors.Snapshot(ctx, r.logger, r.db, args.Trigger.Query)
+	state, err := codemonitors.Snapshot(ctx, r.logger, r.db, args.Trigger.Query)
 	if err != nil {
 		return nil, err
 	}
@@ -155,12 +155,10 @@ func (r *Resolver) CreateCodeMonitor(ctx context.Context, args *graphqlbackend.C
 			return err
 		}
 
-		// Save the snapshotted commit IDs
-		for repoID, commitIDs := range resolvedRevisions {
-			err = tx.db.CodeMonitors().UpsertLastSearched(ctx, m.ID, repoID, commitIDs)
-			if err != nil {
-				return err
-			}
+		// Save the snapshotted commit IDs or matches
+		err = state.Save(ctx, tx.db.CodeMonitors(), m.ID)
+		if err != nil {
+			return err
 		}
 
 		// Create actions.
@@ -273,11 +271,16 @@ func (r *Resolver) createActions(ctx context.Context, monitorID int64, args []*g
 	for _, a := range args {
 		switch {
 		case a.Email != nil:
+			digest, err := toActionDigest(a.Email.Digest)
+			if err != nil {
+				return err
+			}
 			e, err := r.db.CodeMonitors().CreateEmailAction(ctx, monitorID, &database.EmailActionArgs{
 				Enabled:        a.Email.Enabled,
 				IncludeResults: a.Email.IncludeResults,
 				Priority:       a.Email.Priority,
 				Header:         a.Email.Header,
+				Digest:         digest,
 			})
 			if err != nil {
 				return err
@@ -287,7 +290,11 @@ func (r *Resolver) createActions(ctx context.Context, monitorID int64, args []*g
 				return err
 			}
 		case a.Webhook != nil:
-			_, err := r.db.CodeMonitors().CreateWebhookAction(ctx, monitorID, a.Webhook.Enabled, a.Webhook.IncludeResults, a.Webhook.URL)
+			digest, err := toActionDigest(a.Webhook.Digest)
+			if err != nil {
+				return err
+			}
+			_, err = r.db.CodeMonitors().CreateWebhookAction(ctx, monitorID, a.Webhook.Enabled, a.Webhook.IncludeResults, a.Webhook.URL, digest)
 			if err != nil {
 				return err
 			}
@@ -295,19 +302,39 @@ func (r *Resolver) createActions(ctx context.Context, monitorID int64, args []*g
 			if err := validateSlackURL(a.SlackWebhook.URL); err != nil {
 				return err
 			}
-			_, err := r.db.CodeMonitors().CreateSlackWebhookAction(ctx, monitorID, a.SlackWebhook.Enabled, a.SlackWebhook.IncludeResults, a.SlackWebhook.URL)
+			digest, err := toActionDigest(a.SlackWebhook.Digest)
+			if err != nil {
+				return err
+			}
+			_, err = r.db.CodeMonitors().CreateSlackWebhookAction(ctx, monitorID, a.SlackWebhook.Enabled, a.SlackWebhook.IncludeResults, a.SlackWebhook.URL, digest)
+			if err != nil {
+				return err
+			}
+		case a.TeamsWebhook != nil:
+			if err := validateTeamsURL(a.TeamsWebhook.URL); err != nil {
+				return err
+			}
+			_, err := r.db.CodeMonitors().CreateTeamsWebhookAction(ctx, monitorID, a.TeamsWebhook.Enabled, a.TeamsWebhook.IncludeResults, a.TeamsWebhook.URL)
+			if err != nil {
+				return err
+			}
+		case a.IncidentWebhook != nil:
+			if err := background.ValidateIncidentPayloadTemplate(a.IncidentWebhook.PayloadTemplate); err != nil {
+				return err
+			}
+			_, err := r.db.CodeMonitors().CreateIncidentWebhookAction(ctx, monitorID, a.IncidentWebhook.Enabled, a.IncidentWebhook.URL, a.IncidentWebhook.PayloadTemplate)
 			if err != nil {
 				return err
 			}
 		default:
-			return errors.New("exactly one of Email, Webhook, or SlackWebhook must be set")
+			return errors.New("exactly one of Email, Webhook, SlackWebhook, TeamsWebhook, or IncidentWebhook must be set")
 		}
 	}
 	return nil
 }
 
 func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []graphql.ID) error {
-	var email, webhook, slackWebhook []int64
+	var email, webhook, slackWebhook, teamsWebhook, incidentWebhook []int64
 	for _, id := range ids {
 		var intID int64
 		err := relay.UnmarshalSpec(id, &intID)
@@ -322,8 +349,12 @@ func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []gra
 			webhook = append(webhook, intID)
 		case monitorActionSlackWebhookKind:
 			slackWebhook = append(slackWebhook, intID)
+		case monitorActionTeamsWebhookKind:
+			teamsWebhook = append(teamsWebhook, intID)
+		case monitorActionIncidentWebhookKind:
+			incidentWebhook = append(incidentWebhook, intID)
 		default:
-			return errors.New("action IDs must be exactly one of email, webhook, or slack webhook")
+			return errors.New("action IDs must be exactly one of email, webhook, slack webhook, teams webhook, or incident webhook")
 		}
 	}
 
@@ -339,6 +370,14 @@ func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []gra
 		return err
 	}
 
+	if err := r.db.CodeMonitors().DeleteTeamsWebhookActions(ctx, monitorID, teamsWebhook...); err != nil {
+		return err
+	}
+
+	if err := r.db.CodeMonitors().DeleteIncidentWebhookActions(ctx, monitorID, incidentWebhook...); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -419,6 +458,36 @@ func (r *Resolver) TriggerTestSlackWebhookAction(ctx context.Context, args *grap
 	return &graphqlbackend.EmptyResponse{}, nil
 }
 
+func (r *Resolver) TriggerTestTeamsWebhookAction(ctx context.Context, args *graphqlbackend.TriggerTestTeamsWebhookActionArgs) (*graphqlbackend.EmptyResponse, error) {
+	err := r.isAllowedToCreate(ctx, args.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateTeamsURL(args.TeamsWebhook.URL); err != nil {
+		return nil, err
+	}
+
+	if err := background.SendTestTeamsWebhook(ctx, httpcli.ExternalDoer, args.Description, args.TeamsWebhook.URL); err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) TriggerTestIncidentWebhookAction(ctx context.Context, args *graphqlbackend.TriggerTestIncidentWebhookActionArgs) (*graphqlbackend.EmptyResponse, error) {
+	err := r.isAllowedToCreate(ctx, args.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := background.SendTestIncidentWebhook(ctx, httpcli.ExternalDoer, args.Description, args.IncidentWebhook.URL, args.IncidentWebhook.PayloadTemplate); err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
 func sendTestEmail(ctx context.Context, db database.DB, recipient graphql.ID, description string) error {
 	var (
 		userID int32
@@ -450,7 +519,15 @@ func (r *Resolver) actionIDsForMonitorIDInt64(ctx context.Context, monitorID int
 	if err != nil {
 		return nil, err
 	}
-	ids := make([]graphql.ID, 0, len(emailActions)+len(webhookActions)+len(slackWebhookActions))
+	teamsWebhookActions, err := r.db.CodeMonitors().ListTeamsWebhookActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	incidentWebhookActions, err := r.db.CodeMonitors().ListIncidentWebhookActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]graphql.ID, 0, len(emailActions)+len(webhookActions)+len(slackWebhookActions)+len(teamsWebhookActions)+len(incidentWebhookActions))
 	for _, emailAction := range emailActions {
 		ids = append(ids, (&monitorEmail{EmailAction: emailAction}).ID())
 	}
@@ -460,6 +537,12 @@ func (r *Resolver) actionIDsForMonitorIDInt64(ctx context.Context, monitorID int
 	for _, slackWebhookAction := range slackWebhookActions {
 		ids = append(ids, (&monitorSlackWebhook{SlackWebhookAction: slackWebhookAction}).ID())
 	}
+	for _, teamsWebhookAction := range teamsWebhookActions {
+		ids = append(ids, (&monitorTeamsWebhook{TeamsWebhookAction: teamsWebhookAction}).ID())
+	}
+	for _, incidentWebhookAction := range incidentWebhookActions {
+		ids = append(ids, (&monitorIncidentWebhook{IncidentWebhookAction: incidentWebhookAction}).ID())
+	}
 	return ids, nil
 }
 
@@ -504,6 +587,26 @@ func splitActionIDs(args *graphqlbackend.UpdateCodeMonitorArgs, actionIDs []grap
 			}
 			toUpdateActions = append(toUpdateActions, a)
 			delete(aMap, *a.SlackWebhook.Id)
+		case a.TeamsWebhook != nil:
+			if a.TeamsWebhook.Id == nil {
+				toCreate = append(toCreate, &graphqlbackend.CreateActionArgs{TeamsWebhook: a.TeamsWebhook.Update})
+				continue
+			}
+			if _, ok := aMap[*a.TeamsWebhook.Id]; !ok {
+				return nil, nil, errors.Errorf("unknown ID=%s for action", *a.TeamsWebhook.Id)
+			}
+			toUpdateActions = append(toUpdateActions, a)
+			delete(aMap, *a.TeamsWebhook.Id)
+		case a.IncidentWebhook != nil:
+			if a.IncidentWebhook.Id == nil {
+				toCreate = append(toCreate, &graphqlbackend.CreateActionArgs{IncidentWebhook: a.IncidentWebhook.Update})
+				continue
+			}
+			if _, ok := aMap[*a.IncidentWebhook.Id]; !ok {
+				return nil, nil, errors.Errorf("unknown ID=%s for action", *a.IncidentWebhook.Id)
+			}
+			toUpdateActions = append(toUpdateActions, a)
+			delete(aMap, *a.IncidentWebhook.Id)
 		}
 	}
 
@@ -554,15 +657,13 @@ func (r *Resolver) updateCodeMonitor(ctx context.Context, rawDB database.DB, arg
 		// Snapshot the state of the searched repos when the monitor is created so that
 		// we can distinguish new repos.
 		// NOTE: we use rawDB here because Snapshot requires that the db conn is not a transaction.
-		resolvedRevisions, err := codemonitors.Snapshot(ctx, r.logger, rawDB, args.Trigger.Update.Query)
+		state, err := codemonitors.Snapshot(ctx, r.logger, rawDB, args.Trigger.Update.Query)
 		if err != nil {
 			return nil, err
 		}
-		for repoID, commitIDs := range resolvedRevisions {
-			err = r.db.CodeMonitors().UpsertLastSearched(ctx, monitorID, repoID, commitIDs)
-			if err != nil {
-				return nil, err
-			}
+		err = state.Save(ctx, r.db.CodeMonitors(), monitorID)
+		if err != nil {
+			return nil, err
 		}
 	}
 
@@ -590,8 +691,18 @@ func (r *Resolver) updateCodeMonitor(ctx context.Context, rawDB database.DB, arg
 				return nil, err
 			}
 			err = r.updateSlackWebhookAction(ctx, *action.SlackWebhook)
+		case action.TeamsWebhook != nil:
+			if err := validateTeamsURL(action.TeamsWebhook.Update.URL); err != nil {
+				return nil, err
+			}
+			err = r.updateTeamsWebhookAction(ctx, *action.TeamsWebhook)
+		case action.IncidentWebhook != nil:
+			if err := background.ValidateIncidentPayloadTemplate(action.IncidentWebhook.Update.PayloadTemplate); err != nil {
+				return nil, err
+			}
+			err = r.updateIncidentWebhookAction(ctx, *action.IncidentWebhook)
 		default:
-			err = errors.New("action must be one of email, webhook, or slack webhook")
+			err = errors.New("action must be one of email, webhook, slack webhook, teams webhook, or incident webhook")
 		}
 		if err != nil {
 			return nil, err
@@ -608,6 +719,10 @@ func (r *Resolver) updateEmailAction(ctx context.Context, args graphqlbackend.Ed
 	if err != nil {
 		return err
 	}
+	digest, err := toActionDigest(args.Update.Digest)
+	if err != nil {
+		return err
+	}
 	err = r.db.CodeMonitors().DeleteRecipients(ctx, emailID)
 	if err != nil {
 		return err
@@ -618,6 +733,7 @@ func (r *Resolver) updateEmailAction(ctx context.Context, args graphqlbackend.Ed
 		IncludeResults: args.Update.IncludeResults,
 		Priority:       args.Update.Priority,
 		Header:         args.Update.Header,
+		Digest:         digest,
 	})
 	if err != nil {
 		return err
@@ -631,8 +747,12 @@ func (r *Resolver) updateWebhookAction(ctx context.Context, args graphqlbackend.
 	if err != nil {
 		return err
 	}
+	digest, err := toActionDigest(args.Update.Digest)
+	if err != nil {
+		return err
+	}
 
-	_, err = r.db.CodeMonitors().UpdateWebhookAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, args.Update.URL)
+	_, err = r.db.CodeMonitors().UpdateWebhookAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, args.Update.URL, digest)
 	return err
 }
 
@@ -642,8 +762,34 @@ func (r *Resolver) updateSlackWebhookAction(ctx context.Context, args graphqlbac
 	if err != nil {
 		return err
 	}
+	digest, err := toActionDigest(args.Update.Digest)
+	if err != nil {
+		return err
+	}
 
-	_, err = r.db.CodeMonitors().UpdateSlackWebhookAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, args.Update.URL)
+	_, err = r.db.CodeMonitors().UpdateSlackWebhookAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, args.Update.URL, digest)
+	return err
+}
+
+func (r *Resolver) updateTeamsWebhookAction(ctx context.Context, args graphqlbackend.EditActionTeamsWebhookArgs) error {
+	var id int64
+	err := relay.UnmarshalSpec(*args.Id, &id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.CodeMonitors().UpdateTeamsWebhookAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, args.Update.URL)
+	return err
+}
+
+func (r *Resolver) updateIncidentWebhookAction(ctx context.Context, args graphqlbackend.EditActionIncidentWebhookArgs) error {
+	var id int64
+	err := relay.UnmarshalSpec(*args.Id, &id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.CodeMonitors().UpdateIncidentWebhookAction(ctx, id, args.Update.Enabled, args.Update.URL, args.Update.PayloadTemplate)
 	return err
 }
 
@@ -730,16 +876,20 @@ func (m *monitorConnection) PageInfo() *graphqlutil.PageInfo {
 }
 
 const (
-	MonitorKind                        = "CodeMonitor"
-	monitorTriggerQueryKind            = "CodeMonitorTriggerQuery"
-	monitorTriggerEventKind            = "CodeMonitorTriggerEvent"
-	monitorActionEmailKind             = "CodeMonitorActionEmail"
-	monitorActionWebhookKind           = "CodeMonitorActionWebhook"
-	monitorActionSlackWebhookKind      = "CodeMonitorActionSlackWebhook"
-	monitorActionEmailEventKind        = "CodeMonitorActionEmailEvent"
-	monitorActionWebhookEventKind      = "CodeMonitorActionWebhookEvent"
-	monitorActionSlackWebhookEventKind = "CodeMonitorActionSlackWebhookEvent"
-	monitorActionEmailRecipientKind    = "CodeMonitorActionEmailRecipient"
+	MonitorKind                           = "CodeMonitor"
+	monitorTriggerQueryKind               = "CodeMonitorTriggerQuery"
+	monitorTriggerEventKind               = "CodeMonitorTriggerEvent"
+	monitorActionEmailKind                = "CodeMonitorActionEmail"
+	monitorActionWebhookKind              = "CodeMonitorActionWebhook"
+	monitorActionSlackWebhookKind         = "CodeMonitorActionSlackWebhook"
+	monitorActionTeamsWebhookKind         = "CodeMonitorActionTeamsWebhook"
+	monitorActionIncidentWebhookKind      = "CodeMonitorActionIncidentWebhook"
+	monitorActionEmailEventKind           = "CodeMonitorActionEmailEvent"
+	monitorActionWebhookEventKind         = "CodeMonitorActionWebhookEvent"
+	monitorActionSlackWebhookEventKind    = "CodeMonitorActionSlackWebhookEvent"
+	monitorActionTeamsWebhookEventKind    = "CodeMonitorActionTeamsWebhookEvent"
+	monitorActionIncidentWebhookEventKind = "CodeMonitorActionIncidentWebhookEvent"
+	monitorActionEmailRecipientKind       = "CodeMonitorActionEmailRecipient"
 )
 
 func unmarshalMonitorID(id graphql.ID) (int64, error) {
@@ -831,7 +981,17 @@ func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, tr
 		return nil, err
 	}
 
-	actions := make([]graphqlbackend.MonitorAction, 0, len(es)+len(ws)+len(sws))
+	tws, err := r.db.CodeMonitors().ListTeamsWebhookActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	iws, err := r.db.CodeMonitors().ListIncidentWebhookActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make([]graphqlbackend.MonitorAction, 0, len(es)+len(ws)+len(sws)+len(tws)+len(iws))
 	for _, e := range es {
 		actions = append(actions, &action{
 			email: &monitorEmail{
@@ -859,6 +1019,24 @@ func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, tr
 			},
 		})
 	}
+	for _, tw := range tws {
+		actions = append(actions, &action{
+			teamsWebhook: &monitorTeamsWebhook{
+				Resolver:           r,
+				TeamsWebhookAction: tw,
+				triggerEventID:     triggerEventID,
+			},
+		})
+	}
+	for _, iw := range iws {
+		actions = append(actions, &action{
+			incidentWebhook: &monitorIncidentWebhook{
+				Resolver:              r,
+				IncidentWebhookAction: iw,
+				triggerEventID:        triggerEventID,
+			},
+		})
+	}
 
 	totalCount := len(actions)
 	if args.After != nil {
@@ -1032,9 +1210,11 @@ func (a *monitorActionConnection) PageInfo() *graphqlutil.PageInfo {
 
 // Action <<UNION>>
 type action struct {
-	email        graphqlbackend.MonitorEmailResolver
-	webhook      graphqlbackend.MonitorWebhookResolver
-	slackWebhook graphqlbackend.MonitorSlackWebhookResolver
+	email           graphqlbackend.MonitorEmailResolver
+	webhook         graphqlbackend.MonitorWebhookResolver
+	slackWebhook    graphqlbackend.MonitorSlackWebhookResolver
+	teamsWebhook    graphqlbackend.MonitorTeamsWebhookResolver
+	incidentWebhook graphqlbackend.MonitorIncidentWebhookResolver
 }
 
 func (a *action) ID() graphql.ID {
@@ -1045,6 +1225,10 @@ func (a *action) ID() graphql.ID {
 		return a.webhook.ID()
 	case a.slackWebhook != nil:
 		return a.slackWebhook.ID()
+	case a.teamsWebhook != nil:
+		return a.teamsWebhook.ID()
+	case a.incidentWebhook != nil:
+		return a.incidentWebhook.ID()
 	default:
 		panic("action must have a type")
 	}
@@ -1062,6 +1246,14 @@ func (a *action) ToMonitorSlackWebhook() (graphqlbackend.MonitorSlackWebhookReso
 	return a.slackWebhook, a.slackWebhook != nil
 }
 
+func (a *action) ToMonitorTeamsWebhook() (graphqlbackend.MonitorTeamsWebhookResolver, bool) {
+	return a.teamsWebhook, a.teamsWebhook != nil
+}
+
+func (a *action) ToMonitorIncidentWebhook() (graphqlbackend.MonitorIncidentWebhookResolver, bool) {
+	return a.incidentWebhook, a.incidentWebhook != nil
+}
+
 // Email
 type monitorEmail struct {
 	*Resolver
@@ -1131,6 +1323,10 @@ func (m *monitorEmail) Header() string {
 	return m.EmailAction.Header
 }
 
+func (m *monitorEmail) Digest() graphqlbackend.MonitorDigestResolver {
+	return newMonitorDigest(m.EmailAction.Digest)
+}
+
 func (m *monitorEmail) ID() graphql.ID {
 	return relay.MarshalID(monitorActionEmailKind, m.EmailAction.ID)
 }
@@ -1191,6 +1387,10 @@ func (m *monitorWebhook) URL() string {
 	return m.WebhookAction.URL
 }
 
+func (m *monitorWebhook) Digest() graphqlbackend.MonitorDigestResolver {
+	return newMonitorDigest(m.WebhookAction.Digest)
+}
+
 func (m *monitorWebhook) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
 	after, err := unmarshalAfter(args.After)
 	if err != nil {
@@ -1247,6 +1447,10 @@ func (m *monitorSlackWebhook) URL() string {
 	return m.SlackWebhookAction.URL
 }
 
+func (m *monitorSlackWebhook) Digest() graphqlbackend.MonitorDigestResolver {
+	return newMonitorDigest(m.SlackWebhookAction.Digest)
+}
+
 func (m *monitorSlackWebhook) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
 	after, err := unmarshalAfter(args.After)
 	if err != nil {
@@ -1277,6 +1481,140 @@ func (m *monitorSlackWebhook) Events(ctx context.Context, args *graphqlbackend.L
 	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
 }
 
+// Digest
+type monitorDigest struct {
+	*database.ActionDigest
+}
+
+// newMonitorDigest returns a resolver for the digest settings of an action, or
+// nil if the action sends a notification for every trigger run.
+func newMonitorDigest(d *database.ActionDigest) graphqlbackend.MonitorDigestResolver {
+	if d == nil {
+		return nil
+	}
+	return &monitorDigest{d}
+}
+
+func (m *monitorDigest) Interval() string {
+	return m.ActionDigest.Interval
+}
+
+func (m *monitorDigest) MaxResults() int32 {
+	return m.ActionDigest.MaxResults
+}
+
+type monitorTeamsWebhook struct {
+	*Resolver
+	*database.TeamsWebhookAction
+
+	// If triggerEventID == nil, all events of this action will be returned.
+	// Otherwise, only those events of this action which are related to the specified
+	// trigger event will be returned.
+	triggerEventID *int32
+}
+
+func (m *monitorTeamsWebhook) ID() graphql.ID {
+	return relay.MarshalID(monitorActionTeamsWebhookKind, m.TeamsWebhookAction.ID)
+}
+
+func (m *monitorTeamsWebhook) Enabled() bool {
+	return m.TeamsWebhookAction.Enabled
+}
+
+func (m *monitorTeamsWebhook) IncludeResults() bool {
+	return m.TeamsWebhookAction.IncludeResults
+}
+
+func (m *monitorTeamsWebhook) URL() string {
+	return m.TeamsWebhookAction.URL
+}
+
+func (m *monitorTeamsWebhook) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
+	after, err := unmarshalAfter(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	ajs, err := m.db.CodeMonitors().ListActionJobs(ctx, database.ListActionJobsOpts{
+		TeamsWebhookID: pointers.Ptr(int(m.TeamsWebhookAction.ID)),
+		TriggerEventID: m.triggerEventID,
+		First:          pointers.Ptr(int(args.First)),
+		After:          after,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := m.db.CodeMonitors().CountActionJobs(ctx, database.ListActionJobsOpts{
+		TeamsWebhookID: pointers.Ptr(int(m.TeamsWebhookAction.ID)),
+		TriggerEventID: m.triggerEventID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]graphqlbackend.MonitorActionEventResolver, len(ajs))
+	for i, aj := range ajs {
+		events[i] = &monitorActionEvent{Resolver: m.Resolver, ActionJob: aj}
+	}
+	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
+}
+
+type monitorIncidentWebhook struct {
+	*Resolver
+	*database.IncidentWebhookAction
+
+	// If triggerEventID == nil, all events of this action will be returned.
+	// Otherwise, only those events of this action which are related to the specified
+	// trigger event will be returned.
+	triggerEventID *int32
+}
+
+func (m *monitorIncidentWebhook) ID() graphql.ID {
+	return relay.MarshalID(monitorActionIncidentWebhookKind, m.IncidentWebhookAction.ID)
+}
+
+func (m *monitorIncidentWebhook) Enabled() bool {
+	return m.IncidentWebhookAction.Enabled
+}
+
+func (m *monitorIncidentWebhook) URL() string {
+	return m.IncidentWebhookAction.URL
+}
+
+func (m *monitorIncidentWebhook) PayloadTemplate() string {
+	return m.IncidentWebhookAction.PayloadTemplate
+}
+
+func (m *monitorIncidentWebhook) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
+	after, err := unmarshalAfter(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	ajs, err := m.db.CodeMonitors().ListActionJobs(ctx, database.ListActionJobsOpts{
+		IncidentWebhookID: pointers.Ptr(int(m.IncidentWebhookAction.ID)),
+		TriggerEventID:    m.triggerEventID,
+		First:             pointers.Ptr(int(args.First)),
+		After:             after,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := m.db.CodeMonitors().CountActionJobs(ctx, database.ListActionJobsOpts{
+		IncidentWebhookID: pointers.Ptr(int(m.IncidentWebhookAction.ID)),
+		TriggerEventID:    m.triggerEventID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]graphqlbackend.MonitorActionEventResolver, len(ajs))
+	for i, aj := range ajs {
+		events[i] = &monitorActionEvent{Resolver: m.Resolver, ActionJob: aj}
+	}
+	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
+}
+
 func intPtrToInt64Ptr(i *int) *int64 {
 	if i == nil {
 		return nil
@@ -1369,3 +1707,39 @@ func validateSlackURL(urlString string) error {
 	}
 	return nil
 }
+
+func validateTeamsURL(urlString string) error {
+	u, err := url.Parse(urlString)
+	if err != nil {
+		return err
+	}
+
+	// Teams webhooks are hosted on tenant-specific hosts, so we can only
+	// restrict them to HTTPS.
+	if u.Scheme != "https" {
+		return errors.New("Microsoft Teams webhook URL must begin with 'https://'")
+	}
+	return nil
+}
+
+const (
+	defaultDigestMaxResults = 50
+	maxDigestMaxResults     = 1000
+)
+
+// toActionDigest validates the digest settings of an action. It returns nil if
+// the action should send a notification for every trigger run.
+func toActionDigest(args *graphqlbackend.CreateActionDigestArgs) (*database.ActionDigest, error) {
+	if args == nil {
+		return nil, nil
+	}
+
+	maxResults := int32(defaultDigestMaxResults)
+	if args.MaxResults != nil {
+		maxResults = *args.MaxResults
+	}
+	if maxResults < 1 || maxResults > maxDigestMaxResults {
+		return nil, errors.Errorf("digest maxResults must be between 1 and %d", maxDigestMaxResults)
+	}
+	return &database.ActionDigest{Interval: args.Interval, MaxResults: maxResults}, nil
+}
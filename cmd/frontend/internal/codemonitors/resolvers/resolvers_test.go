@@ -85,6 +85,35 @@ func TestCreateCodeMonitor(t *testing.T) {
 		require.Error(t, err)
 	})
 
+	t.Run("invalid teams webhook", func(t *testing.T) {
+		namespace := relay.MarshalID("User", user.ID)
+		_, err := r.CreateCodeMonitor(ctx, &graphqlbackend.CreateCodeMonitorArgs{
+			Monitor: &graphqlbackend.CreateMonitorArgs{Namespace: namespace},
+			Trigger: &graphqlbackend.CreateTriggerArgs{Query: "repo:."},
+			Actions: []*graphqlbackend.CreateActionArgs{{
+				TeamsWebhook: &graphqlbackend.CreateActionTeamsWebhookArgs{
+					URL: "http://example.webhook.office.com",
+				},
+			}},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid incident payload template", func(t *testing.T) {
+		namespace := relay.MarshalID("User", user.ID)
+		_, err := r.CreateCodeMonitor(ctx, &graphqlbackend.CreateCodeMonitorArgs{
+			Monitor: &graphqlbackend.CreateMonitorArgs{Namespace: namespace},
+			Trigger: &graphqlbackend.CreateTriggerArgs{Query: "repo:."},
+			Actions: []*graphqlbackend.CreateActionArgs{{
+				IncidentWebhook: &graphqlbackend.CreateActionIncidentWebhookArgs{
+					URL:             "https://events.pagerduty.com/v2/enqueue",
+					PayloadTemplate: `{"summary": {{.MonitorDescription}}}`,
+				},
+			}},
+		})
+		require.Error(t, err)
+	})
+
 	t.Run("invalid query", func(t *testing.T) {
 		namespace := relay.MarshalID("User", user.ID)
 		_, err := r.CreateCodeMonitor(ctx, &graphqlbackend.CreateCodeMonitorArgs{
@@ -1322,3 +1351,31 @@ func TestValidateSlackURL(t *testing.T) {
 		require.Error(t, validateSlackURL(url))
 	}
 }
+
+func TestValidateTeamsURL(t *testing.T) {
+	require.NoError(t, validateTeamsURL("https://example.webhook.office.com/webhookb2/8d8d8"))
+
+	invalid := []string{
+		"http://example.webhook.office.com/webhookb2/8d8d8",
+		"internal:8989",
+	}
+
+	for _, url := range invalid {
+		require.Error(t, validateTeamsURL(url))
+	}
+}
+
+func TestToActionDigest(t *testing.T) {
+	got, err := toActionDigest(nil)
+	require.NoError(t, err)
+	require.Nil(t, got)
+
+	got, err = toActionDigest(&graphqlbackend.CreateActionDigestArgs{Interval: database.DigestIntervalDaily})
+	require.NoError(t, err)
+	require.Equal(t, &database.ActionDigest{Interval: database.DigestIntervalDaily, MaxResults: 50}, got)
+
+	for _, maxResults := range []int32{0, -1, 1001} {
+		_, err := toActionDigest(&graphqlbackend.CreateActionDigestArgs{Interval: database.DigestIntervalDaily, MaxResults: &maxResults})
+		require.Error(t, err)
+	}
+}
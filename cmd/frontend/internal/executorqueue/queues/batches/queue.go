@@ -3,6 +3,7 @@ package batches
 import (
 	"context"
 
+	"github.com/keegancsmith/sqlf"
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/executorqueue/handler"
@@ -11,6 +12,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	apiclient "github.com/sourcegraph/sourcegraph/internal/executor/types"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 )
 
 func QueueHandler(observationCtx *observation.Context, db database.DB, _ func() string) handler.QueueHandler[*btypes.BatchSpecWorkspaceExecutionJob] {
@@ -20,10 +22,22 @@ func QueueHandler(observationCtx *observation.Context, db database.DB, _ func()
 		return transformRecord(ctx, logger, batchesStore, record, version)
 	}
 
-	store := bstore.NewBatchSpecWorkspaceExecutionWorkerStore(observationCtx, db.Handle())
+	store := &executorStore{Store: bstore.NewBatchSpecWorkspaceExecutionWorkerStore(observationCtx, db.Handle())}
 	return handler.QueueHandler[*btypes.BatchSpecWorkspaceExecutionJob]{
 		Name:              "batches",
 		Store:             store,
 		RecordTransformer: recordTransformer,
 	}
 }
+
+// executorStore only hands out the jobs that need to run in a container to
+// executors. Jobs of batch specs that consist of rewrite steps only are
+// processed by the rewrite worker.
+type executorStore struct {
+	dbworkerstore.Store[*btypes.BatchSpecWorkspaceExecutionJob]
+}
+
+func (s *executorStore) Dequeue(ctx context.Context, workerHostname string, conditions []*sqlf.Query) (*btypes.BatchSpecWorkspaceExecutionJob, bool, error) {
+	conditions = append(conditions, sqlf.Sprintf("NOT (%s)", bstore.ContainerlessBatchSpecWorkspaceExecutionJobsCondition()))
+	return s.Store.Dequeue(ctx, workerHostname, conditions)
+}
@@ -0,0 +1,231 @@
+package resolvers
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const alertRuleKind = "InsightSeriesAlertRule"
+
+func (r *Resolver) InsightSeriesAlertRules(ctx context.Context, args *graphqlbackend.InsightSeriesAlertRulesArgs) ([]graphqlbackend.InsightSeriesAlertRuleResolver, error) {
+	uid := actor.FromContext(ctx).UID
+	if uid == 0 {
+		return nil, errors.New("must be authenticated to list alert rules")
+	}
+	viewSeries, err := r.alertRuleViewSeries(ctx, args.InsightViewID)
+	if err != nil {
+		return nil, err
+	}
+
+	alertStore := store.NewAlertRuleStore(r.insightsDB)
+	resolvers := []graphqlbackend.InsightSeriesAlertRuleResolver{}
+	for _, series := range viewSeries {
+		if args.SeriesID != nil && series.SeriesID != *args.SeriesID {
+			continue
+		}
+		// 🚨 SECURITY: alert rules contain the webhook URLs of their creator, so users only see their own rules.
+		rules, err := alertStore.ListAlertRules(ctx, store.ListAlertRulesArgs{SeriesID: series.InsightSeriesID, CreatedBy: uid})
+		if err != nil {
+			return nil, errors.Wrap(err, "ListAlertRules")
+		}
+		for _, rule := range rules {
+			resolvers = append(resolvers, &alertRuleResolver{rule: rule, seriesID: series.SeriesID})
+		}
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) CreateInsightSeriesAlertRule(ctx context.Context, args *graphqlbackend.CreateInsightSeriesAlertRuleArgs) (graphqlbackend.InsightSeriesAlertRuleResolver, error) {
+	uid := actor.FromContext(ctx).UID
+	if uid == 0 {
+		return nil, errors.New("must be authenticated to create an alert rule")
+	}
+	input := args.Input
+	rule, err := alertRuleFromInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	viewSeries, err := r.alertRuleViewSeries(ctx, input.InsightViewID)
+	if err != nil {
+		return nil, err
+	}
+	var series *types.InsightViewSeries
+	for i := range viewSeries {
+		if viewSeries[i].SeriesID == input.SeriesID {
+			series = &viewSeries[i]
+			break
+		}
+	}
+	if series == nil {
+		return nil, errors.New("series not found")
+	}
+
+	rule.SeriesID = series.InsightSeriesID
+	rule.CreatedBy = &uid
+	if input.NotifyByEmail {
+		rule.EmailUserID = &uid
+	}
+	created, err := store.NewAlertRuleStore(r.insightsDB).CreateAlertRule(ctx, rule)
+	if err != nil {
+		return nil, err
+	}
+	return &alertRuleResolver{rule: created, seriesID: series.SeriesID}, nil
+}
+
+func (r *Resolver) DeleteInsightSeriesAlertRule(ctx context.Context, args *graphqlbackend.DeleteInsightSeriesAlertRuleArgs) (*graphqlbackend.EmptyResponse, error) {
+	var id int
+	if err := relay.UnmarshalSpec(args.ID, &id); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal alert rule id")
+	}
+
+	alertStore := store.NewAlertRuleStore(r.insightsDB)
+	rule, err := alertStore.GetAlertRule(ctx, id)
+	if err != nil && !errors.Is(err, store.ErrAlertRuleNotFound) {
+		return nil, errors.Wrap(err, "GetAlertRule")
+	}
+	// 🚨 SECURITY: alert rules can only be deleted by their creator. We return a generic not found error to
+	// prevent leaking the existence of the rules of other users.
+	uid := actor.FromContext(ctx).UID
+	if err != nil || uid == 0 || rule.CreatedBy == nil || *rule.CreatedBy != uid {
+		return nil, errors.New("alert rule not found")
+	}
+
+	if err := alertStore.DeleteAlertRule(ctx, id); err != nil {
+		return nil, errors.Wrap(err, "DeleteAlertRule")
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+// alertRuleViewSeries returns the series of the given insight view, after validating that the user can see the
+// view.
+func (r *Resolver) alertRuleViewSeries(ctx context.Context, insightViewID graphql.ID) ([]types.InsightViewSeries, error) {
+	var viewID string
+	if err := relay.UnmarshalSpec(insightViewID, &viewID); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling the insight view id")
+	}
+	permissionsValidator := PermissionsValidatorFromBase(&r.baseInsightResolver)
+	if err := permissionsValidator.validateUserAccessForView(ctx, viewID); err != nil {
+		return nil, err
+	}
+
+	insights, err := r.insightStore.GetMapped(ctx, store.InsightQueryArgs{WithoutAuthorization: true, UniqueID: viewID})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetMapped")
+	}
+	if len(insights) != 1 {
+		return nil, errors.New("insight not found")
+	}
+	return insights[0].Series, nil
+}
+
+func alertRuleFromInput(input graphqlbackend.CreateInsightSeriesAlertRuleInput) (types.InsightSeriesAlertRule, error) {
+	rule := types.InsightSeriesAlertRule{
+		Kind:            types.AlertRuleKind(strings.ToLower(input.Kind)),
+		Direction:       types.AlertRuleDirection(strings.ToLower(input.Direction)),
+		Threshold:       input.Threshold,
+		WindowSize:      int(input.WindowSize),
+		SlackWebhookURL: input.SlackWebhookURL,
+		WebhookURL:      input.WebhookURL,
+	}
+	switch rule.Kind {
+	case types.AlertRuleThreshold:
+		rule.WindowSize = 1
+	case types.AlertRulePercentChange, types.AlertRuleAnomaly:
+		if rule.WindowSize < 1 {
+			return rule, errors.New("the window size has to be at least 1")
+		}
+		if rule.Threshold < 0 {
+			return rule, errors.New("the threshold must not be negative")
+		}
+	default:
+		return rule, errors.Newf("invalid alert rule kind %q", input.Kind)
+	}
+	if rule.Direction != types.AlertRuleAbove && rule.Direction != types.AlertRuleBelow {
+		return rule, errors.Newf("invalid alert rule direction %q", input.Direction)
+	}
+
+	if !input.NotifyByEmail && input.SlackWebhookURL == nil && input.WebhookURL == nil {
+		return rule, errors.New("at least one recipient is required")
+	}
+	for _, u := range []*string{input.SlackWebhookURL, input.WebhookURL} {
+		if u == nil {
+			continue
+		}
+		parsed, err := url.Parse(*u)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return rule, errors.Newf("invalid webhook URL %q", *u)
+		}
+	}
+	return rule, nil
+}
+
+var _ graphqlbackend.InsightSeriesAlertRuleResolver = &alertRuleResolver{}
+
+type alertRuleResolver struct {
+	rule     types.InsightSeriesAlertRule
+	seriesID string
+}
+
+func (a *alertRuleResolver) ID() graphql.ID {
+	return relay.MarshalID(alertRuleKind, a.rule.ID)
+}
+
+func (a *alertRuleResolver) SeriesID() string {
+	return a.seriesID
+}
+
+func (a *alertRuleResolver) Kind() string {
+	return strings.ToUpper(string(a.rule.Kind))
+}
+
+func (a *alertRuleResolver) Direction() string {
+	return strings.ToUpper(string(a.rule.Direction))
+}
+
+func (a *alertRuleResolver) Threshold() float64 {
+	return a.rule.Threshold
+}
+
+func (a *alertRuleResolver) WindowSize() int32 {
+	return int32(a.rule.WindowSize)
+}
+
+func (a *alertRuleResolver) NotifyByEmail() bool {
+	return a.rule.EmailUserID != nil
+}
+
+func (a *alertRuleResolver) SlackWebhookURL() *string {
+	return a.rule.SlackWebhookURL
+}
+
+func (a *alertRuleResolver) WebhookURL() *string {
+	return a.rule.WebhookURL
+}
+
+func (a *alertRuleResolver) Firing() bool {
+	return a.rule.Firing
+}
+
+func (a *alertRuleResolver) LastEvaluatedAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(a.rule.LastEvaluatedAt)
+}
+
+func (a *alertRuleResolver) LastTriggeredAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(a.rule.LastTriggeredAt)
+}
+
+func (a *alertRuleResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: a.rule.CreatedAt}
+}
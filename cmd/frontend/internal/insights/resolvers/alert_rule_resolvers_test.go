@@ -0,0 +1,73 @@
+package resolvers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+)
+
+func TestAlertRuleFromInput(t *testing.T) {
+	valid := func() graphqlbackend.CreateInsightSeriesAlertRuleInput {
+		webhookURL := "https://example.com/webhook"
+		return graphqlbackend.CreateInsightSeriesAlertRuleInput{
+			SeriesID:   "series1",
+			Kind:       "PERCENT_CHANGE",
+			Direction:  "BELOW",
+			Threshold:  10,
+			WindowSize: 3,
+			WebhookURL: &webhookURL,
+		}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		rule, err := alertRuleFromInput(valid())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rule.Kind != types.AlertRulePercentChange || rule.Direction != types.AlertRuleBelow || rule.WindowSize != 3 {
+			t.Fatalf("unexpected rule %+v", rule)
+		}
+	})
+
+	t.Run("threshold rules ignore the window size", func(t *testing.T) {
+		input := valid()
+		input.Kind = "THRESHOLD"
+		input.WindowSize = 0
+		rule, err := alertRuleFromInput(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rule.WindowSize != 1 {
+			t.Fatalf("unexpected window size %d", rule.WindowSize)
+		}
+	})
+
+	testCases := []struct {
+		name    string
+		modify  func(*graphqlbackend.CreateInsightSeriesAlertRuleInput)
+		wantErr string
+	}{
+		{name: "invalid kind", modify: func(i *graphqlbackend.CreateInsightSeriesAlertRuleInput) { i.Kind = "SOMETIMES" }, wantErr: "invalid alert rule kind"},
+		{name: "invalid direction", modify: func(i *graphqlbackend.CreateInsightSeriesAlertRuleInput) { i.Direction = "SIDEWAYS" }, wantErr: "invalid alert rule direction"},
+		{name: "window size", modify: func(i *graphqlbackend.CreateInsightSeriesAlertRuleInput) { i.WindowSize = 0 }, wantErr: "window size has to be at least 1"},
+		{name: "negative threshold", modify: func(i *graphqlbackend.CreateInsightSeriesAlertRuleInput) { i.Threshold = -10 }, wantErr: "threshold must not be negative"},
+		{name: "no recipient", modify: func(i *graphqlbackend.CreateInsightSeriesAlertRuleInput) { i.WebhookURL = nil }, wantErr: "at least one recipient"},
+		{name: "invalid webhook URL", modify: func(i *graphqlbackend.CreateInsightSeriesAlertRuleInput) {
+			u := "file:///etc/passwd"
+			i.SlackWebhookURL = &u
+		}, wantErr: "invalid webhook URL"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := valid()
+			tc.modify(&input)
+			_, err := alertRuleFromInput(input)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
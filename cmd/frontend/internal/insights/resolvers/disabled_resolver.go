@@ -40,6 +40,34 @@ func (r *disabledResolver) RemoveInsightViewFromDashboard(ctx context.Context, a
 	return nil, errors.New(r.reason)
 }
 
+func (r *disabledResolver) ExportInsightsDashboards(ctx context.Context, args *graphqlbackend.ExportInsightsDashboardsArgs) (string, error) {
+	return "", errors.New(r.reason)
+}
+
+func (r *disabledResolver) ImportInsightsDashboards(ctx context.Context, args *graphqlbackend.ImportInsightsDashboardsArgs) (graphqlbackend.ImportInsightsDashboardsPayloadResolver, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) InsightHistoricalQuery(ctx context.Context, args *graphqlbackend.InsightHistoricalQueryArgs) (graphqlbackend.InsightHistoricalQueryResolver, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) RunInsightHistoricalQuery(ctx context.Context, args *graphqlbackend.RunInsightHistoricalQueryArgs) (graphqlbackend.InsightHistoricalQueryResolver, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) InsightSeriesAlertRules(ctx context.Context, args *graphqlbackend.InsightSeriesAlertRulesArgs) ([]graphqlbackend.InsightSeriesAlertRuleResolver, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) CreateInsightSeriesAlertRule(ctx context.Context, args *graphqlbackend.CreateInsightSeriesAlertRuleArgs) (graphqlbackend.InsightSeriesAlertRuleResolver, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) DeleteInsightSeriesAlertRule(ctx context.Context, args *graphqlbackend.DeleteInsightSeriesAlertRuleArgs) (*graphqlbackend.EmptyResponse, error) {
+	return nil, errors.New(r.reason)
+}
+
 func (r *disabledResolver) UpdateInsightSeries(ctx context.Context, args *graphqlbackend.UpdateInsightSeriesArgs) (graphqlbackend.InsightSeriesMetadataPayloadResolver, error) {
 	return nil, errors.New(r.reason)
 }
@@ -0,0 +1,183 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/insights/background/historicalquery"
+	"github.com/sourcegraph/sourcegraph/internal/insights/query/querybuilder"
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/timeseries"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	searchquery "github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	historicalQueryKind = "InsightHistoricalQuery"
+
+	maxHistoricalQueryRepos   = 100
+	maxHistoricalQuerySamples = 90
+)
+
+func (r *Resolver) RunInsightHistoricalQuery(ctx context.Context, args *graphqlbackend.RunInsightHistoricalQueryArgs) (graphqlbackend.InsightHistoricalQueryResolver, error) {
+	uid := actor.FromContext(ctx).UID
+	if uid == 0 {
+		return nil, errors.New("must be authenticated to run a historical query")
+	}
+	input := args.Input
+	if err := validateHistoricalQueryInput(input); err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: the repositories are listed in the context of the user, so only repositories visible to
+	// the user can be queried. The query itself runs in the background as an internal actor.
+	if err := validateRepositoryList(ctx, input.Repositories, r.postgresDB.Repos()); err != nil {
+		return nil, err
+	}
+
+	jobStore := basestore.NewWithHandle(r.insightsDB.Handle())
+	id, err := historicalquery.EnqueueJob(ctx, jobStore, &historicalquery.Job{
+		UserID:              uid,
+		Query:               input.Query,
+		Repositories:        input.Repositories,
+		SampleIntervalUnit:  types.IntervalUnit(input.TimeScope.StepInterval.Unit),
+		SampleIntervalValue: int(input.TimeScope.StepInterval.Value),
+		NumSamples:          int(input.NumSamples),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "EnqueueJob")
+	}
+	job, err := historicalquery.GetJob(ctx, jobStore, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetJob")
+	}
+	return &historicalQueryResolver{job: job}, nil
+}
+
+func validateHistoricalQueryInput(input graphqlbackend.InsightHistoricalQueryInput) error {
+	if strings.TrimSpace(input.Query) == "" {
+		return errors.New("a query is required")
+	}
+	containsRepo, err := querybuilder.ContainsField(input.Query, searchquery.FieldRepo)
+	if err != nil {
+		return errors.Wrap(err, "invalid query")
+	}
+	if containsRepo {
+		return errors.New("the query must not contain a repo filter, the repositories are given separately")
+	}
+
+	if len(input.Repositories) == 0 {
+		return errors.New("at least one repository is required")
+	}
+	if len(input.Repositories) > maxHistoricalQueryRepos {
+		return errors.Newf("historical queries are limited to %d repositories", maxHistoricalQueryRepos)
+	}
+
+	if input.TimeScope.StepInterval == nil {
+		return errors.New("a step interval is required")
+	}
+	if input.TimeScope.Revisions != nil {
+		return errors.New("historical queries can not be sampled at tags")
+	}
+	interval := timeseries.TimeInterval{
+		Unit:  types.IntervalUnit(input.TimeScope.StepInterval.Unit),
+		Value: int(input.TimeScope.StepInterval.Value),
+	}
+	if !interval.IsValid() || interval.Value < 1 {
+		return errors.New("invalid step interval")
+	}
+
+	if input.NumSamples < 1 || input.NumSamples > maxHistoricalQuerySamples {
+		return errors.Newf("the number of samples has to be between 1 and %d", maxHistoricalQuerySamples)
+	}
+	return nil
+}
+
+func (r *Resolver) InsightHistoricalQuery(ctx context.Context, args *graphqlbackend.InsightHistoricalQueryArgs) (graphqlbackend.InsightHistoricalQueryResolver, error) {
+	var id int
+	if err := relay.UnmarshalSpec(args.ID, &id); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal historical query id")
+	}
+
+	job, err := historicalquery.GetJob(ctx, basestore.NewWithHandle(r.insightsDB.Handle()), id)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetJob")
+	}
+	// 🚨 SECURITY: historical queries are only visible to the user who ran them.
+	if job == nil || job.UserID != actor.FromContext(ctx).UID {
+		return nil, nil
+	}
+	return &historicalQueryResolver{job: job}, nil
+}
+
+var _ graphqlbackend.InsightHistoricalQueryResolver = &historicalQueryResolver{}
+
+type historicalQueryResolver struct {
+	job *historicalquery.Job
+}
+
+func (h *historicalQueryResolver) ID() graphql.ID {
+	return relay.MarshalID(historicalQueryKind, h.job.ID)
+}
+
+func (h *historicalQueryResolver) Query() string {
+	return h.job.Query
+}
+
+func (h *historicalQueryResolver) State() string {
+	switch h.job.State {
+	case "queued", "processing", "errored", "completed":
+		return strings.ToUpper(h.job.State)
+	default:
+		return "FAILED"
+	}
+}
+
+func (h *historicalQueryResolver) RepositoriesTotal() int32 {
+	return int32(len(h.job.Repositories))
+}
+
+func (h *historicalQueryResolver) RepositoriesCompleted() int32 {
+	return int32(h.job.RepositoriesCompleted)
+}
+
+func (h *historicalQueryResolver) Points(ctx context.Context) ([]graphqlbackend.InsightsDataPointResolver, error) {
+	resolvers := make([]graphqlbackend.InsightsDataPointResolver, 0, len(h.job.Results))
+	for i, point := range h.job.Results {
+		var after *time.Time
+		if i > 0 {
+			after = &h.job.Results[i-1].Time
+		}
+		resolvers = append(resolvers, insightsDataPointResolver{
+			p: store.SeriesPoint{
+				SeriesID: fmt.Sprintf("historical-query-%d", h.job.ID),
+				Time:     point.Time,
+				Value:    point.Value,
+			},
+			diffInfo: &querybuilder.PointDiffQueryOpts{
+				After:       after,
+				Before:      point.Time,
+				RepoList:    h.job.Repositories,
+				SearchQuery: querybuilder.BasicQuery(h.job.Query),
+			},
+		})
+	}
+	return resolvers, nil
+}
+
+func (h *historicalQueryResolver) FailureMessage() *string {
+	return h.job.FailureMessage
+}
+
+func (h *historicalQueryResolver) ExpiresAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: h.job.ExpiresAt()}
+}
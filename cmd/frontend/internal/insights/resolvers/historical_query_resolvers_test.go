@@ -0,0 +1,53 @@
+package resolvers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+)
+
+func TestValidateHistoricalQueryInput(t *testing.T) {
+	valid := func() graphqlbackend.InsightHistoricalQueryInput {
+		return graphqlbackend.InsightHistoricalQueryInput{
+			Query:        "TODO",
+			Repositories: []string{"github.com/sourcegraph/sourcegraph"},
+			TimeScope:    graphqlbackend.TimeScopeInput{StepInterval: &graphqlbackend.TimeIntervalStepInput{Unit: "MONTH", Value: 1}},
+			NumSamples:   24,
+		}
+	}
+
+	testCases := []struct {
+		name    string
+		modify  func(*graphqlbackend.InsightHistoricalQueryInput)
+		wantErr string
+	}{
+		{name: "valid", modify: func(*graphqlbackend.InsightHistoricalQueryInput) {}},
+		{name: "empty query", modify: func(i *graphqlbackend.InsightHistoricalQueryInput) { i.Query = " " }, wantErr: "a query is required"},
+		{name: "repo filter", modify: func(i *graphqlbackend.InsightHistoricalQueryInput) { i.Query = "repo:sourcegraph TODO" }, wantErr: "must not contain a repo filter"},
+		{name: "no repositories", modify: func(i *graphqlbackend.InsightHistoricalQueryInput) { i.Repositories = nil }, wantErr: "at least one repository"},
+		{name: "too many repositories", modify: func(i *graphqlbackend.InsightHistoricalQueryInput) {
+			i.Repositories = make([]string, maxHistoricalQueryRepos+1)
+		}, wantErr: "limited to 100 repositories"},
+		{name: "missing step interval", modify: func(i *graphqlbackend.InsightHistoricalQueryInput) { i.TimeScope.StepInterval = nil }, wantErr: "step interval is required"},
+		{name: "invalid step interval", modify: func(i *graphqlbackend.InsightHistoricalQueryInput) { i.TimeScope.StepInterval.Value = 0 }, wantErr: "invalid step interval"},
+		{name: "too many samples", modify: func(i *graphqlbackend.InsightHistoricalQueryInput) { i.NumSamples = 91 }, wantErr: "between 1 and 90"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := valid()
+			tc.modify(&input)
+			err := validateHistoricalQueryInput(input)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -55,6 +55,8 @@ func (i insightsDataPointResolver) DiffQuery() (*string, error) {
 	return &q, nil
 }
 
+func (i insightsDataPointResolver) Revision() *string { return i.p.Revision }
+
 type statusInfo struct {
 	totalPoints, pendingJobs, completedJobs, failedJobs int32
 	backfillQueuedAt                                    *time.Time
@@ -161,10 +163,11 @@ type precalculatedInsightSeriesResolver struct {
 	metadataStore   store.InsightMetadataStore
 	statusResolver  graphqlbackend.InsightStatusResolver
 
-	seriesId string
-	points   []store.SeriesPoint
-	label    string
-	filters  types.InsightViewFilters
+	seriesId        string
+	points          []store.SeriesPoint
+	label           string
+	filters         types.InsightViewFilters
+	breakdownLoader repoBreakdownLoader
 }
 
 func (p *precalculatedInsightSeriesResolver) SeriesId() string {
@@ -198,7 +201,14 @@ func (p *precalculatedInsightSeriesResolver) Points(ctx context.Context, _ *grap
 	// Replacing capture group values if present
 	// Ignoring errors so it falls back to the entered query
 	query := p.series.Query
-	if p.series.GeneratedFromCaptureGroups && len(modifiedPoints) > 0 {
+	if p.series.GenerationMethod == types.SearchOwners && len(modifiedPoints) > 0 {
+		if modifiedPoints[0].Capture != nil {
+			ownerQuery, err := querybuilder.AddOwnerFilter(querybuilder.BasicQuery(query), *modifiedPoints[0].Capture)
+			if err == nil {
+				query = ownerQuery.String()
+			}
+		}
+	} else if p.series.GeneratedFromCaptureGroups && len(modifiedPoints) > 0 {
 		replacer, _ := querybuilder.NewPatternReplacer(querybuilder.BasicQuery(query), searchquery.SearchTypeRegex)
 		if replacer != nil {
 			replaced, err := replacer.Replace(*modifiedPoints[0].Capture)
@@ -226,6 +236,16 @@ func (p *precalculatedInsightSeriesResolver) Points(ctx context.Context, _ *grap
 				SearchQuery:        querybuilder.BasicQuery(query),
 			},
 		}
+		if p.series.GenerationMethod == types.PreciseCodeIntel {
+			// The query of precise code intelligence series is not a search query, so there is no
+			// search that shows the difference between two points.
+			pointResolver.diffInfo = nil
+		}
+		if p.series.RevisionPattern != nil {
+			// Diff searches cover a range of commit dates, which does not match the range of
+			// commits between two tags.
+			pointResolver.diffInfo = nil
+		}
 		resolvers = append(resolvers, pointResolver)
 	}
 
@@ -235,6 +255,10 @@ func (p *precalculatedInsightSeriesResolver) Points(ctx context.Context, _ *grap
 // This will make sure that no two snapshots are too close together. We'll use 20% of the time interval to
 // remove these "close" points.
 func removeClosePoints(points []store.SeriesPoint, series types.InsightViewSeries) []store.SeriesPoint {
+	if series.RevisionPattern != nil {
+		// Series sampled at tags have no snapshots, and tags can be created close together.
+		return points
+	}
 	buffer := intervalToMinutes(types.IntervalUnit(series.SampleIntervalUnit), series.SampleIntervalValue) / 5
 	modifiedPoints := []store.SeriesPoint{}
 	for i := 0; i < len(points)-1; i++ {
@@ -271,6 +295,10 @@ func (p *precalculatedInsightSeriesResolver) Status(ctx context.Context) (graphq
 	return p.statusResolver, nil
 }
 
+func (p *precalculatedInsightSeriesResolver) RepositoryBreakdown(ctx context.Context, args *graphqlbackend.InsightRepositoryBreakdownArgs) (graphqlbackend.InsightRepositoryBreakdownConnectionResolver, error) {
+	return &repositoryBreakdownConnectionResolver{args: args, series: p.series, loader: p.breakdownLoader}, nil
+}
+
 type insightSeriesResolverGenerator interface {
 	Generate(ctx context.Context, series types.InsightViewSeries, baseResolver baseInsightResolver, filters types.InsightViewFilters, options types.SeriesDisplayOptions) ([]graphqlbackend.InsightSeriesResolver, error)
 	handles(series types.InsightViewSeries) bool
@@ -420,6 +448,7 @@ func recordedSeries(ctx context.Context, definition types.InsightViewSeries, r b
 		filters:         filters,
 		seriesId:        definition.SeriesID,
 		statusResolver:  statusResolver,
+		breakdownLoader: &recordedRepoBreakdownLoader{definition: definition, filters: filters, options: options, r: &r},
 	})
 	return resolvers, nil
 }
@@ -444,6 +473,7 @@ func expandCaptureGroupSeriesRecorded(ctx context.Context, definition types.Insi
 
 	var resolvers []graphqlbackend.InsightSeriesResolver
 	for capturedValue, points := range groupedByCapture {
+		capturedValue := capturedValue
 		sort.Slice(points, func(i, j int) bool {
 			return points[i].Time.Before(points[j].Time)
 		})
@@ -457,6 +487,7 @@ func expandCaptureGroupSeriesRecorded(ctx context.Context, definition types.Insi
 			filters:         filters,
 			seriesId:        fmt.Sprintf("%s-%s", definition.SeriesID, capturedValue),
 			statusResolver:  statusResolver,
+			breakdownLoader: &recordedRepoBreakdownLoader{definition: definition, filters: filters, options: options, r: &r, capture: &capturedValue},
 		})
 	}
 	if len(resolvers) == 0 {
@@ -2,6 +2,7 @@ package resolvers
 
 import (
 	"context"
+	"path"
 	"sort"
 	"strings"
 	"sync"
@@ -35,6 +36,7 @@ var _ graphqlbackend.SearchInsightDataSeriesDefinitionResolver = &searchInsightD
 var _ graphqlbackend.InsightRepositoryScopeResolver = &insightRepositoryScopeResolver{}
 var _ graphqlbackend.InsightRepositoryDefinition = &insightRepositoryDefinitionResolver{}
 var _ graphqlbackend.InsightIntervalTimeScope = &insightIntervalTimeScopeResolver{}
+var _ graphqlbackend.InsightRevisionTimeScope = &insightRevisionTimeScopeResolver{}
 var _ graphqlbackend.InsightViewFiltersResolver = &insightViewFiltersResolver{}
 var _ graphqlbackend.InsightViewPayloadResolver = &insightPayloadResolver{}
 var _ graphqlbackend.InsightTimeScope = &insightTimeScopeUnionResolver{}
@@ -251,12 +253,7 @@ func (i *insightViewResolver) TimeScope(ctx context.Context) (graphqlbackend.Ins
 		return nil, errors.New("no time scope available")
 	}
 
-	return &insightTimeScopeUnionResolver{
-		resolver: &insightIntervalTimeScopeResolver{
-			unit:  i.view.Series[0].SampleIntervalUnit,
-			value: int32(i.view.Series[0].SampleIntervalValue),
-		},
-	}, nil
+	return newInsightTimeScopeResolver(i.view.Series[0]), nil
 }
 
 func (i *insightViewResolver) Presentation(ctx context.Context) (graphqlbackend.InsightPresentation, error) {
@@ -328,19 +325,22 @@ func (s *searchInsightDataSeriesDefinitionResolver) RepositoryDefinition(ctx con
 }
 
 func (s *searchInsightDataSeriesDefinitionResolver) TimeScope(ctx context.Context) (graphqlbackend.InsightTimeScope, error) {
-	intervalResolver := &insightIntervalTimeScopeResolver{
-		unit:  s.series.SampleIntervalUnit,
-		value: int32(s.series.SampleIntervalValue),
-	}
-
-	return &insightTimeScopeUnionResolver{resolver: intervalResolver}, nil
+	return newInsightTimeScopeResolver(*s.series), nil
 }
 
 func (s *searchInsightDataSeriesDefinitionResolver) GeneratedFromCaptureGroups() (bool, error) {
 	return s.series.GeneratedFromCaptureGroups, nil
 }
 
+func (s *searchInsightDataSeriesDefinitionResolver) GeneratedFromPreciseCodeIntel() (bool, error) {
+	return s.series.GenerationMethod == types.PreciseCodeIntel, nil
+}
+
 func (s *searchInsightDataSeriesDefinitionResolver) GroupBy() (*string, error) {
+	if s.series.GenerationMethod == types.SearchOwners {
+		groupBy := strings.ToUpper(ownerGroupBy)
+		return &groupBy, nil
+	}
 	if s.series.GroupBy != nil {
 		groupBy := strings.ToUpper(*s.series.GroupBy)
 		return &groupBy, nil
@@ -361,6 +361,28 @@ func (i *insightIntervalTimeScopeResolver) Value(ctx context.Context) (int32, er
 	return i.value, nil
 }
 
+type insightRevisionTimeScopeResolver struct {
+	pattern string
+}
+
+func (i *insightRevisionTimeScopeResolver) Pattern() string {
+	return i.pattern
+}
+
+// newInsightTimeScopeResolver returns the time scope of a series, which is either its sample
+// interval or the tags that it is sampled at.
+func newInsightTimeScopeResolver(series types.InsightViewSeries) *insightTimeScopeUnionResolver {
+	if series.RevisionPattern != nil {
+		return &insightTimeScopeUnionResolver{resolver: &insightRevisionTimeScopeResolver{pattern: *series.RevisionPattern}}
+	}
+	return &insightTimeScopeUnionResolver{
+		resolver: &insightIntervalTimeScopeResolver{
+			unit:  series.SampleIntervalUnit,
+			value: int32(series.SampleIntervalValue),
+		},
+	}
+}
+
 type insightRepositoryScopeResolver struct {
 	repositories []string
 }
@@ -612,7 +634,7 @@ func (r *Resolver) UpdateLineChartSearchInsight(ctx context.Context, args *graph
 	// Capture group insight only have 1 associated insight series at most.
 	captureGroupInsight := false
 	for _, newSeries := range args.Input.DataSeries {
-		if isCaptureGroupSeries(newSeries.GeneratedFromCaptureGroups) {
+		if isCaptureGroupSeries(newSeries.GeneratedFromCaptureGroups) || isOwnerGroupBySeries(newSeries.GroupBy) {
 			captureGroupInsight = true
 			break
 		}
@@ -659,6 +681,20 @@ func validateRepositoryList(ctx context.Context, repos []string, repoStore datab
 	return nil
 }
 
+func isPreciseCodeIntelSeries(generatedFromPreciseCodeIntel *bool) bool {
+	return generatedFromPreciseCodeIntel != nil && *generatedFromPreciseCodeIntel
+}
+
+// ownerGroupBy is the group by value of series grouped by the owners of files.
+const ownerGroupBy = "owner"
+
+// isOwnerGroupBySeries returns true if the series is grouped by the owners of the files that match its query.
+// Unlike other group by series these are not computed with a compute query, but are regular search series with
+// one dynamic series per owner.
+func isOwnerGroupBySeries(groupBy *string) bool {
+	return groupBy != nil && strings.EqualFold(*groupBy, ownerGroupBy)
+}
+
 func isCaptureGroupSeries(generatedFromCaptureGroups *bool) bool {
 	if generatedFromCaptureGroups == nil {
 		return false
@@ -773,6 +809,15 @@ func existingSeriesHasChanged(new graphqlbackend.LineChartSearchInsightDataSerie
 			return true
 		}
 	}
+	if isPreciseCodeIntelSeries(new.GeneratedFromPreciseCodeIntel) != (existing.GenerationMethod == types.PreciseCodeIntel) {
+		return true
+	}
+	if emptyIfNil(revisionPattern(new.TimeScope)) != emptyIfNil(existing.RevisionPattern) {
+		return true
+	}
+	if isOwnerGroupBySeries(new.GroupBy) || existing.GenerationMethod == types.SearchOwners {
+		return isOwnerGroupBySeries(new.GroupBy) != (existing.GenerationMethod == types.SearchOwners)
+	}
 	return emptyIfNil(new.GroupBy) != emptyIfNil(existing.GroupBy)
 }
 
@@ -905,28 +950,8 @@ func (r *Resolver) CreatePieChartSearchInsight(ctx context.Context, args *graphq
 	if err != nil {
 		return nil, errors.Wrap(err, "CreateView")
 	}
-	repos := args.Input.RepositoryScope.Repositories
-	seriesToAdd, err := insightTx.CreateSeries(ctx, types.InsightSeries{
-		SeriesID:           ksuid.New().String(),
-		Query:              args.Input.Query,
-		CreatedAt:          time.Now(),
-		Repositories:       repos,
-		SampleIntervalUnit: string(types.Month),
-		JustInTime:         len(repos) > 0,
-		// one might ask themselves why is the generation method a language stats method if this mutation is search insight? The answer is that search is ultimately the
-		// driver behind language stats, but global language stats behave differently than standard search. Long term the vision is that
-		// search will power this, and we can iterate over repos just like any other search insight. But for now, this is just something weird that we will have to live with.
-		// As a note, this does mean that this mutation doesn't even technically do what it is named - it does not create a 'search' insight, and with that in mind
-		// if we decide to support pie charts for other insights than language stats (which we likely will, say on arbitrary aggregations or capture groups) we will need to
-		// revisit this.
-		GenerationMethod: types.LanguageStats,
-	})
-	if err != nil {
-		return nil, errors.Wrap(err, "CreateSeries")
-	}
-	err = insightTx.AttachSeriesToView(ctx, seriesToAdd, view, types.InsightViewSeriesMetadata{})
-	if err != nil {
-		return nil, errors.Wrap(err, "AttachSeriesToView")
+	if err := createAndAttachPieChartSeries(ctx, insightTx, view, args.Input.Query, args.Input.RepositoryScope.Repositories); err != nil {
+		return nil, err
 	}
 
 	if len(dashboardIds) > 0 {
@@ -998,6 +1023,33 @@ func (r *Resolver) UpdatePieChartSearchInsight(ctx context.Context, args *graphq
 	return &insightPayloadResolver{baseInsightResolver: r.baseInsightResolver, validator: permissionsValidator, viewId: view.UniqueID}, nil
 }
 
+// createAndAttachPieChartSeries creates the language statistics series of a pie chart and attaches it to the given view.
+func createAndAttachPieChartSeries(ctx context.Context, tx *store.InsightStore, view types.InsightView, query string, repos []string) error {
+	seriesToAdd, err := tx.CreateSeries(ctx, types.InsightSeries{
+		SeriesID:           ksuid.New().String(),
+		Query:              query,
+		CreatedAt:          time.Now(),
+		Repositories:       repos,
+		SampleIntervalUnit: string(types.Month),
+		JustInTime:         len(repos) > 0,
+		// one might ask themselves why is the generation method a language stats method if this mutation is search insight? The answer is that search is ultimately the
+		// driver behind language stats, but global language stats behave differently than standard search. Long term the vision is that
+		// search will power this, and we can iterate over repos just like any other search insight. But for now, this is just something weird that we will have to live with.
+		// As a note, this does mean that this mutation doesn't even technically do what it is named - it does not create a 'search' insight, and with that in mind
+		// if we decide to support pie charts for other insights than language stats (which we likely will, say on arbitrary aggregations or capture groups) we will need to
+		// revisit this.
+		GenerationMethod: types.LanguageStats,
+	})
+	if err != nil {
+		return errors.Wrap(err, "CreateSeries")
+	}
+	err = tx.AttachSeriesToView(ctx, seriesToAdd, view, types.InsightViewSeriesMetadata{})
+	if err != nil {
+		return errors.Wrap(err, "AttachSeriesToView")
+	}
+	return nil
+}
+
 type pieChartInsightViewPresentation struct {
 	view *types.Insight
 }
@@ -1060,6 +1112,12 @@ func (r *insightTimeScopeUnionResolver) ToInsightIntervalTimeScope() (graphqlbac
 	return res, ok
 }
 
+// ToInsightRevisionTimeScope is used by the GraphQL library to resolve type fragments for unions
+func (r *insightTimeScopeUnionResolver) ToInsightRevisionTimeScope() (graphqlbackend.InsightRevisionTimeScope, bool) {
+	res, ok := r.resolver.(*insightRevisionTimeScopeResolver)
+	return res, ok
+}
+
 // A dummy type to represent the GraphQL union InsightPresentation
 type insightPresentationUnionResolver struct {
 	resolver any
@@ -1316,7 +1374,15 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 	var err error
 	var dynamic bool
 	// Validate the query before creating anything; we don't want faulty insights running pointlessly.
-	if series.GroupBy != nil || series.GeneratedFromCaptureGroups != nil {
+	if isPreciseCodeIntelSeries(series.GeneratedFromPreciseCodeIntel) {
+		if _, err := querybuilder.ParseCodeIntelQuery(series.Query); err != nil {
+			return errors.Wrap(err, "query validation")
+		}
+	} else if isOwnerGroupBySeries(series.GroupBy) {
+		if _, err := querybuilder.ParseQuery(series.Query, "literal"); err != nil {
+			return errors.Wrap(err, "query validation")
+		}
+	} else if series.GroupBy != nil || series.GeneratedFromCaptureGroups != nil {
 		if _, err := querybuilder.ParseComputeQuery(series.Query, gitserver.NewClient("graphql.insights.computequery")); err != nil {
 			return errors.Wrap(err, "query validation")
 		}
@@ -1331,6 +1397,12 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 	}
 
 	groupBy := lowercaseGroupBy(series.GroupBy)
+	if isOwnerGroupBySeries(series.GroupBy) {
+		// Series grouped by owner are recorded like capture group series, with one dynamic series per owner.
+		// They are identified by their generation method, as a group by would make them compute series.
+		dynamic = true
+		groupBy = nil
+	}
 	var nextRecordingAfter time.Time
 	var oldestHistoricalAt time.Time
 	if series.GroupBy != nil {
@@ -1350,6 +1422,7 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 			StepIntervalValue:         int(series.TimeScope.StepInterval.Value),
 			GenerateFromCaptureGroups: dynamic,
 			GroupBy:                   groupBy,
+			GenerationMethod:          searchGenerationMethod(series),
 		})
 		if err != nil {
 			return errors.Wrap(err, "FindMatchingSeries")
@@ -1372,6 +1445,7 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 			NextRecordingAfter:         nextRecordingAfter,
 			OldestHistoricalAt:         oldestHistoricalAt,
 			RepositoryCriteria:         series.RepositoryScope.RepositoryCriteria,
+			RevisionPattern:            revisionPattern(series.TimeScope),
 		})
 		if err != nil {
 			return errors.Wrap(err, "CreateSeries")
@@ -1398,7 +1472,22 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 	return nil
 }
 
+// revisionPattern returns the tag pattern of a series that is sampled at tags, or nil for series
+// sampled at time intervals.
+func revisionPattern(timeScope *graphqlbackend.TimeScopeInput) *string {
+	if timeScope == nil || timeScope.Revisions == nil {
+		return nil
+	}
+	return &timeScope.Revisions.Pattern
+}
+
 func searchGenerationMethod(series graphqlbackend.LineChartSearchInsightDataSeriesInput) types.GenerationMethod {
+	if isPreciseCodeIntelSeries(series.GeneratedFromPreciseCodeIntel) {
+		return types.PreciseCodeIntel
+	}
+	if isOwnerGroupBySeries(series.GroupBy) {
+		return types.SearchOwners
+	}
 	if series.GeneratedFromCaptureGroups != nil && *series.GeneratedFromCaptureGroups {
 		if series.GroupBy != nil {
 			return types.MappingCompute
@@ -1617,9 +1706,35 @@ func isValidSeriesInput(seriesInput graphqlbackend.LineChartSearchInsightDataSer
 	if repoListSpecified && repoCriteriaSpecified {
 		return errors.New("series can not specify both a repository list and repository critieria")
 	}
-	if !repoListSpecified && seriesInput.GroupBy != nil {
+	if !repoListSpecified && seriesInput.GroupBy != nil && !isOwnerGroupBySeries(seriesInput.GroupBy) {
 		return errors.New("group by series require a list of repositories to be specified.")
 	}
+	if isOwnerGroupBySeries(seriesInput.GroupBy) && isCaptureGroupSeries(seriesInput.GeneratedFromCaptureGroups) {
+		return errors.New("series grouped by owner can not be generated from capture groups")
+	}
+	if isPreciseCodeIntelSeries(seriesInput.GeneratedFromPreciseCodeIntel) {
+		if seriesInput.GroupBy != nil || isCaptureGroupSeries(seriesInput.GeneratedFromCaptureGroups) {
+			return errors.New("series generated from precise code intelligence can not be grouped or generated from capture groups")
+		}
+		if repoCriteriaSpecified {
+			return errors.New("series generated from precise code intelligence require a list of repositories or no repository scope")
+		}
+	}
+
+	if revisions := seriesInput.TimeScope.Revisions; revisions != nil {
+		if seriesInput.TimeScope.StepInterval == nil {
+			return errors.New("series sampled at tags require a step interval, which sets how often new tags are looked for")
+		}
+		if len(seriesInput.RepositoryScope.Repositories) != 1 {
+			return errors.New("series sampled at tags require exactly one repository")
+		}
+		if seriesInput.GroupBy != nil || isCaptureGroupSeries(seriesInput.GeneratedFromCaptureGroups) || isPreciseCodeIntelSeries(seriesInput.GeneratedFromPreciseCodeIntel) {
+			return errors.New("series sampled at tags can not be grouped or generated from capture groups or precise code intelligence")
+		}
+		if _, err := path.Match(revisions.Pattern, ""); err != nil || revisions.Pattern == "" {
+			return errors.Newf("invalid tag pattern %q", revisions.Pattern)
+		}
+	}
 
 	if repoCriteriaSpecified {
 		plan, err := querybuilder.ParseQuery(*seriesInput.RepositoryScope.RepositoryCriteria, "literal")
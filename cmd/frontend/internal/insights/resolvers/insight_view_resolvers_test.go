@@ -3,6 +3,7 @@ package resolvers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"testing"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
 )
 
 func TestFrozenInsightDataSeriesResolver(t *testing.T) {
@@ -400,6 +402,20 @@ func TestRemoveClosePoints(t *testing.T) {
 				getPoint(7, 2, 2, 8),
 			},
 		},
+		{
+			name:   "test revisions",
+			series: types.InsightViewSeries{SampleIntervalUnit: string(types.Day), SampleIntervalValue: 1, RevisionPattern: pointers.Ptr("v*")},
+			points: []store.SeriesPoint{
+				getPoint(4, 15, 2, 0),
+				getPoint(4, 15, 2, 5),
+				getPoint(4, 16, 2, 0),
+			},
+			want: []store.SeriesPoint{
+				getPoint(4, 15, 2, 0),
+				getPoint(4, 15, 2, 5),
+				getPoint(4, 16, 2, 0),
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -485,3 +501,49 @@ func TestInsightRepoScopeResolver(t *testing.T) {
 	}
 
 }
+
+func TestIsValidSeriesInputRevisions(t *testing.T) {
+	stepInterval := &graphqlbackend.TimeIntervalStepInput{Unit: string(types.Day), Value: 1}
+	input := func(repos []string, revisions string, stepInterval *graphqlbackend.TimeIntervalStepInput) graphqlbackend.LineChartSearchInsightDataSeriesInput {
+		return graphqlbackend.LineChartSearchInsightDataSeriesInput{
+			Query:           "TODO",
+			RepositoryScope: &graphqlbackend.RepositoryScopeInput{Repositories: repos},
+			TimeScope: &graphqlbackend.TimeScopeInput{
+				StepInterval: stepInterval,
+				Revisions:    &graphqlbackend.RevisionsTimeScopeInput{Pattern: revisions},
+			},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		input graphqlbackend.LineChartSearchInsightDataSeriesInput
+		want  autogold.Value
+	}{
+		{
+			name:  "valid",
+			input: input([]string{"github.com/sourcegraph/sourcegraph"}, "v*", stepInterval),
+			want:  autogold.Expect("<nil>"),
+		},
+		{
+			name:  "no step interval",
+			input: input([]string{"github.com/sourcegraph/sourcegraph"}, "v*", nil),
+			want:  autogold.Expect("series sampled at tags require a step interval, which sets how often new tags are looked for"),
+		},
+		{
+			name:  "several repositories",
+			input: input([]string{"github.com/sourcegraph/sourcegraph", "github.com/sourcegraph/zoekt"}, "v*", stepInterval),
+			want:  autogold.Expect("series sampled at tags require exactly one repository"),
+		},
+		{
+			name:  "invalid pattern",
+			input: input([]string{"github.com/sourcegraph/sourcegraph"}, "v[", stepInterval),
+			want:  autogold.Expect(`invalid tag pattern "v["`),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.want.Equal(t, fmt.Sprint(isValidSeriesInput(test.input)))
+		})
+	}
+}
@@ -197,12 +197,21 @@ func isValidPreviewArgs(args graphqlbackend.SearchInsightPreviewArgs) error {
 	if args.Input.TimeScope.StepInterval == nil {
 		return &livePreviewError{Code: invalidArgsErrorCode, Message: "live preview currently only supports a time interval time scope"}
 	}
+	if args.Input.TimeScope.Revisions != nil {
+		return &livePreviewError{Code: invalidArgsErrorCode, Message: "live preview is not supported for series sampled at tags"}
+	}
 	hasRepoCriteria := args.Input.RepositoryScope.RepositoryCriteria != nil
 	// Error if both are provided
 	if hasRepoCriteria && len(args.Input.RepositoryScope.Repositories) > 0 {
 		return &livePreviewError{Code: invalidArgsErrorCode, Message: "can not specify both a repository list and a repository search"}
 	}
 
+	for i := 0; i < len(args.Input.Series); i++ {
+		if isOwnerGroupBySeries(args.Input.Series[i].GroupBy) {
+			return &livePreviewError{Code: invalidArgsErrorCode, Message: "live preview is not supported for series grouped by owner"}
+		}
+	}
+
 	if hasRepoCriteria {
 		for i := 0; i < len(args.Input.Series); i++ {
 			if args.Input.Series[i].GroupBy != nil {
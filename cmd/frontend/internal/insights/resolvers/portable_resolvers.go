@@ -0,0 +1,539 @@
+package resolvers
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/insights/portable"
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+func (r *Resolver) ExportInsightsDashboards(ctx context.Context, args *graphqlbackend.ExportInsightsDashboardsArgs) (string, error) {
+	permissionsValidator := PermissionsValidatorFromBase(&r.baseInsightResolver)
+
+	doc := &portable.Document{Version: portable.CurrentVersion, Dashboards: []portable.Dashboard{}}
+	for _, id := range args.Dashboards {
+		dashboardID, err := unmarshalDashboardID(id)
+		if err != nil {
+			return "", errors.Wrap(err, "unable to unmarshal dashboard id")
+		}
+		if !dashboardID.isReal() {
+			return "", errors.New("unable to export a virtualized dashboard")
+		}
+		// 🚨 SECURITY: only dashboards visible to the user can be exported.
+		if err := permissionsValidator.validateUserAccessForDashboard(ctx, int(dashboardID.Arg)); err != nil {
+			return "", err
+		}
+
+		dashboards, err := r.dashboardStore.GetDashboards(ctx, store.DashboardQueryArgs{
+			IDs:     []int{int(dashboardID.Arg)},
+			UserIDs: permissionsValidator.userIds,
+			OrgIDs:  permissionsValidator.orgIds,
+		})
+		if err != nil {
+			return "", errors.Wrap(err, "GetDashboards")
+		} else if len(dashboards) < 1 {
+			return "", errors.New("dashboard not found")
+		}
+
+		viewSeries, err := r.insightStore.GetAllOnDashboard(ctx, store.InsightsOnDashboardQueryArgs{DashboardID: dashboards[0].ID})
+		if err != nil {
+			return "", errors.Wrap(err, "GetAllOnDashboard")
+		}
+		views := r.insightStore.GroupByView(ctx, viewSeries)
+		sort.Slice(views, func(i, j int) bool {
+			return views[i].DashboardViewId < views[j].DashboardViewId
+		})
+
+		exported := portable.Dashboard{Title: dashboards[0].Title}
+		for _, view := range views {
+			exported.Insights = append(exported.Insights, exportInsight(view))
+		}
+		doc.Dashboards = append(doc.Dashboards, exported)
+	}
+
+	raw, err := portable.Marshal(doc, portable.Format(args.Format))
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+func exportInsight(insight types.Insight) portable.Insight {
+	exported := portable.Insight{
+		ID:          insight.UniqueID,
+		Title:       insight.Title,
+		Description: insight.Description,
+	}
+
+	if insight.PresentationType == types.Pie {
+		exported.Type = portable.PieChart
+		exported.OtherThreshold = insight.OtherThreshold
+		for _, series := range insight.Series {
+			exported.Series = append(exported.Series, portable.Series{
+				Query:        series.Query,
+				Repositories: series.Repositories,
+			})
+		}
+		return exported
+	}
+
+	exported.Type = portable.LineChart
+	if insight.Filters.IncludeRepoRegex != nil || insight.Filters.ExcludeRepoRegex != nil || len(insight.Filters.SearchContexts) > 0 {
+		exported.Filters = &portable.Filters{
+			IncludeRepoRegex: emptyIfNil(insight.Filters.IncludeRepoRegex),
+			ExcludeRepoRegex: emptyIfNil(insight.Filters.ExcludeRepoRegex),
+			SearchContexts:   insight.Filters.SearchContexts,
+		}
+	}
+	if opts := insight.SeriesOptions; opts.SortOptions != nil || opts.Limit != nil || opts.NumSamples != nil {
+		exported.SeriesDisplayOptions = &portable.SeriesDisplayOptions{
+			Limit:      opts.Limit,
+			NumSamples: opts.NumSamples,
+		}
+		if opts.SortOptions != nil {
+			exported.SeriesDisplayOptions.SortMode = opts.SortOptions.Mode
+			exported.SeriesDisplayOptions.SortDirection = opts.SortOptions.Direction
+		}
+	}
+	for _, series := range insight.Series {
+		exportedSeries := portable.Series{
+			Query:                         series.Query,
+			Label:                         series.Label,
+			Color:                         series.LineColor,
+			Repositories:                  series.Repositories,
+			RepositoryCriteria:            emptyIfNil(series.RepositoryCriteria),
+			TimeScope:                     &portable.TimeScope{Unit: types.IntervalUnit(series.SampleIntervalUnit), Value: int32(series.SampleIntervalValue), Revisions: emptyIfNil(series.RevisionPattern)},
+			GeneratedFromCaptureGroups:    series.GeneratedFromCaptureGroups,
+			GroupBy:                       emptyIfNil(series.GroupBy),
+			GeneratedFromPreciseCodeIntel: series.GenerationMethod == types.PreciseCodeIntel,
+		}
+		if series.GenerationMethod == types.SearchOwners {
+			// Series grouped by owner are dynamic, which is implied by the group by.
+			exportedSeries.GeneratedFromCaptureGroups = false
+			exportedSeries.GroupBy = ownerGroupBy
+		}
+		exported.Series = append(exported.Series, exportedSeries)
+	}
+	return exported
+}
+
+// errDryRun is used to roll back the transaction of a dry run import.
+var errDryRun = errors.New("dry run")
+
+func (r *Resolver) ImportInsightsDashboards(ctx context.Context, args *graphqlbackend.ImportInsightsDashboardsArgs) (_ graphqlbackend.ImportInsightsDashboardsPayloadResolver, err error) {
+	doc, err := portable.Parse([]byte(args.Input.Document))
+	if err != nil {
+		return nil, err
+	}
+	if licenseError := licensing.Check(licensing.FeatureCodeInsights); licenseError != nil {
+		return nil, errors.New("Cannot import insights in Limited Access Mode.")
+	}
+
+	uid := actor.FromContext(ctx).UID
+	if uid == 0 {
+		return nil, errors.New("must be authenticated to import insights")
+	}
+	permissionsValidator := PermissionsValidatorFromBase(&r.baseInsightResolver)
+	if err := permissionsValidator.loadUserContext(ctx); err != nil {
+		return nil, err
+	}
+
+	dashboardGrants := []store.DashboardGrant{store.UserDashboardGrant(int(uid))}
+	if args.Input.Grants != nil {
+		dashboardGrants, err = parseDashboardGrants(*args.Input.Grants)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to parse dashboard grants")
+		}
+		if len(dashboardGrants) == 0 {
+			return nil, errors.New("dashboards must be created with at least one grant")
+		}
+	}
+	if !hasPermissionForGrants(dashboardGrants, permissionsValidator.userIds, permissionsValidator.orgIds) {
+		return nil, errors.New("user does not have permission to create dashboards with these grants")
+	}
+
+	// Group by values are case-insensitive, but stored in lowercase.
+	for i := range doc.Dashboards {
+		for j := range doc.Dashboards[i].Insights {
+			for k := range doc.Dashboards[i].Insights[j].Series {
+				series := &doc.Dashboards[i].Insights[j].Series[k]
+				series.GroupBy = strings.ToLower(series.GroupBy)
+			}
+		}
+	}
+
+	// Validate the series against this instance before creating anything.
+	for _, dashboard := range doc.Dashboards {
+		for _, insight := range dashboard.Insights {
+			if err := r.validateImportedInsight(ctx, insight); err != nil {
+				return nil, errors.Wrapf(err, "insight %q", insight.ID)
+			}
+		}
+	}
+
+	tx, err := r.insightStore.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err == nil && args.Input.DryRun {
+			// Roll back all changes made by a dry run. Nothing was committed, even if the rollback failed.
+			if err = tx.Done(errDryRun); errors.Is(err, errDryRun) {
+				err = nil
+			}
+			return
+		}
+		err = tx.Done(err)
+	}()
+	dashboardTx := r.dashboardStore.With(tx)
+	txValidator := permissionsValidator.WithBaseStore(tx.Store)
+
+	fillSeries := makeFillSeriesStrategy(tx, r.scheduler, r.insightEnqueuer)
+	if args.Input.DryRun {
+		// Backfills are queued outside of the transaction, so they must not be started by a dry run.
+		fillSeries = func(context.Context, types.InsightSeries) error { return nil }
+	}
+
+	// Dashboards have no stable identifier across instances, so existing dashboards are matched by title.
+	visibleDashboards, err := dashboardTx.GetDashboards(ctx, store.DashboardQueryArgs{UserIDs: txValidator.userIds, OrgIDs: txValidator.orgIds})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetDashboards")
+	}
+	dashboardsByTitle := make(map[string]int, len(visibleDashboards))
+	for _, dashboard := range visibleDashboards {
+		if _, ok := dashboardsByTitle[dashboard.Title]; !ok {
+			dashboardsByTitle[dashboard.Title] = dashboard.ID
+		}
+	}
+
+	// Created insights get the grants of every dashboard they are imported into, so that they are
+	// visible to everyone who can see these dashboards.
+	insightGrants := make(map[string][]store.InsightViewGrant)
+	for _, dashboard := range doc.Dashboards {
+		grants := dashboardGrants
+		if dashboardID, ok := dashboardsByTitle[dashboard.Title]; ok {
+			existingGrants, err := dashboardTx.GetDashboardGrants(ctx, dashboardID)
+			if err != nil {
+				return nil, errors.Wrap(err, "GetDashboardGrants")
+			}
+			grants = make([]store.DashboardGrant, 0, len(existingGrants))
+			for _, grant := range existingGrants {
+				grants = append(grants, *grant)
+			}
+		}
+		for _, insight := range dashboard.Insights {
+			insightGrants[insight.ID] = appendViewGrants(insightGrants[insight.ID], grants)
+		}
+	}
+
+	payload := &importInsightsDashboardsPayloadResolver{dryRun: args.Input.DryRun, baseInsightResolver: r.baseInsightResolver}
+	imported := make(map[string]struct{})
+	var dashboardIDs []int
+	for _, dashboard := range doc.Dashboards {
+		dashboardID, ok := dashboardsByTitle[dashboard.Title]
+		if !ok {
+			created, err := dashboardTx.CreateDashboard(ctx, store.CreateDashboardArgs{
+				Dashboard: types.Dashboard{Title: dashboard.Title, Save: true},
+				Grants:    dashboardGrants,
+				UserIDs:   txValidator.userIds,
+				OrgIDs:    txValidator.orgIds,
+			})
+			if err != nil {
+				return nil, errors.Wrap(err, "CreateDashboard")
+			}
+			if created == nil {
+				return nil, errors.Newf("dashboard %q is not visible with the given grants", dashboard.Title)
+			}
+			dashboardID = created.ID
+			dashboardsByTitle[dashboard.Title] = dashboardID
+			payload.createdDashboards++
+		}
+
+		viewIDs := make([]string, 0, len(dashboard.Insights))
+		for _, insight := range dashboard.Insights {
+			viewIDs = append(viewIDs, insight.ID)
+			if _, ok := imported[insight.ID]; ok {
+				continue
+			}
+			imported[insight.ID] = struct{}{}
+
+			result, err := r.importInsight(ctx, tx, txValidator, fillSeries, insight, insightGrants[insight.ID])
+			if err != nil {
+				return nil, errors.Wrapf(err, "insight %q", insight.ID)
+			}
+			switch result {
+			case insightCreated:
+				payload.createdInsights++
+			case insightUpdated:
+				payload.updatedInsights++
+			default:
+				payload.unchangedInsights++
+			}
+		}
+		// Insights that are already on the dashboard are ignored.
+		if err := dashboardTx.AddViewsToDashboard(ctx, dashboardID, viewIDs); err != nil {
+			return nil, errors.Wrap(err, "AddViewsToDashboard")
+		}
+		dashboardIDs = append(dashboardIDs, dashboardID)
+	}
+
+	if !args.Input.DryRun && len(dashboardIDs) > 0 {
+		payload.dashboards, err = dashboardTx.GetDashboards(ctx, store.DashboardQueryArgs{IDs: dashboardIDs, UserIDs: txValidator.userIds, OrgIDs: txValidator.orgIds})
+		if err != nil {
+			return nil, errors.Wrap(err, "GetDashboards")
+		}
+	}
+	return payload, nil
+}
+
+func (r *Resolver) validateImportedInsight(ctx context.Context, insight portable.Insight) error {
+	for _, series := range insight.Series {
+		if insight.Type == portable.LineChart {
+			if err := isValidSeriesInput(lineChartSeriesInput(series)); err != nil {
+				return err
+			}
+		}
+		if len(series.Repositories) > 0 {
+			if err := validateRepositoryList(ctx, series.Repositories, r.postgresDB.Repos()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type importResult int
+
+const (
+	insightUnchanged importResult = iota
+	insightCreated
+	insightUpdated
+)
+
+// importInsight creates the given insight with the given grants, or updates the existing insight with
+// the same unique ID if its definition differs from the given one.
+func (r *Resolver) importInsight(ctx context.Context, tx *store.InsightStore, validator *InsightPermissionsValidator, fillSeries fillSeriesStrategy, insight portable.Insight, grants []store.InsightViewGrant) (importResult, error) {
+	existing, err := tx.GetMapped(ctx, store.InsightQueryArgs{UniqueID: insight.ID, WithoutAuthorization: true})
+	if err != nil {
+		return 0, errors.Wrap(err, "GetMapped")
+	}
+
+	if len(existing) == 0 {
+		view, err := tx.CreateView(ctx, importedView(insight), grants)
+		if err != nil {
+			return 0, errors.Wrap(err, "CreateView")
+		}
+
+		if insight.Type == portable.PieChart {
+			if err := createAndAttachPieChartSeries(ctx, tx, view, insight.Series[0].Query, insight.Series[0].Repositories); err != nil {
+				return 0, err
+			}
+			return insightCreated, nil
+		}
+
+		// Views are created without their series display options.
+		if insight.SeriesDisplayOptions != nil {
+			if view, err = tx.UpdateView(ctx, view); err != nil {
+				return 0, errors.Wrap(err, "UpdateView")
+			}
+		}
+		for _, series := range insight.Series {
+			if err := createAndAttachSeries(ctx, tx, fillSeries, view, lineChartSeriesInput(series)); err != nil {
+				return 0, errors.Wrap(err, "createAndAttachSeries")
+			}
+		}
+		return insightCreated, nil
+	}
+
+	// 🚨 SECURITY: an existing insight can only be updated by users who can see it.
+	if err := validator.validateUserAccessForView(ctx, insight.ID); err != nil {
+		return 0, err
+	}
+	if exportInsight(existing[0]).Equal(insight) {
+		return insightUnchanged, nil
+	}
+	if (existing[0].PresentationType == types.Pie) != (insight.Type == portable.PieChart) {
+		return 0, errors.New("the type of an existing insight can not be changed")
+	}
+
+	view, err := tx.UpdateView(ctx, importedView(insight))
+	if err != nil {
+		return 0, errors.Wrap(err, "UpdateView")
+	}
+
+	if insight.Type == portable.PieChart {
+		if len(existing[0].Series) == 0 {
+			return 0, errors.New("No matching series found for this view. The view data may be corrupted.")
+		}
+		err := tx.UpdateFrontendSeries(ctx, store.UpdateFrontendSeriesArgs{
+			SeriesID:         existing[0].Series[0].SeriesID,
+			Query:            insight.Series[0].Query,
+			Repositories:     insight.Series[0].Repositories,
+			StepIntervalUnit: string(types.Month),
+		})
+		if err != nil {
+			return 0, errors.Wrap(err, "UpdateSeries")
+		}
+		return insightUpdated, nil
+	}
+
+	inputs := make([]graphqlbackend.LineChartSearchInsightDataSeriesInput, 0, len(insight.Series))
+	captureGroupInsight := false
+	for _, series := range insight.Series {
+		inputs = append(inputs, lineChartSeriesInput(series))
+		captureGroupInsight = captureGroupInsight || series.GeneratedFromCaptureGroups
+	}
+	// Keep the existing series, and their data, for every series whose definition did not change.
+	used := make(map[string]struct{}, len(existing[0].Series))
+	for i := range inputs {
+		for _, series := range existing[0].Series {
+			if _, ok := used[series.SeriesID]; ok || existingSeriesHasChanged(inputs[i], series) {
+				continue
+			}
+			used[series.SeriesID] = struct{}{}
+			seriesID := series.SeriesID
+			inputs[i].SeriesId = &seriesID
+			break
+		}
+	}
+
+	if captureGroupInsight {
+		if err := updateCaptureGroupInsight(ctx, inputs[0], existing[0].Series, view, tx, fillSeries); err != nil {
+			return 0, errors.Wrap(err, "updateCaptureGroupInsight")
+		}
+	} else {
+		if err := updateSearchOrComputeInsight(ctx, graphqlbackend.UpdateLineChartSearchInsightInput{DataSeries: inputs}, existing[0].Series, view, tx, fillSeries); err != nil {
+			return 0, errors.Wrap(err, "updateSearchOrComputeInsight")
+		}
+	}
+	return insightUpdated, nil
+}
+
+// appendViewGrants appends an insight view grant for each of the given dashboard grants that grants
+// doesn't contain yet.
+func appendViewGrants(grants []store.InsightViewGrant, dashboardGrants []store.DashboardGrant) []store.InsightViewGrant {
+	for _, dashboardGrant := range dashboardGrants {
+		grant := store.InsightViewGrant{UserID: dashboardGrant.UserID, OrgID: dashboardGrant.OrgID, Global: dashboardGrant.Global}
+		if !slices.ContainsFunc(grants, func(other store.InsightViewGrant) bool { return sameViewGrant(grant, other) }) {
+			grants = append(grants, grant)
+		}
+	}
+	return grants
+}
+
+func sameViewGrant(a, b store.InsightViewGrant) bool {
+	return pointers.Deref(a.UserID, 0) == pointers.Deref(b.UserID, 0) &&
+		pointers.Deref(a.OrgID, 0) == pointers.Deref(b.OrgID, 0) &&
+		pointers.Deref(a.Global, false) == pointers.Deref(b.Global, false)
+}
+
+func importedView(insight portable.Insight) types.InsightView {
+	view := types.InsightView{
+		Title:       insight.Title,
+		Description: insight.Description,
+		UniqueID:    insight.ID,
+	}
+
+	if insight.Type == portable.PieChart {
+		view.PresentationType = types.Pie
+		view.OtherThreshold = insight.OtherThreshold
+		return view
+	}
+
+	view.PresentationType = types.Line
+	if filters := insight.Filters; filters != nil {
+		view.Filters = types.InsightViewFilters{
+			IncludeRepoRegex: nilIfEmpty(filters.IncludeRepoRegex),
+			ExcludeRepoRegex: nilIfEmpty(filters.ExcludeRepoRegex),
+			SearchContexts:   filters.SearchContexts,
+		}
+	}
+	if opts := insight.SeriesDisplayOptions; opts != nil {
+		if opts.SortMode != "" && opts.SortDirection != "" {
+			view.SeriesSortMode = &opts.SortMode
+			view.SeriesSortDirection = &opts.SortDirection
+		}
+		view.SeriesLimit = opts.Limit
+		view.SeriesNumSamples = opts.NumSamples
+	}
+	return view
+}
+
+func lineChartSeriesInput(series portable.Series) graphqlbackend.LineChartSearchInsightDataSeriesInput {
+	label, color := series.Label, series.Color
+	input := graphqlbackend.LineChartSearchInsightDataSeriesInput{
+		Query: series.Query,
+		RepositoryScope: &graphqlbackend.RepositoryScopeInput{
+			Repositories:       series.Repositories,
+			RepositoryCriteria: nilIfEmpty(series.RepositoryCriteria),
+		},
+		Options: graphqlbackend.LineChartDataSeriesOptionsInput{
+			Label:     &label,
+			LineColor: &color,
+		},
+		GroupBy: nilIfEmpty(series.GroupBy),
+	}
+	if series.TimeScope != nil {
+		input.TimeScope = &graphqlbackend.TimeScopeInput{StepInterval: &graphqlbackend.TimeIntervalStepInput{
+			Unit:  string(series.TimeScope.Unit),
+			Value: series.TimeScope.Value,
+		}}
+		if series.TimeScope.Revisions != "" {
+			input.TimeScope.Revisions = &graphqlbackend.RevisionsTimeScopeInput{Pattern: series.TimeScope.Revisions}
+		}
+	}
+	if series.GeneratedFromCaptureGroups {
+		input.GeneratedFromCaptureGroups = &series.GeneratedFromCaptureGroups
+	}
+	if series.GeneratedFromPreciseCodeIntel {
+		input.GeneratedFromPreciseCodeIntel = &series.GeneratedFromPreciseCodeIntel
+	}
+	return input
+}
+
+func nilIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+type importInsightsDashboardsPayloadResolver struct {
+	dryRun            bool
+	dashboards        []*types.Dashboard
+	createdDashboards int32
+	createdInsights   int32
+	updatedInsights   int32
+	unchangedInsights int32
+
+	baseInsightResolver
+}
+
+func (p *importInsightsDashboardsPayloadResolver) DryRun() bool { return p.dryRun }
+
+func (p *importInsightsDashboardsPayloadResolver) Dashboards(ctx context.Context) ([]graphqlbackend.InsightsDashboardResolver, error) {
+	resolvers := make([]graphqlbackend.InsightsDashboardResolver, 0, len(p.dashboards))
+	for _, dashboard := range p.dashboards {
+		id := newRealDashboardID(int64(dashboard.ID))
+		resolvers = append(resolvers, &insightsDashboardResolver{dashboard: dashboard, id: &id, baseInsightResolver: p.baseInsightResolver})
+	}
+	return resolvers, nil
+}
+
+func (p *importInsightsDashboardsPayloadResolver) CreatedDashboards() int32 {
+	return p.createdDashboards
+}
+func (p *importInsightsDashboardsPayloadResolver) CreatedInsights() int32 { return p.createdInsights }
+func (p *importInsightsDashboardsPayloadResolver) UpdatedInsights() int32 { return p.updatedInsights }
+func (p *importInsightsDashboardsPayloadResolver) UnchangedInsights() int32 {
+	return p.unchangedInsights
+}
@@ -0,0 +1,21 @@
+package resolvers
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+)
+
+func TestAppendViewGrants(t *testing.T) {
+	var grants []store.InsightViewGrant
+	grants = appendViewGrants(grants, []store.DashboardGrant{store.UserDashboardGrant(1), store.OrgDashboardGrant(2)})
+	// A second dashboard shared with the same org and globally.
+	grants = appendViewGrants(grants, []store.DashboardGrant{store.OrgDashboardGrant(2), store.GlobalDashboardGrant()})
+
+	want := []store.InsightViewGrant{store.UserGrant(1), store.OrgGrant(2), store.GlobalGrant()}
+	if diff := cmp.Diff(want, grants); diff != "" {
+		t.Fatalf("unexpected grants (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,219 @@
+package resolvers
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/timeseries"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	breakdownOrderByLatestValue = "LATEST_VALUE"
+	breakdownOrderByChange      = "CHANGE"
+)
+
+var _ graphqlbackend.InsightRepositoryBreakdownConnectionResolver = &repositoryBreakdownConnectionResolver{}
+var _ graphqlbackend.InsightRepositorySeriesResolver = &insightRepositorySeriesResolver{}
+
+// repoBreakdownLoader loads the per-repository series behind a series.
+type repoBreakdownLoader interface {
+	// Breakdown returns a page of the repositories of the series and the total number of repositories.
+	Breakdown(ctx context.Context, opts store.RepoSeriesBreakdownOpts) ([]store.RepoSeriesSummary, int, error)
+	// Points returns the points of the given repositories.
+	Points(ctx context.Context, repoIDs []api.RepoID) ([]store.RepoSeries, error)
+}
+
+// recordedRepoBreakdownLoader loads the per-repository series of a recorded series, with the same
+// filters and number of samples as the points of the series.
+type recordedRepoBreakdownLoader struct {
+	definition types.InsightViewSeries
+	filters    types.InsightViewFilters
+	options    types.SeriesDisplayOptions
+	r          *baseInsightResolver
+	capture    *string
+}
+
+func (l *recordedRepoBreakdownLoader) Breakdown(ctx context.Context, opts store.RepoSeriesBreakdownOpts) ([]store.RepoSeriesSummary, int, error) {
+	pointsOpts, err := l.pointsOpts(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return l.r.timeSeriesStore.RepoSeriesBreakdown(ctx, *pointsOpts, opts)
+}
+
+func (l *recordedRepoBreakdownLoader) Points(ctx context.Context, repoIDs []api.RepoID) ([]store.RepoSeries, error) {
+	pointsOpts, err := l.pointsOpts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pointsOpts.Included = repoIDs
+	return l.r.timeSeriesStore.RepoSeriesPoints(ctx, *pointsOpts)
+}
+
+func (l *recordedRepoBreakdownLoader) pointsOpts(ctx context.Context) (*store.SeriesPointsOpts, error) {
+	opts, err := getRecordedSeriesPointOpts(ctx, database.NewDBWith(log.Scoped("repoSeries"), l.r.postgresDB), l.r.timeSeriesStore, l.definition, l.filters, l.options)
+	if err != nil {
+		return nil, errors.Wrap(err, "getRecordedSeriesPointOpts")
+	}
+	opts.Capture = l.capture
+	return opts, nil
+}
+
+type repositoryBreakdownConnectionResolver struct {
+	args   *graphqlbackend.InsightRepositoryBreakdownArgs
+	series types.InsightViewSeries
+	loader repoBreakdownLoader
+
+	once    sync.Once
+	nodes   []*insightRepositorySeriesResolver
+	total   int
+	next    *int32
+	loadErr error
+}
+
+func (r *repositoryBreakdownConnectionResolver) Nodes(ctx context.Context) ([]graphqlbackend.InsightRepositorySeriesResolver, error) {
+	nodes, _, _, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]graphqlbackend.InsightRepositorySeriesResolver, 0, len(nodes))
+	for _, node := range nodes {
+		resolvers = append(resolvers, node)
+	}
+	return resolvers, nil
+}
+
+func (r *repositoryBreakdownConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	_, total, _, err := r.compute(ctx)
+	return int32(total), err
+}
+
+func (r *repositoryBreakdownConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	_, _, next, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlutil.EncodeIntCursor(next), nil
+}
+
+func (r *repositoryBreakdownConnectionResolver) compute(ctx context.Context) ([]*insightRepositorySeriesResolver, int, *int32, error) {
+	r.once.Do(func() {
+		if err := validateBreakdownArgs(r.args); err != nil {
+			r.loadErr = err
+			return
+		}
+		offset, err := graphqlutil.DecodeIntCursor(r.args.After)
+		if err != nil {
+			r.loadErr = errors.Wrap(err, "failed to decode cursor")
+			return
+		}
+		// Placeholder series, such as capture group series without any data yet, have no
+		// repositories.
+		if r.loader == nil {
+			return
+		}
+
+		// The repositories are ordered and paginated by the store, so only the points of the
+		// repositories of this page are loaded.
+		opts := store.RepoSeriesBreakdownOpts{
+			OrderByChange: r.args.OrderBy == breakdownOrderByChange,
+			Descending:    r.args.Descending,
+			Limit:         int(r.args.First),
+			Offset:        offset,
+		}
+		if r.args.ChangeWindow != nil {
+			opts.ChangeWindow = &timeseries.TimeInterval{Unit: types.IntervalUnit(r.args.ChangeWindow.Unit), Value: int(r.args.ChangeWindow.Value)}
+		}
+		summaries, total, err := r.loader.Breakdown(ctx, opts)
+		if err != nil {
+			r.loadErr = err
+			return
+		}
+		r.total = total
+
+		if len(summaries) > 0 {
+			repoIDs := make([]api.RepoID, 0, len(summaries))
+			for _, summary := range summaries {
+				repoIDs = append(repoIDs, summary.RepoID)
+			}
+			repoSeries, err := r.loader.Points(ctx, repoIDs)
+			if err != nil {
+				r.loadErr = err
+				return
+			}
+			r.nodes = breakdownNodes(summaries, repoSeries, r.series)
+		}
+		r.next = graphqlutil.NextOffset(offset, len(r.nodes), r.total)
+	})
+	return r.nodes, r.total, r.next, r.loadErr
+}
+
+func validateBreakdownArgs(args *graphqlbackend.InsightRepositoryBreakdownArgs) error {
+	if args.First < 0 {
+		return errors.New("first must be a non-negative integer")
+	}
+	switch args.OrderBy {
+	case breakdownOrderByLatestValue, breakdownOrderByChange:
+	default:
+		return errors.Newf("invalid order by: %s", args.OrderBy)
+	}
+	if args.ChangeWindow != nil {
+		window := timeseries.TimeInterval{Unit: types.IntervalUnit(args.ChangeWindow.Unit), Value: int(args.ChangeWindow.Value)}
+		if !window.IsValid() || window.Value < 1 {
+			return errors.New("invalid change window")
+		}
+	}
+	return nil
+}
+
+// breakdownNodes returns a resolver for each of the repositories, in the same order, with the
+// points of its series.
+func breakdownNodes(summaries []store.RepoSeriesSummary, repoSeries []store.RepoSeries, series types.InsightViewSeries) []*insightRepositorySeriesResolver {
+	pointsByRepo := make(map[api.RepoID][]store.SeriesPoint, len(repoSeries))
+	for _, rs := range repoSeries {
+		pointsByRepo[rs.RepoID] = rs.Points
+	}
+
+	nodes := make([]*insightRepositorySeriesResolver, 0, len(summaries))
+	for _, summary := range summaries {
+		nodes = append(nodes, &insightRepositorySeriesResolver{
+			repoName:    summary.RepoName,
+			latestValue: summary.LatestValue,
+			change:      summary.Change,
+			// The points of each repository match the points of the series as a whole.
+			points: removeClosePoints(pointsByRepo[summary.RepoID], series),
+		})
+	}
+	return nodes
+}
+
+type insightRepositorySeriesResolver struct {
+	repoName    string
+	latestValue float64
+	change      float64
+	points      []store.SeriesPoint
+}
+
+func (r *insightRepositorySeriesResolver) RepositoryName() string { return r.repoName }
+
+func (r *insightRepositorySeriesResolver) LatestValue() float64 { return r.latestValue }
+
+func (r *insightRepositorySeriesResolver) Change() float64 { return r.change }
+
+func (r *insightRepositorySeriesResolver) Points() []graphqlbackend.InsightsDataPointResolver {
+	resolvers := make([]graphqlbackend.InsightsDataPointResolver, 0, len(r.points))
+	for _, point := range r.points {
+		// Diff searches are only available for the series as a whole.
+		resolvers = append(resolvers, insightsDataPointResolver{p: point})
+	}
+	return resolvers
+}
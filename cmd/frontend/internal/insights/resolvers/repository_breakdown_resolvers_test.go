@@ -0,0 +1,148 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/timeseries"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+)
+
+// fakeBreakdownLoader returns a page of its summaries, and records the options and repositories
+// that it was called with.
+type fakeBreakdownLoader struct {
+	summaries  []store.RepoSeriesSummary
+	repoSeries []store.RepoSeries
+
+	opts    store.RepoSeriesBreakdownOpts
+	repoIDs []api.RepoID
+}
+
+func (f *fakeBreakdownLoader) Breakdown(_ context.Context, opts store.RepoSeriesBreakdownOpts) ([]store.RepoSeriesSummary, int, error) {
+	f.opts = opts
+	start := opts.Offset
+	if start > len(f.summaries) {
+		start = len(f.summaries)
+	}
+	end := start + opts.Limit
+	if end > len(f.summaries) {
+		end = len(f.summaries)
+	}
+	return f.summaries[start:end], len(f.summaries), nil
+}
+
+func (f *fakeBreakdownLoader) Points(_ context.Context, repoIDs []api.RepoID) ([]store.RepoSeries, error) {
+	f.repoIDs = repoIDs
+	var repoSeries []store.RepoSeries
+	for _, rs := range f.repoSeries {
+		for _, id := range repoIDs {
+			if rs.RepoID == id {
+				repoSeries = append(repoSeries, rs)
+			}
+		}
+	}
+	return repoSeries, nil
+}
+
+func TestRepositoryBreakdown(t *testing.T) {
+	start := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
+	week := 7 * 24 * time.Hour
+	repoSeries := func(id api.RepoID, name string, values ...float64) store.RepoSeries {
+		rs := store.RepoSeries{RepoID: id, RepoName: name}
+		for i, value := range values {
+			rs.Points = append(rs.Points, store.SeriesPoint{SeriesID: "series", Time: start.Add(time.Duration(i) * week), Value: value})
+		}
+		return rs
+	}
+	newLoader := func() *fakeBreakdownLoader {
+		return &fakeBreakdownLoader{
+			summaries: []store.RepoSeriesSummary{
+				{RepoID: 1, RepoName: "a", LatestValue: 6, Change: 5},
+				{RepoID: 3, RepoName: "c", LatestValue: 6, Change: 6},
+				{RepoID: 2, RepoName: "b", LatestValue: 4, Change: -6},
+			},
+			repoSeries: []store.RepoSeries{
+				repoSeries(1, "a", 1, 5, 6),
+				repoSeries(2, "b", 10, 8, 4),
+				repoSeries(3, "c", 0, 0, 6),
+			},
+		}
+	}
+	series := types.InsightViewSeries{SampleIntervalUnit: string(types.Week), SampleIntervalValue: 1}
+
+	type result struct {
+		name          string
+		latest, delta float64
+	}
+	breakdown := func(t *testing.T, loader repoBreakdownLoader, args *graphqlbackend.InsightRepositoryBreakdownArgs) ([]result, *string) {
+		t.Helper()
+		ctx := context.Background()
+		resolver := &repositoryBreakdownConnectionResolver{args: args, series: series, loader: loader}
+		nodes, err := resolver.Nodes(ctx)
+		require.NoError(t, err)
+		total, err := resolver.TotalCount(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int32(3), total)
+		pageInfo, err := resolver.PageInfo(ctx)
+		require.NoError(t, err)
+
+		var results []result
+		for _, node := range nodes {
+			require.Len(t, node.Points(), 3)
+			results = append(results, result{name: node.RepositoryName(), latest: node.LatestValue(), delta: node.Change()})
+		}
+		return results, pageInfo.EndCursor()
+	}
+
+	t.Run("order", func(t *testing.T) {
+		loader := newLoader()
+		got, _ := breakdown(t, loader, &graphqlbackend.InsightRepositoryBreakdownArgs{
+			First:        50,
+			OrderBy:      breakdownOrderByChange,
+			ChangeWindow: &graphqlbackend.TimeIntervalStepInput{Unit: string(types.Week), Value: 1},
+		})
+		require.Equal(t, []result{{"a", 6, 5}, {"c", 6, 6}, {"b", 4, -6}}, got)
+		require.Equal(t, store.RepoSeriesBreakdownOpts{
+			OrderByChange: true,
+			ChangeWindow:  &timeseries.TimeInterval{Unit: types.Week, Value: 1},
+			Limit:         50,
+		}, loader.opts)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		loader := newLoader()
+		args := &graphqlbackend.InsightRepositoryBreakdownArgs{First: 2, OrderBy: breakdownOrderByLatestValue, Descending: true}
+		got, cursor := breakdown(t, loader, args)
+		require.Equal(t, []result{{"a", 6, 5}, {"c", 6, 6}}, got)
+		require.NotNil(t, cursor)
+		// Only the points of the repositories of the page are loaded.
+		require.Equal(t, []api.RepoID{1, 3}, loader.repoIDs)
+
+		args.After = cursor
+		got, cursor = breakdown(t, loader, args)
+		require.Equal(t, []result{{"b", 4, -6}}, got)
+		require.Nil(t, cursor)
+		require.Equal(t, store.RepoSeriesBreakdownOpts{Descending: true, Limit: 2, Offset: 2}, loader.opts)
+		require.Equal(t, []api.RepoID{2}, loader.repoIDs)
+	})
+
+	t.Run("invalid change window", func(t *testing.T) {
+		resolver := &repositoryBreakdownConnectionResolver{
+			args: &graphqlbackend.InsightRepositoryBreakdownArgs{
+				First:        50,
+				OrderBy:      breakdownOrderByChange,
+				ChangeWindow: &graphqlbackend.TimeIntervalStepInput{Unit: string(types.Week), Value: 0},
+			},
+			series: series,
+			loader: newLoader(),
+		}
+		_, err := resolver.Nodes(context.Background())
+		require.ErrorContains(t, err, "invalid change window")
+	})
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/perforce"
@@ -272,6 +273,40 @@ func TestGerritCloneURL(t *testing.T) {
 	}
 }
 
+func TestGiteaCloneURL(t *testing.T) {
+	logger := logtest.Scoped(t)
+	repo := &gitea.Repository{
+		FullName: "owner/repo",
+		CloneURL: "https://gitea.example.com/owner/repo.git",
+		SSHURL:   "git@gitea.example.com:owner/repo.git",
+	}
+
+	cfg := schema.GiteaConnection{
+		Url:   "https://gitea.example.com",
+		Token: "secret",
+	}
+
+	t.Run("ssh", func(t *testing.T) {
+		cfg.GitURLType = "ssh"
+
+		got := giteaCloneURL(logger, repo, &cfg)
+		want := "git@gitea.example.com:owner/repo.git"
+		if got != want {
+			t.Fatalf("wrong cloneURL, got: %q, want: %q", got, want)
+		}
+	})
+
+	t.Run("http", func(t *testing.T) {
+		cfg.GitURLType = "http"
+
+		got := giteaCloneURL(logger, repo, &cfg)
+		want := "https://secret@gitea.example.com/owner/repo.git"
+		if got != want {
+			t.Fatalf("wrong cloneURL, got: %q, want: %q", got, want)
+		}
+	})
+}
+
 func TestPerforceCloneURL(t *testing.T) {
 	cfg := schema.PerforceConnection{
 		P4Port:   "ssl:111.222.333.444:1666",
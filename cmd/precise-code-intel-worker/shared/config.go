@@ -11,11 +11,13 @@ import (
 type Config struct {
 	env.BaseConfig
 
-	WorkerPollInterval    time.Duration
-	WorkerConcurrency     int
-	WorkerBudget          int64
-	MaximumRuntimePerJob  time.Duration
-	LSIFUploadStoreConfig *lsifuploadstore.Config
+	WorkerPollInterval       time.Duration
+	WorkerConcurrency        int
+	WorkerBudget             int64
+	MaximumRuntimePerJob     time.Duration
+	StrictValidation         bool
+	ValidationErrorThreshold int
+	LSIFUploadStoreConfig    *lsifuploadstore.Config
 }
 
 func (c *Config) Load() {
@@ -26,6 +28,8 @@ func (c *Config) Load() {
 	c.WorkerConcurrency = c.GetInt("PRECISE_CODE_INTEL_WORKER_CONCURRENCY", "1", "The maximum number of indexes that can be processed concurrently.")
 	c.WorkerBudget = int64(c.GetInt("PRECISE_CODE_INTEL_WORKER_BUDGET", "0", "The amount of compressed input data (in bytes) a worker can process concurrently. Zero acts as an infinite budget."))
 	c.MaximumRuntimePerJob = c.GetInterval("PRECISE_CODE_INTEL_WORKER_MAXIMUM_RUNTIME_PER_JOB", "25m", "The maximum time a single LSIF processing job can take.")
+	c.StrictValidation = c.GetBool("PRECISE_CODE_INTEL_WORKER_STRICT_VALIDATION", "false", "Whether to fail uploads whose index contains more validation errors than allowed by PRECISE_CODE_INTEL_WORKER_VALIDATION_ERROR_THRESHOLD.")
+	c.ValidationErrorThreshold = c.GetInt("PRECISE_CODE_INTEL_WORKER_VALIDATION_ERROR_THRESHOLD", "0", "The number of validation errors an index may contain before it is rejected in strict validation mode.")
 }
 
 func (c *Config) Validate() error {
@@ -84,6 +84,8 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 		config.WorkerBudget,
 		config.WorkerPollInterval,
 		config.MaximumRuntimePerJob,
+		config.StrictValidation,
+		config.ValidationErrorThreshold,
 	)
 
 	// Initialize health server
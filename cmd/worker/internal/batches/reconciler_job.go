@@ -42,19 +42,23 @@ func (j *reconcilerJob) Routines(_ context.Context, observationCtx *observation.
 		return nil, err
 	}
 
+	gitClient := gitserver.NewClient("batches.reconciler")
+	sourcer := sources.NewSourcer(httpcli.NewExternalClientFactory(
+		httpcli.NewLoggingMiddleware(observationCtx.Logger.Scoped("sourcer")),
+	))
+
 	reconcilerWorker := workers.NewReconcilerWorker(
 		workCtx,
 		observationCtx,
 		bstore,
 		reconcilerStore,
-		gitserver.NewClient("batches.reconciler"),
-		sources.NewSourcer(httpcli.NewExternalClientFactory(
-			httpcli.NewLoggingMiddleware(observationCtx.Logger.Scoped("sourcer")),
-		)),
+		gitClient,
+		sourcer,
 	)
 
 	routines := []goroutine.BackgroundRoutine{
 		reconcilerWorker,
+		workers.NewChangesetRebaser(workCtx, observationCtx, bstore, gitClient, sourcer),
 	}
 
 	return routines, nil
@@ -6,8 +6,11 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/batches/scheduler"
+	"github.com/sourcegraph/sourcegraph/internal/batches/sources"
 	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
 
@@ -33,8 +36,19 @@ func (j *schedulerJob) Routines(_ context.Context, observationCtx *observation.C
 		return nil, err
 	}
 
+	sourcer := sources.NewSourcer(httpcli.NewExternalClientFactory(
+		httpcli.NewLoggingMiddleware(observationCtx.Logger.Scoped("sourcer")),
+	))
+
 	routines := []goroutine.BackgroundRoutine{
 		scheduler.NewScheduler(workCtx, bstore),
+		scheduler.NewAutoMerger(
+			workCtx,
+			observationCtx.Logger.Scoped("AutoMerger"),
+			bstore,
+			sourcer,
+			gitserver.NewClient("batches.automerger"),
+		),
 	}
 
 	return routines, nil
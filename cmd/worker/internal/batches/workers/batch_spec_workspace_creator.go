@@ -281,7 +281,16 @@ func (r *batchSpecWorkspaceCreator) process(
 
 		workspace.dbWorkspace.CachedResultFound = true
 
-		rawSpecs, err := cache.ChangesetSpecsFromCache(spec.Spec, workspace.repo, *res.Value, workspace.dbWorkspace.Path, true, changesetAuthor)
+		// Cache results are only ever found for a prefix of the steps, so the
+		// results of all steps up to the latest one are available here.
+		stepResults := []execution.AfterStepResult{*res.Value}
+		for _, c := range workspace.dbWorkspace.StepCacheResults {
+			if c.Value != nil && c.Value.StepIndex < res.Value.StepIndex {
+				stepResults = append(stepResults, *c.Value)
+			}
+		}
+
+		rawSpecs, err := cache.ChangesetSpecsFromStepResults(spec.Spec, workspace.repo, stepResults, workspace.dbWorkspace.Path, true, changesetAuthor)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,273 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/batches/rewrite"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/executor"
+	executorutil "github.com/sourcegraph/sourcegraph/internal/executor/util"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
+	"github.com/sourcegraph/sourcegraph/lib/batches/execution/cache"
+	"github.com/sourcegraph/sourcegraph/lib/batches/git"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewBatchSpecWorkspaceRewriteWorker creates a dbworker.newWorker that
+// executes the workspaces of batch specs that only consist of rewrite steps.
+// Those don't need a container, so they are executed directly against
+// gitserver instead of being handed out to executors.
+func NewBatchSpecWorkspaceRewriteWorker(
+	ctx context.Context,
+	observationCtx *observation.Context,
+	s *store.Store,
+	workerStore dbworkerstore.Store[*btypes.BatchSpecWorkspaceExecutionJob],
+	gitClient gitserver.Client,
+) *workerutil.Worker[*btypes.BatchSpecWorkspaceExecutionJob] {
+	r := &batchSpecWorkspaceRewriter{
+		store:           s,
+		workerStore:     workerStore,
+		gitserverClient: gitClient,
+	}
+
+	options := workerutil.WorkerOptions{
+		Name:              "batch_changes_batch_spec_workspace_rewrite_worker",
+		Description:       "executes the workspaces of batch specs that only consist of rewrite steps",
+		NumHandlers:       5,
+		Interval:          1 * time.Second,
+		HeartbeatInterval: 15 * time.Second,
+		Metrics:           workerutil.NewMetrics(observationCtx, "batch_changes_batch_spec_workspace_rewrite_worker"),
+	}
+
+	return dbworker.NewWorker[*btypes.BatchSpecWorkspaceExecutionJob](ctx, workerStore, r, options)
+}
+
+// batchSpecWorkspaceRewriter runs the rewrite steps of a workspace and
+// records their results in the execution logs of the job, in the same format
+// that batcheshelper uses on executors. Marking the job as complete then
+// builds the changeset specs from them.
+type batchSpecWorkspaceRewriter struct {
+	store           *store.Store
+	workerStore     dbworkerstore.Store[*btypes.BatchSpecWorkspaceExecutionJob]
+	gitserverClient gitserver.Client
+}
+
+var _ workerutil.Handler[*btypes.BatchSpecWorkspaceExecutionJob] = &batchSpecWorkspaceRewriter{}
+var _ workerutil.WithPreDequeue = &batchSpecWorkspaceRewriter{}
+
+// PreDequeue makes sure that only the jobs of batch specs without container
+// steps are dequeued.
+func (r *batchSpecWorkspaceRewriter) PreDequeue(_ context.Context, _ log.Logger) (bool, any, error) {
+	return true, []*sqlf.Query{store.ContainerlessBatchSpecWorkspaceExecutionJobsCondition()}, nil
+}
+
+func (r *batchSpecWorkspaceRewriter) Handle(ctx context.Context, logger log.Logger, job *btypes.BatchSpecWorkspaceExecutionJob) error {
+	workspace, err := r.store.GetBatchSpecWorkspace(ctx, store.GetBatchSpecWorkspaceOpts{ID: job.BatchSpecWorkspaceID})
+	if err != nil {
+		return errors.Wrapf(err, "fetching workspace %d", job.BatchSpecWorkspaceID)
+	}
+
+	batchSpec, err := r.store.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: workspace.BatchSpecID})
+	if err != nil {
+		return errors.Wrap(err, "fetching batch spec")
+	}
+
+	// 🚨 SECURITY: Set the actor on the context so we check for permissions
+	// when loading the repository and its contents.
+	ctx = actor.WithActor(ctx, actor.FromUser(job.UserID))
+
+	repo, err := r.store.Repos().Get(ctx, workspace.RepoID)
+	if err != nil {
+		return errors.Wrap(err, "fetching repo")
+	}
+
+	skippedSteps, err := batcheslib.SkippedStepsForRepo(batchSpec.Spec, string(repo.Name), workspace.FileMatches)
+	if err != nil {
+		return err
+	}
+
+	// Only the files that the steps could rewrite are loaded.
+	var rewriteSteps []*batcheslib.RewriteStep
+	for i, step := range batchSpec.Spec.Steps {
+		if _, ok := skippedSteps[i]; !ok && step.IsRewrite() {
+			rewriteSteps = append(rewriteSteps, step.Rewrite)
+		}
+	}
+	original, err := rewrite.LoadFiles(ctx, r.gitserverClient, repo.Name, api.CommitID(workspace.Commit), workspace.Path, rewriteSteps)
+	if err != nil {
+		return errors.Wrap(err, "loading workspace files")
+	}
+	current := make(rewrite.Files, len(original))
+	for name, content := range original {
+		current[name] = content
+	}
+
+	attrs := template.BatchChangeAttributes{
+		Name:        batchSpec.Spec.Name,
+		Description: batchSpec.Spec.Description,
+	}
+	cacheRepo := batcheslib.Repository{
+		ID:          string(marshalRepositoryID(repo.ID)),
+		Name:        string(repo.Name),
+		BaseRef:     workspace.Branch,
+		BaseRev:     workspace.Commit,
+		FileMatches: workspace.FileMatches,
+	}
+
+	// Rewrite steps are cheap compared to steps that run in a container, so
+	// unlike batcheshelper we don't start from cached step results but always
+	// run all steps.
+	var previous execution.AfterStepResult
+	for i, step := range batchSpec.Spec.Steps {
+		if _, ok := skippedSteps[i]; ok {
+			continue
+		}
+		if !step.IsRewrite() {
+			return errors.Newf("step %d is not a rewrite step", i+1)
+		}
+
+		changes, err := git.ChangesInDiff(previous.Diff)
+		if err != nil {
+			return errors.Wrap(err, "failed to compute changes")
+		}
+		outputs := make(map[string]any, len(previous.Outputs))
+		for k, v := range previous.Outputs {
+			outputs[k] = v
+		}
+		stepContext := template.StepContext{
+			BatchChange: attrs,
+			Repository: template.Repository{
+				Name:        string(repo.Name),
+				Branch:      workspace.Branch,
+				FileMatches: workspace.FileMatches,
+			},
+			Outputs: outputs,
+			Steps: template.StepsContext{
+				Path:    workspace.Path,
+				Changes: changes,
+			},
+			PreviousStep: previous,
+		}
+
+		cond, err := template.EvalStepCondition(step.IfCondition(), &stepContext)
+		if err != nil {
+			return errors.Wrap(err, "failed to evaluate step condition")
+		}
+		if !cond {
+			if err := r.addLogEntry(ctx, job, "step.rewrite."+executorutil.FormatPreKey(i), time.Now(), 0, nil, logEvent(
+				batcheslib.LogEventOperationTaskStepSkipped,
+				batcheslib.LogEventStatusProgress,
+				&batcheslib.TaskStepSkippedMetadata{Step: i + 1},
+			)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		start := time.Now()
+		changed, err := rewrite.Apply(ctx, logger, step.Rewrite, current)
+		if err != nil {
+			if logErr := r.addLogEntry(ctx, job, "step.rewrite."+executorutil.FormatRunKey(i), start, 1, []string{"stderr: " + err.Error()}); logErr != nil {
+				err = errors.Append(err, logErr)
+			}
+			return errors.Wrapf(err, "running step %d", i+1)
+		}
+
+		out := make([]string, 0, len(changed))
+		for _, name := range changed.Paths() {
+			current[name] = changed[name]
+			out = append(out, "stdout: rewrote "+name)
+		}
+		if err := r.addLogEntry(ctx, job, "step.rewrite."+executorutil.FormatRunKey(i), start, 0, out); err != nil {
+			return err
+		}
+
+		start = time.Now()
+		result := execution.AfterStepResult{
+			Version:   2,
+			StepIndex: i,
+			Diff:      rewrite.Diff(original, current),
+			Outputs:   make(map[string]any),
+		}
+		stepContext.Step = result
+		if err := batcheslib.SetOutputs(step.Outputs, outputs, &stepContext); err != nil {
+			return errors.Wrap(err, "setting outputs")
+		}
+		for k, v := range outputs {
+			result.Outputs[k] = v
+		}
+
+		key, err := cache.KeyForWorkspace(&attrs, cacheRepo, workspace.Path, nil, workspace.OnlyFetchWorkspace, batchSpec.Spec.Steps, i, nil).Key()
+		if err != nil {
+			return errors.Wrap(err, "failed to compute cache key")
+		}
+
+		if err := r.addLogEntry(ctx, job, "step.rewrite."+executorutil.FormatPostKey(i), start, 0, nil,
+			logEvent(
+				batcheslib.LogEventOperationTaskStep,
+				batcheslib.LogEventStatusSuccess,
+				&batcheslib.TaskStepMetadata{Version: 2, Step: i, Diff: result.Diff, Outputs: outputs},
+			),
+			logEvent(
+				batcheslib.LogEventOperationCacheAfterStepResult,
+				batcheslib.LogEventStatusSuccess,
+				&batcheslib.CacheAfterStepResultMetadata{Key: key, Value: result},
+			),
+		); err != nil {
+			return err
+		}
+
+		previous = result
+	}
+
+	return nil
+}
+
+// addLogEntry adds an execution log entry with the given output lines and
+// events to the job.
+func (r *batchSpecWorkspaceRewriter) addLogEntry(ctx context.Context, job *btypes.BatchSpecWorkspaceExecutionJob, key string, start time.Time, exitCode int, out []string, events ...batcheslib.LogEvent) error {
+	for _, e := range events {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return errors.Wrap(err, "failed to encode event")
+		}
+		out = append(out, "stdout: "+string(line))
+	}
+
+	duration := int(time.Since(start).Milliseconds())
+
+	_, err := r.workerStore.AddExecutionLogEntry(ctx, int(job.ID), executor.ExecutionLogEntry{
+		Key:        key,
+		Command:    []string{},
+		StartTime:  start,
+		ExitCode:   &exitCode,
+		Out:        strings.Join(out, "\n"),
+		DurationMs: &duration,
+	}, dbworkerstore.ExecutionLogEntryOptions{})
+	return errors.Wrapf(err, "adding execution log entry %q", key)
+}
+
+func logEvent(operation batcheslib.LogEventOperation, status batcheslib.LogEventStatus, metadata any) batcheslib.LogEvent {
+	return batcheslib.LogEvent{
+		Operation: operation,
+		Status:    status,
+		Timestamp: time.Now().UTC().Truncate(time.Millisecond),
+		Metadata:  metadata,
+	}
+}
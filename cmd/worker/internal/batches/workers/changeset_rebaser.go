@@ -0,0 +1,36 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/batches/reconciler"
+	"github.com/sourcegraph/sourcegraph/internal/batches/sources"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+const changesetRebaseInterval = 10 * time.Minute
+
+// NewChangesetRebaser creates a goroutine.PeriodicGoroutine that re-applies the
+// diffs of changesets of batch changes with auto-rebase enabled onto the latest
+// commit of their base branch.
+func NewChangesetRebaser(
+	ctx context.Context,
+	observationCtx *observation.Context,
+	s *store.Store,
+	gitClient gitserver.Client,
+	sourcer sources.Sourcer,
+) goroutine.BackgroundRoutine {
+	r := reconciler.NewRebaser(observationCtx.Logger.Scoped("ChangesetRebaser"), gitClient, sourcer, s)
+
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		goroutine.HandlerFunc(r.RebaseChangesets),
+		goroutine.WithName("batchchanges.changeset-rebaser"),
+		goroutine.WithDescription("re-applies changeset diffs onto moved base branches"),
+		goroutine.WithInterval(changesetRebaseInterval),
+	)
+}
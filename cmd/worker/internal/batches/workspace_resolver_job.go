@@ -7,6 +7,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
@@ -46,8 +47,22 @@ func (j *workspaceResolverJob) Routines(_ context.Context, observationCtx *obser
 		resStore,
 	)
 
+	execStore, err := InitBatchSpecWorkspaceExecutionWorkerStore()
+	if err != nil {
+		return nil, err
+	}
+
+	rewriteWorker := workers.NewBatchSpecWorkspaceRewriteWorker(
+		workCtx,
+		observationCtx,
+		bstore,
+		execStore,
+		gitserver.NewClient("batches.rewriter"),
+	)
+
 	routines := []goroutine.BackgroundRoutine{
 		resolverWorker,
+		rewriteWorker,
 	}
 
 	return routines, nil
@@ -0,0 +1,25 @@
+package insights
+
+import (
+	workercodeintel "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/codeintel"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/insights/background/queryrunner"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// initCodeIntelClient returns the client used to generate insight series from precise code
+// intelligence data.
+func initCodeIntelClient(observationCtx *observation.Context, db database.DB) (queryrunner.CodeIntelClient, error) {
+	services, err := workercodeintel.InitServices(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryrunner.NewCodeIntelClient(
+		services.CodenavService,
+		services.UploadsService,
+		db.Repos(),
+		gitserver.NewClient("insights.codeintel"),
+	), nil
+}
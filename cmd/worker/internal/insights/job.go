@@ -40,7 +40,12 @@ func (s *insightsJob) Routines(_ context.Context, observationCtx *observation.Co
 		return nil, err
 	}
 
-	return background.GetBackgroundJobs(context.Background(), observationCtx.Logger, db, insightsDB), nil
+	codeIntel, err := initCodeIntelClient(observationCtx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	return background.GetBackgroundJobs(context.Background(), observationCtx.Logger, db, insightsDB, codeIntel), nil
 }
 
 func NewInsightsJob() job.Job {
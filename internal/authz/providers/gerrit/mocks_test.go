@@ -22,6 +22,9 @@ type MockGerritClient struct {
 	// AbandonChangeFunc is an instance of a mock function object
 	// controlling the behavior of the method AbandonChange.
 	AbandonChangeFunc *GerritClientAbandonChangeFunc
+	// AddChangeReviewerFunc is an instance of a mock function object
+	// controlling the behavior of the method AddChangeReviewer.
+	AddChangeReviewerFunc *GerritClientAddChangeReviewerFunc
 	// AuthenticatorFunc is an instance of a mock function object
 	// controlling the behavior of the method Authenticator.
 	AuthenticatorFunc *GerritClientAuthenticatorFunc
@@ -82,6 +85,11 @@ func NewMockGerritClient() *MockGerritClient {
 				return
 			},
 		},
+		AddChangeReviewerFunc: &GerritClientAddChangeReviewerFunc{
+			defaultHook: func(context.Context, string, gerrit.AddReviewerPayload) (r0 error) {
+				return
+			},
+		},
 		AuthenticatorFunc: &GerritClientAuthenticatorFunc{
 			defaultHook: func() (r0 auth.Authenticator) {
 				return
@@ -174,6 +182,11 @@ func NewStrictMockGerritClient() *MockGerritClient {
 				panic("unexpected invocation of MockGerritClient.AbandonChange")
 			},
 		},
+		AddChangeReviewerFunc: &GerritClientAddChangeReviewerFunc{
+			defaultHook: func(context.Context, string, gerrit.AddReviewerPayload) error {
+				panic("unexpected invocation of MockGerritClient.AddChangeReviewer")
+			},
+		},
 		AuthenticatorFunc: &GerritClientAuthenticatorFunc{
 			defaultHook: func() auth.Authenticator {
 				panic("unexpected invocation of MockGerritClient.Authenticator")
@@ -265,6 +278,9 @@ func NewMockGerritClientFrom(i gerrit.Client) *MockGerritClient {
 		AbandonChangeFunc: &GerritClientAbandonChangeFunc{
 			defaultHook: i.AbandonChange,
 		},
+		AddChangeReviewerFunc: &GerritClientAddChangeReviewerFunc{
+			defaultHook: i.AddChangeReviewer,
+		},
 		AuthenticatorFunc: &GerritClientAuthenticatorFunc{
 			defaultHook: i.Authenticator,
 		},
@@ -424,6 +440,116 @@ func (c GerritClientAbandonChangeFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// GerritClientAddChangeReviewerFunc describes the behavior when the
+// AddChangeReviewer method of the parent MockGerritClient instance is
+// invoked.
+type GerritClientAddChangeReviewerFunc struct {
+	defaultHook func(context.Context, string, gerrit.AddReviewerPayload) error
+	hooks       []func(context.Context, string, gerrit.AddReviewerPayload) error
+	history     []GerritClientAddChangeReviewerFuncCall
+	mutex       sync.Mutex
+}
+
+// AddChangeReviewer delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGerritClient) AddChangeReviewer(v0 context.Context, v1 string, v2 gerrit.AddReviewerPayload) error {
+	r0 := m.AddChangeReviewerFunc.nextHook()(v0, v1, v2)
+	m.AddChangeReviewerFunc.appendCall(GerritClientAddChangeReviewerFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the AddChangeReviewer
+// method of the parent MockGerritClient instance is invoked and the hook
+// queue is empty.
+func (f *GerritClientAddChangeReviewerFunc) SetDefaultHook(hook func(context.Context, string, gerrit.AddReviewerPayload) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AddChangeReviewer method of the parent MockGerritClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *GerritClientAddChangeReviewerFunc) PushHook(hook func(context.Context, string, gerrit.AddReviewerPayload) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GerritClientAddChangeReviewerFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string, gerrit.AddReviewerPayload) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GerritClientAddChangeReviewerFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string, gerrit.AddReviewerPayload) error {
+		return r0
+	})
+}
+
+func (f *GerritClientAddChangeReviewerFunc) nextHook() func(context.Context, string, gerrit.AddReviewerPayload) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GerritClientAddChangeReviewerFunc) appendCall(r0 GerritClientAddChangeReviewerFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GerritClientAddChangeReviewerFuncCall
+// objects describing the invocations of this function.
+func (f *GerritClientAddChangeReviewerFunc) History() []GerritClientAddChangeReviewerFuncCall {
+	f.mutex.Lock()
+	history := make([]GerritClientAddChangeReviewerFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GerritClientAddChangeReviewerFuncCall is an object that describes an
+// invocation of method AddChangeReviewer on an instance of
+// MockGerritClient.
+type GerritClientAddChangeReviewerFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 gerrit.AddReviewerPayload
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GerritClientAddChangeReviewerFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GerritClientAddChangeReviewerFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // GerritClientAuthenticatorFunc describes the behavior when the
 // Authenticator method of the parent MockGerritClient instance is invoked.
 type GerritClientAuthenticatorFunc struct {
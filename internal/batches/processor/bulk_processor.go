@@ -200,6 +200,14 @@ func (b *bulkProcessor) mergeChangeset(ctx context.Context, job *btypes.Changese
 		return nil, errcode.MakeNonRetryable(err)
 	}
 
+	// Changesets stacked on top of this one are waiting for it to be merged.
+	if cs.ExternalState == btypes.ChangesetExternalStateMerged {
+		if err := b.tx.EnqueueStackedChangesets(ctx, cs.Changeset); err != nil {
+			b.logger.Error("EnqueueStackedChangesets", log.Error(err))
+			return nil, errcode.MakeNonRetryable(err)
+		}
+	}
+
 	afterDone = func(s *store.Store) { b.enqueueWebhook(ctx, s, webhooks.ChangesetClose) }
 	return afterDone, nil
 }
@@ -38,6 +38,7 @@ func executePlan(ctx context.Context, logger log.Logger, client gitserver.Client
 		tx:                tx,
 		ch:                plan.Changeset,
 		spec:              plan.ChangesetSpec,
+		stackBaseRef:      plan.StackBaseRef,
 	}
 
 	return e.Run(ctx, plan)
@@ -52,6 +53,10 @@ type executor struct {
 	ch                *btypes.Changeset
 	spec              *btypes.ChangesetSpec
 
+	// stackBaseRef is the ref the changes of a stacked changeset are pushed
+	// on top of. See Plan.StackBaseRef.
+	stackBaseRef string
+
 	// targetRepo represents the repo where the changeset should be opened.
 	targetRepo *types.Repo
 
@@ -86,7 +91,9 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (afterDone func(store *s
 	// trigger a webhooks.ChangesetUpdate event for this operation as well.
 	var triggerUpdateWebhook bool
 	if plan.Ops.Contains(btypes.ReconcilerOperationPush) && !plan.Ops.Contains(btypes.ReconcilerOperationPublish) && !plan.Ops.Contains(btypes.ReconcilerOperationUpdate) {
-		triggerUpdateWebhook = true
+		// Stacked changesets are pushed before they are published, which
+		// isn't an update of the changeset yet.
+		triggerUpdateWebhook = e.ch.Published()
 	}
 
 	for _, op := range plan.Ops.ExecutionOrder() {
@@ -151,6 +158,14 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (afterDone func(store *s
 		return afterDone, err
 	}
 
+	// Changesets stacked on top of this one have to be pushed on top of its
+	// latest changes.
+	if e.ch.Published() && plan.Ops.Contains(btypes.ReconcilerOperationPush) {
+		if err := e.tx.EnqueueStackedChangesets(ctx, e.ch); err != nil {
+			return afterDone, errors.Wrap(err, "enqueueing stacked changesets")
+		}
+	}
+
 	e.ch.PreviousFailureMessage = nil
 
 	return afterDone, e.tx.UpdateChangeset(ctx, e.ch)
@@ -202,6 +217,18 @@ func (e *executor) pushChangesetPatch(ctx context.Context, triggerUpdateWebhook
 		return afterDone, err
 	}
 	opts := css.BuildCommitOpts(e.targetRepo, e.ch, e.spec, pushConf)
+	if e.stackBaseRef != "" {
+		// The changes of a stacked changeset only contain what its stage
+		// added to the previous stage, so they are applied on top of the
+		// latest commit of the branch of the previous stage, or of the base
+		// branch once the previous stage has been merged, instead of the
+		// base revision the batch spec was executed against.
+		baseCommit, err := e.client.ResolveRevision(ctx, e.targetRepo.Name, e.stackBaseRef, gitserver.ResolveRevisionOptions{})
+		if err != nil {
+			return afterDone, errors.Wrap(err, "resolving base of stacked changeset")
+		}
+		opts.BaseCommit = baseCommit
+	}
 	resp, err := e.pushCommit(ctx, opts)
 	if err != nil {
 		var pce pushCommitError
@@ -318,6 +345,12 @@ func (e *executor) publishChangeset(ctx context.Context, asDraft bool) (afterDon
 	// Set the changeset to published.
 	e.ch.PublicationState = btypes.ChangesetPublicationStatePublished
 
+	// Reviews are only requested once the changeset is ready for review, so
+	// draft changesets are handled when they are undrafted.
+	if !asDraft {
+		e.requestReviewsFromOwners(ctx, css, cs)
+	}
+
 	// Enqueue the appropriate webhook.
 	if exists && outdated {
 		afterDone = func(store *store.Store) { e.enqueueWebhook(ctx, store, webhooks.ChangesetUpdate) }
@@ -550,6 +583,8 @@ func (e *executor) undraftChangeset(ctx context.Context) (afterDone func(store *
 		return afterDone, errors.Wrap(err, "undrafting changeset")
 	}
 
+	e.requestReviewsFromOwners(ctx, draftCss, cs)
+
 	afterDone = func(store *store.Store) { e.enqueueWebhook(ctx, store, webhooks.ChangesetUpdate) }
 	return afterDone, nil
 }
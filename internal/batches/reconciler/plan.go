@@ -107,6 +107,12 @@ type Plan struct {
 	// The Delta between a possible previous ChangesetSpec and the current
 	// ChangesetSpec.
 	Delta *ChangesetSpecDelta
+
+	// StackBaseRef is the ref the changes of a stacked changeset are pushed on
+	// top of: the head ref of the changeset it is stacked on while that is
+	// open, and the base ref once it has been merged. It is empty for
+	// changesets that are not stacked.
+	StackBaseRef string
 }
 
 func (p *Plan) AddOp(op btypes.ReconcilerOperation) { p.Ops = append(p.Ops, op) }
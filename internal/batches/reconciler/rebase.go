@@ -0,0 +1,292 @@
+package reconciler
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/batches/sources"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Rebaser re-applies the diffs of the changesets of batch changes with
+// auto-rebase enabled onto the latest commit of their base branch and
+// force-pushes the result, so that changesets don't go stale when their base
+// branch moves on.
+type Rebaser struct {
+	gitserverClient gitserver.Client
+	sourcer         sources.Sourcer
+	store           *store.Store
+	logger          log.Logger
+}
+
+// NewRebaser returns a Rebaser that pushes to code hosts using the given
+// gitserver client and sourcer.
+func NewRebaser(logger log.Logger, gitClient gitserver.Client, sourcer sources.Sourcer, store *store.Store) *Rebaser {
+	return &Rebaser{
+		gitserverClient: gitClient,
+		sourcer:         sourcer,
+		store:           store,
+		logger:          logger,
+	}
+}
+
+// RebaseChangesets rebases all changesets whose base branch moved on since
+// their diff was last applied in a way that may conflict with the diff, or by
+// more than the configured number of commits.
+func (r *Rebaser) RebaseChangesets(ctx context.Context) error {
+	cs, err := r.store.ListChangesetsToRebase(ctx)
+	if err != nil {
+		return errors.Wrap(err, "listing changesets to rebase")
+	}
+
+	var errs error
+	for _, ch := range cs {
+		if err := r.rebaseChangeset(ctx, ch.ID); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "rebasing changeset %d", ch.ID))
+		}
+	}
+	return errs
+}
+
+// rebaseChangeset rebases the changeset with the given ID. Like the
+// reconciler, it holds a transaction while pushing, in which the changeset is
+// locked so that the reconciler can't process the changeset at the same time.
+func (r *Rebaser) rebaseChangeset(ctx context.Context, id int64) (err error) {
+	tx, err := r.store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	ch, err := tx.LockChangesetToRebase(ctx, id)
+	if err != nil {
+		if err == store.ErrNoResults {
+			// The changeset has been changed or is being processed by the
+			// reconciler since it was listed.
+			return nil
+		}
+		return errors.Wrap(err, "locking changeset")
+	}
+
+	return r.rebaseLockedChangeset(ctx, tx, ch)
+}
+
+func (r *Rebaser) rebaseLockedChangeset(ctx context.Context, tx *store.Store, ch *btypes.Changeset) error {
+	spec, err := tx.GetChangesetSpecByID(ctx, ch.CurrentSpecID)
+	if err != nil {
+		return errors.Wrap(err, "loading changeset spec")
+	}
+
+	repo, err := tx.Repos().Get(ctx, ch.RepoID)
+	if err != nil {
+		return errors.Wrap(err, "loading repository")
+	}
+
+	baseRev, err := r.gitserverClient.ResolveRevision(ctx, repo.Name, spec.BaseRef, gitserver.ResolveRevisionOptions{})
+	if err != nil {
+		return errors.Wrap(err, "resolving base branch")
+	}
+
+	// The diff is based on the base revision of the spec, unless it has been
+	// rebased since the spec was applied.
+	appliedRev := spec.BaseRev
+	hasConflicts := false
+	last, err := tx.GetChangesetRebase(ctx, ch.ID)
+	if err != nil && err != store.ErrNoResults {
+		return errors.Wrap(err, "loading last rebase")
+	}
+	if last != nil && last.ChangesetSpecID == spec.ID {
+		appliedRev = last.BaseRev
+		hasConflicts = last.HasConflicts()
+	}
+	// If the last rebase onto this commit had conflicts, there's no point in
+	// trying again until the base branch moves on.
+	if appliedRev == string(baseRev) {
+		return nil
+	}
+
+	// A changeset whose diff didn't apply last time is retried whenever the
+	// base branch moves on. Otherwise, we only rebase if the changeset may be
+	// in conflict or has fallen too far behind.
+	if !hasConflicts {
+		batchChange, err := tx.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: ch.OwnedByBatchChangeID})
+		if err != nil {
+			return errors.Wrap(err, "loading batch change")
+		}
+
+		ok, err := r.needsRebase(ctx, repo.Name, spec.Diff, appliedRev, string(baseRev), batchChange.AutoRebaseMaxBehind)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+	}
+
+	e := &executor{
+		client:     r.gitserverClient,
+		logger:     r.logger,
+		sourcer:    r.sourcer,
+		tx:         tx,
+		ch:         ch,
+		spec:       spec,
+		targetRepo: repo,
+	}
+
+	css, err := e.changesetSource(ctx)
+	if err != nil {
+		return err
+	}
+	remoteRepo, err := e.remoteRepo(ctx)
+	if err != nil {
+		return err
+	}
+	if remoteRepo.Archived {
+		return nil
+	}
+
+	pushConf, err := css.GitserverPushConfig(remoteRepo)
+	if err != nil {
+		return err
+	}
+	opts := css.BuildCommitOpts(repo, ch, spec, pushConf)
+	opts.BaseCommit = baseRev
+
+	rebase := &btypes.ChangesetRebase{
+		ChangesetID:     ch.ID,
+		ChangesetSpecID: spec.ID,
+		BaseRev:         string(baseRev),
+	}
+
+	resp, err := r.gitserverClient.CreateCommitFromPatch(ctx, opts)
+	if err != nil {
+		var pe *protocol.CreateCommitFromPatchError
+		if !errors.As(err, &pe) || !strings.Contains(pe.CombinedOutput, "patch does not apply") {
+			return errors.Wrap(err, "pushing commit")
+		}
+
+		files := conflictingFiles(pe.CombinedOutput)
+		if len(files) == 0 {
+			return pushCommitError{pe}
+		}
+		r.logger.Info("changeset diff does not apply onto base branch",
+			log.Int64("changeset", ch.ID),
+			log.String("baseRev", rebase.BaseRev),
+			log.Strings("files", files))
+
+		rebase.ConflictingFiles = files
+		return tx.UpsertChangesetRebase(ctx, rebase)
+	}
+
+	if err := e.runAfterCommit(ctx, css, resp, remoteRepo, opts); err != nil {
+		return errors.Wrap(err, "running after commit routine")
+	}
+
+	// Changesets stacked on top of this one have to be pushed on top of its
+	// latest changes.
+	if err := tx.EnqueueStackedChangesets(ctx, ch); err != nil {
+		return errors.Wrap(err, "enqueueing stacked changesets")
+	}
+
+	return tx.UpsertChangesetRebase(ctx, rebase)
+}
+
+// needsRebase returns true if the base branch moved on from appliedRev to
+// baseRev in a way that warrants re-applying the given diff: either it changed
+// any of the files that the diff changes, so the changeset may be in conflict,
+// or it moved on by more than maxBehind commits (if non-zero).
+func (r *Rebaser) needsRebase(ctx context.Context, repo api.RepoName, diff []byte, appliedRev, baseRev string, maxBehind int32) (bool, error) {
+	if maxBehind > 0 {
+		behindAhead, err := r.gitserverClient.GetBehindAhead(ctx, repo, appliedRev, baseRev)
+		if err != nil {
+			return false, errors.Wrap(err, "counting commits on base branch")
+		}
+		if behindAhead.Ahead > uint32(maxBehind) {
+			return true, nil
+		}
+	}
+
+	paths, err := changedPaths(diff)
+	if err != nil {
+		return false, errors.Wrap(err, "parsing diff")
+	}
+	if len(paths) == 0 {
+		return false, nil
+	}
+
+	iter, err := r.gitserverClient.Diff(ctx, gitserver.DiffOptions{
+		Repo:      repo,
+		Base:      appliedRev,
+		Head:      baseRev,
+		RangeType: "..",
+		Paths:     paths,
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "diffing base branch")
+	}
+	defer iter.Close()
+
+	if _, err := iter.Next(); err != nil {
+		if err == io.EOF {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "diffing base branch")
+	}
+	return true, nil
+}
+
+// conflictingFiles returns the files that git apply reported problems with in
+// the given output, in the order in which they were reported.
+func conflictingFiles(output string) []string {
+	var files []string
+	seen := make(map[string]struct{})
+	add := func(file string) {
+		if _, ok := seen[file]; ok || file == "" {
+			return
+		}
+		seen[file] = struct{}{}
+		files = append(files, file)
+	}
+
+	s := bufio.NewScanner(strings.NewReader(output))
+	for s.Scan() {
+		line, ok := strings.CutPrefix(strings.TrimSpace(s.Text()), "error: ")
+		if !ok {
+			continue
+		}
+
+		// error: patch failed: README.md:12
+		if rest, ok := strings.CutPrefix(line, "patch failed: "); ok {
+			if i := strings.LastIndexByte(rest, ':'); i > 0 {
+				add(rest[:i])
+			}
+			continue
+		}
+
+		// error: README.md: patch does not apply
+		// error: main.go: does not exist in index
+		// error: main.go: already exists in index
+		for _, suffix := range []string{
+			": patch does not apply",
+			": does not exist in index",
+			": already exists in index",
+			": already exists in working directory",
+			": No such file or directory",
+		} {
+			if file, ok := strings.CutSuffix(line, suffix); ok {
+				add(file)
+				break
+			}
+		}
+	}
+	return files
+}
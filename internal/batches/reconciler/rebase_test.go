@@ -0,0 +1,291 @@
+package reconciler
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	stesting "github.com/sourcegraph/sourcegraph/internal/batches/sources/testing"
+	bstore "github.com/sourcegraph/sourcegraph/internal/batches/store"
+	bt "github.com/sourcegraph/sourcegraph/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	et "github.com/sourcegraph/sourcegraph/internal/encryption/testing"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	gitprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestConflictingFiles(t *testing.T) {
+	for name, tc := range map[string]struct {
+		output string
+		want   []string
+	}{
+		"no errors": {
+			output: "Checking patch README.md...\n",
+			want:   nil,
+		},
+		"content conflicts": {
+			output: `Checking patch README.md...
+error: while searching for:
+Hello world
+error: patch failed: README.md:12
+error: README.md: patch does not apply
+Checking patch cmd/main.go...
+error: patch failed: cmd/main.go:3
+error: cmd/main.go: patch does not apply
+`,
+			want: []string{"README.md", "cmd/main.go"},
+		},
+		"missing and existing files": {
+			output: `error: deleted.go: does not exist in index
+error: added.go: already exists in index
+`,
+			want: []string{"deleted.go", "added.go"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, conflictingFiles(tc.output)); diff != "" {
+				t.Errorf("mismatch (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRebaser_RebaseChangesets(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := actor.WithInternalActor(context.Background())
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	store := bstore.New(db, &observation.TestContext, et.TestKey{})
+
+	admin := bt.CreateTestUser(t, db, true)
+	repo, extSvc := bt.CreateTestRepo(t, ctx, db)
+
+	batchSpec := bt.CreateBatchSpec(t, ctx, store, "auto-rebase", admin.ID, 0)
+	batchChange := bt.CreateBatchChange(t, ctx, store, "auto-rebase", admin.ID, batchSpec.ID)
+	batchChange.AutoRebase = true
+	if err := store.UpdateBatchChangeAutoRebase(ctx, batchChange); err != nil {
+		t.Fatal(err)
+	}
+
+	spec := bt.CreateChangesetSpec(t, ctx, store, bt.TestSpecOpts{
+		User:       admin.ID,
+		Repo:       repo.ID,
+		BatchSpec:  batchSpec.ID,
+		HeadRef:    "refs/heads/auto-rebase",
+		BaseRef:    "refs/heads/main",
+		BaseRev:    "executed-against",
+		Typ:        btypes.ChangesetSpecTypeBranch,
+		Published:  true,
+		CommitDiff: []byte(readmeDiff),
+	})
+	changeset := bt.CreateChangeset(t, ctx, store, bt.TestChangesetOpts{
+		Repo:               repo.ID,
+		BatchChanges:       []btypes.BatchChangeAssoc{{BatchChangeID: batchChange.ID}},
+		OwnedByBatchChange: batchChange.ID,
+		CurrentSpec:        spec.ID,
+		PublicationState:   btypes.ChangesetPublicationStatePublished,
+		ExternalID:         "1",
+		ExternalBranch:     "refs/heads/auto-rebase",
+		ExternalState:      btypes.ChangesetExternalStateOpen,
+		ReconcilerState:    btypes.ReconcilerStateCompleted,
+	})
+
+	var (
+		baseRev    api.CommitID
+		baseDiff   string
+		baseAhead  uint32
+		pushes     []gitprotocol.CreateCommitFromPatchRequest
+		pushErr    error
+		gitClient  = gitserver.NewMockClient()
+		sourcer    = stesting.NewFakeSourcer(nil, &stesting.FakeChangesetSource{Svc: extSvc, CurrentAuthenticator: &auth.OAuthBearerToken{Token: "token"}})
+		rebaser    = NewRebaser(logger, gitClient, sourcer, store)
+		lastRebase = func(t *testing.T) *btypes.ChangesetRebase {
+			t.Helper()
+			rebase, err := store.GetChangesetRebase(ctx, changeset.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return rebase
+		}
+	)
+	gitClient.ResolveRevisionFunc.SetDefaultHook(func(context.Context, api.RepoName, string, gitserver.ResolveRevisionOptions) (api.CommitID, error) {
+		return baseRev, nil
+	})
+	gitClient.DiffFunc.SetDefaultHook(func(_ context.Context, opts gitserver.DiffOptions) (*gitserver.DiffFileIterator, error) {
+		if diff := cmp.Diff([]string{"README.md"}, opts.Paths); diff != "" {
+			t.Errorf("wrong diff paths (-want +have):\n%s", diff)
+		}
+		return gitserver.NewDiffFileIterator(io.NopCloser(strings.NewReader(baseDiff))), nil
+	})
+	gitClient.GetBehindAheadFunc.SetDefaultHook(func(context.Context, api.RepoName, string, string) (*gitdomain.BehindAhead, error) {
+		return &gitdomain.BehindAhead{Ahead: baseAhead}, nil
+	})
+	gitClient.CreateCommitFromPatchFunc.SetDefaultHook(func(_ context.Context, req gitprotocol.CreateCommitFromPatchRequest) (*gitprotocol.CreateCommitFromPatchResponse, error) {
+		pushes = append(pushes, req)
+		if pushErr != nil {
+			return nil, pushErr
+		}
+		return new(gitprotocol.CreateCommitFromPatchResponse), nil
+	})
+
+	t.Run("base branch unchanged", func(t *testing.T) {
+		baseRev = "executed-against"
+		if err := rebaser.RebaseChangesets(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if len(pushes) != 0 {
+			t.Fatalf("unexpected push: %+v", pushes)
+		}
+	})
+
+	t.Run("base branch moved without changing the files of the diff", func(t *testing.T) {
+		baseRev = "unrelated"
+		baseAhead = 5
+		if err := rebaser.RebaseChangesets(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if len(pushes) != 0 {
+			t.Fatalf("unexpected push: %+v", pushes)
+		}
+	})
+
+	t.Run("base branch fell behind", func(t *testing.T) {
+		batchChange.AutoRebaseMaxBehind = 3
+		if err := store.UpdateBatchChangeAutoRebase(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			batchChange.AutoRebaseMaxBehind = 0
+			if err := store.UpdateBatchChangeAutoRebase(ctx, batchChange); err != nil {
+				t.Fatal(err)
+			}
+			pushes = nil
+		}()
+
+		baseRev = "behind"
+		baseAhead = 4
+		if err := rebaser.RebaseChangesets(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if len(pushes) != 1 || pushes[0].BaseCommit != "behind" {
+			t.Fatalf("wrong pushes: %+v", pushes)
+		}
+	})
+
+	t.Run("base branch changed the files of the diff", func(t *testing.T) {
+		baseRev = "moved"
+		baseAhead = 1
+		baseDiff = readmeDiff
+		if err := rebaser.RebaseChangesets(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if len(pushes) != 1 {
+			t.Fatalf("wrong number of pushes. want=1, have=%d", len(pushes))
+		}
+		if pushes[0].BaseCommit != "moved" {
+			t.Fatalf("wrong base commit. want=%q, have=%q", "moved", pushes[0].BaseCommit)
+		}
+		if rebase := lastRebase(t); rebase.BaseRev != "moved" || len(rebase.ConflictingFiles) != 0 {
+			t.Fatalf("wrong rebase recorded: %+v", rebase)
+		}
+
+		// The diff has been applied onto the latest commit already.
+		if err := rebaser.RebaseChangesets(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if len(pushes) != 1 {
+			t.Fatalf("wrong number of pushes. want=1, have=%d", len(pushes))
+		}
+	})
+
+	t.Run("conflicts", func(t *testing.T) {
+		baseRev = "conflicting"
+		pushErr = &gitprotocol.CreateCommitFromPatchError{
+			CombinedOutput: "error: patch failed: README.md:12\nerror: README.md: patch does not apply\n",
+		}
+		defer func() { pushErr = nil }()
+
+		if err := rebaser.RebaseChangesets(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"README.md"}, lastRebase(t).ConflictingFiles); diff != "" {
+			t.Fatalf("wrong conflicting files (-want +have):\n%s", diff)
+		}
+
+		// Conflicts are not retried until the base branch moves on.
+		pushes = nil
+		if err := rebaser.RebaseChangesets(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if len(pushes) != 0 {
+			t.Fatalf("unexpected push: %+v", pushes)
+		}
+	})
+
+	t.Run("changeset locked by another transaction", func(t *testing.T) {
+		baseRev = "locked"
+		pushes = nil
+
+		tx, err := store.Transact(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tx.LockChangesetToRebase(ctx, changeset.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		err = rebaser.RebaseChangesets(ctx)
+		if doneErr := tx.Done(nil); doneErr != nil {
+			t.Fatal(doneErr)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(pushes) != 0 {
+			t.Fatalf("unexpected push: %+v", pushes)
+		}
+	})
+
+	t.Run("changeset processed by the reconciler", func(t *testing.T) {
+		baseRev = "processing"
+		pushes = nil
+
+		changeset.ReconcilerState = btypes.ReconcilerStateProcessing
+		if err := store.UpdateChangeset(ctx, changeset); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := store.LockChangesetToRebase(ctx, changeset.ID); err != bstore.ErrNoResults {
+			t.Fatalf("unexpected error locking changeset: %v", err)
+		}
+
+		if err := rebaser.RebaseChangesets(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if len(pushes) != 0 {
+			t.Fatalf("unexpected push: %+v", pushes)
+		}
+	})
+}
+
+const readmeDiff = `diff --git a/README.md b/README.md
+index 671e50a..ec2f2d1 100644
+--- a/README.md
++++ b/README.md
+@@ -1 +1 @@
+-Hello world
++Hello Sourcegraph
+`
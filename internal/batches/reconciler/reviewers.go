@@ -0,0 +1,262 @@
+package reconciler
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"strings"
+
+	godiff "github.com/sourcegraph/go-diff/diff"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/auth/providers"
+	"github.com/sourcegraph/sourcegraph/internal/batches/sources"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/own"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// requestReviewsFromOwners requests reviews on the given changeset from the
+// owners of the files changed by the changeset spec, if the spec asks for it.
+// On code hosts that don't support requesting reviews, the owners are listed in
+// a comment on the changeset instead. The changeset has already been published
+// at this point, so errors are only logged.
+func (e *executor) requestReviewsFromOwners(ctx context.Context, css sources.ChangesetSource, cs *sources.Changeset) {
+	if e.spec == nil || !e.spec.RequestReviewsFromOwners {
+		return
+	}
+
+	logger := e.logger.With(log.Int64("changeset", e.ch.ID))
+
+	paths, err := changedPaths(e.spec.Diff)
+	if err != nil {
+		logger.Warn("failed to parse changeset diff", log.Error(err))
+		return
+	}
+
+	db := e.tx.DatabaseDB()
+	reviewers, err := ownerReviewers(ctx, db, own.NewService(e.client, db), e.targetRepo, api.CommitID(e.spec.BaseRev), paths)
+	if err != nil {
+		logger.Warn("failed to resolve owners of changed files", log.Error(err))
+		return
+	}
+	if reviewers.IsEmpty() {
+		return
+	}
+
+	rcss, ok := css.(sources.ReviewerRequestingChangesetSource)
+	if !ok {
+		if err := css.CreateComment(ctx, cs, ownersComment(reviewers)); err != nil {
+			logger.Warn("failed to list owners on changeset", log.Error(err))
+		}
+		return
+	}
+
+	if err := rcss.RequestReviewers(ctx, cs, reviewers); err != nil {
+		logger.Warn("failed to request reviews from owners", log.Error(err))
+	}
+}
+
+// ownersComment returns the comment that lists the owners of a changeset on
+// code hosts where reviews can't be requested from them.
+func ownersComment(reviewers sources.Reviewers) string {
+	owners := make([]string, 0, len(reviewers.Users)+len(reviewers.Teams))
+	for _, handle := range append(append([]string{}, reviewers.Users...), reviewers.Teams...) {
+		owners = append(owners, "@"+handle)
+	}
+	return "Reviews can't be requested automatically on this code host. The owners of the changed files are: " + strings.Join(owners, ", ")
+}
+
+// ownerReviewers resolves the code host users and teams that own the given
+// paths of the repository at the given commit. Owners are taken from the
+// CODEOWNERS file of the repository and the owners assigned in Sourcegraph.
+// Sourcegraph users, including CODEOWNERS handles that are Sourcegraph
+// usernames, are mapped to the code host through their external accounts, and
+// users without an account on the code host are skipped. Teams assigned in
+// Sourcegraph that were synced from the code host are requested as code host
+// teams, while reviews from other teams are requested from their members.
+func ownerReviewers(ctx context.Context, db database.DB, ownService own.Service, repo *types.Repo, commitID api.CommitID, paths []string) (sources.Reviewers, error) {
+	ruleset, err := ownService.RulesetForRepo(ctx, repo.Name, repo.ID, commitID)
+	if err != nil {
+		return sources.Reviewers{}, errors.Wrap(err, "loading CODEOWNERS ruleset")
+	}
+	assignedOwners, err := ownService.AssignedOwnership(ctx, repo.ID, commitID)
+	if err != nil {
+		return sources.Reviewers{}, errors.Wrap(err, "loading assigned owners")
+	}
+	assignedTeams, err := ownService.AssignedTeams(ctx, repo.ID, commitID)
+	if err != nil {
+		return sources.Reviewers{}, errors.Wrap(err, "loading assigned teams")
+	}
+
+	var (
+		codeOwnersHandles = make(map[string]struct{})
+		handles           = make(map[string]struct{})
+		emails            = make(map[string]struct{})
+		userIDs           = make(map[int32]struct{})
+		teamIDs           = make(map[int32]struct{})
+	)
+	for _, path := range paths {
+		if ruleset != nil {
+			for _, owner := range ruleset.Match(path).GetOwner() {
+				if owner.GetHandle() != "" {
+					codeOwnersHandles[owner.GetHandle()] = struct{}{}
+				} else if owner.GetEmail() != "" {
+					emails[owner.GetEmail()] = struct{}{}
+				}
+			}
+		}
+		for _, o := range assignedOwners.Match(path) {
+			userIDs[o.OwnerUserID] = struct{}{}
+		}
+		for _, t := range assignedTeams.Match(path) {
+			teamIDs[t.OwnerTeamID] = struct{}{}
+		}
+	}
+
+	for handle := range codeOwnersHandles {
+		// Handles containing a slash refer to teams on the code host, like
+		// @org/team on GitHub or @group/subgroup on GitLab.
+		if strings.Contains(handle, "/") {
+			handles[handle] = struct{}{}
+			continue
+		}
+
+		// Sourcegraph resolves handles to Sourcegraph users first, so we do
+		// the same and use their login on the code host. Other handles are
+		// taken to be code host logins.
+		user, err := db.Users().GetByUsername(ctx, handle)
+		if err != nil {
+			if errcode.IsNotFound(err) {
+				handles[handle] = struct{}{}
+				continue
+			}
+			return sources.Reviewers{}, errors.Wrap(err, "getting user by username")
+		}
+		login, err := codeHostLogin(ctx, db, repo, user.ID)
+		if err != nil {
+			return sources.Reviewers{}, err
+		}
+		if login == "" {
+			login = handle
+		}
+		handles[login] = struct{}{}
+	}
+
+	for email := range emails {
+		user, err := db.Users().GetByVerifiedEmail(ctx, email)
+		if err != nil {
+			if errcode.IsNotFound(err) {
+				continue
+			}
+			return sources.Reviewers{}, errors.Wrap(err, "getting user by email")
+		}
+		userIDs[user.ID] = struct{}{}
+	}
+
+	for teamID := range teamIDs {
+		team, err := db.Teams().GetTeamByID(ctx, teamID)
+		if err != nil {
+			if errcode.IsNotFound(err) {
+				continue
+			}
+			return sources.Reviewers{}, errors.Wrap(err, "getting team")
+		}
+
+		// Teams synced from the code host are named after the team on the
+		// code host, including its organization, like org/team.
+		if strings.Contains(team.Name, "/") {
+			handles[team.Name] = struct{}{}
+			continue
+		}
+
+		members, _, err := db.Teams().ListTeamMembers(ctx, database.ListTeamMembersOpts{TeamID: teamID})
+		if err != nil {
+			return sources.Reviewers{}, errors.Wrap(err, "listing team members")
+		}
+		for _, m := range members {
+			userIDs[m.UserID] = struct{}{}
+		}
+	}
+
+	for userID := range userIDs {
+		login, err := codeHostLogin(ctx, db, repo, userID)
+		if err != nil {
+			return sources.Reviewers{}, err
+		}
+		if login != "" {
+			handles[login] = struct{}{}
+		}
+	}
+
+	var reviewers sources.Reviewers
+	for handle := range handles {
+		if strings.Contains(handle, "/") {
+			reviewers.Teams = append(reviewers.Teams, handle)
+		} else {
+			reviewers.Users = append(reviewers.Users, handle)
+		}
+	}
+	sort.Strings(reviewers.Users)
+	sort.Strings(reviewers.Teams)
+
+	return reviewers, nil
+}
+
+// codeHostLogin returns the login of the given user on the code host of the
+// repository, or an empty string if the user has no account on it.
+func codeHostLogin(ctx context.Context, db database.DB, repo *types.Repo, userID int32) (string, error) {
+	accounts, err := db.UserExternalAccounts().List(ctx, database.ExternalAccountsListOptions{
+		UserID:         userID,
+		ServiceType:    repo.ExternalRepo.ServiceType,
+		ServiceID:      repo.ExternalRepo.ServiceID,
+		ExcludeExpired: true,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "listing external accounts")
+	}
+
+	for _, account := range accounts {
+		p := providers.GetProviderbyServiceType(account.ServiceType)
+		if p == nil {
+			continue
+		}
+		data, err := p.ExternalAccountInfo(ctx, *account)
+		if err != nil {
+			return "", errors.Wrap(err, "getting external account info")
+		}
+		if data != nil && data.Login != "" {
+			return data.Login, nil
+		}
+	}
+	return "", nil
+}
+
+// changedPaths returns the paths of the files changed by the given diff.
+func changedPaths(diff []byte) ([]string, error) {
+	var paths []string
+	reader := godiff.NewMultiFileDiffReader(bytes.NewReader(diff))
+	for {
+		fileDiff, err := reader.ReadFile()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Deleted files only have their original name.
+		name := strings.TrimPrefix(fileDiff.NewName, "b/")
+		if fileDiff.NewName == "/dev/null" {
+			name = strings.TrimPrefix(fileDiff.OrigName, "a/")
+		}
+		if name != "" {
+			paths = append(paths, name)
+		}
+	}
+	return paths, nil
+}
@@ -0,0 +1,182 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/auth/providers"
+	"github.com/sourcegraph/sourcegraph/internal/batches/sources"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/own"
+	"github.com/sourcegraph/sourcegraph/internal/own/codeowners"
+	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/v1"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestOwnerReviewers(t *testing.T) {
+	ctx := context.Background()
+
+	repo := &types.Repo{
+		ID:   1,
+		Name: "github.com/sourcegraph/sourcegraph",
+		ExternalRepo: api.ExternalRepoSpec{
+			ServiceType: extsvc.TypeGitHub,
+			ServiceID:   "https://github.com/",
+		},
+	}
+
+	ownService := fakeOwnService{
+		ruleset: codeowners.NewRuleset(codeowners.IngestedRulesetSource{}, &codeownerspb.File{
+			Rule: []*codeownerspb.Rule{
+				{Pattern: "*.go", Owner: []*codeownerspb.Owner{{Handle: "gopher"}, {Handle: "sourcegraph/go-team"}}},
+				{Pattern: "/docs/", Owner: []*codeownerspb.Owner{{Email: "writer@example.com"}}},
+				{Pattern: "/cmd/", Owner: []*codeownerspb.Owner{{Handle: "sg-writer"}, {Handle: "unlinked"}}},
+			},
+		}),
+		assignedOwners: own.AssignedOwners{
+			"client": {{OwnerUserID: 2, FilePath: "client"}},
+		},
+		assignedTeams: own.AssignedTeams{
+			"":     {{OwnerTeamID: 3, FilePath: ""}},
+			"docs": {{OwnerTeamID: 4, FilePath: "docs"}},
+		},
+	}
+
+	users := dbmocks.NewMockUserStore()
+	users.GetByVerifiedEmailFunc.SetDefaultHook(func(_ context.Context, email string) (*types.User, error) {
+		if email != "writer@example.com" {
+			t.Errorf("unexpected email %q", email)
+		}
+		return &types.User{ID: 1}, nil
+	})
+	// Handles from CODEOWNERS can be Sourcegraph usernames.
+	users.GetByUsernameFunc.SetDefaultHook(func(_ context.Context, username string) (*types.User, error) {
+		switch username {
+		case "sg-writer":
+			return &types.User{ID: 1, Username: username}, nil
+		case "unlinked":
+			return &types.User{ID: 2, Username: username}, nil
+		}
+		return nil, database.NewUserNotFoundErr()
+	})
+
+	accounts := dbmocks.NewMockUserExternalAccountsStore()
+	accounts.ListFunc.SetDefaultHook(func(_ context.Context, opts database.ExternalAccountsListOptions) ([]*extsvc.Account, error) {
+		if opts.ServiceType != extsvc.TypeGitHub || opts.ServiceID != "https://github.com/" {
+			t.Errorf("unexpected code host %q %q", opts.ServiceType, opts.ServiceID)
+		}
+		// User 2 has no account on the code host.
+		if opts.UserID != 1 {
+			return nil, nil
+		}
+		return []*extsvc.Account{{UserID: 1, AccountSpec: extsvc.AccountSpec{ServiceType: extsvc.TypeGitHub}}}, nil
+	})
+
+	// Team 3 only exists in Sourcegraph, so its members are requested. Team 4
+	// was synced from the code host.
+	teams := dbmocks.NewMockTeamStore()
+	teams.GetTeamByIDFunc.SetDefaultHook(func(_ context.Context, id int32) (*types.Team, error) {
+		if id == 3 {
+			return &types.Team{ID: 3, Name: "everyone"}, nil
+		}
+		return &types.Team{ID: 4, Name: "sourcegraph/docs"}, nil
+	})
+	teams.ListTeamMembersFunc.SetDefaultHook(func(_ context.Context, opts database.ListTeamMembersOpts) ([]*types.TeamMember, *database.TeamMemberListCursor, error) {
+		if opts.TeamID != 3 {
+			t.Errorf("unexpected team %d", opts.TeamID)
+		}
+		return []*types.TeamMember{{TeamID: 3, UserID: 1}, {TeamID: 3, UserID: 2}}, nil, nil
+	})
+
+	db := dbmocks.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.UserExternalAccountsFunc.SetDefaultReturn(accounts)
+	db.TeamsFunc.SetDefaultReturn(teams)
+
+	providers.MockProviders = []providers.Provider{providers.MockAuthProvider{
+		MockConfigID:          providers.ConfigID{Type: extsvc.TypeGitHub},
+		MockPublicAccountData: &extsvc.PublicAccountData{Login: "writer"},
+	}}
+	t.Cleanup(func() { providers.MockProviders = nil })
+
+	have, err := ownerReviewers(ctx, db, ownService, repo, "deadbeef", []string{"main.go", "docs/index.md", "client/app.ts", "cmd/main.go"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// sg-writer is mapped to the login of user 1 on the code host, while
+	// unlinked has no account on it and is taken to be a code host login.
+	want := sources.Reviewers{
+		Users: []string{"gopher", "unlinked", "writer"},
+		Teams: []string{"sourcegraph/docs", "sourcegraph/go-team"},
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("wrong reviewers (-want +got):\n%s", diff)
+	}
+}
+
+func TestOwnersComment(t *testing.T) {
+	have := ownersComment(sources.Reviewers{Users: []string{"alice", "bob"}, Teams: []string{"org/team"}})
+	want := "Reviews can't be requested automatically on this code host. The owners of the changed files are: @alice, @bob, @org/team"
+	if have != want {
+		t.Fatalf("wrong comment. want=%q, have=%q", want, have)
+	}
+}
+
+func TestChangedPaths(t *testing.T) {
+	diff := []byte(`diff --git a/README.md b/README.md
+index 851b23a..140f333 100644
+--- a/README.md
++++ b/README.md
+@@ -1 +1 @@
+-# README
++# Hello
+diff --git a/old.go b/old.go
+deleted file mode 100644
+index 851b23a..0000000
+--- a/old.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package old
+diff --git a/new/file.go b/new/file.go
+new file mode 100644
+index 0000000..140f333
+--- /dev/null
++++ b/new/file.go
+@@ -0,0 +1 @@
++package file
+`)
+
+	have, err := changedPaths(diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"README.md", "old.go", "new/file.go"}, have); diff != "" {
+		t.Fatalf("wrong paths (-want +got):\n%s", diff)
+	}
+}
+
+type fakeOwnService struct {
+	ruleset        *codeowners.Ruleset
+	assignedOwners own.AssignedOwners
+	assignedTeams  own.AssignedTeams
+}
+
+var _ own.Service = fakeOwnService{}
+
+func (s fakeOwnService) RulesetForRepo(context.Context, api.RepoName, api.RepoID, api.CommitID) (*codeowners.Ruleset, error) {
+	return s.ruleset, nil
+}
+
+func (s fakeOwnService) AssignedOwnership(context.Context, api.RepoID, api.CommitID) (own.AssignedOwners, error) {
+	return s.assignedOwners, nil
+}
+
+func (s fakeOwnService) AssignedTeams(context.Context, api.RepoID, api.CommitID) (own.AssignedTeams, error) {
+	return s.assignedTeams, nil
+}
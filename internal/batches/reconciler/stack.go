@@ -0,0 +1,84 @@
+package reconciler
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+)
+
+// applyStackConstraints adjusts the plan of a changeset that is stacked on top
+// of another changeset in the same repository:
+//
+//   - As long as the parent has not been published, nothing is pushed, since
+//     there is no branch to stack the changes on.
+//   - While the parent is open, the changes are pushed on top of the branch of
+//     the parent, but the changeset is not published yet.
+//   - Once the parent has been merged, the changes are rebased onto the base
+//     branch and the changeset is published.
+//
+// The changeset is enqueued again whenever the parent is pushed to or merged.
+func applyStackConstraints(ctx context.Context, tx *store.Store, plan *Plan) error {
+	spec := plan.ChangesetSpec
+	if spec == nil || spec.StackParentHeadRef == "" {
+		return nil
+	}
+	if !plan.Ops.Contains(btypes.ReconcilerOperationPush) &&
+		!plan.Ops.Contains(btypes.ReconcilerOperationPublish) &&
+		!plan.Ops.Contains(btypes.ReconcilerOperationPublishDraft) {
+		return nil
+	}
+
+	parent, err := stackParent(ctx, tx, plan.Changeset, spec)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case parent == nil:
+		plan.Ops = withoutOperations(plan.Ops, btypes.ReconcilerOperationPublish, btypes.ReconcilerOperationPublishDraft, btypes.ReconcilerOperationPush)
+
+	case parent.ExternalState == btypes.ChangesetExternalStateMerged:
+		plan.StackBaseRef = spec.BaseRef
+
+	case parent.ExternalState == btypes.ChangesetExternalStateOpen || parent.ExternalState == btypes.ChangesetExternalStateDraft:
+		plan.Ops = withoutOperations(plan.Ops, btypes.ReconcilerOperationPublish, btypes.ReconcilerOperationPublishDraft)
+		plan.StackBaseRef = parent.ExternalBranch
+
+	default:
+		// The parent has been closed without being merged, so there is
+		// nothing to stack the changes on.
+		plan.Ops = withoutOperations(plan.Ops, btypes.ReconcilerOperationPublish, btypes.ReconcilerOperationPublishDraft, btypes.ReconcilerOperationPush)
+	}
+	return nil
+}
+
+// stackParent returns the published changeset the given changeset is stacked
+// on, or nil if it has not been published yet. The parent must be owned by the
+// same batch change and live in the same repository.
+func stackParent(ctx context.Context, tx *store.Store, ch *btypes.Changeset, spec *btypes.ChangesetSpec) (*btypes.Changeset, error) {
+	parent, err := tx.GetChangeset(ctx, store.GetChangesetOpts{
+		RepoID:               ch.RepoID,
+		ExternalBranch:       spec.StackParentHeadRef,
+		OwnedByBatchChangeID: ch.OwnedByBatchChangeID,
+		PublicationState:     btypes.ChangesetPublicationStatePublished,
+	})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return parent, nil
+}
+
+// withoutOperations returns ops without the given operations.
+func withoutOperations(ops Operations, remove ...btypes.ReconcilerOperation) Operations {
+	var filtered Operations
+	for _, op := range ops {
+		if !Operations(remove).Contains(op) {
+			filtered = append(filtered, op)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,282 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	stesting "github.com/sourcegraph/sourcegraph/internal/batches/sources/testing"
+	bstore "github.com/sourcegraph/sourcegraph/internal/batches/store"
+	bt "github.com/sourcegraph/sourcegraph/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	et "github.com/sourcegraph/sourcegraph/internal/encryption/testing"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	gitprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestApplyStackConstraints(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := actor.WithInternalActor(context.Background())
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	store := bstore.New(db, &observation.TestContext, nil)
+
+	admin := bt.CreateTestUser(t, db, true)
+	repo, _ := bt.CreateTestRepo(t, ctx, db)
+
+	batchSpec := bt.CreateBatchSpec(t, ctx, store, "stacked", admin.ID, 0)
+	batchChange := bt.CreateBatchChange(t, ctx, store, "stacked", admin.ID, batchSpec.ID)
+
+	// Another batch change that uses the same branch name in the same
+	// repository. Its changeset must never be taken for the parent.
+	otherBatchSpec := bt.CreateBatchSpec(t, ctx, store, "other", admin.ID, 0)
+	otherBatchChange := bt.CreateBatchChange(t, ctx, store, "other", admin.ID, otherBatchSpec.ID)
+	bt.CreateChangeset(t, ctx, store, bt.TestChangesetOpts{
+		Repo:               repo.ID,
+		BatchChanges:       []btypes.BatchChangeAssoc{{BatchChangeID: otherBatchChange.ID}},
+		OwnedByBatchChange: otherBatchChange.ID,
+		PublicationState:   btypes.ChangesetPublicationStatePublished,
+		ExternalID:         "other",
+		ExternalBranch:     "refs/heads/stage-1",
+		ExternalState:      btypes.ChangesetExternalStateMerged,
+		ReconcilerState:    btypes.ReconcilerStateCompleted,
+	})
+
+	parentSpec := bt.CreateChangesetSpec(t, ctx, store, bt.TestSpecOpts{
+		User:       admin.ID,
+		Repo:       repo.ID,
+		BatchSpec:  batchSpec.ID,
+		HeadRef:    "refs/heads/stage-1",
+		Typ:        btypes.ChangesetSpecTypeBranch,
+		Published:  true,
+		StackStage: 1,
+	})
+	parent := bt.CreateChangeset(t, ctx, store, bt.TestChangesetOpts{
+		Repo:               repo.ID,
+		BatchChanges:       []btypes.BatchChangeAssoc{{BatchChangeID: batchChange.ID}},
+		OwnedByBatchChange: batchChange.ID,
+		CurrentSpec:        parentSpec.ID,
+		PublicationState:   btypes.ChangesetPublicationStateUnpublished,
+		ReconcilerState:    btypes.ReconcilerStateCompleted,
+	})
+
+	childSpec := bt.CreateChangesetSpec(t, ctx, store, bt.TestSpecOpts{
+		User:               admin.ID,
+		Repo:               repo.ID,
+		BatchSpec:          batchSpec.ID,
+		HeadRef:            "refs/heads/stage-2",
+		BaseRef:            "refs/heads/main",
+		Typ:                btypes.ChangesetSpecTypeBranch,
+		Published:          true,
+		StackStage:         2,
+		StackParentHeadRef: "refs/heads/stage-1",
+	})
+	child := bt.CreateChangeset(t, ctx, store, bt.TestChangesetOpts{
+		Repo:               repo.ID,
+		BatchChanges:       []btypes.BatchChangeAssoc{{BatchChangeID: batchChange.ID}},
+		OwnedByBatchChange: batchChange.ID,
+		CurrentSpec:        childSpec.ID,
+		PublicationState:   btypes.ChangesetPublicationStateUnpublished,
+		ReconcilerState:    btypes.ReconcilerStateCompleted,
+	})
+
+	plan := func() *Plan {
+		return &Plan{
+			Changeset:     child,
+			ChangesetSpec: childSpec,
+			Ops: Operations{
+				btypes.ReconcilerOperationPublish,
+				btypes.ReconcilerOperationPush,
+			},
+		}
+	}
+
+	t.Run("parent not published", func(t *testing.T) {
+		pl := plan()
+		if err := applyStackConstraints(ctx, store, pl); err != nil {
+			t.Fatal(err)
+		}
+		if !pl.Ops.IsNone() {
+			t.Fatalf("want no operations, have %s", pl.Ops)
+		}
+	})
+
+	t.Run("parent open", func(t *testing.T) {
+		parent.PublicationState = btypes.ChangesetPublicationStatePublished
+		parent.ExternalID = "1"
+		parent.ExternalBranch = "refs/heads/stage-1"
+		parent.ExternalState = btypes.ChangesetExternalStateOpen
+		if err := store.UpdateChangeset(ctx, parent); err != nil {
+			t.Fatal(err)
+		}
+
+		pl := plan()
+		if err := applyStackConstraints(ctx, store, pl); err != nil {
+			t.Fatal(err)
+		}
+		// The changes are pushed on top of the branch of the parent, but
+		// the changeset is not published yet.
+		if want := (Operations{btypes.ReconcilerOperationPush}); !pl.Ops.Equal(want) {
+			t.Fatalf("wrong operations. want=%s, have=%s", want, pl.Ops)
+		}
+		if want := "refs/heads/stage-1"; pl.StackBaseRef != want {
+			t.Fatalf("wrong stack base ref. want=%q, have=%q", want, pl.StackBaseRef)
+		}
+	})
+
+	t.Run("parent merged", func(t *testing.T) {
+		parent.ExternalState = btypes.ChangesetExternalStateMerged
+		if err := store.UpdateChangesetCodeHostState(ctx, parent); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.EnqueueStackedChangesets(ctx, parent); err != nil {
+			t.Fatal(err)
+		}
+
+		reloaded, err := store.GetChangesetByID(ctx, child.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reloaded.ReconcilerState != btypes.ReconcilerStateQueued {
+			t.Fatalf("child not enqueued: reconciler state %s", reloaded.ReconcilerState)
+		}
+
+		pl := plan()
+		if err := applyStackConstraints(ctx, store, pl); err != nil {
+			t.Fatal(err)
+		}
+		// The changes are rebased onto the base branch and published.
+		if want := plan().Ops; !pl.Ops.Equal(want) {
+			t.Fatalf("wrong operations. want=%s, have=%s", want, pl.Ops)
+		}
+		if want := "refs/heads/main"; pl.StackBaseRef != want {
+			t.Fatalf("wrong stack base ref. want=%q, have=%q", want, pl.StackBaseRef)
+		}
+	})
+}
+
+func TestExecutor_ExecutePlan_StackedChangeset(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := actor.WithInternalActor(context.Background())
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	store := bstore.New(db, &observation.TestContext, et.TestKey{})
+
+	user := bt.CreateTestUser(t, db, false)
+	repo, extSvc := bt.CreateTestRepo(t, ctx, db)
+
+	if _, err := store.UserCredentials().Create(ctx, database.UserCredentialScope{
+		Domain:              database.UserCredentialDomainBatches,
+		UserID:              user.ID,
+		ExternalServiceType: repo.ExternalRepo.ServiceType,
+		ExternalServiceID:   repo.ExternalRepo.ServiceID,
+	}, &auth.OAuthBearerToken{Token: "token"}); err != nil {
+		t.Fatal(err)
+	}
+
+	batchSpec := bt.CreateBatchSpec(t, ctx, store, "stacked-push", user.ID, 0)
+	batchChange := bt.CreateBatchChange(t, ctx, store, "stacked-push", user.ID, batchSpec.ID)
+
+	commits := map[string]api.CommitID{
+		"refs/heads/main":    "base-branch-commit",
+		"refs/heads/stage-1": "parent-branch-commit",
+	}
+
+	for _, tc := range []struct {
+		name           string
+		stackBaseRef   string
+		wantBaseCommit api.CommitID
+	}{
+		{name: "parent open", stackBaseRef: "refs/heads/stage-1", wantBaseCommit: "parent-branch-commit"},
+		{name: "parent merged", stackBaseRef: "refs/heads/main", wantBaseCommit: "base-branch-commit"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			plan := &Plan{
+				Changeset: &btypes.Changeset{
+					OwnedByBatchChangeID: batchChange.ID,
+					RepoID:               repo.ID,
+				},
+				ChangesetSpec: bt.BuildChangesetSpec(t, bt.TestSpecOpts{
+					HeadRef:            "refs/heads/stage-2",
+					BaseRef:            "refs/heads/main",
+					BaseRev:            "executed-against",
+					Typ:                btypes.ChangesetSpecTypeBranch,
+					Published:          true,
+					CommitDiff:         []byte("testdiff"),
+					StackStage:         2,
+					StackParentHeadRef: "refs/heads/stage-1",
+				}),
+				Ops:          Operations{btypes.ReconcilerOperationPush},
+				StackBaseRef: tc.stackBaseRef,
+			}
+
+			gitserverClient := gitserver.NewMockClient()
+			gitserverClient.ResolveRevisionFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, spec string, _ gitserver.ResolveRevisionOptions) (api.CommitID, error) {
+				if commit, ok := commits[spec]; ok {
+					return commit, nil
+				}
+				return "", &gitdomain.RevisionNotFoundError{Spec: spec}
+			})
+			var req gitprotocol.CreateCommitFromPatchRequest
+			gitserverClient.CreateCommitFromPatchFunc.SetDefaultHook(func(_ context.Context, r gitprotocol.CreateCommitFromPatchRequest) (*gitprotocol.CreateCommitFromPatchResponse, error) {
+				req = r
+				return new(gitprotocol.CreateCommitFromPatchResponse), nil
+			})
+
+			sourcer := stesting.NewFakeSourcer(nil, &stesting.FakeChangesetSource{Svc: extSvc, CurrentAuthenticator: &auth.OAuthBearerToken{Token: "token"}})
+			if _, err := executePlan(actor.WithActor(ctx, actor.FromUser(user.ID)), logger, gitserverClient, sourcer, true, store, plan); err != nil {
+				t.Fatalf("executing plan failed: %s", err)
+			}
+			if req.BaseCommit != tc.wantBaseCommit {
+				t.Fatalf("wrong base commit. want=%q, have=%q", tc.wantBaseCommit, req.BaseCommit)
+			}
+		})
+	}
+
+	t.Run("base cannot be resolved", func(t *testing.T) {
+		plan := &Plan{
+			Changeset: &btypes.Changeset{
+				OwnedByBatchChangeID: batchChange.ID,
+				RepoID:               repo.ID,
+			},
+			ChangesetSpec: bt.BuildChangesetSpec(t, bt.TestSpecOpts{
+				HeadRef:            "refs/heads/stage-3",
+				BaseRef:            "refs/heads/main",
+				Typ:                btypes.ChangesetSpecTypeBranch,
+				Published:          true,
+				CommitDiff:         []byte("testdiff"),
+				StackStage:         3,
+				StackParentHeadRef: "refs/heads/stage-2",
+			}),
+			Ops:          Operations{btypes.ReconcilerOperationPush},
+			StackBaseRef: "refs/heads/stage-2",
+		}
+
+		gitserverClient := gitserver.NewMockClient()
+		gitserverClient.ResolveRevisionFunc.SetDefaultReturn("", &gitdomain.RevisionNotFoundError{Spec: "refs/heads/stage-2"})
+
+		sourcer := stesting.NewFakeSourcer(nil, &stesting.FakeChangesetSource{Svc: extSvc, CurrentAuthenticator: &auth.OAuthBearerToken{Token: "token"}})
+		_, err := executePlan(actor.WithActor(ctx, actor.FromUser(user.ID)), logger, gitserverClient, sourcer, true, store, plan)
+		if !errors.HasType(err, &gitdomain.RevisionNotFoundError{}) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(gitserverClient.CreateCommitFromPatchFunc.History()) != 0 {
+			t.Fatal("changes pushed without a base")
+		}
+	})
+}
@@ -0,0 +1,257 @@
+// Package rewrite implements the rewrite steps of batch specs, which apply a
+// Comby or regular expression search-and-replace to the files of a workspace
+// without running a container.
+package rewrite
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hexops/gotextdiff"
+	"github.com/hexops/gotextdiff/myers"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/comby"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maxFileSize is the size above which files are not rewritten.
+const maxFileSize = 1 << 20
+
+// genericMatcher is the Comby matcher that is used if a rewrite step doesn't
+// specify one. It applies to files of any language.
+const genericMatcher = ".generic"
+
+// Files maps the paths of files, relative to the root of the repository, to
+// their content.
+type Files map[string][]byte
+
+// Paths returns the sorted paths of the files.
+func (f Files) Paths() []string {
+	paths := make([]string, 0, len(f))
+	for p := range f {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// LoadFiles loads the text files below the given directory of the repository
+// at the given commit from gitserver that any of the given steps could
+// rewrite. An empty directory loads the files of the whole repository. The
+// archive is streamed, so only the candidate files are held in memory. Binary
+// files and files larger than 1 MiB are skipped, since they can't be
+// rewritten.
+//
+// A file that none of the steps match initially can't be changed by any of
+// them, so skipping it doesn't affect the result of applying the steps in
+// order.
+func LoadFiles(ctx context.Context, client gitserver.Client, repo api.RepoName, commit api.CommitID, dir string, steps []*batcheslib.RewriteStep) (Files, error) {
+	filters := make([]*filter, 0, len(steps))
+	for _, step := range steps {
+		f, err := newFilter(step)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, f)
+	}
+
+	files := make(Files)
+	if len(filters) == 0 {
+		return files, nil
+	}
+
+	opts := gitserver.ArchiveOptions{
+		Treeish: string(commit),
+		Format:  gitserver.ArchiveFormatTar,
+	}
+	if dir = strings.Trim(dir, "/"); dir != "" {
+		opts.Pathspecs = []gitdomain.Pathspec{gitdomain.PathspecLiteral(dir)}
+	}
+
+	rc, err := client.ArchiveReader(ctx, repo, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading archive")
+	}
+	defer rc.Close()
+
+	var buf bytes.Buffer
+	tr := tar.NewReader(rc)
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, errors.Wrap(err, "reading archive")
+		}
+		if header.Typeflag != tar.TypeReg || header.Size > maxFileSize {
+			continue
+		}
+		if !anyMatchesPath(filters, header.Name) {
+			continue
+		}
+
+		buf.Reset()
+		if _, err := io.CopyN(&buf, tr, header.Size); err != nil {
+			return nil, errors.Wrap(err, "reading archive")
+		}
+		content := buf.Bytes()
+		if bytes.IndexByte(content, 0) >= 0 || !anyMatches(filters, header.Name, content) {
+			continue
+		}
+		files[header.Name] = bytes.Clone(content)
+	}
+	return files, nil
+}
+
+// filter tells which files a rewrite step could change. It is cheap compared
+// to applying the step.
+type filter struct {
+	// ext is the file extension the step applies to. Empty if the step
+	// applies to files of any language.
+	ext string
+	re  *regexp.Regexp
+}
+
+func newFilter(step *batcheslib.RewriteStep) (*filter, error) {
+	if step.Matcher == batcheslib.RewriteMatcherRegexp {
+		re, err := regexp.Compile(step.Pattern)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid pattern")
+		}
+		return &filter{re: re}, nil
+	}
+
+	re, err := regexp.Compile(comby.StructuralPatToRegexpQuery(step.Pattern, true))
+	if err != nil {
+		return nil, errors.Wrap(err, "building prefilter")
+	}
+	f := &filter{re: re}
+	if step.Matcher != "" && step.Matcher != genericMatcher {
+		f.ext = step.Matcher
+	}
+	return f, nil
+}
+
+func (f *filter) matchesPath(name string) bool {
+	return f.ext == "" || path.Ext(name) == f.ext
+}
+
+func (f *filter) matches(name string, content []byte) bool {
+	return f.matchesPath(name) && f.re.Match(content)
+}
+
+func anyMatchesPath(filters []*filter, name string) bool {
+	for _, f := range filters {
+		if f.matchesPath(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func anyMatches(filters []*filter, name string, content []byte) bool {
+	for _, f := range filters {
+		if f.matches(name, content) {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply applies the given rewrite step to the files and returns the files
+// whose content changed, with their new content. The given files are not
+// modified.
+func Apply(ctx context.Context, logger log.Logger, step *batcheslib.RewriteStep, files Files) (Files, error) {
+	f, err := newFilter(step)
+	if err != nil {
+		return nil, err
+	}
+	if step.Matcher == batcheslib.RewriteMatcherRegexp {
+		return applyRegexp(step, f.re, files)
+	}
+	return applyComby(ctx, logger, step, f, files)
+}
+
+func applyRegexp(step *batcheslib.RewriteStep, re *regexp.Regexp, files Files) (Files, error) {
+	changed := make(Files)
+	for name, content := range files {
+		if !re.Match(content) {
+			continue
+		}
+		rewritten := re.ReplaceAll(content, []byte(step.Rewrite))
+		if !bytes.Equal(content, rewritten) {
+			changed[name] = rewritten
+		}
+	}
+	return changed, nil
+}
+
+func applyComby(ctx context.Context, logger log.Logger, step *batcheslib.RewriteStep, f *filter, files Files) (Files, error) {
+	if !comby.Exists() {
+		return nil, errors.New("comby is not installed")
+	}
+
+	matcher := step.Matcher
+	if matcher == "" {
+		matcher = genericMatcher
+	}
+
+	changed := make(Files)
+	for _, name := range files.Paths() {
+		content := files[name]
+		// Running comby is comparatively expensive, so we skip all files
+		// that can't possibly match.
+		if !f.matches(name, content) {
+			continue
+		}
+
+		replacements, err := comby.Replacements(ctx, logger, comby.Args{
+			Input:           comby.FileContent(content),
+			MatchTemplate:   step.Pattern,
+			RewriteTemplate: step.Rewrite,
+			Matcher:         matcher,
+			ResultKind:      comby.Replacement,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "rewriting %s", name)
+		}
+		if len(replacements) == 0 {
+			continue
+		}
+		if rewritten := []byte(replacements[0].Content); !bytes.Equal(content, rewritten) {
+			changed[name] = rewritten
+		}
+	}
+	return changed, nil
+}
+
+// Diff returns the changes between the original and the modified files in the
+// format of git diff --no-prefix, which is what the diffs of changeset specs
+// use. Files that are not part of modified are unchanged.
+func Diff(original, modified Files) []byte {
+	var buf bytes.Buffer
+	for _, name := range modified.Paths() {
+		from, to := string(original[name]), string(modified[name])
+		if from == to {
+			continue
+		}
+
+		edits := myers.ComputeEdits("", from, to)
+		fmt.Fprintf(&buf, "diff --git %s %s\n", name, name)
+		fmt.Fprint(&buf, gotextdiff.ToUnified(name, name, from, edits))
+	}
+	return buf.Bytes()
+}
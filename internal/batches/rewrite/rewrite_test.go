@@ -0,0 +1,137 @@
+package rewrite
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestLoadFiles(t *testing.T) {
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	for name, content := range map[string]string{
+		"README.md":      "See http://example.com\n",
+		"CHANGELOG.md":   "Nothing to see here\n",
+		"main.go":        "// http://example.com\npackage main\n",
+		"logo.png":       "http://\x00binary",
+		"util/log.go":    "fmt.Println(x)\n",
+		"docs/guide.txt": "fmt.Println(x)\n",
+	} {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	client := gitserver.NewMockClient()
+	client.ArchiveReaderFunc.SetDefaultHook(func(context.Context, api.RepoName, gitserver.ArchiveOptions) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(archive.Bytes())), nil
+	})
+
+	have, err := LoadFiles(context.Background(), client, "github.com/sourcegraph/sourcegraph", "deadbeef", "", []*batcheslib.RewriteStep{
+		{Pattern: `http://(\S+)`, Rewrite: `https://$1`, Matcher: batcheslib.RewriteMatcherRegexp},
+		{Pattern: `fmt.Println(:[args])`, Rewrite: `log.Println(:[args])`, Matcher: ".go"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only text files that one of the steps could rewrite are loaded.
+	want := Files{
+		"README.md":   []byte("See http://example.com\n"),
+		"main.go":     []byte("// http://example.com\npackage main\n"),
+		"util/log.go": []byte("fmt.Println(x)\n"),
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("unexpected files (-want +got):\n%s", diff)
+	}
+
+	if have, err := LoadFiles(context.Background(), client, "github.com/sourcegraph/sourcegraph", "deadbeef", "", nil); err != nil || len(have) != 0 {
+		t.Fatalf("files loaded without steps: %v, %v", have, err)
+	}
+}
+
+func TestApply_Regexp(t *testing.T) {
+	files := Files{
+		"README.md":   []byte("See http://example.com and http://sourcegraph.com.\n"),
+		"main.go":     []byte("package main\n"),
+		"docs/faq.md": []byte("Ask at http://example.com/faq\n"),
+	}
+
+	have, err := Apply(context.Background(), logtest.Scoped(t), &batcheslib.RewriteStep{
+		Pattern: `http://(\S+)`,
+		Rewrite: `https://$1`,
+		Matcher: batcheslib.RewriteMatcherRegexp,
+	}, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Files{
+		"README.md":   []byte("See https://example.com and https://sourcegraph.com.\n"),
+		"docs/faq.md": []byte("Ask at https://example.com/faq\n"),
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("unexpected files (-want +got):\n%s", diff)
+	}
+
+	if string(files["README.md"]) != "See http://example.com and http://sourcegraph.com.\n" {
+		t.Fatal("original files were modified")
+	}
+}
+
+func TestApply_InvalidRegexp(t *testing.T) {
+	_, err := Apply(context.Background(), logtest.Scoped(t), &batcheslib.RewriteStep{
+		Pattern: `(`,
+		Matcher: batcheslib.RewriteMatcherRegexp,
+	}, Files{"README.md": []byte("(")})
+	if err == nil {
+		t.Fatal("no error returned")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	original := Files{
+		"README.md": []byte("# Title\n\nSee http://example.com\n"),
+		"main.go":   []byte("package main\n"),
+		"LICENSE":   []byte("MIT"),
+	}
+	modified := Files{
+		"README.md": []byte("# Title\n\nSee https://example.com\n"),
+		"LICENSE":   []byte("Apache"),
+	}
+
+	want := "diff --git LICENSE LICENSE\n" +
+		"--- LICENSE\n" +
+		"+++ LICENSE\n" +
+		"@@ -1 +1 @@\n" +
+		"-MIT\n" +
+		"\\ No newline at end of file\n" +
+		"+Apache\n" +
+		"\\ No newline at end of file\n" +
+		"diff --git README.md README.md\n" +
+		"--- README.md\n" +
+		"+++ README.md\n" +
+		"@@ -1,3 +1,3 @@\n" +
+		" # Title\n" +
+		" \n" +
+		"-See http://example.com\n" +
+		"+See https://example.com\n"
+	if diff := cmp.Diff(want, string(Diff(original, modified))); diff != "" {
+		t.Fatalf("unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,220 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/batches/sources"
+	"github.com/sourcegraph/sourcegraph/internal/batches/state"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/batches/types/scheduler/config"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const autoMergeInterval = 2 * time.Minute
+
+// AutoMerger merges the changesets of batch changes with an auto-merge policy
+// once their checks pass and they have been approved. Merges only happen
+// inside the configured rollout windows, and at most the number of merges per
+// hour allowed by the policy happen on each code host.
+type AutoMerger struct {
+	store           *store.Store
+	sourcer         sources.Sourcer
+	gitserverClient gitserver.Client
+	logger          log.Logger
+}
+
+// NewAutoMerger creates a goroutine.PeriodicGoroutine that automatically
+// merges changesets.
+func NewAutoMerger(ctx context.Context, logger log.Logger, bstore *store.Store, sourcer sources.Sourcer, gitClient gitserver.Client) goroutine.BackgroundRoutine {
+	m := &AutoMerger{
+		store:           bstore,
+		sourcer:         sourcer,
+		gitserverClient: gitClient,
+		logger:          logger,
+	}
+
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		goroutine.HandlerFunc(m.MergeChangesets),
+		goroutine.WithName("batchchanges.auto-merger"),
+		goroutine.WithDescription("merges approved changesets of batch changes with an auto-merge policy"),
+		goroutine.WithInterval(autoMergeInterval),
+	)
+}
+
+// MergeChangesets considers all changesets of batch changes with an auto-merge
+// policy for merging, and records their progress.
+func (m *AutoMerger) MergeChangesets(ctx context.Context) error {
+	cs, err := m.store.ListChangesetsToAutoMerge(ctx)
+	if err != nil {
+		return errors.Wrap(err, "listing changesets to merge")
+	}
+
+	now := m.store.Clock()()
+	windowOpen := config.ActiveWindow().IsOpen(now)
+	batchChanges := make(map[int64]*btypes.BatchChange)
+
+	var errs error
+	for _, ch := range cs {
+		bc, ok := batchChanges[ch.OwnedByBatchChangeID]
+		if !ok {
+			bc, err = m.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: ch.OwnedByBatchChangeID})
+			if err != nil {
+				errs = errors.Append(errs, errors.Wrapf(err, "loading batch change %d", ch.OwnedByBatchChangeID))
+				continue
+			}
+			batchChanges[bc.ID] = bc
+		}
+
+		if err := m.mergeChangeset(ctx, bc, ch, windowOpen, now); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "merging changeset %d", ch.ID))
+		}
+	}
+	return errs
+}
+
+func (m *AutoMerger) mergeChangeset(ctx context.Context, bc *btypes.BatchChange, ch *btypes.Changeset, windowOpen bool, now time.Time) (err error) {
+	repo, err := m.store.Repos().Get(ctx, ch.RepoID)
+	if err != nil {
+		return errors.Wrap(err, "loading repository")
+	}
+
+	merged, err := m.store.CountChangesetAutoMerges(ctx, store.CountChangesetAutoMergesOpts{
+		BatchChangeID:     bc.ID,
+		ExternalServiceID: repo.ExternalRepo.ServiceID,
+		MergedAfter:       now.Add(-1 * time.Hour),
+	})
+	if err != nil {
+		return errors.Wrap(err, "counting merged changesets")
+	}
+
+	// Changesets without any checks configured on the code host never get a
+	// passed check state, so we need to tell them apart from changesets whose
+	// checks are still running.
+	hasChecks := true
+	if ch.ExternalCheckState == btypes.ChangesetCheckStateUnknown || ch.ExternalCheckState == btypes.ChangesetCheckStatePending {
+		events, _, err := m.store.ListChangesetEvents(ctx, store.ListChangesetEventsOpts{ChangesetIDs: []int64{ch.ID}})
+		if err != nil {
+			return errors.Wrap(err, "loading changeset events")
+		}
+		hasChecks = state.HasChecks(ch, events)
+	}
+
+	progress := &btypes.ChangesetAutoMerge{
+		ChangesetID:   ch.ID,
+		BatchChangeID: bc.ID,
+	}
+	if s, message := autoMergeState(bc, ch, hasChecks, windowOpen, merged); s != "" {
+		progress.State = s
+		progress.Message = message
+		return m.store.UpsertChangesetAutoMerge(ctx, progress)
+	}
+
+	tx, err := m.store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	// Lock the changeset so that the reconciler doesn't process it while we
+	// merge it, and skip it if the reconciler picked it up since it was listed.
+	ch, err = tx.LockChangesetToAutoMerge(ctx, ch.ID)
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil
+		}
+		return errors.Wrap(err, "locking changeset")
+	}
+
+	css, err := m.sourcer.ForChangeset(ctx, tx, ch, sources.AuthenticationStrategyUserCredential, repo)
+	if err != nil {
+		return m.recordFailure(ctx, tx, progress, errors.Wrap(err, "loading changeset source"))
+	}
+	remoteRepo, err := sources.GetRemoteRepo(ctx, css, repo, ch, nil)
+	if err != nil {
+		return m.recordFailure(ctx, tx, progress, errors.Wrap(err, "loading remote repo"))
+	}
+
+	cs := &sources.Changeset{
+		Changeset:  ch,
+		TargetRepo: repo,
+		RemoteRepo: remoteRepo,
+	}
+	if err := css.MergeChangeset(ctx, cs, bc.AutoMergeSquash); err != nil {
+		// The changeset may just not be mergeable right now, e.g. because of
+		// branch protection rules. We try again on the next run.
+		return m.recordFailure(ctx, tx, progress, err)
+	}
+
+	events, err := cs.Changeset.Events()
+	if err != nil {
+		return errors.Wrap(err, "computing changeset events")
+	}
+	state.SetDerivedState(ctx, tx.Repos(), m.gitserverClient, cs.Changeset, events)
+
+	if err := tx.UpsertChangesetEvents(ctx, events...); err != nil {
+		return err
+	}
+	if err := tx.UpdateChangesetCodeHostState(ctx, cs.Changeset); err != nil {
+		return err
+	}
+
+	// Changesets stacked on top of this one are waiting for it to be merged.
+	if cs.ExternalState == btypes.ChangesetExternalStateMerged {
+		if err := tx.EnqueueStackedChangesets(ctx, cs.Changeset); err != nil {
+			return err
+		}
+	}
+
+	progress.State = btypes.ChangesetAutoMergeStateMerged
+	progress.MergedAt = now
+	return tx.UpsertChangesetAutoMerge(ctx, progress)
+}
+
+func (m *AutoMerger) recordFailure(ctx context.Context, tx *store.Store, progress *btypes.ChangesetAutoMerge, err error) error {
+	m.logger.Warn("failed to merge changeset", log.Int64("changeset", progress.ChangesetID), log.Error(err))
+
+	progress.State = btypes.ChangesetAutoMergeStateFailed
+	progress.Message = err.Error()
+	return tx.UpsertChangesetAutoMerge(ctx, progress)
+}
+
+// autoMergeState returns what the given changeset is waiting for before it can
+// be merged, along with a human readable explanation. An empty state is
+// returned if the changeset can be merged right away. Changesets without any
+// checks configured on the code host don't wait for checks.
+func autoMergeState(bc *btypes.BatchChange, ch *btypes.Changeset, hasChecks, windowOpen bool, mergedInLastHour int) (btypes.ChangesetAutoMergeState, string) {
+	switch {
+	case ch.ExternalCheckState == btypes.ChangesetCheckStatePassed:
+	case ch.ExternalCheckState == btypes.ChangesetCheckStateFailed:
+		return btypes.ChangesetAutoMergeStateWaitingForChecks, "Checks failed."
+	case !hasChecks:
+	default:
+		return btypes.ChangesetAutoMergeStateWaitingForChecks, "Checks have not passed yet."
+	}
+
+	switch ch.ExternalReviewState {
+	case btypes.ChangesetReviewStateApproved:
+	case btypes.ChangesetReviewStateChangesRequested:
+		return btypes.ChangesetAutoMergeStateWaitingForReview, "Changes have been requested."
+	default:
+		return btypes.ChangesetAutoMergeStateWaitingForReview, "The changeset has not been approved yet."
+	}
+
+	if !windowOpen {
+		return btypes.ChangesetAutoMergeStateWaitingForWindow, "Waiting for the next rollout window to open."
+	}
+
+	if mergedInLastHour >= int(bc.AutoMergeMaxPerHour) {
+		return btypes.ChangesetAutoMergeStateRateLimited, fmt.Sprintf("%d changesets of this batch change have been merged on this code host in the last hour.", mergedInLastHour)
+	}
+
+	return "", ""
+}
@@ -0,0 +1,93 @@
+package scheduler
+
+import (
+	"testing"
+
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+)
+
+func TestAutoMergeState(t *testing.T) {
+	bc := &btypes.BatchChange{AutoMerge: true, AutoMergeMaxPerHour: 2}
+
+	for name, tc := range map[string]struct {
+		checkState  btypes.ChangesetCheckState
+		noChecks    bool
+		reviewState btypes.ChangesetReviewState
+		windowOpen  bool
+		merged      int
+		want        btypes.ChangesetAutoMergeState
+	}{
+		"pending checks": {
+			checkState:  btypes.ChangesetCheckStatePending,
+			reviewState: btypes.ChangesetReviewStateApproved,
+			windowOpen:  true,
+			want:        btypes.ChangesetAutoMergeStateWaitingForChecks,
+		},
+		"unknown checks": {
+			checkState:  btypes.ChangesetCheckStateUnknown,
+			reviewState: btypes.ChangesetReviewStateApproved,
+			windowOpen:  true,
+			want:        btypes.ChangesetAutoMergeStateWaitingForChecks,
+		},
+		"no checks configured": {
+			checkState:  btypes.ChangesetCheckStateUnknown,
+			noChecks:    true,
+			reviewState: btypes.ChangesetReviewStateApproved,
+			windowOpen:  true,
+			want:        "",
+		},
+		"no checks configured and not approved": {
+			checkState:  btypes.ChangesetCheckStateUnknown,
+			noChecks:    true,
+			reviewState: btypes.ChangesetReviewStatePending,
+			windowOpen:  true,
+			want:        btypes.ChangesetAutoMergeStateWaitingForReview,
+		},
+		"failed checks": {
+			checkState:  btypes.ChangesetCheckStateFailed,
+			reviewState: btypes.ChangesetReviewStateApproved,
+			windowOpen:  true,
+			want:        btypes.ChangesetAutoMergeStateWaitingForChecks,
+		},
+		"not approved": {
+			checkState:  btypes.ChangesetCheckStatePassed,
+			reviewState: btypes.ChangesetReviewStatePending,
+			windowOpen:  true,
+			want:        btypes.ChangesetAutoMergeStateWaitingForReview,
+		},
+		"outside of rollout window": {
+			checkState:  btypes.ChangesetCheckStatePassed,
+			reviewState: btypes.ChangesetReviewStateApproved,
+			windowOpen:  false,
+			want:        btypes.ChangesetAutoMergeStateWaitingForWindow,
+		},
+		"rate limited": {
+			checkState:  btypes.ChangesetCheckStatePassed,
+			reviewState: btypes.ChangesetReviewStateApproved,
+			windowOpen:  true,
+			merged:      2,
+			want:        btypes.ChangesetAutoMergeStateRateLimited,
+		},
+		"mergeable": {
+			checkState:  btypes.ChangesetCheckStatePassed,
+			reviewState: btypes.ChangesetReviewStateApproved,
+			windowOpen:  true,
+			merged:      1,
+			want:        "",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ch := &btypes.Changeset{
+				ExternalCheckState:  tc.checkState,
+				ExternalReviewState: tc.reviewState,
+			}
+			have, message := autoMergeState(bc, ch, !tc.noChecks, tc.windowOpen, tc.merged)
+			if have != tc.want {
+				t.Errorf("wrong state. want=%q, have=%q", tc.want, have)
+			}
+			if have != "" && message == "" {
+				t.Error("no message for state")
+			}
+		})
+	}
+}
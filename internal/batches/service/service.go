@@ -74,7 +74,13 @@ type Service struct {
 type operations struct {
 	createBatchSpec                      *observation.Operation
 	createBatchSpecFromRaw               *observation.Operation
+	createBatchSpecFromTemplate          *observation.Operation
+	createBatchSpecTemplate              *observation.Operation
+	updateBatchSpecTemplate              *observation.Operation
+	deleteBatchSpecTemplate              *observation.Operation
 	executeBatchSpec                     *observation.Operation
+	previewBatchSpecExecution            *observation.Operation
+	getBatchSpecExecutionPreviewSummary  *observation.Operation
 	cancelBatchSpec                      *observation.Operation
 	replaceBatchSpecInput                *observation.Operation
 	upsertBatchSpecInput                 *observation.Operation
@@ -85,6 +91,8 @@ type operations struct {
 	getNewestBatchSpec                   *observation.Operation
 	moveBatchChange                      *observation.Operation
 	closeBatchChange                     *observation.Operation
+	setBatchChangeAutoRebase             *observation.Operation
+	setBatchChangeAutoMergePolicy        *observation.Operation
 	deleteBatchChange                    *observation.Operation
 	enqueueChangesetSync                 *observation.Operation
 	reenqueueChangeset                   *observation.Operation
@@ -95,6 +103,7 @@ type operations struct {
 	applyBatchChange                     *observation.Operation
 	reconcileBatchChange                 *observation.Operation
 	validateChangesetSpecs               *observation.Operation
+	rollbackBatchChange                  *observation.Operation
 }
 
 var (
@@ -124,7 +133,13 @@ func newOperations(observationCtx *observation.Context) *operations {
 		singletonOperations = &operations{
 			createBatchSpec:                      op("CreateBatchSpec"),
 			createBatchSpecFromRaw:               op("CreateBatchSpecFromRaw"),
+			createBatchSpecFromTemplate:          op("CreateBatchSpecFromTemplate"),
+			createBatchSpecTemplate:              op("CreateBatchSpecTemplate"),
+			updateBatchSpecTemplate:              op("UpdateBatchSpecTemplate"),
+			deleteBatchSpecTemplate:              op("DeleteBatchSpecTemplate"),
 			executeBatchSpec:                     op("ExecuteBatchSpec"),
+			previewBatchSpecExecution:            op("PreviewBatchSpecExecution"),
+			getBatchSpecExecutionPreviewSummary:  op("GetBatchSpecExecutionPreviewSummary"),
 			cancelBatchSpec:                      op("CancelBatchSpec"),
 			replaceBatchSpecInput:                op("ReplaceBatchSpecInput"),
 			upsertBatchSpecInput:                 op("UpsertBatchSpecInput"),
@@ -135,6 +150,8 @@ func newOperations(observationCtx *observation.Context) *operations {
 			getNewestBatchSpec:                   op("GetNewestBatchSpec"),
 			moveBatchChange:                      op("MoveBatchChange"),
 			closeBatchChange:                     op("CloseBatchChange"),
+			setBatchChangeAutoRebase:             op("SetBatchChangeAutoRebase"),
+			setBatchChangeAutoMergePolicy:        op("SetBatchChangeAutoMergePolicy"),
 			deleteBatchChange:                    op("DeleteBatchChange"),
 			enqueueChangesetSync:                 op("EnqueueChangesetSync"),
 			reenqueueChangeset:                   op("ReenqueueChangeset"),
@@ -145,6 +162,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 			applyBatchChange:                     op("ApplyBatchChange"),
 			reconcileBatchChange:                 op("ReconcileBatchChange"),
 			validateChangesetSpecs:               op("ValidateChangesetSpecs"),
+			rollbackBatchChange:                  op("RollbackBatchChange"),
 		}
 	})
 
@@ -610,50 +628,64 @@ func (s *Service) ExecuteBatchSpec(ctx context.Context, opts ExecuteBatchSpecOpt
 	}
 	defer func() { err = tx.Done(err) }()
 
-	resolutionJob, err := tx.GetBatchSpecResolutionJob(ctx, store.GetBatchSpecResolutionJobOpts{BatchSpecID: batchSpec.ID})
+	if err := prepareBatchSpecExecution(ctx, tx, batchSpec, opts.NoCache); err != nil {
+		return nil, err
+	}
+
+	err = tx.CreateBatchSpecWorkspaceExecutionJobs(ctx, batchSpec.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.MarkSkippedBatchSpecWorkspaces(ctx, batchSpec.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The workspaces executed in an execution preview are now part of the
+	// full execution.
+	err = tx.DeleteBatchSpecExecutionPreview(ctx, batchSpec.ID)
 	if err != nil {
 		return nil, err
 	}
 
+	return batchSpec, nil
+}
+
+// prepareBatchSpecExecution checks that the workspaces of the batch spec have
+// been resolved successfully and disables the execution cache if requested.
+func prepareBatchSpecExecution(ctx context.Context, tx *store.Store, batchSpec *btypes.BatchSpec, noCache *bool) error {
+	resolutionJob, err := tx.GetBatchSpecResolutionJob(ctx, store.GetBatchSpecResolutionJobOpts{BatchSpecID: batchSpec.ID})
+	if err != nil {
+		return err
+	}
+
 	switch resolutionJob.State {
 	case btypes.BatchSpecResolutionJobStateErrored, btypes.BatchSpecResolutionJobStateFailed:
-		return nil, ErrBatchSpecResolutionErrored{resolutionJob.FailureMessage}
+		return ErrBatchSpecResolutionErrored{resolutionJob.FailureMessage}
 
 	case btypes.BatchSpecResolutionJobStateCompleted:
 		// Continue below the switch statement.
 
 	default:
-		return nil, ErrBatchSpecResolutionIncomplete
+		return ErrBatchSpecResolutionIncomplete
 	}
 
 	// If the batch spec nocache flag doesn't match what's been provided in the API,
 	// update the batch spec state in the db.
-	if opts.NoCache != nil && batchSpec.NoCache != *opts.NoCache {
-		batchSpec.NoCache = *opts.NoCache
+	if noCache != nil && batchSpec.NoCache != *noCache {
+		batchSpec.NoCache = *noCache
 		if err := tx.UpdateBatchSpec(ctx, batchSpec); err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	// Disable caching if requested.
 	if batchSpec.NoCache {
-		err = tx.DisableBatchSpecWorkspaceExecutionCache(ctx, batchSpec.ID)
-		if err != nil {
-			return nil, err
-		}
+		return tx.DisableBatchSpecWorkspaceExecutionCache(ctx, batchSpec.ID)
 	}
 
-	err = tx.CreateBatchSpecWorkspaceExecutionJobs(ctx, batchSpec.ID)
-	if err != nil {
-		return nil, err
-	}
-
-	err = tx.MarkSkippedBatchSpecWorkspaces(ctx, batchSpec.ID)
-	if err != nil {
-		return nil, err
-	}
-
-	return batchSpec, nil
+	return nil
 }
 
 var ErrBatchSpecNotCancelable = errors.New("batch spec is not in cancelable state")
@@ -1036,6 +1068,90 @@ func (s *Service) MoveBatchChange(ctx context.Context, opts MoveBatchChangeOpts)
 	return batchChange, tx.UpdateBatchChange(ctx, batchChange)
 }
 
+// ErrInvalidAutoRebaseMaxBehind is returned when the number of commits a
+// changeset can fall behind its base branch is negative.
+var ErrInvalidAutoRebaseMaxBehind = errors.New("the maximum number of commits behind must not be negative")
+
+// SetBatchChangeAutoRebase enables or disables the automatic rebasing of the
+// changesets of the batch change with the given ID onto their moved base
+// branches. If maxBehind is nil, the current value is kept.
+func (s *Service) SetBatchChangeAutoRebase(ctx context.Context, id int64, enabled bool, maxBehind *int32) (batchChange *btypes.BatchChange, err error) {
+	ctx, _, endObservation := s.operations.setBatchChangeAutoRebase.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	if maxBehind != nil && *maxBehind < 0 {
+		return nil, ErrInvalidAutoRebaseMaxBehind
+	}
+
+	batchChange, err = s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: id})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting batch change")
+	}
+
+	if err := s.checkViewerCanAdminister(ctx, batchChange.NamespaceOrgID, batchChange.CreatorID, false); err != nil {
+		return nil, err
+	}
+
+	if batchChange.AutoRebase == enabled && (maxBehind == nil || batchChange.AutoRebaseMaxBehind == *maxBehind) {
+		return batchChange, nil
+	}
+
+	batchChange.AutoRebase = enabled
+	if maxBehind != nil {
+		batchChange.AutoRebaseMaxBehind = *maxBehind
+	}
+	if err := s.store.UpdateBatchChangeAutoRebase(ctx, batchChange); err != nil {
+		return nil, err
+	}
+	return batchChange, nil
+}
+
+// ErrInvalidAutoMergeRate is returned when an auto-merge policy would not allow
+// any merges.
+var ErrInvalidAutoMergeRate = errors.New("the maximum number of merges per hour must be greater than zero")
+
+// SetBatchChangeAutoMergePolicyOpts are the options for updating the
+// auto-merge policy of a batch change. Nil fields keep their current value.
+type SetBatchChangeAutoMergePolicyOpts struct {
+	BatchChangeID int64
+
+	Enabled          bool
+	MaxMergesPerHour *int32
+	Squash           *bool
+}
+
+// SetBatchChangeAutoMergePolicy updates the policy under which the changesets
+// of the batch change are merged automatically once they are mergeable.
+func (s *Service) SetBatchChangeAutoMergePolicy(ctx context.Context, opts SetBatchChangeAutoMergePolicyOpts) (batchChange *btypes.BatchChange, err error) {
+	ctx, _, endObservation := s.operations.setBatchChangeAutoMergePolicy.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	if opts.MaxMergesPerHour != nil && *opts.MaxMergesPerHour <= 0 {
+		return nil, ErrInvalidAutoMergeRate
+	}
+
+	batchChange, err = s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: opts.BatchChangeID})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting batch change")
+	}
+
+	if err := s.checkViewerCanAdminister(ctx, batchChange.NamespaceOrgID, batchChange.CreatorID, false); err != nil {
+		return nil, err
+	}
+
+	batchChange.AutoMerge = opts.Enabled
+	if opts.MaxMergesPerHour != nil {
+		batchChange.AutoMergeMaxPerHour = *opts.MaxMergesPerHour
+	}
+	if opts.Squash != nil {
+		batchChange.AutoMergeSquash = *opts.Squash
+	}
+	if err := s.store.UpdateBatchChangeAutoMerge(ctx, batchChange); err != nil {
+		return nil, err
+	}
+	return batchChange, nil
+}
+
 // CloseBatchChange closes the BatchChange with the given ID if it has not been closed yet.
 func (s *Service) CloseBatchChange(ctx context.Context, id int64, closeChangesets bool) (batchChange *btypes.BatchChange, err error) {
 	ctx, _, endObservation := s.operations.closeBatchChange.With(ctx, &err, observation.Args{})
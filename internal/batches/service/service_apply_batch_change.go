@@ -28,6 +28,11 @@ var ErrMatchingBatchChangeExists = errors.New("a batch change matching the given
 // batchSpec exists in the given namespace but has a different ID.
 var ErrEnsureBatchChangeFailed = errors.New("a batch change in the given namespace and with the given name exists but does not match the given ID")
 
+// ErrApplyPreviewBatchSpec is returned by ApplyBatchChange if the batch spec
+// has only been executed on a sample of its workspaces in an execution
+// preview.
+var ErrApplyPreviewBatchSpec = errors.New("batch spec has only been executed in an execution preview; execute all workspaces before applying it")
+
 type ApplyBatchChangeOpts struct {
 	BatchSpecRandID     string
 	EnsureBatchChangeID int64
@@ -71,6 +76,14 @@ func (s *Service) ApplyBatchChange(
 		return nil, err
 	}
 
+	// A batch spec that has only been executed on a sample of its workspaces
+	// would close the changesets of all other workspaces.
+	if _, err := s.store.GetBatchSpecExecutionPreview(ctx, batchSpec.ID); err == nil {
+		return nil, ErrApplyPreviewBatchSpec
+	} else if err != store.ErrNoResults {
+		return nil, err
+	}
+
 	// Validate ChangesetSpecs and return error if they're invalid and the
 	// BatchSpec can't be applied safely.
 	if err := s.ValidateChangesetSpecs(ctx, batchSpec.ID); err != nil {
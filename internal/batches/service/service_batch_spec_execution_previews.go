@@ -0,0 +1,283 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	godiff "github.com/sourcegraph/go-diff/diff"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ErrInvalidPreviewSampleSize is returned by PreviewBatchSpecExecution if the
+// sample size is not positive.
+var ErrInvalidPreviewSampleSize = errors.New("the sample size of an execution preview must be greater than zero")
+
+// ErrBatchSpecAlreadyExecuted is returned by PreviewBatchSpecExecution if the
+// batch spec has already been executed or previewed.
+var ErrBatchSpecAlreadyExecuted = errors.New("batch spec has already been executed")
+
+type PreviewBatchSpecExecutionOpts struct {
+	BatchSpecRandID string
+	// SampleSize is the number of workspaces to execute. If the batch spec
+	// has fewer workspaces to execute, all of them are executed.
+	SampleSize int
+	NoCache    *bool
+}
+
+// PreviewBatchSpecExecution executes the batch spec on a random sample of the
+// workspaces that a full execution would execute. The changes produced by the
+// sample can be inspected with GetBatchSpecExecutionPreviewSummary before
+// executing the remaining workspaces with ExecuteBatchSpec. Until then, the
+// batch spec cannot be applied.
+func (s *Service) PreviewBatchSpecExecution(ctx context.Context, opts PreviewBatchSpecExecutionOpts) (batchSpec *btypes.BatchSpec, err error) {
+	ctx, _, endObservation := s.operations.previewBatchSpecExecution.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("BatchSpecRandID", opts.BatchSpecRandID),
+		attribute.Int("SampleSize", opts.SampleSize),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if opts.SampleSize <= 0 {
+		return nil, ErrInvalidPreviewSampleSize
+	}
+
+	batchSpec, err = s.store.GetBatchSpec(ctx, store.GetBatchSpecOpts{RandID: opts.BatchSpecRandID})
+	if err != nil {
+		return nil, err
+	}
+
+	// Check whether the current user has access to either one of the namespaces.
+	err = s.CheckNamespaceAccess(ctx, batchSpec.NamespaceUserID, batchSpec.NamespaceOrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	jobs, err := tx.ListBatchSpecWorkspaceExecutionJobs(ctx, store.ListBatchSpecWorkspaceExecutionJobsOpts{
+		BatchSpecID: batchSpec.ID,
+		ExcludeRank: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(jobs) > 0 {
+		return nil, ErrBatchSpecAlreadyExecuted
+	}
+
+	if err := prepareBatchSpecExecution(ctx, tx, batchSpec, opts.NoCache); err != nil {
+		return nil, err
+	}
+
+	workspaces, _, err := tx.ListBatchSpecWorkspaces(ctx, store.ListBatchSpecWorkspacesOpts{
+		BatchSpecID:                      batchSpec.ID,
+		OnlyWithoutExecutionAndNotCached: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Only consider the workspaces that a full execution would execute.
+	var candidates []int64
+	for _, w := range workspaces {
+		if (w.Ignored && !batchSpec.AllowIgnored) || (w.Unsupported && !batchSpec.AllowUnsupported) {
+			continue
+		}
+		candidates = append(candidates, w.ID)
+	}
+
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	sample := candidates
+	if len(sample) > opts.SampleSize {
+		sample = sample[:opts.SampleSize]
+	}
+
+	if len(sample) > 0 {
+		if err := tx.CreateBatchSpecWorkspaceExecutionJobsForWorkspaces(ctx, sample); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.MarkSkippedBatchSpecWorkspaces(ctx, batchSpec.ID); err != nil {
+		return nil, err
+	}
+
+	err = tx.CreateBatchSpecExecutionPreview(ctx, &btypes.BatchSpecExecutionPreview{
+		BatchSpecID: batchSpec.ID,
+		SampleSize:  int32(len(sample)),
+		Workspaces:  int32(len(candidates)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return batchSpec, nil
+}
+
+// BatchSpecExecutionPreviewSummary summarizes the changes produced by the
+// sampled workspaces of a batch spec execution preview.
+type BatchSpecExecutionPreviewSummary struct {
+	CompletedWorkspaces int
+	FailedWorkspaces    int
+
+	// DiffStat is the combined diff stat of all changeset specs produced by
+	// the completed workspaces.
+	DiffStat godiff.Stat
+	// FileExtensions breaks DiffStat down by the extension of the changed
+	// files, ordered by the number of changed files.
+	FileExtensions []FileExtensionDiffStat
+
+	// EstimatedExecutionTime is the estimated time it takes to execute all
+	// workspaces one after another, based on the average execution time of
+	// the completed workspaces. It is nil while no workspace has completed.
+	EstimatedExecutionTime *time.Duration
+}
+
+// FileExtensionDiffStat is the diff stat of the changed files with the given
+// extension. Files without an extension have an empty Extension.
+type FileExtensionDiffStat struct {
+	Extension string
+	Files     int
+	DiffStat  godiff.Stat
+}
+
+// GetBatchSpecExecutionPreviewSummary summarizes the progress and the changes
+// of the sampled workspaces of the given execution preview.
+func (s *Service) GetBatchSpecExecutionPreviewSummary(ctx context.Context, preview *btypes.BatchSpecExecutionPreview) (summary *BatchSpecExecutionPreviewSummary, err error) {
+	ctx, _, endObservation := s.operations.getBatchSpecExecutionPreviewSummary.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int64("BatchSpecID", preview.BatchSpecID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	jobs, err := s.store.ListBatchSpecWorkspaceExecutionJobs(ctx, store.ListBatchSpecWorkspaceExecutionJobsOpts{
+		BatchSpecID: preview.BatchSpecID,
+		ExcludeRank: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	summary = &BatchSpecExecutionPreviewSummary{}
+	var (
+		completedWorkspaceIDs []int64
+		totalDuration         time.Duration
+	)
+	for _, j := range jobs {
+		switch j.State {
+		case btypes.BatchSpecWorkspaceExecutionJobStateCompleted:
+			summary.CompletedWorkspaces++
+			completedWorkspaceIDs = append(completedWorkspaceIDs, j.BatchSpecWorkspaceID)
+			totalDuration += j.FinishedAt.Sub(j.StartedAt)
+		case btypes.BatchSpecWorkspaceExecutionJobStateFailed, btypes.BatchSpecWorkspaceExecutionJobStateCanceled:
+			summary.FailedWorkspaces++
+		}
+	}
+
+	if summary.CompletedWorkspaces > 0 {
+		estimate := totalDuration / time.Duration(summary.CompletedWorkspaces) * time.Duration(preview.Workspaces)
+		summary.EstimatedExecutionTime = &estimate
+	}
+
+	if len(completedWorkspaceIDs) == 0 {
+		return summary, nil
+	}
+
+	workspaces, _, err := s.store.ListBatchSpecWorkspaces(ctx, store.ListBatchSpecWorkspacesOpts{
+		IDs: completedWorkspaceIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var changesetSpecIDs []int64
+	for _, w := range workspaces {
+		changesetSpecIDs = append(changesetSpecIDs, w.ChangesetSpecIDs...)
+	}
+	if len(changesetSpecIDs) == 0 {
+		return summary, nil
+	}
+
+	specs, _, err := s.store.ListChangesetSpecs(ctx, store.ListChangesetSpecsOpts{IDs: changesetSpecIDs})
+	if err != nil {
+		return nil, err
+	}
+	diffs := make([][]byte, 0, len(specs))
+	for _, spec := range specs {
+		diffs = append(diffs, spec.Diff)
+	}
+
+	summary.DiffStat, summary.FileExtensions, err = diffStatsByFileExtension(diffs)
+	if err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// diffStatsByFileExtension returns the combined diff stat of the given diffs
+// and its breakdown by the extension of the changed files.
+func diffStatsByFileExtension(diffs [][]byte) (total godiff.Stat, byExtension []FileExtensionDiffStat, err error) {
+	stats := make(map[string]*FileExtensionDiffStat)
+	for _, d := range diffs {
+		reader := godiff.NewMultiFileDiffReader(bytes.NewReader(d))
+		for {
+			fileDiff, err := reader.ReadFile()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return godiff.Stat{}, nil, errors.Wrap(err, "parsing diff")
+			}
+
+			// Deleted files only have their original name.
+			name := fileDiff.NewName
+			if name == "/dev/null" {
+				name = fileDiff.OrigName
+			}
+			ext := strings.ToLower(path.Ext(name))
+
+			stat := fileDiff.Stat()
+			total.Added += stat.Added
+			total.Changed += stat.Changed
+			total.Deleted += stat.Deleted
+
+			s, ok := stats[ext]
+			if !ok {
+				s = &FileExtensionDiffStat{Extension: ext}
+				stats[ext] = s
+			}
+			s.Files++
+			s.DiffStat.Added += stat.Added
+			s.DiffStat.Changed += stat.Changed
+			s.DiffStat.Deleted += stat.Deleted
+		}
+	}
+
+	byExtension = make([]FileExtensionDiffStat, 0, len(stats))
+	for _, s := range stats {
+		byExtension = append(byExtension, *s)
+	}
+	sort.Slice(byExtension, func(i, j int) bool {
+		if byExtension[i].Files != byExtension[j].Files {
+			return byExtension[i].Files > byExtension[j].Files
+		}
+		return byExtension[i].Extension < byExtension[j].Extension
+	})
+
+	return total, byExtension, nil
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	godiff "github.com/sourcegraph/go-diff/diff"
+)
+
+func TestDiffStatsByFileExtension(t *testing.T) {
+	diffs := [][]byte{
+		[]byte(`diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1,2 +1,2 @@
+ package main
+-var a = 1
++var a = 2
+diff --git a/README.md b/README.md
+--- a/README.md
++++ /dev/null
+@@ -1 +0,0 @@
+-# README
+`),
+		[]byte(`diff --git a/cmd/util.GO b/cmd/util.GO
+--- /dev/null
++++ b/cmd/util.GO
+@@ -0,0 +1,2 @@
++package cmd
++
+diff --git a/Makefile b/Makefile
+--- a/Makefile
++++ b/Makefile
+@@ -1 +1,2 @@
+ all:
++	go build
+`),
+	}
+
+	total, byExtension, err := diffStatsByFileExtension(diffs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (godiff.Stat{Added: 3, Changed: 1, Deleted: 1}); total != want {
+		t.Errorf("wrong total diff stat. want=%+v, have=%+v", want, total)
+	}
+
+	want := []FileExtensionDiffStat{
+		{Extension: ".go", Files: 2, DiffStat: godiff.Stat{Added: 2, Changed: 1}},
+		{Extension: "", Files: 1, DiffStat: godiff.Stat{Added: 1}},
+		{Extension: ".md", Files: 1, DiffStat: godiff.Stat{Deleted: 1}},
+	}
+	if diff := cmp.Diff(want, byExtension); diff != "" {
+		t.Errorf("wrong diff stats by file extension (-want +have):\n%s", diff)
+	}
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	sgactor "github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// CreateBatchSpecTemplate parses the given raw template and publishes it to
+// the batch spec templates library. Only site admins can publish templates.
+func (s *Service) CreateBatchSpecTemplate(ctx context.Context, rawTemplate string) (tmpl *btypes.BatchSpecTemplate, err error) {
+	ctx, _, endObservation := s.operations.createBatchSpecTemplate.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	// 🚨 SECURITY: Only site admins can publish templates.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, s.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	tmpl, err = btypes.NewBatchSpecTemplateFromRaw(rawTemplate)
+	if err != nil {
+		return nil, err
+	}
+	tmpl.CreatorID = sgactor.FromContext(ctx).UID
+
+	if err := s.store.CreateBatchSpecTemplate(ctx, tmpl); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// UpdateBatchSpecTemplate replaces the template of the batch spec template
+// with the given ID. Only site admins can update templates.
+func (s *Service) UpdateBatchSpecTemplate(ctx context.Context, id int64, rawTemplate string) (tmpl *btypes.BatchSpecTemplate, err error) {
+	ctx, _, endObservation := s.operations.updateBatchSpecTemplate.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int64("ID", id),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	// 🚨 SECURITY: Only site admins can update templates.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, s.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	tmpl, err = btypes.NewBatchSpecTemplateFromRaw(rawTemplate)
+	if err != nil {
+		return nil, err
+	}
+	tmpl.ID = id
+
+	if err := s.store.UpdateBatchSpecTemplate(ctx, tmpl); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// DeleteBatchSpecTemplate removes the batch spec template with the given ID
+// from the library. Batch specs created from it are not affected. Only site
+// admins can delete templates.
+func (s *Service) DeleteBatchSpecTemplate(ctx context.Context, id int64) (err error) {
+	ctx, _, endObservation := s.operations.deleteBatchSpecTemplate.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int64("ID", id),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	// 🚨 SECURITY: Only site admins can delete templates.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, s.store.DatabaseDB()); err != nil {
+		return err
+	}
+
+	return s.store.DeleteBatchSpecTemplate(ctx, id)
+}
+
+// CreateBatchSpecFromTemplateOpts are the options for
+// CreateBatchSpecFromTemplate.
+type CreateBatchSpecFromTemplateOpts struct {
+	TemplateID int64
+	// Values are the values of the parameters of the template, keyed by the
+	// name of the parameter.
+	Values map[string]any
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+
+	AllowIgnored     bool
+	AllowUnsupported bool
+	NoCache          bool
+
+	BatchChange int64
+}
+
+// CreateBatchSpecFromTemplate renders the batch spec template with the given
+// values and creates a batch spec from the result, like CreateBatchSpecFromRaw.
+func (s *Service) CreateBatchSpecFromTemplate(ctx context.Context, opts CreateBatchSpecFromTemplateOpts) (spec *btypes.BatchSpec, err error) {
+	ctx, _, endObservation := s.operations.createBatchSpecFromTemplate.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int64("templateID", opts.TemplateID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	tmpl, err := s.store.GetBatchSpecTemplate(ctx, store.GetBatchSpecTemplateOpts{ID: opts.TemplateID})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting batch spec template")
+	}
+
+	rawSpec, err := tmpl.Template.Render(opts.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.CreateBatchSpecFromRaw(ctx, CreateBatchSpecFromRawOpts{
+		RawSpec:          rawSpec,
+		NamespaceUserID:  opts.NamespaceUserID,
+		NamespaceOrgID:   opts.NamespaceOrgID,
+		AllowIgnored:     opts.AllowIgnored,
+		AllowUnsupported: opts.AllowUnsupported,
+		NoCache:          opts.NoCache,
+		BatchChange:      opts.BatchChange,
+	})
+}
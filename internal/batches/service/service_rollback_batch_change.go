@@ -0,0 +1,296 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go/relay"
+	godiff "github.com/sourcegraph/go-diff/diff"
+	"go.opentelemetry.io/otel/attribute"
+	"gopkg.in/yaml.v2"
+
+	sgactor "github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store/author"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ErrNoRevertibleChangesets is returned by RollbackBatchChange if none of the
+// changesets of the batch change can be reverted.
+var ErrNoRevertibleChangesets = errors.New("batch change has no merged changesets that can be reverted")
+
+type RollbackBatchChangeOpts struct {
+	BatchChangeID int64
+	// Publish is true if the reverting changesets should be published right
+	// away.
+	Publish bool
+}
+
+// RollbackBatchChange creates a new batch change in the namespace of the given
+// batch change that reverts each of its merged changesets. The changesets of
+// the new batch change revert the merge commit of the changeset they belong
+// to and are published through the same code hosts once applied. Merged
+// changesets that can't be reverted, for example because their merge commit is
+// unknown, are skipped and listed in the description of the new batch change.
+func (s *Service) RollbackBatchChange(ctx context.Context, gitserverClient gitserver.Client, opts RollbackBatchChangeOpts) (batchChange *btypes.BatchChange, err error) {
+	ctx, _, endObservation := s.operations.rollbackBatchChange.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int64("BatchChangeID", opts.BatchChangeID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	original, err := s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: opts.BatchChangeID})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only site-admins or the creator of the batch change can
+	// roll it back.
+	if err := s.checkViewerCanAdminister(ctx, original.NamespaceOrgID, original.CreatorID, false); err != nil {
+		return nil, err
+	}
+
+	changesets, _, err := s.store.ListChangesets(ctx, store.ListChangesetsOpts{
+		OwnedByBatchChangeID: original.ID,
+		ExternalStates:       []btypes.ChangesetExternalState{btypes.ChangesetExternalStateMerged},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: database.Repos.GetReposSetByIDs uses the authzFilter under
+	// the hood and filters out repositories that the user doesn't have access
+	// to.
+	reposByID, err := s.store.Repos().GetReposSetByIDs(ctx, changesets.RepoIDs()...)
+	if err != nil {
+		return nil, err
+	}
+
+	a := sgactor.FromContext(ctx)
+	changesetAuthor, err := author.GetChangesetAuthorForUser(ctx, database.UsersWith(s.logger, s.store), a.UID)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating changeset author")
+	}
+	if changesetAuthor == nil {
+		// The user has no primary email, so we use the same default author
+		// as for changesets created by batch specs.
+		changesetAuthor = &batcheslib.ChangesetSpecAuthor{
+			Name:  "Sourcegraph",
+			Email: "batch-changes@sourcegraph.com",
+		}
+	}
+
+	var (
+		specs   []*btypes.ChangesetSpec
+		skipped []string
+	)
+	for _, ch := range changesets {
+		repo, ok := reposByID[ch.RepoID]
+		if !ok {
+			continue
+		}
+
+		spec, err := revertChangesetSpec(ctx, gitserverClient, repo, ch, changesetAuthor, opts.Publish)
+		if err != nil {
+			var notRevertible notRevertibleError
+			if ctx.Err() != nil || !errors.As(err, &notRevertible) {
+				return nil, errors.Wrapf(err, "reverting changeset %d", ch.ID)
+			}
+			url, _ := ch.URL()
+			skipped = append(skipped, fmt.Sprintf("%s: %s", url, notRevertible.reason))
+			continue
+		}
+		spec.UserID = a.UID
+		spec.RevertedChangesetID = ch.ID
+		specs = append(specs, spec)
+	}
+
+	if len(specs) == 0 {
+		return nil, ErrNoRevertibleChangesets
+	}
+
+	description := fmt.Sprintf("Reverts the merged changesets of batch change %s.", original.Name)
+	if len(skipped) > 0 {
+		description += "\n\nThe following changesets could not be reverted:\n"
+		for _, entry := range skipped {
+			description += "\n- " + entry
+		}
+	}
+	rawSpec, err := yaml.Marshal(struct {
+		Name        string `yaml:"name"`
+		Description string `yaml:"description"`
+	}{Name: original.Name + "-rollback", Description: description})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling batch spec")
+	}
+
+	batchSpec, err := btypes.NewBatchSpecFromRaw(string(rawSpec))
+	if err != nil {
+		return nil, err
+	}
+	batchSpec.NamespaceUserID = original.NamespaceUserID
+	batchSpec.NamespaceOrgID = original.NamespaceOrgID
+	batchSpec.UserID = a.UID
+
+	if err := s.createRollbackBatchSpec(ctx, batchSpec, specs); err != nil {
+		return nil, err
+	}
+
+	return s.ApplyBatchChange(ctx, ApplyBatchChangeOpts{
+		BatchSpecRandID:         batchSpec.RandID,
+		FailIfBatchChangeExists: true,
+	})
+}
+
+func (s *Service) createRollbackBatchSpec(ctx context.Context, batchSpec *btypes.BatchSpec, specs []*btypes.ChangesetSpec) (err error) {
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.CreateBatchSpec(ctx, batchSpec); err != nil {
+		return err
+	}
+	for _, spec := range specs {
+		spec.BatchSpecID = batchSpec.ID
+	}
+	return tx.CreateChangesetSpec(ctx, specs...)
+}
+
+// notRevertibleError is returned by revertChangesetSpec if the changeset can't
+// be reverted. Such changesets are skipped by RollbackBatchChange.
+type notRevertibleError struct {
+	reason string
+}
+
+func (e notRevertibleError) Error() string {
+	return e.reason
+}
+
+// revertChangesetSpec builds a changeset spec that reverts the changes the
+// given changeset merged, on top of the current head of its base branch. If the
+// changeset can't be reverted, a notRevertibleError is returned.
+func revertChangesetSpec(ctx context.Context, gitserverClient gitserver.Client, repo *types.Repo, ch *btypes.Changeset, changesetAuthor *batcheslib.ChangesetSpecAuthor, publish bool) (*btypes.ChangesetSpec, error) {
+	mergeCommit, err := ch.MergeCommitOid()
+	if err != nil {
+		return nil, err
+	}
+	if mergeCommit == "" {
+		return nil, notRevertibleError{reason: "its merge commit is unknown"}
+	}
+
+	title, err := ch.Title()
+	if err != nil {
+		return nil, err
+	}
+	url, err := ch.URL()
+	if err != nil {
+		return nil, err
+	}
+	headRef, err := ch.HeadRef()
+	if err != nil {
+		return nil, err
+	}
+	baseRef, err := ch.BaseRef()
+	if err != nil {
+		return nil, err
+	}
+
+	baseRev, err := gitserverClient.ResolveRevision(ctx, repo.Name, baseRef, gitserver.ResolveRevisionOptions{})
+	if err != nil {
+		return nil, notRevertibleError{reason: fmt.Sprintf("resolving base branch %s: %s", baseRef, err)}
+	}
+
+	diff, err := revertDiff(ctx, gitserverClient, repo.Name, ch, mergeCommit)
+	if err != nil {
+		return nil, err
+	}
+
+	repoID := string(relay.MarshalID("Repository", repo.ID))
+	revertTitle := fmt.Sprintf("Revert %q", title)
+	return btypes.NewChangesetSpecFromSpec(&batcheslib.ChangesetSpec{
+		BaseRepository: repoID,
+		BaseRef:        baseRef,
+		BaseRev:        string(baseRev),
+		HeadRepository: repoID,
+		HeadRef:        "refs/heads/revert-" + strings.TrimPrefix(headRef, "refs/heads/"),
+		Title:          revertTitle,
+		Body:           fmt.Sprintf("Reverts %s.", url),
+		Commits: []batcheslib.GitCommitDescription{{
+			Version:     2,
+			Message:     fmt.Sprintf("%s\n\nThis reverts commit %s.", revertTitle, mergeCommit),
+			Diff:        diff,
+			AuthorName:  changesetAuthor.Name,
+			AuthorEmail: changesetAuthor.Email,
+		}},
+		Published: batcheslib.PublishedValue{Val: publish},
+	})
+}
+
+// revertDiff returns the diff that reverts the changes the given changeset
+// merged into its base branch. If the changeset was merged with a merge commit,
+// these are the changes between the merge commit and its first parent.
+// Squashed or rebased changesets have no such commit, so the changes between
+// the head of the changeset and the base commit it was merged onto are
+// reverted instead.
+func revertDiff(ctx context.Context, gitserverClient gitserver.Client, repo api.RepoName, ch *btypes.Changeset, mergeCommit string) ([]byte, error) {
+	commit, err := gitserverClient.GetCommit(ctx, repo, api.CommitID(mergeCommit))
+	if err != nil {
+		return nil, notRevertibleError{reason: fmt.Sprintf("loading merge commit %s: %s", mergeCommit, err)}
+	}
+
+	var from, to string
+	if len(commit.Parents) > 1 {
+		from, to = mergeCommit, string(commit.Parents[0])
+	} else {
+		headOid, err := ch.HeadRefOid()
+		if err != nil {
+			return nil, err
+		}
+		if headOid == "" {
+			return nil, notRevertibleError{reason: fmt.Sprintf("%s is not a merge commit and the head commit of the changeset is unknown", mergeCommit)}
+		}
+		base, err := gitserverClient.MergeBase(ctx, repo, mergeCommit, headOid)
+		if err != nil {
+			return nil, notRevertibleError{reason: fmt.Sprintf("finding the base commit of %s: %s", headOid, err)}
+		}
+		from, to = headOid, string(base)
+	}
+
+	iter, err := gitserverClient.Diff(ctx, gitserver.DiffOptions{
+		Repo:      repo,
+		Base:      from,
+		Head:      to,
+		RangeType: "..",
+	})
+	if err != nil {
+		return nil, notRevertibleError{reason: fmt.Sprintf("computing revert diff: %s", err)}
+	}
+	defer iter.Close()
+
+	var fileDiffs []*godiff.FileDiff
+	for {
+		fd, err := iter.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, notRevertibleError{reason: fmt.Sprintf("reading revert diff: %s", err)}
+		}
+		fileDiffs = append(fileDiffs, fd)
+	}
+	if len(fileDiffs) == 0 {
+		return nil, notRevertibleError{reason: "it has no changes to revert"}
+	}
+
+	return godiff.PrintMultiFileDiff(fileDiffs)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 	"testing"
 	"time"
@@ -11,9 +12,11 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/keegancsmith/sqlf"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	godiff "github.com/sourcegraph/go-diff/diff"
 	"github.com/sourcegraph/log/logtest"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
@@ -29,6 +32,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	extsvcauth "github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
@@ -248,6 +253,21 @@ func TestServicePermissionLevels(t *testing.T) {
 				tc.assertFunc(t, err)
 			})
 
+			t.Run("PreviewBatchSpecExecution", func(t *testing.T) {
+				_, err := svc.PreviewBatchSpecExecution(currentUserCtx, PreviewBatchSpecExecutionOpts{
+					BatchSpecRandID: batchSpec.RandID,
+					SampleSize:      1,
+				})
+				tc.assertFunc(t, err)
+			})
+
+			t.Run("RollbackBatchChange", func(t *testing.T) {
+				_, err := svc.RollbackBatchChange(currentUserCtx, gitserver.NewMockClient(), RollbackBatchChangeOpts{
+					BatchChangeID: batchChange.ID,
+				})
+				tc.assertFunc(t, err)
+			})
+
 			t.Run("ReplaceBatchSpecInput", func(t *testing.T) {
 				_, err := svc.ReplaceBatchSpecInput(currentUserCtx, ReplaceBatchSpecInputOpts{
 					BatchSpecRandID: batchSpec.RandID,
@@ -1712,6 +1732,389 @@ index e5af166..d44c3fc 100644
 		})
 	})
 
+	t.Run("PreviewBatchSpecExecution", func(t *testing.T) {
+		adminCtx := actor.WithActor(ctx, actor.FromUser(admin.ID))
+
+		createResolvedSpec := func(t *testing.T) (*btypes.BatchSpec, []int64) {
+			t.Helper()
+
+			spec := testBatchSpec(admin.ID)
+			if err := s.CreateBatchSpec(ctx, spec); err != nil {
+				t.Fatal(err)
+			}
+
+			// Simulate successful resolution.
+			job := &btypes.BatchSpecResolutionJob{
+				State:       btypes.BatchSpecResolutionJobStateCompleted,
+				BatchSpecID: spec.ID,
+				InitiatorID: admin.ID,
+			}
+			if err := s.CreateBatchSpecResolutionJob(ctx, job); err != nil {
+				t.Fatal(err)
+			}
+
+			var workspaceIDs []int64
+			for _, repo := range rs {
+				ws := &btypes.BatchSpecWorkspace{
+					BatchSpecID: spec.ID,
+					RepoID:      repo.ID,
+				}
+				if err := s.CreateBatchSpecWorkspace(ctx, ws); err != nil {
+					t.Fatal(err)
+				}
+				workspaceIDs = append(workspaceIDs, ws.ID)
+			}
+			return spec, workspaceIDs
+		}
+
+		t.Run("success", func(t *testing.T) {
+			spec, workspaceIDs := createResolvedSpec(t)
+
+			if _, err := svc.PreviewBatchSpecExecution(adminCtx, PreviewBatchSpecExecutionOpts{BatchSpecRandID: spec.RandID, SampleSize: 2}); err != nil {
+				t.Fatal(err)
+			}
+
+			jobs, err := s.ListBatchSpecWorkspaceExecutionJobs(ctx, store.ListBatchSpecWorkspaceExecutionJobsOpts{
+				BatchSpecWorkspaceIDs: workspaceIDs,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(jobs) != 2 {
+				t.Fatalf("wrong number of execution jobs created. want=%d, have=%d", 2, len(jobs))
+			}
+
+			preview, err := s.GetBatchSpecExecutionPreview(ctx, spec.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if preview.SampleSize != 2 || int(preview.Workspaces) != len(rs) {
+				t.Fatalf("wrong preview created: %+v", preview)
+			}
+
+			// A previewed batch spec cannot be applied or previewed again.
+			if _, err := svc.ApplyBatchChange(adminCtx, ApplyBatchChangeOpts{BatchSpecRandID: spec.RandID}); err != ErrApplyPreviewBatchSpec {
+				t.Fatalf("wrong error. want=%s, have=%v", ErrApplyPreviewBatchSpec, err)
+			}
+			if _, err := svc.PreviewBatchSpecExecution(adminCtx, PreviewBatchSpecExecutionOpts{BatchSpecRandID: spec.RandID, SampleSize: 2}); err != ErrBatchSpecAlreadyExecuted {
+				t.Fatalf("wrong error. want=%s, have=%v", ErrBatchSpecAlreadyExecuted, err)
+			}
+
+			// Executing the batch spec executes the remaining workspaces.
+			if _, err := svc.ExecuteBatchSpec(adminCtx, ExecuteBatchSpecOpts{BatchSpecRandID: spec.RandID}); err != nil {
+				t.Fatal(err)
+			}
+
+			jobs, err = s.ListBatchSpecWorkspaceExecutionJobs(ctx, store.ListBatchSpecWorkspaceExecutionJobsOpts{
+				BatchSpecWorkspaceIDs: workspaceIDs,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(jobs) != len(rs) {
+				t.Fatalf("wrong number of execution jobs created. want=%d, have=%d", len(rs), len(jobs))
+			}
+
+			if _, err := s.GetBatchSpecExecutionPreview(ctx, spec.ID); err != store.ErrNoResults {
+				t.Fatalf("preview not deleted: %v", err)
+			}
+		})
+
+		t.Run("sample larger than workspaces", func(t *testing.T) {
+			spec, _ := createResolvedSpec(t)
+
+			if _, err := svc.PreviewBatchSpecExecution(adminCtx, PreviewBatchSpecExecutionOpts{BatchSpecRandID: spec.RandID, SampleSize: 100}); err != nil {
+				t.Fatal(err)
+			}
+
+			preview, err := s.GetBatchSpecExecutionPreview(ctx, spec.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if int(preview.SampleSize) != len(rs) {
+				t.Fatalf("wrong sample size. want=%d, have=%d", len(rs), preview.SampleSize)
+			}
+		})
+
+		t.Run("invalid sample size", func(t *testing.T) {
+			spec, _ := createResolvedSpec(t)
+
+			if _, err := svc.PreviewBatchSpecExecution(adminCtx, PreviewBatchSpecExecutionOpts{BatchSpecRandID: spec.RandID}); err != ErrInvalidPreviewSampleSize {
+				t.Fatalf("wrong error. want=%s, have=%v", ErrInvalidPreviewSampleSize, err)
+			}
+		})
+
+		t.Run("GetBatchSpecExecutionPreviewSummary", func(t *testing.T) {
+			spec, workspaceIDs := createResolvedSpec(t)
+
+			if _, err := svc.PreviewBatchSpecExecution(adminCtx, PreviewBatchSpecExecutionOpts{BatchSpecRandID: spec.RandID, SampleSize: 1}); err != nil {
+				t.Fatal(err)
+			}
+			jobs, err := s.ListBatchSpecWorkspaceExecutionJobs(ctx, store.ListBatchSpecWorkspaceExecutionJobsOpts{
+				BatchSpecWorkspaceIDs: workspaceIDs,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(jobs) != 1 {
+				t.Fatalf("wrong number of execution jobs created. want=%d, have=%d", 1, len(jobs))
+			}
+
+			changesetSpec := bt.CreateChangesetSpec(t, ctx, s, bt.TestSpecOpts{
+				User:      admin.ID,
+				Repo:      rs[0].ID,
+				BatchSpec: spec.ID,
+				HeadRef:   "refs/heads/preview",
+				Typ:       btypes.ChangesetSpecTypeBranch,
+				CommitDiff: []byte(`diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1 +1,3 @@
+ package main
++
++func main() {}
+`),
+			})
+			err = s.Exec(ctx, sqlf.Sprintf(
+				"UPDATE batch_spec_workspaces SET changeset_spec_ids = %s WHERE id = %s",
+				fmt.Sprintf(`{"%d": true}`, changesetSpec.ID),
+				jobs[0].BatchSpecWorkspaceID,
+			))
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = s.Exec(ctx, sqlf.Sprintf(
+				"UPDATE batch_spec_workspace_execution_jobs SET state = 'completed', started_at = %s, finished_at = %s WHERE id = %s",
+				now.Add(-time.Minute),
+				now,
+				jobs[0].ID,
+			))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			preview, err := s.GetBatchSpecExecutionPreview(ctx, spec.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			summary, err := svc.GetBatchSpecExecutionPreviewSummary(ctx, preview)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if summary.CompletedWorkspaces != 1 || summary.FailedWorkspaces != 0 {
+				t.Fatalf("wrong workspace counts: %+v", summary)
+			}
+			wantFileExtensions := []FileExtensionDiffStat{
+				{Extension: ".go", Files: 1, DiffStat: godiff.Stat{Added: 2}},
+			}
+			if diff := cmp.Diff(wantFileExtensions, summary.FileExtensions); diff != "" {
+				t.Fatalf("wrong file extensions (-want +have):\n%s", diff)
+			}
+			if summary.DiffStat != (godiff.Stat{Added: 2}) {
+				t.Fatalf("wrong diff stat: %+v", summary.DiffStat)
+			}
+			wantEstimate := time.Duration(len(rs)) * time.Minute
+			if summary.EstimatedExecutionTime == nil || *summary.EstimatedExecutionTime != wantEstimate {
+				t.Fatalf("wrong estimated execution time. want=%s, have=%v", wantEstimate, summary.EstimatedExecutionTime)
+			}
+		})
+	})
+
+	t.Run("RollbackBatchChange", func(t *testing.T) {
+		spec := testBatchSpec(admin.ID)
+		if err := s.CreateBatchSpec(ctx, spec); err != nil {
+			t.Fatal(err)
+		}
+		batchChange := testBatchChange(admin.ID, spec)
+		if err := s.CreateBatchChange(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+
+		const testDiff = `diff README.md README.md
+index 671e50a..851b23a 100644
+--- README.md
++++ README.md
+@@ -1,2 +1 @@
+ # README
+-This line was added by the changeset.
+`
+
+		gitserverClient := gitserver.NewMockClient()
+		gitserverClient.ResolveRevisionFunc.SetDefaultReturn("base-rev", nil)
+		gitserverClient.GetCommitFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, id api.CommitID) (*gitdomain.Commit, error) {
+			switch id {
+			case "merge-commit", "empty-merge-commit":
+				return &gitdomain.Commit{ID: id, Parents: []api.CommitID{"first-parent", "head-commit"}}, nil
+			case "squash-commit":
+				return &gitdomain.Commit{ID: id, Parents: []api.CommitID{"first-parent"}}, nil
+			}
+			return nil, &gitdomain.RevisionNotFoundError{Repo: "repo", Spec: string(id)}
+		})
+		gitserverClient.MergeBaseFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, base, head string) (api.CommitID, error) {
+			if base != "squash-commit" || head != "squash-head" {
+				t.Errorf("unexpected merge base of %s and %s", base, head)
+			}
+			return "squash-base", nil
+		})
+		gitserverClient.DiffFunc.SetDefaultHook(func(_ context.Context, opts gitserver.DiffOptions) (*gitserver.DiffFileIterator, error) {
+			switch opts.Base + ".." + opts.Head {
+			case "merge-commit..first-parent", "squash-head..squash-base":
+				return gitserver.NewDiffFileIterator(io.NopCloser(strings.NewReader(testDiff))), nil
+			case "empty-merge-commit..first-parent":
+				return gitserver.NewDiffFileIterator(io.NopCloser(strings.NewReader(""))), nil
+			}
+			t.Errorf("unexpected diff range %s..%s", opts.Base, opts.Head)
+			return nil, errors.New("unexpected diff range")
+		})
+
+		t.Run("no merged changesets", func(t *testing.T) {
+			_, err := svc.RollbackBatchChange(adminCtx, gitserverClient, RollbackBatchChangeOpts{BatchChangeID: batchChange.ID})
+			if err != ErrNoRevertibleChangesets {
+				t.Fatalf("wrong error. want=%s, have=%v", ErrNoRevertibleChangesets, err)
+			}
+		})
+
+		merged := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			Repo:               rs[0].ID,
+			BatchChange:        batchChange.ID,
+			OwnedByBatchChange: batchChange.ID,
+			PublicationState:   btypes.ChangesetPublicationStatePublished,
+			ExternalState:      btypes.ChangesetExternalStateMerged,
+			Metadata: &github.PullRequest{
+				Title:       "Update README",
+				URL:         "https://github.com/sourcegraph/sourcegraph/pull/1",
+				HeadRefName: "update-readme",
+				BaseRefName: "main",
+				TimelineItems: []github.TimelineItem{
+					{Type: "MergedEvent", Item: &github.MergedEvent{Commit: github.Commit{OID: "merge-commit"}}},
+				},
+			},
+		})
+		// Squashed or rebased changesets revert the changes between their head
+		// and the base commit they were merged onto.
+		squashed := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			Repo:               rs[3].ID,
+			BatchChange:        batchChange.ID,
+			OwnedByBatchChange: batchChange.ID,
+			PublicationState:   btypes.ChangesetPublicationStatePublished,
+			ExternalState:      btypes.ChangesetExternalStateMerged,
+			Metadata: &github.PullRequest{
+				Title:       "Update README",
+				URL:         "https://github.com/sourcegraph/sourcegraph/pull/4",
+				HeadRefName: "update-readme",
+				HeadRefOid:  "squash-head",
+				BaseRefName: "main",
+				TimelineItems: []github.TimelineItem{
+					{Type: "MergedEvent", Item: &github.MergedEvent{Commit: github.Commit{OID: "squash-commit"}}},
+				},
+			},
+		})
+		// Changesets without a known merge commit are skipped.
+		bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			Repo:               rs[1].ID,
+			BatchChange:        batchChange.ID,
+			OwnedByBatchChange: batchChange.ID,
+			PublicationState:   btypes.ChangesetPublicationStatePublished,
+			ExternalState:      btypes.ChangesetExternalStateMerged,
+			Metadata: &github.PullRequest{
+				URL:         "https://github.com/sourcegraph/sourcegraph/pull/2",
+				HeadRefName: "update-readme",
+				BaseRefName: "main",
+			},
+		})
+		// Changesets without changes to revert are skipped.
+		bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			Repo:               rs[0].ID,
+			BatchChange:        batchChange.ID,
+			OwnedByBatchChange: batchChange.ID,
+			ExternalID:         "3",
+			PublicationState:   btypes.ChangesetPublicationStatePublished,
+			ExternalState:      btypes.ChangesetExternalStateMerged,
+			Metadata: &github.PullRequest{
+				URL:         "https://github.com/sourcegraph/sourcegraph/pull/3",
+				HeadRefName: "update-readme",
+				BaseRefName: "main",
+				TimelineItems: []github.TimelineItem{
+					{Type: "MergedEvent", Item: &github.MergedEvent{Commit: github.Commit{OID: "empty-merge-commit"}}},
+				},
+			},
+		})
+		// Open changesets are not reverted.
+		bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			Repo:               rs[2].ID,
+			BatchChange:        batchChange.ID,
+			OwnedByBatchChange: batchChange.ID,
+			PublicationState:   btypes.ChangesetPublicationStatePublished,
+			ExternalState:      btypes.ChangesetExternalStateOpen,
+		})
+
+		t.Run("user without access", func(t *testing.T) {
+			_, err := svc.RollbackBatchChange(userCtx, gitserverClient, RollbackBatchChangeOpts{BatchChangeID: batchChange.ID})
+			assertAuthError(t, err)
+		})
+
+		t.Run("success", func(t *testing.T) {
+			rollback, err := svc.RollbackBatchChange(adminCtx, gitserverClient, RollbackBatchChangeOpts{BatchChangeID: batchChange.ID, Publish: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := batchChange.Name + "-rollback"; rollback.Name != want {
+				t.Fatalf("wrong name. want=%q, have=%q", want, rollback.Name)
+			}
+			if rollback.NamespaceUserID != batchChange.NamespaceUserID {
+				t.Fatalf("wrong namespace. want=%d, have=%d", batchChange.NamespaceUserID, rollback.NamespaceUserID)
+			}
+
+			rollbackSpec, err := s.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: rollback.BatchSpecID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, url := range []string{"https://github.com/sourcegraph/sourcegraph/pull/2", "https://github.com/sourcegraph/sourcegraph/pull/3"} {
+				if !strings.Contains(rollbackSpec.Spec.Description, url) {
+					t.Fatalf("skipped changeset %s not listed in description: %q", url, rollbackSpec.Spec.Description)
+				}
+			}
+
+			specs, _, err := s.ListChangesetSpecs(ctx, store.ListChangesetSpecsOpts{BatchSpecID: rollback.BatchSpecID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(specs) != 2 {
+				t.Fatalf("wrong number of changeset specs. want=2, have=%d", len(specs))
+			}
+			if specs[1].RevertedChangesetID != squashed.ID {
+				t.Errorf("wrong reverted changeset. want=%d, have=%d", squashed.ID, specs[1].RevertedChangesetID)
+			}
+			have := specs[0]
+			if have.RevertedChangesetID != merged.ID {
+				t.Errorf("wrong reverted changeset. want=%d, have=%d", merged.ID, have.RevertedChangesetID)
+			}
+			if have.BaseRepoID != rs[0].ID || have.BaseRef != "refs/heads/main" || have.BaseRev != "base-rev" {
+				t.Errorf("wrong base: %d %s %s", have.BaseRepoID, have.BaseRef, have.BaseRev)
+			}
+			if have.HeadRef != "refs/heads/revert-update-readme" {
+				t.Errorf("wrong head ref %q", have.HeadRef)
+			}
+			if have.Title != `Revert "Update README"` {
+				t.Errorf("wrong title %q", have.Title)
+			}
+			if !strings.Contains(have.CommitMessage, "This reverts commit merge-commit.") {
+				t.Errorf("wrong commit message %q", have.CommitMessage)
+			}
+			if !have.Published.True() {
+				t.Errorf("changeset spec not published")
+			}
+
+			// Rolling back again fails, because the rollback batch change
+			// already exists.
+			_, err = svc.RollbackBatchChange(adminCtx, gitserverClient, RollbackBatchChangeOpts{BatchChangeID: batchChange.ID})
+			if err != ErrMatchingBatchChangeExists {
+				t.Fatalf("wrong error. want=%s, have=%v", ErrMatchingBatchChangeExists, err)
+			}
+		})
+	})
+
 	t.Run("CancelBatchSpec", func(t *testing.T) {
 		t.Run("success", func(t *testing.T) {
 			spec := testBatchSpec(admin.ID)
@@ -2165,6 +2568,69 @@ changesetTemplate:
 		})
 	})
 
+	t.Run("BatchSpecTemplates", func(t *testing.T) {
+		const rawTemplate = `
+name: echo
+parameters:
+  - name: repositories
+    type: repositoryQuery
+    required: true
+spec: |
+  name: echo
+  on:
+    - repositoriesMatchingQuery: ${{ params.repositories }}
+  steps:
+    - run: echo 'foobar'
+      container: alpine
+`
+
+		if _, err := svc.CreateBatchSpecTemplate(userCtx, rawTemplate); err != auth.ErrMustBeSiteAdmin {
+			t.Fatalf("wrong error. want=%s, have=%v", auth.ErrMustBeSiteAdmin, err)
+		}
+
+		tmpl, err := svc.CreateBatchSpecTemplate(adminCtx, rawTemplate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tmpl.CreatorID != admin.ID {
+			t.Fatalf("wrong creator. want=%d, have=%d", admin.ID, tmpl.CreatorID)
+		}
+
+		t.Run("invalid values", func(t *testing.T) {
+			_, err := svc.CreateBatchSpecFromTemplate(userCtx, CreateBatchSpecFromTemplateOpts{
+				TemplateID:      tmpl.ID,
+				NamespaceUserID: user.ID,
+			})
+			if err == nil {
+				t.Fatal("no error returned")
+			}
+		})
+
+		t.Run("valid values", func(t *testing.T) {
+			spec, err := svc.CreateBatchSpecFromTemplate(userCtx, CreateBatchSpecFromTemplateOpts{
+				TemplateID:      tmpl.ID,
+				Values:          map[string]any{"repositories": "lang:go"},
+				NamespaceUserID: user.ID,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have, want := spec.Spec.On[0].RepositoriesMatchingQuery, "lang:go"; have != want {
+				t.Fatalf("wrong query. want=%q, have=%q", want, have)
+			}
+			if spec.UserID != user.ID || !spec.CreatedFromRaw {
+				t.Fatalf("wrong batch spec: %+v", spec)
+			}
+		})
+
+		if err := svc.DeleteBatchSpecTemplate(userCtx, tmpl.ID); err != auth.ErrMustBeSiteAdmin {
+			t.Fatalf("wrong error. want=%s, have=%v", auth.ErrMustBeSiteAdmin, err)
+		}
+		if err := svc.DeleteBatchSpecTemplate(adminCtx, tmpl.ID); err != nil {
+			t.Fatal(err)
+		}
+	})
+
 	t.Run("UpsertBatchSpecInput", func(t *testing.T) {
 		adminCtx := actor.WithActor(ctx, actor.FromUser(admin.ID))
 		t.Run("new spec", func(t *testing.T) {
@@ -26,7 +26,10 @@ type BitbucketServerSource struct {
 	au     auth.Authenticator
 }
 
-var _ ForkableChangesetSource = BitbucketServerSource{}
+var (
+	_ ForkableChangesetSource           = BitbucketServerSource{}
+	_ ReviewerRequestingChangesetSource = BitbucketServerSource{}
+)
 
 // NewBitbucketServerSource returns a new BitbucketServerSource from the given external service.
 func NewBitbucketServerSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*BitbucketServerSource, error) {
@@ -309,6 +312,57 @@ func (s BitbucketServerSource) MergeChangeset(ctx context.Context, c *Changeset,
 	return c.Changeset.SetMetadata(merged)
 }
 
+// RequestReviewers adds the given users to the reviewers of the pull request.
+// Bitbucket Server doesn't support requesting reviews from groups, so teams
+// are skipped, as is the author of the pull request.
+func (s BitbucketServerSource) RequestReviewers(ctx context.Context, c *Changeset, reviewers Reviewers) error {
+	var updated *bitbucketserver.PullRequest
+	_, err := s.callAndRetryIfOutdated(ctx, c, func(ctx context.Context, pr *bitbucketserver.PullRequest) error {
+		existing := make(map[string]struct{}, len(pr.Reviewers)+1)
+		if pr.Author.User != nil {
+			existing[strings.ToLower(pr.Author.User.Name)] = struct{}{}
+		}
+		for _, r := range pr.Reviewers {
+			if r.User != nil {
+				existing[strings.ToLower(r.User.Name)] = struct{}{}
+			}
+		}
+
+		prReviewers := pr.Reviewers
+		for _, username := range reviewers.Users {
+			if _, ok := existing[strings.ToLower(username)]; ok {
+				continue
+			}
+			existing[strings.ToLower(username)] = struct{}{}
+			prReviewers = append(prReviewers, bitbucketserver.Reviewer{User: &bitbucketserver.User{Name: username}})
+		}
+		if len(prReviewers) == len(pr.Reviewers) {
+			updated = pr
+			return nil
+		}
+
+		update := &bitbucketserver.UpdatePullRequestInput{
+			PullRequestID: strconv.Itoa(pr.ID),
+			Title:         pr.Title,
+			Description:   pr.Description,
+			Version:       pr.Version,
+			Reviewers:     prReviewers,
+		}
+		update.ToRef.ID = pr.ToRef.ID
+		update.ToRef.Repository.Slug = pr.ToRef.Repository.Slug
+		update.ToRef.Repository.Project.Key = pr.ToRef.Repository.Project.Key
+
+		var err error
+		updated, err = s.client.UpdatePullRequest(ctx, update)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Changeset.SetMetadata(updated)
+}
+
 type bitbucketClientFunc func(context.Context, *bitbucketserver.PullRequest) error
 
 func (s BitbucketServerSource) callAndRetryIfOutdated(ctx context.Context, c *Changeset, fn bitbucketClientFunc) (*bitbucketserver.PullRequest, error) {
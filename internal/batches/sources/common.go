@@ -60,6 +60,32 @@ type ForkableChangesetSource interface {
 	GetFork(ctx context.Context, targetRepo *types.Repo, namespace, name *string) (*types.Repo, error)
 }
 
+// A ReviewerRequestingChangesetSource can request reviews on changesets from
+// users and teams on the code host.
+type ReviewerRequestingChangesetSource interface {
+	ChangesetSource
+
+	// RequestReviewers requests reviews on the Changeset from the given
+	// reviewers. Reviewers that can't review the changeset, such as its
+	// author, are skipped.
+	RequestReviewers(context.Context, *Changeset, Reviewers) error
+}
+
+// Reviewers are the users and teams on a code host that reviews are requested
+// from.
+type Reviewers struct {
+	// Users are the usernames of the reviewers on the code host.
+	Users []string
+	// Teams are the names of the reviewing teams on the code host. For GitHub,
+	// these are in the form "org/team-slug".
+	Teams []string
+}
+
+// IsEmpty returns true if there are no reviewers.
+func (r Reviewers) IsEmpty() bool {
+	return len(r.Users) == 0 && len(r.Teams) == 0
+}
+
 // A ChangesetSource can load the latest state of a list of Changesets.
 type ChangesetSource interface {
 	// GitserverPushConfig returns an authenticated push config used for pushing
@@ -28,6 +28,8 @@ type GerritSource struct {
 	client gerrit.Client
 }
 
+var _ ReviewerRequestingChangesetSource = GerritSource{}
+
 func NewGerritSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GerritSource, error) {
 	rawConfig, err := svc.Config.Decrypt(ctx)
 	if err != nil {
@@ -247,6 +249,27 @@ func (s GerritSource) CreateComment(ctx context.Context, cs *Changeset, comment
 	})
 }
 
+// RequestReviewers adds the given users and groups as reviewers of the change.
+// The owner of the change is skipped. Gerrit adds reviewers one at a time, so
+// failing to add one doesn't stop the others from being added.
+func (s GerritSource) RequestReviewers(ctx context.Context, cs *Changeset, reviewers Reviewers) error {
+	change, ok := cs.Changeset.Metadata.(*gerritbatches.AnnotatedChange)
+	if !ok {
+		return errors.New("Changeset is not a Gerrit change")
+	}
+
+	var errs error
+	for _, reviewer := range append(append([]string{}, reviewers.Users...), reviewers.Teams...) {
+		if strings.EqualFold(reviewer, change.Change.Owner.Username) {
+			continue
+		}
+		if err := s.client.AddChangeReviewer(ctx, cs.ExternalID, gerrit.AddReviewerPayload{Reviewer: reviewer}); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "adding reviewer %q", reviewer))
+		}
+	}
+	return errs
+}
+
 // MergeChangeset merges a Changeset on the code host, if in a mergeable state.
 // If squash is true, and the code host supports squash merges, the source
 // must attempt a squash merge. Otherwise, it is expected to perform a regular
@@ -723,6 +723,37 @@ func TestGerritSource_CreateComment(t *testing.T) {
 	})
 }
 
+func TestGerritSource_RequestReviewers(t *testing.T) {
+	ctx := context.Background()
+
+	cs, id, _ := mockGerritChangeset()
+	cs.ExternalID = id
+	change := mockGerritChange(&testProject, id)
+	change.Owner.Username = "alice"
+	cs.Metadata = &gerritbatches.AnnotatedChange{Change: change}
+	s, client := mockGerritSource()
+
+	want := errors.New("error")
+	var added []string
+	client.AddChangeReviewerFunc.SetDefaultHook(func(ctx context.Context, changeID string, input gerrit.AddReviewerPayload) error {
+		assert.Equal(t, id, changeID)
+		added = append(added, input.Reviewer)
+		if input.Reviewer == "bob" {
+			return want
+		}
+		return nil
+	})
+
+	// Failing to add one reviewer doesn't stop the others from being added,
+	// and the owner of the change is skipped.
+	err := s.RequestReviewers(ctx, cs, Reviewers{
+		Users: []string{"alice", "bob", "carol"},
+		Teams: []string{"reviewers"},
+	})
+	assert.ErrorIs(t, err, want)
+	assert.Equal(t, []string{"bob", "carol", "reviewers"}, added)
+}
+
 func TestGerritSource_MergeChangeset(t *testing.T) {
 	ctx := context.Background()
 
@@ -0,0 +1,379 @@
+package sources
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	giteabatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gitea"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type GiteaSource struct {
+	client *gitea.Client
+}
+
+var (
+	_ ForkableChangesetSource           = GiteaSource{}
+	_ ReviewerRequestingChangesetSource = GiteaSource{}
+)
+
+func NewGiteaSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GiteaSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.GiteaConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	giteaURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing Gitea URL")
+	}
+
+	client, err := gitea.NewClient(svc.URN(), giteaURL, &auth.OAuthBearerToken{Token: c.Token}, cli)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating Gitea client")
+	}
+
+	return &GiteaSource{client: client}, nil
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s GiteaSource) GitserverPushConfig(repo *types.Repo) (*protocol.PushConfig, error) {
+	return GitserverPushConfig(repo, s.client.Authenticator())
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s GiteaSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	client, err := s.client.WithAuthenticator(a)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GiteaSource{client: client}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+func (s GiteaSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.client.GetAuthenticatedUser(ctx)
+	return err
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s GiteaSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	repo := cs.TargetRepo.Metadata.(*gitea.Repository)
+	number, err := strconv.ParseInt(cs.ExternalID, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "parsing changeset external ID")
+	}
+
+	pr, err := s.client.GetPullRequest(ctx, repo.Namespace(), repo.Name, number)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting pull request")
+	}
+
+	return errors.Wrap(s.setChangesetMetadata(ctx, repo, pr, cs), "setting Gitea changeset metadata")
+}
+
+// CreateChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+func (s GiteaSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	repo := cs.TargetRepo.Metadata.(*gitea.Repository)
+
+	head := gitdomain.AbbreviateRef(cs.HeadRef)
+	// If we're forking, the head branch has to be qualified with the
+	// namespace of the fork.
+	if cs.RemoteRepo != cs.TargetRepo {
+		head = cs.RemoteRepo.Metadata.(*gitea.Repository).Namespace() + ":" + head
+	}
+	base := gitdomain.AbbreviateRef(cs.BaseRef)
+
+	exists := false
+	pr, err := s.client.CreatePullRequest(ctx, repo.Namespace(), repo.Name, gitea.CreatePullRequestInput{
+		Head:  head,
+		Base:  base,
+		Title: cs.Title,
+		Body:  cs.Body,
+	})
+	if err != nil {
+		if !errors.Is(err, gitea.ErrPullRequestAlreadyExists) {
+			return false, errors.Wrap(err, "creating pull request")
+		}
+
+		exists = true
+		pr, err = s.client.GetPullRequestByBaseHead(ctx, repo.Namespace(), repo.Name, base, head)
+		if err != nil {
+			return false, errors.Wrap(err, "getting existing pull request")
+		}
+	}
+
+	if err := s.setChangesetMetadata(ctx, repo, pr, cs); err != nil {
+		return false, errors.Wrap(err, "setting Gitea changeset metadata")
+	}
+
+	return exists, nil
+}
+
+// CloseChangeset will close the Changeset on the source, where "close"
+// means the appropriate final state on the codehost (e.g. "closed" on
+// Gitea).
+func (s GiteaSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	closed := gitea.PullRequestStateClosed
+	updated, err := s.editPullRequest(ctx, cs, gitea.EditPullRequestInput{State: &closed})
+	if err != nil {
+		return err
+	}
+
+	if conf.Get().BatchChangesAutoDeleteBranch {
+		remote := cs.RemoteRepo.Metadata.(*gitea.Repository)
+		if err := s.client.DeleteBranch(ctx, remote.Namespace(), remote.Name, gitdomain.AbbreviateRef(cs.HeadRef)); err != nil && !errcode.IsNotFound(err) {
+			return errors.Wrap(err, "deleting source branch")
+		}
+	}
+
+	return errors.Wrap(s.setChangesetMetadata(ctx, cs.TargetRepo.Metadata.(*gitea.Repository), updated, cs), "setting Gitea changeset metadata")
+}
+
+// UpdateChangeset can update Changesets.
+func (s GiteaSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	updated, err := s.editPullRequest(ctx, cs, gitea.EditPullRequestInput{
+		Title: &cs.Title,
+		Body:  &cs.Body,
+		Base:  gitdomain.AbbreviateRef(cs.BaseRef),
+	})
+	if err != nil {
+		return err
+	}
+
+	return errors.Wrap(s.setChangesetMetadata(ctx, cs.TargetRepo.Metadata.(*gitea.Repository), updated, cs), "setting Gitea changeset metadata")
+}
+
+// ReopenChangeset will reopen the Changeset on the source, if it's closed.
+// If not, it's a noop.
+func (s GiteaSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	open := gitea.PullRequestStateOpen
+	updated, err := s.editPullRequest(ctx, cs, gitea.EditPullRequestInput{State: &open})
+	if err != nil {
+		return err
+	}
+
+	return errors.Wrap(s.setChangesetMetadata(ctx, cs.TargetRepo.Metadata.(*gitea.Repository), updated, cs), "setting Gitea changeset metadata")
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s GiteaSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	repo := cs.TargetRepo.Metadata.(*gitea.Repository)
+	number, err := strconv.ParseInt(cs.ExternalID, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "parsing changeset external ID")
+	}
+
+	return s.client.CreateIssueComment(ctx, repo.Namespace(), repo.Name, number, comment)
+}
+
+// RequestReviewers requests reviews on the pull request from the given users
+// and teams. Teams are given as "org/team", and only the team name is sent to
+// Gitea. The author of the pull request is skipped.
+func (s GiteaSource) RequestReviewers(ctx context.Context, cs *Changeset, reviewers Reviewers) error {
+	pr, ok := cs.Changeset.Metadata.(*giteabatches.AnnotatedPullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Gitea pull request")
+	}
+	repo := cs.TargetRepo.Metadata.(*gitea.Repository)
+
+	var input gitea.RequestReviewersInput
+	for _, user := range reviewers.Users {
+		if pr.User != nil && strings.EqualFold(user, pr.User.Login) {
+			continue
+		}
+		input.Reviewers = append(input.Reviewers, user)
+	}
+	for _, team := range reviewers.Teams {
+		_, name, _ := strings.Cut(team, "/")
+		input.TeamReviewers = append(input.TeamReviewers, name)
+	}
+	if len(input.Reviewers) == 0 && len(input.TeamReviewers) == 0 {
+		return nil
+	}
+
+	return s.client.RequestReviewers(ctx, repo.Namespace(), repo.Name, pr.Number, input)
+}
+
+// MergeChangeset merges a Changeset on the code host, if in a mergeable state.
+// If squash is true, and the code host supports squash merges, the source
+// must attempt a squash merge. Otherwise, it is expected to perform a regular
+// merge. If the changeset cannot be merged, because it is in an unmergeable
+// state, ChangesetNotMergeableError must be returned.
+func (s GiteaSource) MergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	repo := cs.TargetRepo.Metadata.(*gitea.Repository)
+	number, err := strconv.ParseInt(cs.ExternalID, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "parsing changeset external ID")
+	}
+
+	style := gitea.MergeStyleMerge
+	if squash {
+		style = gitea.MergeStyleSquash
+	}
+
+	if err := s.client.MergePullRequest(ctx, repo.Namespace(), repo.Name, number, gitea.MergePullRequestInput{
+		Do:                     style,
+		HeadCommitID:           cs.SyncState.HeadRefOid,
+		DeleteBranchAfterMerge: conf.Get().BatchChangesAutoDeleteBranch,
+	}); err != nil {
+		if errors.Is(err, gitea.ErrPullRequestNotMergeable) {
+			return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+		}
+		return errors.Wrap(err, "merging pull request")
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// GetFork returns a repo pointing to a fork of the target repo, ensuring that the fork
+// exists and creating it if it doesn't. If namespace is not provided, the fork will be
+// created in the namespace of the authenticated user. If name is not provided, the fork
+// will be named with the default Sourcegraph convention:
+// "${original-namespace}-${original-name}"
+func (s GiteaSource) GetFork(ctx context.Context, targetRepo *types.Repo, ns, n *string) (*types.Repo, error) {
+	tr := targetRepo.Metadata.(*gitea.Repository)
+
+	user, err := s.client.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting authenticated user")
+	}
+
+	namespace := user.Login
+	if ns != nil {
+		namespace = *ns
+	}
+
+	name := DefaultForkName(tr.Namespace(), tr.Name)
+	if n != nil {
+		name = *n
+	}
+
+	// Figure out if we already have a fork of the repo in the given namespace.
+	fork, err := s.client.GetRepository(ctx, namespace, name)
+	if err != nil {
+		if !errcode.IsNotFound(err) {
+			return nil, errors.Wrap(err, "checking for fork existence")
+		}
+
+		input := gitea.ForkRepositoryInput{Name: &name}
+		// Gitea only accepts organizations as explicit fork targets; forks into
+		// the namespace of the authenticated user are requested without one.
+		if namespace != user.Login {
+			input.Organization = &namespace
+		}
+
+		fork, err = s.client.ForkRepository(ctx, tr.Namespace(), tr.Name, input)
+		if err != nil {
+			return nil, errors.Wrap(err, "forking repository")
+		}
+	}
+
+	if !fork.Fork || fork.Parent == nil {
+		return nil, errors.New("repo is not a fork")
+	} else if fork.Parent.ID != tr.ID {
+		return nil, errors.New("repo was not forked from the target repo")
+	}
+
+	// Now we make a copy of targetRepo, but with its sources and metadata updated to
+	// point to the fork.
+	forkRepo, err := CopyRepoAsFork(targetRepo, fork, tr.FullName, fork.FullName)
+	if err != nil {
+		return nil, errors.Wrap(err, "updating target repo sources and metadata")
+	}
+
+	return forkRepo, nil
+}
+
+func (s GiteaSource) BuildCommitOpts(repo *types.Repo, _ *btypes.Changeset, spec *btypes.ChangesetSpec, pushOpts *protocol.PushConfig) protocol.CreateCommitFromPatchRequest {
+	return BuildCommitOptsCommon(repo, spec, pushOpts)
+}
+
+func (s GiteaSource) editPullRequest(ctx context.Context, cs *Changeset, input gitea.EditPullRequestInput) (*gitea.PullRequest, error) {
+	repo := cs.TargetRepo.Metadata.(*gitea.Repository)
+	number, err := strconv.ParseInt(cs.ExternalID, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing changeset external ID")
+	}
+
+	updated, err := s.client.EditPullRequest(ctx, repo.Namespace(), repo.Name, number, input)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, ChangesetNotFoundError{Changeset: cs}
+		}
+		return nil, errors.Wrap(err, "updating pull request")
+	}
+	return updated, nil
+}
+
+func (s GiteaSource) annotatePullRequest(ctx context.Context, repo *gitea.Repository, pr *gitea.PullRequest) (*giteabatches.AnnotatedPullRequest, error) {
+	reviews, err := s.client.ListPullReviews(ctx, repo.Namespace(), repo.Name, pr.Number)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing pull request reviews")
+	}
+
+	apr := &giteabatches.AnnotatedPullRequest{
+		PullRequest: pr,
+		Reviews:     reviews,
+	}
+
+	if pr.Head != nil && pr.Head.SHA != "" {
+		status, err := s.client.GetCombinedStatus(ctx, repo.Namespace(), repo.Name, pr.Head.SHA)
+		if err != nil && !errcode.IsNotFound(err) {
+			return nil, errors.Wrap(err, "getting pull request commit status")
+		}
+		apr.Status = status
+	}
+
+	return apr, nil
+}
+
+func (s GiteaSource) setChangesetMetadata(ctx context.Context, repo *gitea.Repository, pr *gitea.PullRequest, cs *Changeset) error {
+	apr, err := s.annotatePullRequest(ctx, repo, pr)
+	if err != nil {
+		return errors.Wrap(err, "annotating pull request")
+	}
+
+	if err := cs.SetMetadata(apr); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return nil
+}
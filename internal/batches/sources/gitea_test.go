@@ -0,0 +1,259 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	giteabatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gitea"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/types/typestest"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+var testGiteaRepo = &gitea.Repository{
+	ID:       1,
+	Name:     "repo",
+	FullName: "owner/repo",
+	Owner:    &gitea.User{ID: 10, Login: "owner"},
+}
+
+func TestGiteaSource_CreateChangeset(t *testing.T) {
+	t.Run("opens pull request from fork", func(t *testing.T) {
+		mux := newGiteaTestMux(t)
+		mux.HandleFunc("/api/v1/repos/owner/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+			var input gitea.CreatePullRequestInput
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+			assert.Equal(t, gitea.CreatePullRequestInput{
+				Head:  "alice:my-branch",
+				Base:  "main",
+				Title: "title",
+				Body:  "body",
+			}, input)
+
+			w.WriteHeader(http.StatusCreated)
+			writeGiteaJSON(t, w, testGiteaPullRequest(42, 2))
+		})
+
+		s := newGiteaTestSource(t, mux)
+		cs := testGiteaChangeset()
+		cs.RemoteRepo = testGiteaRemoteRepo(&gitea.Repository{ID: 2, Name: "repo", FullName: "alice/repo", Owner: &gitea.User{Login: "alice"}})
+
+		exists, err := s.CreateChangeset(context.Background(), cs)
+		require.NoError(t, err)
+		assert.False(t, exists)
+		assert.Equal(t, "42", cs.ExternalID)
+		assert.Equal(t, extsvc.TypeGitea, cs.ExternalServiceType)
+		assert.Equal(t, "refs/heads/my-branch", cs.ExternalBranch)
+		assert.Equal(t, "alice", cs.ExternalForkNamespace)
+		assert.Equal(t, "repo", cs.ExternalForkName)
+
+		apr := cs.Metadata.(*giteabatches.AnnotatedPullRequest)
+		assert.Len(t, apr.Reviews, 1)
+		assert.Equal(t, gitea.CommitStatusSuccess, apr.Status.State)
+	})
+
+	t.Run("pull request already exists", func(t *testing.T) {
+		mux := newGiteaTestMux(t)
+		mux.HandleFunc("/api/v1/repos/owner/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+		})
+		mux.HandleFunc("/api/v1/repos/owner/repo/pulls/main/my-branch", func(w http.ResponseWriter, r *http.Request) {
+			writeGiteaJSON(t, w, testGiteaPullRequest(42, 1))
+		})
+
+		s := newGiteaTestSource(t, mux)
+		cs := testGiteaChangeset()
+
+		exists, err := s.CreateChangeset(context.Background(), cs)
+		require.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, "42", cs.ExternalID)
+		assert.Empty(t, cs.ExternalForkNamespace)
+	})
+}
+
+func TestGiteaSource_LoadChangeset(t *testing.T) {
+	s := newGiteaTestSource(t, newGiteaTestMux(t))
+	cs := testGiteaChangeset()
+	cs.ExternalID = "404"
+
+	err := s.LoadChangeset(context.Background(), cs)
+	assert.Equal(t, ChangesetNotFoundError{Changeset: cs}, err)
+}
+
+func TestGiteaSource_MergeChangeset(t *testing.T) {
+	mux := newGiteaTestMux(t)
+	mux.HandleFunc("/api/v1/repos/owner/repo/pulls/42/merge", func(w http.ResponseWriter, r *http.Request) {
+		var input gitea.MergePullRequestInput
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+		assert.Equal(t, gitea.MergeStyleSquash, input.Do)
+
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	})
+
+	s := newGiteaTestSource(t, mux)
+	cs := testGiteaChangeset()
+	cs.ExternalID = "42"
+
+	err := s.MergeChangeset(context.Background(), cs, true)
+	var target ChangesetNotMergeableError
+	assert.ErrorAs(t, err, &target)
+}
+
+func TestGiteaSource_RequestReviewers(t *testing.T) {
+	mux := newGiteaTestMux(t)
+	mux.HandleFunc("/api/v1/repos/owner/repo/pulls/42/requested_reviewers", func(w http.ResponseWriter, r *http.Request) {
+		var input gitea.RequestReviewersInput
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+		assert.Equal(t, gitea.RequestReviewersInput{
+			Reviewers:     []string{"bob"},
+			TeamReviewers: []string{"owners"},
+		}, input)
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`[]`))
+	})
+
+	s := newGiteaTestSource(t, mux)
+	cs := testGiteaChangeset()
+	pr := testGiteaPullRequest(42, 1)
+	pr.User = &gitea.User{Login: "alice"}
+	cs.Metadata = &giteabatches.AnnotatedPullRequest{PullRequest: pr}
+
+	// The author of the pull request can't review it.
+	err := s.RequestReviewers(context.Background(), cs, Reviewers{
+		Users: []string{"alice", "bob"},
+		Teams: []string{"owner/owners"},
+	})
+	require.NoError(t, err)
+}
+
+func TestGiteaSource_GetFork(t *testing.T) {
+	fork := &gitea.Repository{
+		ID:       3,
+		Name:     "owner-repo",
+		FullName: "alice/owner-repo",
+		Owner:    &gitea.User{Login: "alice"},
+		Fork:     true,
+		Parent:   testGiteaRepo,
+	}
+
+	mux := newGiteaTestMux(t)
+	mux.HandleFunc("/api/v1/repos/alice/owner-repo", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/api/v1/repos/owner/repo/forks", func(w http.ResponseWriter, r *http.Request) {
+		var input gitea.ForkRepositoryInput
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+		// Forks into the namespace of the authenticated user must not name
+		// an organization.
+		assert.Nil(t, input.Organization)
+		assert.Equal(t, "owner-repo", *input.Name)
+
+		w.WriteHeader(http.StatusAccepted)
+		writeGiteaJSON(t, w, fork)
+	})
+
+	s := newGiteaTestSource(t, mux)
+
+	forkRepo, err := s.GetFork(context.Background(), testGiteaRemoteRepo(testGiteaRepo), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, fork, forkRepo.Metadata)
+	assert.Equal(t, "https://gitea.test/alice/owner-repo.git", forkRepo.Sources["extsvc:gitea:1"].CloneURL)
+}
+
+func newGiteaTestMux(t *testing.T) *http.ServeMux {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/user", func(w http.ResponseWriter, r *http.Request) {
+		writeGiteaJSON(t, w, gitea.User{ID: 20, Login: "alice"})
+	})
+	mux.HandleFunc("/api/v1/repos/owner/repo/pulls/42/reviews", func(w http.ResponseWriter, r *http.Request) {
+		writeGiteaJSON(t, w, []*gitea.PullReview{{ID: 1, Reviewer: &gitea.User{ID: 30}, State: gitea.ReviewStateApproved}})
+	})
+	mux.HandleFunc("/api/v1/repos/owner/repo/commits/abc/status", func(w http.ResponseWriter, r *http.Request) {
+		writeGiteaJSON(t, w, gitea.CombinedStatus{State: gitea.CommitStatusSuccess, SHA: "abc", TotalCount: 1})
+	})
+	return mux
+}
+
+func newGiteaTestSource(t *testing.T, h http.Handler) *GiteaSource {
+	t.Helper()
+	ratelimit.SetupForTest(t)
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	svc := typestest.MakeExternalService(t, extsvc.VariantGitea, &schema.GiteaConnection{
+		Url:   srv.URL,
+		Token: "secret",
+	})
+
+	s, err := NewGiteaSource(context.Background(), svc, httpcli.NewFactory(nil))
+	require.NoError(t, err)
+
+	return s
+}
+
+func testGiteaChangeset() *Changeset {
+	repo := testGiteaRemoteRepo(testGiteaRepo)
+	return &Changeset{
+		Title:      "title",
+		Body:       "body",
+		HeadRef:    "refs/heads/my-branch",
+		BaseRef:    "refs/heads/main",
+		RemoteRepo: repo,
+		TargetRepo: repo,
+		Changeset:  &btypes.Changeset{},
+	}
+}
+
+func testGiteaRemoteRepo(r *gitea.Repository) *types.Repo {
+	return &types.Repo{
+		Name: api.RepoName("gitea.test/" + r.FullName),
+		ExternalRepo: api.ExternalRepoSpec{
+			ServiceType: extsvc.TypeGitea,
+		},
+		Sources: map[string]*types.SourceInfo{
+			"extsvc:gitea:1": {
+				ID:       "extsvc:gitea:1",
+				CloneURL: "https://gitea.test/" + r.FullName + ".git",
+			},
+		},
+		Metadata: r,
+	}
+}
+
+func testGiteaPullRequest(number, headRepoID int64) *gitea.PullRequest {
+	head := &gitea.PullRequestBranch{Ref: "my-branch", SHA: "abc", RepoID: headRepoID}
+	if headRepoID != testGiteaRepo.ID {
+		head.Repository = &gitea.Repository{ID: headRepoID, Name: "repo", FullName: "alice/repo", Owner: &gitea.User{Login: "alice"}}
+	}
+
+	return &gitea.PullRequest{
+		Number: number,
+		Title:  "title",
+		Body:   "body",
+		State:  gitea.PullRequestStateOpen,
+		Head:   head,
+		Base:   &gitea.PullRequestBranch{Ref: "main", SHA: "def", RepoID: testGiteaRepo.ID},
+	}
+}
+
+func writeGiteaJSON(t *testing.T, w http.ResponseWriter, v any) {
+	t.Helper()
+	require.NoError(t, json.NewEncoder(w).Encode(v))
+}
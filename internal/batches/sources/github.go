@@ -29,7 +29,10 @@ type GitHubSource struct {
 	au     auth.Authenticator
 }
 
-var _ ForkableChangesetSource = GitHubSource{}
+var (
+	_ ForkableChangesetSource           = GitHubSource{}
+	_ ReviewerRequestingChangesetSource = GitHubSource{}
+)
 
 func NewGitHubSource(ctx context.Context, db database.DB, svc *types.ExternalService, cf *httpcli.Factory) (*GitHubSource, error) {
 	rawConfig, err := svc.Config.Decrypt(ctx)
@@ -373,6 +376,42 @@ func (s GitHubSource) MergeChangeset(ctx context.Context, c *Changeset, squash b
 	return c.Changeset.SetMetadata(pr)
 }
 
+// RequestReviewers requests reviews on the pull request from the given users
+// and teams. The author of the pull request and teams of other organizations
+// are skipped.
+func (s GitHubSource) RequestReviewers(ctx context.Context, c *Changeset, reviewers Reviewers) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	repo := c.TargetRepo.Metadata.(*github.Repository)
+	owner, repoName, err := github.SplitRepositoryNameWithOwner(repo.NameWithOwner)
+	if err != nil {
+		return errors.Wrap(err, "getting owner and repo name to request reviewers")
+	}
+
+	var users, teams []string
+	for _, u := range reviewers.Users {
+		if !strings.EqualFold(u, pr.Author.Login) {
+			users = append(users, u)
+		}
+	}
+	for _, t := range reviewers.Teams {
+		org, slug, found := strings.Cut(t, "/")
+		if !found {
+			teams = append(teams, t)
+		} else if strings.EqualFold(org, owner) {
+			teams = append(teams, slug)
+		}
+	}
+	if len(users) == 0 && len(teams) == 0 {
+		return nil
+	}
+
+	return s.client.RequestReviewers(ctx, owner, repoName, pr.Number, users, teams)
+}
+
 func (GitHubSource) IsPushResponseArchived(s string) bool {
 	return strings.Contains(s, "This repository was archived so it is read-only.")
 }
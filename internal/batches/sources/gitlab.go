@@ -32,6 +32,7 @@ type GitLabSource struct {
 var _ ChangesetSource = &GitLabSource{}
 var _ DraftChangesetSource = &GitLabSource{}
 var _ ForkableChangesetSource = &GitLabSource{}
+var _ ReviewerRequestingChangesetSource = &GitLabSource{}
 
 // NewGitLabSource returns a new GitLabSource from the given external service.
 func NewGitLabSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GitLabSource, error) {
@@ -301,6 +302,11 @@ func (s *GitLabSource) decorateMergeRequestData(ctx context.Context, project *gi
 		return errors.Wrap(err, "retrieving pipelines")
 	}
 
+	jobs, err := s.getLatestPipelineJobs(ctx, project, mr, pipelines)
+	if err != nil {
+		return errors.Wrap(err, "retrieving pipeline jobs")
+	}
+
 	if mr.SourceProjectID != mr.ProjectID {
 		project, err := s.client.GetProject(ctx, gitlab.GetProjectOp{
 			ID: int(mr.SourceProjectID),
@@ -328,6 +334,7 @@ func (s *GitLabSource) decorateMergeRequestData(ctx context.Context, project *gi
 	mr.Notes = notes
 	mr.Pipelines = pipelines
 	mr.ResourceStateEvents = events
+	mr.Jobs = jobs
 	return nil
 }
 
@@ -421,6 +428,21 @@ func (s *GitLabSource) getMergeRequestPipelines(ctx context.Context, project *gi
 	return pipelines, nil
 }
 
+// getLatestPipelineJobs retrieves the jobs of the most recent of the given
+// pipelines, which must be in descending time order, falling back to the head
+// pipeline of the merge request.
+func (s *GitLabSource) getLatestPipelineJobs(ctx context.Context, project *gitlab.Project, mr *gitlab.MergeRequest, pipelines []*gitlab.Pipeline) ([]*gitlab.Job, error) {
+	latest := mr.HeadPipeline
+	if len(pipelines) > 0 {
+		latest = pipelines[0]
+	}
+	if latest == nil {
+		return nil, nil
+	}
+
+	return s.client.GetPipelineJobs(ctx, project, latest.ID)
+}
+
 func readPipelines(it func() ([]*gitlab.Pipeline, error)) ([]*gitlab.Pipeline, error) {
 	var pipelines []*gitlab.Pipeline
 
@@ -564,6 +586,58 @@ func (s *GitLabSource) MergeChangeset(ctx context.Context, c *Changeset, squash
 	return c.Changeset.SetMetadata(updated)
 }
 
+// RequestReviewers sets the given users as the reviewers of the merge request.
+// GitLab doesn't support requesting reviews from groups, so teams are skipped,
+// as are users that can't be found on GitLab and the author of the merge
+// request.
+func (s *GitLabSource) RequestReviewers(ctx context.Context, c *Changeset, reviewers Reviewers) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+	project := c.TargetRepo.Metadata.(*gitlab.Project)
+
+	var reviewerIDs []int32
+	for _, username := range reviewers.Users {
+		if strings.EqualFold(username, mr.Author.Username) {
+			continue
+		}
+
+		q := make(url.Values)
+		q.Add("username", username)
+		users, _, err := s.client.ListUsers(ctx, "users?"+q.Encode())
+		if err != nil {
+			return errors.Wrapf(err, "looking up GitLab user %q", username)
+		}
+		if len(users) == 0 {
+			continue
+		}
+		reviewerIDs = append(reviewerIDs, users[0].ID)
+	}
+	if len(reviewerIDs) == 0 {
+		return nil
+	}
+
+	// Title and TargetBranch are required, even though we're not actually
+	// changing them.
+	updated, err := s.client.UpdateMergeRequest(ctx, project, mr, gitlab.UpdateMergeRequestOpts{
+		Title:              mr.Title,
+		TargetBranch:       mr.TargetBranch,
+		RemoveSourceBranch: conf.Get().BatchChangesAutoDeleteBranch,
+		ReviewerIDs:        reviewerIDs,
+	})
+	if err != nil {
+		return errors.Wrap(err, "requesting reviewers on GitLab merge request")
+	}
+
+	// These additional API calls can go away once we can use the GraphQL API.
+	if err := s.decorateMergeRequestData(ctx, project, updated); err != nil {
+		return errors.Wrapf(err, "retrieving additional data for merge request %d", mr.IID)
+	}
+
+	return c.Changeset.SetMetadata(updated)
+}
+
 func (*GitLabSource) IsPushResponseArchived(s string) bool {
 	return strings.Contains(s, "ERROR: You are not allowed to push code to this project")
 }
@@ -719,6 +719,11 @@ func TestGitLabSource_ChangesetSource(t *testing.T) {
 			p.mockGetMergeRequestNotes(43, nil, 20, nil)
 			p.mockGetMergeRequestResourceStateEvents(43, nil, 20, nil)
 			p.mockGetMergeRequestPipelines(43, pipelines, 20, nil)
+			// Only the jobs of the latest pipeline are loaded.
+			jobs := []*gitlab.Job{
+				{ID: 10, Name: "lint", Status: gitlab.PipelineStatusFailed},
+			}
+			p.mockGetPipelineJobs(1, jobs, nil)
 
 			if err := p.source.LoadChangeset(p.ctx, p.changeset); err != nil {
 				t.Errorf("unexpected error: %+v", err)
@@ -726,6 +731,9 @@ func TestGitLabSource_ChangesetSource(t *testing.T) {
 			if diff := cmp.Diff(mr.Pipelines, pipelines); diff != "" {
 				t.Errorf("unexpected pipelines: %s", diff)
 			}
+			if diff := cmp.Diff(mr.Jobs, jobs); diff != "" {
+				t.Errorf("unexpected jobs: %s", diff)
+			}
 
 			// A subsequent load should result in the same pipelines. Since we
 			// changed the IID in the merge request, we do need to change the
@@ -882,6 +890,52 @@ func TestGitLabSource_ChangesetSource(t *testing.T) {
 		}
 	})
 
+	t.Run("RequestReviewers", func(t *testing.T) {
+		in := &gitlab.MergeRequest{IID: 2, Title: "title", TargetBranch: "main", Author: gitlab.User{Username: "author"}}
+		out := &gitlab.MergeRequest{IID: 2}
+
+		p := newGitLabChangesetSourceTestProvider(t)
+		p.changeset.Changeset.Metadata = in
+
+		oldListUsers := gitlab.MockListUsers
+		t.Cleanup(func() { gitlab.MockListUsers = oldListUsers })
+		gitlab.MockListUsers = func(c *gitlab.Client, ctx context.Context, urlStr string) ([]*gitlab.AuthUser, *string, error) {
+			switch urlStr {
+			case "users?username=alice":
+				return []*gitlab.AuthUser{{ID: 10, Username: "alice"}}, nil, nil
+			case "users?username=unknown":
+				return nil, nil, nil
+			default:
+				t.Errorf("unexpected user lookup %q", urlStr)
+				return nil, nil, nil
+			}
+		}
+
+		oldMock := gitlab.MockUpdateMergeRequest
+		t.Cleanup(func() { gitlab.MockUpdateMergeRequest = oldMock })
+		gitlab.MockUpdateMergeRequest = func(c *gitlab.Client, ctx context.Context, project *gitlab.Project, mr *gitlab.MergeRequest, opts gitlab.UpdateMergeRequestOpts) (*gitlab.MergeRequest, error) {
+			if diff := cmp.Diff([]int32{10}, opts.ReviewerIDs); diff != "" {
+				t.Errorf("unexpected reviewer IDs (-want +got):\n%s", diff)
+			}
+			if have, want := opts.Title, "title"; have != want {
+				t.Errorf("unexpected title: have=%q want=%q", have, want)
+			}
+			return out, nil
+		}
+
+		p.mockGetMergeRequestNotes(in.IID, nil, 20, nil)
+		p.mockGetMergeRequestResourceStateEvents(in.IID, nil, 20, nil)
+		p.mockGetMergeRequestPipelines(in.IID, nil, 20, nil)
+
+		reviewers := Reviewers{Users: []string{"alice", "author", "unknown"}, Teams: []string{"group/team"}}
+		if err := p.source.RequestReviewers(p.ctx, p.changeset, reviewers); err != nil {
+			t.Errorf("unexpected non-nil error: %+v", err)
+		}
+		if p.changeset.Changeset.Metadata != out {
+			t.Errorf("metadata not correctly updated: have %+v; want %+v", p.changeset.Changeset.Metadata, out)
+		}
+	})
+
 	t.Run("CreateComment", func(t *testing.T) {
 		commentBody := "test-comment"
 		t.Run("invalid metadata", func(t *testing.T) {
@@ -1193,6 +1247,16 @@ func (p *gitLabChangesetSourceTestProvider) mockGetMergeRequestPipelines(expecte
 	}
 }
 
+func (p *gitLabChangesetSourceTestProvider) mockGetPipelineJobs(expectedPipelineID gitlab.ID, jobs []*gitlab.Job, err error) {
+	gitlab.MockGetPipelineJobs = func(client *gitlab.Client, ctx context.Context, project *gitlab.Project, pipelineID gitlab.ID) ([]*gitlab.Job, error) {
+		p.testCommonParams(ctx, client, project)
+		if expectedPipelineID != pipelineID {
+			p.t.Errorf("unexpected pipeline ID: have %d; want %d", pipelineID, expectedPipelineID)
+		}
+		return jobs, err
+	}
+}
+
 func (p *gitLabChangesetSourceTestProvider) mockGetOpenMergeRequestByRefs(mr *gitlab.MergeRequest, err error) {
 	gitlab.MockGetOpenMergeRequestByRefs = func(client *gitlab.Client, ctx context.Context, project *gitlab.Project, source, target string) (*gitlab.MergeRequest, error) {
 		p.testCommonParams(ctx, client, project)
@@ -1264,6 +1328,7 @@ func (p *gitLabChangesetSourceTestProvider) unmock() {
 	gitlab.MockGetMergeRequestNotes = nil
 	gitlab.MockGetMergeRequestResourceStateEvents = nil
 	gitlab.MockGetMergeRequestPipelines = nil
+	gitlab.MockGetPipelineJobs = nil
 	gitlab.MockGetOpenMergeRequestByRefs = nil
 	gitlab.MockUpdateMergeRequest = nil
 	gitlab.MockCreateMergeRequestNote = nil
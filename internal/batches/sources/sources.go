@@ -459,7 +459,8 @@ func loadExternalService(ctx context.Context, s database.ExternalServiceStore, o
 			*schema.BitbucketCloudConnection,
 			*schema.AzureDevOpsConnection,
 			*schema.GerritConnection,
-			*schema.PerforceConnection:
+			*schema.PerforceConnection,
+			*schema.GiteaConnection:
 			return e, nil
 		}
 	}
@@ -485,6 +486,8 @@ func buildChangesetSource(ctx context.Context, tx SourcerStore, cf *httpcli.Fact
 		return NewGerritSource(ctx, externalService, cf)
 	case extsvc.KindPerforce:
 		return NewPerforceSource(ctx, gitserver.NewClient("batches.perforcesource"), externalService, cf)
+	case extsvc.KindGitea:
+		return NewGiteaSource(ctx, externalService, cf)
 	default:
 		return nil, errors.Errorf("unsupported external service type %q", extsvc.KindToType(externalService.Kind))
 	}
@@ -525,7 +528,7 @@ func loadSiteCredential(ctx context.Context, s SourcerStore, opts store.GetSiteC
 // with the specific quirks per code host.
 func setOAuthTokenAuth(u *vcs.URL, extSvcType, token string) error {
 	switch extSvcType {
-	case extsvc.TypeGitHub:
+	case extsvc.TypeGitHub, extsvc.TypeGitea:
 		u.User = url.User(token)
 
 	case extsvc.TypeGitLab:
@@ -544,7 +547,7 @@ func setOAuthTokenAuth(u *vcs.URL, extSvcType, token string) error {
 // password combination, with the specific quirks per code host.
 func setBasicAuth(u *vcs.URL, extSvcType, username, password string) error {
 	switch extSvcType {
-	case extsvc.TypeGitHub, extsvc.TypeGitLab:
+	case extsvc.TypeGitHub, extsvc.TypeGitLab, extsvc.TypeGitea:
 		return errors.New("need token to push commits to " + extSvcType)
 	case extsvc.TypeBitbucketServer, extsvc.TypeBitbucketCloud, extsvc.TypeAzureDevOps, extsvc.TypeGerrit:
 		u.User = url.UserPassword(username, password)
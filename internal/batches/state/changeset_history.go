@@ -213,93 +213,38 @@ func computeHistory(ch *btypes.Changeset, ce ChangesetEvents) (changesetHistory,
 			btypes.ChangesetEventKindGitLabApproved,
 			btypes.ChangesetEventKindBitbucketCloudApproved,
 			btypes.ChangesetEventKindBitbucketCloudPullRequestApproved,
-			btypes.ChangesetEventKindAzureDevOpsPullRequestApproved:
-			s, err := e.ReviewState()
-			if err != nil {
-				return nil, err
-			}
-
-			// We only care about "Approved", "ChangesRequested" or "Dismissed" reviews
-			if s != btypes.ChangesetReviewStateApproved &&
-				s != btypes.ChangesetReviewStateChangesRequested &&
-				s != btypes.ChangesetReviewStateDismissed {
-				continue
-			}
-
-			author := e.ReviewAuthor()
-			// If the user has been deleted, skip their reviews, as they don't count towards the final state anymore.
-			if author == "" {
-				continue
-			}
-
-			// Save current review state, then insert new review or delete
-			// dismissed review, then recompute overall review state
-			oldReviewState := currentReviewState
-
-			if s == btypes.ChangesetReviewStateDismissed {
-				// In case of a dismissed review we dismiss _all_ of the
-				// previous reviews by the author, since that is what GitHub
-				// does in its UI.
-				delete(lastReviewByAuthor, author)
-			} else {
-				lastReviewByAuthor[author] = s
-			}
-
-			newReviewState := reduceReviewStates(lastReviewByAuthor)
-
-			if newReviewState != oldReviewState {
-				currentReviewState = newReviewState
-				pushStates(et)
-			}
-
-		case btypes.ChangesetEventKindBitbucketServerUnapproved,
+			btypes.ChangesetEventKindAzureDevOpsPullRequestApproved,
+			btypes.ChangesetEventKindBitbucketServerUnapproved,
 			btypes.ChangesetEventKindBitbucketServerDismissed,
 			btypes.ChangesetEventKindGitLabUnapproved,
 			btypes.ChangesetEventKindBitbucketCloudPullRequestChangesRequestRemoved,
 			btypes.ChangesetEventKindBitbucketCloudPullRequestUnapproved:
+			// Save current review state, then apply the review and recompute
+			// overall review state
+			oldReviewState := currentReviewState
 
-			author := e.ReviewAuthor()
-			// If the user has been deleted, skip their reviews, as they don't count towards the final state anymore.
-			if author == "" {
-				continue
-			}
-
-			if e.Type() == btypes.ChangesetEventKindBitbucketServerUnapproved {
-				// A BitbucketServer Unapproved can only follow a previous Approved by
-				// the same author.
-				lastReview, ok := lastReviewByAuthor[author]
-				if !ok || lastReview != btypes.ChangesetReviewStateApproved {
-					log15.Warn("Bitbucket Server Unapproval not following an Approval", "event", e)
-					continue
-				}
+			applied, err := applyReviewEvent(lastReviewByAuthor, e)
+			if err != nil {
+				return nil, err
 			}
-
-			if e.Type() == btypes.ChangesetEventKindBitbucketServerDismissed {
-				// A BitbucketServer Dismissed event can only follow a previous "Changes Requested" review by
-				// the same author.
-				lastReview, ok := lastReviewByAuthor[author]
-				if !ok || lastReview != btypes.ChangesetReviewStateChangesRequested {
-					log15.Warn("Bitbucket Server Dismissal not following a Review", "event", e)
-					continue
-				}
+			if !applied {
+				continue
 			}
 
-			// Save current review state, then remove last approval and
-			// recompute overall review state
-			oldReviewState := currentReviewState
-			delete(lastReviewByAuthor, author)
 			newReviewState := reduceReviewStates(lastReviewByAuthor)
 
 			if newReviewState != oldReviewState {
 				currentReviewState = newReviewState
 				pushStates(et)
 			}
+
 		case btypes.ChangesetEventKindAzureDevOpsPullRequestRejected,
 			btypes.ChangesetEventKindAzureDevOpsPullRequestApprovedWithSuggestions,
 			btypes.ChangesetEventKindAzureDevOpsPullRequestWaitingForAuthor:
+			if _, err := applyReviewEvent(lastReviewByAuthor, e); err != nil {
+				return nil, err
+			}
 			currentReviewState = btypes.ChangesetReviewStateChangesRequested
-			author := e.ReviewAuthor()
-			lastReviewByAuthor[author] = currentReviewState
 			pushStates(et)
 		}
 	}
@@ -315,6 +260,124 @@ func computeHistory(ch *btypes.Changeset, ce ChangesetEvents) (changesetHistory,
 	return states, nil
 }
 
+// applyReviewEvent updates the given map of the last review per author with
+// the given review event. It returns false if the event doesn't change the
+// review of its author.
+func applyReviewEvent(lastReviewByAuthor map[string]btypes.ChangesetReviewState, e *btypes.ChangesetEvent) (bool, error) {
+	switch e.Kind {
+	case btypes.ChangesetEventKindGitHubReviewed,
+		btypes.ChangesetEventKindBitbucketServerApproved,
+		btypes.ChangesetEventKindBitbucketServerReviewed,
+		btypes.ChangesetEventKindGitLabApproved,
+		btypes.ChangesetEventKindBitbucketCloudApproved,
+		btypes.ChangesetEventKindBitbucketCloudPullRequestApproved,
+		btypes.ChangesetEventKindAzureDevOpsPullRequestApproved:
+		s, err := e.ReviewState()
+		if err != nil {
+			return false, err
+		}
+
+		// We only care about "Approved", "ChangesRequested" or "Dismissed" reviews
+		if s != btypes.ChangesetReviewStateApproved &&
+			s != btypes.ChangesetReviewStateChangesRequested &&
+			s != btypes.ChangesetReviewStateDismissed {
+			return false, nil
+		}
+
+		author := e.ReviewAuthor()
+		// If the user has been deleted, skip their reviews, as they don't count towards the final state anymore.
+		if author == "" {
+			return false, nil
+		}
+
+		if s == btypes.ChangesetReviewStateDismissed {
+			// In case of a dismissed review we dismiss _all_ of the
+			// previous reviews by the author, since that is what GitHub
+			// does in its UI.
+			delete(lastReviewByAuthor, author)
+		} else {
+			lastReviewByAuthor[author] = s
+		}
+		return true, nil
+
+	case btypes.ChangesetEventKindBitbucketServerUnapproved,
+		btypes.ChangesetEventKindBitbucketServerDismissed,
+		btypes.ChangesetEventKindGitLabUnapproved,
+		btypes.ChangesetEventKindBitbucketCloudPullRequestChangesRequestRemoved,
+		btypes.ChangesetEventKindBitbucketCloudPullRequestUnapproved:
+
+		author := e.ReviewAuthor()
+		// If the user has been deleted, skip their reviews, as they don't count towards the final state anymore.
+		if author == "" {
+			return false, nil
+		}
+
+		if e.Type() == btypes.ChangesetEventKindBitbucketServerUnapproved {
+			// A BitbucketServer Unapproved can only follow a previous Approved by
+			// the same author.
+			lastReview, ok := lastReviewByAuthor[author]
+			if !ok || lastReview != btypes.ChangesetReviewStateApproved {
+				log15.Warn("Bitbucket Server Unapproval not following an Approval", "event", e)
+				return false, nil
+			}
+		}
+
+		if e.Type() == btypes.ChangesetEventKindBitbucketServerDismissed {
+			// A BitbucketServer Dismissed event can only follow a previous "Changes Requested" review by
+			// the same author.
+			lastReview, ok := lastReviewByAuthor[author]
+			if !ok || lastReview != btypes.ChangesetReviewStateChangesRequested {
+				log15.Warn("Bitbucket Server Dismissal not following a Review", "event", e)
+				return false, nil
+			}
+		}
+
+		// Remove last approval of the author
+		delete(lastReviewByAuthor, author)
+		return true, nil
+
+	case btypes.ChangesetEventKindAzureDevOpsPullRequestRejected,
+		btypes.ChangesetEventKindAzureDevOpsPullRequestApprovedWithSuggestions,
+		btypes.ChangesetEventKindAzureDevOpsPullRequestWaitingForAuthor:
+		lastReviewByAuthor[e.ReviewAuthor()] = btypes.ChangesetReviewStateChangesRequested
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// computeReviewerDecisions returns the latest approval or change request of
+// every reviewer of the changeset, sorted by reviewer. Dismissed and withdrawn
+// reviews are not included.
+// The ChangesetEvents MUST be sorted by their Timestamp.
+func computeReviewerDecisions(ce ChangesetEvents) ([]btypes.ChangesetReviewerDecision, error) {
+	if !sort.IsSorted(ce) {
+		return nil, errors.New("changeset events not sorted")
+	}
+
+	lastReviewByAuthor := map[string]btypes.ChangesetReviewState{}
+	for _, e := range ce {
+		if e.Timestamp().IsZero() {
+			continue
+		}
+		if _, err := applyReviewEvent(lastReviewByAuthor, e); err != nil {
+			return nil, err
+		}
+	}
+
+	var decisions []btypes.ChangesetReviewerDecision
+	for author, s := range lastReviewByAuthor {
+		if author == "" {
+			continue
+		}
+		decisions = append(decisions, btypes.ChangesetReviewerDecision{Reviewer: author, State: s})
+	}
+	sort.Slice(decisions, func(i, j int) bool {
+		return decisions[i].Reviewer < decisions[j].Reviewer
+	})
+	return decisions, nil
+}
+
 // reduceReviewStates reduces the given a map of review per author down to a
 // single overall ChangesetReviewState.
 func reduceReviewStates(statesByAuthor map[string]btypes.ChangesetReviewState) btypes.ChangesetReviewState {
@@ -0,0 +1,217 @@
+package state
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	bbcs "github.com/sourcegraph/sourcegraph/internal/batches/sources/bitbucketcloud"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+)
+
+// computeCheckRuns computes the individual checks that make up the overall
+// check state of the changeset, based on the current synced checks and any
+// webhook events that have arrived after the most recent sync. The checks are
+// sorted by name.
+func computeCheckRuns(c *btypes.Changeset, events ChangesetEvents) []btypes.ChangesetCheckRun {
+	var runs map[string]btypes.ChangesetCheckRun
+	switch m := c.Metadata.(type) {
+	case *github.PullRequest:
+		runs = computeGitHubCheckRuns(c.UpdatedAt, m, events)
+	case *gitlab.MergeRequest:
+		runs = computeGitLabCheckRuns(m)
+	case *bitbucketserver.PullRequest:
+		runs = computeBitbucketServerCheckRuns(c.UpdatedAt, m, events)
+	case *bbcs.AnnotatedPullRequest:
+		runs = computeBitbucketCloudCheckRuns(c.UpdatedAt, m, events)
+	}
+
+	if len(runs) == 0 {
+		return nil
+	}
+
+	checkRuns := make([]btypes.ChangesetCheckRun, 0, len(runs))
+	for _, r := range runs {
+		checkRuns = append(checkRuns, r)
+	}
+	sort.SliceStable(checkRuns, func(i, j int) bool {
+		if checkRuns[i].Name != checkRuns[j].Name {
+			return checkRuns[i].Name < checkRuns[j].Name
+		}
+		return checkRuns[i].URL < checkRuns[j].URL
+	})
+	return checkRuns
+}
+
+func computeGitHubCheckRuns(lastSynced time.Time, pr *github.PullRequest, events []*btypes.ChangesetEvent) map[string]btypes.ChangesetCheckRun {
+	// Like in computeGitHubCheckState, only the checks of the latest commit
+	// are considered. Commit statuses are keyed by their context, check runs
+	// by their ID.
+	var latestCommitTime time.Time
+	var latestOID string
+	contexts := make(map[string]btypes.ChangesetCheckRun)
+	checkRuns := make(map[string]btypes.ChangesetCheckRun)
+
+	if len(pr.Commits.Nodes) > 0 {
+		commit := pr.Commits.Nodes[0]
+		latestCommitTime = commit.Commit.CommittedDate
+		latestOID = commit.Commit.OID
+		for _, c := range commit.Commit.Status.Contexts {
+			contexts[c.Context] = btypes.ChangesetCheckRun{
+				Name:  c.Context,
+				State: parseGithubCheckState(c.State),
+				URL:   c.TargetURL,
+			}
+		}
+		for _, s := range commit.Commit.CheckSuites.Nodes {
+			for _, r := range s.CheckRuns.Nodes {
+				checkRuns[r.ID] = btypes.ChangesetCheckRun{
+					Name:  r.Name,
+					State: parseGithubCheckSuiteState(r.Status, r.Conclusion),
+					URL:   r.DetailsURL,
+				}
+			}
+		}
+	}
+
+	var statuses []*github.CommitStatus
+	for _, e := range events {
+		switch m := e.Metadata.(type) {
+		case *github.CommitStatus:
+			if m.ReceivedAt.After(lastSynced) {
+				statuses = append(statuses, m)
+			}
+		case *github.PullRequestCommit:
+			if m.Commit.CommittedDate.After(latestCommitTime) {
+				latestCommitTime = m.Commit.CommittedDate
+				latestOID = m.Commit.OID
+				// The statuses are now out of date.
+				contexts = make(map[string]btypes.ChangesetCheckRun)
+			}
+		case *github.CheckRun:
+			if m.ReceivedAt.After(lastSynced) {
+				run := btypes.ChangesetCheckRun{
+					Name:  m.Name,
+					State: parseGithubCheckSuiteState(m.Status, m.Conclusion),
+					URL:   m.DetailsURL,
+				}
+				// Events recorded before check run names were stored don't
+				// have a name, so we keep the one from the last sync.
+				if prev, ok := checkRuns[m.ID]; ok && run.Name == "" {
+					run.Name = prev.Name
+					run.URL = prev.URL
+				}
+				checkRuns[m.ID] = run
+			}
+		}
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].ReceivedAt.Before(statuses[j].ReceivedAt)
+	})
+	for _, s := range statuses {
+		if s.SHA != latestOID {
+			continue
+		}
+		contexts[s.Context] = btypes.ChangesetCheckRun{
+			Name:  s.Context,
+			State: parseGithubCheckState(s.State),
+			URL:   s.TargetURL,
+		}
+	}
+
+	for id, r := range checkRuns {
+		contexts["run:"+id] = r
+	}
+	return contexts
+}
+
+func computeGitLabCheckRuns(mr *gitlab.MergeRequest) map[string]btypes.ChangesetCheckRun {
+	// Jobs are only loaded when syncing, pipeline webhooks don't contain
+	// them.
+	runs := make(map[string]btypes.ChangesetCheckRun, len(mr.Jobs))
+	for _, j := range mr.Jobs {
+		state := parseGitLabPipelineStatus(j.Status)
+		if j.AllowFailure && state == btypes.ChangesetCheckStateFailed {
+			// Jobs that are allowed to fail don't fail the pipeline.
+			state = btypes.ChangesetCheckStatePassed
+		}
+		runs[strconv.FormatInt(int64(j.ID), 10)] = btypes.ChangesetCheckRun{
+			Name:  j.Name,
+			State: state,
+			URL:   j.WebURL,
+		}
+	}
+	return runs
+}
+
+func computeBitbucketServerCheckRuns(lastSynced time.Time, pr *bitbucketserver.PullRequest, events []*btypes.ChangesetEvent) map[string]btypes.ChangesetCheckRun {
+	var latestCommit bitbucketserver.Commit
+	for _, c := range pr.Commits {
+		if latestCommit.CommitterTimestamp <= c.CommitterTimestamp {
+			latestCommit = *c
+		}
+	}
+
+	runs := make(map[string]btypes.ChangesetCheckRun)
+	add := func(s *bitbucketserver.CommitStatus) {
+		name := s.Status.Name
+		if name == "" {
+			name = s.Status.Key
+		}
+		runs[s.Key()] = btypes.ChangesetCheckRun{
+			Name:  name,
+			State: parseBitbucketServerBuildState(s.Status.State),
+			URL:   s.Status.Url,
+		}
+	}
+
+	for _, s := range pr.CommitStatus {
+		add(s)
+	}
+	for _, e := range events {
+		if m, ok := e.Metadata.(*bitbucketserver.CommitStatus); ok {
+			if m.Commit != latestCommit.ID || unixMilliToTime(m.Status.DateAdded).Before(lastSynced) {
+				continue
+			}
+			add(m)
+		}
+	}
+	return runs
+}
+
+func computeBitbucketCloudCheckRuns(lastSynced time.Time, apr *bbcs.AnnotatedPullRequest, events []*btypes.ChangesetEvent) map[string]btypes.ChangesetCheckRun {
+	// Statuses are keyed by their key, which identifies the build that
+	// reported them across commits.
+	runs := make(map[string]btypes.ChangesetCheckRun)
+	for _, s := range apr.Statuses {
+		runs[s.StatusKey] = btypes.ChangesetCheckRun{
+			Name:  s.Name,
+			State: parseBitbucketCloudBuildState(s.State),
+			URL:   s.URL,
+		}
+	}
+
+	add := func(s *bitbucketcloud.CommitStatus) {
+		if lastSynced.Before(s.CreatedOn) {
+			runs[s.Key] = btypes.ChangesetCheckRun{
+				Name:  s.Name,
+				State: parseBitbucketCloudBuildState(s.State),
+				URL:   s.URL,
+			}
+		}
+	}
+	for _, e := range events {
+		switch m := e.Metadata.(type) {
+		case *bitbucketcloud.RepoCommitStatusCreatedEvent:
+			add(&m.CommitStatus)
+		case *bitbucketcloud.RepoCommitStatusUpdatedEvent:
+			add(&m.CommitStatus)
+		}
+	}
+	return runs
+}
@@ -10,9 +10,11 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/batches/sources/azuredevops"
 	gerritbatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gerrit"
+	giteabatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	adobatches "github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/perforce"
 
 	"github.com/sourcegraph/go-diff/diff"
@@ -53,6 +55,13 @@ func SetDerivedState(ctx context.Context, repoStore database.RepoStore, client g
 	}
 
 	c.ExternalCheckState = computeCheckState(c, events)
+	c.ExternalCheckRuns = computeCheckRuns(c, events)
+
+	if decisions, err := computeReviewerDecisions(events); err != nil {
+		logger.Warn("Computing changeset reviewer decisions", log.Error(err))
+	} else {
+		c.ExternalReviewerDecisions = decisions
+	}
 
 	history, err := computeHistory(c, events)
 	if err != nil {
@@ -123,6 +132,8 @@ func computeCheckState(c *btypes.Changeset, events ChangesetEvents) btypes.Chang
 		return computeAzureDevOpsBuildState(m)
 	case *gerritbatches.AnnotatedChange:
 		return computeGerritBuildState(m)
+	case *giteabatches.AnnotatedPullRequest:
+		return computeGiteaCheckState(m)
 	case *perforce.ChangelistState:
 		// Perforce doesn't have builds built-in, its better to be explicit by still
 		// including this case for clarity.
@@ -132,6 +143,59 @@ func computeCheckState(c *btypes.Changeset, events ChangesetEvents) btypes.Chang
 	return btypes.ChangesetCheckStateUnknown
 }
 
+// HasChecks returns whether any checks have been reported for the changeset on
+// the code host, either when it was last synced or through the given events.
+// Changesets without any checks have an unknown check state, which can't be
+// told apart from checks in a state we don't know about otherwise.
+func HasChecks(c *btypes.Changeset, events []*btypes.ChangesetEvent) bool {
+	for _, e := range events {
+		switch m := e.Metadata.(type) {
+		case *github.CommitStatus, *github.CheckRun, *bitbucketserver.CommitStatus, *gitlab.Pipeline,
+			*bitbucketcloud.RepoCommitStatusCreatedEvent, *bitbucketcloud.RepoCommitStatusUpdatedEvent:
+			return true
+		case *github.CheckSuite:
+			if len(m.CheckRuns.Nodes) > 0 {
+				return true
+			}
+		}
+	}
+
+	switch m := c.Metadata.(type) {
+	case *github.PullRequest:
+		for _, commit := range m.Commits.Nodes {
+			if len(commit.Commit.Status.Contexts) > 0 {
+				return true
+			}
+			for _, suite := range commit.Commit.CheckSuites.Nodes {
+				if len(suite.CheckRuns.Nodes) > 0 {
+					return true
+				}
+			}
+		}
+		return false
+	case *bitbucketserver.PullRequest:
+		return len(m.CommitStatus) > 0
+	case *gitlab.MergeRequest:
+		return len(m.Pipelines) > 0 || m.HeadPipeline != nil
+	case *bbcs.AnnotatedPullRequest:
+		return len(m.Statuses) > 0
+	case *azuredevops.AnnotatedPullRequest:
+		return len(m.Statuses) > 0
+	case *gerritbatches.AnnotatedChange:
+		for _, reviewer := range m.Reviewers {
+			for key := range reviewer.Approvals {
+				if key != gerrit.CodeReviewKey {
+					return true
+				}
+			}
+		}
+		return false
+	case *giteabatches.AnnotatedPullRequest:
+		return m.Status != nil && m.Status.TotalCount > 0
+	}
+	return false
+}
+
 // computeExternalState computes the external state for the changeset and its
 // associated events.
 func computeExternalState(c *btypes.Changeset, history []changesetStatesAtTime, repo *types.Repo) (btypes.ChangesetExternalState, error) {
@@ -344,6 +408,31 @@ func parseGerritBuildState(s string) btypes.ChangesetCheckState {
 	}
 }
 
+func computeGiteaCheckState(apr *giteabatches.AnnotatedPullRequest) btypes.ChangesetCheckState {
+	if apr.Status == nil || apr.Status.TotalCount == 0 {
+		return btypes.ChangesetCheckStateUnknown
+	}
+
+	states := make([]btypes.ChangesetCheckState, 0, len(apr.Status.Statuses))
+	for _, status := range apr.Status.Statuses {
+		states = append(states, parseGiteaCheckState(status.State))
+	}
+	return combineCheckStates(states)
+}
+
+func parseGiteaCheckState(s gitea.CommitStatusState) btypes.ChangesetCheckState {
+	switch s {
+	case gitea.CommitStatusError, gitea.CommitStatusFailure, gitea.CommitStatusWarning:
+		return btypes.ChangesetCheckStateFailed
+	case gitea.CommitStatusPending:
+		return btypes.ChangesetCheckStatePending
+	case gitea.CommitStatusSuccess:
+		return btypes.ChangesetCheckStatePassed
+	default:
+		return btypes.ChangesetCheckStateUnknown
+	}
+}
+
 func computeGitHubCheckState(lastSynced time.Time, pr *github.PullRequest, events []*btypes.ChangesetEvent) btypes.ChangesetCheckState {
 	// We should only consider the latest commit. This could be from a sync or a webhook that
 	// has occurred later
@@ -638,6 +727,17 @@ func computeSingleChangesetExternalState(c *btypes.Changeset) (s btypes.Changese
 		default:
 			return "", errors.Errorf("unknown Gerrit Change state: %s", m.Change.Status)
 		}
+	case *giteabatches.AnnotatedPullRequest:
+		switch {
+		case m.HasMerged:
+			s = btypes.ChangesetExternalStateMerged
+		case m.State == gitea.PullRequestStateClosed:
+			s = btypes.ChangesetExternalStateClosed
+		case m.State == gitea.PullRequestStateOpen:
+			s = btypes.ChangesetExternalStateOpen
+		default:
+			return "", errors.Errorf("unknown Gitea pull request state: %s", m.State)
+		}
 	case *perforce.Changelist:
 		switch m.State {
 		case perforce.ChangelistStateClosed:
@@ -757,6 +857,30 @@ func computeSingleChangesetReviewState(c *btypes.Changeset) (s btypes.ChangesetR
 			}
 
 		}
+	case *giteabatches.AnnotatedPullRequest:
+		// Reviews are returned in chronological order, so only the last
+		// non-comment review of each reviewer counts. Stale and dismissed
+		// reviews no longer apply to the current head.
+		latest := make(map[int64]gitea.ReviewState)
+		for _, review := range m.Reviews {
+			if review.Reviewer == nil || review.Stale || review.Dismissed || review.State == gitea.ReviewStateComment {
+				continue
+			}
+			latest[review.Reviewer.ID] = review.State
+		}
+		if len(latest) == 0 {
+			states[btypes.ChangesetReviewStatePending] = true
+		}
+		for _, state := range latest {
+			switch state {
+			case gitea.ReviewStateApproved:
+				states[btypes.ChangesetReviewStateApproved] = true
+			case gitea.ReviewStateRequestChanges:
+				states[btypes.ChangesetReviewStateChangesRequested] = true
+			default:
+				states[btypes.ChangesetReviewStatePending] = true
+			}
+		}
 	case *perforce.Changelist:
 		states[btypes.ChangesetReviewStatePending] = true
 	default:
@@ -10,7 +10,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	azuredevops2 "github.com/sourcegraph/sourcegraph/internal/batches/sources/azuredevops"
+	giteabatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/perforce"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 
@@ -396,6 +398,118 @@ func TestComputeAzureDevOpsBuildStatus(t *testing.T) {
 	}
 }
 
+func TestComputeGiteaCheckState(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status *gitea.CombinedStatus
+		want   btypes.ChangesetCheckState
+	}{
+		{
+			name:   "no status",
+			status: nil,
+			want:   btypes.ChangesetCheckStateUnknown,
+		},
+		{
+			name:   "no statuses",
+			status: &gitea.CombinedStatus{State: gitea.CommitStatusPending},
+			want:   btypes.ChangesetCheckStateUnknown,
+		},
+		{
+			name: "single success",
+			status: &gitea.CombinedStatus{TotalCount: 1, Statuses: []*gitea.CommitStatus{
+				{ID: 1, State: gitea.CommitStatusSuccess},
+			}},
+			want: btypes.ChangesetCheckStatePassed,
+		},
+		{
+			name: "warning + success",
+			status: &gitea.CombinedStatus{TotalCount: 2, Statuses: []*gitea.CommitStatus{
+				{ID: 1, State: gitea.CommitStatusWarning},
+				{ID: 2, State: gitea.CommitStatusSuccess},
+			}},
+			want: btypes.ChangesetCheckStateFailed,
+		},
+		{
+			name: "failure + pending",
+			status: &gitea.CombinedStatus{TotalCount: 2, Statuses: []*gitea.CommitStatus{
+				{ID: 1, State: gitea.CommitStatusFailure},
+				{ID: 2, State: gitea.CommitStatusPending},
+			}},
+			want: btypes.ChangesetCheckStatePending,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have := computeGiteaCheckState(&giteabatches.AnnotatedPullRequest{
+				PullRequest: &gitea.PullRequest{},
+				Status:      tc.status,
+			})
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestHasChecks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		metadata any
+		events   []*btypes.ChangesetEvent
+		want     bool
+	}{
+		{
+			name:     "github without checks",
+			metadata: &github.PullRequest{},
+			want:     false,
+		},
+		{
+			name: "bitbucket server with status",
+			metadata: &bitbucketserver.PullRequest{CommitStatus: []*bitbucketserver.CommitStatus{
+				{Commit: "commit1", Status: bitbucketserver.BuildStatus{State: "INPROGRESS", Key: "ci"}},
+			}},
+			want: true,
+		},
+		{
+			name:     "github with status event",
+			metadata: &github.PullRequest{},
+			events: []*btypes.ChangesetEvent{
+				{Kind: btypes.ChangesetEventKindCommitStatus, Metadata: &github.CommitStatus{SHA: "commit1", Context: "ctx1", State: "PENDING"}},
+			},
+			want: true,
+		},
+		{
+			name:     "gitlab without pipelines",
+			metadata: &gitlab.MergeRequest{},
+			want:     false,
+		},
+		{
+			name:     "gitlab with head pipeline",
+			metadata: &gitlab.MergeRequest{HeadPipeline: &gitlab.Pipeline{Status: gitlab.PipelineStatusRunning}},
+			want:     true,
+		},
+		{
+			name:     "gitea without statuses",
+			metadata: &giteabatches.AnnotatedPullRequest{PullRequest: &gitea.PullRequest{}, Status: &gitea.CombinedStatus{}},
+			want:     false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have := HasChecks(&btypes.Changeset{Metadata: tc.metadata}, tc.events)
+			if have != tc.want {
+				t.Fatalf("wrong result. want=%t, have=%t", tc.want, have)
+			}
+		})
+	}
+}
+
 func TestComputeGitLabCheckState(t *testing.T) {
 	t.Parallel()
 
@@ -526,6 +640,125 @@ func TestComputeGitLabCheckState(t *testing.T) {
 	})
 }
 
+func TestComputeCheckRuns(t *testing.T) {
+	t.Parallel()
+
+	now := timeutil.Now()
+	lastSynced := now.Add(-1 * time.Minute)
+
+	t.Run("github", func(t *testing.T) {
+		pr := &github.PullRequest{}
+		commit := github.CommitWithChecks{}
+		commit.Commit.OID = "deadbeef"
+		commit.Commit.CommittedDate = now.Add(-10 * time.Minute)
+		commit.Commit.Status.Contexts = []github.Context{
+			{Context: "ci/lint", State: "SUCCESS", TargetURL: "https://ci.example.com/lint"},
+		}
+		suite := github.CheckSuite{ID: "cs1", Status: "IN_PROGRESS"}
+		suite.CheckRuns.Nodes = []github.CheckRun{
+			{ID: "cr1", Name: "build", Status: "IN_PROGRESS", DetailsURL: "https://ci.example.com/build"},
+		}
+		commit.Commit.CheckSuites.Nodes = []github.CheckSuite{suite}
+		pr.Commits.Nodes = []github.CommitWithChecks{commit}
+
+		events := []*btypes.ChangesetEvent{
+			{
+				Kind: btypes.ChangesetEventKindCommitStatus,
+				Metadata: &github.CommitStatus{
+					SHA:        "deadbeef",
+					Context:    "ci/test",
+					State:      "FAILURE",
+					TargetURL:  "https://ci.example.com/test",
+					ReceivedAt: now,
+				},
+			},
+			{
+				Kind: btypes.ChangesetEventKindCommitStatus,
+				Metadata: &github.CommitStatus{
+					SHA:        "other",
+					Context:    "ci/stale",
+					State:      "FAILURE",
+					ReceivedAt: now,
+				},
+			},
+			{
+				Kind: btypes.ChangesetEventKindCheckRun,
+				Metadata: &github.CheckRun{
+					ID:         "cr1",
+					Name:       "build",
+					Status:     "COMPLETED",
+					Conclusion: "FAILURE",
+					DetailsURL: "https://ci.example.com/build",
+					ReceivedAt: now,
+				},
+			},
+		}
+
+		c := &btypes.Changeset{UpdatedAt: lastSynced, Metadata: pr}
+		want := []btypes.ChangesetCheckRun{
+			{Name: "build", State: btypes.ChangesetCheckStateFailed, URL: "https://ci.example.com/build"},
+			{Name: "ci/lint", State: btypes.ChangesetCheckStatePassed, URL: "https://ci.example.com/lint"},
+			{Name: "ci/test", State: btypes.ChangesetCheckStateFailed, URL: "https://ci.example.com/test"},
+		}
+		if diff := cmp.Diff(want, computeCheckRuns(c, events)); diff != "" {
+			t.Fatalf("wrong check runs: %s", diff)
+		}
+	})
+
+	t.Run("gitlab", func(t *testing.T) {
+		mr := &gitlab.MergeRequest{
+			Jobs: []*gitlab.Job{
+				{ID: 2, Name: "test", Status: gitlab.PipelineStatusFailed, WebURL: "https://gitlab.com/-/jobs/2"},
+				{ID: 1, Name: "lint", Status: gitlab.PipelineStatusFailed, AllowFailure: true},
+				{ID: 3, Name: "deploy", Status: gitlab.PipelineStatusRunning},
+			},
+		}
+
+		c := &btypes.Changeset{UpdatedAt: lastSynced, Metadata: mr}
+		want := []btypes.ChangesetCheckRun{
+			{Name: "deploy", State: btypes.ChangesetCheckStatePending},
+			{Name: "lint", State: btypes.ChangesetCheckStatePassed},
+			{Name: "test", State: btypes.ChangesetCheckStateFailed, URL: "https://gitlab.com/-/jobs/2"},
+		}
+		if diff := cmp.Diff(want, computeCheckRuns(c, nil)); diff != "" {
+			t.Fatalf("wrong check runs: %s", diff)
+		}
+	})
+
+	t.Run("bitbucketserver", func(t *testing.T) {
+		pr := &bitbucketserver.PullRequest{
+			Commits: []*bitbucketserver.Commit{{ID: "deadbeef"}},
+			CommitStatus: []*bitbucketserver.CommitStatus{
+				{Commit: "deadbeef", Status: bitbucketserver.BuildStatus{Key: "build", Name: "Build", State: "INPROGRESS", Url: "https://ci.example.com/build"}},
+			},
+		}
+		events := []*btypes.ChangesetEvent{
+			{
+				Kind: btypes.ChangesetEventKindBitbucketServerCommitStatus,
+				Metadata: &bitbucketserver.CommitStatus{
+					Commit: "deadbeef",
+					Status: bitbucketserver.BuildStatus{Key: "build", Name: "Build", State: "FAILED", Url: "https://ci.example.com/build", DateAdded: int64(timeToUnixMilli(now))},
+				},
+			},
+		}
+
+		c := &btypes.Changeset{UpdatedAt: lastSynced, Metadata: pr}
+		want := []btypes.ChangesetCheckRun{
+			{Name: "Build", State: btypes.ChangesetCheckStateFailed, URL: "https://ci.example.com/build"},
+		}
+		if diff := cmp.Diff(want, computeCheckRuns(c, events)); diff != "" {
+			t.Fatalf("wrong check runs: %s", diff)
+		}
+	})
+
+	t.Run("no checks", func(t *testing.T) {
+		c := &btypes.Changeset{UpdatedAt: lastSynced, Metadata: &github.PullRequest{}}
+		if have := computeCheckRuns(c, nil); have != nil {
+			t.Fatalf("unexpected check runs: %+v", have)
+		}
+	})
+}
+
 func TestComputeReviewState(t *testing.T) {
 	t.Parallel()
 
@@ -655,6 +888,40 @@ func TestComputeReviewState(t *testing.T) {
 			},
 			want: btypes.ChangesetReviewStateChangesRequested,
 		},
+		{
+			name:      "gitea - no reviews",
+			changeset: giteaChangeset(daysAgo(0), gitea.PullRequestStateOpen, false, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStatePending,
+		},
+		{
+			name: "gitea - changes requested, then approved",
+			changeset: giteaChangeset(daysAgo(0), gitea.PullRequestStateOpen, false, []*gitea.PullReview{
+				{Reviewer: &gitea.User{ID: 1}, State: gitea.ReviewStateRequestChanges},
+				{Reviewer: &gitea.User{ID: 1}, State: gitea.ReviewStateComment},
+				{Reviewer: &gitea.User{ID: 1}, State: gitea.ReviewStateApproved},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateApproved,
+		},
+		{
+			name: "gitea - stale changes requested",
+			changeset: giteaChangeset(daysAgo(0), gitea.PullRequestStateOpen, false, []*gitea.PullReview{
+				{Reviewer: &gitea.User{ID: 1}, State: gitea.ReviewStateApproved},
+				{Reviewer: &gitea.User{ID: 2}, State: gitea.ReviewStateRequestChanges, Stale: true},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateApproved,
+		},
+		{
+			name: "gitea - one reviewer requested changes",
+			changeset: giteaChangeset(daysAgo(0), gitea.PullRequestStateOpen, false, []*gitea.PullReview{
+				{Reviewer: &gitea.User{ID: 1}, State: gitea.ReviewStateApproved},
+				{Reviewer: &gitea.User{ID: 2}, State: gitea.ReviewStateRequestChanges},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateChangesRequested,
+		},
 	}
 
 	for i, tc := range tests {
@@ -673,6 +940,31 @@ func TestComputeReviewState(t *testing.T) {
 	}
 }
 
+func TestComputeReviewerDecisions(t *testing.T) {
+	t.Parallel()
+
+	now := timeutil.Now()
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	events := ChangesetEvents{
+		ghReview(1, daysAgo(5), "alice", "CHANGES_REQUESTED"),
+		ghReview(1, daysAgo(4), "bob", "APPROVED"),
+		ghReview(1, daysAgo(3), "carol", "COMMENTED"),
+		ghReview(1, daysAgo(2), "bob", "DISMISSED"),
+		ghReview(1, daysAgo(1), "dave", "APPROVED"),
+		ghReview(1, daysAgo(0), "alice", "APPROVED"),
+	}
+
+	have, err := computeReviewerDecisions(events)
+	require.NoError(t, err)
+
+	want := []btypes.ChangesetReviewerDecision{
+		{Reviewer: "alice", State: btypes.ChangesetReviewStateApproved},
+		{Reviewer: "dave", State: btypes.ChangesetReviewStateApproved},
+	}
+	assert.Equal(t, want, have)
+}
+
 func TestComputeExternalState(t *testing.T) {
 	t.Parallel()
 
@@ -864,6 +1156,24 @@ func TestComputeExternalState(t *testing.T) {
 			},
 			want: btypes.ChangesetExternalStateReadOnly,
 		},
+		{
+			name:      "gitea open - no events",
+			changeset: giteaChangeset(daysAgo(10), gitea.PullRequestStateOpen, false, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateOpen,
+		},
+		{
+			name:      "gitea closed - no events",
+			changeset: giteaChangeset(daysAgo(10), gitea.PullRequestStateClosed, false, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateClosed,
+		},
+		{
+			name:      "gitea merged - no events",
+			changeset: giteaChangeset(daysAgo(10), gitea.PullRequestStateClosed, true, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateMerged,
+		},
 		{
 			name:      "perforce closed - no events",
 			changeset: perforceChangeset(daysAgo(10), perforce.ChangelistStateClosed),
@@ -1051,6 +1361,20 @@ func gitLabChangeset(updatedAt time.Time, state gitlab.MergeRequestState, notes
 	}
 }
 
+func giteaChangeset(updatedAt time.Time, state gitea.PullRequestState, merged bool, reviews []*gitea.PullReview) *btypes.Changeset {
+	return &btypes.Changeset{
+		ExternalServiceType: extsvc.TypeGitea,
+		UpdatedAt:           updatedAt,
+		Metadata: &giteabatches.AnnotatedPullRequest{
+			PullRequest: &gitea.PullRequest{
+				State:     state,
+				HasMerged: merged,
+			},
+			Reviews: reviews,
+		},
+	}
+}
+
 func perforceChangeset(updatedAt time.Time, state perforce.ChangelistState) *btypes.Changeset {
 	return &btypes.Changeset{
 		ExternalServiceType: extsvc.TypePerforce,
@@ -37,6 +37,11 @@ var batchChangeColumns = []*sqlf.Query{
 	sqlf.Sprintf("batch_changes.updated_at"),
 	sqlf.Sprintf("batch_changes.closed_at"),
 	sqlf.Sprintf("batch_changes.batch_spec_id"),
+	sqlf.Sprintf("batch_changes.auto_rebase"),
+	sqlf.Sprintf("batch_changes.auto_merge"),
+	sqlf.Sprintf("batch_changes.auto_merge_max_per_hour"),
+	sqlf.Sprintf("batch_changes.auto_merge_squash"),
+	sqlf.Sprintf("batch_changes.auto_rebase_max_behind"),
 }
 
 // batchChangeInsertColumns is the list of batch changes columns that are
@@ -237,6 +242,66 @@ func (s *Store) updateBatchChangeQuery(c *btypes.BatchChange) *sqlf.Query {
 	)
 }
 
+// UpdateBatchChangeAutoRebase updates only the auto-rebase columns &
+// `updated_at` of the given batch change. The setting is not part of
+// UpdateBatchChange, so that applying a batch spec doesn't reset it.
+func (s *Store) UpdateBatchChangeAutoRebase(ctx context.Context, c *btypes.BatchChange) (err error) {
+	ctx, _, endObservation := s.operations.updateBatchChangeAutoRebase.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("ID", int(c.ID)),
+		attribute.Bool("autoRebase", c.AutoRebase),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	c.UpdatedAt = s.now()
+	q := sqlf.Sprintf(
+		updateBatchChangeAutoRebaseQueryFmtstr,
+		c.AutoRebase,
+		c.AutoRebaseMaxBehind,
+		c.UpdatedAt,
+		c.ID,
+		sqlf.Join(batchChangeColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc dbutil.Scanner) (err error) { return scanBatchChange(c, sc) })
+}
+
+var updateBatchChangeAutoRebaseQueryFmtstr = `
+UPDATE batch_changes
+SET (auto_rebase, auto_rebase_max_behind, updated_at) = (%s, %s, %s)
+WHERE id = %s
+RETURNING %s
+`
+
+// UpdateBatchChangeAutoMerge updates only the auto-merge policy columns &
+// `updated_at` of the given batch change.
+func (s *Store) UpdateBatchChangeAutoMerge(ctx context.Context, c *btypes.BatchChange) (err error) {
+	ctx, _, endObservation := s.operations.updateBatchChangeAutoMerge.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("ID", int(c.ID)),
+		attribute.Bool("autoMerge", c.AutoMerge),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	c.UpdatedAt = s.now()
+	q := sqlf.Sprintf(
+		updateBatchChangeAutoMergeQueryFmtstr,
+		c.AutoMerge,
+		c.AutoMergeMaxPerHour,
+		c.AutoMergeSquash,
+		c.UpdatedAt,
+		c.ID,
+		sqlf.Join(batchChangeColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc dbutil.Scanner) (err error) { return scanBatchChange(c, sc) })
+}
+
+var updateBatchChangeAutoMergeQueryFmtstr = `
+UPDATE batch_changes
+SET (auto_merge, auto_merge_max_per_hour, auto_merge_squash, updated_at) = (%s, %s, %s, %s)
+WHERE id = %s
+RETURNING %s
+`
+
 // DeleteBatchChange deletes the batch change with the given ID.
 func (s *Store) DeleteBatchChange(ctx context.Context, id int64) (err error) {
 	ctx, _, endObservation := s.operations.deleteBatchChange.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
@@ -402,6 +467,11 @@ func (s *Store) GetBatchChange(ctx context.Context, opts GetBatchChangeOpts) (bc
 			&c.UpdatedAt,
 			&dbutil.NullTime{Time: &c.ClosedAt},
 			&c.BatchSpecID,
+			&c.AutoRebase,
+			&c.AutoMerge,
+			&c.AutoMergeMaxPerHour,
+			&c.AutoMergeSquash,
+			&c.AutoRebaseMaxBehind,
 			// Namespace deleted values
 			&dbutil.NullTime{Time: &userDeletedAt},
 			&dbutil.NullTime{Time: &orgDeletedAt},
@@ -710,6 +780,11 @@ func scanBatchChange(c *btypes.BatchChange, s dbutil.Scanner) error {
 		&c.UpdatedAt,
 		&dbutil.NullTime{Time: &c.ClosedAt},
 		&c.BatchSpecID,
+		&c.AutoRebase,
+		&c.AutoMerge,
+		&c.AutoMergeMaxPerHour,
+		&c.AutoMergeSquash,
+		&c.AutoRebaseMaxBehind,
 	)
 }
 
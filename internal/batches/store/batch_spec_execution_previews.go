@@ -0,0 +1,111 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"go.opentelemetry.io/otel/attribute"
+
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// CreateBatchSpecExecutionPreview creates the given batch spec execution
+// preview.
+func (s *Store) CreateBatchSpecExecutionPreview(ctx context.Context, p *btypes.BatchSpecExecutionPreview) (err error) {
+	ctx, _, endObservation := s.operations.createBatchSpecExecutionPreview.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("batchSpecID", int(p.BatchSpecID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = s.now()
+	}
+
+	q := sqlf.Sprintf(
+		createBatchSpecExecutionPreviewQueryFmtstr,
+		p.BatchSpecID,
+		p.SampleSize,
+		p.Workspaces,
+		p.CreatedAt,
+		sqlf.Join(batchSpecExecutionPreviewColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanBatchSpecExecutionPreview(p, sc)
+	})
+}
+
+var createBatchSpecExecutionPreviewQueryFmtstr = `
+INSERT INTO batch_spec_execution_previews (batch_spec_id, sample_size, workspaces, created_at)
+VALUES (%s, %s, %s, %s)
+RETURNING %s
+`
+
+// GetBatchSpecExecutionPreview returns the execution preview of the batch
+// spec with the given ID. ErrNoResults is returned if the batch spec hasn't
+// been previewed.
+func (s *Store) GetBatchSpecExecutionPreview(ctx context.Context, batchSpecID int64) (p *btypes.BatchSpecExecutionPreview, err error) {
+	ctx, _, endObservation := s.operations.getBatchSpecExecutionPreview.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("batchSpecID", int(batchSpecID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		getBatchSpecExecutionPreviewQueryFmtstr,
+		sqlf.Join(batchSpecExecutionPreviewColumns, ", "),
+		batchSpecID,
+	)
+
+	var c btypes.BatchSpecExecutionPreview
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanBatchSpecExecutionPreview(&c, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if c.BatchSpecID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &c, nil
+}
+
+var getBatchSpecExecutionPreviewQueryFmtstr = `
+SELECT %s FROM batch_spec_execution_previews
+WHERE batch_spec_id = %s
+`
+
+// DeleteBatchSpecExecutionPreview deletes the execution preview of the batch
+// spec with the given ID, if it exists.
+func (s *Store) DeleteBatchSpecExecutionPreview(ctx context.Context, batchSpecID int64) (err error) {
+	ctx, _, endObservation := s.operations.deleteBatchSpecExecutionPreview.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("batchSpecID", int(batchSpecID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Exec(ctx, sqlf.Sprintf(deleteBatchSpecExecutionPreviewQueryFmtstr, batchSpecID))
+}
+
+var deleteBatchSpecExecutionPreviewQueryFmtstr = `
+DELETE FROM batch_spec_execution_previews
+WHERE batch_spec_id = %s
+`
+
+var batchSpecExecutionPreviewColumns = []*sqlf.Query{
+	sqlf.Sprintf("batch_spec_execution_previews.batch_spec_id"),
+	sqlf.Sprintf("batch_spec_execution_previews.sample_size"),
+	sqlf.Sprintf("batch_spec_execution_previews.workspaces"),
+	sqlf.Sprintf("batch_spec_execution_previews.created_at"),
+}
+
+func scanBatchSpecExecutionPreview(p *btypes.BatchSpecExecutionPreview, s dbutil.Scanner) error {
+	return s.Scan(
+		&p.BatchSpecID,
+		&p.SampleSize,
+		&p.Workspaces,
+		&p.CreatedAt,
+	)
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	bt "github.com/sourcegraph/sourcegraph/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+)
+
+func testStoreBatchSpecExecutionPreviews(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	user := bt.CreateTestUser(t, s.DatabaseDB(), false)
+	batchSpec := bt.CreateBatchSpec(t, ctx, s, "preview", user.ID, 0)
+
+	t.Run("Get not found", func(t *testing.T) {
+		if _, err := s.GetBatchSpecExecutionPreview(ctx, batchSpec.ID); err != ErrNoResults {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrNoResults, err)
+		}
+	})
+
+	preview := &btypes.BatchSpecExecutionPreview{
+		BatchSpecID: batchSpec.ID,
+		SampleSize:  5,
+		Workspaces:  120,
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		if err := s.CreateBatchSpecExecutionPreview(ctx, preview); err != nil {
+			t.Fatal(err)
+		}
+		if have, want := preview.CreatedAt, clock.Now(); !have.Equal(want) {
+			t.Fatalf("wrong CreatedAt. want=%s, have=%s", want, have)
+		}
+
+		if err := s.CreateBatchSpecExecutionPreview(ctx, &btypes.BatchSpecExecutionPreview{BatchSpecID: batchSpec.ID}); err == nil {
+			t.Fatal("batch spec previewed twice, but no error returned")
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		have, err := s.GetBatchSpecExecutionPreview(ctx, batchSpec.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(preview, have); diff != "" {
+			t.Fatalf("invalid preview returned (-want +have):\n%s", diff)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if err := s.DeleteBatchSpecExecutionPreview(ctx, batchSpec.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.GetBatchSpecExecutionPreview(ctx, batchSpec.ID); err != ErrNoResults {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrNoResults, err)
+		}
+
+		// Deleting a missing preview is not an error.
+		if err := s.DeleteBatchSpecExecutionPreview(ctx, batchSpec.ID); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
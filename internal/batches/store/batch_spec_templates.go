@@ -0,0 +1,291 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/keegancsmith/sqlf"
+	"go.opentelemetry.io/otel/attribute"
+
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ErrBatchSpecTemplateNameTaken is returned when a batch spec template is
+// created or renamed with the name of another template.
+var ErrBatchSpecTemplateNameTaken = errors.New("a batch spec template with this name already exists")
+
+var batchSpecTemplateColumns = []*sqlf.Query{
+	sqlf.Sprintf("batch_spec_templates.id"),
+	sqlf.Sprintf("batch_spec_templates.name"),
+	sqlf.Sprintf("batch_spec_templates.description"),
+	sqlf.Sprintf("batch_spec_templates.parameters"),
+	sqlf.Sprintf("batch_spec_templates.spec"),
+	sqlf.Sprintf("batch_spec_templates.raw_template"),
+	sqlf.Sprintf("batch_spec_templates.creator_id"),
+	sqlf.Sprintf("batch_spec_templates.created_at"),
+	sqlf.Sprintf("batch_spec_templates.updated_at"),
+}
+
+// CreateBatchSpecTemplate creates the given batch spec template.
+func (s *Store) CreateBatchSpecTemplate(ctx context.Context, t *btypes.BatchSpecTemplate) (err error) {
+	ctx, _, endObservation := s.operations.createBatchSpecTemplate.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = s.now()
+	}
+	if t.UpdatedAt.IsZero() {
+		t.UpdatedAt = t.CreatedAt
+	}
+
+	parameters, err := jsonbArrayColumn(t.Template.Parameters)
+	if err != nil {
+		return err
+	}
+
+	q := sqlf.Sprintf(
+		createBatchSpecTemplateQueryFmtstr,
+		t.Template.Name,
+		t.Template.Description,
+		parameters,
+		t.Template.Spec,
+		t.RawTemplate,
+		dbutil.NullInt32Column(t.CreatorID),
+		t.CreatedAt,
+		t.UpdatedAt,
+		sqlf.Join(batchSpecTemplateColumns, ", "),
+	)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error { return scanBatchSpecTemplate(t, sc) })
+	if isUniqueConstraintViolation(err, "batch_spec_templates_name_unique") {
+		return ErrBatchSpecTemplateNameTaken
+	}
+	return err
+}
+
+var createBatchSpecTemplateQueryFmtstr = `
+INSERT INTO batch_spec_templates (name, description, parameters, spec, raw_template, creator_id, created_at, updated_at)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING %s
+`
+
+// UpdateBatchSpecTemplate replaces the template of the given batch spec
+// template. ErrNoResults is returned if the template doesn't exist.
+func (s *Store) UpdateBatchSpecTemplate(ctx context.Context, t *btypes.BatchSpecTemplate) (err error) {
+	ctx, _, endObservation := s.operations.updateBatchSpecTemplate.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("ID", int(t.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	t.UpdatedAt = s.now()
+
+	parameters, err := jsonbArrayColumn(t.Template.Parameters)
+	if err != nil {
+		return err
+	}
+
+	q := sqlf.Sprintf(
+		updateBatchSpecTemplateQueryFmtstr,
+		t.Template.Name,
+		t.Template.Description,
+		parameters,
+		t.Template.Spec,
+		t.RawTemplate,
+		t.UpdatedAt,
+		t.ID,
+		sqlf.Join(batchSpecTemplateColumns, ", "),
+	)
+
+	updated := &btypes.BatchSpecTemplate{}
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error { return scanBatchSpecTemplate(updated, sc) })
+	if isUniqueConstraintViolation(err, "batch_spec_templates_name_unique") {
+		return ErrBatchSpecTemplateNameTaken
+	}
+	if err != nil {
+		return err
+	}
+	if updated.ID == 0 {
+		return ErrNoResults
+	}
+	*t = *updated
+	return nil
+}
+
+var updateBatchSpecTemplateQueryFmtstr = `
+UPDATE batch_spec_templates
+SET
+	name = %s,
+	description = %s,
+	parameters = %s,
+	spec = %s,
+	raw_template = %s,
+	updated_at = %s
+WHERE id = %s
+RETURNING %s
+`
+
+// DeleteBatchSpecTemplate deletes the batch spec template with the given ID.
+// ErrNoResults is returned if the template doesn't exist.
+func (s *Store) DeleteBatchSpecTemplate(ctx context.Context, id int64) (err error) {
+	ctx, _, endObservation := s.operations.deleteBatchSpecTemplate.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("ID", int(id)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(deleteBatchSpecTemplateQueryFmtstr, id))
+	if err != nil {
+		return err
+	}
+
+	if rows, err := res.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		return ErrNoResults
+	}
+	return nil
+}
+
+var deleteBatchSpecTemplateQueryFmtstr = `
+DELETE FROM batch_spec_templates WHERE id = %s
+`
+
+// GetBatchSpecTemplateOpts captures the query options needed for getting a
+// batch spec template.
+type GetBatchSpecTemplateOpts struct {
+	ID   int64
+	Name string
+}
+
+// GetBatchSpecTemplate gets a batch spec template matching the given options.
+// ErrNoResults is returned if no template matches.
+func (s *Store) GetBatchSpecTemplate(ctx context.Context, opts GetBatchSpecTemplateOpts) (t *btypes.BatchSpecTemplate, err error) {
+	ctx, _, endObservation := s.operations.getBatchSpecTemplate.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("ID", int(opts.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	var preds []*sqlf.Query
+	if opts.ID != 0 {
+		preds = append(preds, sqlf.Sprintf("id = %s", opts.ID))
+	}
+	if opts.Name != "" {
+		preds = append(preds, sqlf.Sprintf("name = %s", opts.Name))
+	}
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+
+	q := sqlf.Sprintf(
+		getBatchSpecTemplateQueryFmtstr,
+		sqlf.Join(batchSpecTemplateColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+
+	var tmpl btypes.BatchSpecTemplate
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error { return scanBatchSpecTemplate(&tmpl, sc) })
+	if err != nil {
+		return nil, err
+	}
+
+	if tmpl.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &tmpl, nil
+}
+
+var getBatchSpecTemplateQueryFmtstr = `
+SELECT %s FROM batch_spec_templates
+WHERE %s
+LIMIT 1
+`
+
+// ListBatchSpecTemplatesOpts captures the query options needed for listing
+// batch spec templates.
+type ListBatchSpecTemplatesOpts struct {
+	LimitOpts
+	Cursor int64
+}
+
+// ListBatchSpecTemplates lists the batch spec templates in the order they
+// were published.
+func (s *Store) ListBatchSpecTemplates(ctx context.Context, opts ListBatchSpecTemplatesOpts) (ts []*btypes.BatchSpecTemplate, next int64, err error) {
+	ctx, _, endObservation := s.operations.listBatchSpecTemplates.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.Cursor != 0 {
+		preds = append(preds, sqlf.Sprintf("id >= %s", opts.Cursor))
+	}
+
+	q := sqlf.Sprintf(
+		listBatchSpecTemplatesQueryFmtstr+opts.ToDB(),
+		sqlf.Join(batchSpecTemplateColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+
+	ts = make([]*btypes.BatchSpecTemplate, 0, opts.DBLimit())
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var t btypes.BatchSpecTemplate
+		if err := scanBatchSpecTemplate(&t, sc); err != nil {
+			return err
+		}
+		ts = append(ts, &t)
+		return nil
+	})
+
+	if opts.Limit != 0 && len(ts) == opts.DBLimit() {
+		next = ts[len(ts)-1].ID
+		ts = ts[:len(ts)-1]
+	}
+
+	return ts, next, err
+}
+
+var listBatchSpecTemplatesQueryFmtstr = `
+SELECT %s FROM batch_spec_templates
+WHERE %s
+ORDER BY id ASC
+`
+
+// CountBatchSpecTemplates returns the number of batch spec templates.
+func (s *Store) CountBatchSpecTemplates(ctx context.Context) (count int, err error) {
+	ctx, _, endObservation := s.operations.countBatchSpecTemplates.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.queryCount(ctx, sqlf.Sprintf("SELECT COUNT(*) FROM batch_spec_templates"))
+}
+
+func scanBatchSpecTemplate(t *btypes.BatchSpecTemplate, s dbutil.Scanner) error {
+	var (
+		tmpl       batcheslib.BatchSpecTemplate
+		parameters json.RawMessage
+	)
+	if err := s.Scan(
+		&t.ID,
+		&tmpl.Name,
+		&tmpl.Description,
+		&parameters,
+		&tmpl.Spec,
+		&t.RawTemplate,
+		&dbutil.NullInt32{N: &t.CreatorID},
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	); err != nil {
+		return errors.Wrap(err, "scanning batch spec template")
+	}
+
+	if err := json.Unmarshal(parameters, &tmpl.Parameters); err != nil {
+		return errors.Wrap(err, "scanBatchSpecTemplate: failed to unmarshal parameters")
+	}
+	if len(tmpl.Parameters) == 0 {
+		tmpl.Parameters = nil
+	}
+	t.Template = &tmpl
+
+	return nil
+}
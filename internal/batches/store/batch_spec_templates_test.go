@@ -0,0 +1,154 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	bt "github.com/sourcegraph/sourcegraph/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+)
+
+func testStoreBatchSpecTemplates(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	admin := bt.CreateTestUser(t, s.DatabaseDB(), true)
+
+	templates := make([]*btypes.BatchSpecTemplate, 0, 3)
+	for _, name := range []string{"bump-go", "bump-node", "fix-readme"} {
+		tmpl, err := btypes.NewBatchSpecTemplateFromRaw(`
+name: ` + name + `
+description: A template
+parameters:
+  - name: repositories
+    type: repositoryQuery
+    required: true
+spec: |
+  name: ` + name + `
+  on:
+    - repositoriesMatchingQuery: ${{ params.repositories }}
+`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tmpl.CreatorID = admin.ID
+		templates = append(templates, tmpl)
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		for _, tmpl := range templates {
+			if err := s.CreateBatchSpecTemplate(ctx, tmpl); err != nil {
+				t.Fatal(err)
+			}
+			if tmpl.ID == 0 {
+				t.Fatal("template has no ID")
+			}
+		}
+
+		dup, err := btypes.NewBatchSpecTemplateFromRaw("name: bump-go\nspec: foo\n")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.CreateBatchSpecTemplate(ctx, dup); err != ErrBatchSpecTemplateNameTaken {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrBatchSpecTemplateNameTaken, err)
+		}
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		count, err := s.CountBatchSpecTemplates(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != len(templates) {
+			t.Fatalf("wrong count. want=%d, have=%d", len(templates), count)
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		for _, want := range templates {
+			have, err := s.GetBatchSpecTemplate(ctx, GetBatchSpecTemplateOpts{ID: want.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, have); diff != "" {
+				t.Fatal(diff)
+			}
+
+			have, err = s.GetBatchSpecTemplate(ctx, GetBatchSpecTemplateOpts{Name: want.Template.Name})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have.ID != want.ID {
+				t.Fatalf("wrong template. want=%d, have=%d", want.ID, have.ID)
+			}
+		}
+
+		if _, err := s.GetBatchSpecTemplate(ctx, GetBatchSpecTemplateOpts{ID: 0xdeadbeef}); err != ErrNoResults {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrNoResults, err)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		have, next, err := s.ListBatchSpecTemplates(ctx, ListBatchSpecTemplatesOpts{LimitOpts: LimitOpts{Limit: 2}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(templates[:2], have); diff != "" {
+			t.Fatal(diff)
+		}
+		if next != templates[2].ID {
+			t.Fatalf("wrong next cursor. want=%d, have=%d", templates[2].ID, next)
+		}
+
+		have, next, err = s.ListBatchSpecTemplates(ctx, ListBatchSpecTemplatesOpts{LimitOpts: LimitOpts{Limit: 2}, Cursor: next})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(templates[2:], have); diff != "" {
+			t.Fatal(diff)
+		}
+		if next != 0 {
+			t.Fatalf("unexpected next cursor %d", next)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		updated, err := btypes.NewBatchSpecTemplateFromRaw("name: bump-go-1-21\nspec: foo\n")
+		if err != nil {
+			t.Fatal(err)
+		}
+		updated.ID = templates[0].ID
+
+		clock.Add(1 * time.Second)
+		if err := s.UpdateBatchSpecTemplate(ctx, updated); err != nil {
+			t.Fatal(err)
+		}
+		if updated.Template.Name != "bump-go-1-21" || updated.Template.Parameters != nil {
+			t.Fatalf("template not updated: %+v", updated.Template)
+		}
+		if updated.CreatorID != admin.ID || !updated.UpdatedAt.After(updated.CreatedAt) {
+			t.Fatalf("wrong metadata: %+v", updated)
+		}
+
+		updated.Template.Name = templates[1].Template.Name
+		if err := s.UpdateBatchSpecTemplate(ctx, updated); err != ErrBatchSpecTemplateNameTaken {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrBatchSpecTemplateNameTaken, err)
+		}
+
+		missing := &btypes.BatchSpecTemplate{ID: 0xdeadbeef, Template: updated.Template}
+		if err := s.UpdateBatchSpecTemplate(ctx, missing); err != ErrNoResults {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrNoResults, err)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		for _, tmpl := range templates {
+			if err := s.DeleteBatchSpecTemplate(ctx, tmpl.ID); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := s.DeleteBatchSpecTemplate(ctx, templates[0].ID); err != ErrNoResults {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrNoResults, err)
+		}
+	})
+}
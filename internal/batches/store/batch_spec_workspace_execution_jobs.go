@@ -88,6 +88,13 @@ WHERE
 	batch_spec_workspaces.batch_spec_id = %s
 AND
 	%s
+AND
+	-- Workspaces that were already executed in an execution preview are not
+	-- executed again.
+	NOT EXISTS (
+		SELECT 1 FROM batch_spec_workspace_execution_jobs
+		WHERE batch_spec_workspace_execution_jobs.batch_spec_workspace_id = batch_spec_workspaces.id
+	)
 `
 
 const executableWorkspaceJobsConditionFmtstr = `
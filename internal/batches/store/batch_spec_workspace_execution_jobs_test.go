@@ -564,6 +564,23 @@ func testStoreBatchSpecWorkspaceExecutionJobs(t *testing.T, ctx context.Context,
 			createWorkspaces(t, batchSpec, normalWorkspace, ignoredWorkspace, unsupportedWorkspace)
 			createJobsAndAssert(t, batchSpec, []int64{normalWorkspace.ID, ignoredWorkspace.ID, unsupportedWorkspace.ID})
 		})
+
+		t.Run("already executed workspaces", func(t *testing.T) {
+			executedWorkspace := &btypes.BatchSpecWorkspace{}
+			normalWorkspace := &btypes.BatchSpecWorkspace{}
+
+			batchSpec := &btypes.BatchSpec{}
+
+			createBatchSpec(t, batchSpec)
+			createWorkspaces(t, batchSpec, executedWorkspace, normalWorkspace)
+
+			// Workspaces executed in an execution preview already have a job.
+			if err := s.CreateBatchSpecWorkspaceExecutionJobsForWorkspaces(ctx, []int64{executedWorkspace.ID}); err != nil {
+				t.Fatal(err)
+			}
+
+			createJobsAndAssert(t, batchSpec, []int64{executedWorkspace.ID, normalWorkspace.ID})
+		})
 	})
 
 	t.Run("CreateBatchSpecWorkspaceExecutionJobsForWorkspaces", func(t *testing.T) {
@@ -466,6 +466,12 @@ candidate_batch_spec_workspaces AS (
 		batch_spec_workspaces
 	WHERE
 		batch_spec_workspaces.batch_spec_id = (SELECT id FROM batch_spec)
+		AND
+		-- Keep the results of workspaces already executed in an execution preview.
+		NOT EXISTS (
+			SELECT 1 FROM batch_spec_workspace_execution_jobs
+			WHERE batch_spec_workspace_execution_jobs.batch_spec_workspace_id = batch_spec_workspaces.id
+		)
 	ORDER BY id
 ),
 removable_changeset_specs AS (
@@ -0,0 +1,228 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"go.opentelemetry.io/otel/attribute"
+
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// ListChangesetsToAutoMerge lists the open, published changesets that are
+// owned by an open batch change with auto-merge enabled and that are not
+// currently being processed by the reconciler.
+func (s *Store) ListChangesetsToAutoMerge(ctx context.Context) (cs btypes.Changesets, err error) {
+	ctx, _, endObservation := s.operations.listChangesetsToAutoMerge.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	q := changesetsToAutoMergeQuery(sqlf.Sprintf("TRUE"), sqlf.Sprintf("ORDER BY changesets.id ASC"))
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var c btypes.Changeset
+		if err := ScanChangeset(&c, sc); err != nil {
+			return err
+		}
+		cs = append(cs, &c)
+		return nil
+	})
+	return cs, err
+}
+
+// LockChangesetToAutoMerge locks the changeset with the given ID for the rest
+// of the transaction, if it should still be considered for auto-merging. The
+// reconciler doesn't dequeue a locked changeset, and the merge is skipped if
+// the reconciler started processing the changeset since it was listed.
+// ErrNoResults is returned if the changeset is not to be merged or is locked
+// by another transaction.
+func (s *Store) LockChangesetToAutoMerge(ctx context.Context, id int64) (ch *btypes.Changeset, err error) {
+	ctx, _, endObservation := s.operations.lockChangesetToAutoMerge.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("ID", int(id)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := changesetsToAutoMergeQuery(sqlf.Sprintf("changesets.id = %s", id), sqlf.Sprintf("FOR UPDATE OF changesets SKIP LOCKED"))
+
+	var c btypes.Changeset
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error { return ScanChangeset(&c, sc) })
+	if err != nil {
+		return nil, err
+	}
+
+	if c.ID == 0 {
+		return nil, ErrNoResults
+	}
+	return &c, nil
+}
+
+func changesetsToAutoMergeQuery(cond, suffix *sqlf.Query) *sqlf.Query {
+	return sqlf.Sprintf(
+		changesetsToAutoMergeQueryFmtstr,
+		sqlf.Join(ChangesetColumns, ", "),
+		btypes.ChangesetPublicationStatePublished,
+		btypes.ChangesetExternalStateOpen,
+		btypes.ReconcilerStateCompleted.ToDB(),
+		cond,
+		suffix,
+	)
+}
+
+var changesetsToAutoMergeQueryFmtstr = `
+SELECT %s FROM changesets
+JOIN batch_changes ON batch_changes.id = changesets.owned_by_batch_change_id
+JOIN repo ON repo.id = changesets.repo_id
+WHERE
+	batch_changes.auto_merge
+	AND batch_changes.closed_at IS NULL
+	AND changesets.publication_state = %s
+	AND changesets.external_state = %s
+	AND changesets.reconciler_state = %s
+	AND changesets.detached_at IS NULL
+	AND repo.deleted_at IS NULL
+	AND %s
+%s
+`
+
+// GetChangesetAutoMerge returns the auto-merge progress of the changeset with
+// the given ID. ErrNoResults is returned if the changeset has never been
+// considered for auto-merging.
+func (s *Store) GetChangesetAutoMerge(ctx context.Context, changesetID int64) (m *btypes.ChangesetAutoMerge, err error) {
+	ctx, _, endObservation := s.operations.getChangesetAutoMerge.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("changesetID", int(changesetID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		getChangesetAutoMergeQueryFmtstr,
+		sqlf.Join(changesetAutoMergeColumns, ", "),
+		changesetID,
+	)
+
+	var c btypes.ChangesetAutoMerge
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanChangesetAutoMerge(&c, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if c.ChangesetID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &c, nil
+}
+
+var getChangesetAutoMergeQueryFmtstr = `
+SELECT %s FROM changeset_auto_merges
+WHERE changeset_id = %s
+`
+
+// UpsertChangesetAutoMerge records the given auto-merge progress, replacing
+// any previous progress of the same changeset.
+func (s *Store) UpsertChangesetAutoMerge(ctx context.Context, m *btypes.ChangesetAutoMerge) (err error) {
+	ctx, _, endObservation := s.operations.upsertChangesetAutoMerge.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("changesetID", int(m.ChangesetID)),
+		attribute.String("state", string(m.State)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	m.UpdatedAt = s.now()
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = m.UpdatedAt
+	}
+
+	q := sqlf.Sprintf(
+		upsertChangesetAutoMergeQueryFmtstr,
+		m.ChangesetID,
+		m.BatchChangeID,
+		m.State,
+		dbutil.NullStringColumn(m.Message),
+		dbutil.NullTimeColumn(m.MergedAt),
+		m.CreatedAt,
+		m.UpdatedAt,
+		sqlf.Join(changesetAutoMergeColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanChangesetAutoMerge(m, sc)
+	})
+}
+
+var upsertChangesetAutoMergeQueryFmtstr = `
+INSERT INTO changeset_auto_merges (changeset_id, batch_change_id, state, message, merged_at, created_at, updated_at)
+VALUES (%s, %s, %s, %s, %s, %s, %s)
+ON CONFLICT (changeset_id) DO UPDATE SET
+	batch_change_id = EXCLUDED.batch_change_id,
+	state = EXCLUDED.state,
+	message = EXCLUDED.message,
+	merged_at = EXCLUDED.merged_at,
+	updated_at = EXCLUDED.updated_at
+RETURNING %s
+`
+
+// CountChangesetAutoMergesOpts captures the query options needed for counting
+// automatically merged changesets.
+type CountChangesetAutoMergesOpts struct {
+	BatchChangeID int64
+	// ExternalServiceID limits the count to changesets in repositories on the
+	// given code host.
+	ExternalServiceID string
+	MergedAfter       time.Time
+}
+
+// CountChangesetAutoMerges returns the number of changesets that have been
+// automatically merged.
+func (s *Store) CountChangesetAutoMerges(ctx context.Context, opts CountChangesetAutoMergesOpts) (count int, err error) {
+	ctx, _, endObservation := s.operations.countChangesetAutoMerges.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("batchChangeID", int(opts.BatchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("changeset_auto_merges.merged_at IS NOT NULL"),
+	}
+	if opts.BatchChangeID != 0 {
+		preds = append(preds, sqlf.Sprintf("changeset_auto_merges.batch_change_id = %s", opts.BatchChangeID))
+	}
+	if opts.ExternalServiceID != "" {
+		preds = append(preds, sqlf.Sprintf("repo.external_service_id = %s", opts.ExternalServiceID))
+	}
+	if !opts.MergedAfter.IsZero() {
+		preds = append(preds, sqlf.Sprintf("changeset_auto_merges.merged_at > %s", opts.MergedAfter))
+	}
+
+	return s.queryCount(ctx, sqlf.Sprintf(countChangesetAutoMergesQueryFmtstr, sqlf.Join(preds, "\n AND ")))
+}
+
+var countChangesetAutoMergesQueryFmtstr = `
+SELECT COUNT(*) FROM changeset_auto_merges
+JOIN changesets ON changesets.id = changeset_auto_merges.changeset_id
+JOIN repo ON repo.id = changesets.repo_id
+WHERE %s
+`
+
+var changesetAutoMergeColumns = []*sqlf.Query{
+	sqlf.Sprintf("changeset_auto_merges.changeset_id"),
+	sqlf.Sprintf("changeset_auto_merges.batch_change_id"),
+	sqlf.Sprintf("changeset_auto_merges.state"),
+	sqlf.Sprintf("changeset_auto_merges.message"),
+	sqlf.Sprintf("changeset_auto_merges.merged_at"),
+	sqlf.Sprintf("changeset_auto_merges.created_at"),
+	sqlf.Sprintf("changeset_auto_merges.updated_at"),
+}
+
+func scanChangesetAutoMerge(m *btypes.ChangesetAutoMerge, s dbutil.Scanner) error {
+	return s.Scan(
+		&m.ChangesetID,
+		&m.BatchChangeID,
+		&m.State,
+		&dbutil.NullString{S: &m.Message},
+		&dbutil.NullTime{Time: &m.MergedAt},
+		&m.CreatedAt,
+		&m.UpdatedAt,
+	)
+}
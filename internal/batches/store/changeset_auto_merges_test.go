@@ -0,0 +1,153 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	bt "github.com/sourcegraph/sourcegraph/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+)
+
+func testStoreChangesetAutoMerges(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	db := s.DatabaseDB()
+	user := bt.CreateTestUser(t, db, false)
+	repo, _ := bt.CreateTestRepo(t, ctx, db)
+
+	batchSpec := bt.CreateBatchSpec(t, ctx, s, "auto-merge", user.ID, 0)
+	batchChange := bt.CreateBatchChange(t, ctx, s, "auto-merge", user.ID, batchSpec.ID)
+	changeset := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+		Repo:               repo.ID,
+		BatchChanges:       []btypes.BatchChangeAssoc{{BatchChangeID: batchChange.ID}},
+		OwnedByBatchChange: batchChange.ID,
+		PublicationState:   btypes.ChangesetPublicationStatePublished,
+		ExternalState:      btypes.ChangesetExternalStateOpen,
+		ReconcilerState:    btypes.ReconcilerStateCompleted,
+	})
+
+	t.Run("ListChangesetsToAutoMerge", func(t *testing.T) {
+		have, err := s.ListChangesetsToAutoMerge(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 0 {
+			t.Fatalf("auto-merge is disabled, but changesets were returned: %+v", have)
+		}
+
+		batchChange.AutoMerge = true
+		batchChange.AutoMergeMaxPerHour = 5
+		batchChange.AutoMergeSquash = true
+		if err := s.UpdateBatchChangeAutoMerge(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err = s.ListChangesetsToAutoMerge(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 1 || have[0].ID != changeset.ID {
+			t.Fatalf("wrong changesets returned: %+v", have)
+		}
+
+		locked, err := s.LockChangesetToAutoMerge(ctx, changeset.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if locked.ID != changeset.ID {
+			t.Fatalf("wrong changeset locked. want=%d, have=%d", changeset.ID, locked.ID)
+		}
+		if _, err := s.LockChangesetToAutoMerge(ctx, changeset.ID+1000); err != ErrNoResults {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrNoResults, err)
+		}
+
+		reloaded, err := s.GetBatchChange(ctx, GetBatchChangeOpts{ID: batchChange.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reloaded.AutoMerge || reloaded.AutoMergeMaxPerHour != 5 || !reloaded.AutoMergeSquash {
+			t.Fatalf("auto-merge policy not persisted: %+v", reloaded)
+		}
+	})
+
+	t.Run("UpsertChangesetAutoMerge", func(t *testing.T) {
+		if _, err := s.GetChangesetAutoMerge(ctx, changeset.ID); err != ErrNoResults {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrNoResults, err)
+		}
+
+		waiting := &btypes.ChangesetAutoMerge{
+			ChangesetID:   changeset.ID,
+			BatchChangeID: batchChange.ID,
+			State:         btypes.ChangesetAutoMergeStateWaitingForReview,
+		}
+		if err := s.UpsertChangesetAutoMerge(ctx, waiting); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.GetChangesetAutoMerge(ctx, changeset.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(waiting, have); diff != "" {
+			t.Fatalf("invalid auto-merge returned (-want +have):\n%s", diff)
+		}
+
+		count, err := s.CountChangesetAutoMerges(ctx, CountChangesetAutoMergesOpts{BatchChangeID: batchChange.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 0 {
+			t.Fatalf("wrong count. want=0, have=%d", count)
+		}
+
+		merged := &btypes.ChangesetAutoMerge{
+			ChangesetID:   changeset.ID,
+			BatchChangeID: batchChange.ID,
+			State:         btypes.ChangesetAutoMergeStateMerged,
+			MergedAt:      clock.Now(),
+		}
+		if err := s.UpsertChangesetAutoMerge(ctx, merged); err != nil {
+			t.Fatal(err)
+		}
+
+		// A changeset of another batch change merged on the same code host.
+		otherBatchSpec := bt.CreateBatchSpec(t, ctx, s, "other-auto-merge", user.ID, 0)
+		otherBatchChange := bt.CreateBatchChange(t, ctx, s, "other-auto-merge", user.ID, otherBatchSpec.ID)
+		otherChangeset := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			Repo:               repo.ID,
+			BatchChanges:       []btypes.BatchChangeAssoc{{BatchChangeID: otherBatchChange.ID}},
+			OwnedByBatchChange: otherBatchChange.ID,
+			PublicationState:   btypes.ChangesetPublicationStatePublished,
+			ExternalState:      btypes.ChangesetExternalStateMerged,
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+		})
+		if err := s.UpsertChangesetAutoMerge(ctx, &btypes.ChangesetAutoMerge{
+			ChangesetID:   otherChangeset.ID,
+			BatchChangeID: otherBatchChange.ID,
+			State:         btypes.ChangesetAutoMergeStateMerged,
+			MergedAt:      clock.Now(),
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, tc := range []struct {
+			opts CountChangesetAutoMergesOpts
+			want int
+		}{
+			{opts: CountChangesetAutoMergesOpts{BatchChangeID: batchChange.ID}, want: 1},
+			{opts: CountChangesetAutoMergesOpts{BatchChangeID: batchChange.ID, ExternalServiceID: repo.ExternalRepo.ServiceID}, want: 1},
+			{opts: CountChangesetAutoMergesOpts{BatchChangeID: batchChange.ID, ExternalServiceID: "https://other.example.com/"}, want: 0},
+			{opts: CountChangesetAutoMergesOpts{BatchChangeID: batchChange.ID, MergedAfter: clock.Now()}, want: 0},
+			{opts: CountChangesetAutoMergesOpts{ExternalServiceID: repo.ExternalRepo.ServiceID}, want: 2},
+			{opts: CountChangesetAutoMergesOpts{ExternalServiceID: "https://other.example.com/"}, want: 0},
+		} {
+			have, err := s.CountChangesetAutoMerges(ctx, tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have != tc.want {
+				t.Errorf("wrong count for %+v. want=%d, have=%d", tc.opts, tc.want, have)
+			}
+		}
+	})
+}
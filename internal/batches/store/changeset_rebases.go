@@ -0,0 +1,192 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// ListChangesetsToRebase lists the changesets whose diff should be re-applied
+// onto the latest commit of their base branch: open, published branch
+// changesets that are owned by an open batch change with auto-rebase enabled
+// and that are not currently being processed by the reconciler.
+func (s *Store) ListChangesetsToRebase(ctx context.Context) (cs btypes.Changesets, err error) {
+	ctx, _, endObservation := s.operations.listChangesetsToRebase.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	q := changesetsToRebaseQuery(sqlf.Sprintf("TRUE"), sqlf.Sprintf("ORDER BY changesets.id ASC"))
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var c btypes.Changeset
+		if err := ScanChangeset(&c, sc); err != nil {
+			return err
+		}
+		cs = append(cs, &c)
+		return nil
+	})
+	return cs, err
+}
+
+// LockChangesetToRebase locks the changeset with the given ID for the rest of
+// the transaction, if it should still be rebased. The reconciler doesn't
+// dequeue a locked changeset, and the rebase is skipped if the reconciler
+// started processing the changeset since it was listed. ErrNoResults is
+// returned if the changeset is not to be rebased or is locked by another
+// transaction.
+func (s *Store) LockChangesetToRebase(ctx context.Context, id int64) (ch *btypes.Changeset, err error) {
+	ctx, _, endObservation := s.operations.lockChangesetToRebase.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("ID", int(id)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := changesetsToRebaseQuery(sqlf.Sprintf("changesets.id = %s", id), sqlf.Sprintf("FOR UPDATE OF changesets SKIP LOCKED"))
+
+	var c btypes.Changeset
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error { return ScanChangeset(&c, sc) })
+	if err != nil {
+		return nil, err
+	}
+
+	if c.ID == 0 {
+		return nil, ErrNoResults
+	}
+	return &c, nil
+}
+
+func changesetsToRebaseQuery(cond, suffix *sqlf.Query) *sqlf.Query {
+	return sqlf.Sprintf(
+		changesetsToRebaseQueryFmtstr,
+		sqlf.Join(ChangesetColumns, ", "),
+		btypes.ChangesetPublicationStatePublished,
+		pq.Array([]btypes.ChangesetExternalState{btypes.ChangesetExternalStateOpen, btypes.ChangesetExternalStateDraft}),
+		btypes.ReconcilerStateCompleted.ToDB(),
+		btypes.ChangesetSpecTypeBranch,
+		cond,
+		suffix,
+	)
+}
+
+var changesetsToRebaseQueryFmtstr = `
+SELECT %s FROM changesets
+JOIN batch_changes ON batch_changes.id = changesets.owned_by_batch_change_id
+JOIN changeset_specs ON changeset_specs.id = changesets.current_spec_id
+JOIN repo ON repo.id = changesets.repo_id
+WHERE
+	batch_changes.auto_rebase
+	AND batch_changes.closed_at IS NULL
+	AND changesets.publication_state = %s
+	AND changesets.external_state = ANY(%s)
+	AND changesets.reconciler_state = %s
+	AND changesets.detached_at IS NULL
+	AND changeset_specs.type = %s
+	AND repo.deleted_at IS NULL
+	AND NOT repo.archived
+	AND %s
+%s
+`
+
+// GetChangesetRebase returns the last rebase of the changeset with the given
+// ID. ErrNoResults is returned if the changeset has never been rebased.
+func (s *Store) GetChangesetRebase(ctx context.Context, changesetID int64) (r *btypes.ChangesetRebase, err error) {
+	ctx, _, endObservation := s.operations.getChangesetRebase.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("changesetID", int(changesetID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		getChangesetRebaseQueryFmtstr,
+		sqlf.Join(changesetRebaseColumns, ", "),
+		changesetID,
+	)
+
+	var c btypes.ChangesetRebase
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanChangesetRebase(&c, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if c.ChangesetID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &c, nil
+}
+
+var getChangesetRebaseQueryFmtstr = `
+SELECT %s FROM changeset_rebases
+WHERE changeset_id = %s
+`
+
+// UpsertChangesetRebase records the given rebase, replacing any previous
+// rebase of the same changeset.
+func (s *Store) UpsertChangesetRebase(ctx context.Context, r *btypes.ChangesetRebase) (err error) {
+	ctx, _, endObservation := s.operations.upsertChangesetRebase.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("changesetID", int(r.ChangesetID)),
+		attribute.Int("conflictingFiles", len(r.ConflictingFiles)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	r.UpdatedAt = s.now()
+	if r.CreatedAt.IsZero() {
+		r.CreatedAt = r.UpdatedAt
+	}
+
+	conflictingFiles := r.ConflictingFiles
+	if conflictingFiles == nil {
+		conflictingFiles = []string{}
+	}
+
+	q := sqlf.Sprintf(
+		upsertChangesetRebaseQueryFmtstr,
+		r.ChangesetID,
+		r.ChangesetSpecID,
+		r.BaseRev,
+		pq.Array(conflictingFiles),
+		r.CreatedAt,
+		r.UpdatedAt,
+		sqlf.Join(changesetRebaseColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanChangesetRebase(r, sc)
+	})
+}
+
+var upsertChangesetRebaseQueryFmtstr = `
+INSERT INTO changeset_rebases (changeset_id, changeset_spec_id, base_rev, conflicting_files, created_at, updated_at)
+VALUES (%s, %s, %s, %s, %s, %s)
+ON CONFLICT (changeset_id) DO UPDATE SET
+	changeset_spec_id = EXCLUDED.changeset_spec_id,
+	base_rev = EXCLUDED.base_rev,
+	conflicting_files = EXCLUDED.conflicting_files,
+	updated_at = EXCLUDED.updated_at
+RETURNING %s
+`
+
+var changesetRebaseColumns = []*sqlf.Query{
+	sqlf.Sprintf("changeset_rebases.changeset_id"),
+	sqlf.Sprintf("changeset_rebases.changeset_spec_id"),
+	sqlf.Sprintf("changeset_rebases.base_rev"),
+	sqlf.Sprintf("changeset_rebases.conflicting_files"),
+	sqlf.Sprintf("changeset_rebases.created_at"),
+	sqlf.Sprintf("changeset_rebases.updated_at"),
+}
+
+func scanChangesetRebase(r *btypes.ChangesetRebase, s dbutil.Scanner) error {
+	return s.Scan(
+		&r.ChangesetID,
+		&r.ChangesetSpecID,
+		&r.BaseRev,
+		pq.Array(&r.ConflictingFiles),
+		&r.CreatedAt,
+		&r.UpdatedAt,
+	)
+}
@@ -0,0 +1,112 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	bt "github.com/sourcegraph/sourcegraph/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+)
+
+func testStoreChangesetRebases(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	db := s.DatabaseDB()
+	user := bt.CreateTestUser(t, db, false)
+	repo, _ := bt.CreateTestRepo(t, ctx, db)
+
+	batchSpec := bt.CreateBatchSpec(t, ctx, s, "rebase", user.ID, 0)
+	batchChange := bt.CreateBatchChange(t, ctx, s, "rebase", user.ID, batchSpec.ID)
+	spec := bt.CreateChangesetSpec(t, ctx, s, bt.TestSpecOpts{
+		User:      user.ID,
+		Repo:      repo.ID,
+		BatchSpec: batchSpec.ID,
+		HeadRef:   "refs/heads/rebase",
+		Typ:       btypes.ChangesetSpecTypeBranch,
+	})
+	changeset := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+		Repo:               repo.ID,
+		BatchChanges:       []btypes.BatchChangeAssoc{{BatchChangeID: batchChange.ID}},
+		OwnedByBatchChange: batchChange.ID,
+		CurrentSpec:        spec.ID,
+		PublicationState:   btypes.ChangesetPublicationStatePublished,
+		ExternalState:      btypes.ChangesetExternalStateOpen,
+		ReconcilerState:    btypes.ReconcilerStateCompleted,
+	})
+
+	t.Run("ListChangesetsToRebase", func(t *testing.T) {
+		have, err := s.ListChangesetsToRebase(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 0 {
+			t.Fatalf("auto-rebase is disabled, but changesets were returned: %+v", have)
+		}
+
+		batchChange.AutoRebase = true
+		if err := s.UpdateBatchChangeAutoRebase(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err = s.ListChangesetsToRebase(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 1 || have[0].ID != changeset.ID {
+			t.Fatalf("wrong changesets returned: %+v", have)
+		}
+
+		// Applying a batch spec must not reset the setting.
+		if err := s.UpdateBatchChange(ctx, batchChange); err != nil {
+			t.Fatal(err)
+		}
+		reloaded, err := s.GetBatchChange(ctx, GetBatchChangeOpts{ID: batchChange.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reloaded.AutoRebase {
+			t.Fatal("auto-rebase was reset")
+		}
+	})
+
+	t.Run("GetChangesetRebase", func(t *testing.T) {
+		if _, err := s.GetChangesetRebase(ctx, changeset.ID); err != ErrNoResults {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrNoResults, err)
+		}
+
+		conflicted := &btypes.ChangesetRebase{
+			ChangesetID:      changeset.ID,
+			ChangesetSpecID:  spec.ID,
+			BaseRev:          "d34db33f",
+			ConflictingFiles: []string{"README.md", "main.go"},
+		}
+		if err := s.UpsertChangesetRebase(ctx, conflicted); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.GetChangesetRebase(ctx, changeset.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(conflicted, have); diff != "" {
+			t.Fatalf("invalid rebase returned (-want +have):\n%s", diff)
+		}
+
+		rebased := &btypes.ChangesetRebase{
+			ChangesetID:     changeset.ID,
+			ChangesetSpecID: spec.ID,
+			BaseRev:         "f00b4r",
+		}
+		if err := s.UpsertChangesetRebase(ctx, rebased); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err = s.GetChangesetRebase(ctx, changeset.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have.BaseRev != "f00b4r" || have.HasConflicts() {
+			t.Fatalf("rebase was not updated: %+v", have)
+		}
+	})
+}
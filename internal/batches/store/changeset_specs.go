@@ -45,6 +45,10 @@ var changesetSpecInsertColumns = []string{
 	"commit_author_name",
 	"commit_author_email",
 	"type",
+	"stack_parent_head_ref",
+	"stack_stage",
+	"request_reviews_from_owners",
+	"reverted_changeset_id",
 }
 
 // changesetSpecColumns are used by the changeset spec related Store methods to
@@ -72,6 +76,10 @@ var changesetSpecColumns = SQLColumns{
 	"changeset_specs.commit_author_name",
 	"changeset_specs.commit_author_email",
 	"changeset_specs.type",
+	"changeset_specs.stack_parent_head_ref",
+	"changeset_specs.stack_stage",
+	"changeset_specs.request_reviews_from_owners",
+	"changeset_specs.reverted_changeset_id",
 }
 
 var oneGigabyte = 1000000000
@@ -137,6 +145,10 @@ func (s *Store) CreateChangesetSpec(ctx context.Context, cs ...*btypes.Changeset
 				dbutil.NewNullString(c.CommitAuthorName),
 				dbutil.NewNullString(c.CommitAuthorEmail),
 				c.Type,
+				dbutil.NewNullString(c.StackParentHeadRef),
+				dbutil.NewNullInt32(c.StackStage),
+				c.RequestReviewsFromOwners,
+				dbutil.NullInt64Column(c.RevertedChangesetID),
 			); err != nil {
 				return err
 			}
@@ -563,6 +575,10 @@ func scanChangesetSpec(c *btypes.ChangesetSpec, s dbutil.Scanner) error {
 		&dbutil.NullString{S: &c.CommitAuthorName},
 		&dbutil.NullString{S: &c.CommitAuthorEmail},
 		&typ,
+		&dbutil.NullString{S: &c.StackParentHeadRef},
+		&dbutil.NullInt32{N: &c.StackStage},
+		&c.RequestReviewsFromOwners,
+		&dbutil.NullInt64{N: &c.RevertedChangesetID},
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset spec")
@@ -78,6 +78,8 @@ func testStoreChangesetSpecs(t *testing.T, ctx context.Context, s *Store, clock
 			c.CommitAuthorName = "name"
 			c.CommitAuthorEmail = "email"
 			c.Type = btypes.ChangesetSpecTypeBranch
+			c.RequestReviewsFromOwners = true
+			c.RevertedChangesetID = 4242
 		} else {
 			c.ExternalID = "123456"
 			c.Type = btypes.ChangesetSpecTypeExisting
@@ -14,8 +14,10 @@ import (
 
 	adobatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/azuredevops"
 	gerritbatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gerrit"
+	giteabatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/perforce"
 
 	"github.com/keegancsmith/sqlf"
@@ -34,6 +36,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -55,6 +58,8 @@ var changesetStringColumns = SQLColumns{
 	"external_state",
 	"external_review_state",
 	"external_check_state",
+	"external_check_runs",
+	"external_reviewer_decisions",
 	"commit_verification",
 	"diff_stat_added",
 	"diff_stat_deleted",
@@ -100,6 +105,8 @@ var ChangesetColumns = []*sqlf.Query{
 	sqlf.Sprintf("changesets.external_state"),
 	sqlf.Sprintf("changesets.external_review_state"),
 	sqlf.Sprintf("changesets.external_check_state"),
+	sqlf.Sprintf("changesets.external_check_runs"),
+	sqlf.Sprintf("changesets.external_reviewer_decisions"),
 	sqlf.Sprintf("changesets.commit_verification"),
 	sqlf.Sprintf("changesets.diff_stat_added"),
 	sqlf.Sprintf("changesets.diff_stat_deleted"),
@@ -144,6 +151,8 @@ var changesetInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("external_state"),
 	sqlf.Sprintf("external_review_state"),
 	sqlf.Sprintf("external_check_state"),
+	sqlf.Sprintf("external_check_runs"),
+	sqlf.Sprintf("external_reviewer_decisions"),
 	sqlf.Sprintf("commit_verification"),
 	sqlf.Sprintf("diff_stat_added"),
 	sqlf.Sprintf("diff_stat_deleted"),
@@ -182,6 +191,8 @@ var changesetCodeHostStateInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("external_state"),
 	sqlf.Sprintf("external_review_state"),
 	sqlf.Sprintf("external_check_state"),
+	sqlf.Sprintf("external_check_runs"),
+	sqlf.Sprintf("external_reviewer_decisions"),
 	sqlf.Sprintf("diff_stat_added"),
 	sqlf.Sprintf("diff_stat_deleted"),
 	sqlf.Sprintf("sync_state"),
@@ -210,6 +221,8 @@ var changesetInsertStringColumns = []string{
 	"external_state",
 	"external_review_state",
 	"external_check_state",
+	"external_check_runs",
+	"external_reviewer_decisions",
 	"commit_verification",
 	"diff_stat_added",
 	"diff_stat_deleted",
@@ -283,6 +296,16 @@ func (s *Store) CreateChangeset(ctx context.Context, cs ...*btypes.Changeset) (e
 				return err
 			}
 
+			checkRuns, err := jsonbArrayColumn(c.ExternalCheckRuns)
+			if err != nil {
+				return err
+			}
+
+			reviewerDecisions, err := jsonbArrayColumn(c.ExternalReviewerDecisions)
+			if err != nil {
+				return err
+			}
+
 			var cv json.RawMessage
 			// Don't bother to record the result of verification if it's not even verified.
 			if c.CommitVerification != nil && c.CommitVerification.Verified {
@@ -317,6 +340,8 @@ func (s *Store) CreateChangeset(ctx context.Context, cs ...*btypes.Changeset) (e
 				dbutil.NullStringColumn(string(c.ExternalState)),
 				dbutil.NullStringColumn(string(c.ExternalReviewState)),
 				dbutil.NullStringColumn(string(c.ExternalCheckState)),
+				checkRuns,
+				reviewerDecisions,
 				cv,
 				c.DiffStatAdded,
 				c.DiffStatDeleted,
@@ -378,12 +403,15 @@ DELETE FROM changesets WHERE id = %s
 // CountChangesetsOpts captures the query options needed for
 // counting changesets.
 type CountChangesetsOpts struct {
-	BatchChangeID        int64
-	OnlyArchived         bool
-	IncludeArchived      bool
-	ExternalStates       []btypes.ChangesetExternalState
-	ExternalReviewState  *btypes.ChangesetReviewState
-	ExternalCheckState   *btypes.ChangesetCheckState
+	BatchChangeID       int64
+	OnlyArchived        bool
+	IncludeArchived     bool
+	ExternalStates      []btypes.ChangesetExternalState
+	ExternalReviewState *btypes.ChangesetReviewState
+	ExternalCheckState  *btypes.ChangesetCheckState
+	// FailingCheck limits the results to changesets where the check with the
+	// given name failed.
+	FailingCheck         string
 	ReconcilerStates     []btypes.ReconcilerState
 	OwnedByBatchChangeID int64
 	PublicationState     *btypes.ChangesetPublicationState
@@ -441,6 +469,9 @@ func countChangesetsQuery(opts *CountChangesetsOpts, authzConds *sqlf.Query) *sq
 	if opts.ExternalCheckState != nil {
 		preds = append(preds, sqlf.Sprintf("changesets.external_check_state = %s", *opts.ExternalCheckState))
 	}
+	if opts.FailingCheck != "" {
+		preds = append(preds, failingCheckPredicate(opts.FailingCheck))
+	}
 	if len(opts.ReconcilerStates) != 0 {
 		// TODO: Would be nice if we could use this with pq.Array.
 		states := make([]*sqlf.Query, len(opts.ReconcilerStates))
@@ -487,13 +518,14 @@ func (s *Store) GetChangesetByID(ctx context.Context, id int64) (*btypes.Changes
 
 // GetChangesetOpts captures the query options needed for getting a Changeset
 type GetChangesetOpts struct {
-	ID                  int64
-	RepoID              api.RepoID
-	ExternalID          string
-	ExternalServiceType string
-	ExternalBranch      string
-	ReconcilerState     btypes.ReconcilerState
-	PublicationState    btypes.ChangesetPublicationState
+	ID                   int64
+	RepoID               api.RepoID
+	ExternalID           string
+	ExternalServiceType  string
+	ExternalBranch       string
+	ReconcilerState      btypes.ReconcilerState
+	PublicationState     btypes.ChangesetPublicationState
+	OwnedByBatchChangeID int64
 }
 
 // GetChangeset gets a changeset matching the given options.
@@ -551,6 +583,9 @@ func getChangesetQuery(opts *GetChangesetOpts) *sqlf.Query {
 	if opts.PublicationState != "" {
 		preds = append(preds, sqlf.Sprintf("changesets.publication_state = %s", opts.PublicationState))
 	}
+	if opts.OwnedByBatchChangeID != 0 {
+		preds = append(preds, sqlf.Sprintf("changesets.owned_by_batch_change_id = %s", opts.OwnedByBatchChangeID))
+	}
 
 	return sqlf.Sprintf(
 		getChangesetsQueryFmtstr,
@@ -637,17 +672,20 @@ func listChangesetSyncDataQuery(opts ListChangesetSyncDataOpts) *sqlf.Query {
 // in conjunction with at least one other option (most likely, BatchChangeID).
 type ListChangesetsOpts struct {
 	LimitOpts
-	Cursor               int64
-	BatchChangeID        int64
-	OnlyArchived         bool
-	IncludeArchived      bool
-	IDs                  []int64
-	States               []btypes.ChangesetState
-	PublicationState     *btypes.ChangesetPublicationState
-	ReconcilerStates     []btypes.ReconcilerState
-	ExternalStates       []btypes.ChangesetExternalState
-	ExternalReviewState  *btypes.ChangesetReviewState
-	ExternalCheckState   *btypes.ChangesetCheckState
+	Cursor              int64
+	BatchChangeID       int64
+	OnlyArchived        bool
+	IncludeArchived     bool
+	IDs                 []int64
+	States              []btypes.ChangesetState
+	PublicationState    *btypes.ChangesetPublicationState
+	ReconcilerStates    []btypes.ReconcilerState
+	ExternalStates      []btypes.ChangesetExternalState
+	ExternalReviewState *btypes.ChangesetReviewState
+	ExternalCheckState  *btypes.ChangesetCheckState
+	// FailingCheck limits the results to changesets where the check with the
+	// given name failed.
+	FailingCheck         string
 	OwnedByBatchChangeID int64
 	TextSearch           []search.TextSearchTerm
 	EnforceAuthz         bool
@@ -655,6 +693,16 @@ type ListChangesetsOpts struct {
 	BitbucketCloudCommit string
 }
 
+// failingCheckPredicate returns a predicate matching changesets with a failed
+// check run of the given name.
+func failingCheckPredicate(name string) *sqlf.Query {
+	return sqlf.Sprintf(
+		"changesets.external_check_runs @> jsonb_build_array(jsonb_build_object('name', %s::text, 'state', %s::text))",
+		name,
+		btypes.ChangesetCheckStateFailed,
+	)
+}
+
 // ListChangesets lists Changesets with the given filters.
 func (s *Store) ListChangesets(ctx context.Context, opts ListChangesetsOpts) (cs btypes.Changesets, next int64, err error) {
 	ctx, _, endObservation := s.operations.listChangesets.With(ctx, &err, observation.Args{})
@@ -735,6 +783,9 @@ func listChangesetsQuery(opts *ListChangesetsOpts, authzConds *sqlf.Query) *sqlf
 	if opts.ExternalCheckState != nil {
 		preds = append(preds, sqlf.Sprintf("changesets.external_check_state = %s", *opts.ExternalCheckState))
 	}
+	if opts.FailingCheck != "" {
+		preds = append(preds, failingCheckPredicate(opts.FailingCheck))
+	}
 	if opts.OwnedByBatchChangeID != 0 {
 		preds = append(preds, sqlf.Sprintf("changesets.owned_by_batch_change_id = %s", opts.OwnedByBatchChangeID))
 	}
@@ -876,6 +927,16 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *btypes.Changese
 		return nil, err
 	}
 
+	checkRuns, err := jsonbArrayColumn(c.ExternalCheckRuns)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewerDecisions, err := jsonbArrayColumn(c.ExternalReviewerDecisions)
+	if err != nil {
+		return nil, err
+	}
+
 	var cv json.RawMessage
 	// Don't bother to record the result of verification if it's not even verified.
 	if c.CommitVerification != nil && c.CommitVerification.Verified {
@@ -910,6 +971,8 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *btypes.Changese
 		dbutil.NullStringColumn(string(c.ExternalState)),
 		dbutil.NullStringColumn(string(c.ExternalReviewState)),
 		dbutil.NullStringColumn(string(c.ExternalCheckState)),
+		checkRuns,
+		reviewerDecisions,
 		cv,
 		c.DiffStatAdded,
 		c.DiffStatDeleted,
@@ -943,7 +1006,7 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *btypes.Changese
 
 var updateChangesetQueryFmtstr = `
 UPDATE changesets
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING
   %s
@@ -1166,6 +1229,16 @@ func updateChangesetCodeHostStateQuery(c *btypes.Changeset) (*sqlf.Query, error)
 		return nil, err
 	}
 
+	checkRuns, err := jsonbArrayColumn(c.ExternalCheckRuns)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewerDecisions, err := jsonbArrayColumn(c.ExternalReviewerDecisions)
+	if err != nil {
+		return nil, err
+	}
+
 	// Not being able to find a title is fine, we just have a NULL in the database then.
 	title, _ := c.Title()
 
@@ -1181,6 +1254,8 @@ func updateChangesetCodeHostStateQuery(c *btypes.Changeset) (*sqlf.Query, error)
 		dbutil.NullStringColumn(string(c.ExternalState)),
 		dbutil.NullStringColumn(string(c.ExternalReviewState)),
 		dbutil.NullStringColumn(string(c.ExternalCheckState)),
+		checkRuns,
+		reviewerDecisions,
 		c.DiffStatAdded,
 		c.DiffStatDeleted,
 		syncState,
@@ -1195,7 +1270,7 @@ func updateChangesetCodeHostStateQuery(c *btypes.Changeset) (*sqlf.Query, error)
 
 var updateChangesetCodeHostStateQueryFmtstr = `
 UPDATE changesets
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING
   %s
@@ -1388,6 +1463,49 @@ updated_records AS (
 SELECT COUNT(id) FROM all_matching WHERE all_matching.reconciler_state = %s
 `
 
+// EnqueueStackedChangesets enqueues the unpublished changesets that are stacked
+// on top of the given changeset, so that the reconciler can push them on top
+// of its latest changes, or publish them once it has been merged.
+func (s *Store) EnqueueStackedChangesets(ctx context.Context, parent *btypes.Changeset) (err error) {
+	ctx, _, endObservation := s.operations.enqueueStackedChangesets.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("parentID", int(parent.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if parent.OwnedByBatchChangeID == 0 || parent.ExternalBranch == "" {
+		return nil
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(
+		enqueueStackedChangesetsFmtstr,
+		btypes.ReconcilerStateQueued.ToDB(),
+		s.now(),
+		parent.OwnedByBatchChangeID,
+		parent.RepoID,
+		btypes.ChangesetPublicationStateUnpublished,
+		btypes.ReconcilerStateCompleted.ToDB(),
+		gitdomain.EnsureRefPrefix(parent.ExternalBranch),
+	))
+}
+
+const enqueueStackedChangesetsFmtstr = `
+UPDATE changesets
+SET
+	reconciler_state = %s,
+	num_resets = 0,
+	num_failures = 0,
+	failure_message = NULL,
+	updated_at = %s
+FROM changeset_specs
+WHERE
+	changeset_specs.id = changesets.current_spec_id
+	AND changesets.owned_by_batch_change_id = %s
+	AND changesets.repo_id = %s
+	AND changesets.publication_state = %s
+	AND changesets.reconciler_state = %s
+	AND changeset_specs.stack_parent_head_ref = %s
+`
+
 // jsonBatchChangeChangesetSet represents a "join table" set as a JSONB object
 // where the keys are the ids and the values are json objects holding the properties.
 // It implements the sql.Scanner interface so it can be used as a scan destination,
@@ -1437,7 +1555,7 @@ func (n jsonBatchChangeChangesetSet) Value() (driver.Value, error) {
 }
 
 func ScanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
-	var metadata, syncState, commitVerification json.RawMessage
+	var metadata, syncState, checkRuns, reviewerDecisions, commitVerification json.RawMessage
 
 	var (
 		externalState          string
@@ -1465,6 +1583,8 @@ func ScanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		&dbutil.NullString{S: &externalState},
 		&dbutil.NullString{S: &externalReviewState},
 		&dbutil.NullString{S: &externalCheckState},
+		&checkRuns,
+		&reviewerDecisions,
 		&commitVerification,
 		&t.DiffStatAdded,
 		&t.DiffStatDeleted,
@@ -1530,6 +1650,11 @@ func ScanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		t.Metadata = new(perforce.Changelist)
 	case extsvc.TypeGerrit:
 		t.Metadata = new(gerrit.Change)
+	case extsvc.TypeGitea:
+		m := new(giteabatches.AnnotatedPullRequest)
+		// Ensure the inner PR is initialized, it should never be nil.
+		m.PullRequest = &gitea.PullRequest{}
+		t.Metadata = m
 	default:
 		return errors.New("unknown external service type")
 	}
@@ -1540,6 +1665,22 @@ func ScanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 	if err = json.Unmarshal(syncState, &t.SyncState); err != nil {
 		return errors.Wrapf(err, "scanChangeset: failed to unmarshal sync state: %s", syncState)
 	}
+	// Leave the slices nil rather than empty when there are no check runs or
+	// reviews, so that changesets round-trip through the database unchanged.
+	t.ExternalCheckRuns = nil
+	if err = json.Unmarshal(checkRuns, &t.ExternalCheckRuns); err != nil {
+		return errors.Wrapf(err, "scanChangeset: failed to unmarshal check runs: %s", checkRuns)
+	}
+	if len(t.ExternalCheckRuns) == 0 {
+		t.ExternalCheckRuns = nil
+	}
+	t.ExternalReviewerDecisions = nil
+	if err = json.Unmarshal(reviewerDecisions, &t.ExternalReviewerDecisions); err != nil {
+		return errors.Wrapf(err, "scanChangeset: failed to unmarshal reviewer decisions: %s", reviewerDecisions)
+	}
+	if len(t.ExternalReviewerDecisions) == 0 {
+		t.ExternalReviewerDecisions = nil
+	}
 	var cv *github.Verification
 	if err = json.Unmarshal(commitVerification, &cv); err != nil {
 		return errors.Wrapf(err, "scanChangesetSpecs: failed to unmarshal commitVerification: %s", commitVerification)
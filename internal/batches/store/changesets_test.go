@@ -141,6 +141,14 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, clock bt.C
 				th.StartedAt = clock.Now()
 				th.FinishedAt = clock.Now()
 				th.ProcessAfter = clock.Now()
+
+				th.ExternalCheckRuns = []btypes.ChangesetCheckRun{
+					{Name: "build", State: btypes.ChangesetCheckStatePassed, URL: "https://ci.example.com/build"},
+					{Name: "lint", State: btypes.ChangesetCheckStateFailed},
+				}
+				th.ExternalReviewerDecisions = []btypes.ChangesetReviewerDecision{
+					{Reviewer: "alice", State: btypes.ChangesetReviewStateApproved},
+				}
 			}
 
 			if err := s.CreateChangeset(ctx, th); err != nil {
@@ -879,6 +887,18 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, clock bt.C
 				},
 				wantCount: 1,
 			},
+			{
+				opts: ListChangesetsOpts{
+					FailingCheck: "lint",
+				},
+				wantCount: 2,
+			},
+			{
+				opts: ListChangesetsOpts{
+					FailingCheck: "build",
+				},
+				wantCount: 0,
+			},
 		}
 
 		for i, tc := range filterCases {
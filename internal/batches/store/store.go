@@ -177,21 +177,27 @@ func (s *Store) queryCount(ctx context.Context, q *sqlf.Query) (int, error) {
 }
 
 type operations struct {
-	createBatchChange      *observation.Operation
-	upsertBatchChange      *observation.Operation
-	updateBatchChange      *observation.Operation
-	deleteBatchChange      *observation.Operation
-	countBatchChanges      *observation.Operation
-	getBatchChange         *observation.Operation
-	getBatchChangeDiffStat *observation.Operation
-	getRepoDiffStat        *observation.Operation
-	listBatchChanges       *observation.Operation
+	createBatchChange           *observation.Operation
+	upsertBatchChange           *observation.Operation
+	updateBatchChange           *observation.Operation
+	updateBatchChangeAutoRebase *observation.Operation
+	updateBatchChangeAutoMerge  *observation.Operation
+	deleteBatchChange           *observation.Operation
+	countBatchChanges           *observation.Operation
+	getBatchChange              *observation.Operation
+	getBatchChangeDiffStat      *observation.Operation
+	getRepoDiffStat             *observation.Operation
+	listBatchChanges            *observation.Operation
 
 	createBatchSpecExecution *observation.Operation
 	getBatchSpecExecution    *observation.Operation
 	cancelBatchSpecExecution *observation.Operation
 	listBatchSpecExecutions  *observation.Operation
 
+	createBatchSpecExecutionPreview *observation.Operation
+	getBatchSpecExecutionPreview    *observation.Operation
+	deleteBatchSpecExecutionPreview *observation.Operation
+
 	createBatchSpec         *observation.Operation
 	updateBatchSpec         *observation.Operation
 	deleteBatchSpec         *observation.Operation
@@ -249,6 +255,7 @@ type operations struct {
 	getChangesetExternalIDs           *observation.Operation
 	cancelQueuedBatchChangeChangesets *observation.Operation
 	enqueueChangesetsToClose          *observation.Operation
+	enqueueStackedChangesets          *observation.Operation
 	getChangesetsStats                *observation.Operation
 	getRepoChangesetsStats            *observation.Operation
 	getGlobalChangesetsStats          *observation.Operation
@@ -256,6 +263,24 @@ type operations struct {
 	getChangesetPlaceInSchedulerQueue *observation.Operation
 	cleanDetachedChangesets           *observation.Operation
 
+	listChangesetsToRebase *observation.Operation
+	lockChangesetToRebase  *observation.Operation
+	getChangesetRebase     *observation.Operation
+	upsertChangesetRebase  *observation.Operation
+
+	listChangesetsToAutoMerge *observation.Operation
+	lockChangesetToAutoMerge  *observation.Operation
+	getChangesetAutoMerge     *observation.Operation
+	upsertChangesetAutoMerge  *observation.Operation
+	countChangesetAutoMerges  *observation.Operation
+
+	createBatchSpecTemplate *observation.Operation
+	updateBatchSpecTemplate *observation.Operation
+	deleteBatchSpecTemplate *observation.Operation
+	getBatchSpecTemplate    *observation.Operation
+	listBatchSpecTemplates  *observation.Operation
+	countBatchSpecTemplates *observation.Operation
+
 	listCodeHosts         *observation.Operation
 	getExternalServiceIDs *observation.Operation
 
@@ -322,21 +347,27 @@ func newOperations(observationCtx *observation.Context) *operations {
 		}
 
 		singletonOperations = &operations{
-			createBatchChange:      op("CreateBatchChange"),
-			upsertBatchChange:      op("UpsertBatchChange"),
-			updateBatchChange:      op("UpdateBatchChange"),
-			deleteBatchChange:      op("DeleteBatchChange"),
-			countBatchChanges:      op("CountBatchChanges"),
-			listBatchChanges:       op("ListBatchChanges"),
-			getBatchChange:         op("GetBatchChange"),
-			getBatchChangeDiffStat: op("GetBatchChangeDiffStat"),
-			getRepoDiffStat:        op("GetRepoDiffStat"),
+			createBatchChange:           op("CreateBatchChange"),
+			upsertBatchChange:           op("UpsertBatchChange"),
+			updateBatchChange:           op("UpdateBatchChange"),
+			updateBatchChangeAutoRebase: op("UpdateBatchChangeAutoRebase"),
+			updateBatchChangeAutoMerge:  op("UpdateBatchChangeAutoMerge"),
+			deleteBatchChange:           op("DeleteBatchChange"),
+			countBatchChanges:           op("CountBatchChanges"),
+			listBatchChanges:            op("ListBatchChanges"),
+			getBatchChange:              op("GetBatchChange"),
+			getBatchChangeDiffStat:      op("GetBatchChangeDiffStat"),
+			getRepoDiffStat:             op("GetRepoDiffStat"),
 
 			createBatchSpecExecution: op("CreateBatchSpecExecution"),
 			getBatchSpecExecution:    op("GetBatchSpecExecution"),
 			cancelBatchSpecExecution: op("CancelBatchSpecExecution"),
 			listBatchSpecExecutions:  op("ListBatchSpecExecutions"),
 
+			createBatchSpecExecutionPreview: op("CreateBatchSpecExecutionPreview"),
+			getBatchSpecExecutionPreview:    op("GetBatchSpecExecutionPreview"),
+			deleteBatchSpecExecutionPreview: op("DeleteBatchSpecExecutionPreview"),
+
 			createBatchSpec:         op("CreateBatchSpec"),
 			updateBatchSpec:         op("UpdateBatchSpec"),
 			deleteBatchSpec:         op("DeleteBatchSpec"),
@@ -394,6 +425,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 			getChangesetExternalIDs:           op("GetChangesetExternalIDs"),
 			cancelQueuedBatchChangeChangesets: op("CancelQueuedBatchChangeChangesets"),
 			enqueueChangesetsToClose:          op("EnqueueChangesetsToClose"),
+			enqueueStackedChangesets:          op("EnqueueStackedChangesets"),
 			getChangesetsStats:                op("GetChangesetsStats"),
 			getRepoChangesetsStats:            op("GetRepoChangesetsStats"),
 			getGlobalChangesetsStats:          op("GetGlobalChangesetsStats"),
@@ -401,6 +433,24 @@ func newOperations(observationCtx *observation.Context) *operations {
 			getChangesetPlaceInSchedulerQueue: op("GetChangesetPlaceInSchedulerQueue"),
 			cleanDetachedChangesets:           op("CleanDetachedChangesets"),
 
+			listChangesetsToRebase: op("ListChangesetsToRebase"),
+			lockChangesetToRebase:  op("LockChangesetToRebase"),
+			getChangesetRebase:     op("GetChangesetRebase"),
+			upsertChangesetRebase:  op("UpsertChangesetRebase"),
+
+			listChangesetsToAutoMerge: op("ListChangesetsToAutoMerge"),
+			lockChangesetToAutoMerge:  op("LockChangesetToAutoMerge"),
+			getChangesetAutoMerge:     op("GetChangesetAutoMerge"),
+			upsertChangesetAutoMerge:  op("UpsertChangesetAutoMerge"),
+			countChangesetAutoMerges:  op("CountChangesetAutoMerges"),
+
+			createBatchSpecTemplate: op("CreateBatchSpecTemplate"),
+			updateBatchSpecTemplate: op("UpdateBatchSpecTemplate"),
+			deleteBatchSpecTemplate: op("DeleteBatchSpecTemplate"),
+			getBatchSpecTemplate:    op("GetBatchSpecTemplate"),
+			listBatchSpecTemplates:  op("ListBatchSpecTemplates"),
+			countBatchSpecTemplates: op("CountBatchSpecTemplates"),
+
 			listCodeHosts:         op("ListCodeHosts"),
 			getExternalServiceIDs: op("GetExternalServiceIDs"),
 
@@ -468,6 +518,15 @@ func buildRecordScanner[T any](scan func(*T, dbutil.Scanner) error) func(dbutil.
 	}
 }
 
+// jsonbArrayColumn marshals the given items for a NOT NULL jsonb array column,
+// which must not be set to null when there are no items.
+func jsonbArrayColumn[T any](items []T) (json.RawMessage, error) {
+	if len(items) == 0 {
+		return json.RawMessage("[]"), nil
+	}
+	return json.Marshal(items)
+}
+
 func jsonbColumn(metadata any) (msg json.RawMessage, err error) {
 	switch m := metadata.(type) {
 	case nil:
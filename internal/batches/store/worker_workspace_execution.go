@@ -20,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
 	"github.com/sourcegraph/sourcegraph/lib/batches/execution/cache"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -65,6 +66,31 @@ func NewBatchSpecWorkspaceExecutionWorkerStore(observationCtx *observation.Conte
 
 var _ dbworkerstore.Store[*btypes.BatchSpecWorkspaceExecutionJob] = &batchSpecWorkspaceExecutionWorkerStore{}
 
+// ContainerlessBatchSpecWorkspaceExecutionJobsCondition returns a condition
+// that can be passed to Dequeue to match the jobs of batch specs whose steps
+// don't run in a container. Those jobs are processed by the rewrite worker
+// instead of an executor.
+func ContainerlessBatchSpecWorkspaceExecutionJobsCondition() *sqlf.Query {
+	return sqlf.Sprintf(containerlessBatchSpecWorkspaceExecutionJobsConditionFmtstr)
+}
+
+const containerlessBatchSpecWorkspaceExecutionJobsConditionFmtstr = `
+EXISTS (
+	SELECT 1
+	FROM batch_spec_workspaces
+	JOIN batch_specs ON batch_specs.id = batch_spec_workspaces.batch_spec_id
+	WHERE
+		batch_spec_workspaces.id = batch_spec_workspace_execution_jobs.batch_spec_workspace_id
+		AND jsonb_typeof(batch_specs.spec->'steps') = 'array'
+		AND jsonb_array_length(batch_specs.spec->'steps') > 0
+		AND NOT EXISTS (
+			SELECT 1
+			FROM jsonb_array_elements(batch_specs.spec->'steps') AS step
+			WHERE NOT step ? 'rewrite'
+		)
+)
+`
+
 // batchSpecWorkspaceExecutionWorkerStore is a thin wrapper around
 // dbworkerstore.Store that allows us to extract information out of the
 // ExecutionLogEntry field and persisting it to separate columns when marking a
@@ -203,21 +229,31 @@ func (s *batchSpecWorkspaceExecutionWorkerStore) MarkComplete(ctx context.Contex
 		return false, err
 	}
 
-	// Find the result for the last step. This is the one we'll be building the execution
-	// result from.
-	latestStepResult := stepResults[0]
-	for _, r := range stepResults {
-		if r.Value.StepIndex > latestStepResult.Value.StepIndex {
-			latestStepResult = r
+	// Collect the results of all steps. The result of the last step is the one
+	// we'll be building the execution result from, while the results of earlier
+	// steps are needed to split the changes into stacked changesets. Steps that
+	// weren't executed because their result was cached are not part of the
+	// logs, so we fall back to the cache results of the workspace.
+	results := make(map[int]execution.AfterStepResult, len(stepResults))
+	for _, c := range workspace.StepCacheResults {
+		if c.Value != nil {
+			results[c.Value.StepIndex] = *c.Value
 		}
 	}
+	for _, r := range stepResults {
+		results[r.Value.StepIndex] = r.Value
+	}
+	allStepResults := make([]execution.AfterStepResult, 0, len(results))
+	for _, r := range results {
+		allStepResults = append(allStepResults, r)
+	}
 
 	changesetAuthor, err := author.GetChangesetAuthorForUser(ctx, database.UsersWith(s.logger, s), batchSpec.UserID)
 	if err != nil {
 		return false, errors.Wrap(err, "creating changeset author")
 	}
 
-	rawSpecs, err := cache.ChangesetSpecsFromCache(
+	rawSpecs, err := cache.ChangesetSpecsFromStepResults(
 		batchSpec.Spec,
 		batcheslib.Repository{
 			ID:          string(relay.MarshalID("Repository", repo.ID)),
@@ -226,7 +262,7 @@ func (s *batchSpecWorkspaceExecutionWorkerStore) MarkComplete(ctx context.Contex
 			BaseRev:     workspace.Commit,
 			FileMatches: workspace.FileMatches,
 		},
-		latestStepResult.Value,
+		allStepResults,
 		workspace.Path,
 		true,
 		changesetAuthor,
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
 
 	"github.com/sourcegraph/log/logtest"
 
@@ -588,6 +589,57 @@ func TestBatchSpecWorkspaceExecutionWorkerStore_Dequeue_RoundRobin_NoDoubleDeque
 	}
 }
 
+func TestBatchSpecWorkspaceExecutionWorkerStore_Dequeue_Containerless(t *testing.T) {
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(t))
+
+	repo, _ := bt.CreateTestRepo(t, ctx, db)
+	user := bt.CreateTestUser(t, db, true)
+
+	s := New(db, &observation.TestContext, nil)
+	workerStore := dbworkerstore.New(&observation.TestContext, s.Handle(), batchSpecWorkspaceExecutionWorkerStoreOptions)
+
+	containerSpec := &btypes.BatchSpec{UserID: user.ID, NamespaceUserID: user.ID, RawSpec: "horse", Spec: &batcheslib.BatchSpec{
+		Steps:             []batcheslib.Step{{Run: "echo hello", Container: "alpine:3"}},
+		ChangesetTemplate: &batcheslib.ChangesetTemplate{},
+	}}
+	rewriteSpec := &btypes.BatchSpec{UserID: user.ID, NamespaceUserID: user.ID, RawSpec: "horse", Spec: &batcheslib.BatchSpec{
+		Steps:             []batcheslib.Step{{Rewrite: &batcheslib.RewriteStep{Pattern: "foo", Rewrite: "bar"}}},
+		ChangesetTemplate: &batcheslib.ChangesetTemplate{},
+	}}
+	for _, bs := range []*btypes.BatchSpec{containerSpec, rewriteSpec} {
+		if err := s.CreateBatchSpec(ctx, bs); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	containerJob := setupBatchSpecAssociation(ctx, s, t, containerSpec, repo)
+	rewriteJob := setupBatchSpecAssociation(ctx, s, t, rewriteSpec, repo)
+
+	dequeueAll := func(conditions []*sqlf.Query) []int64 {
+		have := []int64{}
+		for {
+			r, found, err := workerStore.Dequeue(ctx, "test-worker", conditions)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !found {
+				return have
+			}
+			have = append(have, int64(r.RecordID()))
+		}
+	}
+
+	cond := ContainerlessBatchSpecWorkspaceExecutionJobsCondition()
+	if diff := cmp.Diff([]int64{containerJob}, dequeueAll([]*sqlf.Query{sqlf.Sprintf("NOT (%s)", cond)})); diff != "" {
+		t.Fatalf("invalid jobs dequeued for executors (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]int64{rewriteJob}, dequeueAll([]*sqlf.Query{cond})); diff != "" {
+		t.Fatalf("invalid containerless jobs dequeued (-want +got):\n%s", diff)
+	}
+}
+
 func setupUserBatchSpec(t *testing.T, ctx context.Context, s *Store, user *types.User) *btypes.BatchSpec {
 	t.Helper()
 	bs := &btypes.BatchSpec{UserID: user.ID, NamespaceUserID: user.ID, RawSpec: "horse", Spec: &batcheslib.BatchSpec{
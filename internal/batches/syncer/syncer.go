@@ -525,6 +525,7 @@ func SyncChangeset(ctx context.Context, syncStore SyncStore, client gitserver.Cl
 	if err != nil {
 		return err
 	}
+	wasMerged := c.ExternalState == btypes.ChangesetExternalStateMerged
 	state.SetDerivedState(ctx, syncStore.Repos(), client, c, events)
 
 	tx, err := syncStore.Transact(ctx)
@@ -541,5 +542,12 @@ func SyncChangeset(ctx context.Context, syncStore SyncStore, client gitserver.Cl
 		return err
 	}
 
+	// Changesets stacked on top of this one are waiting for it to be merged.
+	if !wasMerged && c.ExternalState == btypes.ChangesetExternalStateMerged {
+		if err := tx.EnqueueStackedChangesets(ctx, c); err != nil {
+			return err
+		}
+	}
+
 	return tx.UpsertChangesetEvents(ctx, events...)
 }
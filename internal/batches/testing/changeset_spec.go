@@ -40,6 +40,11 @@ type TestSpecOpts struct {
 	BaseRef string
 
 	Typ btypes.ChangesetSpecType
+
+	StackStage         int32
+	StackParentHeadRef string
+
+	RequestReviewsFromOwners bool
 }
 
 var TestChangsetSpecDiffStat = &diff.Stat{Added: 15, Deleted: 7}
@@ -75,6 +80,11 @@ func BuildChangesetSpec(t *testing.T, opts TestSpecOpts) *btypes.ChangesetSpec {
 		DiffStatAdded:     TestChangsetSpecDiffStat.Added,
 		DiffStatDeleted:   TestChangsetSpecDiffStat.Deleted,
 		Type:              opts.Typ,
+
+		StackStage:         opts.StackStage,
+		StackParentHeadRef: opts.StackParentHeadRef,
+
+		RequestReviewsFromOwners: opts.RequestReviewsFromOwners,
 	}
 
 	return spec
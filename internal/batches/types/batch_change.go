@@ -36,6 +36,20 @@ type BatchChange struct {
 
 	ClosedAt time.Time
 
+	// AutoRebase is set when the diffs of the changesets of this batch change
+	// should be re-applied onto their base branch once it moves on in a way
+	// that may conflict with them, or once it is more than
+	// AutoRebaseMaxBehind commits ahead of them (if non-zero).
+	AutoRebase          bool
+	AutoRebaseMaxBehind int32
+
+	// AutoMerge is set when the changesets of this batch change should be
+	// merged once their checks pass and they have been approved. At most
+	// AutoMergeMaxPerHour changesets are merged per hour on each code host.
+	AutoMerge           bool
+	AutoMergeMaxPerHour int32
+	AutoMergeSquash     bool
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -0,0 +1,18 @@
+package types
+
+import "time"
+
+// BatchSpecExecutionPreview records that a batch spec has only been executed
+// on a random sample of its workspaces, so that the resulting changes can be
+// previewed before all workspaces are executed.
+type BatchSpecExecutionPreview struct {
+	BatchSpecID int64
+
+	// SampleSize is the number of workspaces that were executed.
+	SampleSize int32
+	// Workspaces is the number of workspaces that a full execution of the
+	// batch spec would execute, including the sampled ones.
+	Workspaces int32
+
+	CreatedAt time.Time
+}
@@ -0,0 +1,33 @@
+package types
+
+import (
+	"time"
+
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+// NewBatchSpecTemplateFromRaw parses and validates the given rawTemplate, and
+// returns a BatchSpecTemplate containing the result.
+func NewBatchSpecTemplateFromRaw(rawTemplate string) (_ *BatchSpecTemplate, err error) {
+	t := &BatchSpecTemplate{RawTemplate: rawTemplate}
+
+	t.Template, err = batcheslib.ParseBatchSpecTemplate([]byte(rawTemplate))
+
+	return t, err
+}
+
+// BatchSpecTemplate is a reusable batch spec with typed input parameters that
+// has been published to the templates library by a site admin.
+type BatchSpecTemplate struct {
+	ID int64
+
+	RawTemplate string
+	Template    *batcheslib.BatchSpecTemplate
+
+	// CreatorID is the user that published the template. It is 0 if the user
+	// has been deleted since.
+	CreatorID int32
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -15,6 +15,7 @@ import (
 	adobatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/azuredevops"
 	bbcs "github.com/sourcegraph/sourcegraph/internal/batches/sources/bitbucketcloud"
 	gerritbatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gerrit"
+	giteabatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
@@ -243,6 +244,23 @@ func (s ChangesetCheckState) Valid() bool {
 	}
 }
 
+// ChangesetCheckRun is a single check, such as a CI job, that ran against the
+// latest commit of a changeset on the code host.
+type ChangesetCheckRun struct {
+	Name  string              `json:"name"`
+	State ChangesetCheckState `json:"state"`
+	// URL links to the details of the check on the code host or CI system, if
+	// the code host provides one.
+	URL string `json:"url,omitempty"`
+}
+
+// ChangesetReviewerDecision is the latest decision of a single reviewer of a
+// changeset. Reviewers that only commented are not recorded.
+type ChangesetReviewerDecision struct {
+	Reviewer string               `json:"reviewer"`
+	State    ChangesetReviewState `json:"state"`
+}
+
 // BatchChangeAssoc stores the details of a association to a BatchChange.
 type BatchChangeAssoc struct {
 	BatchChangeID int64 `json:"-"`
@@ -272,6 +290,11 @@ type Changeset struct {
 	ExternalState         ChangesetExternalState
 	ExternalReviewState   ChangesetReviewState
 	ExternalCheckState    ChangesetCheckState
+	// ExternalCheckRuns and ExternalReviewerDecisions are the individual
+	// checks and reviews that ExternalCheckState and ExternalReviewState are
+	// derived from.
+	ExternalCheckRuns         []ChangesetCheckRun
+	ExternalReviewerDecisions []ChangesetReviewerDecision
 
 	// If the commit created for a changeset is signed, commit verification is the
 	// signature verification result from the code host.
@@ -468,6 +491,20 @@ func (c *Changeset) SetMetadata(meta any) error {
 		c.ExternalServiceType = extsvc.TypeGerrit
 		c.ExternalBranch = gitdomain.EnsureRefPrefix(pr.Change.Branch)
 		c.ExternalUpdatedAt = pr.Change.Updated
+	case *giteabatches.AnnotatedPullRequest:
+		c.Metadata = pr
+		c.ExternalID = strconv.FormatInt(pr.Number, 10)
+		c.ExternalServiceType = extsvc.TypeGitea
+		c.ExternalBranch = gitdomain.EnsureRefPrefix(pr.Head.Ref)
+		c.ExternalUpdatedAt = pr.UpdatedAt
+
+		if pr.Head.RepoID != pr.Base.RepoID && pr.Head.Repository != nil {
+			c.ExternalForkNamespace = pr.Head.Repository.Namespace()
+			c.ExternalForkName = pr.Head.Repository.Name
+		} else {
+			c.ExternalForkNamespace = ""
+			c.ExternalForkName = ""
+		}
 	case *perforce.Changelist:
 		c.Metadata = pr
 		c.ExternalID = pr.ID
@@ -508,6 +545,8 @@ func (c *Changeset) Title() (string, error) {
 		// Remove extra quotes added by the commit message
 		title = strings.TrimPrefix(strings.TrimSuffix(title, "\""), "\"")
 		return title, nil
+	case *giteabatches.AnnotatedPullRequest:
+		return m.Title, nil
 	case *perforce.Changelist:
 		return m.Title, nil
 	default:
@@ -535,6 +574,11 @@ func (c *Changeset) AuthorName() (string, error) {
 		return m.CreatedBy.UniqueName, nil
 	case *gerritbatches.AnnotatedChange:
 		return m.Change.Owner.Name, nil
+	case *giteabatches.AnnotatedPullRequest:
+		if m.User == nil {
+			return "", nil
+		}
+		return m.User.Login, nil
 	case *perforce.Changelist:
 		return m.Author, nil
 	default:
@@ -570,6 +614,11 @@ func (c *Changeset) AuthorEmail() (string, error) {
 		return m.CreatedBy.UniqueName, nil
 	case *gerritbatches.AnnotatedChange:
 		return m.Change.Owner.Email, nil
+	case *giteabatches.AnnotatedPullRequest:
+		if m.User == nil {
+			return "", nil
+		}
+		return m.User.Email, nil
 	case *perforce.Changelist:
 		return "", nil
 	default:
@@ -594,6 +643,8 @@ func (c *Changeset) ExternalCreatedAt() time.Time {
 		return m.CreationDate
 	case *gerritbatches.AnnotatedChange:
 		return m.Change.Created
+	case *giteabatches.AnnotatedPullRequest:
+		return m.CreatedAt
 	case *perforce.Changelist:
 		return m.CreationDate
 	default:
@@ -617,6 +668,8 @@ func (c *Changeset) Body() (string, error) {
 	case *gerritbatches.AnnotatedChange:
 		// Gerrit doesn't really differentiate between title/description.
 		return m.Change.Subject, nil
+	case *giteabatches.AnnotatedPullRequest:
+		return m.Body, nil
 	case *perforce.Changelist:
 		return "", nil
 	default:
@@ -685,6 +738,8 @@ func (c *Changeset) URL() (s string, err error) {
 		return returnURL.String(), nil
 	case *gerritbatches.AnnotatedChange:
 		return m.CodeHostURL.JoinPath("c", url.PathEscape(m.Change.Project), "+", url.PathEscape(strconv.Itoa(m.Change.ChangeNumber))).String(), nil
+	case *giteabatches.AnnotatedPullRequest:
+		return m.HTMLURL, nil
 	case *perforce.Changelist:
 		return "", nil
 	default:
@@ -905,6 +960,10 @@ func (c *Changeset) Events() (events []*ChangesetEvent, err error) {
 				Metadata:    reviewer,
 			})
 		}
+	case *giteabatches.AnnotatedPullRequest:
+		// Reviews and commit statuses are stored on the annotated pull
+		// request itself, so there are no events to create.
+		break
 	case *perforce.Changelist:
 		// We don't have any events we care about right now
 		break
@@ -930,6 +989,8 @@ func (c *Changeset) HeadRefOid() (string, error) {
 		return "", nil
 	case *gerritbatches.AnnotatedChange:
 		return "", nil
+	case *giteabatches.AnnotatedPullRequest:
+		return m.Head.SHA, nil
 	case *perforce.Changelist:
 		return "", nil
 	default:
@@ -953,6 +1014,8 @@ func (c *Changeset) HeadRef() (string, error) {
 		return m.SourceRefName, nil
 	case *gerritbatches.AnnotatedChange:
 		return "", nil
+	case *giteabatches.AnnotatedPullRequest:
+		return "refs/heads/" + m.Head.Ref, nil
 	case *perforce.Changelist:
 		return "", nil
 	default:
@@ -977,6 +1040,8 @@ func (c *Changeset) BaseRefOid() (string, error) {
 		return "", nil
 	case *gerritbatches.AnnotatedChange:
 		return "", nil
+	case *giteabatches.AnnotatedPullRequest:
+		return m.Base.SHA, nil
 	case *perforce.Changelist:
 		return "", nil
 	default:
@@ -1000,6 +1065,8 @@ func (c *Changeset) BaseRef() (string, error) {
 		return m.TargetRefName, nil
 	case *gerritbatches.AnnotatedChange:
 		return "refs/heads/" + m.Change.Branch, nil
+	case *giteabatches.AnnotatedPullRequest:
+		return "refs/heads/" + m.Base.Ref, nil
 	case *perforce.Changelist:
 		// TODO: @peterguy we may need to change this to something.
 		return "", nil
@@ -1008,6 +1075,51 @@ func (c *Changeset) BaseRef() (string, error) {
 	}
 }
 
+// MergeCommitOid returns the git ObjectID of the commit that merged the
+// Changeset into its base branch. If the codehost doesn't include the ObjectID
+// or the changeset hasn't been merged, an empty string is returned.
+func (c *Changeset) MergeCommitOid() (string, error) {
+	switch m := c.Metadata.(type) {
+	case *github.PullRequest:
+		for _, ti := range m.TimelineItems {
+			if e, ok := ti.Item.(*github.MergedEvent); ok {
+				return e.Commit.OID, nil
+			}
+		}
+		return "", nil
+	case *bitbucketserver.PullRequest:
+		for _, a := range m.Activities {
+			if a.Action == bitbucketserver.MergedActivityAction && a.Commit != nil {
+				return a.Commit.ID, nil
+			}
+		}
+		return "", nil
+	case *gitlab.MergeRequest:
+		if m.MergeCommitSHA != "" {
+			return m.MergeCommitSHA, nil
+		}
+		return m.SquashCommitSHA, nil
+	case *bbcs.AnnotatedPullRequest:
+		if m.MergeCommit != nil {
+			return m.MergeCommit.Hash, nil
+		}
+		return "", nil
+	case *adobatches.AnnotatedPullRequest:
+		return "", nil
+	case *gerritbatches.AnnotatedChange:
+		return "", nil
+	case *giteabatches.AnnotatedPullRequest:
+		if m.MergeCommitSHA != nil {
+			return *m.MergeCommitSHA, nil
+		}
+		return "", nil
+	case *perforce.Changelist:
+		return "", nil
+	default:
+		return "", errors.New("merge commit oid unknown changeset type")
+	}
+}
+
 // AttachedTo returns true if the changeset is currently attached to the batch
 // change with the given batchChangeID.
 func (c *Changeset) AttachedTo(batchChangeID int64) bool {
@@ -1119,6 +1231,16 @@ func (c *Changeset) Labels() []ChangesetLabel {
 			labels[i] = ChangesetLabel{Name: l, Color: "000000"}
 		}
 		return labels
+	case *giteabatches.AnnotatedPullRequest:
+		labels := make([]ChangesetLabel, len(m.Labels))
+		for i, l := range m.Labels {
+			labels[i] = ChangesetLabel{
+				Name:        l.Name,
+				Color:       strings.TrimPrefix(l.Color, "#"),
+				Description: l.Description,
+			}
+		}
+		return labels
 	default:
 		return []ChangesetLabel{}
 	}
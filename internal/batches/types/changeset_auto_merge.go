@@ -0,0 +1,47 @@
+package types
+
+import "time"
+
+// ChangesetAutoMergeState defines the possible states of automatically merging
+// a changeset.
+type ChangesetAutoMergeState string
+
+// ChangesetAutoMergeState constants.
+const (
+	ChangesetAutoMergeStateWaitingForChecks ChangesetAutoMergeState = "WAITING_FOR_CHECKS"
+	ChangesetAutoMergeStateWaitingForReview ChangesetAutoMergeState = "WAITING_FOR_REVIEW"
+	ChangesetAutoMergeStateWaitingForWindow ChangesetAutoMergeState = "WAITING_FOR_WINDOW"
+	ChangesetAutoMergeStateRateLimited      ChangesetAutoMergeState = "RATE_LIMITED"
+	ChangesetAutoMergeStateMerged           ChangesetAutoMergeState = "MERGED"
+	ChangesetAutoMergeStateFailed           ChangesetAutoMergeState = "FAILED"
+)
+
+// Valid returns true if the given ChangesetAutoMergeState is valid.
+func (s ChangesetAutoMergeState) Valid() bool {
+	switch s {
+	case ChangesetAutoMergeStateWaitingForChecks,
+		ChangesetAutoMergeStateWaitingForReview,
+		ChangesetAutoMergeStateWaitingForWindow,
+		ChangesetAutoMergeStateRateLimited,
+		ChangesetAutoMergeStateMerged,
+		ChangesetAutoMergeStateFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// ChangesetAutoMerge records the progress of automatically merging a changeset
+// of a batch change with an auto-merge policy.
+type ChangesetAutoMerge struct {
+	ChangesetID   int64
+	BatchChangeID int64
+
+	State ChangesetAutoMergeState
+	// Message explains the state, e.g. why merging the changeset failed.
+	Message string
+
+	MergedAt  time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,23 @@
+package types
+
+import "time"
+
+// ChangesetRebase records the last attempt to re-apply the diff of a
+// changeset onto the latest commit of its base branch.
+type ChangesetRebase struct {
+	ChangesetID int64
+	// ChangesetSpecID is the spec whose diff was re-applied. A rebase is only
+	// relevant as long as this is the current spec of the changeset.
+	ChangesetSpecID int64
+	// BaseRev is the base branch commit the diff was re-applied onto.
+	BaseRev string
+	// ConflictingFiles are the files whose changes did not apply onto
+	// BaseRev. The changeset then needs manual attention.
+	ConflictingFiles []string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// HasConflicts returns true when the diff did not apply onto BaseRev.
+func (r *ChangesetRebase) HasConflicts() bool { return len(r.ConflictingFiles) > 0 }
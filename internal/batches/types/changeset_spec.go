@@ -74,6 +74,9 @@ func NewChangesetSpecFromSpec(spec *batcheslib.ChangesetSpec) (*ChangesetSpec, e
 		c.CommitMessage = commitMsg
 		c.CommitAuthorName = authorName
 		c.CommitAuthorEmail = authorEmail
+		c.StackStage = int32(spec.StackStage)
+		c.StackParentHeadRef = spec.StackParentHeadRef
+		c.RequestReviewsFromOwners = spec.RequestReviewsFromOwners
 	}
 
 	c.computeForkNamespace(spec.Fork)
@@ -116,6 +119,23 @@ type ChangesetSpec struct {
 	CommitAuthorEmail string
 
 	ForkNamespace *string
+
+	// StackStage is the 1-based position of the changeset in a stack of
+	// dependent changesets in the same repository, or zero if the changeset
+	// is not part of a stack.
+	StackStage int32
+	// StackParentHeadRef is the head ref of the previous changeset in the
+	// stack. The changeset is only published once that changeset is merged.
+	StackParentHeadRef string
+
+	// RequestReviewsFromOwners is true if reviews should be requested from
+	// the owners of the changed files once the changeset is published.
+	RequestReviewsFromOwners bool
+
+	// RevertedChangesetID is the ID of the merged changeset that this
+	// changeset spec reverts, if it was created by rolling back a batch
+	// change.
+	RevertedChangesetID int64
 }
 
 // Clone returns a clone of a ChangesetSpec.
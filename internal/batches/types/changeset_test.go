@@ -731,6 +731,78 @@ func TestChangeset_BaseRef(t *testing.T) {
 	})
 }
 
+func TestChangeset_MergeCommitOid(t *testing.T) {
+	for name, tc := range map[string]struct {
+		meta any
+		want string
+	}{
+		"azuredevops": {
+			meta: &adobatches.AnnotatedPullRequest{
+				PullRequest: &azuredevops.PullRequest{},
+			},
+			want: "",
+		},
+		"bitbucketcloud": {
+			meta: &bbcs.AnnotatedPullRequest{
+				PullRequest: &bitbucketcloud.PullRequest{
+					MergeCommit: &bitbucketcloud.PullRequestCommit{Hash: "foo"},
+				},
+			},
+			want: "foo",
+		},
+		"bitbucketcloud not merged": {
+			meta: &bbcs.AnnotatedPullRequest{
+				PullRequest: &bitbucketcloud.PullRequest{},
+			},
+			want: "",
+		},
+		"bitbucketserver": {
+			meta: &bitbucketserver.PullRequest{
+				Activities: []*bitbucketserver.Activity{
+					{Action: bitbucketserver.OpenedActivityAction},
+					{Action: bitbucketserver.MergedActivityAction, Commit: &bitbucketserver.Commit{ID: "foo"}},
+				},
+			},
+			want: "foo",
+		},
+		"GitHub": {
+			meta: &github.PullRequest{
+				TimelineItems: []github.TimelineItem{
+					{Type: "ClosedEvent", Item: &github.ClosedEvent{}},
+					{Type: "MergedEvent", Item: &github.MergedEvent{Commit: github.Commit{OID: "foo"}}},
+				},
+			},
+			want: "foo",
+		},
+		"GitLab": {
+			meta: &gitlab.MergeRequest{MergeCommitSHA: "foo"},
+			want: "foo",
+		},
+		"GitLab squashed": {
+			meta: &gitlab.MergeRequest{SquashCommitSHA: "foo"},
+			want: "foo",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := &Changeset{Metadata: tc.meta}
+			have, err := c.MergeCommitOid()
+			if err != nil {
+				t.Errorf("unexpected error: %+v", err)
+			}
+			if have != tc.want {
+				t.Errorf("unexpected merge commit oid: have %s; want %s", have, tc.want)
+			}
+		})
+	}
+
+	t.Run("unknown changeset type", func(t *testing.T) {
+		c := &Changeset{}
+		if _, err := c.MergeCommitOid(); err == nil {
+			t.Error("unexpected nil error")
+		}
+	})
+}
+
 func TestChangeset_Labels(t *testing.T) {
 	for name, tc := range map[string]struct {
 		meta any
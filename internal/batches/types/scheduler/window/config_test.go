@@ -197,6 +197,52 @@ func TestConfiguration_Schedule(t *testing.T) {
 	}
 }
 
+func TestConfiguration_IsOpen(t *testing.T) {
+	// Monday, 9:00 UTC.
+	now := time.Date(2021, 4, 5, 9, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		windows []Window
+		want    bool
+	}{
+		"no rollout windows": {
+			windows: []Window{},
+			want:    true,
+		},
+		"open window": {
+			windows: []Window{
+				{days: newWeekdaySet(time.Monday), rate: rate{n: 10, unit: ratePerHour}},
+			},
+			want: true,
+		},
+		"unlimited window": {
+			windows: []Window{
+				{days: newWeekdaySet(time.Monday), rate: makeUnlimitedRate()},
+			},
+			want: true,
+		},
+		"zero rate window": {
+			windows: []Window{
+				{days: newWeekdaySet(time.Monday), rate: rate{n: 0, unit: ratePerHour}},
+			},
+			want: false,
+		},
+		"outside of windows": {
+			windows: []Window{
+				{days: newWeekdaySet(time.Tuesday), rate: rate{n: 10, unit: ratePerHour}},
+			},
+			want: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Configuration{windows: tc.windows}
+			if have := cfg.IsOpen(now); have != tc.want {
+				t.Errorf("unexpected result: have=%v want=%v", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestConfiguration_currentFor(t *testing.T) {
 	// Let's set up some common windows to simplify defining the test cases.
 
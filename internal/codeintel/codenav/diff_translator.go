@@ -0,0 +1,328 @@
+package codenav
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"github.com/sourcegraph/go-diff/diff"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	sgtypes "github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// diffGitTreeTranslator translates positions within an ephemeral revision that does not
+// exist on gitserver (e.g. the contents of an editor buffer with unsaved changes). The
+// ephemeral revision is described by a base commit and a unified diff of the changes made
+// on top of that commit. Translations to and from other commits pass through the base
+// commit: the diff is applied (or un-applied) locally, and the remaining distance between
+// the base commit and the target commit is handled by a regular git tree translator.
+type diffGitTreeTranslator struct {
+	base     GitTreeTranslator
+	path     string
+	basePath string
+	changes  *uncommittedChanges
+}
+
+// NewDiffGitTreeTranslator creates a new GitTreeTranslator for the revision obtained by applying the
+// given unified diff to the given base commit. The given path names the requested file within the
+// base commit; positions are given and returned relative to the file's content after applying the
+// diff, which may also rename the file.
+func NewDiffGitTreeTranslator(client gitserver.Client, repo *sgtypes.Repo, baseCommit, basePath string, rawDiff []byte, hunkCache HunkCache) (GitTreeTranslator, error) {
+	changes, err := parseUncommittedChanges(rawDiff)
+	if err != nil {
+		return nil, err
+	}
+
+	path, ok := changes.newPath(basePath)
+	if !ok {
+		return nil, errors.Newf("file %q is deleted by the given diff", basePath)
+	}
+
+	args := &requestArgs{
+		repo:   repo,
+		commit: baseCommit,
+		path:   basePath,
+	}
+
+	return &diffGitTreeTranslator{
+		base:     NewGitTreeTranslator(client, args, hunkCache),
+		path:     path,
+		basePath: basePath,
+		changes:  changes,
+	}, nil
+}
+
+// GetTargetCommitPathFromSourcePath translates the given path from the ephemeral revision into the
+// given target commit. If reverse is true, then the given path is translated from the target commit
+// into the ephemeral revision.
+func (g *diffGitTreeTranslator) GetTargetCommitPathFromSourcePath(ctx context.Context, commit, path string, reverse bool) (string, bool, error) {
+	if reverse {
+		basePath, ok, err := g.base.GetTargetCommitPathFromSourcePath(ctx, commit, path, true)
+		if err != nil || !ok {
+			return "", false, err
+		}
+
+		newPath, ok := g.changes.newPath(basePath)
+		return newPath, ok, nil
+	}
+
+	basePath, ok := g.changes.basePath(path)
+	if !ok {
+		return "", false, nil
+	}
+
+	return g.base.GetTargetCommitPathFromSourcePath(ctx, commit, basePath, false)
+}
+
+// GetTargetCommitPositionFromSourcePosition translates the given position from the ephemeral revision
+// into the given target commit. The target commit's path and position are returned, along with a
+// boolean flag indicating that the translation was successful. If reverse is true, then the given
+// position is translated from the target commit into the ephemeral revision.
+func (g *diffGitTreeTranslator) GetTargetCommitPositionFromSourcePosition(ctx context.Context, commit string, px shared.Position, reverse bool) (string, shared.Position, bool, error) {
+	if reverse {
+		_, basePosition, ok, err := g.base.GetTargetCommitPositionFromSourcePosition(ctx, commit, px, true)
+		if err != nil || !ok {
+			return "", shared.Position{}, false, err
+		}
+
+		hunks, ok := g.changes.hunksFromBase(g.basePath)
+		if !ok {
+			return "", shared.Position{}, false, nil
+		}
+
+		position, ok := translatePosition(hunks, basePosition)
+		return g.path, position, ok, nil
+	}
+
+	hunks, ok := g.changes.hunksToBase(g.path)
+	if !ok {
+		return "", shared.Position{}, false, nil
+	}
+
+	basePosition, ok := translatePosition(hunks, px)
+	if !ok {
+		return "", shared.Position{}, false, nil
+	}
+
+	return g.base.GetTargetCommitPositionFromSourcePosition(ctx, commit, basePosition, false)
+}
+
+// GetTargetCommitRangeFromSourceRange translates the given range from the ephemeral revision into
+// the given target commit. The target commit's path and range are returned, along with a boolean
+// flag indicating that the translation was successful. If reverse is true, then the given range is
+// translated from the target commit into the ephemeral revision.
+func (g *diffGitTreeTranslator) GetTargetCommitRangeFromSourceRange(ctx context.Context, commit, path string, rx shared.Range, reverse bool) (string, shared.Range, bool, error) {
+	if reverse {
+		basePath, baseRange, ok, err := g.base.GetTargetCommitRangeFromSourceRange(ctx, commit, path, rx, true)
+		if err != nil || !ok {
+			return "", shared.Range{}, false, err
+		}
+
+		newPath, ok := g.changes.newPath(basePath)
+		if !ok {
+			return "", shared.Range{}, false, nil
+		}
+
+		hunks, _ := g.changes.hunksFromBase(basePath)
+		rng, ok := translateRange(hunks, baseRange)
+		return newPath, rng, ok, nil
+	}
+
+	basePath, ok := g.changes.basePath(path)
+	if !ok {
+		return "", shared.Range{}, false, nil
+	}
+
+	hunks, _ := g.changes.hunksToBase(path)
+	baseRange, ok := translateRange(hunks, rx)
+	if !ok {
+		return "", shared.Range{}, false, nil
+	}
+
+	return g.base.GetTargetCommitRangeFromSourceRange(ctx, commit, basePath, baseRange, false)
+}
+
+// uncommittedChanges indexes the file diffs of a unified diff by both their original
+// (base commit) and new (ephemeral revision) paths.
+type uncommittedChanges struct {
+	byBasePath map[string]*fileChange
+	byNewPath  map[string]*fileChange
+}
+
+// fileChange holds the hunks of a single file diff in both directions. A path is empty
+// when the file does not exist on that side of the diff.
+type fileChange struct {
+	basePath  string
+	newPath   string
+	forward   []*diff.Hunk
+	backwards []*diff.Hunk
+}
+
+// devNull is the path used by unified diffs to denote a created or deleted file.
+const devNull = "/dev/null"
+
+// parseUncommittedChanges parses the given unified diff. An error is returned if the diff
+// is malformed or contains a hunk whose header does not agree with its body.
+func parseUncommittedChanges(rawDiff []byte) (*uncommittedChanges, error) {
+	fileDiffs, err := diff.ParseMultiFileDiff(rawDiff)
+	if err != nil {
+		return nil, errors.Wrap(err, "diff.ParseMultiFileDiff")
+	}
+
+	changes := &uncommittedChanges{
+		byBasePath: map[string]*fileChange{},
+		byNewPath:  map[string]*fileChange{},
+	}
+
+	for _, fileDiff := range fileDiffs {
+		change := &fileChange{
+			basePath: normalizeDiffPath(fileDiff.OrigName, "a/"),
+			newPath:  normalizeDiffPath(fileDiff.NewName, "b/"),
+			forward:  fileDiff.Hunks,
+		}
+
+		for _, hunk := range fileDiff.Hunks {
+			if err := validateHunk(hunk); err != nil {
+				return nil, errors.Wrapf(err, "malformed diff for %q", fileDiff.NewName)
+			}
+
+			change.backwards = append(change.backwards, invertHunk(hunk))
+		}
+
+		if change.basePath != "" {
+			changes.byBasePath[change.basePath] = change
+		}
+		if change.newPath != "" {
+			changes.byNewPath[change.newPath] = change
+		}
+	}
+
+	return changes, nil
+}
+
+// basePath returns the path in the base commit of the given path in the ephemeral revision.
+// This method returns false if the file was created in the ephemeral revision.
+func (c *uncommittedChanges) basePath(path string) (string, bool) {
+	if change, ok := c.byNewPath[path]; ok {
+		return change.basePath, change.basePath != ""
+	}
+	if _, ok := c.byBasePath[path]; ok {
+		// The file was moved or deleted, and the given path is now unoccupied
+		return "", false
+	}
+
+	return path, true
+}
+
+// newPath returns the path in the ephemeral revision of the given path in the base commit.
+// This method returns false if the file was deleted in the ephemeral revision.
+func (c *uncommittedChanges) newPath(path string) (string, bool) {
+	if change, ok := c.byBasePath[path]; ok {
+		return change.newPath, change.newPath != ""
+	}
+	if _, ok := c.byNewPath[path]; ok {
+		// The path is occupied by a created or moved file
+		return "", false
+	}
+
+	return path, true
+}
+
+// hunksFromBase returns the hunks that translate lines of the given base commit path into the
+// ephemeral revision. This method returns false if the file was deleted in the ephemeral revision.
+func (c *uncommittedChanges) hunksFromBase(path string) ([]*diff.Hunk, bool) {
+	if _, ok := c.newPath(path); !ok {
+		return nil, false
+	}
+	if change, ok := c.byBasePath[path]; ok {
+		return change.forward, true
+	}
+
+	return nil, true
+}
+
+// hunksToBase returns the hunks that translate lines of the given ephemeral revision path into
+// the base commit. This method returns false if the file was created in the ephemeral revision.
+func (c *uncommittedChanges) hunksToBase(path string) ([]*diff.Hunk, bool) {
+	if _, ok := c.basePath(path); !ok {
+		return nil, false
+	}
+	if change, ok := c.byNewPath[path]; ok {
+		return change.backwards, true
+	}
+
+	return nil, true
+}
+
+// normalizeDiffPath strips the given git prefix and leading slashes from the given diff
+// header path. An empty string is returned for a missing file.
+func normalizeDiffPath(path, prefix string) string {
+	if path == devNull {
+		return ""
+	}
+
+	return strings.TrimPrefix(strings.TrimPrefix(path, prefix), "/")
+}
+
+// validateHunk ensures that the body of the given hunk contains exactly the number of
+// lines indicated by its header. Translating positions through a hunk that is shorter
+// than its header claims would otherwise read past the end of the hunk.
+func validateHunk(hunk *diff.Hunk) error {
+	origLines, newLines := int32(0), int32(0)
+	for _, line := range hunkLines(hunk.Body) {
+		if !strings.HasPrefix(line, "+") {
+			origLines++
+		}
+		if !strings.HasPrefix(line, "-") {
+			newLines++
+		}
+	}
+
+	if origLines != hunk.OrigLines || newLines != hunk.NewLines {
+		return errors.Newf(
+			"hunk at line %d has an unexpected body: want %d original and %d new lines, have %d and %d",
+			hunk.OrigStartLine, hunk.OrigLines, hunk.NewLines, origLines, newLines,
+		)
+	}
+
+	return nil
+}
+
+// invertHunk returns a copy of the given hunk that describes the inverse change, which
+// translates lines of the new file back into lines of the original file.
+func invertHunk(hunk *diff.Hunk) *diff.Hunk {
+	var body bytes.Buffer
+	for _, line := range hunkLines(hunk.Body) {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			line = "-" + line[1:]
+		case strings.HasPrefix(line, "-"):
+			line = "+" + line[1:]
+		}
+
+		body.WriteString(line)
+		body.WriteByte('\n')
+	}
+
+	return &diff.Hunk{
+		OrigStartLine: hunk.NewStartLine,
+		OrigLines:     hunk.NewLines,
+		NewStartLine:  hunk.OrigStartLine,
+		NewLines:      hunk.OrigLines,
+		Section:       hunk.Section,
+		Body:          body.Bytes(),
+	}
+}
+
+// hunkLines splits the given hunk body into lines, discarding the empty string that
+// follows the body's trailing newline.
+func hunkLines(body []byte) []string {
+	if len(body) == 0 {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")
+}
@@ -0,0 +1,223 @@
+package codenav
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	sgtypes "github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// uncommittedDiff inserts two lines after line 2, edits line 5, and removes line 8 of
+// foo/bar.go; moves old/name.go to new/name.go while inserting two lines after line 3;
+// deletes gone.go; and creates added.go.
+const uncommittedDiff = `diff --git a/foo/bar.go b/foo/bar.go
+index 1111111..2222222 100644
+--- a/foo/bar.go
++++ b/foo/bar.go
+@@ -1,10 +1,11 @@
+ L1
+ L2
++N1
++N2
+ L3
+ L4
+-L5
++M5
+ L6
+ L7
+-L8
+ L9
+ L10
+diff --git a/old/name.go b/new/name.go
+similarity index 90%
+rename from old/name.go
+rename to new/name.go
+index 3333333..4444444 100644
+--- a/old/name.go
++++ b/new/name.go
+@@ -3,0 +4,2 @@
++X
++Y
+diff --git a/gone.go b/gone.go
+deleted file mode 100644
+index 5555555..0000000
+--- a/gone.go
++++ /dev/null
+@@ -1 +0,0 @@
+-gone
+diff --git a/added.go b/added.go
+new file mode 100644
+index 0000000..6666666
+--- /dev/null
++++ b/added.go
+@@ -0,0 +1 @@
++added
+`
+
+func TestDiffGitTreeTranslatorPosition(t *testing.T) {
+	testCases := []struct {
+		name     string
+		path     string
+		line     int
+		reverse  bool
+		wantPath string
+		wantLine int
+		wantOK   bool
+	}{
+		{name: "before changes", path: "foo/bar.go", line: 1, wantPath: "foo/bar.go", wantLine: 1, wantOK: true},
+		{name: "after insertion", path: "foo/bar.go", line: 4, wantPath: "foo/bar.go", wantLine: 2, wantOK: true},
+		{name: "inserted line", path: "foo/bar.go", line: 2, wantOK: false},
+		{name: "edited line", path: "foo/bar.go", line: 6, wantOK: false},
+		{name: "after deletion", path: "foo/bar.go", line: 9, wantPath: "foo/bar.go", wantLine: 8, wantOK: true},
+		{name: "after hunk", path: "foo/bar.go", line: 20, wantPath: "foo/bar.go", wantLine: 19, wantOK: true},
+		{name: "renamed before insertion", path: "old/name.go", line: 2, wantPath: "old/name.go", wantLine: 2, wantOK: true},
+		{name: "renamed inserted line", path: "old/name.go", line: 3, wantOK: false},
+		{name: "renamed after insertion", path: "old/name.go", line: 5, wantPath: "old/name.go", wantLine: 3, wantOK: true},
+
+		{name: "reverse before changes", path: "foo/bar.go", line: 1, reverse: true, wantPath: "foo/bar.go", wantLine: 1, wantOK: true},
+		{name: "reverse after insertion", path: "foo/bar.go", line: 2, reverse: true, wantPath: "foo/bar.go", wantLine: 4, wantOK: true},
+		{name: "reverse edited line", path: "foo/bar.go", line: 4, reverse: true, wantOK: false},
+		{name: "reverse deleted line", path: "foo/bar.go", line: 7, reverse: true, wantOK: false},
+		{name: "reverse after deletion", path: "foo/bar.go", line: 8, reverse: true, wantPath: "foo/bar.go", wantLine: 9, wantOK: true},
+		{name: "reverse after hunk", path: "foo/bar.go", line: 19, reverse: true, wantPath: "foo/bar.go", wantLine: 20, wantOK: true},
+		{name: "reverse renamed before insertion", path: "old/name.go", line: 2, reverse: true, wantPath: "new/name.go", wantLine: 2, wantOK: true},
+		{name: "reverse renamed after insertion", path: "old/name.go", line: 3, reverse: true, wantPath: "new/name.go", wantLine: 5, wantOK: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			translator, err := NewDiffGitTreeTranslator(gitserver.NewMockClient(), &sgtypes.Repo{ID: 50}, "deadbeef1", testCase.path, []byte(uncommittedDiff), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			// Translating to the base commit itself requires no diff from gitserver
+			path, pos, ok, err := translator.GetTargetCommitPositionFromSourcePosition(context.Background(), "deadbeef1", shared.Position{Line: testCase.line, Character: 10}, testCase.reverse)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if ok != testCase.wantOK {
+				t.Fatalf("unexpected ok. want=%v have=%v", testCase.wantOK, ok)
+			}
+			if !ok {
+				return
+			}
+
+			if path != testCase.wantPath {
+				t.Errorf("unexpected path. want=%s have=%s", testCase.wantPath, path)
+			}
+			if diff := cmp.Diff(shared.Position{Line: testCase.wantLine, Character: 10}, pos); diff != "" {
+				t.Errorf("unexpected position (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiffGitTreeTranslatorPath(t *testing.T) {
+	translator, err := NewDiffGitTreeTranslator(gitserver.NewMockClient(), &sgtypes.Repo{ID: 50}, "deadbeef1", "foo/bar.go", []byte(uncommittedDiff), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	testCases := []struct {
+		path     string
+		reverse  bool
+		wantPath string
+		wantOK   bool
+	}{
+		{path: "foo/bar.go", wantPath: "foo/bar.go", wantOK: true},
+		{path: "unchanged.go", wantPath: "unchanged.go", wantOK: true},
+		{path: "new/name.go", wantPath: "old/name.go", wantOK: true},
+		{path: "old/name.go", wantOK: false},
+		{path: "added.go", wantOK: false},
+
+		{path: "old/name.go", reverse: true, wantPath: "new/name.go", wantOK: true},
+		{path: "new/name.go", reverse: true, wantOK: false},
+		{path: "gone.go", reverse: true, wantOK: false},
+	}
+
+	for _, testCase := range testCases {
+		path, ok, err := translator.GetTargetCommitPathFromSourcePath(context.Background(), "deadbeef1", testCase.path, testCase.reverse)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if ok != testCase.wantOK {
+			t.Errorf("unexpected ok for %q (reverse=%v). want=%v have=%v", testCase.path, testCase.reverse, testCase.wantOK, ok)
+		} else if ok && path != testCase.wantPath {
+			t.Errorf("unexpected path for %q (reverse=%v). want=%s have=%s", testCase.path, testCase.reverse, testCase.wantPath, path)
+		}
+	}
+}
+
+func TestDiffGitTreeTranslatorRangeThroughBaseCommit(t *testing.T) {
+	// The upload commit deadbeef2 adds a single line to the top of the file
+	client := gitserver.NewMockClientWithExecReader(nil, func(_ context.Context, _ api.RepoName, args []string) (io.ReadCloser, error) {
+		diff := "diff --git a/foo/bar.go b/foo/bar.go\n--- a/foo/bar.go\n+++ b/foo/bar.go\n@@ -1,3 +1,4 @@\n+header\n L1\n L2\n L3\n"
+		if args[1] == "deadbeef2" {
+			diff = "diff --git a/foo/bar.go b/foo/bar.go\n--- a/foo/bar.go\n+++ b/foo/bar.go\n@@ -1,4 +1,3 @@\n-header\n L1\n L2\n L3\n"
+		}
+
+		return io.NopCloser(bytes.NewReader([]byte(diff))), nil
+	})
+
+	translator, err := NewDiffGitTreeTranslator(client, &sgtypes.Repo{ID: 50}, "deadbeef1", "foo/bar.go", []byte(uncommittedDiff), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	editedRange := shared.Range{
+		Start: shared.Position{Line: 4, Character: 3},
+		End:   shared.Position{Line: 5, Character: 7},
+	}
+	uploadRange := shared.Range{
+		Start: shared.Position{Line: 3, Character: 3},
+		End:   shared.Position{Line: 4, Character: 7},
+	}
+
+	path, rng, ok, err := translator.GetTargetCommitRangeFromSourceRange(context.Background(), "deadbeef2", "foo/bar.go", editedRange, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !ok {
+		t.Fatalf("expected translation to succeed")
+	}
+	if path != "foo/bar.go" {
+		t.Errorf("unexpected path. want=%s have=%s", "foo/bar.go", path)
+	}
+	if diff := cmp.Diff(uploadRange, rng); diff != "" {
+		t.Errorf("unexpected range (-want +got):\n%s", diff)
+	}
+
+	path, rng, ok, err = translator.GetTargetCommitRangeFromSourceRange(context.Background(), "deadbeef2", "foo/bar.go", uploadRange, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !ok {
+		t.Fatalf("expected translation to succeed")
+	}
+	if path != "foo/bar.go" {
+		t.Errorf("unexpected path. want=%s have=%s", "foo/bar.go", path)
+	}
+	if diff := cmp.Diff(editedRange, rng); diff != "" {
+		t.Errorf("unexpected range (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewDiffGitTreeTranslatorInvalidDiff(t *testing.T) {
+	for name, rawDiff := range map[string]string{
+		"unparseable":  "--- a/foo/bar.go\n+++ b/foo/bar.go\n@@ -1,x +1 @@\n L1\n",
+		"short hunk":   "--- a/foo/bar.go\n+++ b/foo/bar.go\n@@ -1,3 +1,3 @@\n L1\n",
+		"deleted file": "--- a/foo/bar.go\n+++ /dev/null\n@@ -1 +0,0 @@\n-L1\n",
+	} {
+		if _, err := NewDiffGitTreeTranslator(gitserver.NewMockClient(), &sgtypes.Repo{ID: 50}, "deadbeef1", "foo/bar.go", []byte(rawDiff), nil); err == nil {
+			t.Errorf("expected error for %s diff", name)
+		}
+	}
+}
@@ -161,7 +161,7 @@ func (g *gitTreeTranslator) readHunks(ctx context.Context, repo *sgtypes.Repo, s
 // findHunk returns the last thunk that does not begin after the given line.
 func findHunk(hunks []*diff.Hunk, line int) *diff.Hunk {
 	i := 0
-	for i < len(hunks) && int(hunks[i].OrigStartLine) <= line {
+	for i < len(hunks) && hunkOrigStartLine(hunks[i]) <= line {
 		i++
 	}
 
@@ -171,6 +171,33 @@ func findHunk(hunks []*diff.Hunk, line int) *diff.Hunk {
 	return hunks[i-1]
 }
 
+// hunkOrigStartLine returns the first line of the original file covered by the given hunk.
+// A hunk without any original lines (a pure addition without context) names the line
+// after which the addition occurs, so the hunk is considered to start on the next line.
+//
+// Diffs between commits are generated with context lines, so they only contain such hunks
+// for files that were empty before or after the diff, which have no lines to translate.
+// Diffs without context lines, such as the diffs of uncommitted changes translated by the
+// diffGitTreeTranslator, contain them for every pure addition or deletion. Using the start
+// line of these hunks as-is would shift the line they name by the size of the hunk.
+func hunkOrigStartLine(hunk *diff.Hunk) int {
+	if hunk.OrigLines == 0 {
+		return int(hunk.OrigStartLine) + 1
+	}
+
+	return int(hunk.OrigStartLine)
+}
+
+// hunkNewStartLine returns the first line of the new file covered by the given hunk. See
+// hunkOrigStartLine for the treatment of hunks without any new lines.
+func hunkNewStartLine(hunk *diff.Hunk) int {
+	if hunk.NewLines == 0 {
+		return int(hunk.NewStartLine) + 1
+	}
+
+	return int(hunk.NewStartLine)
+}
+
 // translateRange translates the given range by calling translatePosition on both of the range's
 // endpoints. This function returns a boolean flag indicating that the translation was
 // successful (which occurs when both endpoints of the range can be translated).
@@ -216,9 +243,9 @@ func translateLineNumbers(hunks []*diff.Hunk, line int) (int, bool) {
 
 	// If the hunk ends before this line, we can simply set the line offset by the
 	// relative difference between the line offsets in each file after this hunk.
-	if line >= int(hunk.OrigStartLine+hunk.OrigLines) {
-		endOfSourceHunk := int(hunk.OrigStartLine + hunk.OrigLines)
-		endOfTargetHunk := int(hunk.NewStartLine + hunk.NewLines)
+	if line >= hunkOrigStartLine(hunk)+int(hunk.OrigLines) {
+		endOfSourceHunk := hunkOrigStartLine(hunk) + int(hunk.OrigLines)
+		endOfTargetHunk := hunkNewStartLine(hunk) + int(hunk.NewLines)
 		targetCommitLineNumber := line + (endOfTargetHunk - endOfSourceHunk)
 
 		// Translate from git diff one-index to bundle/lsp zero-index
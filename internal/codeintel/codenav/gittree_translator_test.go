@@ -369,8 +369,54 @@ var prometheusTestCases = []gitTreeTranslatorTestCase{
 	{prometheusDiff, "prometheus", "after hunk", 500, true, 500},
 }
 
+// insertionDiff is a diff without context lines that inserts two lines after line 5.
+const insertionDiff = `
+diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -5,0 +6,2 @@
++	a := 1
++	b := 2
+`
+
+// Hunks without original lines name the line after which the lines are inserted, so the
+// line named by the hunk is not part of the hunk.
+var insertionTestCases = []gitTreeTranslatorTestCase{
+	{insertionDiff, "insertion", "before insertion", 4, true, 4},
+	{insertionDiff, "insertion", "line named by hunk", 5, true, 5},
+	{insertionDiff, "insertion", "directly after insertion", 6, true, 8},
+	{insertionDiff, "insertion", "after insertion", 10, true, 12},
+}
+
+// deletionDiff is a diff without context lines that deletes lines 6 and 7.
+const deletionDiff = `
+diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -6,2 +5,0 @@
+-	a := 1
+-	b := 2
+`
+
+// Hunks without new lines name the line after which the lines were deleted.
+var deletionTestCases = []gitTreeTranslatorTestCase{
+	{deletionDiff, "deletion", "before deletion", 5, true, 5},
+	{deletionDiff, "deletion", "on deletion 1", 6, false, 0},
+	{deletionDiff, "deletion", "on deletion 2", 7, false, 0},
+	{deletionDiff, "deletion", "directly after deletion", 8, true, 6},
+	{deletionDiff, "deletion", "after deletion", 12, true, 10},
+}
+
 func TestRawGetTargetCommitPositionFromSourcePosition(t *testing.T) {
-	for _, testCase := range append(append([]gitTreeTranslatorTestCase(nil), hugoTestCases...), prometheusTestCases...) {
+	var testCases []gitTreeTranslatorTestCase
+	testCases = append(testCases, hugoTestCases...)
+	testCases = append(testCases, prometheusTestCases...)
+	testCases = append(testCases, insertionTestCases...)
+	testCases = append(testCases, deletionTestCases...)
+
+	for _, testCase := range testCases {
 		name := fmt.Sprintf("%s : %s", testCase.diffName, testCase.description)
 
 		t.Run(name, func(t *testing.T) {
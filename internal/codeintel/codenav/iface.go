@@ -12,4 +12,5 @@ type UploadService interface {
 	GetUploadIDsWithReferences(ctx context.Context, orderedMonikers []precise.QualifiedMonikerData, ignoreIDs []int, repositoryID int, commit string, limit int, offset int) (ids []int, recordsScanned int, totalCount int, err error)
 	GetDumpsByIDs(ctx context.Context, ids []int) (_ []shared.Dump, err error)
 	InferClosestUploads(ctx context.Context, repositoryID int, commit, path string, exactPath bool, indexer string) (_ []shared.Dump, err error)
+	IncrementUploadQueryCounts(ctx context.Context, uploadIDs []int) error
 }
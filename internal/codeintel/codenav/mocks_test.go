@@ -2976,6 +2976,10 @@ type MockUploadService struct {
 	// object controlling the behavior of the method
 	// GetUploadIDsWithReferences.
 	GetUploadIDsWithReferencesFunc *UploadServiceGetUploadIDsWithReferencesFunc
+	// IncrementUploadQueryCountsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// IncrementUploadQueryCounts.
+	IncrementUploadQueryCountsFunc *UploadServiceIncrementUploadQueryCountsFunc
 	// InferClosestUploadsFunc is an instance of a mock function object
 	// controlling the behavior of the method InferClosestUploads.
 	InferClosestUploadsFunc *UploadServiceInferClosestUploadsFunc
@@ -3000,6 +3004,11 @@ func NewMockUploadService() *MockUploadService {
 				return
 			},
 		},
+		IncrementUploadQueryCountsFunc: &UploadServiceIncrementUploadQueryCountsFunc{
+			defaultHook: func(context.Context, []int) (r0 error) {
+				return
+			},
+		},
 		InferClosestUploadsFunc: &UploadServiceInferClosestUploadsFunc{
 			defaultHook: func(context.Context, int, string, string, bool, string) (r0 []shared1.Dump, r1 error) {
 				return
@@ -3027,6 +3036,11 @@ func NewStrictMockUploadService() *MockUploadService {
 				panic("unexpected invocation of MockUploadService.GetUploadIDsWithReferences")
 			},
 		},
+		IncrementUploadQueryCountsFunc: &UploadServiceIncrementUploadQueryCountsFunc{
+			defaultHook: func(context.Context, []int) error {
+				panic("unexpected invocation of MockUploadService.IncrementUploadQueryCounts")
+			},
+		},
 		InferClosestUploadsFunc: &UploadServiceInferClosestUploadsFunc{
 			defaultHook: func(context.Context, int, string, string, bool, string) ([]shared1.Dump, error) {
 				panic("unexpected invocation of MockUploadService.InferClosestUploads")
@@ -3049,6 +3063,9 @@ func NewMockUploadServiceFrom(i UploadService) *MockUploadService {
 		GetUploadIDsWithReferencesFunc: &UploadServiceGetUploadIDsWithReferencesFunc{
 			defaultHook: i.GetUploadIDsWithReferences,
 		},
+		IncrementUploadQueryCountsFunc: &UploadServiceIncrementUploadQueryCountsFunc{
+			defaultHook: i.IncrementUploadQueryCounts,
+		},
 		InferClosestUploadsFunc: &UploadServiceInferClosestUploadsFunc{
 			defaultHook: i.InferClosestUploads,
 		},
@@ -3408,6 +3425,115 @@ func (c UploadServiceGetUploadIDsWithReferencesFuncCall) Results() []interface{}
 	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
 }
 
+// UploadServiceIncrementUploadQueryCountsFunc describes the behavior when
+// the IncrementUploadQueryCounts method of the parent MockUploadService
+// instance is invoked.
+type UploadServiceIncrementUploadQueryCountsFunc struct {
+	defaultHook func(context.Context, []int) error
+	hooks       []func(context.Context, []int) error
+	history     []UploadServiceIncrementUploadQueryCountsFuncCall
+	mutex       sync.Mutex
+}
+
+// IncrementUploadQueryCounts delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockUploadService) IncrementUploadQueryCounts(v0 context.Context, v1 []int) error {
+	r0 := m.IncrementUploadQueryCountsFunc.nextHook()(v0, v1)
+	m.IncrementUploadQueryCountsFunc.appendCall(UploadServiceIncrementUploadQueryCountsFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// IncrementUploadQueryCounts method of the parent MockUploadService
+// instance is invoked and the hook queue is empty.
+func (f *UploadServiceIncrementUploadQueryCountsFunc) SetDefaultHook(hook func(context.Context, []int) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// IncrementUploadQueryCounts method of the parent MockUploadService
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *UploadServiceIncrementUploadQueryCountsFunc) PushHook(hook func(context.Context, []int) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UploadServiceIncrementUploadQueryCountsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, []int) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UploadServiceIncrementUploadQueryCountsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, []int) error {
+		return r0
+	})
+}
+
+func (f *UploadServiceIncrementUploadQueryCountsFunc) nextHook() func(context.Context, []int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UploadServiceIncrementUploadQueryCountsFunc) appendCall(r0 UploadServiceIncrementUploadQueryCountsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// UploadServiceIncrementUploadQueryCountsFuncCall objects describing the
+// invocations of this function.
+func (f *UploadServiceIncrementUploadQueryCountsFunc) History() []UploadServiceIncrementUploadQueryCountsFuncCall {
+	f.mutex.Lock()
+	history := make([]UploadServiceIncrementUploadQueryCountsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UploadServiceIncrementUploadQueryCountsFuncCall is an object that
+// describes an invocation of method IncrementUploadQueryCounts on an
+// instance of MockUploadService.
+type UploadServiceIncrementUploadQueryCountsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UploadServiceIncrementUploadQueryCountsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UploadServiceIncrementUploadQueryCountsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // UploadServiceInferClosestUploadsFunc describes the behavior when the
 // InferClosestUploads method of the parent MockUploadService instance is
 // invoked.
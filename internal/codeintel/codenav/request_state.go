@@ -89,6 +89,20 @@ func (r *RequestState) SetLocalGitTreeTranslator(client gitserver.Client, repo *
 	r.GitTreeTranslator = NewGitTreeTranslator(client, args, hunkCache)
 }
 
+// SetDiffGitTreeTranslator replaces the request's git tree translator with one that translates
+// positions relative to the given commit with the given unified diff applied. This allows
+// precise code navigation within revisions that do not exist on gitserver (e.g. unsaved
+// changes in an editor buffer) using the uploads of the base commit.
+func (r *RequestState) SetDiffGitTreeTranslator(client gitserver.Client, repo *sgTypes.Repo, commit, path string, rawDiff []byte, hunkCache HunkCache) error {
+	translator, err := NewDiffGitTreeTranslator(client, repo, commit, path, rawDiff, hunkCache)
+	if err != nil {
+		return err
+	}
+
+	r.GitTreeTranslator = translator
+	return nil
+}
+
 func (r *RequestState) SetLocalCommitCache(repoStore database.RepoStore, client gitserver.Client) {
 	r.commitCache = NewCommitCache(repoStore, client)
 }
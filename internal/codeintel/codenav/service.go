@@ -741,9 +741,56 @@ func (s *Service) GetClosestDumpsForBlob(ctx context.Context, repositoryID int,
 		attribute.Int("numFiltered", len(filtered)),
 		attribute.String("filtered", uploadIDsToString(filtered)))
 
+	// Record which uploads answer code navigation requests so that the most useful uploads
+	// are retained when enforcing storage budgets. This is best-effort and never fails the
+	// request.
+	uploadIDs := make([]int, 0, len(filtered))
+	for _, upload := range filtered {
+		uploadIDs = append(uploadIDs, upload.ID)
+	}
+	if err := s.uploadSvc.IncrementUploadQueryCounts(ctx, uploadIDs); err != nil {
+		s.logger.Warn("failed to record upload query counts", log.Error(err))
+	}
+
 	return filtered, nil
 }
 
+// CountSymbolOccurrences returns the number of occurrences of the given SCIP symbol of the given kind
+// (one of "definitions", "references", or "implementations") within the uploads closest to the given
+// commit. The returned flag is false if there is no precise code intelligence data for the commit.
+func (s *Service) CountSymbolOccurrences(ctx context.Context, repositoryID int, commit, kind, symbol string) (_ int, _ bool, err error) {
+	ctx, trace, endObservation := s.operations.countSymbolOccurrences.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("repositoryID", repositoryID),
+		attribute.String("commit", commit),
+		attribute.String("kind", kind),
+		attribute.String("symbol", symbol),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	switch kind {
+	case "definitions", "references", "implementations":
+	default:
+		return 0, false, errors.Newf("unsupported occurrence kind %q", kind)
+	}
+
+	uploads, err := s.GetClosestDumpsForBlob(ctx, repositoryID, commit, "", false, "")
+	if err != nil {
+		return 0, false, err
+	}
+	trace.AddEvent("GetClosestDumpsForBlob", attribute.Int("numUploads", len(uploads)))
+	if len(uploads) == 0 {
+		return 0, false, nil
+	}
+
+	monikers := []precise.QualifiedMonikerData{{MonikerData: precise.MonikerData{Identifier: symbol}}}
+	_, totalCount, err := s.getBulkMonikerLocations(ctx, uploads, monikers, kind, 0, 0)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return totalCount, true, nil
+}
+
 // filterUploadsWithCommits removes the uploads for commits which are unknown to gitserver from the given
 // slice. The slice is filtered in-place and returned (to update the slice length).
 func filterUploadsWithCommits(ctx context.Context, commitCache CommitCache, uploads []uploadsshared.Dump) ([]uploadsshared.Dump, error) {
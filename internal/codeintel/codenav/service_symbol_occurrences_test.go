@@ -0,0 +1,81 @@
+package codenav
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestCountSymbolOccurrences(t *testing.T) {
+	// Set up mocks
+	mockRepoStore := defaultMockRepoStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := gitserver.NewMockClient()
+
+	// Init service
+	svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	uploads := []uploadsshared.Dump{
+		{ID: 50, RepositoryID: 42, Commit: mockCommit, Root: "sub1/"},
+		{ID: 51, RepositoryID: 42, Commit: mockCommit, Root: "sub2/"},
+	}
+	mockUploadSvc.InferClosestUploadsFunc.SetDefaultReturn(uploads, nil)
+	mockLsifStore.GetBulkMonikerLocationsFunc.SetDefaultReturn(nil, 17, nil)
+
+	count, found, err := svc.CountSymbolOccurrences(context.Background(), 42, mockCommit, "references", "scip-go gomod example v1 `example`/Foo().")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !found {
+		t.Fatal("expected precise data to be found")
+	}
+	if count != 17 {
+		t.Errorf("unexpected count. want=%d have=%d", 17, count)
+	}
+
+	if history := mockLsifStore.GetBulkMonikerLocationsFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected number of calls to GetBulkMonikerLocations. want=%d have=%d", 1, len(history))
+	} else {
+		call := history[0]
+		if call.Arg1 != "references" {
+			t.Errorf("unexpected table name. want=%q have=%q", "references", call.Arg1)
+		}
+		if diff := cmp.Diff([]int{50, 51}, call.Arg2); diff != "" {
+			t.Errorf("unexpected upload ids (-want +got):\n%s", diff)
+		}
+		if len(call.Arg3) != 1 || call.Arg3[0].Identifier != "scip-go gomod example v1 `example`/Foo()." {
+			t.Errorf("unexpected monikers: %v", call.Arg3)
+		}
+	}
+}
+
+func TestCountSymbolOccurrencesNoUploads(t *testing.T) {
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	svc := newService(&observation.TestContext, defaultMockRepoStore(), mockLsifStore, mockUploadSvc, gitserver.NewMockClient())
+
+	count, found, err := svc.CountSymbolOccurrences(context.Background(), 42, mockCommit, "definitions", "foo")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if found || count != 0 {
+		t.Errorf("unexpected result. want=(0, false) have=(%d, %v)", count, found)
+	}
+	if len(mockLsifStore.GetBulkMonikerLocationsFunc.History()) != 0 {
+		t.Error("expected GetBulkMonikerLocations not to be called")
+	}
+}
+
+func TestCountSymbolOccurrencesInvalidKind(t *testing.T) {
+	svc := newService(&observation.TestContext, defaultMockRepoStore(), NewMockLsifStore(), NewMockUploadService(), gitserver.NewMockClient())
+
+	if _, _, err := svc.CountSymbolOccurrences(context.Background(), 42, mockCommit, "hovers", "foo"); err == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -77,6 +77,7 @@ func (r *rootResolver) GitBlobLSIFData(ctx context.Context, args *resolverstubs.
 		attribute.String("path", args.Path),
 		attribute.Bool("exactPath", args.ExactPath),
 		attribute.String("toolName", args.ToolName),
+		attribute.Bool("hasDiff", args.Diff != ""),
 	}})
 	endObservation.OnCancel(ctx, 1, observation.Args{})
 
@@ -105,6 +106,11 @@ func (r *rootResolver) GitBlobLSIFData(ctx context.Context, args *resolverstubs.
 		r.maximumIndexesPerMonikerSearch,
 		r.hunkCache,
 	)
+	if args.Diff != "" {
+		if err := reqState.SetDiffGitTreeTranslator(r.gitserverClient, args.Repo, string(args.Commit), args.Path, []byte(args.Diff), r.hunkCache); err != nil {
+			return nil, err
+		}
+	}
 
 	return newGitBlobLSIFDataResolver(
 		r.svc,
@@ -2,9 +2,7 @@ package exporter
 
 import (
 	"context"
-	"crypto/md5"
 	"path/filepath"
-	"strings"
 
 	"github.com/sourcegraph/log"
 	"github.com/sourcegraph/scip/bindings/go/scip"
@@ -156,7 +154,7 @@ func setDefinitionsAndReferencesForUpload(
 			}
 
 			// Parse and format symbol into an opaque string for ranking calculations
-			if checksum, ok := canonicalizeSymbol(occ.Symbol); ok {
+			if checksum, ok := rankingshared.CanonicalizeSymbol(occ.Symbol); ok {
 				references <- checksum
 				referencesCount++
 			}
@@ -203,12 +201,13 @@ func setDefinitionsForUpload(
 			}
 
 			// Parse and format symbol into an opaque string for ranking calculations
-			if checksum, ok := canonicalizeSymbol(occ.Symbol); ok {
+			if checksum, ok := rankingshared.CanonicalizeSymbol(occ.Symbol); ok {
 				definitions <- shared.RankingDefinitions{
 					UploadID:         uploadID,
 					ExportedUploadID: exportedUploadID,
 					SymbolChecksum:   checksum,
 					DocumentPath:     documentPath,
+					Exported:         rankingshared.IsExportedSymbol(occ.Symbol),
 				}
 				seenDefinitions[occ.Symbol] = struct{}{}
 			}
@@ -225,40 +224,3 @@ func setDefinitionsForUpload(
 
 	return seenDefinitions, nil
 }
-
-const skipPrefix = "lsif ."
-
-var emptyChecksum = [16]byte{}
-
-// canonicalizeSymbol transforms a symbol name into an opaque string that
-// can be matched internally by the ranking machinery.
-//
-// Canonicalization of a symbol name for ranking makes two transformations:
-//
-//   - The package version is removed so that we don't need to match SCIP
-//     uploads exactly to get a reference count.
-//   - We then hash the simplified symbol name into a fixed-sized block that
-//     can be matched in constant time against other symbols in Postgres.
-func canonicalizeSymbol(symbolName string) ([16]byte, bool) {
-	if symbolName == "" || scip.IsLocalSymbol(symbolName) || strings.HasPrefix(symbolName, skipPrefix) {
-		return emptyChecksum, false
-	}
-
-	symbol, err := noVersionFormatter.Format(symbolName)
-	if err != nil {
-		return emptyChecksum, false
-	}
-
-	return md5.Sum([]byte(symbol)), true
-}
-
-var noVersionFormatter = scip.SymbolFormatter{
-	OnError:               func(err error) error { return err },
-	IncludeScheme:         func(_ string) bool { return true },
-	IncludePackageManager: func(_ string) bool { return true },
-	IncludePackageName:    func(_ string) bool { return true },
-	IncludePackageVersion: func(_ string) bool { return false },
-	IncludeDescriptor:     func(_ string) bool { return true },
-	IncludeRawDescriptor:  func(_ *scip.Descriptor) bool { return true },
-	IncludeDisambiguator:  func(_ string) bool { return true },
-}
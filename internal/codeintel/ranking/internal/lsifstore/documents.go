@@ -0,0 +1,65 @@
+package lsifstore
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// GetDocumentsByPaths returns the SCIP documents of the given upload with the given root-relative
+// paths, keyed by path. Paths that do not exist in the upload are absent from the result.
+func (s *store) GetDocumentsByPaths(ctx context.Context, uploadID int, paths []string) (_ map[string]*scip.Document, err error) {
+	ctx, _, endObservation := s.operations.getDocumentsByPaths.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("uploadID", uploadID),
+		attribute.Int("numPaths", len(paths)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	rows, err := s.db.Query(ctx, sqlf.Sprintf(getDocumentsByPathsQuery, uploadID, pq.Array(paths)))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	documents := make(map[string]*scip.Document, len(paths))
+	for rows.Next() {
+		var path string
+		var compressedSCIPPayload []byte
+		if err := rows.Scan(&path, &compressedSCIPPayload); err != nil {
+			return nil, err
+		}
+
+		scipPayload, err := shared.Decompressor.Decompress(bytes.NewReader(compressedSCIPPayload))
+		if err != nil {
+			return nil, err
+		}
+
+		var document scip.Document
+		if err := proto.Unmarshal(scipPayload, &document); err != nil {
+			return nil, err
+		}
+		documents[path] = &document
+	}
+
+	return documents, nil
+}
+
+const getDocumentsByPathsQuery = `
+SELECT
+	sid.document_path,
+	sd.raw_scip_payload
+FROM codeintel_scip_document_lookup sid
+JOIN codeintel_scip_documents sd ON sd.id = sid.document_id
+WHERE
+	sid.upload_id = %s AND
+	sid.document_path = ANY(%s)
+`
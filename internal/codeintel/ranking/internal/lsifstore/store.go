@@ -16,6 +16,9 @@ type Store interface {
 
 	// Stream
 	InsertDefinitionsAndReferencesForDocument(ctx context.Context, upload shared.ExportedUpload, rankingGraphKey string, rankingBatchSize int, f func(ctx context.Context, upload shared.ExportedUpload, rankingBatchSize int, rankingGraphKey, path string, document *scip.Document) error) error
+
+	// Documents
+	GetDocumentsByPaths(ctx context.Context, uploadID int, paths []string) (map[string]*scip.Document, error)
 }
 
 type SCIPWriter interface {
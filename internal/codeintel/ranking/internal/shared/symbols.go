@@ -0,0 +1,89 @@
+package shared
+
+import (
+	"crypto/md5"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+)
+
+const skipPrefix = "lsif ."
+
+var emptyChecksum = [16]byte{}
+
+// CanonicalizeSymbol transforms a symbol name into an opaque string that
+// can be matched internally by the ranking machinery.
+//
+// Canonicalization of a symbol name for ranking makes two transformations:
+//
+//   - The package version is removed so that we don't need to match SCIP
+//     uploads exactly to get a reference count.
+//   - We then hash the simplified symbol name into a fixed-sized block that
+//     can be matched in constant time against other symbols in Postgres.
+func CanonicalizeSymbol(symbolName string) ([16]byte, bool) {
+	if symbolName == "" || scip.IsLocalSymbol(symbolName) || strings.HasPrefix(symbolName, skipPrefix) {
+		return emptyChecksum, false
+	}
+
+	symbol, err := noVersionFormatter.Format(symbolName)
+	if err != nil {
+		return emptyChecksum, false
+	}
+
+	return md5.Sum([]byte(symbol)), true
+}
+
+var noVersionFormatter = scip.SymbolFormatter{
+	OnError:               func(err error) error { return err },
+	IncludeScheme:         func(_ string) bool { return true },
+	IncludePackageManager: func(_ string) bool { return true },
+	IncludePackageName:    func(_ string) bool { return true },
+	IncludePackageVersion: func(_ string) bool { return false },
+	IncludeDescriptor:     func(_ string) bool { return true },
+	IncludeRawDescriptor:  func(_ *scip.Descriptor) bool { return true },
+	IncludeDisambiguator:  func(_ string) bool { return true },
+}
+
+// IsExportedSymbol returns true if the given symbol can be referenced from outside of the
+// package that defines it. SCIP does not record visibility, so this is inferred from the
+// naming conventions of languages that encode visibility in names (Go and Python). Symbols
+// of other languages are assumed to be exported unless they are local or parameters.
+func IsExportedSymbol(symbolName string) bool {
+	if symbolName == "" || scip.IsLocalSymbol(symbolName) {
+		return false
+	}
+
+	symbol, err := scip.ParseSymbol(symbolName)
+	if err != nil {
+		return false
+	}
+
+	for _, descriptor := range symbol.Descriptors {
+		switch descriptor.Suffix {
+		case scip.Descriptor_Local, scip.Descriptor_Parameter, scip.Descriptor_TypeParameter:
+			return false
+		case scip.Descriptor_Namespace:
+			// Package and module names do not affect visibility
+			continue
+		}
+
+		switch symbol.Scheme {
+		case "scip-go":
+			if r, _ := utf8.DecodeRuneInString(descriptor.Name); !unicode.IsUpper(r) {
+				return false
+			}
+		case "scip-python":
+			if strings.HasPrefix(descriptor.Name, "_") && !isDunder(descriptor.Name) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func isDunder(name string) bool {
+	return len(name) > 4 && strings.HasPrefix(name, "__") && strings.HasSuffix(name, "__")
+}
@@ -0,0 +1,27 @@
+package shared
+
+import "testing"
+
+func TestIsExportedSymbol(t *testing.T) {
+	testCases := map[string]bool{
+		"scip-go gomod github.com/sourcegraph/sourcegraph abc123 `github.com/sourcegraph/sourcegraph/internal/foo`/Bar#":        true,
+		"scip-go gomod github.com/sourcegraph/sourcegraph abc123 `github.com/sourcegraph/sourcegraph/internal/foo`/Bar#Baz().":  true,
+		"scip-go gomod github.com/sourcegraph/sourcegraph abc123 `github.com/sourcegraph/sourcegraph/internal/foo`/bar#":        false,
+		"scip-go gomod github.com/sourcegraph/sourcegraph abc123 `github.com/sourcegraph/sourcegraph/internal/foo`/Bar#baz().":  false,
+		"scip-go gomod github.com/sourcegraph/sourcegraph abc123 `github.com/sourcegraph/sourcegraph/internal/foo`/Bar().(ctx)": false,
+		"scip-python python example 1.0 `example.mod`/Foo#":                                                                     true,
+		"scip-python python example 1.0 `example.mod`/Foo#__init__().":                                                          true,
+		"scip-python python example 1.0 `example.mod`/_Foo#":                                                                    false,
+		"scip-python python example 1.0 `example.mod`/Foo#_helper().":                                                           false,
+		"scip-typescript npm example 1.0 src/`index.ts`/helper().":                                                              true,
+		"scip-typescript npm example 1.0 src/`index.ts`/Foo#[T]":                                                                false,
+		"local 42": false,
+		"":         false,
+	}
+
+	for symbolName, expected := range testCases {
+		if exported := IsExportedSymbol(symbolName); exported != expected {
+			t.Errorf("unexpected result for %q. want=%v have=%v", symbolName, expected, exported)
+		}
+	}
+}
@@ -19,7 +19,7 @@ func (s *store) InsertDefinitionsForRanking(
 	return s.withTransaction(ctx, func(tx *store) error {
 		inserter := func(inserter *batch.Inserter) error {
 			for definition := range definitions {
-				if err := inserter.Insert(ctx, definition.ExportedUploadID, "", derefChecksum(definition.SymbolChecksum), definition.DocumentPath, rankingGraphKey); err != nil {
+				if err := inserter.Insert(ctx, definition.ExportedUploadID, "", derefChecksum(definition.SymbolChecksum), definition.DocumentPath, rankingGraphKey, definition.Exported); err != nil {
 					return err
 				}
 			}
@@ -38,6 +38,7 @@ func (s *store) InsertDefinitionsForRanking(
 				"symbol_checksum",
 				"document_path",
 				"graph_key",
+				"exported",
 			},
 			inserter,
 		); err != nil {
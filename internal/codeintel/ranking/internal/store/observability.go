@@ -32,6 +32,7 @@ type operations struct {
 	vacuumStaleGraphs              *observation.Operation
 	insertPathRanks                *observation.Operation
 	vacuumStaleRanks               *observation.Operation
+	getUnreferencedDefinitions     *observation.Operation
 }
 
 var m = new(metrics.SingletonREDMetrics)
@@ -79,5 +80,6 @@ func newOperations(observationCtx *observation.Context) *operations {
 		vacuumStaleGraphs:              op("VacuumStaleGraphs"),
 		insertPathRanks:                op("InsertPathRanks"),
 		vacuumStaleRanks:               op("VacuumStaleRanks"),
+		getUnreferencedDefinitions:     op("GetUnreferencedDefinitions"),
 	}
 }
@@ -26,6 +26,7 @@ type Store interface {
 	GetReferenceCountStatistics(ctx context.Context) (logmean float64, _ error)
 	CoverageCounts(ctx context.Context, graphKey string) (_ shared.CoverageCounts, err error)
 	LastUpdatedAt(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID]time.Time, error)
+	GetUnreferencedDefinitions(ctx context.Context, graphKey string, opts shared.GetUnreferencedSymbolsOptions) ([]shared.UnreferencedDefinition, int, error)
 
 	// Export uploads (metadata tracking) + cleanup
 	GetUploadsForRanking(ctx context.Context, graphKey, objectPrefix string, batchSize int) ([]uploadsshared.ExportedUpload, error)
@@ -0,0 +1,98 @@
+package store
+
+import (
+	"context"
+	"strings"
+
+	"github.com/keegancsmith/sqlf"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// GetUnreferencedDefinitions returns a page of the definitions exported for the given repository
+// under the given graph key that are not referenced by any other document in any exported upload.
+// Definitions known to be unexported are skipped; definitions exported before visibility was
+// recorded are returned. If a path is supplied, only definitions in documents within that
+// directory are returned.
+func (s *store) GetUnreferencedDefinitions(ctx context.Context, graphKey string, opts shared.GetUnreferencedSymbolsOptions) (_ []shared.UnreferencedDefinition, totalCount int, err error) {
+	ctx, _, endObservation := s.operations.getUnreferencedDefinitions.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("graphKey", graphKey),
+		attribute.Int("repositoryID", opts.RepositoryID),
+		attribute.String("path", opts.Path),
+		attribute.Int("limit", opts.Limit),
+		attribute.Int("offset", opts.Offset),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	conds := []*sqlf.Query{
+		sqlf.Sprintf("rd.graph_key = %s", graphKey),
+		sqlf.Sprintf("u.repository_id = %s", opts.RepositoryID),
+		sqlf.Sprintf("rd.exported IS NOT FALSE"),
+	}
+	if path := strings.Trim(opts.Path, "/"); path != "" {
+		conds = append(conds, sqlf.Sprintf("starts_with(rd.document_path, %s)", path+"/"))
+	}
+
+	var definitions []shared.UnreferencedDefinition
+	scanner := func(s dbutil.Scanner) (bool, error) {
+		var definition shared.UnreferencedDefinition
+		var symbolChecksum []byte
+		if err := s.Scan(
+			&definition.UploadID,
+			&definition.Commit,
+			&definition.Root,
+			&definition.DocumentPath,
+			&symbolChecksum,
+			&totalCount,
+		); err != nil {
+			return false, err
+		}
+
+		copy(definition.SymbolChecksum[:], symbolChecksum)
+		definitions = append(definitions, definition)
+		return true, nil
+	}
+
+	if err := basestore.NewCallbackScanner(scanner)(s.db.Query(ctx, sqlf.Sprintf(
+		getUnreferencedDefinitionsQuery,
+		sqlf.Join(conds, " AND "),
+		opts.Limit,
+		opts.Offset,
+	))); err != nil {
+		return nil, 0, err
+	}
+
+	return definitions, totalCount, nil
+}
+
+const getUnreferencedDefinitionsQuery = `
+SELECT
+	u.id,
+	u.commit,
+	u.root,
+	rd.document_path,
+	rd.symbol_checksum,
+	COUNT(*) OVER () AS total_count
+FROM codeintel_ranking_definitions rd
+JOIN codeintel_ranking_exports cre ON cre.id = rd.exported_upload_id
+JOIN lsif_uploads u ON u.id = cre.upload_id
+WHERE
+	%s AND
+	cre.deleted_at IS NULL AND
+	NOT EXISTS (
+		SELECT 1
+		FROM codeintel_ranking_references rr
+		JOIN codeintel_ranking_exports rcre ON rcre.id = rr.exported_upload_id
+		WHERE
+			rr.graph_key = rd.graph_key AND
+			rr.symbol_checksums @> ARRAY[rd.symbol_checksum] AND
+			rcre.deleted_at IS NULL
+	)
+ORDER BY rd.document_path, rd.id
+LIMIT %s
+OFFSET %s
+`
@@ -0,0 +1,161 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/shared"
+	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestGetUnreferencedDefinitions(t *testing.T) {
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	store := New(&observation.TestContext, db)
+
+	// Insert uploads
+	insertUploads(t, db,
+		uploadsshared.Upload{ID: 4, RepositoryID: 50, Root: "lib/"},
+		uploadsshared.Upload{ID: 5, RepositoryID: 51},
+		uploadsshared.Upload{ID: 6, RepositoryID: 52},
+	)
+
+	// Insert exported uploads (the export of upload 6 is stale)
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO codeintel_ranking_exports (id, upload_id, graph_key, upload_key, deleted_at)
+		VALUES
+			(104, 4, $1, md5('key-4'), NULL),
+			(105, 5, $1, md5('key-5'), NULL),
+			(106, 6, $1, md5('key-6'), NOW())
+	`,
+		mockRankingGraphKey,
+	); err != nil {
+		t.Fatalf("unexpected error inserting exported upload record: %s", err)
+	}
+
+	// Insert definitions
+	definitions := make(chan shared.RankingDefinitions, 7)
+	definitions <- shared.RankingDefinitions{UploadID: 4, ExportedUploadID: 104, SymbolChecksum: hash("foo"), DocumentPath: "lib/foo.go", Exported: true}
+	definitions <- shared.RankingDefinitions{UploadID: 4, ExportedUploadID: 104, SymbolChecksum: hash("bar"), DocumentPath: "lib/foo.go", Exported: true}
+	definitions <- shared.RankingDefinitions{UploadID: 4, ExportedUploadID: 104, SymbolChecksum: hash("private"), DocumentPath: "lib/foo.go", Exported: false}
+	definitions <- shared.RankingDefinitions{UploadID: 4, ExportedUploadID: 104, SymbolChecksum: hash("baz"), DocumentPath: "lib/sub/baz.go", Exported: true}
+	definitions <- shared.RankingDefinitions{UploadID: 4, ExportedUploadID: 104, SymbolChecksum: hash("bonk"), DocumentPath: "lib/sub/bonk.go", Exported: true}
+	definitions <- shared.RankingDefinitions{UploadID: 4, ExportedUploadID: 104, SymbolChecksum: hash("wild"), DocumentPath: "lib/sub_x/wild.go", Exported: true}
+	definitions <- shared.RankingDefinitions{UploadID: 5, ExportedUploadID: 105, SymbolChecksum: hash("quux"), DocumentPath: "quux.go", Exported: true}
+	close(definitions)
+	if err := store.InsertDefinitionsForRanking(ctx, mockRankingGraphKey, definitions); err != nil {
+		t.Fatalf("unexpected error inserting definitions: %s", err)
+	}
+
+	// Definitions exported before visibility was recorded are treated as exported
+	legacyChecksum := hash("legacy")
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO codeintel_ranking_definitions (exported_upload_id, symbol_name, symbol_checksum, document_path, graph_key, exported)
+		VALUES (104, '', $1, 'lib/legacy.go', $2, NULL)
+	`,
+		legacyChecksum[:],
+		mockRankingGraphKey,
+	); err != nil {
+		t.Fatalf("unexpected error inserting legacy definition: %s", err)
+	}
+
+	// Insert references: foo is referenced by another repository; bonk is referenced
+	// only by a stale export and is therefore considered unreferenced
+	for exportedUploadID, checksums := range map[int][][16]byte{
+		105: {hash("foo")},
+		106: {hash("bonk")},
+	} {
+		references := make(chan [16]byte, len(checksums))
+		for _, checksum := range checksums {
+			references <- checksum
+		}
+		close(references)
+
+		if err := store.InsertReferencesForRanking(ctx, mockRankingGraphKey, mockRankingBatchSize, exportedUploadID, references); err != nil {
+			t.Fatalf("unexpected error inserting references: %s", err)
+		}
+	}
+
+	unreferenced := func(symbolName, documentPath string) shared.UnreferencedDefinition {
+		return shared.UnreferencedDefinition{
+			UploadID:       4,
+			Commit:         makeCommit(4),
+			Root:           "lib/",
+			DocumentPath:   documentPath,
+			SymbolChecksum: hash(symbolName),
+		}
+	}
+
+	testCases := []struct {
+		name                string
+		opts                shared.GetUnreferencedSymbolsOptions
+		expectedDefinitions []shared.UnreferencedDefinition
+		expectedTotalCount  int
+	}{
+		{
+			name: "repository",
+			opts: shared.GetUnreferencedSymbolsOptions{RepositoryID: 50, Limit: 10},
+			expectedDefinitions: []shared.UnreferencedDefinition{
+				unreferenced("bar", "lib/foo.go"),
+				unreferenced("legacy", "lib/legacy.go"),
+				unreferenced("baz", "lib/sub/baz.go"),
+				unreferenced("bonk", "lib/sub/bonk.go"),
+				unreferenced("wild", "lib/sub_x/wild.go"),
+			},
+			expectedTotalCount: 5,
+		},
+		{
+			name: "directory",
+			opts: shared.GetUnreferencedSymbolsOptions{RepositoryID: 50, Path: "lib/sub/", Limit: 10},
+			expectedDefinitions: []shared.UnreferencedDefinition{
+				unreferenced("baz", "lib/sub/baz.go"),
+				unreferenced("bonk", "lib/sub/bonk.go"),
+			},
+			expectedTotalCount: 2,
+		},
+		{
+			name: "directory with pattern characters",
+			opts: shared.GetUnreferencedSymbolsOptions{RepositoryID: 50, Path: "lib/sub_x", Limit: 10},
+			expectedDefinitions: []shared.UnreferencedDefinition{
+				unreferenced("wild", "lib/sub_x/wild.go"),
+			},
+			expectedTotalCount: 1,
+		},
+		{
+			name:                "directory matched literally",
+			opts:                shared.GetUnreferencedSymbolsOptions{RepositoryID: 50, Path: "lib/su%", Limit: 10},
+			expectedDefinitions: nil,
+			expectedTotalCount:  0,
+		},
+		{
+			name: "paginated",
+			opts: shared.GetUnreferencedSymbolsOptions{RepositoryID: 50, Limit: 1, Offset: 2},
+			expectedDefinitions: []shared.UnreferencedDefinition{
+				unreferenced("baz", "lib/sub/baz.go"),
+			},
+			expectedTotalCount: 5,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			definitions, totalCount, err := store.GetUnreferencedDefinitions(ctx, mockRankingGraphKey, testCase.opts)
+			if err != nil {
+				t.Fatalf("unexpected error getting unreferenced definitions: %s", err)
+			}
+			if totalCount != testCase.expectedTotalCount {
+				t.Errorf("unexpected total count. want=%d have=%d", testCase.expectedTotalCount, totalCount)
+			}
+			if diff := cmp.Diff(testCase.expectedDefinitions, definitions); diff != "" {
+				t.Errorf("unexpected definitions (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
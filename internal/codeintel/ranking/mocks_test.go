@@ -11,7 +11,9 @@ import (
 	"sync"
 	"time"
 
+	scip "github.com/sourcegraph/scip/bindings/go/scip"
 	api "github.com/sourcegraph/sourcegraph/internal/api"
+	lsifstore "github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/lsifstore"
 	store "github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/store"
 	shared "github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/shared"
 	shared1 "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
@@ -49,6 +51,10 @@ type MockStore struct {
 	// GetStarRankFunc is an instance of a mock function object controlling
 	// the behavior of the method GetStarRank.
 	GetStarRankFunc *StoreGetStarRankFunc
+	// GetUnreferencedDefinitionsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetUnreferencedDefinitions.
+	GetUnreferencedDefinitionsFunc *StoreGetUnreferencedDefinitionsFunc
 	// GetUploadsForRankingFunc is an instance of a mock function object
 	// controlling the behavior of the method GetUploadsForRanking.
 	GetUploadsForRankingFunc *StoreGetUploadsForRankingFunc
@@ -153,6 +159,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetUnreferencedDefinitionsFunc: &StoreGetUnreferencedDefinitionsFunc{
+			defaultHook: func(context.Context, string, shared.GetUnreferencedSymbolsOptions) (r0 []shared.UnreferencedDefinition, r1 int, r2 error) {
+				return
+			},
+		},
 		GetUploadsForRankingFunc: &StoreGetUploadsForRankingFunc{
 			defaultHook: func(context.Context, string, string, int) (r0 []shared1.ExportedUpload, r1 error) {
 				return
@@ -285,6 +296,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetStarRank")
 			},
 		},
+		GetUnreferencedDefinitionsFunc: &StoreGetUnreferencedDefinitionsFunc{
+			defaultHook: func(context.Context, string, shared.GetUnreferencedSymbolsOptions) ([]shared.UnreferencedDefinition, int, error) {
+				panic("unexpected invocation of MockStore.GetUnreferencedDefinitions")
+			},
+		},
 		GetUploadsForRankingFunc: &StoreGetUploadsForRankingFunc{
 			defaultHook: func(context.Context, string, string, int) ([]shared1.ExportedUpload, error) {
 				panic("unexpected invocation of MockStore.GetUploadsForRanking")
@@ -401,6 +417,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetStarRankFunc: &StoreGetStarRankFunc{
 			defaultHook: i.GetStarRank,
 		},
+		GetUnreferencedDefinitionsFunc: &StoreGetUnreferencedDefinitionsFunc{
+			defaultHook: i.GetUnreferencedDefinitions,
+		},
 		GetUploadsForRankingFunc: &StoreGetUploadsForRankingFunc{
 			defaultHook: i.GetUploadsForRanking,
 		},
@@ -1312,6 +1331,123 @@ func (c StoreGetStarRankFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreGetUnreferencedDefinitionsFunc describes the behavior when the
+// GetUnreferencedDefinitions method of the parent MockStore instance is
+// invoked.
+type StoreGetUnreferencedDefinitionsFunc struct {
+	defaultHook func(context.Context, string, shared.GetUnreferencedSymbolsOptions) ([]shared.UnreferencedDefinition, int, error)
+	hooks       []func(context.Context, string, shared.GetUnreferencedSymbolsOptions) ([]shared.UnreferencedDefinition, int, error)
+	history     []StoreGetUnreferencedDefinitionsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUnreferencedDefinitions delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) GetUnreferencedDefinitions(v0 context.Context, v1 string, v2 shared.GetUnreferencedSymbolsOptions) ([]shared.UnreferencedDefinition, int, error) {
+	r0, r1, r2 := m.GetUnreferencedDefinitionsFunc.nextHook()(v0, v1, v2)
+	m.GetUnreferencedDefinitionsFunc.appendCall(StoreGetUnreferencedDefinitionsFuncCall{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetUnreferencedDefinitions method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreGetUnreferencedDefinitionsFunc) SetDefaultHook(hook func(context.Context, string, shared.GetUnreferencedSymbolsOptions) ([]shared.UnreferencedDefinition, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUnreferencedDefinitions method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreGetUnreferencedDefinitionsFunc) PushHook(hook func(context.Context, string, shared.GetUnreferencedSymbolsOptions) ([]shared.UnreferencedDefinition, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetUnreferencedDefinitionsFunc) SetDefaultReturn(r0 []shared.UnreferencedDefinition, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, string, shared.GetUnreferencedSymbolsOptions) ([]shared.UnreferencedDefinition, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetUnreferencedDefinitionsFunc) PushReturn(r0 []shared.UnreferencedDefinition, r1 int, r2 error) {
+	f.PushHook(func(context.Context, string, shared.GetUnreferencedSymbolsOptions) ([]shared.UnreferencedDefinition, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreGetUnreferencedDefinitionsFunc) nextHook() func(context.Context, string, shared.GetUnreferencedSymbolsOptions) ([]shared.UnreferencedDefinition, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetUnreferencedDefinitionsFunc) appendCall(r0 StoreGetUnreferencedDefinitionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetUnreferencedDefinitionsFuncCall
+// objects describing the invocations of this function.
+func (f *StoreGetUnreferencedDefinitionsFunc) History() []StoreGetUnreferencedDefinitionsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetUnreferencedDefinitionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetUnreferencedDefinitionsFuncCall is an object that describes an
+// invocation of method GetUnreferencedDefinitions on an instance of
+// MockStore.
+type StoreGetUnreferencedDefinitionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 shared.GetUnreferencedSymbolsOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.UnreferencedDefinition
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetUnreferencedDefinitionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetUnreferencedDefinitionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // StoreGetUploadsForRankingFunc describes the behavior when the
 // GetUploadsForRanking method of the parent MockStore instance is invoked.
 type StoreGetUploadsForRankingFunc struct {
@@ -3216,6 +3352,420 @@ func (c StoreWithTransactionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// MockLSIFStore is a mock implementation of the Store interface (from the
+// package
+// github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/lsifstore)
+// used for unit testing.
+type MockLSIFStore struct {
+	// GetDocumentsByPathsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetDocumentsByPaths.
+	GetDocumentsByPathsFunc *LSIFStoreGetDocumentsByPathsFunc
+	// InsertDefinitionsAndReferencesForDocumentFunc is an instance of a
+	// mock function object controlling the behavior of the method
+	// InsertDefinitionsAndReferencesForDocument.
+	InsertDefinitionsAndReferencesForDocumentFunc *LSIFStoreInsertDefinitionsAndReferencesForDocumentFunc
+	// WithTransactionFunc is an instance of a mock function object
+	// controlling the behavior of the method WithTransaction.
+	WithTransactionFunc *LSIFStoreWithTransactionFunc
+}
+
+// NewMockLSIFStore creates a new mock of the Store interface. All methods
+// return zero values for all results, unless overwritten.
+func NewMockLSIFStore() *MockLSIFStore {
+	return &MockLSIFStore{
+		GetDocumentsByPathsFunc: &LSIFStoreGetDocumentsByPathsFunc{
+			defaultHook: func(context.Context, int, []string) (r0 map[string]*scip.Document, r1 error) {
+				return
+			},
+		},
+		InsertDefinitionsAndReferencesForDocumentFunc: &LSIFStoreInsertDefinitionsAndReferencesForDocumentFunc{
+			defaultHook: func(context.Context, shared1.ExportedUpload, string, int, func(ctx context.Context, upload shared1.ExportedUpload, rankingBatchSize int, rankingGraphKey string, path string, document *scip.Document) error) (r0 error) {
+				return
+			},
+		},
+		WithTransactionFunc: &LSIFStoreWithTransactionFunc{
+			defaultHook: func(context.Context, func(tx lsifstore.Store) error) (r0 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockLSIFStore creates a new mock of the Store interface. All
+// methods panic on invocation, unless overwritten.
+func NewStrictMockLSIFStore() *MockLSIFStore {
+	return &MockLSIFStore{
+		GetDocumentsByPathsFunc: &LSIFStoreGetDocumentsByPathsFunc{
+			defaultHook: func(context.Context, int, []string) (map[string]*scip.Document, error) {
+				panic("unexpected invocation of MockLSIFStore.GetDocumentsByPaths")
+			},
+		},
+		InsertDefinitionsAndReferencesForDocumentFunc: &LSIFStoreInsertDefinitionsAndReferencesForDocumentFunc{
+			defaultHook: func(context.Context, shared1.ExportedUpload, string, int, func(ctx context.Context, upload shared1.ExportedUpload, rankingBatchSize int, rankingGraphKey string, path string, document *scip.Document) error) error {
+				panic("unexpected invocation of MockLSIFStore.InsertDefinitionsAndReferencesForDocument")
+			},
+		},
+		WithTransactionFunc: &LSIFStoreWithTransactionFunc{
+			defaultHook: func(context.Context, func(tx lsifstore.Store) error) error {
+				panic("unexpected invocation of MockLSIFStore.WithTransaction")
+			},
+		},
+	}
+}
+
+// NewMockLSIFStoreFrom creates a new mock of the MockLSIFStore interface.
+// All methods delegate to the given implementation, unless overwritten.
+func NewMockLSIFStoreFrom(i lsifstore.Store) *MockLSIFStore {
+	return &MockLSIFStore{
+		GetDocumentsByPathsFunc: &LSIFStoreGetDocumentsByPathsFunc{
+			defaultHook: i.GetDocumentsByPaths,
+		},
+		InsertDefinitionsAndReferencesForDocumentFunc: &LSIFStoreInsertDefinitionsAndReferencesForDocumentFunc{
+			defaultHook: i.InsertDefinitionsAndReferencesForDocument,
+		},
+		WithTransactionFunc: &LSIFStoreWithTransactionFunc{
+			defaultHook: i.WithTransaction,
+		},
+	}
+}
+
+// LSIFStoreGetDocumentsByPathsFunc describes the behavior when the
+// GetDocumentsByPaths method of the parent MockLSIFStore instance is
+// invoked.
+type LSIFStoreGetDocumentsByPathsFunc struct {
+	defaultHook func(context.Context, int, []string) (map[string]*scip.Document, error)
+	hooks       []func(context.Context, int, []string) (map[string]*scip.Document, error)
+	history     []LSIFStoreGetDocumentsByPathsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetDocumentsByPaths delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) GetDocumentsByPaths(v0 context.Context, v1 int, v2 []string) (map[string]*scip.Document, error) {
+	r0, r1 := m.GetDocumentsByPathsFunc.nextHook()(v0, v1, v2)
+	m.GetDocumentsByPathsFunc.appendCall(LSIFStoreGetDocumentsByPathsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetDocumentsByPaths
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreGetDocumentsByPathsFunc) SetDefaultHook(hook func(context.Context, int, []string) (map[string]*scip.Document, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetDocumentsByPaths method of the parent MockLSIFStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *LSIFStoreGetDocumentsByPathsFunc) PushHook(hook func(context.Context, int, []string) (map[string]*scip.Document, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreGetDocumentsByPathsFunc) SetDefaultReturn(r0 map[string]*scip.Document, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, []string) (map[string]*scip.Document, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreGetDocumentsByPathsFunc) PushReturn(r0 map[string]*scip.Document, r1 error) {
+	f.PushHook(func(context.Context, int, []string) (map[string]*scip.Document, error) {
+		return r0, r1
+	})
+}
+
+func (f *LSIFStoreGetDocumentsByPathsFunc) nextHook() func(context.Context, int, []string) (map[string]*scip.Document, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreGetDocumentsByPathsFunc) appendCall(r0 LSIFStoreGetDocumentsByPathsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreGetDocumentsByPathsFuncCall
+// objects describing the invocations of this function.
+func (f *LSIFStoreGetDocumentsByPathsFunc) History() []LSIFStoreGetDocumentsByPathsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreGetDocumentsByPathsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreGetDocumentsByPathsFuncCall is an object that describes an
+// invocation of method GetDocumentsByPaths on an instance of MockLSIFStore.
+type LSIFStoreGetDocumentsByPathsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string]*scip.Document
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreGetDocumentsByPathsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreGetDocumentsByPathsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// LSIFStoreInsertDefinitionsAndReferencesForDocumentFunc describes the
+// behavior when the InsertDefinitionsAndReferencesForDocument method of the
+// parent MockLSIFStore instance is invoked.
+type LSIFStoreInsertDefinitionsAndReferencesForDocumentFunc struct {
+	defaultHook func(context.Context, shared1.ExportedUpload, string, int, func(ctx context.Context, upload shared1.ExportedUpload, rankingBatchSize int, rankingGraphKey string, path string, document *scip.Document) error) error
+	hooks       []func(context.Context, shared1.ExportedUpload, string, int, func(ctx context.Context, upload shared1.ExportedUpload, rankingBatchSize int, rankingGraphKey string, path string, document *scip.Document) error) error
+	history     []LSIFStoreInsertDefinitionsAndReferencesForDocumentFuncCall
+	mutex       sync.Mutex
+}
+
+// InsertDefinitionsAndReferencesForDocument delegates to the next hook
+// function in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockLSIFStore) InsertDefinitionsAndReferencesForDocument(v0 context.Context, v1 shared1.ExportedUpload, v2 string, v3 int, v4 func(ctx context.Context, upload shared1.ExportedUpload, rankingBatchSize int, rankingGraphKey string, path string, document *scip.Document) error) error {
+	r0 := m.InsertDefinitionsAndReferencesForDocumentFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.InsertDefinitionsAndReferencesForDocumentFunc.appendCall(LSIFStoreInsertDefinitionsAndReferencesForDocumentFuncCall{v0, v1, v2, v3, v4, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// InsertDefinitionsAndReferencesForDocument method of the parent
+// MockLSIFStore instance is invoked and the hook queue is empty.
+func (f *LSIFStoreInsertDefinitionsAndReferencesForDocumentFunc) SetDefaultHook(hook func(context.Context, shared1.ExportedUpload, string, int, func(ctx context.Context, upload shared1.ExportedUpload, rankingBatchSize int, rankingGraphKey string, path string, document *scip.Document) error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InsertDefinitionsAndReferencesForDocument method of the parent
+// MockLSIFStore instance invokes the hook at the front of the queue and
+// discards it. After the queue is empty, the default hook function is
+// invoked for any future action.
+func (f *LSIFStoreInsertDefinitionsAndReferencesForDocumentFunc) PushHook(hook func(context.Context, shared1.ExportedUpload, string, int, func(ctx context.Context, upload shared1.ExportedUpload, rankingBatchSize int, rankingGraphKey string, path string, document *scip.Document) error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreInsertDefinitionsAndReferencesForDocumentFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, shared1.ExportedUpload, string, int, func(ctx context.Context, upload shared1.ExportedUpload, rankingBatchSize int, rankingGraphKey string, path string, document *scip.Document) error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreInsertDefinitionsAndReferencesForDocumentFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, shared1.ExportedUpload, string, int, func(ctx context.Context, upload shared1.ExportedUpload, rankingBatchSize int, rankingGraphKey string, path string, document *scip.Document) error) error {
+		return r0
+	})
+}
+
+func (f *LSIFStoreInsertDefinitionsAndReferencesForDocumentFunc) nextHook() func(context.Context, shared1.ExportedUpload, string, int, func(ctx context.Context, upload shared1.ExportedUpload, rankingBatchSize int, rankingGraphKey string, path string, document *scip.Document) error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreInsertDefinitionsAndReferencesForDocumentFunc) appendCall(r0 LSIFStoreInsertDefinitionsAndReferencesForDocumentFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// LSIFStoreInsertDefinitionsAndReferencesForDocumentFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreInsertDefinitionsAndReferencesForDocumentFunc) History() []LSIFStoreInsertDefinitionsAndReferencesForDocumentFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreInsertDefinitionsAndReferencesForDocumentFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreInsertDefinitionsAndReferencesForDocumentFuncCall is an object
+// that describes an invocation of method
+// InsertDefinitionsAndReferencesForDocument on an instance of
+// MockLSIFStore.
+type LSIFStoreInsertDefinitionsAndReferencesForDocumentFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 shared1.ExportedUpload
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 func(ctx context.Context, upload shared1.ExportedUpload, rankingBatchSize int, rankingGraphKey string, path string, document *scip.Document) error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreInsertDefinitionsAndReferencesForDocumentFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreInsertDefinitionsAndReferencesForDocumentFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// LSIFStoreWithTransactionFunc describes the behavior when the
+// WithTransaction method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreWithTransactionFunc struct {
+	defaultHook func(context.Context, func(tx lsifstore.Store) error) error
+	hooks       []func(context.Context, func(tx lsifstore.Store) error) error
+	history     []LSIFStoreWithTransactionFuncCall
+	mutex       sync.Mutex
+}
+
+// WithTransaction delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) WithTransaction(v0 context.Context, v1 func(tx lsifstore.Store) error) error {
+	r0 := m.WithTransactionFunc.nextHook()(v0, v1)
+	m.WithTransactionFunc.appendCall(LSIFStoreWithTransactionFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the WithTransaction
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreWithTransactionFunc) SetDefaultHook(hook func(context.Context, func(tx lsifstore.Store) error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// WithTransaction method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreWithTransactionFunc) PushHook(hook func(context.Context, func(tx lsifstore.Store) error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreWithTransactionFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, func(tx lsifstore.Store) error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreWithTransactionFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, func(tx lsifstore.Store) error) error {
+		return r0
+	})
+}
+
+func (f *LSIFStoreWithTransactionFunc) nextHook() func(context.Context, func(tx lsifstore.Store) error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreWithTransactionFunc) appendCall(r0 LSIFStoreWithTransactionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreWithTransactionFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreWithTransactionFunc) History() []LSIFStoreWithTransactionFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreWithTransactionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreWithTransactionFuncCall is an object that describes an
+// invocation of method WithTransaction on an instance of MockLSIFStore.
+type LSIFStoreWithTransactionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 func(tx lsifstore.Store) error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreWithTransactionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreWithTransactionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // MockSiteConfigQuerier is a mock implementation of the SiteConfigQuerier
 // interface (from the package
 // github.com/sourcegraph/sourcegraph/internal/conf/conftypes) used for unit
@@ -3,9 +3,12 @@ package ranking
 import (
 	"context"
 	"math"
+	"strings"
 	"time"
 
 	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/lsifstore"
@@ -162,3 +165,78 @@ func (s *Service) NextJobStartsAt(ctx context.Context) (time.Time, bool, error)
 
 	return expr.Next(previous), true, nil
 }
+
+// GetUnreferencedSymbols returns a page of the symbols defined in the given repository (optionally
+// restricted to a directory) which are not referenced from any other document in any repository.
+// Reference counts are determined from the SCIP data most recently exported for ranking, so only
+// non-local symbols of uploads visible to the ranking calculation are considered.
+func (s *Service) GetUnreferencedSymbols(ctx context.Context, opts shared.GetUnreferencedSymbolsOptions) (_ []shared.UnreferencedSymbol, totalCount int, err error) {
+	ctx, _, endObservation := s.operations.getUnreferencedSymbols.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("repositoryID", opts.RepositoryID),
+		attribute.String("path", opts.Path),
+		attribute.Int("limit", opts.Limit),
+		attribute.Int("offset", opts.Offset),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	definitions, totalCount, err := s.store.GetUnreferencedDefinitions(ctx, internalshared.GraphKey(), opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Ranking definitions only record a checksum of each symbol name. Load the SCIP documents
+	// containing the definitions so that we can recover each symbol's name and location.
+	pathsByUploadID := map[int][]string{}
+	for _, definition := range definitions {
+		pathsByUploadID[definition.UploadID] = append(pathsByUploadID[definition.UploadID], relativeDocumentPath(definition))
+	}
+
+	documentsByUploadID := make(map[int]map[string]*scip.Document, len(pathsByUploadID))
+	for uploadID, paths := range pathsByUploadID {
+		documents, err := s.lsifstore.GetDocumentsByPaths(ctx, uploadID, paths)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		documentsByUploadID[uploadID] = documents
+	}
+
+	symbols := make([]shared.UnreferencedSymbol, 0, len(definitions))
+	for _, definition := range definitions {
+		symbol := shared.UnreferencedSymbol{
+			UploadID:     definition.UploadID,
+			Commit:       definition.Commit,
+			DocumentPath: definition.DocumentPath,
+		}
+
+		if document, ok := documentsByUploadID[definition.UploadID][relativeDocumentPath(definition)]; ok {
+			symbol.Symbol, symbol.Range = findDefinition(document, definition.SymbolChecksum)
+		}
+
+		symbols = append(symbols, symbol)
+	}
+
+	return symbols, totalCount, nil
+}
+
+// relativeDocumentPath returns the path of the given definition's document relative to the root
+// of its upload. Ranking definitions are recorded with repository-relative paths.
+func relativeDocumentPath(definition shared.UnreferencedDefinition) string {
+	return strings.TrimPrefix(definition.DocumentPath, definition.Root)
+}
+
+// findDefinition returns the name and range of the first symbol defined in the given document
+// whose canonical form matches the given checksum.
+func findDefinition(document *scip.Document, checksum [16]byte) (string, *scip.Range) {
+	for _, occurrence := range document.Occurrences {
+		if !scip.SymbolRole_Definition.Matches(occurrence) {
+			continue
+		}
+
+		if occurrenceChecksum, ok := internalshared.CanonicalizeSymbol(occurrence.Symbol); ok && occurrenceChecksum == checksum {
+			return occurrence.Symbol, scip.NewRange(occurrence.Range)
+		}
+	}
+
+	return "", nil
+}
@@ -5,6 +5,11 @@ import (
 	"math"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	internalshared "github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/shared"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/shared"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/schema"
@@ -67,3 +72,65 @@ const epsilon = 0.00000001
 func cmpFloat(x, y float64) bool {
 	return math.Abs(x-y) < epsilon
 }
+
+func TestGetUnreferencedSymbols(t *testing.T) {
+	ctx := context.Background()
+	mockStore := NewMockStore()
+	mockLSIFStore := NewMockLSIFStore()
+	svc := newService(&observation.TestContext, mockStore, mockLSIFStore, conf.DefaultClient())
+
+	const (
+		fooSymbol = "scip-go gomod github.com/example/lib v1.2.3 lib/Foo()."
+		barSymbol = "scip-go gomod github.com/example/lib v1.2.3 lib/Bar()."
+	)
+	checksum := func(symbol string) [16]byte {
+		c, _ := internalshared.CanonicalizeSymbol(symbol)
+		return c
+	}
+
+	mockStore.GetUnreferencedDefinitionsFunc.SetDefaultReturn([]shared.UnreferencedDefinition{
+		{UploadID: 42, Commit: "deadbeef", Root: "lib/", DocumentPath: "lib/foo.go", SymbolChecksum: checksum(fooSymbol)},
+		{UploadID: 42, Commit: "deadbeef", Root: "lib/", DocumentPath: "lib/missing.go", SymbolChecksum: checksum(barSymbol)},
+	}, 7, nil)
+	mockLSIFStore.GetDocumentsByPathsFunc.SetDefaultReturn(map[string]*scip.Document{
+		"foo.go": {
+			RelativePath: "foo.go",
+			Occurrences: []*scip.Occurrence{
+				{Range: []int32{3, 5, 8}, Symbol: fooSymbol},
+				{Range: []int32{10, 5, 8}, Symbol: fooSymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+			},
+		},
+	}, nil)
+
+	symbols, totalCount, err := svc.GetUnreferencedSymbols(ctx, shared.GetUnreferencedSymbolsOptions{RepositoryID: 50, Path: "lib", Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error getting unreferenced symbols: %s", err)
+	}
+	if totalCount != 7 {
+		t.Errorf("unexpected total count. want=%d have=%d", 7, totalCount)
+	}
+
+	expectedSymbols := []shared.UnreferencedSymbol{
+		{
+			UploadID:     42,
+			Commit:       "deadbeef",
+			DocumentPath: "lib/foo.go",
+			Symbol:       fooSymbol,
+			Range:        &scip.Range{Start: scip.Position{Line: 10, Character: 5}, End: scip.Position{Line: 10, Character: 8}},
+		},
+		{
+			UploadID:     42,
+			Commit:       "deadbeef",
+			DocumentPath: "lib/missing.go",
+		},
+	}
+	if diff := cmp.Diff(expectedSymbols, symbols); diff != "" {
+		t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+	}
+
+	if calls := mockLSIFStore.GetDocumentsByPathsFunc.History(); len(calls) != 1 {
+		t.Fatalf("unexpected number of GetDocumentsByPaths calls. want=%d have=%d", 1, len(calls))
+	} else if diff := cmp.Diff([]string{"foo.go", "missing.go"}, calls[0].Arg2); diff != "" {
+		t.Errorf("unexpected paths (-want +got):\n%s", diff)
+	}
+}
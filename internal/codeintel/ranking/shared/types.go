@@ -1,6 +1,10 @@
 package shared
 
-import "time"
+import (
+	"time"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+)
 
 type Summary struct {
 	GraphKey                string
@@ -28,6 +32,7 @@ type RankingDefinitions struct {
 	ExportedUploadID int
 	SymbolChecksum   [16]byte
 	DocumentPath     string
+	Exported         bool
 }
 
 type RankingReferences struct {
@@ -35,3 +40,31 @@ type RankingReferences struct {
 	ExportedUploadID int
 	SymbolChecksums  [][16]byte
 }
+
+// UnreferencedDefinition is a symbol defined in a document of an exported upload that
+// is not referenced by any exported upload.
+type UnreferencedDefinition struct {
+	UploadID       int
+	Commit         string
+	Root           string
+	DocumentPath   string
+	SymbolChecksum [16]byte
+}
+
+// UnreferencedSymbol is an unreferenced definition resolved against the SCIP data of
+// its upload. The symbol name and range are empty if the definition could not be found
+// in the upload's SCIP data.
+type UnreferencedSymbol struct {
+	UploadID     int
+	Commit       string
+	DocumentPath string
+	Symbol       string
+	Range        *scip.Range
+}
+
+type GetUnreferencedSymbolsOptions struct {
+	RepositoryID int
+	Path         string
+	Limit        int
+	Offset       int
+}
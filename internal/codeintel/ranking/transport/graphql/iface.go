@@ -14,4 +14,5 @@ type RankingService interface {
 	NextJobStartsAt(ctx context.Context) (time.Time, bool, error)
 	CoverageCounts(ctx context.Context, graphKey string) (shared.CoverageCounts, error)
 	DeleteRankingProgress(ctx context.Context, graphKey string) error
+	GetUnreferencedSymbols(ctx context.Context, opts shared.GetUnreferencedSymbolsOptions) ([]shared.UnreferencedSymbol, int, error)
 }
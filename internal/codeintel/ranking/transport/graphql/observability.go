@@ -11,6 +11,7 @@ type operations struct {
 	rankingSummary         *observation.Operation
 	bumpDerivativeGraphKey *observation.Operation
 	deleteRankingProgress  *observation.Operation
+	unreferencedSymbols    *observation.Operation
 }
 
 func newOperations(observationCtx *observation.Context) *operations {
@@ -33,5 +34,6 @@ func newOperations(observationCtx *observation.Context) *operations {
 		rankingSummary:         op("RankingSummary"),
 		bumpDerivativeGraphKey: op("BumpDerivativeGraphKey"),
 		deleteRankingProgress:  op("DeleteRankingProgress"),
+		unreferencedSymbols:    op("UnreferencedSymbols"),
 	}
 }
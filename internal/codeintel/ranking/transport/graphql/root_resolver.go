@@ -9,6 +9,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/shared"
 	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
 	sharedresolvers "github.com/sourcegraph/sourcegraph/internal/codeintel/shared/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
@@ -16,6 +17,7 @@ import (
 type rootResolver struct {
 	rankingSvc       RankingService
 	siteAdminChecker sharedresolvers.SiteAdminChecker
+	repoStore        database.RepoStore
 	operations       *operations
 }
 
@@ -23,10 +25,12 @@ func NewRootResolver(
 	observationCtx *observation.Context,
 	rankingSvc *ranking.Service,
 	siteAdminChecker sharedresolvers.SiteAdminChecker,
+	repoStore database.RepoStore,
 ) resolverstubs.RankingServiceResolver {
 	return &rootResolver{
 		rankingSvc:       rankingSvc,
 		siteAdminChecker: siteAdminChecker,
+		repoStore:        repoStore,
 		operations:       newOperations(observationCtx),
 	}
 }
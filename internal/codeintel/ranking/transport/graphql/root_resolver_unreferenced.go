@@ -0,0 +1,97 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/shared"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+// 🚨 SECURITY: The repository is loaded through the repo store, which enforces repository permissions.
+func (r *rootResolver) UnreferencedSymbols(ctx context.Context, args *resolverstubs.UnreferencedSymbolsArgs) (_ resolverstubs.UnreferencedSymbolConnectionResolver, err error) {
+	ctx, _, endObservation := r.operations.unreferencedSymbols.WithErrors(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("repository", string(args.Repository)),
+		attribute.String("path", pointers.Deref(args.Path, "")),
+		attribute.Int("first", int(pointers.Deref(args.First, 0))),
+		attribute.String("after", pointers.Deref(args.After, "")),
+	}})
+	endObservation.OnCancel(ctx, 1, observation.Args{})
+
+	repositoryID, err := resolverstubs.UnmarshalID[api.RepoID](args.Repository)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.repoStore.Get(ctx, repositoryID); err != nil {
+		return nil, err
+	}
+
+	limit, offset, err := args.ParseLimitOffset(50)
+	if err != nil {
+		return nil, err
+	}
+
+	symbols, totalCount, err := r.rankingSvc.GetUnreferencedSymbols(ctx, shared.GetUnreferencedSymbolsOptions{
+		RepositoryID: int(repositoryID),
+		Path:         pointers.Deref(args.Path, ""),
+		Limit:        int(limit),
+		Offset:       int(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]resolverstubs.UnreferencedSymbolResolver, 0, len(symbols))
+	for _, symbol := range symbols {
+		resolvers = append(resolvers, &unreferencedSymbolResolver{symbol: symbol})
+	}
+
+	return resolverstubs.NewTotalCountConnectionResolver(resolvers, offset, int32(totalCount)), nil
+}
+
+type unreferencedSymbolResolver struct {
+	symbol shared.UnreferencedSymbol
+}
+
+func (r *unreferencedSymbolResolver) Symbol() *string {
+	if r.symbol.Symbol == "" {
+		return nil
+	}
+
+	return &r.symbol.Symbol
+}
+
+func (r *unreferencedSymbolResolver) Path() string   { return r.symbol.DocumentPath }
+func (r *unreferencedSymbolResolver) Commit() string { return r.symbol.Commit }
+
+func (r *unreferencedSymbolResolver) Range() resolverstubs.RangeResolver {
+	if r.symbol.Range == nil {
+		return nil
+	}
+
+	return &rangeResolver{r: *r.symbol.Range}
+}
+
+type rangeResolver struct {
+	r scip.Range
+}
+
+func (r *rangeResolver) Start() resolverstubs.PositionResolver {
+	return &positionResolver{p: r.r.Start}
+}
+
+func (r *rangeResolver) End() resolverstubs.PositionResolver {
+	return &positionResolver{p: r.r.End}
+}
+
+type positionResolver struct {
+	p scip.Position
+}
+
+func (r *positionResolver) Line() int32      { return r.p.Line }
+func (r *positionResolver) Character() int32 { return r.p.Character }
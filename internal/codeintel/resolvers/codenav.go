@@ -20,6 +20,7 @@ type GitBlobLSIFDataArgs struct {
 	Path      string
 	ExactPath bool
 	ToolName  string
+	Diff      string
 }
 
 type GitBlobLSIFDataResolver interface {
@@ -3,6 +3,8 @@ package resolvers
 import (
 	"context"
 
+	"github.com/graph-gophers/graphql-go"
+
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
 )
 
@@ -10,12 +12,28 @@ type RankingServiceResolver interface {
 	RankingSummary(ctx context.Context) (GlobalRankingSummaryResolver, error)
 	BumpDerivativeGraphKey(ctx context.Context) (*EmptyResponse, error)
 	DeleteRankingProgress(ctx context.Context, args *DeleteRankingProgressArgs) (*EmptyResponse, error)
+	UnreferencedSymbols(ctx context.Context, args *UnreferencedSymbolsArgs) (UnreferencedSymbolConnectionResolver, error)
 }
 
 type DeleteRankingProgressArgs struct {
 	GraphKey string
 }
 
+type UnreferencedSymbolsArgs struct {
+	Repository graphql.ID
+	Path       *string
+	PagedConnectionArgs
+}
+
+type UnreferencedSymbolConnectionResolver = PagedConnectionWithTotalCountResolver[UnreferencedSymbolResolver]
+
+type UnreferencedSymbolResolver interface {
+	Symbol() *string
+	Path() string
+	Commit() string
+	Range() RangeResolver
+}
+
 type GlobalRankingSummaryResolver interface {
 	DerivativeGraphKey() *string
 	RankingSummary() []RankingSummaryResolver
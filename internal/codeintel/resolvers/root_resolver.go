@@ -185,6 +185,10 @@ func (r *Resolver) RepositorySummary(ctx context.Context, id graphql.ID) (_ Code
 	return r.uploadsRootResolver.RepositorySummary(ctx, id)
 }
 
+func (r *Resolver) PreciseIndexStorageBudgetReport(ctx context.Context, args *PreciseIndexStorageBudgetReportArgs) (_ PreciseIndexStorageBudgetReportResolver, err error) {
+	return r.uploadsRootResolver.PreciseIndexStorageBudgetReport(ctx, args)
+}
+
 func (r *Resolver) IndexConfiguration(ctx context.Context, id graphql.ID) (_ IndexConfigurationResolver, err error) {
 	return r.autoIndexingRootResolver.IndexConfiguration(ctx, id)
 }
@@ -220,3 +224,7 @@ func (r *Resolver) BumpDerivativeGraphKey(ctx context.Context) (_ *EmptyResponse
 func (r *Resolver) DeleteRankingProgress(ctx context.Context, args *DeleteRankingProgressArgs) (_ *EmptyResponse, err error) {
 	return r.rankingServiceResolver.DeleteRankingProgress(ctx, args)
 }
+
+func (r *Resolver) UnreferencedSymbols(ctx context.Context, args *UnreferencedSymbolsArgs) (_ UnreferencedSymbolConnectionResolver, err error) {
+	return r.rankingServiceResolver.UnreferencedSymbols(ctx, args)
+}
@@ -26,6 +26,9 @@ type UploadsServiceResolver interface {
 	// Coverage
 	CodeIntelSummary(ctx context.Context) (CodeIntelSummaryResolver, error)
 	RepositorySummary(ctx context.Context, id graphql.ID) (CodeIntelRepositorySummaryResolver, error)
+
+	// Retention
+	PreciseIndexStorageBudgetReport(ctx context.Context, args *PreciseIndexStorageBudgetReportArgs) (PreciseIndexStorageBudgetReportResolver, error)
 }
 
 type PreciseIndexesQueryArgs struct {
@@ -59,6 +62,30 @@ type ReindexPreciseIndexesArgs struct {
 	IsLatestForRepo *bool
 }
 
+type PreciseIndexStorageBudgetReportArgs struct {
+	PagedConnectionArgs
+	RepositoryBudgetBytes *gqlutil.BigInt
+	GlobalBudgetBytes     *gqlutil.BigInt
+	Repository            *graphql.ID
+}
+
+type PreciseIndexStorageBudgetReportResolver interface {
+	RepositoryBudgetBytes() *gqlutil.BigInt
+	GlobalBudgetBytes() *gqlutil.BigInt
+	TotalSizeBytes() gqlutil.BigInt
+	Candidates() PreciseIndexStorageBudgetCandidateConnectionResolver
+}
+
+type PreciseIndexStorageBudgetCandidateConnectionResolver = PagedConnectionWithTotalCountResolver[PreciseIndexStorageBudgetCandidateResolver]
+
+type PreciseIndexStorageBudgetCandidateResolver interface {
+	PreciseIndex() PreciseIndexResolver
+	SizeBytes() gqlutil.BigInt
+	QueryCount() gqlutil.BigInt
+	ReferenceCount() int32
+	RetentionTier() string
+}
+
 type CodeIntelligenceCommitGraphResolver interface {
 	Stale() bool
 	UpdatedAt() *gqlutil.DateTime
@@ -90,6 +117,7 @@ type PreciseIndexResolver interface {
 	IsLatestForRepo() bool
 	RetentionPolicyOverview(ctx context.Context, args *LSIFUploadRetentionPolicyMatchesArgs) (CodeIntelligenceRetentionPolicyMatchesConnectionResolver, error)
 	AuditLogs(ctx context.Context) (*[]LSIFUploadsAuditLogsResolver, error)
+	ValidationReport(ctx context.Context) (PreciseIndexValidationReportResolver, error)
 }
 
 type LSIFUploadRetentionPolicyMatchesArgs struct {
@@ -125,6 +153,21 @@ type AuditLogColumnChange interface {
 	New() *string
 }
 
+type PreciseIndexValidationReportResolver interface {
+	ErrorCount() int32
+	WarningCount() int32
+	Problems() []PreciseIndexValidationProblemResolver
+	CreatedAt() gqlutil.DateTime
+}
+
+type PreciseIndexValidationProblemResolver interface {
+	Code() string
+	Severity() string
+	Description() string
+	Count() int32
+	Samples() []string
+}
+
 type AutoIndexJobDescriptionResolver interface {
 	Root() string
 	Indexer() CodeIntelIndexerResolver
@@ -10,8 +10,10 @@ type Config struct {
 	env.BaseConfig
 
 	DownloaderInterval time.Duration
+	LocalAdvisoryPath  string
 }
 
 func (c *Config) Load() {
 	c.DownloaderInterval = c.GetInterval("CODEINTEL_SENTINEL_DOWNLOADER_INTERVAL", "1h", "How frequently to sync the vulnerability database.")
+	c.LocalAdvisoryPath = c.GetOptional("CODEINTEL_SENTINEL_LOCAL_ADVISORY_PATH", "A directory, tarball, or zip archive of OSV-formatted advisories to read instead of downloading the GitHub advisory database (e.g., for air-gapped instances).")
 }
@@ -14,8 +14,9 @@ import (
 
 func NewCVEDownloader(store store.Store, observationCtx *observation.Context, config *Config) goroutine.BackgroundRoutine {
 	cveParser := &CVEParser{
-		store:  store,
-		logger: log.Scoped("sentinel.parser"),
+		store:             store,
+		logger:            log.Scoped("sentinel.parser"),
+		localAdvisoryPath: config.LocalAdvisoryPath,
 	}
 	metrics := newMetrics(observationCtx)
 
@@ -36,14 +37,15 @@ func NewCVEDownloader(store store.Store, observationCtx *observation.Context, co
 			return nil
 		}),
 		goroutine.WithName("codeintel.sentinel-cve-downloader"),
-		goroutine.WithDescription("Periodically syncs GitHub advisory records into Postgres."),
+		goroutine.WithDescription("Periodically syncs GitHub (or local OSV) advisory records into Postgres."),
 		goroutine.WithInterval(config.DownloaderInterval),
 	)
 }
 
 type CVEParser struct {
-	store  store.Store
-	logger log.Logger
+	store             store.Store
+	logger            log.Logger
+	localAdvisoryPath string
 }
 
 func NewCVEParser() *CVEParser {
@@ -53,5 +55,9 @@ func NewCVEParser() *CVEParser {
 }
 
 func (parser *CVEParser) handle(ctx context.Context) ([]shared.Vulnerability, error) {
+	if parser.localAdvisoryPath != "" {
+		return parser.ReadLocalOSVAdvisories(ctx, parser.localAdvisoryPath)
+	}
+
 	return parser.ReadGitHubAdvisoryDB(ctx, false)
 }
@@ -0,0 +1,244 @@
+package downloader
+
+// Read vulnerabilities from OSV-formatted advisories available on local disk.
+// This allows instances without access to the public advisory databases (e.g.
+// air-gapped installations) to supply their own copy of an OSV export, such as
+// the per-ecosystem archives published at https://osv.dev/.
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/sentinel/shared"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ReadLocalOSVAdvisories reads OSV-formatted advisories from the given path, which may be a
+// directory, a (optionally gzipped) tarball, or a zip archive of JSON documents, and converts
+// them to the internal Vulnerability format.
+func (parser *CVEParser) ReadLocalOSVAdvisories(ctx context.Context, path string) (vulns []shared.Vulnerability, err error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to stat local advisory path")
+	}
+
+	if fi.IsDir() {
+		return parser.ParseOSVDirectory(ctx, path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open local advisory archive")
+	}
+	defer f.Close()
+
+	if strings.HasSuffix(path, ".zip") {
+		return parser.ParseOSVZip(f)
+	}
+
+	return parser.ParseOSVTarball(f)
+}
+
+// ParseOSVDirectory converts each JSON document nested under the given directory.
+func (parser *CVEParser) ParseOSVDirectory(ctx context.Context, root string) (vulns []shared.Vulnerability, err error) {
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		vuln, ok, err := parser.parseLocalOSV(path, f)
+		if err != nil || !ok {
+			return err
+		}
+
+		vulns = append(vulns, vuln)
+		return nil
+	})
+
+	return vulns, err
+}
+
+// ParseOSVTarball converts each JSON document in the given tarball. Gzip-compressed tarballs
+// are detected and decompressed transparently.
+func (parser *CVEParser) ParseOSVTarball(r io.Reader) (vulns []shared.Vulnerability, err error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && bytes.Equal(magic, gzipMagic) {
+		gzr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer gzr.Close()
+
+		r = gzr
+	} else {
+		r = br
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg || filepath.Ext(header.Name) != ".json" {
+			continue
+		}
+
+		vuln, ok, err := parser.parseLocalOSV(header.Name, tr)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			vulns = append(vulns, vuln)
+		}
+	}
+
+	return vulns, nil
+}
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// ParseOSVZip converts each JSON document in the given zip archive.
+func (parser *CVEParser) ParseOSVZip(r io.Reader) (vulns []shared.Vulnerability, err error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range zr.File {
+		if filepath.Ext(f.Name) != ".json" {
+			continue
+		}
+
+		vuln, ok, err := func() (shared.Vulnerability, bool, error) {
+			r, err := f.Open()
+			if err != nil {
+				return shared.Vulnerability{}, false, err
+			}
+			defer r.Close()
+
+			return parser.parseLocalOSV(f.Name, r)
+		}()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			vulns = append(vulns, vuln)
+		}
+	}
+
+	return vulns, nil
+}
+
+// parseLocalOSV decodes a single OSV document. Documents that do not describe any affected
+// package (e.g. index files shipped alongside the advisories) are skipped.
+func (parser *CVEParser) parseLocalOSV(name string, r io.Reader) (shared.Vulnerability, bool, error) {
+	var osvVuln OSV
+	if err := json.NewDecoder(r).Decode(&osvVuln); err != nil {
+		return shared.Vulnerability{}, false, errors.Wrapf(err, "failed to decode OSV document %q", name)
+	}
+	if osvVuln.ID == "" || len(osvVuln.Affected) == 0 {
+		return shared.Vulnerability{}, false, nil
+	}
+
+	// Convert OSV to Vulnerability using the generic OSV handler
+	var l LocalOSV
+	convertedVuln, err := parser.osvToVuln(osvVuln, l)
+	if err != nil {
+		return shared.Vulnerability{}, false, errors.Wrapf(err, "failed to convert OSV document %q", name)
+	}
+
+	return convertedVuln, true, nil
+}
+
+//
+// Generic OSV structs and handlers
+//
+
+// LocalOSVDatabaseSpecific represents the subset of commonly provided top-level database_specific
+// data (e.g. by GHSA advisories re-published through osv.dev) that we understand.
+type LocalOSVDatabaseSpecific struct {
+	Severity string   `mapstructure:"severity" json:"severity"`
+	CweIDs   []string `mapstructure:"cwe_ids" json:"cwe_ids"`
+}
+
+type LocalOSV int64
+
+func (l LocalOSV) topLevelHandler(o OSV, v *shared.Vulnerability) error {
+	v.DataSource = "https://osv.dev/vulnerability/" + o.ID
+	for _, reference := range o.References {
+		if reference.Type == "ADVISORY" {
+			v.DataSource = reference.URL
+			break
+		}
+	}
+
+	// database_specific is free-form; ignore documents that use an unexpected shape
+	var databaseSpecific LocalOSVDatabaseSpecific
+	if err := mapstructure.Decode(o.DatabaseSpecific, &databaseSpecific); err == nil {
+		v.Severity = strings.ToUpper(databaseSpecific.Severity)
+		if v.Severity == "MODERATE" {
+			// Match the CVSS rating names used for all other advisories
+			v.Severity = "MEDIUM"
+		}
+		v.CWEs = databaseSpecific.CweIDs
+	}
+
+	return nil
+}
+
+func (l LocalOSV) affectedHandler(a OSVAffected, affectedPackage *shared.AffectedPackage) error {
+	// Ecosystems may carry a release suffix (e.g. "Debian:11")
+	ecosystem, _, _ := strings.Cut(a.Package.Ecosystem, ":")
+
+	affectedPackage.Language = githubEcosystemToLanguage(ecosystem)
+	affectedPackage.Namespace = "osv:" + a.Package.Ecosystem
+
+	if ecosystem == "Go" {
+		// Go advisories from osv.dev are sourced from Govulndb and list affected symbols
+		var es GovulndbAffectedEcosystemSpecific
+		if err := mapstructure.Decode(a.EcosystemSpecific, &es); err == nil {
+			for _, i := range es.Imports {
+				affectedPackage.AffectedSymbols = append(affectedPackage.AffectedSymbols, shared.AffectedSymbol{
+					Path:    i.Path,
+					Symbols: i.Symbols,
+				})
+			}
+		}
+	}
+
+	return nil
+}
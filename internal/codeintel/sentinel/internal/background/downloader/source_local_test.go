@@ -0,0 +1,129 @@
+package downloader
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/sentinel/shared"
+)
+
+const testLocalOSVAdvisory = `{
+	"id": "GHSA-xxxx-yyyy-zzzz",
+	"summary": "Example vulnerability",
+	"affected": [
+		{
+			"package": {"ecosystem": "PyPI", "name": "example-package"},
+			"ranges": [
+				{"type": "GIT", "repo": "https://github.com/example/package", "events": [{"introduced": "0"}, {"fixed": "deadbeef"}]},
+				{"type": "ECOSYSTEM", "events": [{"introduced": "1.0"}, {"fixed": "1.4.2"}, {"introduced": "2.0"}, {"fixed": "2.1"}]}
+			]
+		},
+		{
+			"package": {"ecosystem": "Maven", "name": "org.example:example"},
+			"versions": ["1.0.0", "1.0.1"]
+		}
+	],
+	"references": [
+		{"type": "WEB", "url": "https://example.com"},
+		{"type": "ADVISORY", "url": "https://example.com/advisory"}
+	],
+	"database_specific": {"severity": "MODERATE", "cwe_ids": ["CWE-79"]}
+}`
+
+var testLocalOSVVulnerabilities = []shared.Vulnerability{
+	{
+		SourceID:    "GHSA-xxxx-yyyy-zzzz",
+		Summary:     "Example vulnerability",
+		DataSource:  "https://example.com/advisory",
+		Severity:    "MEDIUM",
+		CWEs:        []string{"CWE-79"},
+		URLs:        []string{"https://example.com", "https://example.com/advisory"},
+		ModifiedAt:  new(time.Time),
+		WithdrawnAt: new(time.Time),
+		AffectedPackages: []shared.AffectedPackage{
+			{
+				PackageName:       "example-package",
+				Language:          "python",
+				Namespace:         "osv:PyPI",
+				VersionConstraint: []string{">=1.0", "<1.4.2", ">=2.0", "<2.1"},
+				Fixed:             true,
+				FixedIn:           strPtr("2.1"),
+			},
+			{
+				PackageName:       "org.example:example",
+				Language:          "java",
+				Namespace:         "osv:Maven",
+				VersionConstraint: []string{"=1.0.0", "=1.0.1"},
+			},
+		},
+	},
+}
+
+func TestReadLocalOSVAdvisoriesDirectory(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "PyPI"), os.ModePerm); err != nil {
+		t.Fatalf("unexpected error creating directory: %s", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "PyPI", "GHSA-xxxx-yyyy-zzzz.json"), []byte(testLocalOSVAdvisory), 0o644); err != nil {
+		t.Fatalf("unexpected error writing advisory: %s", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "README.md"), []byte("not an advisory"), 0o644); err != nil {
+		t.Fatalf("unexpected error writing readme: %s", err)
+	}
+
+	parser := &CVEParser{logger: logtest.Scoped(t)}
+	vulns, err := parser.ReadLocalOSVAdvisories(context.Background(), root)
+	if err != nil {
+		t.Fatalf("unexpected error reading advisories: %s", err)
+	}
+
+	if diff := cmp.Diff(testLocalOSVVulnerabilities, vulns); diff != "" {
+		t.Errorf("unexpected vulnerabilities (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadLocalOSVAdvisoriesTarball(t *testing.T) {
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	if err := tw.WriteHeader(&tar.Header{Name: "advisories/GHSA-xxxx-yyyy-zzzz.json", Mode: 0o644, Size: int64(len(testLocalOSVAdvisory)), Typeflag: tar.TypeReg}); err != nil {
+		t.Fatalf("unexpected error writing tar header: %s", err)
+	}
+	if _, err := tw.Write([]byte(testLocalOSVAdvisory)); err != nil {
+		t.Fatalf("unexpected error writing tar content: %s", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("unexpected error closing tar writer: %s", err)
+	}
+	if err := gzw.Close(); err != nil {
+		t.Fatalf("unexpected error closing gzip writer: %s", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "advisories.tar.gz")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("unexpected error writing tarball: %s", err)
+	}
+
+	parser := &CVEParser{logger: logtest.Scoped(t)}
+	vulns, err := parser.ReadLocalOSVAdvisories(context.Background(), path)
+	if err != nil {
+		t.Fatalf("unexpected error reading advisories: %s", err)
+	}
+
+	if diff := cmp.Diff(testLocalOSVVulnerabilities, vulns); diff != "" {
+		t.Errorf("unexpected vulnerabilities (-want +got):\n%s", diff)
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -139,19 +139,16 @@ func (parser *CVEParser) osvToVuln(o OSV, dataSourceHandler DataSourceHandler) (
 			return v, err
 		}
 
-		if len(affected.Ranges) > 1 {
-			parser.logger.Warn(
-				"unexpected number of affected.Ranges (>1)",
-				log.String("type", "dataWarning"),
-				log.String("sourceID", v.SourceID),
-				log.String("actualNumRanges", fmt.Sprint(len(affected.Ranges))),
-			)
-		}
-
-		// In all observed cases a single range is used, so keep it simple
+		// Multiple ranges are flattened into a single sequence of constraints, in which each
+		// introduced event opens a new range of affected versions
 		for _, affectedRange := range affected.Ranges {
 			// Implement dataSourceHandler.affectedRangeHandler here if needed
 
+			if affectedRange.Type == "GIT" {
+				// Events of GIT ranges are commit hashes rather than package versions
+				continue
+			}
+
 			for _, event := range affectedRange.Events {
 				if event.Introduced != "" {
 					ap.VersionConstraint = append(ap.VersionConstraint, ">="+event.Introduced)
@@ -171,18 +168,11 @@ func (parser *CVEParser) osvToVuln(o OSV, dataSourceHandler DataSourceHandler) (
 			}
 		}
 
-		if len(affected.Ranges) == 0 && len(affected.Versions) > 0 {
-			// A version indicates a precise affected version, so it doesn't make sense to have >1
-			if len(affected.Versions) > 1 {
-				parser.logger.Warn(
-					"unexpected number of affected versions (>1)",
-					log.String("type", "dataWarning"),
-					log.String("sourceID", v.SourceID),
-					log.String("actual", v.CVSSVector),
-					log.String("err", err.Error()),
-				)
+		if len(ap.VersionConstraint) == 0 {
+			// Each version indicates a precise affected version
+			for _, version := range affected.Versions {
+				ap.VersionConstraint = append(ap.VersionConstraint, "="+version)
 			}
-			ap.VersionConstraint = append(ap.VersionConstraint, "="+affected.Versions[0])
 		}
 
 		pas = append(pas, ap)
@@ -15,7 +15,7 @@ func NewCVEMatcher(store store.Store, observationCtx *observation.Context, confi
 	return goroutine.NewPeriodicGoroutine(
 		actor.WithInternalActor(context.Background()),
 		goroutine.HandlerFunc(func(ctx context.Context) error {
-			numReferencesScanned, numVulnerabilityMatches, err := store.ScanMatches(ctx, config.BatchSize)
+			numReferencesScanned, numVulnerabilityMatches, err := store.ScanMatches(ctx, config.BatchSize, config.RescanInterval)
 			if err != nil {
 				return err
 			}
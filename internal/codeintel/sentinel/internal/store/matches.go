@@ -3,13 +3,13 @@ package store
 import (
 	"context"
 	"sort"
-	"strings"
+	"time"
 
-	"github.com/hashicorp/go-version"
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
 	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/sentinel/internal/versions"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/sentinel/shared"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/batch"
@@ -210,14 +210,28 @@ limit %s offset %s
 //
 //
 
-func (s *store) ScanMatches(ctx context.Context, batchSize int) (numReferencesScanned int, numVulnerabilityMatches int, err error) {
+func (s *store) ScanMatches(ctx context.Context, batchSize int, rescanInterval time.Duration) (numReferencesScanned int, numVulnerabilityMatches int, err error) {
 	ctx, _, endObservation := s.operations.scanMatches.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
 		attribute.Int("batchSize", batchSize),
+		attribute.Stringer("rescanInterval", rescanInterval),
 	}})
 	defer endObservation(1, observation.Args{})
 
+	rescanCondition := sqlf.Sprintf("FALSE")
+	if rescanInterval > 0 {
+		rescanCondition = sqlf.Sprintf("uvs.last_scanned_at < NOW() - (%s * interval '1 second')", int(rescanInterval/time.Second))
+	}
+
 	var a, b int
 	err = s.db.WithTransact(ctx, func(tx *basestore.Store) error {
+		uploadIDs, err := basestore.ScanInts(tx.Query(ctx, sqlf.Sprintf(scanMatchesCandidatesQuery, rescanCondition, batchSize)))
+		if err != nil {
+			return err
+		}
+		if len(uploadIDs) == 0 {
+			return nil
+		}
+
 		type vulnerabilityMatch struct {
 			UploadID                       int
 			VulnerabilityAffectedPackageID int
@@ -225,16 +239,17 @@ func (s *store) ScanMatches(ctx context.Context, batchSize int) (numReferencesSc
 		numScanned := 0
 		scanFilteredVulnerabilityMatches := basestore.NewFilteredSliceScanner(func(s dbutil.Scanner) (m vulnerabilityMatch, _ bool, _ error) {
 			var (
+				language           string
 				version            string
 				versionConstraints []string
 			)
 
-			if err := s.Scan(&m.UploadID, &m.VulnerabilityAffectedPackageID, &version, pq.Array(&versionConstraints)); err != nil {
+			if err := s.Scan(&m.UploadID, &m.VulnerabilityAffectedPackageID, &language, &version, pq.Array(&versionConstraints)); err != nil {
 				return vulnerabilityMatch{}, false, err
 			}
 
 			numScanned++
-			matches, valid := versionMatchesConstraints(version, versionConstraints)
+			matches, valid := versions.MatchesConstraints(language, version, versionConstraints)
 			_ = valid // TODO - log un-parseable versions
 
 			return m, matches, nil
@@ -242,8 +257,8 @@ func (s *store) ScanMatches(ctx context.Context, batchSize int) (numReferencesSc
 
 		matches, err := scanFilteredVulnerabilityMatches(tx.Query(ctx, sqlf.Sprintf(
 			scanMatchesQuery,
-			batchSize,
 			sqlf.Join(makeSchemeTtoVulnerabilityLanguageMappingConditions(), " OR "),
+			pq.Array(uploadIDs),
 		)))
 		if err != nil {
 			return err
@@ -279,6 +294,11 @@ func (s *store) ScanMatches(ctx context.Context, batchSize int) (numReferencesSc
 			return err
 		}
 
+		// Remove matches of re-scanned uploads that no longer apply before inserting new ones
+		if err := tx.Exec(ctx, sqlf.Sprintf(scanMatchesDeleteStaleQuery, pq.Array(uploadIDs))); err != nil {
+			return err
+		}
+
 		numMatched, _, err := basestore.ScanFirstInt(tx.Query(ctx, sqlf.Sprintf(scanMatchesUpdateQuery)))
 		if err != nil {
 			return err
@@ -292,47 +312,44 @@ func (s *store) ScanMatches(ctx context.Context, batchSize int) (numReferencesSc
 	return a, b, err
 }
 
-const scanMatchesQuery = `
+// scanMatchesCandidatesQuery selects (and marks as scanned) a batch of uploads to match against
+// the set of known vulnerabilities. Uploads that have never been scanned come first so that new
+// uploads are scored promptly, followed by uploads whose last scan is older than the rescan interval
+// so that their matches are re-scored against newly ingested advisories. Candidate uploads are locked
+// for the rest of the transaction, and uploads locked by a concurrent run are skipped, so that two
+// runs never scan the same upload.
+const scanMatchesCandidatesQuery = `
 WITH
 candidates AS (
 	SELECT u.id
 	FROM lsif_uploads u
 	JOIN repo r ON r.id = u.repository_id
+	LEFT JOIN lsif_uploads_vulnerability_scan uvs ON uvs.upload_id = u.id
 	WHERE
 		u.state = 'completed' AND
 		r.deleted_at IS NULL AND
 		r.blocked IS NULL AND
-		NOT EXISTS (
-			SELECT 1
-			FROM lsif_uploads_vulnerability_scan uvs
-			WHERE
-				uvs.upload_id = u.id AND
-				-- TODO: we'd rather compare this against vuln update times
-				uvs.last_scanned_at < NOW()
-		)
-	ORDER BY u.id
+		(uvs.upload_id IS NULL OR %s)
+	ORDER BY uvs.last_scanned_at NULLS FIRST, u.id
 	LIMIT %s
-),
-locked_candidates AS (
-	INSERT INTO lsif_uploads_vulnerability_scan (upload_id, last_scanned_at)
-	SELECT id, NOW() FROM candidates
-	ON CONFLICT DO NOTHING
-	RETURNING upload_id
+	FOR UPDATE OF u SKIP LOCKED
 )
+INSERT INTO lsif_uploads_vulnerability_scan (upload_id, last_scanned_at)
+SELECT id, NOW() FROM candidates
+ON CONFLICT (upload_id) DO UPDATE SET last_scanned_at = EXCLUDED.last_scanned_at
+RETURNING upload_id
+`
+
+const scanMatchesQuery = `
 SELECT
 	r.dump_id,
 	vap.id,
+	vap.language,
 	r.version,
 	vap.version_constraint
-FROM locked_candidates lc
-JOIN lsif_references r ON r.dump_id = lc.upload_id
-JOIN vulnerability_affected_packages vap ON
-	-- NOTE: This is currently a bit of a hack that works to find some
-	-- good matches with the dataset we have. We should have a better
-	-- way to match on a normalized name here, or have rules per types
-	-- of language ecosystem.
-	r.name LIKE '%%' || vap.package_name || '%%'
-WHERE %s
+FROM lsif_references r
+JOIN vulnerability_affected_packages vap ON %s
+WHERE r.dump_id = ANY(%s)
 `
 
 const scanMatchesTemporaryTableQuery = `
@@ -342,6 +359,19 @@ CREATE TEMPORARY TABLE t_vulnerability_affected_packages (
 ) ON COMMIT DROP
 `
 
+const scanMatchesDeleteStaleQuery = `
+DELETE FROM vulnerability_matches m
+WHERE
+	m.upload_id = ANY(%s) AND
+	NOT EXISTS (
+		SELECT 1
+		FROM t_vulnerability_affected_packages t
+		WHERE
+			t.upload_id = m.upload_id AND
+			t.vulnerability_affected_package_id = m.vulnerability_affected_package_id
+	)
+`
+
 const scanMatchesUpdateQuery = `
 WITH ins AS (
 	INSERT INTO vulnerability_matches (upload_id, vulnerability_affected_package_id)
@@ -422,36 +452,53 @@ var flattenMatches = func(ms []shared.VulnerabilityMatch) []shared.Vulnerability
 	return flattened
 }
 
-func versionMatchesConstraints(versionString string, constraints []string) (matches, valid bool) {
-	v, err := version.NewVersion(versionString)
-	if err != nil {
-		return false, false
-	}
-
-	constraint, err := version.NewConstraint(strings.Join(constraints, ","))
-	if err != nil {
-		return false, false
-	}
+// scipEcosystem describes how references of a SCIP package scheme are matched against
+// affected packages of a vulnerability.
+type scipEcosystem struct {
+	// language is the language of vulnerability affected packages of this ecosystem.
+	language string
 
-	return constraint.Check(v), true
+	// nameCondition is a SQL condition comparing the name of a reference (r.name) to
+	// the name of an affected package (vap.package_name) of this ecosystem.
+	nameCondition string
 }
 
-var scipSchemeToVulnerabilityLanguage = map[string]string{
-	"gomod": "go",
-	"npm":   "Javascript",
-	// TODO - java mapping
+// substringNameCondition matches package names that appear anywhere in the reference name.
+//
+// NOTE: This is currently a bit of a hack that works to find some good matches with the
+// dataset we have (e.g. Go module paths hosted on GitHub).
+const substringNameCondition = `r.name LIKE '%%' || vap.package_name || '%%'`
+
+var scipSchemeToVulnerabilityEcosystem = map[string]scipEcosystem{
+	"gomod": {language: "go", nameCondition: substringNameCondition},
+	"npm":   {language: "Javascript", nameCondition: substringNameCondition},
+
+	// Crates.io treats hyphens and underscores in crate names as equivalent
+	"rust-analyzer": {language: "rust", nameCondition: `replace(r.name, '-', '_') = replace(vap.package_name, '-', '_')`},
+
+	// PyPI names are compared after PEP 503 normalization
+	"python":      {language: "python", nameCondition: pep503NameCondition},
+	"scip-python": {language: "python", nameCondition: pep503NameCondition},
+
+	"scip-ruby": {language: "ruby", nameCondition: `r.name = vap.package_name`},
+
+	// SCIP Java packages are named maven/<group>/<artifact>; advisories use <group>:<artifact>
+	"semanticdb": {language: "java", nameCondition: `replace(regexp_replace(r.name, '^maven/', ''), '/', ':') = vap.package_name`},
 }
 
+const pep503NameCondition = `lower(regexp_replace(r.name, '[-_.]+', '-', 'g')) = lower(regexp_replace(vap.package_name, '[-_.]+', '-', 'g'))`
+
 func makeSchemeTtoVulnerabilityLanguageMappingConditions() []*sqlf.Query {
-	schemes := make([]string, 0, len(scipSchemeToVulnerabilityLanguage))
-	for scheme := range scipSchemeToVulnerabilityLanguage {
+	schemes := make([]string, 0, len(scipSchemeToVulnerabilityEcosystem))
+	for scheme := range scipSchemeToVulnerabilityEcosystem {
 		schemes = append(schemes, scheme)
 	}
 	sort.Strings(schemes)
 
 	mappings := make([]*sqlf.Query, 0, len(schemes))
 	for _, scheme := range schemes {
-		mappings = append(mappings, sqlf.Sprintf("(r.scheme = %s AND vap.language = %s)", scheme, scipSchemeToVulnerabilityLanguage[scheme]))
+		ecosystem := scipSchemeToVulnerabilityEcosystem[scheme]
+		mappings = append(mappings, sqlf.Sprintf("(r.scheme = %s AND vap.language = %s AND "+ecosystem.nameCondition+")", scheme, ecosystem.language))
 	}
 
 	return mappings
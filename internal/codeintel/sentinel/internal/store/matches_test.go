@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -32,7 +33,7 @@ func TestVulnerabilityMatchByID(t *testing.T) {
 		t.Fatalf("unexpected error inserting vulnerabilities: %s", err)
 	}
 
-	if _, _, err := store.ScanMatches(ctx, 100); err != nil {
+	if _, _, err := store.ScanMatches(ctx, 100, 0); err != nil {
 		t.Fatalf("unexpected error inserting vulnerabilities: %s", err)
 	}
 
@@ -99,7 +100,7 @@ func TestGetVulnerabilityMatches(t *testing.T) {
 		t.Fatalf("unexpected error inserting vulnerabilities: %s", err)
 	}
 
-	if _, _, err := store.ScanMatches(ctx, 1000); err != nil {
+	if _, _, err := store.ScanMatches(ctx, 1000, 0); err != nil {
 		t.Fatalf("unexpected error inserting vulnerabilities: %s", err)
 	}
 
@@ -235,7 +236,7 @@ func TestGetVulberabilityMatchesCountByRepository(t *testing.T) {
 		t.Fatalf("unexpected error inserting vulnerabilities: %s", err)
 	}
 
-	if _, _, err := store.ScanMatches(ctx, 1000); err != nil {
+	if _, _, err := store.ScanMatches(ctx, 1000, 0); err != nil {
 		t.Fatalf("unexpected error inserting vulnerabilities: %s", err)
 	}
 
@@ -363,7 +364,7 @@ func TestGetVulnerabilityMatchesSummaryCount(t *testing.T) {
 		t.Fatalf("unexpected error inserting vulnerabilities: %s", err)
 	}
 
-	if _, _, err := store.ScanMatches(ctx, 1000); err != nil {
+	if _, _, err := store.ScanMatches(ctx, 1000, 0); err != nil {
 		t.Fatalf("unexpected error inserting vulnerabilities: %s", err)
 	}
 
@@ -389,6 +390,94 @@ func TestGetVulnerabilityMatchesSummaryCount(t *testing.T) {
 	}
 }
 
+func TestScanMatchesRescan(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	store := New(&observation.TestContext, db)
+
+	setupReferences(t, db)
+
+	if _, err := store.InsertVulnerabilities(ctx, testVulnerabilities); err != nil {
+		t.Fatalf("unexpected error inserting vulnerabilities: %s", err)
+	}
+
+	if _, numMatches, err := store.ScanMatches(ctx, 100, time.Hour); err != nil {
+		t.Fatalf("unexpected error scanning matches: %s", err)
+	} else if numMatches != 3 {
+		t.Fatalf("unexpected number of matches. want=%d have=%d", 3, numMatches)
+	}
+
+	// A recently scanned upload is not scanned again
+	if numScanned, _, err := store.ScanMatches(ctx, 100, time.Hour); err != nil {
+		t.Fatalf("unexpected error scanning matches: %s", err)
+	} else if numScanned != 0 {
+		t.Fatalf("unexpected number of references scanned. want=%d have=%d", 0, numScanned)
+	}
+
+	// Change a previously vulnerable reference and age all scans past the rescan interval
+	if err := basestore.NewWithHandle(db.Handle()).Exec(ctx, sqlf.Sprintf(`
+		UPDATE lsif_references SET version = 'v1.2.7' WHERE dump_id = 50;
+		UPDATE lsif_uploads_vulnerability_scan SET last_scanned_at = NOW() - interval '2 hours';
+	`)); err != nil {
+		t.Fatalf("unexpected error updating references: %s", err)
+	}
+
+	if _, _, err := store.ScanMatches(ctx, 100, time.Hour); err != nil {
+		t.Fatalf("unexpected error scanning matches: %s", err)
+	}
+
+	matches, _, err := store.GetVulnerabilityMatches(ctx, shared.GetVulnerabilityMatchesArgs{Limit: 10})
+	if err != nil {
+		t.Fatalf("unexpected error getting vulnerability matches: %s", err)
+	}
+
+	var uploadIDs []int
+	for _, match := range matches {
+		uploadIDs = append(uploadIDs, match.UploadID)
+	}
+	sort.Ints(uploadIDs)
+
+	if diff := cmp.Diff([]int{51, 52}, uploadIDs); diff != "" {
+		t.Errorf("unexpected matched uploads (-want +got):\n%s", diff)
+	}
+}
+
+func TestScanMatchesSkipsLockedUploads(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	store := New(&observation.TestContext, db)
+
+	setupReferences(t, db)
+
+	if _, err := store.InsertVulnerabilities(ctx, testVulnerabilities); err != nil {
+		t.Fatalf("unexpected error inserting vulnerabilities: %s", err)
+	}
+
+	// Lock an upload as a concurrent run would
+	tx, err := basestore.NewWithHandle(db.Handle()).Transact(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error starting transaction: %s", err)
+	}
+	defer func() { _ = tx.Done(nil) }()
+	if err := tx.Exec(ctx, sqlf.Sprintf(`SELECT id FROM lsif_uploads WHERE id = 52 FOR UPDATE`)); err != nil {
+		t.Fatalf("unexpected error locking upload: %s", err)
+	}
+
+	if _, _, err := store.ScanMatches(ctx, 100, 0); err != nil {
+		t.Fatalf("unexpected error scanning matches: %s", err)
+	}
+
+	scannedUploadIDs, err := basestore.ScanInts(db.QueryContext(ctx, `SELECT upload_id FROM lsif_uploads_vulnerability_scan ORDER BY upload_id`))
+	if err != nil {
+		t.Fatalf("unexpected error querying scanned uploads: %s", err)
+	}
+	if diff := cmp.Diff([]int{50, 51, 53, 54, 55, 56}, scannedUploadIDs); diff != "" {
+		t.Errorf("unexpected scanned uploads (-want +got):\n%s", diff)
+	}
+}
+
 func setupReferences(t *testing.T, db database.DB) {
 	store := basestore.NewWithHandle(db.Handle())
 
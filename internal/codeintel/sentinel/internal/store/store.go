@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"time"
 
 	logger "github.com/sourcegraph/log"
 
@@ -23,7 +24,7 @@ type Store interface {
 	GetVulnerabilityMatches(ctx context.Context, args shared.GetVulnerabilityMatchesArgs) ([]shared.VulnerabilityMatch, int, error)
 	GetVulnerabilityMatchesSummaryCount(ctx context.Context) (counts shared.GetVulnerabilityMatchesSummaryCounts, err error)
 	GetVulnerabilityMatchesCountByRepository(ctx context.Context, args shared.GetVulnerabilityMatchesCountByRepositoryArgs) (_ []shared.VulnerabilityMatchesByRepository, _ int, err error)
-	ScanMatches(ctx context.Context, batchSize int) (numReferencesScanned int, numVulnerabilityMatches int, _ error)
+	ScanMatches(ctx context.Context, batchSize int, rescanInterval time.Duration) (numReferencesScanned int, numVulnerabilityMatches int, _ error)
 }
 
 type store struct {
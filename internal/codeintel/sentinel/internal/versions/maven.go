@@ -0,0 +1,178 @@
+package versions
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// mavenComparator orders versions of Maven artifacts following the rules of Maven's
+// ComparableVersion. Versions are split into numeric and qualifier items; numeric
+// items are compared numerically and well-known qualifiers are ordered as
+// alpha < beta < milestone < rc < snapshot < (release) < sp. Unknown qualifiers sort
+// after all well-known ones, lexically.
+//
+// Unlike ComparableVersion, hyphen-delimited sub-lists are flattened, which only
+// affects the ordering of unusual versions such as 1-1.foo vs 1-1-foo.
+type mavenComparator struct{}
+
+func (mavenComparator) Compare(a, b string) (int, error) {
+	ia, err := mavenItems(a)
+	if err != nil {
+		return 0, err
+	}
+	ib, err := mavenItems(b)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := 0; i < len(ia) || i < len(ib); i++ {
+		var x, y mavenItem
+		if i < len(ia) {
+			x = ia[i]
+		} else {
+			x = nullMavenItem(ib[i])
+		}
+		if i < len(ib) {
+			y = ib[i]
+		} else {
+			y = nullMavenItem(ia[i])
+		}
+
+		if cmp := x.compare(y); cmp != 0 {
+			return cmp, nil
+		}
+	}
+
+	return 0, nil
+}
+
+type mavenItem struct {
+	numeric   bool
+	number    int
+	qualifier string
+}
+
+// nullMavenItem returns the padding item used when comparing against the given item
+// of a longer version: zero for numbers and the release qualifier otherwise.
+func nullMavenItem(other mavenItem) mavenItem {
+	if other.numeric {
+		return mavenItem{numeric: true}
+	}
+
+	return mavenItem{}
+}
+
+func (i mavenItem) compare(other mavenItem) int {
+	switch {
+	case i.numeric && other.numeric:
+		return compareInts(i.number, other.number)
+	case i.numeric:
+		return 1
+	case other.numeric:
+		return -1
+	}
+
+	ri, rj := mavenQualifierRank(i.qualifier), mavenQualifierRank(other.qualifier)
+	if cmp := compareInts(ri, rj); cmp != 0 || ri != unknownMavenQualifierRank {
+		return cmp
+	}
+
+	return strings.Compare(i.qualifier, other.qualifier)
+}
+
+var mavenQualifierRanks = map[string]int{
+	"alpha":     0,
+	"beta":      1,
+	"milestone": 2,
+	"rc":        3,
+	"snapshot":  4,
+	"":          5,
+	"sp":        6,
+}
+
+const unknownMavenQualifierRank = 7
+
+func mavenQualifierRank(qualifier string) int {
+	if rank, ok := mavenQualifierRanks[qualifier]; ok {
+		return rank
+	}
+
+	return unknownMavenQualifierRank
+}
+
+func mavenItems(v string) ([]mavenItem, error) {
+	v = strings.ToLower(strings.TrimSpace(v))
+	if v == "" {
+		return nil, errors.New("invalid maven version: empty string")
+	}
+
+	var tokens []string
+	var current strings.Builder
+	flush := func() {
+		tokens = append(tokens, current.String())
+		current.Reset()
+	}
+
+	runes := []rune(v)
+	for i, r := range runes {
+		switch {
+		case r == '.' || r == '-' || r == '_':
+			flush()
+		case i > 0 && current.Len() > 0 && unicode.IsDigit(r) != unicode.IsDigit(runes[i-1]):
+			flush()
+			current.WriteRune(r)
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+
+	items := make([]mavenItem, 0, len(tokens))
+	for i, token := range tokens {
+		if n, err := strconv.Atoi(token); err == nil {
+			items = append(items, mavenItem{numeric: true, number: n})
+			continue
+		}
+
+		followedByDigit := i+1 < len(tokens) && isNumeric(tokens[i+1])
+		items = append(items, mavenItem{qualifier: normalizeMavenQualifier(token, followedByDigit)})
+	}
+
+	// Trailing zeros and release qualifiers are insignificant (1.0.0 == 1 == 1-ga)
+	for len(items) > 0 {
+		last := items[len(items)-1]
+		if (last.numeric && last.number == 0) || (!last.numeric && last.qualifier == "") {
+			items = items[:len(items)-1]
+			continue
+		}
+
+		break
+	}
+
+	return items, nil
+}
+
+func normalizeMavenQualifier(qualifier string, followedByDigit bool) string {
+	switch qualifier {
+	case "ga", "final", "release":
+		return ""
+	case "cr":
+		return "rc"
+	}
+
+	if followedByDigit {
+		switch qualifier {
+		case "a":
+			return "alpha"
+		case "b":
+			return "beta"
+		case "m":
+			return "milestone"
+		}
+	}
+
+	return qualifier
+}
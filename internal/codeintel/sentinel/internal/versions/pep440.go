@@ -0,0 +1,211 @@
+package versions
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// pep440Comparator orders versions of Python packages as described in PEP 440.
+// See https://peps.python.org/pep-0440/#version-scheme.
+type pep440Comparator struct{}
+
+func (pep440Comparator) Compare(a, b string) (int, error) {
+	va, err := parsePEP440(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := parsePEP440(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return va.compare(vb), nil
+}
+
+// pep440Pattern is the canonical parsing pattern given in Appendix B of PEP 440.
+var pep440Pattern = regexp.MustCompile(`(?i)^\s*v?` +
+	`(?:(?P<epoch>[0-9]+)!)?` +
+	`(?P<release>[0-9]+(?:\.[0-9]+)*)` +
+	`(?P<pre>[-_.]?(?P<pre_l>a|b|c|rc|alpha|beta|pre|preview)[-_.]?(?P<pre_n>[0-9]+)?)?` +
+	`(?P<post>(?:-(?P<post_n1>[0-9]+))|(?:[-_.]?(?P<post_l>post|rev|r)[-_.]?(?P<post_n2>[0-9]+)?))?` +
+	`(?P<dev>[-_.]?(?P<dev_l>dev)[-_.]?(?P<dev_n>[0-9]+)?)?` +
+	`(?:\+(?P<local>[a-z0-9]+(?:[-_.][a-z0-9]+)*))?\s*$`)
+
+type pep440Version struct {
+	epoch   int
+	release []int
+	hasPre  bool
+	preRank int // a=0, b=1, rc=2
+	preNum  int
+	hasPost bool
+	postNum int
+	hasDev  bool
+	devNum  int
+	local   []string
+}
+
+func parsePEP440(v string) (pep440Version, error) {
+	match := pep440Pattern.FindStringSubmatch(v)
+	if match == nil {
+		return pep440Version{}, errors.Newf("invalid PEP 440 version %q", v)
+	}
+
+	group := func(name string) string {
+		return match[pep440Pattern.SubexpIndex(name)]
+	}
+	atoi := func(s string) int {
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+
+	var parsed pep440Version
+	parsed.epoch = atoi(group("epoch"))
+	for _, part := range strings.Split(group("release"), ".") {
+		parsed.release = append(parsed.release, atoi(part))
+	}
+
+	if group("pre") != "" {
+		parsed.hasPre = true
+		parsed.preNum = atoi(group("pre_n"))
+
+		switch strings.ToLower(group("pre_l")) {
+		case "a", "alpha":
+			parsed.preRank = 0
+		case "b", "beta":
+			parsed.preRank = 1
+		default: // c, rc, pre, preview
+			parsed.preRank = 2
+		}
+	}
+
+	if group("post") != "" {
+		parsed.hasPost = true
+		parsed.postNum = atoi(group("post_n1") + group("post_n2"))
+	}
+
+	if group("dev") != "" {
+		parsed.hasDev = true
+		parsed.devNum = atoi(group("dev_n"))
+	}
+
+	if local := group("local"); local != "" {
+		parsed.local = strings.FieldsFunc(strings.ToLower(local), func(r rune) bool {
+			return r == '-' || r == '_' || r == '.'
+		})
+	}
+
+	return parsed, nil
+}
+
+func (v pep440Version) compare(other pep440Version) int {
+	if cmp := compareInts(v.epoch, other.epoch); cmp != 0 {
+		return cmp
+	}
+	if cmp := compareIntSlices(v.release, other.release); cmp != 0 {
+		return cmp
+	}
+	if cmp := compareInts(v.preKey(), other.preKey()); cmp != 0 {
+		return cmp
+	}
+	if v.hasPre && other.hasPre {
+		if cmp := compareInts(v.preNum, other.preNum); cmp != 0 {
+			return cmp
+		}
+	}
+	if cmp := compareOptional(v.hasPost, v.postNum, other.hasPost, other.postNum, false); cmp != 0 {
+		return cmp
+	}
+	if cmp := compareOptional(v.hasDev, v.devNum, other.hasDev, other.devNum, true); cmp != 0 {
+		return cmp
+	}
+
+	return comparePEP440Local(v.local, other.local)
+}
+
+// preKey ranks the pre-release phase of a version. Development releases of a final
+// version (e.g. 1.0.dev1) sort before its pre-releases, and final releases after.
+func (v pep440Version) preKey() int {
+	switch {
+	case v.hasPre:
+		return v.preRank
+	case v.hasDev && !v.hasPost:
+		return -1
+	default:
+		return 3
+	}
+}
+
+// compareOptional compares two optional segments. Absent segments sort before present
+// ones, unless absentIsGreatest is set (as for development releases).
+func compareOptional(hasA bool, a int, hasB bool, b int, absentIsGreatest bool) int {
+	switch {
+	case hasA && hasB:
+		return compareInts(a, b)
+	case !hasA && !hasB:
+		return 0
+	case hasA == absentIsGreatest:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// comparePEP440Local compares local version labels. Numeric segments sort after
+// alphanumeric ones, and a longer label sorts after its prefix.
+func comparePEP440Local(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		na, errA := strconv.Atoi(a[i])
+		nb, errB := strconv.Atoi(b[i])
+
+		switch {
+		case errA == nil && errB == nil:
+			if cmp := compareInts(na, nb); cmp != 0 {
+				return cmp
+			}
+		case errA == nil:
+			return 1
+		case errB == nil:
+			return -1
+		default:
+			if cmp := strings.Compare(a[i], b[i]); cmp != 0 {
+				return cmp
+			}
+		}
+	}
+
+	return compareInts(len(a), len(b))
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareIntSlices compares two release segments, treating missing trailing
+// components as zero (so that 1.0 == 1.0.0).
+func compareIntSlices(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+
+		if cmp := compareInts(x, y); cmp != 0 {
+			return cmp
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,100 @@
+package versions
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// rubyGemsComparator orders versions of Ruby gems following Gem::Version. Versions
+// are split into numeric and alphabetic segments; an alphabetic segment denotes a
+// pre-release and sorts before any numeric segment (so 1.0.a < 1.0 < 1.0.1).
+type rubyGemsComparator struct{}
+
+var (
+	rubyGemsVersionPattern = regexp.MustCompile(`^[0-9]+(\.[0-9a-zA-Z]+)*(-[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+	rubyGemsSegmentPattern = regexp.MustCompile(`[0-9]+|[a-zA-Z]+`)
+)
+
+func (rubyGemsComparator) Compare(a, b string) (int, error) {
+	sa, err := rubyGemsSegments(a)
+	if err != nil {
+		return 0, err
+	}
+	sb, err := rubyGemsSegments(b)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := 0; i < len(sa) || i < len(sb); i++ {
+		x, y := "0", "0"
+		if i < len(sa) {
+			x = sa[i]
+		}
+		if i < len(sb) {
+			y = sb[i]
+		}
+
+		nx, errX := strconv.Atoi(x)
+		ny, errY := strconv.Atoi(y)
+
+		switch {
+		case errX == nil && errY == nil:
+			if cmp := compareInts(nx, ny); cmp != 0 {
+				return cmp, nil
+			}
+		case errX == nil:
+			return 1, nil
+		case errY == nil:
+			return -1, nil
+		default:
+			if cmp := strings.Compare(x, y); cmp != 0 {
+				return cmp, nil
+			}
+		}
+	}
+
+	return 0, nil
+}
+
+func rubyGemsSegments(v string) ([]string, error) {
+	v = strings.TrimSpace(v)
+	if !rubyGemsVersionPattern.MatchString(v) {
+		return nil, errors.Newf("invalid gem version %q", v)
+	}
+
+	// Gem::Version treats a hyphen as the start of a pre-release segment
+	v = strings.ReplaceAll(v, "-", ".pre.")
+
+	segments := rubyGemsSegmentPattern.FindAllString(v, -1)
+
+	// Trailing zeros are insignificant (1.0.0 == 1.0), including those that
+	// directly precede a pre-release segment (1.0.a == 1.a)
+	trimmed := make([]string, 0, len(segments))
+	for i, segment := range segments {
+		if isZero(segment) {
+			j := i
+			for j < len(segments) && isZero(segments[j]) {
+				j++
+			}
+			if j == len(segments) || !isNumeric(segments[j]) {
+				continue
+			}
+		}
+
+		trimmed = append(trimmed, segment)
+	}
+
+	return trimmed, nil
+}
+
+func isZero(s string) bool {
+	return strings.Trim(s, "0") == ""
+}
+
+func isNumeric(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
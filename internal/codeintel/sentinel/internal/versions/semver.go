@@ -0,0 +1,38 @@
+package versions
+
+import (
+	"strings"
+
+	"golang.org/x/mod/semver"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// semverComparator orders versions following Semantic Versioning 2.0.0, as used by
+// Go modules, crates.io and npm. A leading `v` is optional, and Go pseudo-versions
+// and `+incompatible` suffixes are handled as pre-release and build metadata.
+type semverComparator struct{}
+
+func (semverComparator) Compare(a, b string) (int, error) {
+	ca, err := canonicalSemver(a)
+	if err != nil {
+		return 0, err
+	}
+	cb, err := canonicalSemver(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return semver.Compare(ca, cb), nil
+}
+
+func canonicalSemver(v string) (string, error) {
+	if !strings.HasPrefix(v, "v") {
+		v = "v" + v
+	}
+	if !semver.IsValid(v) {
+		return "", errors.Newf("invalid semantic version %q", v)
+	}
+
+	return v, nil
+}
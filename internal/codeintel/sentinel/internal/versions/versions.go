@@ -0,0 +1,173 @@
+package versions
+
+import (
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+// Comparator orders two version strings of a single package ecosystem.
+type Comparator interface {
+	// Compare returns -1, 0, or 1 if a is less than, equal to, or greater than b.
+	// An error is returned if either version cannot be parsed.
+	Compare(a, b string) (int, error)
+}
+
+// comparatorsByLanguage maps the language stored on an affected package (see the
+// downloader's ecosystem-to-language mapping) to the ordering used by that ecosystem.
+var comparatorsByLanguage = map[string]Comparator{
+	"go":         semverComparator{},
+	"rust":       semverComparator{},
+	"Javascript": semverComparator{},
+	"python":     pep440Comparator{},
+	"ruby":       rubyGemsComparator{},
+	"java":       mavenComparator{},
+}
+
+// ComparatorForLanguage returns the version ordering of the given ecosystem language.
+// Languages without ecosystem-specific semantics fall back to a lenient semantic
+// version ordering.
+func ComparatorForLanguage(language string) Comparator {
+	if comparator, ok := comparatorsByLanguage[language]; ok {
+		return comparator
+	}
+
+	return fallbackComparator{}
+}
+
+// MatchesConstraints determines if the given version falls into the set of versions
+// described by the given constraints. Constraints are formed from the events of an
+// OSV affected range, in order: a `>=` (introduced) constraint opens a range, and a
+// `<` (fixed) or `<=` (last affected, limit) constraint closes it. A `=` constraint
+// matches a single version. The version matches if it is contained in any range.
+//
+// The second return value is false if the version or any of the constraints could
+// not be parsed by the ecosystem's version ordering.
+func MatchesConstraints(language, versionString string, constraints []string) (matches, valid bool) {
+	if len(constraints) == 0 {
+		return false, false
+	}
+
+	comparator := ComparatorForLanguage(language)
+	if _, err := comparator.Compare(versionString, versionString); err != nil {
+		return false, false
+	}
+
+	var (
+		open       bool
+		lowerBound string
+	)
+
+	inRange := func(lower, upper string, inclusive bool) (bool, error) {
+		if lower != "" {
+			cmp, err := comparator.Compare(versionString, lower)
+			if err != nil || cmp < 0 {
+				return false, err
+			}
+		}
+		if upper != "" {
+			cmp, err := comparator.Compare(versionString, upper)
+			if err != nil {
+				return false, err
+			}
+			if cmp > 0 || (cmp == 0 && !inclusive) {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	}
+
+	for _, constraint := range constraints {
+		op, operand := splitConstraint(constraint)
+
+		switch op {
+		case ">=":
+			if open {
+				// Two introduced events in a row; the first range is unbounded above
+				if ok, err := inRange(lowerBound, "", false); err != nil {
+					return false, false
+				} else if ok {
+					return true, true
+				}
+			}
+
+			open = true
+			lowerBound = normalizeIntroduced(operand)
+
+		case "<", "<=":
+			ok, err := inRange(lowerBound, operand, op == "<=")
+			if err != nil {
+				return false, false
+			}
+			if ok {
+				return true, true
+			}
+
+			open = false
+			lowerBound = ""
+
+		case "=":
+			cmp, err := comparator.Compare(versionString, operand)
+			if err != nil {
+				return false, false
+			}
+			if cmp == 0 {
+				return true, true
+			}
+
+		default:
+			return false, false
+		}
+	}
+
+	if open {
+		ok, err := inRange(lowerBound, "", false)
+		if err != nil {
+			return false, false
+		}
+
+		return ok, true
+	}
+
+	return false, true
+}
+
+// splitConstraint splits a constraint such as `>= v1.2.3` into its operator and operand.
+func splitConstraint(constraint string) (op, operand string) {
+	constraint = strings.TrimSpace(constraint)
+
+	for _, candidate := range []string{">=", "<=", "<", "="} {
+		if strings.HasPrefix(constraint, candidate) {
+			return candidate, strings.TrimSpace(strings.TrimPrefix(constraint, candidate))
+		}
+	}
+
+	return "", constraint
+}
+
+// normalizeIntroduced converts the OSV sentinel introduced version "0", which
+// denotes all versions, into an absent lower bound.
+func normalizeIntroduced(operand string) string {
+	if operand == "0" {
+		return ""
+	}
+
+	return operand
+}
+
+// fallbackComparator orders versions with a lenient semantic version parser.
+type fallbackComparator struct{}
+
+func (fallbackComparator) Compare(a, b string) (int, error) {
+	va, err := version.NewVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := version.NewVersion(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return va.Compare(vb), nil
+}
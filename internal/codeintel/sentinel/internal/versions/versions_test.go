@@ -0,0 +1,175 @@
+package versions
+
+import "testing"
+
+func TestComparators(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		lower    string
+		higher   string
+	}{
+		{name: "Go patch", language: "go", lower: "v1.2.3", higher: "v1.2.10"},
+		{name: "Go pre-release", language: "go", lower: "v1.2.0-rc.1", higher: "v1.2.0"},
+		{name: "Go pseudo-version", language: "go", lower: "v0.0.0-20220101000000-abcdefabcdef", higher: "v0.0.1"},
+		{name: "Cargo without prefix", language: "rust", lower: "0.9.9", higher: "0.10.0"},
+		{name: "PyPI dev before pre", language: "python", lower: "1.0.dev1", higher: "1.0a1"},
+		{name: "PyPI pre before final", language: "python", lower: "1.0rc2", higher: "1.0"},
+		{name: "PyPI final before post", language: "python", lower: "1.0", higher: "1.0.post1"},
+		{name: "PyPI epoch", language: "python", lower: "2.0", higher: "1!1.0"},
+		{name: "PyPI local", language: "python", lower: "1.0+abc", higher: "1.0+5"},
+		{name: "RubyGems pre-release", language: "ruby", lower: "1.0.0.beta2", higher: "1.0.0"},
+		{name: "RubyGems numeric", language: "ruby", lower: "1.9", higher: "1.10"},
+		{name: "Maven qualifiers", language: "java", lower: "1.0-alpha-1", higher: "1.0-beta-1"},
+		{name: "Maven snapshot", language: "java", lower: "1.0-SNAPSHOT", higher: "1.0"},
+		{name: "Maven release before sp", language: "java", lower: "1.0.Final", higher: "1.0-sp1"},
+		{name: "Maven milestone", language: "java", lower: "2.0.0-M1", higher: "2.0.0-RC1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comparator := ComparatorForLanguage(tt.language)
+
+			if cmp, err := comparator.Compare(tt.lower, tt.higher); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			} else if cmp != -1 {
+				t.Errorf("unexpected comparison of %q and %q. want=%d have=%d", tt.lower, tt.higher, -1, cmp)
+			}
+			if cmp, err := comparator.Compare(tt.higher, tt.lower); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			} else if cmp != 1 {
+				t.Errorf("unexpected comparison of %q and %q. want=%d have=%d", tt.higher, tt.lower, 1, cmp)
+			}
+		})
+	}
+}
+
+func TestComparatorsEquivalentVersions(t *testing.T) {
+	tests := []struct {
+		language string
+		a, b     string
+	}{
+		{language: "go", a: "v1.2.3", b: "1.2.3"},
+		{language: "python", a: "1.0", b: "1.0.0"},
+		{language: "python", a: "1.0-1", b: "1.0.post1"},
+		{language: "python", a: "1.0alpha1", b: "1.0a1"},
+		{language: "ruby", a: "1.0", b: "1.0.0"},
+		{language: "java", a: "1", b: "1.0.0"},
+		{language: "java", a: "1.0-ga", b: "1.0"},
+		{language: "java", a: "1.0-cr1", b: "1.0-rc1"},
+	}
+
+	for _, tt := range tests {
+		cmp, err := ComparatorForLanguage(tt.language).Compare(tt.a, tt.b)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if cmp != 0 {
+			t.Errorf("expected %q and %q to be equivalent %s versions", tt.a, tt.b, tt.language)
+		}
+	}
+}
+
+func TestMatchesConstraints(t *testing.T) {
+	tests := []struct {
+		name        string
+		language    string
+		version     string
+		constraints []string
+		wantMatch   bool
+		wantValid   bool
+	}{
+		{
+			name:        "within range",
+			language:    "go",
+			version:     "v1.2.4",
+			constraints: []string{">=0", "<1.2.5"},
+			wantMatch:   true,
+			wantValid:   true,
+		},
+		{
+			name:        "fixed version",
+			language:    "go",
+			version:     "v1.2.5",
+			constraints: []string{">=0", "<1.2.5"},
+			wantMatch:   false,
+			wantValid:   true,
+		},
+		{
+			name:        "last affected",
+			language:    "go",
+			version:     "v1.2.5",
+			constraints: []string{"<= v1.2.5"},
+			wantMatch:   true,
+			wantValid:   true,
+		},
+		{
+			name:        "second of multiple ranges",
+			language:    "python",
+			version:     "2.1rc1",
+			constraints: []string{">=1.0", "<1.4.2", ">=2.0", "<2.1"},
+			wantMatch:   true,
+			wantValid:   true,
+		},
+		{
+			name:        "between multiple ranges",
+			language:    "python",
+			version:     "1.5",
+			constraints: []string{">=1.0", "<1.4.2", ">=2.0", "<2.1"},
+			wantMatch:   false,
+			wantValid:   true,
+		},
+		{
+			name:        "unbounded range",
+			language:    "ruby",
+			version:     "7.0.4.1",
+			constraints: []string{">=7.0.0"},
+			wantMatch:   true,
+			wantValid:   true,
+		},
+		{
+			name:        "exact version",
+			language:    "java",
+			version:     "2.14.1",
+			constraints: []string{"=2.14.1"},
+			wantMatch:   true,
+			wantValid:   true,
+		},
+		{
+			name:        "maven pre-release below fix",
+			language:    "java",
+			version:     "2.15.0-rc1",
+			constraints: []string{">=2.0-beta9", "<2.15.0"},
+			wantMatch:   true,
+			wantValid:   true,
+		},
+		{
+			name:        "invalid version",
+			language:    "rust",
+			version:     "not-a-version",
+			constraints: []string{">=0"},
+			wantMatch:   false,
+			wantValid:   false,
+		},
+		{
+			name:        "no constraints",
+			language:    "go",
+			version:     "v1.0.0",
+			constraints: nil,
+			wantMatch:   false,
+			wantValid:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, valid := MatchesConstraints(tt.language, tt.version, tt.constraints)
+			if matches != tt.wantMatch {
+				t.Errorf("unexpected match. want=%v have=%v", tt.wantMatch, matches)
+			}
+			if valid != tt.wantValid {
+				t.Errorf("unexpected validity. want=%v have=%v", tt.wantValid, valid)
+			}
+		})
+	}
+}
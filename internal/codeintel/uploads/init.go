@@ -64,11 +64,15 @@ func NewUploadProcessorJob(
 	workerBudget int64,
 	workerPollInterval time.Duration,
 	maximumRuntimePerJob time.Duration,
+	strictValidation bool,
+	validationErrorThreshold int,
 ) []goroutine.BackgroundRoutine {
 	ProcessorConfigInst.WorkerConcurrency = workerConcurrency
 	ProcessorConfigInst.WorkerBudget = workerBudget
 	ProcessorConfigInst.WorkerPollInterval = workerPollInterval
 	ProcessorConfigInst.MaximumRuntimePerJob = maximumRuntimePerJob
+	ProcessorConfigInst.StrictValidation = strictValidation
+	ProcessorConfigInst.ValidationErrorThreshold = validationErrorThreshold
 
 	return background.NewUploadProcessorJob(
 		scopedContext("processor", observationCtx),
@@ -16,6 +16,12 @@ type Config struct {
 	RepositoryProcessDelay time.Duration
 	UploadBatchSize        int
 	UploadProcessDelay     time.Duration
+
+	StorageBudgetExpirerInterval time.Duration
+	StorageBudgetBatchSize       int
+	RepositoryStorageBudget      int64
+	GlobalStorageBudget          int64
+	StorageBudgetDryRun          bool
 }
 
 func (c *Config) Load() {
@@ -33,4 +39,10 @@ func (c *Config) Load() {
 	c.RepositoryProcessDelay = c.GetInterval(repositoryProcessDelay, "24h", "The minimum frequency that the same repository's uploads can be considered for expiration.")
 	c.UploadBatchSize = c.GetInt(uploadBatchSize, "100", "The number of uploads to consider for expiration at a time.")
 	c.UploadProcessDelay = c.GetInterval(uploadProcessDelay, "24h", "The minimum frequency that the same upload record can be considered for expiration.")
+
+	c.StorageBudgetExpirerInterval = c.GetInterval("CODEINTEL_UPLOAD_EXPIRER_STORAGE_BUDGET_INTERVAL", "10m", "How frequently to run the storage budget upload expirer routine.")
+	c.StorageBudgetBatchSize = c.GetInt("CODEINTEL_UPLOAD_EXPIRER_STORAGE_BUDGET_BATCH_SIZE", "100", "The number of uploads exceeding a storage budget to expire at a time.")
+	c.RepositoryStorageBudget = int64(c.GetInt("CODEINTEL_UPLOAD_EXPIRER_REPOSITORY_STORAGE_BUDGET_BYTES", "0", "The maximum number of processed SCIP bytes to retain per repository. Uploads visible from the tip of the default branch are always retained. Zero disables this budget."))
+	c.GlobalStorageBudget = int64(c.GetInt("CODEINTEL_UPLOAD_EXPIRER_GLOBAL_STORAGE_BUDGET_BYTES", "0", "The maximum number of processed SCIP bytes to retain across all repositories. Uploads visible from the tip of the default branch are always retained. Zero disables this budget."))
+	c.StorageBudgetDryRun = c.GetBool("CODEINTEL_UPLOAD_EXPIRER_STORAGE_BUDGET_DRY_RUN", "false", "If true, uploads exceeding a storage budget are logged but not expired.")
 }
@@ -0,0 +1,88 @@
+package expirer
+
+import (
+	"context"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/internal/store"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func NewStorageBudgetExpirer(
+	observationCtx *observation.Context,
+	store store.Store,
+	config *Config,
+) goroutine.BackgroundRoutine {
+	expirer := &storageBudgetExpirer{
+		store:  store,
+		logger: observationCtx.Logger.Scoped("storage-budget-expirer"),
+	}
+	return goroutine.NewPeriodicGoroutine(
+		actor.WithInternalActor(context.Background()),
+		goroutine.HandlerFunc(func(ctx context.Context) error {
+			return expirer.HandleStorageBudgetBatch(ctx, NewExpirationMetrics(observationCtx), config)
+		}),
+		goroutine.WithName("codeintel.upload-storage-budget-expirer"),
+		goroutine.WithDescription("marks uploads as expired when they exceed the configured storage budgets"),
+		goroutine.WithInterval(config.StorageBudgetExpirerInterval),
+	)
+}
+
+type storageBudgetExpirer struct {
+	store  store.Store
+	logger log.Logger
+}
+
+// HandleStorageBudgetBatch marks the least valuable uploads that do not fit within the configured
+// per-repository and global storage budgets as expired. Expired records with no dependents will be
+// removed by the expiredUploadDeleter.
+//
+// Candidates are returned least valuable first, so expiring a batch does not change the fate of the
+// remaining candidates. Subsequent invocations will continue where this one left off.
+func (e *storageBudgetExpirer) HandleStorageBudgetBatch(ctx context.Context, metrics *ExpirationMetrics, cfg *Config) error {
+	if cfg.RepositoryStorageBudget <= 0 && cfg.GlobalStorageBudget <= 0 {
+		// Storage budgets are disabled
+		return nil
+	}
+
+	candidates, totalCount, totalSize, err := e.store.GetStorageBudgetCandidates(ctx, shared.GetStorageBudgetCandidatesOptions{
+		RepositoryBudget: cfg.RepositoryStorageBudget,
+		GlobalBudget:     cfg.GlobalStorageBudget,
+		Limit:            cfg.StorageBudgetBatchSize,
+	})
+	if err != nil {
+		return errors.Wrap(err, "store.GetStorageBudgetCandidates")
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	if cfg.StorageBudgetDryRun {
+		e.logger.Info(
+			"Uploads exceed storage budget (dry run)",
+			log.Int("numUploads", totalCount),
+			log.Int64("numBytes", totalSize),
+		)
+		return nil
+	}
+
+	ids := make([]int, 0, len(candidates))
+	var size int64
+	for _, candidate := range candidates {
+		ids = append(ids, candidate.UploadID)
+		size += candidate.Size
+	}
+
+	if err := e.store.UpdateUploadRetention(ctx, nil, ids); err != nil {
+		return errors.Wrap(err, "store.UpdateUploadRetention")
+	}
+
+	metrics.NumUploadsExpiredByStorageBudget.Add(float64(len(ids)))
+	metrics.NumBytesExpiredByStorageBudget.Add(float64(size))
+	return nil
+}
@@ -0,0 +1,79 @@
+package expirer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestStorageBudgetExpirer(t *testing.T) {
+	store := NewMockStore()
+	store.GetStorageBudgetCandidatesFunc.SetDefaultReturn([]shared.StorageBudgetCandidate{
+		{UploadID: 3, Size: 100},
+		{UploadID: 1, Size: 200},
+		{UploadID: 2, Size: 300},
+	}, 5, 1000, nil)
+
+	e := &storageBudgetExpirer{store: store, logger: logtest.Scoped(t)}
+	if err := e.HandleStorageBudgetBatch(context.Background(), NewExpirationMetrics(&observation.TestContext), &Config{
+		RepositoryStorageBudget: 1024,
+		StorageBudgetBatchSize:  3,
+	}); err != nil {
+		t.Fatalf("unexpected error from handle: %s", err)
+	}
+
+	if calls := store.GetStorageBudgetCandidatesFunc.History(); len(calls) != 1 {
+		t.Fatalf("unexpected number of calls to GetStorageBudgetCandidates. want=%d have=%d", 1, len(calls))
+	} else {
+		expectedOpts := shared.GetStorageBudgetCandidatesOptions{RepositoryBudget: 1024, Limit: 3}
+		if diff := cmp.Diff(expectedOpts, calls[0].Arg1); diff != "" {
+			t.Errorf("unexpected options (-want +got):\n%s", diff)
+		}
+	}
+
+	if calls := store.UpdateUploadRetentionFunc.History(); len(calls) != 1 {
+		t.Fatalf("unexpected number of calls to UpdateUploadRetention. want=%d have=%d", 1, len(calls))
+	} else {
+		if len(calls[0].Arg1) != 0 {
+			t.Errorf("unexpected protected identifiers: %v", calls[0].Arg1)
+		}
+		if diff := cmp.Diff([]int{3, 1, 2}, calls[0].Arg2); diff != "" {
+			t.Errorf("unexpected expired identifiers (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func TestStorageBudgetExpirerDisabled(t *testing.T) {
+	store := NewMockStore()
+
+	e := &storageBudgetExpirer{store: store, logger: logtest.Scoped(t)}
+	if err := e.HandleStorageBudgetBatch(context.Background(), NewExpirationMetrics(&observation.TestContext), &Config{}); err != nil {
+		t.Fatalf("unexpected error from handle: %s", err)
+	}
+
+	if calls := store.GetStorageBudgetCandidatesFunc.History(); len(calls) != 0 {
+		t.Errorf("unexpected number of calls to GetStorageBudgetCandidates. want=%d have=%d", 0, len(calls))
+	}
+}
+
+func TestStorageBudgetExpirerDryRun(t *testing.T) {
+	store := NewMockStore()
+	store.GetStorageBudgetCandidatesFunc.SetDefaultReturn([]shared.StorageBudgetCandidate{{UploadID: 1, Size: 100}}, 1, 100, nil)
+
+	e := &storageBudgetExpirer{store: store, logger: logtest.Scoped(t)}
+	if err := e.HandleStorageBudgetBatch(context.Background(), NewExpirationMetrics(&observation.TestContext), &Config{
+		GlobalStorageBudget: 1024,
+		StorageBudgetDryRun: true,
+	}); err != nil {
+		t.Fatalf("unexpected error from handle: %s", err)
+	}
+
+	if calls := store.UpdateUploadRetentionFunc.History(); len(calls) != 0 {
+		t.Errorf("unexpected number of calls to UpdateUploadRetention. want=%d have=%d", 0, len(calls))
+	}
+}
@@ -12,6 +12,9 @@ type ExpirationMetrics struct {
 	NumUploadsExpired      prometheus.Counter
 	NumUploadsScanned      prometheus.Counter
 	NumCommitsScanned      prometheus.Counter
+
+	NumUploadsExpiredByStorageBudget prometheus.Counter
+	NumBytesExpiredByStorageBudget   prometheus.Counter
 }
 
 var expirationMetrics = memo.NewMemoizedConstructorWithArg(func(r prometheus.Registerer) (*ExpirationMetrics, error) {
@@ -41,12 +44,23 @@ var expirationMetrics = memo.NewMemoizedConstructorWithArg(func(r prometheus.Reg
 		"src_codeintel_background_upload_records_expired_total",
 		"The number of codeintel upload records marked as expired.",
 	)
+	numUploadsExpiredByStorageBudget := counter(
+		"src_codeintel_background_upload_records_expired_by_storage_budget_total",
+		"The number of codeintel upload records marked as expired to satisfy a storage budget.",
+	)
+	numBytesExpiredByStorageBudget := counter(
+		"src_codeintel_background_upload_bytes_expired_by_storage_budget_total",
+		"The number of processed bytes belonging to codeintel upload records marked as expired to satisfy a storage budget.",
+	)
 
 	return &ExpirationMetrics{
 		NumRepositoriesScanned: numRepositoriesScanned,
 		NumUploadsScanned:      numUploadsScanned,
 		NumCommitsScanned:      numCommitsScanned,
 		NumUploadsExpired:      numUploadsExpired,
+
+		NumUploadsExpiredByStorageBudget: numUploadsExpiredByStorageBudget,
+		NumBytesExpiredByStorageBudget:   numBytesExpiredByStorageBudget,
 	}, nil
 })
 
@@ -138,5 +138,10 @@ func NewExpirationTasks(
 			gitserverClient,
 			config,
 		),
+		expirer.NewStorageBudgetExpirer(
+			observationCtx,
+			store,
+			config,
+		),
 	}
 }
@@ -10,4 +10,9 @@ type Config struct {
 	WorkerBudget         int64
 	WorkerPollInterval   time.Duration
 	MaximumRuntimePerJob time.Duration
+
+	// StrictValidation causes uploads whose validation report contains more than
+	// ValidationErrorThreshold errors to fail processing.
+	StrictValidation         bool
+	ValidationErrorThreshold int
 }
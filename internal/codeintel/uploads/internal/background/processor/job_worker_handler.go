@@ -54,6 +54,8 @@ func NewUploadProcessorWorker(
 		workerStore,
 		uploadStore,
 		config.WorkerBudget,
+		config.StrictValidation,
+		config.ValidationErrorThreshold,
 	)
 
 	metrics := workerutil.NewMetrics(observationCtx, "codeintel_upload_processor", workerutil.WithSampler(func(job workerutil.Record) bool { return true }))
@@ -80,6 +82,9 @@ type handler struct {
 	budgetRemaining int64
 	enableBudget    bool
 	uploadSizeGauge prometheus.Gauge
+
+	strictValidation         bool
+	validationErrorThreshold int
 }
 
 var (
@@ -97,6 +102,8 @@ func NewUploadProcessorHandler(
 	workerStore dbworkerstore.Store[uploadsshared.Upload],
 	uploadStore uploadstore.Store,
 	budgetMax int64,
+	strictValidation bool,
+	validationErrorThreshold int,
 ) workerutil.Handler[uploadsshared.Upload] {
 	operations := newWorkerOperations(observationCtx)
 
@@ -111,6 +118,9 @@ func NewUploadProcessorHandler(
 		budgetRemaining: budgetMax,
 		enableBudget:    budgetMax > 0,
 		uploadSizeGauge: operations.uploadSizeGauge,
+
+		strictValidation:         strictValidation,
+		validationErrorThreshold: validationErrorThreshold,
 	}
 }
 
@@ -261,9 +271,36 @@ func (h *handler) HandleRawUpload(ctx context.Context, logger log.Logger, upload
 			return errors.Wrap(err, "store.CommitDate")
 		}
 
-		scipDataStream, err := prepareSCIPDataStream(ctx, indexReader, upload.Root, getChildren)
+		scipDataStream, validationReport, err := prepareSCIPDataStream(ctx, indexReader, upload.Root, getChildren)
 		if err != nil {
-			return errors.Wrap(err, "prepareSCIPDataStream")
+			err = errors.Wrap(err, "prepareSCIPDataStream")
+
+			// The index may have been rejected because of a problem found during validation
+			// (e.g., missing metadata). Store the report so that it explains the failure.
+			if len(validationReport.Problems) > 0 {
+				if insertErr := h.store.InsertUploadValidationReport(ctx, upload.ID, validationReport); insertErr != nil {
+					err = errors.Append(err, errors.Wrap(insertErr, "store.InsertUploadValidationReport"))
+				}
+			}
+
+			return err
+		}
+
+		trace.AddEvent("TODO Domain Owner",
+			attribute.Int("validationErrors", validationReport.ErrorCount),
+			attribute.Int("validationWarnings", validationReport.WarningCount))
+
+		// Store the validation report outside of the transactions below so that it remains
+		// available to explain an upload that fails processing.
+		if err := h.store.InsertUploadValidationReport(ctx, upload.ID, validationReport); err != nil {
+			return errors.Wrap(err, "store.InsertUploadValidationReport")
+		}
+		if h.strictValidation && validationReport.ErrorCount > h.validationErrorThreshold {
+			return errors.Newf(
+				"index failed validation with %d errors (allowed %d); see the upload's validation report for details",
+				validationReport.ErrorCount,
+				h.validationErrorThreshold,
+			)
 		}
 
 		// Note: this is writing to a different database than the block below, so we need to use a
@@ -1,6 +1,8 @@
 package processor
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"crypto/sha256"
 	"encoding/base64"
@@ -272,6 +274,139 @@ func TestHandle(t *testing.T) {
 	}
 }
 
+func TestHandleStrictValidation(t *testing.T) {
+	setupRepoMocks(t)
+
+	upload := shared.Upload{
+		ID:           42,
+		Root:         "",
+		Commit:       "deadbeef",
+		RepositoryID: 50,
+		Indexer:      "lsif-go",
+		ContentType:  "application/x-protobuf+scip",
+	}
+
+	mockWorkerStore := NewMockWorkerStore[shared.Upload]()
+	mockDBStore := NewMockStore()
+	mockRepoStore := defaultMockRepoStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockUploadStore := uploadstoremocks.NewMockStore()
+	gitserverClient := gitserver.NewMockClient()
+
+	// Set default transaction behavior
+	mockDBStore.WithTransactionFunc.SetDefaultHook(func(ctx context.Context, f func(s store.Store) error) error { return f(mockDBStore) })
+
+	// Give correlation package a valid input dump
+	mockUploadStore.GetFunc.SetDefaultHook(copyTestDumpScip)
+
+	// Allowlist all files in dump
+	gitserverClient.ListDirectoryChildrenFunc.SetDefaultReturn(scipDirectoryChildren, nil)
+
+	gitserverClient.GetCommitFunc.SetDefaultReturn(&gitdomain.Commit{
+		ID:        "deadbeef",
+		Committer: &gitdomain.Signature{Date: time.Unix(1587396557, 0).UTC()},
+	}, nil)
+
+	svc := &handler{
+		store:           mockDBStore,
+		lsifStore:       mockLSIFStore,
+		gitserverClient: gitserverClient,
+		repoStore:       mockRepoStore,
+		workerStore:     mockWorkerStore,
+
+		// A negative threshold rejects every index, including those without errors
+		strictValidation:         true,
+		validationErrorThreshold: -1,
+	}
+
+	if _, err := svc.HandleRawUpload(context.Background(), logtest.Scoped(t), upload, mockUploadStore, observation.TestTraceLogger(logtest.Scoped(t))); err == nil {
+		t.Fatalf("expected an error handling upload")
+	}
+
+	if calls := mockDBStore.InsertUploadValidationReportFunc.History(); len(calls) != 1 {
+		t.Errorf("unexpected number of InsertUploadValidationReport calls. want=%d have=%d", 1, len(calls))
+	} else if calls[0].Arg1 != 42 {
+		t.Errorf("unexpected InsertUploadValidationReport upload id. want=%d have=%d", 42, calls[0].Arg1)
+	}
+
+	if calls := mockLSIFStore.NewSCIPWriterFunc.History(); len(calls) != 0 {
+		t.Errorf("unexpected number of NewSCIPWriter calls. want=%d have=%d", 0, len(calls))
+	}
+	if calls := mockDBStore.UpdatePackagesFunc.History(); len(calls) != 0 {
+		t.Errorf("unexpected number of UpdatePackages calls. want=%d have=%d", 0, len(calls))
+	}
+}
+
+func TestHandleMissingMetadata(t *testing.T) {
+	setupRepoMocks(t)
+
+	upload := shared.Upload{
+		ID:           42,
+		Root:         "",
+		Commit:       "deadbeef",
+		RepositoryID: 50,
+		Indexer:      "lsif-go",
+		ContentType:  "application/x-protobuf+scip",
+	}
+
+	mockWorkerStore := NewMockWorkerStore[shared.Upload]()
+	mockDBStore := NewMockStore()
+	mockRepoStore := defaultMockRepoStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockUploadStore := uploadstoremocks.NewMockStore()
+	gitserverClient := gitserver.NewMockClient()
+
+	// Give correlation package an index without metadata
+	mockUploadStore.GetFunc.SetDefaultHook(func(ctx context.Context, key string) (io.ReadCloser, error) {
+		payload, err := proto.Marshal(&scip.Index{
+			Documents: []*scip.Document{{RelativePath: "template/src/extension.ts"}},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var buf bytes.Buffer
+		gzipWriter := gzip.NewWriter(&buf)
+		if _, err := gzipWriter.Write(payload); err != nil {
+			return nil, err
+		}
+		if err := gzipWriter.Close(); err != nil {
+			return nil, err
+		}
+
+		return io.NopCloser(&buf), nil
+	})
+
+	gitserverClient.GetCommitFunc.SetDefaultReturn(&gitdomain.Commit{
+		ID:        "deadbeef",
+		Committer: &gitdomain.Signature{Date: time.Unix(1587396557, 0).UTC()},
+	}, nil)
+
+	svc := &handler{
+		store:           mockDBStore,
+		lsifStore:       mockLSIFStore,
+		gitserverClient: gitserverClient,
+		repoStore:       mockRepoStore,
+		workerStore:     mockWorkerStore,
+	}
+
+	if _, err := svc.HandleRawUpload(context.Background(), logtest.Scoped(t), upload, mockUploadStore, observation.TestTraceLogger(logtest.Scoped(t))); err == nil {
+		t.Fatalf("expected an error handling upload")
+	}
+
+	if calls := mockDBStore.InsertUploadValidationReportFunc.History(); len(calls) != 1 {
+		t.Fatalf("unexpected number of InsertUploadValidationReport calls. want=%d have=%d", 1, len(calls))
+	} else if calls[0].Arg1 != 42 {
+		t.Errorf("unexpected InsertUploadValidationReport upload id. want=%d have=%d", 42, calls[0].Arg1)
+	} else if report := calls[0].Arg2; report.ErrorCount != 1 || report.Problems[0].Code != problemMissingMetadata {
+		t.Errorf("expected a missing metadata error, have %+v", report)
+	}
+
+	if calls := mockLSIFStore.NewSCIPWriterFunc.History(); len(calls) != 0 {
+		t.Errorf("unexpected number of NewSCIPWriter calls. want=%d have=%d", 0, len(calls))
+	}
+}
+
 func TestHandleError(t *testing.T) {
 	setupRepoMocks(t)
 
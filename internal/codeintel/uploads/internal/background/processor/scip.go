@@ -15,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/pathexistence"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 type firstPassResult struct {
@@ -22,6 +23,7 @@ type firstPassResult struct {
 	externalSymbolsByName map[string]*scip.SymbolInformation
 	relativePaths         []string
 	documentCountByPath   map[string]int
+	validationReport      shared.UploadValidationReport
 }
 
 func aggregateExternalSymbolsAndPaths(indexReader *gzipReadSeeker) (firstPassResult, error) {
@@ -29,9 +31,11 @@ func aggregateExternalSymbolsAndPaths(indexReader *gzipReadSeeker) (firstPassRes
 	var paths []string
 	externalSymbolsByName := make(map[string]*scip.SymbolInformation, 1024)
 	documentCountByPath := make(map[string]int, 1)
+	validator := newIndexValidator()
 	indexVisitor := scip.IndexVisitor{
 		VisitMetadata: func(m *scip.Metadata) {
 			metadata = m
+			validator.visitMetadata(m)
 		},
 		// Assumption: Post-processing of documents is much more expensive than
 		// pure deserialization, so we don't optimize the visitation here to support
@@ -39,9 +43,11 @@ func aggregateExternalSymbolsAndPaths(indexReader *gzipReadSeeker) (firstPassRes
 		VisitDocument: func(d *scip.Document) {
 			paths = append(paths, d.RelativePath)
 			documentCountByPath[d.RelativePath] = documentCountByPath[d.RelativePath] + 1
+			validator.visitDocument(d)
 		},
 		VisitExternalSymbol: func(s *scip.SymbolInformation) {
 			externalSymbolsByName[s.Symbol] = s
+			validator.visitExternalSymbol(s)
 		},
 	}
 	if err := indexVisitor.ParseStreaming(indexReader); err != nil {
@@ -50,7 +56,7 @@ func aggregateExternalSymbolsAndPaths(indexReader *gzipReadSeeker) (firstPassRes
 	if err := indexReader.seekToStart(); err != nil {
 		return firstPassResult{}, err
 	}
-	return firstPassResult{metadata, externalSymbolsByName, paths, documentCountByPath}, nil
+	return firstPassResult{metadata, externalSymbolsByName, paths, documentCountByPath, validator.report()}, nil
 }
 
 type documentOneShotIterator struct {
@@ -173,6 +179,7 @@ func (it *documentOneShotIterator) VisitAllDocuments(
 
 // prepareSCIPDataStream performs a streaming traversal of the index to get some preliminary
 // information, and creates a SCIPDataStream that can be used to write Documents into the database.
+// The same traversal validates the index; the problems found are returned as a validation report.
 //
 // Package information can be obtained when documents are visited.
 func prepareSCIPDataStream(
@@ -180,15 +187,18 @@ func prepareSCIPDataStream(
 	indexReader gzipReadSeeker,
 	root string,
 	getChildren pathexistence.GetChildrenFunc,
-) (lsifstore.SCIPDataStream, error) {
+) (lsifstore.SCIPDataStream, shared.UploadValidationReport, error) {
 	indexSummary, err := aggregateExternalSymbolsAndPaths(&indexReader)
 	if err != nil {
-		return lsifstore.SCIPDataStream{}, err
+		return lsifstore.SCIPDataStream{}, shared.UploadValidationReport{}, err
+	}
+	if indexSummary.metadata == nil {
+		return lsifstore.SCIPDataStream{}, indexSummary.validationReport, errors.New("index does not contain metadata")
 	}
 
 	ignorePaths, err := ignorePaths(ctx, indexSummary.relativePaths, root, getChildren)
 	if err != nil {
-		return lsifstore.SCIPDataStream{}, err
+		return lsifstore.SCIPDataStream{}, shared.UploadValidationReport{}, err
 	}
 
 	metadata := lsifstore.ProcessedMetadata{
@@ -202,7 +212,7 @@ func prepareSCIPDataStream(
 	return lsifstore.SCIPDataStream{
 		Metadata:         metadata,
 		DocumentIterator: &documentOneShotIterator{ignorePaths, indexSummary, indexReader},
-	}, nil
+	}, indexSummary.validationReport, nil
 }
 
 // Copied from io.ReadAll, but uses the given initial size for the buffer to
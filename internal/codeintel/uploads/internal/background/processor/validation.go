@@ -0,0 +1,292 @@
+package processor
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/collections"
+)
+
+// maxValidationSamples is the maximum number of instances of each kind of problem
+// retained in a validation report.
+const maxValidationSamples = 5
+
+type validationProblemKind struct {
+	severity    shared.UploadValidationSeverity
+	description string
+}
+
+const (
+	problemMissingMetadata              = "MISSING_METADATA"
+	problemMissingToolInfo              = "MISSING_TOOL_INFO"
+	problemInvalidDocumentPath          = "INVALID_DOCUMENT_PATH"
+	problemDuplicateDocument            = "DUPLICATE_DOCUMENT"
+	problemInvalidRange                 = "INVALID_RANGE"
+	problemOccurrenceOutOfBounds        = "OCCURRENCE_OUT_OF_BOUNDS"
+	problemMalformedSymbol              = "MALFORMED_SYMBOL"
+	problemSymbolWithoutDefinition      = "SYMBOL_WITHOUT_DEFINITION"
+	problemLocalSymbolWithoutDefinition = "LOCAL_SYMBOL_WITHOUT_DEFINITION"
+)
+
+var validationProblemKinds = map[string]validationProblemKind{
+	problemMissingMetadata: {
+		severity:    shared.UploadValidationSeverityError,
+		description: "The index does not contain metadata.",
+	},
+	problemMissingToolInfo: {
+		severity:    shared.UploadValidationSeverityWarning,
+		description: "The index metadata does not name the tool that produced the index.",
+	},
+	problemInvalidDocumentPath: {
+		severity:    shared.UploadValidationSeverityError,
+		description: "A document's relative path is empty, absolute, or not contained in the project root.",
+	},
+	problemDuplicateDocument: {
+		severity:    shared.UploadValidationSeverityWarning,
+		description: "Multiple documents share the same relative path and will be merged.",
+	},
+	problemInvalidRange: {
+		severity:    shared.UploadValidationSeverityError,
+		description: "An occurrence's range is not a well-formed three- or four-element range.",
+	},
+	problemOccurrenceOutOfBounds: {
+		severity:    shared.UploadValidationSeverityError,
+		description: "An occurrence's range lies outside of the text of its document. Only checked for documents that include their text.",
+	},
+	problemMalformedSymbol: {
+		severity:    shared.UploadValidationSeverityError,
+		description: "A symbol name cannot be parsed.",
+	},
+	problemSymbolWithoutDefinition: {
+		severity:    shared.UploadValidationSeverityWarning,
+		description: "A symbol is documented in the index but no occurrence defines it.",
+	},
+	problemLocalSymbolWithoutDefinition: {
+		severity:    shared.UploadValidationSeverityWarning,
+		description: "A local symbol is referenced but never defined within its document.",
+	},
+}
+
+// indexValidator accumulates problems found while visiting the metadata, documents, and
+// external symbols of a SCIP index. Problems that can only be determined once the entire
+// index has been seen (such as symbols without definitions) are reported by report.
+type indexValidator struct {
+	problems       map[string]*shared.UploadValidationProblem
+	seenMetadata   bool
+	seenPaths      collections.Set[string]
+	definedSymbols collections.Set[string]
+
+	// documentedSymbols maps each global symbol with symbol information in some
+	// document to the path of the first such document.
+	documentedSymbols map[string]string
+}
+
+func newIndexValidator() *indexValidator {
+	return &indexValidator{
+		problems:          map[string]*shared.UploadValidationProblem{},
+		seenPaths:         collections.NewSet[string](),
+		definedSymbols:    collections.NewSet[string](),
+		documentedSymbols: map[string]string{},
+	}
+}
+
+func (v *indexValidator) visitMetadata(metadata *scip.Metadata) {
+	v.seenMetadata = true
+
+	if metadata.GetToolInfo().GetName() == "" {
+		v.add(problemMissingToolInfo, "")
+	}
+}
+
+func (v *indexValidator) visitDocument(document *scip.Document) {
+	relativePath := document.RelativePath
+	if !isValidRelativePath(relativePath) {
+		v.add(problemInvalidDocumentPath, fmt.Sprintf("%q", relativePath))
+	}
+	if v.seenPaths.Has(relativePath) {
+		v.add(problemDuplicateDocument, relativePath)
+	}
+	v.seenPaths.Add(relativePath)
+
+	var lines []string
+	if document.Text != "" {
+		lines = strings.Split(document.Text, "\n")
+	}
+
+	for _, symbol := range document.Symbols {
+		if v.checkSymbol(relativePath, symbol.Symbol) && !scip.IsLocalSymbol(symbol.Symbol) {
+			if _, ok := v.documentedSymbols[symbol.Symbol]; !ok {
+				v.documentedSymbols[symbol.Symbol] = relativePath
+			}
+		}
+	}
+
+	definedLocals := collections.NewSet[string]()
+	referencedLocals := map[string]string{}
+
+	for _, occurrence := range document.Occurrences {
+		location, ok := v.checkRange(relativePath, occurrence.Range, lines)
+		if !ok || occurrence.Symbol == "" || !v.checkSymbol(location, occurrence.Symbol) {
+			continue
+		}
+
+		isDefinition := scip.SymbolRole_Definition.Matches(occurrence)
+
+		if scip.IsLocalSymbol(occurrence.Symbol) {
+			if isDefinition {
+				definedLocals.Add(occurrence.Symbol)
+			} else if _, ok := referencedLocals[occurrence.Symbol]; !ok {
+				referencedLocals[occurrence.Symbol] = location
+			}
+
+			continue
+		}
+
+		if isDefinition {
+			v.definedSymbols.Add(occurrence.Symbol)
+		}
+	}
+
+	for _, symbol := range sortedKeys(referencedLocals) {
+		if !definedLocals.Has(symbol) {
+			v.add(problemLocalSymbolWithoutDefinition, fmt.Sprintf("%s: %s", referencedLocals[symbol], symbol))
+		}
+	}
+}
+
+func (v *indexValidator) visitExternalSymbol(symbol *scip.SymbolInformation) {
+	v.checkSymbol("external symbols", symbol.Symbol)
+}
+
+// checkRange records a problem if the given occurrence range is malformed or does not fit
+// within the given document lines. On success, the human-readable location of the start of
+// the range is returned.
+//
+// The lines of a document are only known when the indexer includes the document's text in
+// the index, which most indexers do not. We don't fetch file contents from gitserver during
+// processing, so out-of-bounds occurrences in documents without text are not detected.
+func (v *indexValidator) checkRange(relativePath string, r []int32, lines []string) (string, bool) {
+	if len(r) != 3 && len(r) != 4 {
+		v.add(problemInvalidRange, fmt.Sprintf("%s: %v", relativePath, r))
+		return "", false
+	}
+
+	startLine, startCharacter, endLine, endCharacter := r[0], r[1], r[0], r[2]
+	if len(r) == 4 {
+		endLine, endCharacter = r[2], r[3]
+	}
+
+	location := fmt.Sprintf("%s:%d:%d", relativePath, startLine+1, startCharacter+1)
+
+	if startLine < 0 || startCharacter < 0 || endLine < startLine || (endLine == startLine && endCharacter < startCharacter) {
+		v.add(problemInvalidRange, fmt.Sprintf("%s: %v", location, r))
+		return "", false
+	}
+
+	if lines != nil && (int(endLine) >= len(lines) || int(endCharacter) > len(lines[endLine])) {
+		// Note: we compare characters against the byte length of the line, which is an
+		// upper bound on the line's length in any of the supported position encodings.
+		v.add(problemOccurrenceOutOfBounds, fmt.Sprintf("%s: %v", location, r))
+		return "", false
+	}
+
+	return location, true
+}
+
+// checkSymbol records a problem if the given global symbol name cannot be parsed.
+func (v *indexValidator) checkSymbol(location, symbol string) bool {
+	if symbol == "" || scip.IsLocalSymbol(symbol) {
+		return true
+	}
+
+	if _, err := scip.ParseSymbol(symbol); err != nil {
+		v.add(problemMalformedSymbol, fmt.Sprintf("%s: %q", location, symbol))
+		return false
+	}
+
+	return true
+}
+
+func (v *indexValidator) add(code, sample string) {
+	problem, ok := v.problems[code]
+	if !ok {
+		kind := validationProblemKinds[code]
+		problem = &shared.UploadValidationProblem{
+			Code:        code,
+			Severity:    kind.severity,
+			Description: kind.description,
+		}
+		v.problems[code] = problem
+	}
+
+	problem.Count++
+	if sample != "" && len(problem.Samples) < maxValidationSamples {
+		problem.Samples = append(problem.Samples, sample)
+	}
+}
+
+// report returns the problems found in the index. Errors are ordered before warnings, and
+// problems of the same severity are ordered by decreasing frequency.
+func (v *indexValidator) report() shared.UploadValidationReport {
+	if !v.seenMetadata {
+		v.add(problemMissingMetadata, "")
+	}
+
+	for _, symbol := range sortedKeys(v.documentedSymbols) {
+		if !v.definedSymbols.Has(symbol) {
+			v.add(problemSymbolWithoutDefinition, fmt.Sprintf("%s: %s", v.documentedSymbols[symbol], symbol))
+		}
+	}
+
+	var report shared.UploadValidationReport
+	for _, problem := range v.problems {
+		switch problem.Severity {
+		case shared.UploadValidationSeverityError:
+			report.ErrorCount += problem.Count
+		case shared.UploadValidationSeverityWarning:
+			report.WarningCount += problem.Count
+		}
+
+		report.Problems = append(report.Problems, *problem)
+	}
+
+	sort.Slice(report.Problems, func(i, j int) bool {
+		pi, pj := report.Problems[i], report.Problems[j]
+		if pi.Severity != pj.Severity {
+			return pi.Severity == shared.UploadValidationSeverityError
+		}
+		if pi.Count != pj.Count {
+			return pi.Count > pj.Count
+		}
+
+		return pi.Code < pj.Code
+	})
+
+	return report
+}
+
+// isValidRelativePath returns true if the given path is a non-empty, slash-separated path
+// that does not escape the directory it is relative to.
+func isValidRelativePath(relativePath string) bool {
+	if relativePath == "" || strings.HasPrefix(relativePath, "/") {
+		return false
+	}
+
+	cleaned := path.Clean(relativePath)
+	return cleaned != ".." && !strings.HasPrefix(cleaned, "../")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,163 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+)
+
+func TestIndexValidator(t *testing.T) {
+	const (
+		fooSymbol = "scip-go gomod example 1.0 foo/Foo()."
+		barSymbol = "scip-go gomod example 1.0 foo/Bar()."
+	)
+
+	v := newIndexValidator()
+	v.visitMetadata(&scip.Metadata{})
+	v.visitDocument(&scip.Document{
+		RelativePath: "foo/foo.go",
+		Text:         "package foo\n\nfunc Foo() {}\n",
+		Symbols: []*scip.SymbolInformation{
+			{Symbol: fooSymbol},
+			{Symbol: barSymbol},
+		},
+		Occurrences: []*scip.Occurrence{
+			{Range: []int32{2, 5, 8}, Symbol: fooSymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+			{Range: []int32{2, 5, 2, 8}, Symbol: "local 0", SymbolRoles: int32(scip.SymbolRole_Definition)},
+			{Range: []int32{2, 10, 12}, Symbol: "local 0"},
+			{Range: []int32{2, 10, 12}, Symbol: "local 1"},
+			{Range: []int32{2, 5}, Symbol: fooSymbol},
+			{Range: []int32{2, 8, 5}, Symbol: fooSymbol},
+			{Range: []int32{10, 0, 3}, Symbol: fooSymbol},
+			{Range: []int32{2, 0, 40}, Symbol: fooSymbol},
+			{Range: []int32{0, 0, 7}, Symbol: "not a symbol"},
+		},
+	})
+	v.visitDocument(&scip.Document{RelativePath: "foo/foo.go"})
+	v.visitDocument(&scip.Document{RelativePath: "../outside.go"})
+	v.visitExternalSymbol(&scip.SymbolInformation{Symbol: "scip-go gomod fmt 1.0 fmt/Println()."})
+
+	expected := shared.UploadValidationReport{
+		ErrorCount:   6,
+		WarningCount: 4,
+		Problems: []shared.UploadValidationProblem{
+			{
+				Code:        problemInvalidRange,
+				Severity:    shared.UploadValidationSeverityError,
+				Description: validationProblemKinds[problemInvalidRange].description,
+				Count:       2,
+				Samples:     []string{"foo/foo.go: [2 5]", "foo/foo.go:3:9: [2 8 5]"},
+			},
+			{
+				Code:        problemOccurrenceOutOfBounds,
+				Severity:    shared.UploadValidationSeverityError,
+				Description: validationProblemKinds[problemOccurrenceOutOfBounds].description,
+				Count:       2,
+				Samples:     []string{"foo/foo.go:11:1: [10 0 3]", "foo/foo.go:3:1: [2 0 40]"},
+			},
+			{
+				Code:        problemInvalidDocumentPath,
+				Severity:    shared.UploadValidationSeverityError,
+				Description: validationProblemKinds[problemInvalidDocumentPath].description,
+				Count:       1,
+				Samples:     []string{`"../outside.go"`},
+			},
+			{
+				Code:        problemMalformedSymbol,
+				Severity:    shared.UploadValidationSeverityError,
+				Description: validationProblemKinds[problemMalformedSymbol].description,
+				Count:       1,
+				Samples:     []string{`foo/foo.go:1:1: "not a symbol"`},
+			},
+			{
+				Code:        problemDuplicateDocument,
+				Severity:    shared.UploadValidationSeverityWarning,
+				Description: validationProblemKinds[problemDuplicateDocument].description,
+				Count:       1,
+				Samples:     []string{"foo/foo.go"},
+			},
+			{
+				Code:        problemLocalSymbolWithoutDefinition,
+				Severity:    shared.UploadValidationSeverityWarning,
+				Description: validationProblemKinds[problemLocalSymbolWithoutDefinition].description,
+				Count:       1,
+				Samples:     []string{"foo/foo.go:3:11: local 1"},
+			},
+			{
+				Code:        problemMissingToolInfo,
+				Severity:    shared.UploadValidationSeverityWarning,
+				Description: validationProblemKinds[problemMissingToolInfo].description,
+				Count:       1,
+			},
+			{
+				Code:        problemSymbolWithoutDefinition,
+				Severity:    shared.UploadValidationSeverityWarning,
+				Description: validationProblemKinds[problemSymbolWithoutDefinition].description,
+				Count:       1,
+				Samples:     []string{"foo/foo.go: " + barSymbol},
+			},
+		},
+	}
+	if diff := cmp.Diff(expected, v.report()); diff != "" {
+		t.Errorf("unexpected report (-want +got):\n%s", diff)
+	}
+}
+
+func TestIndexValidatorWithoutDocumentText(t *testing.T) {
+	v := newIndexValidator()
+	v.visitMetadata(&scip.Metadata{ToolInfo: &scip.ToolInfo{Name: "scip-go"}})
+	v.visitDocument(&scip.Document{
+		RelativePath: "foo.go",
+		Occurrences: []*scip.Occurrence{
+			// Out-of-bounds ranges can't be detected without the document's text
+			{Range: []int32{1000, 0, 4000}, Symbol: "local 0", SymbolRoles: int32(scip.SymbolRole_Definition)},
+			// Malformed ranges are detected regardless
+			{Range: []int32{2, 8, 5}, Symbol: "local 0"},
+		},
+	})
+
+	expected := shared.UploadValidationReport{
+		ErrorCount: 1,
+		Problems: []shared.UploadValidationProblem{
+			{
+				Code:        problemInvalidRange,
+				Severity:    shared.UploadValidationSeverityError,
+				Description: validationProblemKinds[problemInvalidRange].description,
+				Count:       1,
+				Samples:     []string{"foo.go:3:9: [2 8 5]"},
+			},
+		},
+	}
+	if diff := cmp.Diff(expected, v.report()); diff != "" {
+		t.Errorf("unexpected report (-want +got):\n%s", diff)
+	}
+}
+
+func TestIndexValidatorMissingMetadata(t *testing.T) {
+	v := newIndexValidator()
+	v.visitDocument(&scip.Document{RelativePath: "foo.go"})
+
+	report := v.report()
+	if report.ErrorCount != 1 || len(report.Problems) != 1 || report.Problems[0].Code != problemMissingMetadata {
+		t.Errorf("expected a single missing metadata error, have %+v", report)
+	}
+}
+
+func TestIsValidRelativePath(t *testing.T) {
+	for relativePath, expected := range map[string]bool{
+		"foo.go":         true,
+		"foo/bar.go":     true,
+		"foo/../bar.go":  true,
+		"":               false,
+		"/foo.go":        false,
+		"../foo.go":      false,
+		"foo/../../x.go": false,
+	} {
+		if valid := isValidRelativePath(relativePath); valid != expected {
+			t.Errorf("unexpected validity for %q. want=%v have=%v", relativePath, expected, valid)
+		}
+	}
+}
@@ -628,6 +628,7 @@ func sanitizeCommitInput(
 
 		for commit, refDescriptions := range refDescriptions {
 			isDefaultBranch := false
+			isTag := false
 			names := make([]string, 0, len(refDescriptions))
 
 			for _, refDescription := range refDescriptions {
@@ -639,6 +640,9 @@ func sanitizeCommitInput(
 						continue
 					}
 				}
+				if refDescription.Type == gitdomain.RefTypeTag {
+					isTag = true
+				}
 
 				names = append(names, refDescription.Name)
 			}
@@ -657,6 +661,7 @@ func sanitizeCommitInput(
 					uploadMeta.UploadID,
 					strings.Join(names, ","),
 					isDefaultBranch,
+					isTag,
 				) {
 					return
 				}
@@ -701,7 +706,7 @@ func (s *store) writeVisibleUploads(ctx context.Context, sanitizedInput *sanitiz
 		batch.MaxNumPostgresParameters,
 		"t_lsif_nearest_uploads", []string{"commit_bytea", "uploads"},
 		"t_lsif_nearest_uploads_links", []string{"commit_bytea", "ancestor_commit_bytea", "distance"},
-		"t_lsif_uploads_visible_at_tip", []string{"upload_id", "branch_or_tag_name", "is_default_branch"},
+		"t_lsif_uploads_visible_at_tip", []string{"upload_id", "branch_or_tag_name", "is_default_branch", "is_tag"},
 		func(nearestUploadsInserter, nearestUploadsLinksInserter, uploadsVisibleAtTipInserter *batch.Inserter) error {
 			return populateInsertersFromChannels(
 				ctx,
@@ -896,8 +901,8 @@ func (s *store) persistUploadsVisibleAtTip(ctx context.Context, repositoryID int
 }
 
 const uploadsVisibleAtTipInsertQuery = `
-INSERT INTO lsif_uploads_visible_at_tip
-SELECT %s, source.upload_id, source.branch_or_tag_name, source.is_default_branch
+INSERT INTO lsif_uploads_visible_at_tip (repository_id, upload_id, branch_or_tag_name, is_default_branch, is_tag)
+SELECT %s, source.upload_id, source.branch_or_tag_name, source.is_default_branch, source.is_tag
 FROM t_lsif_uploads_visible_at_tip source
 WHERE NOT EXISTS (
 	SELECT 1
@@ -906,7 +911,8 @@ WHERE NOT EXISTS (
 		vat.repository_id = %s AND
 		vat.upload_id = source.upload_id AND
 		vat.branch_or_tag_name = source.branch_or_tag_name AND
-		vat.is_default_branch = source.is_default_branch
+		vat.is_default_branch = source.is_default_branch AND
+		vat.is_tag = source.is_tag
 )
 `
 
@@ -920,7 +926,8 @@ WHERE
 		WHERE
 			source.upload_id = vat.upload_id AND
 			source.branch_or_tag_name = vat.branch_or_tag_name AND
-			source.is_default_branch = vat.is_default_branch
+			source.is_default_branch = vat.is_default_branch AND
+			source.is_tag = vat.is_tag
 	)
 `
 
@@ -998,7 +1005,8 @@ const temporaryUploadsVisibleAtTipTableQuery = `
 CREATE TEMPORARY TABLE t_lsif_uploads_visible_at_tip (
 	upload_id integer NOT NULL,
 	branch_or_tag_name text NOT NULL,
-	is_default_branch boolean NOT NULL
+	is_default_branch boolean NOT NULL,
+	is_tag boolean NOT NULL
 ) ON COMMIT DROP
 `
 
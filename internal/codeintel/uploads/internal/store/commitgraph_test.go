@@ -218,6 +218,10 @@ func TestCalculateVisibleUploadsNonDefaultBranches(t *testing.T) {
 	if diff := cmp.Diff([]int{2, 3, 5}, getProtectedUploads(t, db, 50)); diff != "" {
 		t.Errorf("unexpected protected uploads (-want +got):\n%s", diff)
 	}
+
+	if diff := cmp.Diff([]int{2}, getUploadsVisibleAtTag(t, db, 50)); diff != "" {
+		t.Errorf("unexpected uploads visible at tag (-want +got):\n%s", diff)
+	}
 }
 
 func TestCalculateVisibleUploadsNonDefaultBranchesWithCustomRetentionConfiguration(t *testing.T) {
@@ -1482,6 +1486,20 @@ func getUploadsVisibleAtTip(t testing.TB, db database.DB, repositoryID int) []in
 	return ids
 }
 
+func getUploadsVisibleAtTag(t testing.TB, db database.DB, repositoryID int) []int {
+	query := sqlf.Sprintf(
+		`SELECT DISTINCT upload_id FROM lsif_uploads_visible_at_tip WHERE repository_id = %s AND is_tag ORDER BY upload_id`,
+		repositoryID,
+	)
+
+	ids, err := basestore.ScanInts(db.QueryContext(context.Background(), query.Query(sqlf.PostgresBindVar), query.Args()...))
+	if err != nil {
+		t.Fatalf("unexpected error getting uploads visible at tag: %s", err)
+	}
+
+	return ids
+}
+
 func assertCommitsVisibleFromUploads(t *testing.T, store Store, uploads []shared.Upload, expectedVisibleUploads map[string][]int) {
 	expectedVisibleCommits := map[int][]string{}
 	for commit, uploadIDs := range expectedVisibleUploads {
@@ -2,14 +2,18 @@ package store
 
 import (
 	"context"
+	"database/sql"
 	"os"
 	"sort"
 	"time"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 )
@@ -131,6 +135,204 @@ const updateUploadRetentionQuery = `
 UPDATE lsif_uploads SET %s WHERE id IN (%s)
 `
 
+// GetStorageBudgetCandidates returns the set of completed, unexpired uploads that do not fit within the
+// given per-repository or global storage budgets. Uploads are retained in order of value: uploads visible
+// from the tip of the default branch (which are never returned), then uploads visible from a tag (e.g., a
+// tagged release), then uploads visible from the tip of any other branch, then all remaining uploads. Within
+// each tier, the most queried uploads are retained first, followed by uploads referenced by a larger number
+// of other uploads, followed by the most recently processed uploads.
+//
+// Candidates are returned least valuable first. Expiring a prefix of this list does not change whether or
+// not the remaining candidates exceed the budget, so this method can be invoked repeatedly with a small
+// limit to incrementally apply the same decisions presented in a dry-run report.
+func (s *store) GetStorageBudgetCandidates(ctx context.Context, opts shared.GetStorageBudgetCandidatesOptions) (_ []shared.StorageBudgetCandidate, _ int, _ int64, err error) {
+	ctx, _, endObservation := s.operations.getStorageBudgetCandidates.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int64("repositoryBudget", opts.RepositoryBudget),
+		attribute.Int64("globalBudget", opts.GlobalBudget),
+		attribute.Int("repositoryID", opts.RepositoryID),
+		attribute.Int("limit", opts.Limit),
+		attribute.Int("offset", opts.Offset),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	var budgetConds []*sqlf.Query
+	if opts.RepositoryBudget > 0 {
+		budgetConds = append(budgetConds, sqlf.Sprintf("cs.repository_cumulative_size > %s", opts.RepositoryBudget))
+	}
+	if opts.GlobalBudget > 0 {
+		budgetConds = append(budgetConds, sqlf.Sprintf("cs.global_cumulative_size > %s", opts.GlobalBudget))
+	}
+	if len(budgetConds) == 0 {
+		return nil, 0, 0, nil
+	}
+
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.RepositoryID != 0 {
+		conds = append(conds, sqlf.Sprintf("c.repository_id = %s", opts.RepositoryID))
+	}
+
+	var (
+		candidates []shared.StorageBudgetCandidate
+		totalCount int
+		totalSize  int64
+	)
+	err = s.withTransaction(ctx, func(tx *store) error {
+		candidates, err = scanStorageBudgetCandidates(tx.db.Query(ctx, sqlf.Sprintf(
+			getStorageBudgetCandidatesQuery,
+			sqlf.Join(budgetConds, " OR "),
+			sqlf.Join(conds, " AND "),
+			opts.Limit,
+			opts.Offset,
+		)))
+		if err != nil {
+			return err
+		}
+
+		totalCount, totalSize, err = scanCountAndSize(tx.db.Query(ctx, sqlf.Sprintf(
+			getStorageBudgetCandidatesCountQuery,
+			sqlf.Join(budgetConds, " OR "),
+			sqlf.Join(conds, " AND "),
+		)))
+		return err
+	})
+
+	return candidates, totalCount, totalSize, err
+}
+
+const storageBudgetCandidatesCTEs = `
+WITH
+ranked_uploads AS (
+	SELECT
+		u.id,
+		u.repository_id,
+		u.commit,
+		u.root,
+		u.indexer,
+		u.finished_at,
+		COALESCE(u.uncompressed_size, u.upload_size, 0) AS size,
+		COALESCE(qc.query_count, 0) AS query_count,
+		COALESCE(u.reference_count, 0) AS reference_count,
+		CASE
+			-- Uploads that have not yet been reflected in the commit graph do not yet have
+			-- accurate visibility data; treat them as if they were visible from the default
+			-- branch so that they are not expired before they're given a chance.
+			WHEN (u.finished_at < (SELECT ldr.updated_at FROM lsif_dirty_repositories ldr WHERE ldr.repository_id = u.repository_id)) IS NOT TRUE THEN 0
+			WHEN EXISTS (SELECT 1 FROM lsif_uploads_visible_at_tip t WHERE t.upload_id = u.id AND t.is_default_branch) THEN 0
+			WHEN EXISTS (SELECT 1 FROM lsif_uploads_visible_at_tip t WHERE t.upload_id = u.id AND t.is_tag) THEN 1
+			WHEN EXISTS (SELECT 1 FROM lsif_uploads_visible_at_tip t WHERE t.upload_id = u.id) THEN 2
+			ELSE 3
+		END AS tier
+	FROM lsif_uploads u
+	JOIN repo r ON r.id = u.repository_id
+	LEFT JOIN codeintel_upload_query_counts qc ON qc.upload_id = u.id
+	WHERE
+		u.state = 'completed' AND
+		NOT u.expired AND
+		r.deleted_at IS NULL
+),
+cumulative_sizes AS (
+	SELECT
+		ru.*,
+		SUM(ru.size) OVER (
+			PARTITION BY ru.repository_id
+			ORDER BY ru.tier, ru.query_count DESC, ru.reference_count DESC, ru.finished_at DESC, ru.id DESC
+		) AS repository_cumulative_size,
+		SUM(ru.size) OVER (
+			ORDER BY ru.tier, ru.query_count DESC, ru.reference_count DESC, ru.finished_at DESC, ru.id DESC
+		) AS global_cumulative_size
+	FROM ranked_uploads ru
+),
+candidates AS (
+	SELECT cs.*
+	FROM cumulative_sizes cs
+	WHERE cs.tier > 0 AND (%s)
+)
+`
+
+const getStorageBudgetCandidatesQuery = storageBudgetCandidatesCTEs + `
+SELECT
+	c.id,
+	c.repository_id,
+	r.name,
+	c.commit,
+	c.root,
+	c.indexer,
+	c.size,
+	c.query_count,
+	c.reference_count,
+	c.tier,
+	c.finished_at
+FROM candidates c
+JOIN repo r ON r.id = c.repository_id
+WHERE %s
+ORDER BY c.tier DESC, c.query_count, c.reference_count, c.finished_at, c.id
+LIMIT %s OFFSET %s
+`
+
+const getStorageBudgetCandidatesCountQuery = storageBudgetCandidatesCTEs + `
+SELECT COUNT(*), COALESCE(SUM(c.size), 0)
+FROM candidates c
+WHERE %s
+`
+
+var scanStorageBudgetCandidates = basestore.NewSliceScanner(func(s dbutil.Scanner) (c shared.StorageBudgetCandidate, err error) {
+	err = s.Scan(
+		&c.UploadID,
+		&c.RepositoryID,
+		&c.RepositoryName,
+		&c.Commit,
+		&c.Root,
+		&c.Indexer,
+		&c.Size,
+		&c.QueryCount,
+		&c.ReferenceCount,
+		&c.Tier,
+		&c.FinishedAt,
+	)
+	return c, err
+})
+
+func scanCountAndSize(rows *sql.Rows, queryErr error) (count int, size int64, err error) {
+	if queryErr != nil {
+		return 0, 0, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	for rows.Next() {
+		if err := rows.Scan(&count, &size); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return count, size, nil
+}
+
+// IncrementUploadQueryCounts records that the given uploads were used to answer a code navigation
+// request. These counts are used to rank uploads when enforcing storage budgets.
+func (s *store) IncrementUploadQueryCounts(ctx context.Context, uploadIDs []int) (err error) {
+	ctx, _, endObservation := s.operations.incrementUploadQueryCounts.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("numUploadIDs", len(uploadIDs)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(uploadIDs) == 0 {
+		return nil
+	}
+
+	return s.db.Exec(ctx, sqlf.Sprintf(incrementUploadQueryCountsQuery, pq.Array(uploadIDs)))
+}
+
+const incrementUploadQueryCountsQuery = `
+INSERT INTO codeintel_upload_query_counts (upload_id, query_count, last_queried_at)
+SELECT u.id, 1, NOW()
+FROM lsif_uploads u
+WHERE u.id = ANY(%s)
+ORDER BY u.id
+ON CONFLICT (upload_id) DO UPDATE SET
+	query_count = codeintel_upload_query_counts.query_count + 1,
+	last_queried_at = EXCLUDED.last_queried_at
+`
+
 // SoftDeleteExpiredUploads marks upload records that are both expired and have no references
 // as deleted. The associated repositories will be marked as dirty so that their commit graphs
 // are updated in the near future.
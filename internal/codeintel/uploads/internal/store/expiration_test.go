@@ -4,14 +4,17 @@ import (
 	"context"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
 	"github.com/sourcegraph/log/logtest"
 
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
 )
 
 func TestSoftDeleteExpiredUploads(t *testing.T) {
@@ -94,6 +97,159 @@ func TestSoftDeleteExpiredUploads(t *testing.T) {
 	}
 }
 
+func TestGetStorageBudgetCandidates(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	store := New(&observation.TestContext, db)
+
+	t1 := time.Unix(1587396557, 0).UTC()
+	t2 := t1.Add(time.Minute * 1)
+	t3 := t1.Add(time.Minute * 2)
+	t4 := t1.Add(time.Minute * 3)
+
+	insertUploads(t, db,
+		shared.Upload{ID: 1, RepositoryID: 50, FinishedAt: pointers.Ptr(t1), UploadSize: pointers.Ptr(int64(100))}, // default branch
+		shared.Upload{ID: 2, RepositoryID: 50, FinishedAt: pointers.Ptr(t1), UploadSize: pointers.Ptr(int64(50))},  // non-default branch
+		shared.Upload{ID: 3, RepositoryID: 50, FinishedAt: pointers.Ptr(t3), UploadSize: pointers.Ptr(int64(40))},
+		shared.Upload{ID: 4, RepositoryID: 50, FinishedAt: pointers.Ptr(t2), UploadSize: pointers.Ptr(int64(40))},
+		shared.Upload{ID: 5, RepositoryID: 50, FinishedAt: pointers.Ptr(t1), UploadSize: pointers.Ptr(int64(40))}, // referenced
+		shared.Upload{ID: 6, RepositoryID: 50, FinishedAt: pointers.Ptr(t1), UploadSize: pointers.Ptr(int64(500)), State: "failed"},
+		shared.Upload{ID: 7, RepositoryID: 50, FinishedAt: pointers.Ptr(t1), UploadSize: pointers.Ptr(int64(20))},  // tag
+		shared.Upload{ID: 10, RepositoryID: 51, FinishedAt: pointers.Ptr(t1), UploadSize: pointers.Ptr(int64(30))}, // default branch
+		shared.Upload{ID: 11, RepositoryID: 51, FinishedAt: pointers.Ptr(t1), UploadSize: pointers.Ptr(int64(30))},
+		shared.Upload{ID: 12, RepositoryID: 51, FinishedAt: pointers.Ptr(t4), UploadSize: pointers.Ptr(int64(30))}, // not yet in commit graph
+	)
+	insertVisibleAtTip(t, db, 50, 1)
+	insertVisibleAtTipInternal(t, db, 50, false, 2)
+	insertVisibleAtTag(t, db, 50, 7)
+	insertVisibleAtTip(t, db, 51, 10)
+
+	// Upload 4 has been queried twice; the unknown upload is ignored
+	for i := 0; i < 2; i++ {
+		if err := store.IncrementUploadQueryCounts(ctx, []int{4, 404}); err != nil {
+			t.Fatalf("unexpected error incrementing upload query counts: %s", err)
+		}
+	}
+
+	for _, query := range []*sqlf.Query{
+		sqlf.Sprintf(`UPDATE lsif_uploads SET reference_count = 5 WHERE id = 5`),
+		sqlf.Sprintf(`INSERT INTO lsif_dirty_repositories(repository_id, update_token, dirty_token, updated_at) VALUES (50, 10, 10, %s), (51, 10, 10, %s)`, t4, t3),
+	} {
+		if _, err := db.ExecContext(ctx, query.Query(sqlf.PostgresBindVar), query.Args()...); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	testCases := []struct {
+		name               string
+		opts               shared.GetStorageBudgetCandidatesOptions
+		expectedIDs        []int
+		expectedTotalCount int
+		expectedTotalSize  int64
+	}{
+		{
+			name: "no budget",
+			opts: shared.GetStorageBudgetCandidatesOptions{Limit: 10},
+		},
+		{
+			// repository 50: 1 (100) -> 7 (120) -> 2 (170) -> 4 (210) -> 5 (250) -> 3 (290)
+			// repository 51: 10 (30) -> 12 (60) -> 11 (90)
+			name:               "repository budget",
+			opts:               shared.GetStorageBudgetCandidatesOptions{RepositoryBudget: 200, Limit: 10},
+			expectedIDs:        []int{3, 5, 4},
+			expectedTotalCount: 3,
+			expectedTotalSize:  120,
+		},
+		{
+			name:               "tags are retained before other branches",
+			opts:               shared.GetStorageBudgetCandidatesOptions{RepositoryBudget: 150, Limit: 10},
+			expectedIDs:        []int{3, 5, 4, 2},
+			expectedTotalCount: 4,
+			expectedTotalSize:  170,
+		},
+		{
+			// 12 (30) -> 10 (60) -> 1 (160) -> 7 (180) -> 2 (230) -> 4 (270) -> 5 (310) -> 3 (350) -> 11 (380)
+			name:               "global budget",
+			opts:               shared.GetStorageBudgetCandidatesOptions{GlobalBudget: 250, Limit: 10},
+			expectedIDs:        []int{11, 3, 5, 4},
+			expectedTotalCount: 4,
+			expectedTotalSize:  150,
+		},
+		{
+			name:               "global budget with pagination",
+			opts:               shared.GetStorageBudgetCandidatesOptions{GlobalBudget: 250, Limit: 2, Offset: 1},
+			expectedIDs:        []int{3, 5},
+			expectedTotalCount: 4,
+			expectedTotalSize:  150,
+		},
+		{
+			name:               "global budget for a single repository",
+			opts:               shared.GetStorageBudgetCandidatesOptions{GlobalBudget: 250, RepositoryID: 51, Limit: 10},
+			expectedIDs:        []int{11},
+			expectedTotalCount: 1,
+			expectedTotalSize:  30,
+		},
+		{
+			name:               "default branch is never expired",
+			opts:               shared.GetStorageBudgetCandidatesOptions{RepositoryBudget: 1, Limit: 10},
+			expectedIDs:        []int{11, 3, 5, 4, 2, 7},
+			expectedTotalCount: 6,
+			expectedTotalSize:  220,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			candidates, totalCount, totalSize, err := store.GetStorageBudgetCandidates(ctx, testCase.opts)
+			if err != nil {
+				t.Fatalf("unexpected error getting storage budget candidates: %s", err)
+			}
+
+			var ids []int
+			for _, candidate := range candidates {
+				ids = append(ids, candidate.UploadID)
+			}
+			if diff := cmp.Diff(testCase.expectedIDs, ids); diff != "" {
+				t.Errorf("unexpected candidates (-want +got):\n%s", diff)
+			}
+			if totalCount != testCase.expectedTotalCount {
+				t.Errorf("unexpected total count. want=%d have=%d", testCase.expectedTotalCount, totalCount)
+			}
+			if totalSize != testCase.expectedTotalSize {
+				t.Errorf("unexpected total size. want=%d have=%d", testCase.expectedTotalSize, totalSize)
+			}
+		})
+	}
+
+	t.Run("query counts and tiers", func(t *testing.T) {
+		candidates, _, _, err := store.GetStorageBudgetCandidates(ctx, shared.GetStorageBudgetCandidatesOptions{RepositoryID: 50, RepositoryBudget: 1, Limit: 10})
+		if err != nil {
+			t.Fatalf("unexpected error getting storage budget candidates: %s", err)
+		}
+
+		type candidateSummary struct {
+			QueryCount int64
+			Tier       shared.StorageBudgetRetentionTier
+		}
+		summaries := map[int]candidateSummary{}
+		for _, candidate := range candidates {
+			summaries[candidate.UploadID] = candidateSummary{QueryCount: candidate.QueryCount, Tier: candidate.Tier}
+		}
+
+		expected := map[int]candidateSummary{
+			2: {Tier: shared.StorageBudgetTierBranch},
+			3: {Tier: shared.StorageBudgetTierOther},
+			4: {QueryCount: 2, Tier: shared.StorageBudgetTierOther},
+			5: {Tier: shared.StorageBudgetTierOther},
+			7: {Tier: shared.StorageBudgetTierTag},
+		}
+		if diff := cmp.Diff(expected, summaries); diff != "" {
+			t.Errorf("unexpected candidates (-want +got):\n%s", diff)
+		}
+	})
+}
+
 func TestSoftDeleteExpiredUploadsViaTraversal(t *testing.T) {
 	logger := logtest.Scoped(t)
 	db := database.NewDB(logger, dbtest.NewDB(t))
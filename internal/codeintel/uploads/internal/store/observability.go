@@ -47,6 +47,8 @@ type operations struct {
 	persistNearestUploadsLinks           *observation.Operation
 	persistUploadsVisibleAtTip           *observation.Operation
 	updateUploadRetention                *observation.Operation
+	getStorageBudgetCandidates           *observation.Operation
+	incrementUploadQueryCounts           *observation.Operation
 	updateCommittedAt                    *observation.Operation
 	sourcedCommitsWithoutCommittedAt     *observation.Operation
 	deleteUploadsWithoutRepository       *observation.Operation
@@ -78,6 +80,10 @@ type operations struct {
 	// Audit logs
 	deleteOldAuditLogs *observation.Operation
 
+	// Validation reports
+	insertUploadValidationReport *observation.Operation
+	getUploadValidationReport    *observation.Operation
+
 	// Dependencies
 	insertDependencySyncingJob *observation.Operation
 
@@ -149,6 +155,8 @@ func newOperations(observationCtx *observation.Context) *operations {
 		getVisibleUploadsMatchingMonikers:    op("GetVisibleUploadsMatchingMonikers"),
 		updateUploadsVisibleToCommits:        op("UpdateUploadsVisibleToCommits"),
 		updateUploadRetention:                op("UpdateUploadRetention"),
+		getStorageBudgetCandidates:           op("GetStorageBudgetCandidates"),
+		incrementUploadQueryCounts:           op("IncrementUploadQueryCounts"),
 		updateCommittedAt:                    op("UpdateCommittedAt"),
 		sourcedCommitsWithoutCommittedAt:     op("SourcedCommitsWithoutCommittedAt"),
 		deleteUploadsStuckUploading:          op("DeleteUploadsStuckUploading"),
@@ -185,6 +193,10 @@ func newOperations(observationCtx *observation.Context) *operations {
 		// Audit logs
 		deleteOldAuditLogs: op("DeleteOldAuditLogs"),
 
+		// Validation reports
+		insertUploadValidationReport: op("InsertUploadValidationReport"),
+		getUploadValidationReport:    op("GetUploadValidationReport"),
+
 		// Dependencies
 		insertDependencySyncingJob: op("InsertDependencySyncingJob"),
 
@@ -49,6 +49,8 @@ type Store interface {
 	MarkQueued(ctx context.Context, id int, uploadSize *int64) error
 	MarkFailed(ctx context.Context, id int, reason string) error
 	DeleteOverlappingDumps(ctx context.Context, repositoryID int, commit, root, indexer string) error
+	InsertUploadValidationReport(ctx context.Context, uploadID int, report shared.UploadValidationReport) error
+	GetUploadValidationReport(ctx context.Context, uploadID int) (shared.UploadValidationReport, bool, error)
 	WorkerutilStore(observationCtx *observation.Context) dbworkerstore.Store[shared.Upload]
 
 	// Dependencies
@@ -77,6 +79,8 @@ type Store interface {
 	GetLastUploadRetentionScanForRepository(ctx context.Context, repositoryID int) (*time.Time, error)
 	SetRepositoriesForRetentionScan(ctx context.Context, processDelay time.Duration, limit int) ([]int, error)
 	UpdateUploadRetention(ctx context.Context, protectedIDs, expiredIDs []int) error
+	GetStorageBudgetCandidates(ctx context.Context, opts shared.GetStorageBudgetCandidatesOptions) ([]shared.StorageBudgetCandidate, int, int64, error)
+	IncrementUploadQueryCounts(ctx context.Context, uploadIDs []int) error
 	SoftDeleteExpiredUploads(ctx context.Context, batchSize int) (int, int, error)
 	SoftDeleteExpiredUploadsViaTraversal(ctx context.Context, maxTraversal int) (int, int, error)
 
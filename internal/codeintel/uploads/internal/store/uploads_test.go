@@ -1129,3 +1129,20 @@ func insertVisibleAtTipInternal(t testing.TB, db database.DB, repositoryID int,
 		t.Fatalf("unexpected error while updating uploads visible at tip: %s", err)
 	}
 }
+
+// insertVisibleAtTag populates rows of the lsif_uploads_visible_at_tip table for the given repository
+// with the given identifiers. Each upload is assumed to be visible from a tag.
+func insertVisibleAtTag(t testing.TB, db database.DB, repositoryID int, uploadIDs ...int) {
+	var rows []*sqlf.Query
+	for _, uploadID := range uploadIDs {
+		rows = append(rows, sqlf.Sprintf("(%s, %s, false, true)", repositoryID, uploadID))
+	}
+
+	query := sqlf.Sprintf(
+		`INSERT INTO lsif_uploads_visible_at_tip (repository_id, upload_id, is_default_branch, is_tag) VALUES %s`,
+		sqlf.Join(rows, ","),
+	)
+	if _, err := db.ExecContext(context.Background(), query.Query(sqlf.PostgresBindVar), query.Args()...); err != nil {
+		t.Fatalf("unexpected error while updating uploads visible at tip: %s", err)
+	}
+}
@@ -0,0 +1,95 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/keegancsmith/sqlf"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// InsertUploadValidationReport stores the given validation report for the given upload, replacing
+// any report stored by a previous attempt to process the same upload.
+func (s *store) InsertUploadValidationReport(ctx context.Context, uploadID int, report shared.UploadValidationReport) (err error) {
+	ctx, _, endObservation := s.operations.insertUploadValidationReport.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("uploadID", uploadID),
+		attribute.Int("errorCount", report.ErrorCount),
+		attribute.Int("warningCount", report.WarningCount),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	problems := report.Problems
+	if problems == nil {
+		problems = []shared.UploadValidationProblem{}
+	}
+	serializedProblems, err := json.Marshal(problems)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Exec(ctx, sqlf.Sprintf(
+		insertUploadValidationReportQuery,
+		uploadID,
+		report.ErrorCount,
+		report.WarningCount,
+		serializedProblems,
+	))
+}
+
+const insertUploadValidationReportQuery = `
+INSERT INTO lsif_uploads_validation_reports (upload_id, error_count, warning_count, problems)
+VALUES (%s, %s, %s, %s)
+ON CONFLICT (upload_id) DO UPDATE SET
+	error_count = EXCLUDED.error_count,
+	warning_count = EXCLUDED.warning_count,
+	problems = EXCLUDED.problems,
+	created_at = NOW()
+`
+
+// GetUploadValidationReport returns the validation report stored for the given upload. A false-valued
+// flag is returned if the upload has not (yet) been validated.
+func (s *store) GetUploadValidationReport(ctx context.Context, uploadID int) (_ shared.UploadValidationReport, _ bool, err error) {
+	ctx, _, endObservation := s.operations.getUploadValidationReport.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("uploadID", uploadID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return scanFirstUploadValidationReport(s.db.Query(ctx, sqlf.Sprintf(getUploadValidationReportQuery, uploadID)))
+}
+
+const getUploadValidationReportQuery = `
+SELECT
+	r.upload_id,
+	r.error_count,
+	r.warning_count,
+	r.problems,
+	r.created_at
+FROM lsif_uploads_validation_reports r
+WHERE r.upload_id = %s
+`
+
+func scanUploadValidationReport(s dbutil.Scanner) (report shared.UploadValidationReport, _ error) {
+	var serializedProblems []byte
+	if err := s.Scan(
+		&report.UploadID,
+		&report.ErrorCount,
+		&report.WarningCount,
+		&serializedProblems,
+		&report.CreatedAt,
+	); err != nil {
+		return report, err
+	}
+
+	if err := json.Unmarshal(serializedProblems, &report.Problems); err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
+
+var scanFirstUploadValidationReport = basestore.NewFirstScanner(scanUploadValidationReport)
@@ -0,0 +1,67 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestUploadValidationReports(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	store := New(&observation.TestContext, db)
+	ctx := context.Background()
+
+	insertUploads(t, db, shared.Upload{ID: 50, State: "processing"})
+
+	if _, exists, err := store.GetUploadValidationReport(ctx, 50); err != nil {
+		t.Fatalf("unexpected error getting validation report: %s", err)
+	} else if exists {
+		t.Fatalf("expected no validation report")
+	}
+
+	for _, report := range []shared.UploadValidationReport{
+		{ErrorCount: 7},
+		{
+			ErrorCount:   1,
+			WarningCount: 2,
+			Problems: []shared.UploadValidationProblem{
+				{Code: "INVALID_RANGE", Severity: shared.UploadValidationSeverityError, Count: 1, Samples: []string{"foo.go:1:2"}},
+				{Code: "DUPLICATE_DOCUMENT", Severity: shared.UploadValidationSeverityWarning, Count: 2, Samples: []string{"bar.go", "baz.go"}},
+			},
+		},
+	} {
+		// The second insertion replaces the first
+		if err := store.InsertUploadValidationReport(ctx, 50, report); err != nil {
+			t.Fatalf("unexpected error inserting validation report: %s", err)
+		}
+	}
+
+	expected := shared.UploadValidationReport{
+		UploadID:     50,
+		ErrorCount:   1,
+		WarningCount: 2,
+		Problems: []shared.UploadValidationProblem{
+			{Code: "INVALID_RANGE", Severity: shared.UploadValidationSeverityError, Count: 1, Samples: []string{"foo.go:1:2"}},
+			{Code: "DUPLICATE_DOCUMENT", Severity: shared.UploadValidationSeverityWarning, Count: 2, Samples: []string{"bar.go", "baz.go"}},
+		},
+	}
+
+	report, exists, err := store.GetUploadValidationReport(ctx, 50)
+	if err != nil {
+		t.Fatalf("unexpected error getting validation report: %s", err)
+	} else if !exists {
+		t.Fatalf("expected validation report")
+	}
+	if diff := cmp.Diff(expected, report, cmpopts.IgnoreFields(shared.UploadValidationReport{}, "CreatedAt")); diff != "" {
+		t.Errorf("unexpected validation report (-want +got):\n%s", diff)
+	}
+}
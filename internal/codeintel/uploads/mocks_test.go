@@ -124,6 +124,10 @@ type MockStore struct {
 	// object controlling the behavior of the method
 	// GetRepositoriesMaxStaleAge.
 	GetRepositoriesMaxStaleAgeFunc *StoreGetRepositoriesMaxStaleAgeFunc
+	// GetStorageBudgetCandidatesFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetStorageBudgetCandidates.
+	GetStorageBudgetCandidatesFunc *StoreGetStorageBudgetCandidatesFunc
 	// GetUploadByIDFunc is an instance of a mock function object
 	// controlling the behavior of the method GetUploadByID.
 	GetUploadByIDFunc *StoreGetUploadByIDFunc
@@ -131,6 +135,10 @@ type MockStore struct {
 	// object controlling the behavior of the method
 	// GetUploadIDsWithReferences.
 	GetUploadIDsWithReferencesFunc *StoreGetUploadIDsWithReferencesFunc
+	// GetUploadValidationReportFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetUploadValidationReport.
+	GetUploadValidationReportFunc *StoreGetUploadValidationReportFunc
 	// GetUploadsFunc is an instance of a mock function object controlling
 	// the behavior of the method GetUploads.
 	GetUploadsFunc *StoreGetUploadsFunc
@@ -157,6 +165,10 @@ type MockStore struct {
 	// HasRepositoryFunc is an instance of a mock function object
 	// controlling the behavior of the method HasRepository.
 	HasRepositoryFunc *StoreHasRepositoryFunc
+	// IncrementUploadQueryCountsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// IncrementUploadQueryCounts.
+	IncrementUploadQueryCountsFunc *StoreIncrementUploadQueryCountsFunc
 	// InsertDependencySyncingJobFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// InsertDependencySyncingJob.
@@ -164,6 +176,10 @@ type MockStore struct {
 	// InsertUploadFunc is an instance of a mock function object controlling
 	// the behavior of the method InsertUpload.
 	InsertUploadFunc *StoreInsertUploadFunc
+	// InsertUploadValidationReportFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// InsertUploadValidationReport.
+	InsertUploadValidationReportFunc *StoreInsertUploadValidationReportFunc
 	// MarkFailedFunc is an instance of a mock function object controlling
 	// the behavior of the method MarkFailed.
 	MarkFailedFunc *StoreMarkFailedFunc
@@ -388,6 +404,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetStorageBudgetCandidatesFunc: &StoreGetStorageBudgetCandidatesFunc{
+			defaultHook: func(context.Context, shared.GetStorageBudgetCandidatesOptions) (r0 []shared.StorageBudgetCandidate, r1 int, r2 int64, r3 error) {
+				return
+			},
+		},
 		GetUploadByIDFunc: &StoreGetUploadByIDFunc{
 			defaultHook: func(context.Context, int) (r0 shared.Upload, r1 bool, r2 error) {
 				return
@@ -398,6 +419,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetUploadValidationReportFunc: &StoreGetUploadValidationReportFunc{
+			defaultHook: func(context.Context, int) (r0 shared.UploadValidationReport, r1 bool, r2 error) {
+				return
+			},
+		},
 		GetUploadsFunc: &StoreGetUploadsFunc{
 			defaultHook: func(context.Context, shared.GetUploadsOptions) (r0 []shared.Upload, r1 int, r2 error) {
 				return
@@ -438,6 +464,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		IncrementUploadQueryCountsFunc: &StoreIncrementUploadQueryCountsFunc{
+			defaultHook: func(context.Context, []int) (r0 error) {
+				return
+			},
+		},
 		InsertDependencySyncingJobFunc: &StoreInsertDependencySyncingJobFunc{
 			defaultHook: func(context.Context, int) (r0 int, r1 error) {
 				return
@@ -448,6 +479,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		InsertUploadValidationReportFunc: &StoreInsertUploadValidationReportFunc{
+			defaultHook: func(context.Context, int, shared.UploadValidationReport) (r0 error) {
+				return
+			},
+		},
 		MarkFailedFunc: &StoreMarkFailedFunc{
 			defaultHook: func(context.Context, int, string) (r0 error) {
 				return
@@ -715,6 +751,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetRepositoriesMaxStaleAge")
 			},
 		},
+		GetStorageBudgetCandidatesFunc: &StoreGetStorageBudgetCandidatesFunc{
+			defaultHook: func(context.Context, shared.GetStorageBudgetCandidatesOptions) ([]shared.StorageBudgetCandidate, int, int64, error) {
+				panic("unexpected invocation of MockStore.GetStorageBudgetCandidates")
+			},
+		},
 		GetUploadByIDFunc: &StoreGetUploadByIDFunc{
 			defaultHook: func(context.Context, int) (shared.Upload, bool, error) {
 				panic("unexpected invocation of MockStore.GetUploadByID")
@@ -725,6 +766,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetUploadIDsWithReferences")
 			},
 		},
+		GetUploadValidationReportFunc: &StoreGetUploadValidationReportFunc{
+			defaultHook: func(context.Context, int) (shared.UploadValidationReport, bool, error) {
+				panic("unexpected invocation of MockStore.GetUploadValidationReport")
+			},
+		},
 		GetUploadsFunc: &StoreGetUploadsFunc{
 			defaultHook: func(context.Context, shared.GetUploadsOptions) ([]shared.Upload, int, error) {
 				panic("unexpected invocation of MockStore.GetUploads")
@@ -765,6 +811,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.HasRepository")
 			},
 		},
+		IncrementUploadQueryCountsFunc: &StoreIncrementUploadQueryCountsFunc{
+			defaultHook: func(context.Context, []int) error {
+				panic("unexpected invocation of MockStore.IncrementUploadQueryCounts")
+			},
+		},
 		InsertDependencySyncingJobFunc: &StoreInsertDependencySyncingJobFunc{
 			defaultHook: func(context.Context, int) (int, error) {
 				panic("unexpected invocation of MockStore.InsertDependencySyncingJob")
@@ -775,6 +826,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.InsertUpload")
 			},
 		},
+		InsertUploadValidationReportFunc: &StoreInsertUploadValidationReportFunc{
+			defaultHook: func(context.Context, int, shared.UploadValidationReport) error {
+				panic("unexpected invocation of MockStore.InsertUploadValidationReport")
+			},
+		},
 		MarkFailedFunc: &StoreMarkFailedFunc{
 			defaultHook: func(context.Context, int, string) error {
 				panic("unexpected invocation of MockStore.MarkFailed")
@@ -986,12 +1042,18 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetRepositoriesMaxStaleAgeFunc: &StoreGetRepositoriesMaxStaleAgeFunc{
 			defaultHook: i.GetRepositoriesMaxStaleAge,
 		},
+		GetStorageBudgetCandidatesFunc: &StoreGetStorageBudgetCandidatesFunc{
+			defaultHook: i.GetStorageBudgetCandidates,
+		},
 		GetUploadByIDFunc: &StoreGetUploadByIDFunc{
 			defaultHook: i.GetUploadByID,
 		},
 		GetUploadIDsWithReferencesFunc: &StoreGetUploadIDsWithReferencesFunc{
 			defaultHook: i.GetUploadIDsWithReferences,
 		},
+		GetUploadValidationReportFunc: &StoreGetUploadValidationReportFunc{
+			defaultHook: i.GetUploadValidationReport,
+		},
 		GetUploadsFunc: &StoreGetUploadsFunc{
 			defaultHook: i.GetUploads,
 		},
@@ -1016,12 +1078,18 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		HasRepositoryFunc: &StoreHasRepositoryFunc{
 			defaultHook: i.HasRepository,
 		},
+		IncrementUploadQueryCountsFunc: &StoreIncrementUploadQueryCountsFunc{
+			defaultHook: i.IncrementUploadQueryCounts,
+		},
 		InsertDependencySyncingJobFunc: &StoreInsertDependencySyncingJobFunc{
 			defaultHook: i.InsertDependencySyncingJob,
 		},
 		InsertUploadFunc: &StoreInsertUploadFunc{
 			defaultHook: i.InsertUpload,
 		},
+		InsertUploadValidationReportFunc: &StoreInsertUploadValidationReportFunc{
+			defaultHook: i.InsertUploadValidationReport,
+		},
 		MarkFailedFunc: &StoreMarkFailedFunc{
 			defaultHook: i.MarkFailed,
 		},
@@ -4218,6 +4286,123 @@ func (c StoreGetRepositoriesMaxStaleAgeFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreGetStorageBudgetCandidatesFunc describes the behavior when the
+// GetStorageBudgetCandidates method of the parent MockStore instance is
+// invoked.
+type StoreGetStorageBudgetCandidatesFunc struct {
+	defaultHook func(context.Context, shared.GetStorageBudgetCandidatesOptions) ([]shared.StorageBudgetCandidate, int, int64, error)
+	hooks       []func(context.Context, shared.GetStorageBudgetCandidatesOptions) ([]shared.StorageBudgetCandidate, int, int64, error)
+	history     []StoreGetStorageBudgetCandidatesFuncCall
+	mutex       sync.Mutex
+}
+
+// GetStorageBudgetCandidates delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) GetStorageBudgetCandidates(v0 context.Context, v1 shared.GetStorageBudgetCandidatesOptions) ([]shared.StorageBudgetCandidate, int, int64, error) {
+	r0, r1, r2, r3 := m.GetStorageBudgetCandidatesFunc.nextHook()(v0, v1)
+	m.GetStorageBudgetCandidatesFunc.appendCall(StoreGetStorageBudgetCandidatesFuncCall{v0, v1, r0, r1, r2, r3})
+	return r0, r1, r2, r3
+}
+
+// SetDefaultHook sets function that is called when the
+// GetStorageBudgetCandidates method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreGetStorageBudgetCandidatesFunc) SetDefaultHook(hook func(context.Context, shared.GetStorageBudgetCandidatesOptions) ([]shared.StorageBudgetCandidate, int, int64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetStorageBudgetCandidates method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreGetStorageBudgetCandidatesFunc) PushHook(hook func(context.Context, shared.GetStorageBudgetCandidatesOptions) ([]shared.StorageBudgetCandidate, int, int64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetStorageBudgetCandidatesFunc) SetDefaultReturn(r0 []shared.StorageBudgetCandidate, r1 int, r2 int64, r3 error) {
+	f.SetDefaultHook(func(context.Context, shared.GetStorageBudgetCandidatesOptions) ([]shared.StorageBudgetCandidate, int, int64, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetStorageBudgetCandidatesFunc) PushReturn(r0 []shared.StorageBudgetCandidate, r1 int, r2 int64, r3 error) {
+	f.PushHook(func(context.Context, shared.GetStorageBudgetCandidatesOptions) ([]shared.StorageBudgetCandidate, int, int64, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+func (f *StoreGetStorageBudgetCandidatesFunc) nextHook() func(context.Context, shared.GetStorageBudgetCandidatesOptions) ([]shared.StorageBudgetCandidate, int, int64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetStorageBudgetCandidatesFunc) appendCall(r0 StoreGetStorageBudgetCandidatesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetStorageBudgetCandidatesFuncCall
+// objects describing the invocations of this function.
+func (f *StoreGetStorageBudgetCandidatesFunc) History() []StoreGetStorageBudgetCandidatesFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetStorageBudgetCandidatesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetStorageBudgetCandidatesFuncCall is an object that describes an
+// invocation of method GetStorageBudgetCandidates on an instance of
+// MockStore.
+type StoreGetStorageBudgetCandidatesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 shared.GetStorageBudgetCandidatesOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.StorageBudgetCandidate
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 int64
+	// Result3 is the value of the 4th result returned from this method
+	// invocation.
+	Result3 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetStorageBudgetCandidatesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetStorageBudgetCandidatesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
+}
+
 // StoreGetUploadByIDFunc describes the behavior when the GetUploadByID
 // method of the parent MockStore instance is invoked.
 type StoreGetUploadByIDFunc struct {
@@ -4463,6 +4648,119 @@ func (c StoreGetUploadIDsWithReferencesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
 }
 
+// StoreGetUploadValidationReportFunc describes the behavior when the
+// GetUploadValidationReport method of the parent MockStore instance is
+// invoked.
+type StoreGetUploadValidationReportFunc struct {
+	defaultHook func(context.Context, int) (shared.UploadValidationReport, bool, error)
+	hooks       []func(context.Context, int) (shared.UploadValidationReport, bool, error)
+	history     []StoreGetUploadValidationReportFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUploadValidationReport delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) GetUploadValidationReport(v0 context.Context, v1 int) (shared.UploadValidationReport, bool, error) {
+	r0, r1, r2 := m.GetUploadValidationReportFunc.nextHook()(v0, v1)
+	m.GetUploadValidationReportFunc.appendCall(StoreGetUploadValidationReportFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetUploadValidationReport method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreGetUploadValidationReportFunc) SetDefaultHook(hook func(context.Context, int) (shared.UploadValidationReport, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUploadValidationReport method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreGetUploadValidationReportFunc) PushHook(hook func(context.Context, int) (shared.UploadValidationReport, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetUploadValidationReportFunc) SetDefaultReturn(r0 shared.UploadValidationReport, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (shared.UploadValidationReport, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetUploadValidationReportFunc) PushReturn(r0 shared.UploadValidationReport, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (shared.UploadValidationReport, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreGetUploadValidationReportFunc) nextHook() func(context.Context, int) (shared.UploadValidationReport, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetUploadValidationReportFunc) appendCall(r0 StoreGetUploadValidationReportFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetUploadValidationReportFuncCall
+// objects describing the invocations of this function.
+func (f *StoreGetUploadValidationReportFunc) History() []StoreGetUploadValidationReportFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetUploadValidationReportFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetUploadValidationReportFuncCall is an object that describes an
+// invocation of method GetUploadValidationReport on an instance of
+// MockStore.
+type StoreGetUploadValidationReportFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 shared.UploadValidationReport
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetUploadValidationReportFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetUploadValidationReportFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // StoreGetUploadsFunc describes the behavior when the GetUploads method of
 // the parent MockStore instance is invoked.
 type StoreGetUploadsFunc struct {
@@ -5361,6 +5659,114 @@ func (c StoreHasRepositoryFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreIncrementUploadQueryCountsFunc describes the behavior when the
+// IncrementUploadQueryCounts method of the parent MockStore instance is
+// invoked.
+type StoreIncrementUploadQueryCountsFunc struct {
+	defaultHook func(context.Context, []int) error
+	hooks       []func(context.Context, []int) error
+	history     []StoreIncrementUploadQueryCountsFuncCall
+	mutex       sync.Mutex
+}
+
+// IncrementUploadQueryCounts delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) IncrementUploadQueryCounts(v0 context.Context, v1 []int) error {
+	r0 := m.IncrementUploadQueryCountsFunc.nextHook()(v0, v1)
+	m.IncrementUploadQueryCountsFunc.appendCall(StoreIncrementUploadQueryCountsFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// IncrementUploadQueryCounts method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreIncrementUploadQueryCountsFunc) SetDefaultHook(hook func(context.Context, []int) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// IncrementUploadQueryCounts method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreIncrementUploadQueryCountsFunc) PushHook(hook func(context.Context, []int) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreIncrementUploadQueryCountsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, []int) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreIncrementUploadQueryCountsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, []int) error {
+		return r0
+	})
+}
+
+func (f *StoreIncrementUploadQueryCountsFunc) nextHook() func(context.Context, []int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreIncrementUploadQueryCountsFunc) appendCall(r0 StoreIncrementUploadQueryCountsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreIncrementUploadQueryCountsFuncCall
+// objects describing the invocations of this function.
+func (f *StoreIncrementUploadQueryCountsFunc) History() []StoreIncrementUploadQueryCountsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreIncrementUploadQueryCountsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreIncrementUploadQueryCountsFuncCall is an object that describes an
+// invocation of method IncrementUploadQueryCounts on an instance of
+// MockStore.
+type StoreIncrementUploadQueryCountsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreIncrementUploadQueryCountsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreIncrementUploadQueryCountsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreInsertDependencySyncingJobFunc describes the behavior when the
 // InsertDependencySyncingJob method of the parent MockStore instance is
 // invoked.
@@ -5579,6 +5985,117 @@ func (c StoreInsertUploadFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreInsertUploadValidationReportFunc describes the behavior when the
+// InsertUploadValidationReport method of the parent MockStore instance is
+// invoked.
+type StoreInsertUploadValidationReportFunc struct {
+	defaultHook func(context.Context, int, shared.UploadValidationReport) error
+	hooks       []func(context.Context, int, shared.UploadValidationReport) error
+	history     []StoreInsertUploadValidationReportFuncCall
+	mutex       sync.Mutex
+}
+
+// InsertUploadValidationReport delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) InsertUploadValidationReport(v0 context.Context, v1 int, v2 shared.UploadValidationReport) error {
+	r0 := m.InsertUploadValidationReportFunc.nextHook()(v0, v1, v2)
+	m.InsertUploadValidationReportFunc.appendCall(StoreInsertUploadValidationReportFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// InsertUploadValidationReport method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreInsertUploadValidationReportFunc) SetDefaultHook(hook func(context.Context, int, shared.UploadValidationReport) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InsertUploadValidationReport method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreInsertUploadValidationReportFunc) PushHook(hook func(context.Context, int, shared.UploadValidationReport) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreInsertUploadValidationReportFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, shared.UploadValidationReport) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreInsertUploadValidationReportFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, shared.UploadValidationReport) error {
+		return r0
+	})
+}
+
+func (f *StoreInsertUploadValidationReportFunc) nextHook() func(context.Context, int, shared.UploadValidationReport) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreInsertUploadValidationReportFunc) appendCall(r0 StoreInsertUploadValidationReportFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreInsertUploadValidationReportFuncCall
+// objects describing the invocations of this function.
+func (f *StoreInsertUploadValidationReportFunc) History() []StoreInsertUploadValidationReportFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreInsertUploadValidationReportFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreInsertUploadValidationReportFuncCall is an object that describes an
+// invocation of method InsertUploadValidationReport on an instance of
+// MockStore.
+type StoreInsertUploadValidationReportFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 shared.UploadValidationReport
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreInsertUploadValidationReportFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreInsertUploadValidationReportFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreMarkFailedFunc describes the behavior when the MarkFailed method of
 // the parent MockStore instance is invoked.
 type StoreMarkFailedFunc struct {
@@ -184,6 +184,10 @@ func (s *Service) GetAuditLogsForUpload(ctx context.Context, uploadID int) ([]sh
 	return s.store.GetAuditLogsForUpload(ctx, uploadID)
 }
 
+func (s *Service) GetUploadValidationReport(ctx context.Context, uploadID int) (shared.UploadValidationReport, bool, error) {
+	return s.store.GetUploadValidationReport(ctx, uploadID)
+}
+
 // func (s *Service) GetUploadDocumentsForPath(ctx context.Context, bundleID int, pathPattern string) ([]string, int, error) {
 // 	return s.lsifstore.GetUploadDocumentsForPath(ctx, bundleID, pathPattern)
 // }
@@ -196,6 +200,28 @@ func (s *Service) GetLastUploadRetentionScanForRepository(ctx context.Context, r
 	return s.store.GetLastUploadRetentionScanForRepository(ctx, repositoryID)
 }
 
+// GetStorageBudgetReport returns a dry-run report of the uploads that would be expired by the
+// storage budget expirer if it were configured with the given budgets. No data is modified.
+func (s *Service) GetStorageBudgetReport(ctx context.Context, opts shared.GetStorageBudgetCandidatesOptions) (shared.StorageBudgetReport, error) {
+	candidates, totalCount, totalSize, err := s.store.GetStorageBudgetCandidates(ctx, opts)
+	if err != nil {
+		return shared.StorageBudgetReport{}, err
+	}
+
+	return shared.StorageBudgetReport{
+		RepositoryBudget: opts.RepositoryBudget,
+		GlobalBudget:     opts.GlobalBudget,
+		Candidates:       candidates,
+		TotalCount:       totalCount,
+		TotalSize:        totalSize,
+	}, nil
+}
+
+// IncrementUploadQueryCounts records that the given uploads were used to answer a code navigation request.
+func (s *Service) IncrementUploadQueryCounts(ctx context.Context, uploadIDs []int) error {
+	return s.store.IncrementUploadQueryCounts(ctx, uploadIDs)
+}
+
 func (s *Service) ReindexUploads(ctx context.Context, opts shared.ReindexUploadsOptions) error {
 	return s.store.ReindexUploads(ctx, opts)
 }
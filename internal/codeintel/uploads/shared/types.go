@@ -284,3 +284,98 @@ type UploadsWithRepositoryNamespace struct {
 	Indexer string
 	Uploads []Upload
 }
+
+type GetStorageBudgetCandidatesOptions struct {
+	// RepositoryBudget is the maximum number of processed bytes retained for a single
+	// repository. A non-positive value disables the per-repository budget.
+	RepositoryBudget int64
+
+	// GlobalBudget is the maximum number of processed bytes retained for the entire
+	// instance. A non-positive value disables the global budget.
+	GlobalBudget int64
+
+	// RepositoryID restricts the returned candidates to the given repository. Budgets
+	// are still calculated over every repository.
+	RepositoryID int
+
+	Limit  int
+	Offset int
+}
+
+// StorageBudgetRetentionTier ranks uploads by how valuable they are to retain when
+// enforcing a storage budget. Lower tiers are retained first.
+type StorageBudgetRetentionTier int
+
+const (
+	// StorageBudgetTierDefaultBranch contains uploads visible from the tip of the default
+	// branch as well as uploads not yet reflected in the repository's commit graph. Uploads
+	// in this tier are never expired due to storage budgets.
+	StorageBudgetTierDefaultBranch StorageBudgetRetentionTier = iota
+
+	// StorageBudgetTierTag contains uploads visible from a tag (e.g., a tagged release).
+	StorageBudgetTierTag
+
+	// StorageBudgetTierBranch contains uploads visible from the tip of a non-default branch.
+	StorageBudgetTierBranch
+
+	// StorageBudgetTierOther contains all remaining uploads.
+	StorageBudgetTierOther
+)
+
+// StorageBudgetCandidate is an upload that exceeds a configured storage budget and
+// would be expired to bring its repository (or the instance) back within budget.
+type StorageBudgetCandidate struct {
+	UploadID       int
+	RepositoryID   int
+	RepositoryName string
+	Commit         string
+	Root           string
+	Indexer        string
+	Size           int64
+	// QueryCount is the number of code navigation requests that the upload was used to answer.
+	QueryCount int64
+	// ReferenceCount is the number of other uploads that reference this upload.
+	ReferenceCount int
+	Tier           StorageBudgetRetentionTier
+	FinishedAt     time.Time
+}
+
+// StorageBudgetReport describes the set of uploads that exceed the configured storage
+// budgets. The report is computed without modifying any data.
+type StorageBudgetReport struct {
+	RepositoryBudget int64
+	GlobalBudget     int64
+	Candidates       []StorageBudgetCandidate
+	TotalCount       int
+	TotalSize        int64
+}
+
+// UploadValidationSeverity classifies problems found while validating an upload. Errors
+// describe data that cannot be used (and which may cause the upload to be rejected in
+// strict mode); warnings describe data that is usable but likely degrades navigation.
+type UploadValidationSeverity string
+
+const (
+	UploadValidationSeverityError   UploadValidationSeverity = "ERROR"
+	UploadValidationSeverityWarning UploadValidationSeverity = "WARNING"
+)
+
+// UploadValidationProblem aggregates every instance of a single kind of problem found
+// in an index. Only a bounded number of instances are retained as samples.
+type UploadValidationProblem struct {
+	Code        string                   `json:"code"`
+	Severity    UploadValidationSeverity `json:"severity"`
+	Description string                   `json:"description"`
+	Count       int                      `json:"count"`
+	Samples     []string                 `json:"samples"`
+}
+
+// UploadValidationReport is the result of validating the contents of an upload during
+// processing.
+type UploadValidationReport struct {
+	UploadID     int
+	ErrorCount   int
+	WarningCount int
+	Problems     []UploadValidationProblem
+	CreatedAt    time.Time
+}
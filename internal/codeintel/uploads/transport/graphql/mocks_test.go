@@ -59,9 +59,16 @@ type MockUploadsService struct {
 	// GetRecentUploadsSummaryFunc is an instance of a mock function object
 	// controlling the behavior of the method GetRecentUploadsSummary.
 	GetRecentUploadsSummaryFunc *UploadsServiceGetRecentUploadsSummaryFunc
+	// GetStorageBudgetReportFunc is an instance of a mock function object
+	// controlling the behavior of the method GetStorageBudgetReport.
+	GetStorageBudgetReportFunc *UploadsServiceGetStorageBudgetReportFunc
 	// GetUploadByIDFunc is an instance of a mock function object
 	// controlling the behavior of the method GetUploadByID.
 	GetUploadByIDFunc *UploadsServiceGetUploadByIDFunc
+	// GetUploadValidationReportFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetUploadValidationReport.
+	GetUploadValidationReportFunc *UploadsServiceGetUploadValidationReportFunc
 	// GetUploadsFunc is an instance of a mock function object controlling
 	// the behavior of the method GetUploads.
 	GetUploadsFunc *UploadsServiceGetUploadsFunc
@@ -158,11 +165,21 @@ func NewMockUploadsService() *MockUploadsService {
 				return
 			},
 		},
+		GetStorageBudgetReportFunc: &UploadsServiceGetStorageBudgetReportFunc{
+			defaultHook: func(context.Context, shared.GetStorageBudgetCandidatesOptions) (r0 shared.StorageBudgetReport, r1 error) {
+				return
+			},
+		},
 		GetUploadByIDFunc: &UploadsServiceGetUploadByIDFunc{
 			defaultHook: func(context.Context, int) (r0 shared.Upload, r1 bool, r2 error) {
 				return
 			},
 		},
+		GetUploadValidationReportFunc: &UploadsServiceGetUploadValidationReportFunc{
+			defaultHook: func(context.Context, int) (r0 shared.UploadValidationReport, r1 bool, r2 error) {
+				return
+			},
+		},
 		GetUploadsFunc: &UploadsServiceGetUploadsFunc{
 			defaultHook: func(context.Context, shared.GetUploadsOptions) (r0 []shared.Upload, r1 int, r2 error) {
 				return
@@ -275,11 +292,21 @@ func NewStrictMockUploadsService() *MockUploadsService {
 				panic("unexpected invocation of MockUploadsService.GetRecentUploadsSummary")
 			},
 		},
+		GetStorageBudgetReportFunc: &UploadsServiceGetStorageBudgetReportFunc{
+			defaultHook: func(context.Context, shared.GetStorageBudgetCandidatesOptions) (shared.StorageBudgetReport, error) {
+				panic("unexpected invocation of MockUploadsService.GetStorageBudgetReport")
+			},
+		},
 		GetUploadByIDFunc: &UploadsServiceGetUploadByIDFunc{
 			defaultHook: func(context.Context, int) (shared.Upload, bool, error) {
 				panic("unexpected invocation of MockUploadsService.GetUploadByID")
 			},
 		},
+		GetUploadValidationReportFunc: &UploadsServiceGetUploadValidationReportFunc{
+			defaultHook: func(context.Context, int) (shared.UploadValidationReport, bool, error) {
+				panic("unexpected invocation of MockUploadsService.GetUploadValidationReport")
+			},
+		},
 		GetUploadsFunc: &UploadsServiceGetUploadsFunc{
 			defaultHook: func(context.Context, shared.GetUploadsOptions) ([]shared.Upload, int, error) {
 				panic("unexpected invocation of MockUploadsService.GetUploads")
@@ -367,9 +394,15 @@ func NewMockUploadsServiceFrom(i UploadsService) *MockUploadsService {
 		GetRecentUploadsSummaryFunc: &UploadsServiceGetRecentUploadsSummaryFunc{
 			defaultHook: i.GetRecentUploadsSummary,
 		},
+		GetStorageBudgetReportFunc: &UploadsServiceGetStorageBudgetReportFunc{
+			defaultHook: i.GetStorageBudgetReport,
+		},
 		GetUploadByIDFunc: &UploadsServiceGetUploadByIDFunc{
 			defaultHook: i.GetUploadByID,
 		},
+		GetUploadValidationReportFunc: &UploadsServiceGetUploadValidationReportFunc{
+			defaultHook: i.GetUploadValidationReport,
+		},
 		GetUploadsFunc: &UploadsServiceGetUploadsFunc{
 			defaultHook: i.GetUploads,
 		},
@@ -1840,6 +1873,118 @@ func (c UploadsServiceGetRecentUploadsSummaryFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// UploadsServiceGetStorageBudgetReportFunc describes the behavior when the
+// GetStorageBudgetReport method of the parent MockUploadsService instance
+// is invoked.
+type UploadsServiceGetStorageBudgetReportFunc struct {
+	defaultHook func(context.Context, shared.GetStorageBudgetCandidatesOptions) (shared.StorageBudgetReport, error)
+	hooks       []func(context.Context, shared.GetStorageBudgetCandidatesOptions) (shared.StorageBudgetReport, error)
+	history     []UploadsServiceGetStorageBudgetReportFuncCall
+	mutex       sync.Mutex
+}
+
+// GetStorageBudgetReport delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockUploadsService) GetStorageBudgetReport(v0 context.Context, v1 shared.GetStorageBudgetCandidatesOptions) (shared.StorageBudgetReport, error) {
+	r0, r1 := m.GetStorageBudgetReportFunc.nextHook()(v0, v1)
+	m.GetStorageBudgetReportFunc.appendCall(UploadsServiceGetStorageBudgetReportFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetStorageBudgetReport method of the parent MockUploadsService instance
+// is invoked and the hook queue is empty.
+func (f *UploadsServiceGetStorageBudgetReportFunc) SetDefaultHook(hook func(context.Context, shared.GetStorageBudgetCandidatesOptions) (shared.StorageBudgetReport, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetStorageBudgetReport method of the parent MockUploadsService instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *UploadsServiceGetStorageBudgetReportFunc) PushHook(hook func(context.Context, shared.GetStorageBudgetCandidatesOptions) (shared.StorageBudgetReport, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UploadsServiceGetStorageBudgetReportFunc) SetDefaultReturn(r0 shared.StorageBudgetReport, r1 error) {
+	f.SetDefaultHook(func(context.Context, shared.GetStorageBudgetCandidatesOptions) (shared.StorageBudgetReport, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UploadsServiceGetStorageBudgetReportFunc) PushReturn(r0 shared.StorageBudgetReport, r1 error) {
+	f.PushHook(func(context.Context, shared.GetStorageBudgetCandidatesOptions) (shared.StorageBudgetReport, error) {
+		return r0, r1
+	})
+}
+
+func (f *UploadsServiceGetStorageBudgetReportFunc) nextHook() func(context.Context, shared.GetStorageBudgetCandidatesOptions) (shared.StorageBudgetReport, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UploadsServiceGetStorageBudgetReportFunc) appendCall(r0 UploadsServiceGetStorageBudgetReportFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// UploadsServiceGetStorageBudgetReportFuncCall objects describing the
+// invocations of this function.
+func (f *UploadsServiceGetStorageBudgetReportFunc) History() []UploadsServiceGetStorageBudgetReportFuncCall {
+	f.mutex.Lock()
+	history := make([]UploadsServiceGetStorageBudgetReportFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UploadsServiceGetStorageBudgetReportFuncCall is an object that describes
+// an invocation of method GetStorageBudgetReport on an instance of
+// MockUploadsService.
+type UploadsServiceGetStorageBudgetReportFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 shared.GetStorageBudgetCandidatesOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 shared.StorageBudgetReport
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UploadsServiceGetStorageBudgetReportFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UploadsServiceGetStorageBudgetReportFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // UploadsServiceGetUploadByIDFunc describes the behavior when the
 // GetUploadByID method of the parent MockUploadsService instance is
 // invoked.
@@ -1952,6 +2097,121 @@ func (c UploadsServiceGetUploadByIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// UploadsServiceGetUploadValidationReportFunc describes the behavior when
+// the GetUploadValidationReport method of the parent MockUploadsService
+// instance is invoked.
+type UploadsServiceGetUploadValidationReportFunc struct {
+	defaultHook func(context.Context, int) (shared.UploadValidationReport, bool, error)
+	hooks       []func(context.Context, int) (shared.UploadValidationReport, bool, error)
+	history     []UploadsServiceGetUploadValidationReportFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUploadValidationReport delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockUploadsService) GetUploadValidationReport(v0 context.Context, v1 int) (shared.UploadValidationReport, bool, error) {
+	r0, r1, r2 := m.GetUploadValidationReportFunc.nextHook()(v0, v1)
+	m.GetUploadValidationReportFunc.appendCall(UploadsServiceGetUploadValidationReportFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetUploadValidationReport method of the parent MockUploadsService
+// instance is invoked and the hook queue is empty.
+func (f *UploadsServiceGetUploadValidationReportFunc) SetDefaultHook(hook func(context.Context, int) (shared.UploadValidationReport, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUploadValidationReport method of the parent MockUploadsService
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *UploadsServiceGetUploadValidationReportFunc) PushHook(hook func(context.Context, int) (shared.UploadValidationReport, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UploadsServiceGetUploadValidationReportFunc) SetDefaultReturn(r0 shared.UploadValidationReport, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (shared.UploadValidationReport, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UploadsServiceGetUploadValidationReportFunc) PushReturn(r0 shared.UploadValidationReport, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (shared.UploadValidationReport, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *UploadsServiceGetUploadValidationReportFunc) nextHook() func(context.Context, int) (shared.UploadValidationReport, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UploadsServiceGetUploadValidationReportFunc) appendCall(r0 UploadsServiceGetUploadValidationReportFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// UploadsServiceGetUploadValidationReportFuncCall objects describing the
+// invocations of this function.
+func (f *UploadsServiceGetUploadValidationReportFunc) History() []UploadsServiceGetUploadValidationReportFuncCall {
+	f.mutex.Lock()
+	history := make([]UploadsServiceGetUploadValidationReportFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UploadsServiceGetUploadValidationReportFuncCall is an object that
+// describes an invocation of method GetUploadValidationReport on an
+// instance of MockUploadsService.
+type UploadsServiceGetUploadValidationReportFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 shared.UploadValidationReport
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UploadsServiceGetUploadValidationReportFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UploadsServiceGetUploadValidationReportFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // UploadsServiceGetUploadsFunc describes the behavior when the GetUploads
 // method of the parent MockUploadsService instance is invoked.
 type UploadsServiceGetUploadsFunc struct {
@@ -393,6 +393,19 @@ func (r *preciseIndexResolver) AuditLogs(ctx context.Context) (*[]resolverstubs.
 	return &resolvers, nil
 }
 
+func (r *preciseIndexResolver) ValidationReport(ctx context.Context) (resolverstubs.PreciseIndexValidationReportResolver, error) {
+	if r.upload == nil {
+		return nil, nil
+	}
+
+	report, ok, err := r.uploadsSvc.GetUploadValidationReport(ctx, r.upload.ID)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return newPreciseIndexValidationReportResolver(report), nil
+}
+
 //
 //
 
@@ -487,3 +500,51 @@ func (r *auditLogColumnChangeResolver) Old() *string {
 func (r *auditLogColumnChangeResolver) New() *string {
 	return r.columnTransition["new"]
 }
+
+//
+//
+
+type preciseIndexValidationReportResolver struct {
+	report shared.UploadValidationReport
+}
+
+func newPreciseIndexValidationReportResolver(report shared.UploadValidationReport) resolverstubs.PreciseIndexValidationReportResolver {
+	return &preciseIndexValidationReportResolver{report: report}
+}
+
+func (r *preciseIndexValidationReportResolver) ErrorCount() int32 { return int32(r.report.ErrorCount) }
+func (r *preciseIndexValidationReportResolver) WarningCount() int32 {
+	return int32(r.report.WarningCount)
+}
+
+func (r *preciseIndexValidationReportResolver) Problems() []resolverstubs.PreciseIndexValidationProblemResolver {
+	resolvers := make([]resolverstubs.PreciseIndexValidationProblemResolver, 0, len(r.report.Problems))
+	for _, problem := range r.report.Problems {
+		resolvers = append(resolvers, &preciseIndexValidationProblemResolver{problem: problem})
+	}
+
+	return resolvers
+}
+
+func (r *preciseIndexValidationReportResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.report.CreatedAt}
+}
+
+//
+//
+
+type preciseIndexValidationProblemResolver struct {
+	problem shared.UploadValidationProblem
+}
+
+func (r *preciseIndexValidationProblemResolver) Code() string        { return r.problem.Code }
+func (r *preciseIndexValidationProblemResolver) Severity() string    { return string(r.problem.Severity) }
+func (r *preciseIndexValidationProblemResolver) Description() string { return r.problem.Description }
+func (r *preciseIndexValidationProblemResolver) Count() int32        { return int32(r.problem.Count) }
+func (r *preciseIndexValidationProblemResolver) Samples() []string {
+	if r.problem.Samples == nil {
+		return []string{}
+	}
+
+	return r.problem.Samples
+}
@@ -0,0 +1,178 @@
+package graphql
+
+import (
+	"context"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+const defaultStorageBudgetCandidatesPageSize = 50
+
+// 🚨 SECURITY: Only site admins may view the storage budget report
+func (r *rootResolver) PreciseIndexStorageBudgetReport(ctx context.Context, args *resolverstubs.PreciseIndexStorageBudgetReportArgs) (_ resolverstubs.PreciseIndexStorageBudgetReportResolver, err error) {
+	ctx, errTracer, endObservation := r.operations.preciseIndexStorageBudgetReport.WithErrors(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int64("repositoryBudgetBytes", int64(pointers.Deref(args.RepositoryBudgetBytes, 0))),
+		attribute.Int64("globalBudgetBytes", int64(pointers.Deref(args.GlobalBudgetBytes, 0))),
+		attribute.Int("first", int(pointers.Deref(args.First, 0))),
+		attribute.String("after", pointers.Deref(args.After, "")),
+	}})
+	endObservation.OnCancel(ctx, 1, observation.Args{})
+
+	if err := r.siteAdminChecker.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	repositoryBudget := int64(pointers.Deref(args.RepositoryBudgetBytes, 0))
+	globalBudget := int64(pointers.Deref(args.GlobalBudgetBytes, 0))
+	if repositoryBudget < 0 || globalBudget < 0 {
+		return nil, errors.New("illegal negative storage budget")
+	}
+
+	var repositoryID int
+	if args.Repository != nil {
+		v, err := resolverstubs.UnmarshalID[api.RepoID](*args.Repository)
+		if err != nil {
+			return nil, err
+		}
+
+		repositoryID = int(v)
+	}
+
+	limit, offset, err := args.ParseLimitOffset(defaultStorageBudgetCandidatesPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := r.uploadSvc.GetStorageBudgetReport(ctx, shared.GetStorageBudgetCandidatesOptions{
+		RepositoryBudget: repositoryBudget,
+		GlobalBudget:     globalBudget,
+		RepositoryID:     repositoryID,
+		Limit:            int(limit),
+		Offset:           int(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0, len(report.Candidates))
+	for _, candidate := range report.Candidates {
+		ids = append(ids, candidate.UploadID)
+	}
+	uploads, err := r.uploadSvc.GetUploadsByIDs(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+	uploadsByID := make(map[int]shared.Upload, len(uploads))
+	for _, upload := range uploads {
+		uploadsByID[upload.ID] = upload
+	}
+
+	// Create upload loader with data we already have
+	uploadLoader := r.uploadLoaderFactory.CreateWithInitialData(uploads)
+
+	// Pre-submit associated index ids for subsequent loading
+	indexLoader := r.indexLoaderFactory.Create()
+	PresubmitAssociatedIndexes(indexLoader, uploads...)
+
+	// No data to load for git data (yet)
+	locationResolver := r.locationResolverFactory.Create()
+
+	resolvers := make([]resolverstubs.PreciseIndexStorageBudgetCandidateResolver, 0, len(report.Candidates))
+	for _, candidate := range report.Candidates {
+		upload, ok := uploadsByID[candidate.UploadID]
+		if !ok {
+			// Upload was deleted (or is no longer visible to this user) since the report was computed
+			continue
+		}
+
+		preciseIndex, err := r.preciseIndexResolverFactory.Create(ctx, uploadLoader, indexLoader, locationResolver, errTracer, &upload, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resolvers = append(resolvers, &preciseIndexStorageBudgetCandidateResolver{
+			candidate:    candidate,
+			preciseIndex: preciseIndex,
+		})
+	}
+
+	endCursor := ""
+	if newOffset := int(offset) + len(report.Candidates); newOffset < report.TotalCount {
+		endCursor = strconv.Itoa(newOffset)
+	}
+
+	return &preciseIndexStorageBudgetReportResolver{
+		report:     report,
+		candidates: resolverstubs.NewCursorWithTotalCountConnectionResolver(resolvers, endCursor, int32(report.TotalCount)),
+	}, nil
+}
+
+type preciseIndexStorageBudgetReportResolver struct {
+	report     shared.StorageBudgetReport
+	candidates resolverstubs.PreciseIndexStorageBudgetCandidateConnectionResolver
+}
+
+func (r *preciseIndexStorageBudgetReportResolver) RepositoryBudgetBytes() *gqlutil.BigInt {
+	return budgetOrNil(r.report.RepositoryBudget)
+}
+
+func (r *preciseIndexStorageBudgetReportResolver) GlobalBudgetBytes() *gqlutil.BigInt {
+	return budgetOrNil(r.report.GlobalBudget)
+}
+
+func (r *preciseIndexStorageBudgetReportResolver) TotalSizeBytes() gqlutil.BigInt {
+	return gqlutil.BigInt(r.report.TotalSize)
+}
+
+func (r *preciseIndexStorageBudgetReportResolver) Candidates() resolverstubs.PreciseIndexStorageBudgetCandidateConnectionResolver {
+	return r.candidates
+}
+
+func budgetOrNil(budget int64) *gqlutil.BigInt {
+	if budget <= 0 {
+		return nil
+	}
+
+	return pointers.Ptr(gqlutil.BigInt(budget))
+}
+
+type preciseIndexStorageBudgetCandidateResolver struct {
+	candidate    shared.StorageBudgetCandidate
+	preciseIndex resolverstubs.PreciseIndexResolver
+}
+
+func (r *preciseIndexStorageBudgetCandidateResolver) PreciseIndex() resolverstubs.PreciseIndexResolver {
+	return r.preciseIndex
+}
+
+func (r *preciseIndexStorageBudgetCandidateResolver) SizeBytes() gqlutil.BigInt {
+	return gqlutil.BigInt(r.candidate.Size)
+}
+
+func (r *preciseIndexStorageBudgetCandidateResolver) QueryCount() gqlutil.BigInt {
+	return gqlutil.BigInt(r.candidate.QueryCount)
+}
+
+func (r *preciseIndexStorageBudgetCandidateResolver) ReferenceCount() int32 {
+	return int32(r.candidate.ReferenceCount)
+}
+
+func (r *preciseIndexStorageBudgetCandidateResolver) RetentionTier() string {
+	switch r.candidate.Tier {
+	case shared.StorageBudgetTierTag:
+		return "TAG"
+	case shared.StorageBudgetTierBranch:
+		return "BRANCH"
+	default:
+		return "OTHER"
+	}
+}
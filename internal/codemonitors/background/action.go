@@ -18,4 +18,7 @@ type actionArgs struct {
 	Query          string
 	Results        []*result.CommitMatch
 	IncludeResults bool
+
+	// Digest is set if the notification is a digest of several trigger runs.
+	Digest *digestArgs
 }
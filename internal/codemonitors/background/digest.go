@@ -0,0 +1,119 @@
+package background
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/codemonitors"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// defaultDigestMaxResults limits the size of a digest whose action no longer
+// has digest settings, because it was switched back to a notification per run
+// after the digest job was enqueued.
+const defaultDigestMaxResults = 50
+
+// digestArgs describes the period and contents of a digest notification.
+type digestArgs struct {
+	Interval string
+	Start    time.Time
+	End      time.Time
+
+	// OmittedCount is the number of matches that were left out of the digest
+	// to limit its size.
+	OmittedCount int
+}
+
+// withDigest replaces the results of args with the results of all trigger runs
+// covered by a digest job. Results that were matched by several runs are only
+// included once, and the results are limited to the maximum size of the
+// digest. Jobs that are not digests are returned unchanged.
+func withDigest(ctx context.Context, s database.CodeMonitorStore, j *database.ActionJob, digest *database.ActionDigest, args actionArgs) (actionArgs, error) {
+	if j.DigestStart == nil || j.DigestEnd == nil {
+		return args, nil
+	}
+
+	results, err := s.ListDigestResults(ctx, j.ID)
+	if err != nil {
+		return args, err
+	}
+
+	var interval string
+	maxResults := defaultDigestMaxResults
+	if digest != nil {
+		interval = digest.Interval
+		maxResults = int(digest.MaxResults)
+	}
+
+	deduped := dedupeResults(results, codemonitors.SearchesFileContents(args.Query))
+	truncatedResults, _, truncatedCount := truncateResults(deduped, maxResults)
+	args.Results = truncatedResults
+	args.Digest = &digestArgs{
+		Interval:     interval,
+		Start:        *j.DigestStart,
+		End:          *j.DigestEnd,
+		OmittedCount: truncatedCount,
+	}
+	return args, nil
+}
+
+// dedupeResults returns the first result for each commit. ListDigestResults
+// returns the results of the most recent run first, so this keeps the match of
+// the most recent run.
+//
+// The matches of a monitor that searches file contents all belong to the HEAD
+// commit of their repository, so their results are deduplicated by the matched
+// file and lines instead.
+func dedupeResults(results []*result.CommitMatch, fileContents bool) []*result.CommitMatch {
+	type key struct {
+		repo    string
+		commit  string
+		content string
+	}
+
+	seen := make(map[key]struct{}, len(results))
+	deduped := make([]*result.CommitMatch, 0, len(results))
+	for _, r := range results {
+		k := key{repo: string(r.Repo.Name), commit: string(r.Commit.ID)}
+		if fileContents && r.DiffPreview != nil {
+			k.content = r.DiffPreview.Content
+		}
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		deduped = append(deduped, r)
+	}
+	return deduped
+}
+
+// truncateActionResults is truncateResults for the results of an action. The
+// counts include the matches that were left out of a digest.
+func truncateActionResults(args actionArgs, maxResults int) (_ []*result.CommitMatch, totalCount, truncatedCount int) {
+	results, totalCount, truncatedCount := truncateResults(args.Results, maxResults)
+	if args.Digest != nil {
+		totalCount += args.Digest.OmittedCount
+		truncatedCount += args.Digest.OmittedCount
+	}
+	return results, totalCount, truncatedCount
+}
+
+// digestPeriod returns the period that a notification covers, for use at the
+// end of a sentence like "detected 3 new matches in the last day". It is empty
+// for notifications that are not digests.
+func digestPeriod(args actionArgs) string {
+	if args.Digest == nil {
+		return ""
+	}
+	switch args.Digest.Interval {
+	case database.DigestIntervalHourly:
+		return " in the last hour"
+	case database.DigestIntervalDaily:
+		return " in the last day"
+	case database.DigestIntervalWeekly:
+		return " in the last week"
+	default:
+		return " since " + args.Digest.Start.UTC().Format(time.RFC1123)
+	}
+}
@@ -0,0 +1,137 @@
+package background
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestDedupeResults(t *testing.T) {
+	t.Run("commits", func(t *testing.T) {
+		// diffResultMock and commitResultMock match the same commit, and the
+		// copy of diffResultMock was matched by an earlier run.
+		earlierDiffResult := diffResultMock
+		got := dedupeResults([]*result.CommitMatch{&diffResultMock, &longCommitResultMock, &commitResultMock, &earlierDiffResult}, false)
+		require.Equal(t, []*result.CommitMatch{&diffResultMock, &longCommitResultMock}, got)
+	})
+
+	t.Run("file contents", func(t *testing.T) {
+		// Matches of monitors that search file contents all belong to the
+		// HEAD commit of their repository.
+		contentResult := func(content string) *result.CommitMatch {
+			return &result.CommitMatch{
+				Commit:      gitdomain.Commit{ID: api.CommitID("7815187511872asbasdfgasd")},
+				Repo:        types.MinimalRepo{Name: api.RepoName("github.com/test/test")},
+				DiffPreview: &result.MatchedString{Content: content},
+			}
+		}
+		configMatch := contentResult("config.env config.env\n@@ -1,1 +1,1 @@\n+AWS_SECRET=new\n")
+		deployMatch := contentResult("deploy.sh deploy.sh\n@@ -4,1 +4,1 @@\n+export AWS_SECRET=new\n")
+		earlierConfigMatch := contentResult("config.env config.env\n@@ -1,1 +1,1 @@\n+AWS_SECRET=new\n")
+
+		got := dedupeResults([]*result.CommitMatch{configMatch, deployMatch, earlierConfigMatch}, true)
+		require.Equal(t, []*result.CommitMatch{configMatch, deployMatch}, got)
+		require.Same(t, configMatch, got[0])
+	})
+}
+
+func TestWithDigest(t *testing.T) {
+	start := time.Date(2023, 12, 29, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	store := dbmocks.NewMockCodeMonitorStore()
+	store.ListDigestResultsFunc.SetDefaultReturn([]*result.CommitMatch{&diffResultMock, &commitResultMock, &longCommitResultMock}, nil)
+
+	args := actionArgs{Results: []*result.CommitMatch{&commitResultMock}}
+
+	t.Run("not a digest", func(t *testing.T) {
+		got, err := withDigest(context.Background(), store, &database.ActionJob{ID: 1}, nil, args)
+		require.NoError(t, err)
+		require.Equal(t, args, got)
+	})
+
+	t.Run("digest", func(t *testing.T) {
+		job := &database.ActionJob{ID: 1, DigestStart: &start, DigestEnd: &end}
+		digest := &database.ActionDigest{Interval: database.DigestIntervalDaily, MaxResults: 2}
+
+		got, err := withDigest(context.Background(), store, job, digest, args)
+		require.NoError(t, err)
+		// The two matches of diffResultMock fill the digest, so
+		// longCommitResultMock is left out.
+		require.Equal(t, []*result.CommitMatch{&diffResultMock}, got.Results)
+		require.Equal(t, &digestArgs{
+			Interval:     database.DigestIntervalDaily,
+			Start:        start,
+			End:          end,
+			OmittedCount: 1,
+		}, got.Digest)
+	})
+}
+
+func TestDigestNotifications(t *testing.T) {
+	eu, err := url.Parse("https://sourcegraph.com")
+	require.NoError(t, err)
+
+	start := time.Date(2023, 12, 29, 0, 0, 0, 0, time.UTC)
+	action := actionArgs{
+		MonitorDescription: "My test monitor",
+		MonitorOwnerName:   "Camden Cheek",
+		ExternalURL:        eu,
+		MonitorID:          42,
+		Query:              "repo:camdentest -file:id_rsa.pub BEGIN",
+		Results:            []*result.CommitMatch{&diffResultMock},
+		IncludeResults:     true,
+		Digest: &digestArgs{
+			Interval:     database.DigestIntervalDaily,
+			Start:        start,
+			End:          start.Add(24 * time.Hour),
+			OmittedCount: 4,
+		},
+	}
+
+	t.Run("slack", func(t *testing.T) {
+		action := action
+		action.UTMSource = "code-monitor-slack-webhook"
+
+		blocks := slackPayload(action).Blocks.BlockSet
+		require.Equal(t, "Camden Cheek's Sourcegraph Code monitor, *My test monitor*, detected *6* new matches in the last day.", blockText(t, blocks[0]))
+		require.Equal(t, "...and <https://sourcegraph.com/search?q=repo%3Acamdentest+-file%3Aid_rsa.pub+BEGIN&utm_source=code-monitor-slack-webhook|4 more matches>.", blockText(t, blocks[3]))
+	})
+
+	t.Run("email", func(t *testing.T) {
+		data, err := NewTemplateDataForNewSearchResults(action, &database.EmailAction{Monitor: 42})
+		require.NoError(t, err)
+		require.Equal(t, 6, data.TotalCount)
+		require.Equal(t, 4, data.TruncatedCount)
+		require.Equal(t, " in the last day", data.DigestPeriod)
+	})
+
+	t.Run("webhook", func(t *testing.T) {
+		payload := generateWebhookPayload(action)
+		require.Len(t, payload.Results, 1)
+		require.Equal(t, &webhookDigest{
+			Interval:       database.DigestIntervalDaily,
+			Start:          start,
+			End:            start.Add(24 * time.Hour),
+			OmittedResults: 4,
+		}, payload.Digest)
+	})
+}
+
+func blockText(t *testing.T, b slack.Block) string {
+	t.Helper()
+	section, ok := b.(*slack.SectionBlock)
+	require.True(t, ok)
+	return section.Text.Text
+}
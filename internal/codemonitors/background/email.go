@@ -41,7 +41,7 @@ var (
 )
 
 var newSearchResultsEmailTemplates = txemail.MustValidate(txtypes.Templates{
-	Subject: `{{ if .IsTest }}Test: {{ end }}{{.Priority}}Sourcegraph code monitor {{.Description}} detected {{.TotalCount}} new {{.ResultPluralized}}`,
+	Subject: `{{ if .IsTest }}Test: {{ end }}{{.Priority}}Sourcegraph code monitor {{.Description}} detected {{.TotalCount}} new {{.ResultPluralized}}{{.DigestPeriod}}`,
 	Text:    textTemplate,
 	HTML:    htmlTemplate,
 })
@@ -57,6 +57,7 @@ type TemplateDataNewSearchResults struct {
 	TruncatedCount            int
 	ResultPluralized          string
 	TruncatedResultPluralized string
+	DigestPeriod              string
 	DisplayMoreLink           bool
 	IsTest                    bool
 }
@@ -75,7 +76,7 @@ func NewTemplateDataForNewSearchResults(args actionArgs, email *database.EmailAc
 		priority = ""
 	}
 
-	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
+	truncatedResults, totalCount, truncatedCount := truncateActionResults(args, 5)
 
 	displayResults := make([]*DisplayResult, len(truncatedResults))
 	for i, result := range truncatedResults {
@@ -93,6 +94,7 @@ func NewTemplateDataForNewSearchResults(args actionArgs, email *database.EmailAc
 		TruncatedCount:            truncatedCount,
 		ResultPluralized:          pluralize("result", totalCount),
 		TruncatedResultPluralized: pluralize("result", truncatedCount),
+		DigestPeriod:              digestPeriod(args),
 		DisplayMoreLink:           args.IncludeResults && truncatedCount > 0,
 	}, nil
 }
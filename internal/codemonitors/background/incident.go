@@ -0,0 +1,130 @@
+package background
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"text/template"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func sendIncidentNotification(ctx context.Context, url, payloadTemplate string, args actionArgs) error {
+	return postIncidentWebhook(ctx, httpcli.ExternalDoer, url, payloadTemplate, args)
+}
+
+// incidentTemplateData is the data that the payload template of an incident
+// webhook action is executed with.
+type incidentTemplateData struct {
+	MonitorDescription string
+	MonitorOwnerName   string
+	MonitorURL         string
+	Query              string
+	SearchURL          string
+	ResultCount        int
+	Results            []webhookResult
+}
+
+var incidentTemplateFuncs = template.FuncMap{
+	// json encodes a value as JSON, so that strings from the results are
+	// quoted and escaped when they are embedded into the payload.
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+func newIncidentTemplateData(args actionArgs) incidentTemplateData {
+	totalCount := 0
+	for _, result := range args.Results {
+		totalCount += result.ResultCount()
+	}
+	return incidentTemplateData{
+		MonitorDescription: args.MonitorDescription,
+		MonitorOwnerName:   args.MonitorOwnerName,
+		MonitorURL:         getCodeMonitorURL(args.ExternalURL, args.MonitorID, args.UTMSource),
+		Query:              args.Query,
+		SearchURL:          getSearchURL(args.ExternalURL, args.Query, args.UTMSource),
+		ResultCount:        totalCount,
+		Results:            generateResults(args.Results),
+	}
+}
+
+// renderIncidentPayload executes the payload template of an incident webhook
+// action and returns the JSON request body.
+func renderIncidentPayload(payloadTemplate string, args actionArgs) ([]byte, error) {
+	tmpl, err := template.New("payload").Funcs(incidentTemplateFuncs).Option("missingkey=error").Parse(payloadTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse payload template")
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, newIncidentTemplateData(args)); err != nil {
+		return nil, errors.Wrap(err, "execute payload template")
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, errors.New("payload template did not render valid JSON")
+	}
+	return buf.Bytes(), nil
+}
+
+func postIncidentWebhook(ctx context.Context, doer httpcli.Doer, url, payloadTemplate string, args actionArgs) error {
+	raw, err := renderIncidentPayload(payloadTemplate, args)
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, doer, url, raw)
+}
+
+// ValidateIncidentPayloadTemplate returns an error if the payload template of
+// an incident webhook action does not render valid JSON.
+func ValidateIncidentPayloadTemplate(payloadTemplate string) error {
+	args := testIncidentArgs("test monitor")
+	args.Results = sampleIncidentResults
+	args.IncludeResults = true
+	_, err := renderIncidentPayload(payloadTemplate, args)
+	return err
+}
+
+// sampleIncidentResults are the results payload templates are validated
+// against. There are two of them, so that templates that don't separate the
+// items of a list of results are rejected, and their content contains quotes,
+// so that templates that don't escape it are rejected.
+var sampleIncidentResults = []*result.CommitMatch{{
+	Commit: gitdomain.Commit{ID: "4a0c0f4ec18b3d0b2d0fd0b4a6c2f9f18d6b4e1c"},
+	Repo:   types.MinimalRepo{Name: "github.com/sourcegraph/example"},
+	DiffPreview: &result.MatchedString{
+		Content: "README.md README.md\n@@ -1,1 +1,1 @@\n-\"old\"\n+\"new\"\n",
+		MatchedRanges: result.Ranges{{
+			Start: result.Location{Line: 3, Offset: 44, Column: 1},
+			End:   result.Location{Line: 3, Offset: 49, Column: 6},
+		}},
+	},
+}, {
+	Commit: gitdomain.Commit{ID: "9cb4a43a052f8178566d1d6a0f1f0c0e9b1d5a7e"},
+	Repo:   types.MinimalRepo{Name: "github.com/sourcegraph/example"},
+	MessagePreview: &result.MatchedString{
+		Content: "Replace \"old\" with \"new\"\n",
+		MatchedRanges: result.Ranges{{
+			Start: result.Location{Line: 0, Offset: 19, Column: 19},
+			End:   result.Location{Line: 0, Offset: 24, Column: 24},
+		}},
+	},
+}}
+
+func SendTestIncidentWebhook(ctx context.Context, doer httpcli.Doer, description, u, payloadTemplate string) error {
+	return postIncidentWebhook(ctx, doer, u, payloadTemplate, testIncidentArgs(description))
+}
+
+func testIncidentArgs(description string) actionArgs {
+	return actionArgs{
+		ExternalURL:        &url.URL{},
+		MonitorDescription: description,
+		Query:              "test query",
+	}
+}
@@ -0,0 +1,154 @@
+package background
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// pagerDutyTemplate renders an event for the PagerDuty Events API v2.
+const pagerDutyTemplate = `{
+	"routing_key": "R0UT1NGK3Y",
+	"event_action": "trigger",
+	"dedup_key": {{json .MonitorURL}},
+	"payload": {
+		"summary": {{json (printf "%s detected %d new matches" .MonitorDescription .ResultCount)}},
+		"source": "sourcegraph",
+		"severity": "warning",
+		"custom_details": {
+			"query": {{json .Query}},
+			"commits": [{{range $i, $r := .Results}}{{if $i}}, {{end}}{{json $r.Commit}}{{end}}]
+		}
+	},
+	"links": [{"href": {{json .SearchURL}}, "text": "View results"}]
+}`
+
+func TestIncidentWebhook(t *testing.T) {
+	t.Parallel()
+	eu, err := url.Parse("https://sourcegraph.com")
+	require.NoError(t, err)
+
+	action := actionArgs{
+		MonitorDescription: `My "test" monitor`,
+		MonitorOwnerName:   "Camden Cheek",
+		ExternalURL:        eu,
+		MonitorID:          42,
+		UTMSource:          "code-monitor-incident-webhook",
+		Query:              "repo:camdentest -file:id_rsa.pub BEGIN",
+		Results:            []*result.CommitMatch{&diffResultMock, &commitResultMock},
+		IncludeResults:     true,
+	}
+
+	// newServer returns a stand-in for an incident API that records the
+	// posted body and responds with the given status code.
+	newServer := func(t *testing.T, status int, got *[]byte) *httptest.Server {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			*got = b
+			w.WriteHeader(status)
+		}))
+		t.Cleanup(s.Close)
+		return s
+	}
+
+	t.Run("accepted", func(t *testing.T) {
+		var got []byte
+		s := newServer(t, http.StatusAccepted, &got)
+
+		err := postIncidentWebhook(context.Background(), s.Client(), s.URL, pagerDutyTemplate, action)
+		require.NoError(t, err)
+
+		var event struct {
+			RoutingKey  string `json:"routing_key"`
+			EventAction string `json:"event_action"`
+			DedupKey    string `json:"dedup_key"`
+			Payload     struct {
+				Summary       string `json:"summary"`
+				CustomDetails struct {
+					Query   string   `json:"query"`
+					Commits []string `json:"commits"`
+				} `json:"custom_details"`
+			} `json:"payload"`
+			Links []struct {
+				Href string `json:"href"`
+			} `json:"links"`
+		}
+		require.NoError(t, json.Unmarshal(got, &event))
+		require.Equal(t, "R0UT1NGK3Y", event.RoutingKey)
+		require.Equal(t, "trigger", event.EventAction)
+		require.Equal(t, "https://sourcegraph.com/code-monitoring/Q29kZU1vbml0b3I6NDI=?utm_source=code-monitor-incident-webhook", event.DedupKey)
+		require.Equal(t, `My "test" monitor detected 3 new matches`, event.Payload.Summary)
+		require.Equal(t, action.Query, event.Payload.CustomDetails.Query)
+		require.Equal(t, []string{"7815187511872asbasdfgasd", "7815187511872asbasdfgasd"}, event.Payload.CustomDetails.Commits)
+		require.Equal(t, "https://sourcegraph.com/search?q=repo%3Acamdentest+-file%3Aid_rsa.pub+BEGIN&utm_source=code-monitor-incident-webhook", event.Links[0].Href)
+	})
+
+	t.Run("error is returned", func(t *testing.T) {
+		var got []byte
+		s := newServer(t, http.StatusBadRequest, &got)
+
+		err := postIncidentWebhook(context.Background(), s.Client(), s.URL, pagerDutyTemplate, action)
+		var statusErr StatusCodeError
+		require.ErrorAs(t, err, &statusErr)
+		require.Equal(t, http.StatusBadRequest, statusErr.Code)
+	})
+
+	t.Run("invalid payload is not sent", func(t *testing.T) {
+		var got []byte
+		s := newServer(t, http.StatusOK, &got)
+
+		err := postIncidentWebhook(context.Background(), s.Client(), s.URL, `{"summary": {{.MonitorDescription}}}`, action)
+		require.Error(t, err)
+		require.Nil(t, got)
+	})
+}
+
+func TestValidateIncidentPayloadTemplate(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		template string
+		valid    bool
+	}{
+		{name: "pagerduty", template: pagerDutyTemplate, valid: true},
+		{name: "parse error", template: `{"summary": {{json .Query}`, valid: false},
+		{name: "unknown field", template: `{"summary": {{json .Unknown}}}`, valid: false},
+		{name: "unquoted string", template: `{"summary": {{.Query}}}`, valid: false},
+		{name: "results without separators", template: `{"commits": [{{range .Results}}{{json .Commit}}{{end}}]}`, valid: false},
+		{name: "unquoted result content", template: `{"commits": [{{range $i, $r := .Results}}{{if $i}}, {{end}}"{{$r.Message}}{{$r.Diff}}"{{end}}]}`, valid: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateIncidentPayloadTemplate(tc.template)
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestTriggerTestIncidentWebhookAction(t *testing.T) {
+	var got []byte
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		got = b
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer s.Close()
+
+	err := SendTestIncidentWebhook(context.Background(), httpcli.TestExternalDoer, "My test monitor", s.URL, `{"summary": {{json .MonitorDescription}}, "count": {{.ResultCount}}}`)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"summary": "My test monitor", "count": 0}`, string(got))
+}
@@ -0,0 +1,128 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/slack-go/slack"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+)
+
+// InsightAlert describes a Code Insights series alert rule that started firing. Code Insights
+// alerts are delivered through the same email, Slack and webhook mechanisms as code monitors.
+type InsightAlert struct {
+	SeriesID    string
+	InsightsURL string
+	Query       string
+	Kind        string
+	Direction   string
+	Threshold   float64
+	Value       float64
+	RecordedAt  time.Time
+}
+
+// Summary returns a single line, human readable description of the alert.
+func (a InsightAlert) Summary() string {
+	switch a.Kind {
+	case "percent_change":
+		return fmt.Sprintf("changed by %.2f%%, %s the threshold of %g%%", a.Value, thresholdPosition(a.Direction), a.Threshold)
+	case "anomaly":
+		return fmt.Sprintf("has a z-score of %.2f, %s the threshold of %g", a.Value, thresholdPosition(a.Direction), a.Threshold)
+	default:
+		return fmt.Sprintf("has a value of %g, %s the threshold of %g", a.Value, thresholdPosition(a.Direction), a.Threshold)
+	}
+}
+
+func thresholdPosition(direction string) string {
+	if direction == "below" {
+		return "below"
+	}
+	return "above"
+}
+
+var MockSendInsightAlertEmail func(ctx context.Context, db database.DB, userID int32, alert InsightAlert) error
+
+// SendInsightAlertEmail sends the given Code Insights alert to the primary email address of the
+// given user.
+func SendInsightAlertEmail(ctx context.Context, db database.DB, userID int32, alert InsightAlert) error {
+	if MockSendInsightAlertEmail != nil {
+		return MockSendInsightAlertEmail(ctx, db, userID, alert)
+	}
+	return sendEmail(ctx, db, userID, insightAlertEmailTemplates, templateDataInsightAlert{InsightAlert: alert, Summary: alert.Summary()})
+}
+
+type templateDataInsightAlert struct {
+	InsightAlert
+	Summary string
+}
+
+var insightAlertEmailTemplates = txemail.MustValidate(txtypes.Templates{
+	Subject: `Sourcegraph Code Insights alert: series {{.SeriesID}} {{.Summary}}`,
+	Text: `
+The Code Insights series {{.SeriesID}} {{.Summary}}.
+
+Query: {{.Query}}
+Recorded at: {{.RecordedAt}}
+
+View Code Insights: {{.InsightsURL}}
+`,
+	HTML: `
+<p>The Code Insights series <strong>{{.SeriesID}}</strong> {{.Summary}}.</p>
+<p>Query: <code>{{.Query}}</code><br>Recorded at: {{.RecordedAt}}</p>
+<p><a href="{{.InsightsURL}}">View Code Insights</a></p>
+`,
+})
+
+// SendInsightAlertSlack posts the given Code Insights alert to the given Slack webhook URL.
+func SendInsightAlertSlack(ctx context.Context, url string, alert InsightAlert) error {
+	return postSlackWebhook(ctx, httpcli.ExternalDoer, url, insightAlertSlackPayload(alert))
+}
+
+func insightAlertSlackPayload(alert InsightAlert) *slack.WebhookMessage {
+	text := fmt.Sprintf(
+		"The Sourcegraph Code Insights series *%s* %s.\n%s\n<%s|View Code Insights>",
+		alert.SeriesID,
+		alert.Summary(),
+		formatCodeBlock(alert.Query),
+		alert.InsightsURL,
+	)
+	return &slack.WebhookMessage{Blocks: &slack.Blocks{BlockSet: []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", text, false, false), nil, nil),
+	}}}
+}
+
+// SendInsightAlertWebhook posts the given Code Insights alert as JSON to the given webhook URL.
+func SendInsightAlertWebhook(ctx context.Context, url string, alert InsightAlert) error {
+	return postWebhook(ctx, httpcli.ExternalDoer, url, insightAlertWebhookPayload(alert))
+}
+
+type insightAlertPayload struct {
+	SeriesID    string    `json:"seriesID"`
+	InsightsURL string    `json:"insightsURL"`
+	Query       string    `json:"query"`
+	Kind        string    `json:"kind"`
+	Direction   string    `json:"direction"`
+	Threshold   float64   `json:"threshold"`
+	Value       float64   `json:"value"`
+	RecordedAt  time.Time `json:"recordedAt"`
+	Summary     string    `json:"summary"`
+}
+
+func insightAlertWebhookPayload(alert InsightAlert) insightAlertPayload {
+	return insightAlertPayload{
+		SeriesID:    alert.SeriesID,
+		InsightsURL: alert.InsightsURL,
+		Query:       alert.Query,
+		Kind:        alert.Kind,
+		Direction:   alert.Direction,
+		Threshold:   alert.Threshold,
+		Value:       alert.Value,
+		RecordedAt:  alert.RecordedAt,
+		Summary:     alert.Summary(),
+	}
+}
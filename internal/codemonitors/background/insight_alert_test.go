@@ -0,0 +1,52 @@
+package background
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hexops/autogold/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsightAlert(t *testing.T) {
+	alert := InsightAlert{
+		SeriesID:    "2Mw9wQ3ZrFYnvl0rYNKO1mKhDJw",
+		InsightsURL: "https://sourcegraph.com/insights/all",
+		Query:       "TODO",
+		Kind:        "threshold",
+		Direction:   "above",
+		Threshold:   100,
+		Value:       120,
+		RecordedAt:  time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("summary", func(t *testing.T) {
+		autogold.Expect("has a value of 120, above the threshold of 100").Equal(t, alert.Summary())
+
+		percentChange := alert
+		percentChange.Kind = "percent_change"
+		percentChange.Direction = "below"
+		percentChange.Threshold = 10
+		percentChange.Value = -12.5
+		autogold.Expect("changed by -12.50%, below the threshold of 10%").Equal(t, percentChange.Summary())
+
+		anomaly := alert
+		anomaly.Kind = "anomaly"
+		anomaly.Threshold = 3
+		anomaly.Value = 4.2
+		autogold.Expect("has a z-score of 4.20, above the threshold of 3").Equal(t, anomaly.Summary())
+	})
+
+	t.Run("slack payload", func(t *testing.T) {
+		b, err := json.MarshalIndent(insightAlertSlackPayload(alert), " ", " ")
+		require.NoError(t, err)
+		autogold.ExpectFile(t, autogold.Raw(b))
+	})
+
+	t.Run("webhook payload", func(t *testing.T) {
+		b, err := json.Marshal(insightAlertWebhookPayload(alert))
+		require.NoError(t, err)
+		autogold.ExpectFile(t, autogold.Raw(b))
+	})
+}
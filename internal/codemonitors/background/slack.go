@@ -25,14 +25,15 @@ func slackPayload(args actionArgs) *slack.WebhookMessage {
 		return slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", s, false, false), nil, nil)
 	}
 
-	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
+	truncatedResults, totalCount, truncatedCount := truncateActionResults(args, 5)
 
 	blocks := []slack.Block{
 		newMarkdownSection(fmt.Sprintf(
-			"%s's Sourcegraph Code monitor, *%s*, detected *%d* new matches.",
+			"%s's Sourcegraph Code monitor, *%s*, detected *%d* new matches%s.",
 			args.MonitorOwnerName,
 			args.MonitorDescription,
 			totalCount,
+			digestPeriod(args),
 		)),
 	}
 
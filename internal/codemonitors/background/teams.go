@@ -0,0 +1,128 @@
+package background
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+func sendTeamsNotification(ctx context.Context, url string, args actionArgs) error {
+	return postWebhook(ctx, httpcli.ExternalDoer, url, teamsPayload(args))
+}
+
+// teamsMessage is the body of a message posted to a Microsoft Teams incoming
+// webhook. It wraps a single Adaptive Card.
+type teamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+type teamsAttachment struct {
+	ContentType string       `json:"contentType"`
+	Content     adaptiveCard `json:"content"`
+}
+
+type adaptiveCard struct {
+	Schema  string            `json:"$schema"`
+	Type    string            `json:"type"`
+	Version string            `json:"version"`
+	Body    []adaptiveElement `json:"body"`
+	Actions []adaptiveAction  `json:"actions,omitempty"`
+}
+
+// adaptiveElement is either a TextBlock or a CodeBlock element of an
+// Adaptive Card.
+type adaptiveElement struct {
+	Type        string `json:"type"`
+	Text        string `json:"text,omitempty"`
+	Wrap        bool   `json:"wrap,omitempty"`
+	CodeSnippet string `json:"codeSnippet,omitempty"`
+	Language    string `json:"language,omitempty"`
+}
+
+type adaptiveAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+func newTeamsMessage(body []adaptiveElement, actions ...adaptiveAction) *teamsMessage {
+	return &teamsMessage{
+		Type: "message",
+		Attachments: []teamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content: adaptiveCard{
+				Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+				Type:    "AdaptiveCard",
+				Version: "1.5",
+				Body:    body,
+				Actions: actions,
+			},
+		}},
+	}
+}
+
+func newTextBlock(s string) adaptiveElement {
+	return adaptiveElement{Type: "TextBlock", Text: s, Wrap: true}
+}
+
+func newOpenURLAction(title, url string) adaptiveAction {
+	return adaptiveAction{Type: "Action.OpenUrl", Title: title, URL: url}
+}
+
+func teamsPayload(args actionArgs) *teamsMessage {
+	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
+	searchURL := getSearchURL(args.ExternalURL, args.Query, args.UTMSource)
+
+	body := []adaptiveElement{
+		newTextBlock(fmt.Sprintf(
+			"%s's Sourcegraph Code monitor, **%s**, detected **%d** new matches.",
+			args.MonitorOwnerName,
+			args.MonitorDescription,
+			totalCount,
+		)),
+	}
+
+	if args.IncludeResults {
+		for _, result := range truncatedResults {
+			resultType := "Message"
+			if result.DiffPreview != nil {
+				resultType = "Diff"
+			}
+			body = append(body, newTextBlock(fmt.Sprintf(
+				"%s match: [%s@%s](%s)",
+				resultType,
+				result.Repo.Name,
+				result.Commit.ID.Short(),
+				getCommitURL(args.ExternalURL, string(result.Repo.Name), string(result.Commit.ID), args.UTMSource),
+			)))
+			// Code blocks are not rendered as markdown, so the content of the
+			// match does not need to be escaped.
+			body = append(body, adaptiveElement{
+				Type:        "CodeBlock",
+				CodeSnippet: truncateMatchContent(result),
+				Language:    "PlainText",
+			})
+		}
+		if truncatedCount > 0 {
+			body = append(body, newTextBlock(fmt.Sprintf(
+				"...and [%d more matches](%s).",
+				truncatedCount,
+				searchURL,
+			)))
+		}
+	}
+
+	return newTeamsMessage(body,
+		newOpenURLAction("View results", searchURL),
+		newOpenURLAction("Edit code monitor", getCodeMonitorURL(args.ExternalURL, args.MonitorID, args.UTMSource)),
+	)
+}
+
+func SendTestTeamsWebhook(ctx context.Context, doer httpcli.Doer, description, url string) error {
+	testMessage := newTeamsMessage([]adaptiveElement{
+		newTextBlock(fmt.Sprintf("Test message for Code Monitor '%s'", description)),
+	})
+	return postWebhook(ctx, doer, url, testMessage)
+}
@@ -0,0 +1,122 @@
+package background
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+func TestTeamsWebhook(t *testing.T) {
+	t.Parallel()
+	eu, err := url.Parse("https://sourcegraph.com")
+	require.NoError(t, err)
+
+	action := actionArgs{
+		MonitorDescription: "My test monitor",
+		MonitorOwnerName:   "Camden Cheek",
+		ExternalURL:        eu,
+		MonitorID:          42,
+		UTMSource:          "code-monitor-teams-webhook",
+		Query:              "repo:camdentest -file:id_rsa.pub BEGIN",
+		Results:            []*result.CommitMatch{&diffResultMock, &commitResultMock},
+		IncludeResults:     false,
+	}
+
+	// newServer returns a stand-in for a Teams incoming webhook that decodes
+	// the posted message and responds with the given status code.
+	newServer := func(t *testing.T, status int, got *teamsMessage) *httptest.Server {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(got))
+			w.WriteHeader(status)
+		}))
+		t.Cleanup(s.Close)
+		return s
+	}
+
+	t.Run("no error", func(t *testing.T) {
+		var got teamsMessage
+		s := newServer(t, http.StatusOK, &got)
+
+		err := postWebhook(context.Background(), s.Client(), s.URL, teamsPayload(action))
+		require.NoError(t, err)
+
+		require.Equal(t, "message", got.Type)
+		require.Len(t, got.Attachments, 1)
+		require.Equal(t, "application/vnd.microsoft.card.adaptive", got.Attachments[0].ContentType)
+
+		card := got.Attachments[0].Content
+		require.Equal(t, "AdaptiveCard", card.Type)
+		require.Equal(t, []adaptiveElement{
+			newTextBlock("Camden Cheek's Sourcegraph Code monitor, **My test monitor**, detected **3** new matches."),
+		}, card.Body)
+		require.Len(t, card.Actions, 2)
+		require.Equal(t, "https://sourcegraph.com/search?q=repo%3Acamdentest+-file%3Aid_rsa.pub+BEGIN&utm_source=code-monitor-teams-webhook", card.Actions[0].URL)
+		require.Equal(t, "https://sourcegraph.com/code-monitoring/Q29kZU1vbml0b3I6NDI=?utm_source=code-monitor-teams-webhook", card.Actions[1].URL)
+	})
+
+	t.Run("accepted", func(t *testing.T) {
+		var got teamsMessage
+		s := newServer(t, http.StatusAccepted, &got)
+
+		err := postWebhook(context.Background(), s.Client(), s.URL, teamsPayload(action))
+		require.NoError(t, err)
+	})
+
+	t.Run("error is returned", func(t *testing.T) {
+		var got teamsMessage
+		s := newServer(t, http.StatusInternalServerError, &got)
+
+		err := postWebhook(context.Background(), s.Client(), s.URL, teamsPayload(action))
+		var statusErr StatusCodeError
+		require.ErrorAs(t, err, &statusErr)
+		require.Equal(t, http.StatusInternalServerError, statusErr.Code)
+	})
+
+	t.Run("with results", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.IncludeResults = true
+
+		body := teamsPayload(actionCopy).Attachments[0].Content.Body
+		require.Len(t, body, 5)
+		require.Equal(t, "Diff match: [github.com/test/test@7815187](https://sourcegraph.com/github.com/test/test/-/commit/7815187511872asbasdfgasd?utm_source=code-monitor-teams-webhook)", body[1].Text)
+		require.Equal(t, adaptiveElement{
+			Type:        "CodeBlock",
+			CodeSnippet: truncateMatchContent(&diffResultMock),
+			Language:    "PlainText",
+		}, body[2])
+		require.Equal(t, "CodeBlock", body[4].Type)
+	})
+
+	t.Run("with truncated results", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.IncludeResults = true
+		// quadruple the number of results
+		actionCopy.Results = append(actionCopy.Results, actionCopy.Results...)
+		actionCopy.Results = append(actionCopy.Results, actionCopy.Results...)
+
+		body := teamsPayload(actionCopy).Attachments[0].Content.Body
+		require.Equal(t, "...and [7 more matches](https://sourcegraph.com/search?q=repo%3Acamdentest+-file%3Aid_rsa.pub+BEGIN&utm_source=code-monitor-teams-webhook).", body[len(body)-1].Text)
+	})
+}
+
+func TestTriggerTestTeamsWebhookAction(t *testing.T) {
+	var got teamsMessage
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer s.Close()
+
+	err := SendTestTeamsWebhook(context.Background(), httpcli.TestExternalDoer, "My test monitor", s.URL)
+	require.NoError(t, err)
+	require.Equal(t, []adaptiveElement{newTextBlock("Test message for Code Monitor 'My test monitor'")}, got.Attachments[0].Content.Body)
+}
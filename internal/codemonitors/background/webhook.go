@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
@@ -17,12 +18,17 @@ func sendWebhookNotification(ctx context.Context, url string, args actionArgs) e
 	return postWebhook(ctx, httpcli.ExternalDoer, url, generateWebhookPayload(args))
 }
 
-func postWebhook(ctx context.Context, doer httpcli.Doer, url string, payload webhookPayload) error {
+func postWebhook(ctx context.Context, doer httpcli.Doer, url string, payload any) error {
 	raw, err := json.Marshal(payload)
 	if err != nil {
 		return errors.Wrap(err, "marshal failed")
 	}
+	return postJSON(ctx, doer, url, raw)
+}
 
+// postJSON posts a JSON request body to url. Any 2xx response is treated as
+// success, since APIs that queue events often respond with 202 Accepted.
+func postJSON(ctx context.Context, doer httpcli.Doer, url string, raw []byte) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(raw))
 	if err != nil {
 		return errors.Wrap(err, "failed new request")
@@ -35,7 +41,7 @@ func postWebhook(ctx context.Context, doer httpcli.Doer, url string, payload web
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		body, _ := io.ReadAll(resp.Body)
 		return StatusCodeError{
 			Code:   resp.StatusCode,
@@ -61,6 +67,15 @@ type webhookPayload struct {
 	MonitorURL         string          `json:"monitorURL"`
 	Query              string          `json:"query"`
 	Results            []webhookResult `json:"results,omitempty"`
+	Digest             *webhookDigest  `json:"digest,omitempty"`
+}
+
+// webhookDigest describes the period covered by a digest notification.
+type webhookDigest struct {
+	Interval       string    `json:"interval"`
+	Start          time.Time `json:"start"`
+	End            time.Time `json:"end"`
+	OmittedResults int       `json:"omittedResults"`
 }
 
 func generateWebhookPayload(args actionArgs) webhookPayload {
@@ -74,6 +89,15 @@ func generateWebhookPayload(args actionArgs) webhookPayload {
 		p.Results = generateResults(args.Results)
 	}
 
+	if args.Digest != nil {
+		p.Digest = &webhookDigest{
+			Interval:       args.Digest.Interval,
+			Start:          args.Digest.Start,
+			End:            args.Digest.End,
+			OmittedResults: args.Digest.OmittedCount,
+		}
+	}
+
 	return p
 }
 
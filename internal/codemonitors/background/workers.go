@@ -62,6 +62,21 @@ func newTriggerQueryEnqueuer(ctx context.Context, store database.CodeMonitorStor
 	)
 }
 
+func newDigestActionEnqueuer(ctx context.Context, store database.CodeMonitorStore) goroutine.BackgroundRoutine {
+	enqueueDue := goroutine.HandlerFunc(
+		func(ctx context.Context) error {
+			_, err := store.EnqueueDigestActionJobs(ctx)
+			return err
+		})
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		enqueueDue,
+		goroutine.WithName("code_monitors.digest_action_enqueuer"),
+		goroutine.WithDescription("enqueues digest jobs of code monitor actions"),
+		goroutine.WithInterval(1*time.Minute),
+	)
+}
+
 func newTriggerQueryResetter(_ context.Context, observationCtx *observation.Context, s database.CodeMonitorStore, metrics codeMonitorsMetrics) *dbworker.Resetter[*database.TriggerJob] {
 	workerStore := createDBWorkerStoreForTriggerJobs(observationCtx, s)
 
@@ -223,8 +238,12 @@ func (r *actionRunner) Handle(ctx context.Context, logger log.Logger, j *databas
 		return errors.Wrap(r.handleWebhook(ctx, j), "Webhook")
 	case j.SlackWebhook != nil:
 		return errors.Wrap(r.handleSlackWebhook(ctx, j), "SlackWebhook")
+	case j.TeamsWebhook != nil:
+		return errors.Wrap(r.handleTeamsWebhook(ctx, j), "TeamsWebhook")
+	case j.IncidentWebhook != nil:
+		return errors.Wrap(r.handleIncidentWebhook(ctx, j), "IncidentWebhook")
 	default:
-		return errors.New("job must be one of type email, webhook, or slack webhook")
+		return errors.New("job must be one of type email, webhook, slack webhook, teams webhook, or incident webhook")
 	}
 }
 
@@ -265,6 +284,10 @@ func (r *actionRunner) handleEmail(ctx context.Context, j *database.ActionJob) e
 		Results:            m.Results,
 		IncludeResults:     e.IncludeResults,
 	}
+	args, err = withDigest(ctx, s, j, e.Digest, args)
+	if err != nil {
+		return errors.Wrap(err, "withDigest")
+	}
 
 	data, err := NewTemplateDataForNewSearchResults(args, e)
 	if err != nil {
@@ -318,6 +341,10 @@ func (r *actionRunner) handleWebhook(ctx context.Context, j *database.ActionJob)
 		Results:            m.Results,
 		IncludeResults:     w.IncludeResults,
 	}
+	args, err = withDigest(ctx, s, j, w.Digest, args)
+	if err != nil {
+		return errors.Wrap(err, "withDigest")
+	}
 
 	return sendWebhookNotification(ctx, w.URL, args)
 }
@@ -354,10 +381,87 @@ func (r *actionRunner) handleSlackWebhook(ctx context.Context, j *database.Actio
 		Results:            m.Results,
 		IncludeResults:     w.IncludeResults,
 	}
+	args, err = withDigest(ctx, s, j, w.Digest, args)
+	if err != nil {
+		return errors.Wrap(err, "withDigest")
+	}
 
 	return sendSlackNotification(ctx, w.URL, args)
 }
 
+func (r *actionRunner) handleTeamsWebhook(ctx context.Context, j *database.ActionJob) error {
+	s, err := r.CodeMonitorStore.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = s.Done(err) }()
+
+	m, err := s.GetActionJobMetadata(ctx, j.ID)
+	if err != nil {
+		return errors.Wrap(err, "GetActionJobMetadata")
+	}
+
+	w, err := s.GetTeamsWebhookAction(ctx, *j.TeamsWebhook)
+	if err != nil {
+		return errors.Wrap(err, "GetTeamsWebhookAction")
+	}
+
+	externalURL, err := url.Parse(conf.Get().ExternalURL)
+	if err != nil {
+		return err
+	}
+
+	args := actionArgs{
+		MonitorDescription: m.Description,
+		MonitorID:          w.Monitor,
+		ExternalURL:        externalURL,
+		UTMSource:          "code-monitor-teams-webhook",
+		Query:              m.Query,
+		MonitorOwnerName:   m.OwnerName,
+		Results:            m.Results,
+		IncludeResults:     w.IncludeResults,
+	}
+
+	return sendTeamsNotification(ctx, w.URL, args)
+}
+
+func (r *actionRunner) handleIncidentWebhook(ctx context.Context, j *database.ActionJob) error {
+	s, err := r.CodeMonitorStore.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = s.Done(err) }()
+
+	m, err := s.GetActionJobMetadata(ctx, j.ID)
+	if err != nil {
+		return errors.Wrap(err, "GetActionJobMetadata")
+	}
+
+	w, err := s.GetIncidentWebhookAction(ctx, *j.IncidentWebhook)
+	if err != nil {
+		return errors.Wrap(err, "GetIncidentWebhookAction")
+	}
+
+	externalURL, err := url.Parse(conf.Get().ExternalURL)
+	if err != nil {
+		return err
+	}
+
+	// The payload template decides which parts of the results are sent.
+	args := actionArgs{
+		MonitorDescription: m.Description,
+		MonitorID:          w.Monitor,
+		ExternalURL:        externalURL,
+		UTMSource:          "code-monitor-incident-webhook",
+		Query:              m.Query,
+		MonitorOwnerName:   m.OwnerName,
+		Results:            m.Results,
+		IncludeResults:     true,
+	}
+
+	return sendIncidentNotification(ctx, w.URL, w.PayloadTemplate, args)
+}
+
 type StatusCodeError struct {
 	Code   int
 	Status string
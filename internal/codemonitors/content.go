@@ -0,0 +1,226 @@
+package codemonitors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+// contentMatches are the file content matches of a search, grouped by repository.
+type contentMatches struct {
+	repos map[api.RepoID]*repoContentMatches
+	// limitHit is true if the search did not return all matches.
+	limitHit bool
+}
+
+type repoContentMatches struct {
+	files []*fileContentMatches
+}
+
+type fileContentMatches struct {
+	file *result.FileMatch
+	// lines are the matched lines of the file. It is empty if only the path of the file matched.
+	lines []*result.LineMatch
+}
+
+func runContentSearch(ctx context.Context, clients job.RuntimeClients, planJob job.Job) (*contentMatches, error) {
+	agg := streaming.NewAggregatingStream()
+	_, err := planJob.Run(ctx, clients, agg)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := &contentMatches{
+		repos:    make(map[api.RepoID]*repoContentMatches),
+		limitHit: agg.Stats.IsLimitHit,
+	}
+	for _, res := range agg.Results {
+		// Other results, like repository matches, do not identify file contents.
+		fm, ok := res.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+		repo, ok := matches.repos[fm.Repo.ID]
+		if !ok {
+			repo = &repoContentMatches{}
+			matches.repos[fm.Repo.ID] = repo
+		}
+		repo.files = append(repo.files, &fileContentMatches{file: fm, lines: fm.ChunkMatches.AsLineMatches()})
+	}
+	return matches, nil
+}
+
+// searchContent runs a search of file contents and returns the matches that are new since the last
+// run of the monitor. They are returned as matches of the searched commits, so that they can be handled
+// by the same actions as the results of commit searches.
+func searchContent(ctx context.Context, db database.DB, clients job.RuntimeClients, planJob job.Job, monitorID int64) ([]*result.CommitMatch, error) {
+	matches, err := runContentSearch(ctx, clients, planJob)
+	if err != nil {
+		return nil, err
+	}
+
+	cm := db.CodeMonitors()
+	var newMatches []*fileContentMatches
+	repoIDs := make([]api.RepoID, 0, len(matches.repos))
+	for repoID, repo := range matches.repos {
+		lastMatched, err := cm.GetLastMatched(ctx, monitorID, repoID)
+		if err != nil {
+			return nil, err
+		}
+		seen := make(map[string]struct{}, len(lastMatched))
+		for _, key := range lastMatched {
+			seen[key] = struct{}{}
+		}
+		for _, file := range repo.files {
+			if m := file.newSince(seen); m != nil {
+				newMatches = append(newMatches, m)
+			}
+		}
+
+		matchKeys := repo.keys()
+		if matches.limitHit {
+			// Keep the matches the incomplete search did not return, so that they are not
+			// reported as new once they are returned again.
+			matchKeys = dedupeSorted(append(matchKeys, lastMatched...))
+		}
+		if err := cm.UpsertLastMatched(ctx, monitorID, repoID, matchKeys); err != nil {
+			return nil, err
+		}
+		repoIDs = append(repoIDs, repoID)
+	}
+
+	if !matches.limitHit {
+		// Repositories without matches are reported again once they have matches.
+		if err := cm.DeleteLastMatchedExcept(ctx, monitorID, repoIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(newMatches, func(i, j int) bool {
+		if newMatches[i].file.Repo.Name != newMatches[j].file.Repo.Name {
+			return newMatches[i].file.Repo.Name < newMatches[j].file.Repo.Name
+		}
+		return newMatches[i].file.Path < newMatches[j].file.Path
+	})
+	results := make([]*result.CommitMatch, 0, len(newMatches))
+	for _, m := range newMatches {
+		results = append(results, m.toCommitMatch())
+	}
+	return results, nil
+}
+
+// keys returns the sorted keys of all matches in the repository.
+func (r *repoContentMatches) keys() []string {
+	var keys []string
+	for _, file := range r.files {
+		keys = append(keys, file.keys()...)
+	}
+	return dedupeSorted(keys)
+}
+
+func (f *fileContentMatches) keys() []string {
+	if len(f.lines) == 0 {
+		return []string{matchKey(f.file.Path, nil)}
+	}
+	keys := make([]string, 0, len(f.lines))
+	for _, line := range f.lines {
+		keys = append(keys, matchKey(f.file.Path, line))
+	}
+	return keys
+}
+
+// newSince returns the matches of the file whose keys are not in seen, or nil if there are none.
+func (f *fileContentMatches) newSince(seen map[string]struct{}) *fileContentMatches {
+	if len(f.lines) == 0 {
+		if _, ok := seen[matchKey(f.file.Path, nil)]; ok {
+			return nil
+		}
+		return f
+	}
+
+	var lines []*result.LineMatch
+	for _, line := range f.lines {
+		if _, ok := seen[matchKey(f.file.Path, line)]; !ok {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return &fileContentMatches{file: f.file, lines: lines}
+}
+
+// toCommitMatch returns a match of the commit of the file, with a diff that adds the matched lines.
+func (f *fileContentMatches) toCommitMatch() *result.CommitMatch {
+	var buf strings.Builder
+	buf.WriteString(result.FormatDiffFiles([]result.DiffFile{{OrigName: f.file.Path, NewName: f.file.Path}}))
+
+	var ranges result.Ranges
+	line := 1
+	for _, l := range f.lines {
+		lineNumber := int(l.LineNumber) + 1
+		fmt.Fprintf(&buf, "@@ -%d,0 +%d,1 @@\n", lineNumber-1, lineNumber)
+		line++
+
+		// Matches start after the "+" prefix of the added line. Their offsets are counted in
+		// characters, but the offsets of ranges in bytes.
+		offset := buf.Len() + 1
+		runes := []rune(l.Preview)
+		for _, ol := range l.OffsetAndLengths {
+			start, end := int(ol[0]), int(ol[0]+ol[1])
+			if end > len(runes) {
+				continue
+			}
+			ranges = append(ranges, result.Range{
+				Start: result.Location{Offset: offset + len(string(runes[:start])), Line: line, Column: start + 1},
+				End:   result.Location{Offset: offset + len(string(runes[:end])), Line: line, Column: end + 1},
+			})
+		}
+		buf.WriteByte('+')
+		buf.WriteString(l.Preview)
+		buf.WriteByte('\n')
+		line++
+	}
+
+	preview := &result.MatchedString{Content: buf.String(), MatchedRanges: ranges}
+	diff, _ := result.ParseDiffString(preview.Content)
+	return &result.CommitMatch{
+		Commit:      gitdomain.Commit{ID: f.file.CommitID},
+		Repo:        f.file.Repo,
+		DiffPreview: preview,
+		Diff:        diff,
+	}
+}
+
+// matchKey identifies a matched line by the path and the content of the line, so that the match is
+// not reported again when other lines of the file change. The key of a file whose path matched is
+// derived from its path only. Keys are hashed to not store file contents.
+func matchKey(path string, line *result.LineMatch) string {
+	data := path
+	if line != nil {
+		data += "\x00" + line.Preview
+	}
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+func dedupeSorted(keys []string) []string {
+	sort.Strings(keys)
+	out := keys[:0]
+	for _, key := range keys {
+		if len(out) == 0 || key != out[len(out)-1] {
+			out = append(out, key)
+		}
+	}
+	return out
+}
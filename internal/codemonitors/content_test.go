@@ -0,0 +1,68 @@
+package codemonitors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSearchContent(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+	secrets := &result.FileMatch{
+		File: result.File{Repo: repo, CommitID: "abc", Path: "config.env"},
+		ChunkMatches: result.ChunkMatches{{
+			Content:      "AWS_SECRET=old\nAWS_SECRET=new",
+			ContentStart: result.Location{Offset: 10, Line: 1, Column: 0},
+			Ranges: result.Ranges{{
+				Start: result.Location{Offset: 10, Line: 1, Column: 0},
+				End:   result.Location{Offset: 20, Line: 1, Column: 10},
+			}, {
+				Start: result.Location{Offset: 25, Line: 2, Column: 0},
+				End:   result.Location{Offset: 35, Line: 2, Column: 10},
+			}},
+		}},
+	}
+	pathMatch := &result.FileMatch{
+		File: result.File{Repo: repo, CommitID: "abc", Path: "AWS_SECRET.txt"},
+	}
+
+	planJob := mockjob.NewMockJob()
+	planJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{Results: result.Matches{secrets, pathMatch, &result.RepoMatch{Name: repo.Name, ID: repo.ID}}})
+		return nil, nil
+	})
+
+	cm := dbmocks.NewMockCodeMonitorStore()
+	cm.GetLastMatchedFunc.SetDefaultReturn([]string{matchKey("config.env", &result.LineMatch{Preview: "AWS_SECRET=old"})}, nil)
+	db := dbmocks.NewMockDB()
+	db.CodeMonitorsFunc.SetDefaultReturn(cm)
+
+	results, err := searchContent(context.Background(), db, job.RuntimeClients{}, planJob, 42)
+	require.NoError(t, err)
+
+	// Only the new line and the new file are reported.
+	require.Len(t, results, 2)
+	require.Equal(t, "AWS_SECRET.txt AWS_SECRET.txt\n", results[0].DiffPreview.Content)
+	require.Equal(t, "config.env config.env\n@@ -2,0 +3,1 @@\n+AWS_SECRET=new\n", results[1].DiffPreview.Content)
+	require.Equal(t, result.Ranges{{
+		Start: result.Location{Offset: 39, Line: 2, Column: 1},
+		End:   result.Location{Offset: 49, Line: 2, Column: 11},
+	}}, results[1].DiffPreview.MatchedRanges)
+	require.Equal(t, api.CommitID("abc"), results[1].Commit.ID)
+
+	// All current matches are saved for the next run.
+	require.Len(t, cm.UpsertLastMatchedFunc.History(), 1)
+	require.Len(t, cm.UpsertLastMatchedFunc.History()[0].Arg3, 3)
+	require.Len(t, cm.DeleteLastMatchedExceptFunc.History(), 1)
+	require.Equal(t, []api.RepoID{1}, cm.DeleteLastMatchedExceptFunc.History()[0].Arg2)
+}
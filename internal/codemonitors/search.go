@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/search/commit"
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
 	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/search/repos"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
@@ -46,6 +47,13 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 		return nil, errcode.MakeNonRetryable(err)
 	}
 
+	if !job.HasDescendent[*commit.SearchJob](planJob) {
+		if !searchesFileContents(inputs.Plan) {
+			return nil, errcode.MakeNonRetryable(ErrInvalidMonitorQueryType)
+		}
+		return searchContent(ctx, db, clients, planJob, monitorID)
+	}
+
 	hook := func(ctx context.Context, db database.DB, gs commit.GitserverClient, args *gitprotocol.SearchRequest, repoID api.RepoID, doSearch commit.DoSearchFunc) error {
 		return hookWithID(ctx, db, gs, monitorID, repoID, args, doSearch)
 	}
@@ -73,10 +81,46 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 	return results, nil
 }
 
+// MonitorState is the state of the repositories searched by a code monitor, which is saved so that
+// subsequent runs of the monitor only notify about changes since then.
+type MonitorState struct {
+	// ResolvedRevisions are the commits searched in each repository by a monitor that searches
+	// commits.
+	ResolvedRevisions map[api.RepoID][]string
+	// MatchKeys identify the file content matches in each repository of a monitor that searches
+	// file contents. It is nil for monitors that search commits.
+	MatchKeys map[api.RepoID][]string
+}
+
+// Save saves the state for the given code monitor.
+func (s *MonitorState) Save(ctx context.Context, cm database.CodeMonitorStore, monitorID int64) error {
+	for repoID, commitIDs := range s.ResolvedRevisions {
+		if err := cm.UpsertLastSearched(ctx, monitorID, repoID, commitIDs); err != nil {
+			return err
+		}
+	}
+
+	if s.MatchKeys == nil {
+		// Forget the matches of a previous query of the monitor that searched file contents, so
+		// that they are not compared against if the monitor searches file contents again.
+		return cm.DeleteLastMatchedExcept(ctx, monitorID, nil)
+	}
+	repoIDs := make([]api.RepoID, 0, len(s.MatchKeys))
+	for repoID, matchKeys := range s.MatchKeys {
+		if err := cm.UpsertLastMatched(ctx, monitorID, repoID, matchKeys); err != nil {
+			return err
+		}
+		repoIDs = append(repoIDs, repoID)
+	}
+	// Forget the matches of a previous query of the monitor.
+	return cm.DeleteLastMatchedExcept(ctx, monitorID, repoIDs)
+}
+
 // Snapshot runs a dummy search that just saves the current state of the searched repos in the database.
 // On subsequent runs, this allows us to treat all new repos or sets of args as something new that should
-// be searched from the beginning.
-func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query string) (map[api.RepoID][]string, error) {
+// be searched from the beginning. For queries that search file contents, the snapshot contains the current
+// matches so that only matches that appear later are treated as new.
+func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query string) (*MonitorState, error) {
 	if db.Handle().InTransaction() {
 		return nil, errors.New("Snapshot cannot be run in a transaction")
 	}
@@ -101,6 +145,21 @@ func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query stri
 		return nil, err
 	}
 
+	if !job.HasDescendent[*commit.SearchJob](planJob) {
+		if !searchesFileContents(inputs.Plan) {
+			return nil, ErrInvalidMonitorQueryType
+		}
+		matches, err := runContentSearch(ctx, clients, planJob)
+		if err != nil {
+			return nil, err
+		}
+		matchKeys := make(map[api.RepoID][]string, len(matches.repos))
+		for repoID, repo := range matches.repos {
+			matchKeys[repoID] = repo.keys()
+		}
+		return &MonitorState{MatchKeys: matchKeys}, nil
+	}
+
 	var (
 		mu                sync.Mutex
 		resolvedRevisions = make(map[api.RepoID][]string)
@@ -130,11 +189,46 @@ func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query stri
 		return nil, err
 	}
 
-	return resolvedRevisions, nil
+	return &MonitorState{ResolvedRevisions: resolvedRevisions}, nil
 }
 
 var ErrInvalidMonitorQuery = errors.New("code monitor cannot use different patterns for different repos")
 
+var ErrInvalidMonitorQueryType = errors.New("code monitor queries must contain type:diff or type:commit to search commits, or type:file to search file contents. If you have an AND/OR operator in your query, ensure that both sides have the same type.")
+
+// SearchesFileContents returns true if the given code monitor query searches
+// file contents rather than commits.
+func SearchesFileContents(q string) bool {
+	plan, err := query.Pipeline(query.Init(q, query.SearchTypeStandard))
+	if err != nil {
+		return false
+	}
+	return searchesFileContents(plan)
+}
+
+// searchesFileContents returns true if every branch of the query only searches file contents with an
+// explicit type:file. Queries without a type, or that search repositories, symbols or paths, are not
+// valid code monitor queries.
+func searchesFileContents(plan query.Plan) bool {
+	if len(plan) == 0 {
+		return false
+	}
+	for _, basic := range plan {
+		file, other := false, false
+		basic.Parameters.VisitParameter(query.FieldType, func(value string, negated bool, _ query.Annotation) {
+			if value == "file" && !negated {
+				file = true
+			} else {
+				other = true
+			}
+		})
+		if !file || other {
+			return false
+		}
+	}
+	return true
+}
+
 func addCodeMonitorHook(in job.Job, hook commit.CodeMonitorHook) (_ job.Job, err error) {
 	commitSearchJobCount := 0
 	return job.Map(in, func(j job.Job) job.Job {
@@ -154,7 +248,7 @@ func addCodeMonitorHook(in job.Job, hook commit.CodeMonitorHook) (_ job.Job, err
 		default:
 			if len(j.Children()) == 0 {
 				if err == nil {
-					err = errors.New("all branches of query must be of type:diff or type:commit. If you have an AND/OR operator in your query, ensure that both sides have type:commit or type:diff.")
+					err = errors.New("all branches of query must be of type:diff or type:commit, or all of them of type:file to search file contents. If you have an AND/OR operator in your query, ensure that both sides have type:commit or type:diff.")
 				}
 			}
 			return j
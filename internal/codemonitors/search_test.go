@@ -9,7 +9,9 @@ import (
 	"github.com/sourcegraph/log/logtest"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	gitprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
@@ -179,3 +181,46 @@ func TestCodeMonitorHook(t *testing.T) {
 		require.ErrorContains(t, err, "some commits may be skipped")
 	})
 }
+
+func TestSearchesFileContents(t *testing.T) {
+	for input, want := range map[string]bool{
+		"type:file AWS_SECRET":                   true,
+		"type:file AWS_SECRET repo:sourcegraph":  true,
+		"type:file a or type:file b":             true,
+		"AWS_SECRET":                             false,
+		"type:repo sourcegraph":                  false,
+		"type:symbol NewClient":                  false,
+		"type:path config.env":                   false,
+		"type:file type:repo AWS_SECRET":         false,
+		"type:file AWS_SECRET or type:diff hash": false,
+	} {
+		t.Run(input, func(t *testing.T) {
+			require.Equal(t, want, SearchesFileContents(input))
+		})
+	}
+}
+
+func TestMonitorStateSave(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("commit search forgets previous matches", func(t *testing.T) {
+		cm := dbmocks.NewMockCodeMonitorStore()
+		state := &MonitorState{ResolvedRevisions: map[api.RepoID][]string{1: {"abc"}}}
+		require.NoError(t, state.Save(ctx, cm, 42))
+
+		require.Len(t, cm.UpsertLastSearchedFunc.History(), 1)
+		require.Empty(t, cm.UpsertLastMatchedFunc.History())
+		require.Len(t, cm.DeleteLastMatchedExceptFunc.History(), 1)
+		require.Empty(t, cm.DeleteLastMatchedExceptFunc.History()[0].Arg2)
+	})
+
+	t.Run("content search keeps matches of searched repos", func(t *testing.T) {
+		cm := dbmocks.NewMockCodeMonitorStore()
+		state := &MonitorState{MatchKeys: map[api.RepoID][]string{1: {"key"}}}
+		require.NoError(t, state.Save(ctx, cm, 42))
+
+		require.Len(t, cm.UpsertLastMatchedFunc.History(), 1)
+		require.Len(t, cm.DeleteLastMatchedExceptFunc.History(), 1)
+		require.Equal(t, []api.RepoID{1}, cm.DeleteLastMatchedExceptFunc.History()[0].Arg2)
+	})
+}
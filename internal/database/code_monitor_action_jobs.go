@@ -14,11 +14,18 @@ import (
 )
 
 type ActionJob struct {
-	ID           int32
-	Email        *int64
-	Webhook      *int64
-	SlackWebhook *int64
-	TriggerEvent int32
+	ID              int32
+	Email           *int64
+	Webhook         *int64
+	SlackWebhook    *int64
+	TeamsWebhook    *int64
+	IncidentWebhook *int64
+	TriggerEvent    int32
+
+	// DigestStart and DigestEnd are set if the job sends a digest of the
+	// trigger runs of the monitor that finished in (DigestStart, DigestEnd].
+	DigestStart *time.Time
+	DigestEnd   *time.Time
 
 	// Fields demanded by any dbworker.
 	State          string
@@ -45,7 +52,8 @@ type ActionJobMetadata struct {
 	Results     []*result.CommitMatch
 	OwnerName   string
 
-	// The query with after: filter.
+	// The query with after: filter. For digest jobs, this is the query of the
+	// trigger without the filter, since the digest covers several runs.
 	Query string
 }
 
@@ -56,7 +64,11 @@ var ActionJobColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_action_jobs.email"),
 	sqlf.Sprintf("cm_action_jobs.webhook"),
 	sqlf.Sprintf("cm_action_jobs.slack_webhook"),
+	sqlf.Sprintf("cm_action_jobs.teams_webhook"),
+	sqlf.Sprintf("cm_action_jobs.incident_webhook"),
 	sqlf.Sprintf("cm_action_jobs.trigger_event"),
+	sqlf.Sprintf("cm_action_jobs.digest_start"),
+	sqlf.Sprintf("cm_action_jobs.digest_end"),
 	sqlf.Sprintf("cm_action_jobs.state"),
 	sqlf.Sprintf("cm_action_jobs.failure_message"),
 	sqlf.Sprintf("cm_action_jobs.started_at"),
@@ -87,6 +99,14 @@ type ListActionJobsOpts struct {
 	// the given slack webhook action. Refers to cm_slack_webhooks(id)
 	SlackWebhookID *int
 
+	// TeamsWebhookID, if set, will filter to only actions jobs that are executing
+	// the given Microsoft Teams webhook action. Refers to cm_teams_webhooks(id)
+	TeamsWebhookID *int
+
+	// IncidentWebhookID, if set, will filter to only actions jobs that are executing
+	// the given incident webhook action. Refers to cm_incident_webhooks(id)
+	IncidentWebhookID *int
+
 	// First, if defined, limits the operation to only the first n results
 	First *int
 
@@ -110,6 +130,12 @@ func (o ListActionJobsOpts) Conds() *sqlf.Query {
 	if o.SlackWebhookID != nil {
 		conds = append(conds, sqlf.Sprintf("slack_webhook = %s", *o.SlackWebhookID))
 	}
+	if o.TeamsWebhookID != nil {
+		conds = append(conds, sqlf.Sprintf("teams_webhook = %s", *o.TeamsWebhookID))
+	}
+	if o.IncidentWebhookID != nil {
+		conds = append(conds, sqlf.Sprintf("incident_webhook = %s", *o.IncidentWebhookID))
+	}
 	if o.After != nil {
 		conds = append(conds, sqlf.Sprintf("id > %s", *o.After))
 	}
@@ -175,6 +201,7 @@ WITH due_emails AS (
 	FROM cm_emails
 	WHERE monitor = %s
 		AND enabled = true
+		AND digest_interval IS NULL
 	EXCEPT
 	SELECT DISTINCT email as id FROM cm_action_jobs
 	WHERE state = 'queued'
@@ -184,6 +211,7 @@ WITH due_emails AS (
 	FROM cm_webhooks
 	WHERE monitor = %s
 		AND enabled = true
+		AND digest_interval IS NULL
 	EXCEPT
 	SELECT DISTINCT webhook as id FROM cm_action_jobs
 	WHERE state = 'queued'
@@ -193,18 +221,41 @@ WITH due_emails AS (
 	FROM cm_slack_webhooks
 	WHERE monitor = %s
 		AND enabled = true
+		AND digest_interval IS NULL
 	EXCEPT
 	SELECT DISTINCT slack_webhook as id FROM cm_action_jobs
 	WHERE state = 'queued'
 		OR state = 'processing'
+), due_teams_webhooks AS (
+	SELECT id
+	FROM cm_teams_webhooks
+	WHERE monitor = %s
+		AND enabled = true
+	EXCEPT
+	SELECT DISTINCT teams_webhook as id FROM cm_action_jobs
+	WHERE state = 'queued'
+		OR state = 'processing'
+), due_incident_webhooks AS (
+	SELECT id
+	FROM cm_incident_webhooks
+	WHERE monitor = %s
+		AND enabled = true
+	EXCEPT
+	SELECT DISTINCT incident_webhook as id FROM cm_action_jobs
+	WHERE state = 'queued'
+		OR state = 'processing'
 )
-INSERT INTO cm_action_jobs (email, webhook, slack_webhook, trigger_event)
-SELECT id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer from due_emails
+INSERT INTO cm_action_jobs (email, webhook, slack_webhook, teams_webhook, incident_webhook, trigger_event)
+SELECT id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer from due_emails
+UNION
+SELECT CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer from due_webhooks
 UNION
-SELECT CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), %s::integer from due_webhooks
+SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer from due_slack_webhooks
 UNION
-SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, %s::integer from due_slack_webhooks
-ORDER BY 1, 2, 3
+SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), %s::integer from due_teams_webhooks
+UNION
+SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, %s::integer from due_incident_webhooks
+ORDER BY 1, 2, 3, 4, 5
 RETURNING %s
 `
 
@@ -214,6 +265,10 @@ func (s *codeMonitorStore) EnqueueActionJobsForMonitor(ctx context.Context, moni
 		monitorID,
 		monitorID,
 		monitorID,
+		monitorID,
+		monitorID,
+		triggerJobID,
+		triggerJobID,
 		triggerJobID,
 		triggerJobID,
 		triggerJobID,
@@ -230,7 +285,7 @@ func (s *codeMonitorStore) EnqueueActionJobsForMonitor(ctx context.Context, moni
 const getActionJobMetadataFmtStr = `
 SELECT
 	cm.description,
-	ctj.query_string,
+	CASE WHEN caj.digest_start IS NULL THEN ctj.query_string ELSE cq.query END,
 	cm.id AS monitorID,
 	ctj.search_results,
 	CASE WHEN LENGTH(users.display_name) > 0 THEN users.display_name ELSE users.username END
@@ -288,7 +343,11 @@ func ScanActionJob(row dbutil.Scanner) (*ActionJob, error) {
 		&aj.Email,
 		&aj.Webhook,
 		&aj.SlackWebhook,
+		&aj.TeamsWebhook,
+		&aj.IncidentWebhook,
 		&aj.TriggerEvent,
+		&aj.DigestStart,
+		&aj.DigestEnd,
 		&aj.State,
 		&aj.FailureMessage,
 		&aj.StartedAt,
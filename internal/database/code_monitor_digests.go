@@ -0,0 +1,180 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// The intervals at which a code monitor action can send digests.
+const (
+	DigestIntervalHourly = "HOURLY"
+	DigestIntervalDaily  = "DAILY"
+	DigestIntervalWeekly = "WEEKLY"
+)
+
+// ActionDigest configures a code monitor action to periodically send a single
+// notification for the matches found since the previous one, rather than a
+// notification for every trigger run with matches.
+type ActionDigest struct {
+	Interval   string
+	MaxResults int32
+}
+
+// digestColumnValues returns the values of the digest_interval and
+// digest_max_results columns of an action with the given digest.
+func digestColumnValues(d *ActionDigest) (interval *string, maxResults *int32) {
+	if d == nil {
+		return nil, nil
+	}
+	return &d.Interval, &d.MaxResults
+}
+
+// digestStartValue returns the value of the last_digest_at column of a new
+// action with the given digest. Its first digest covers the matches found
+// after it was created.
+func digestStartValue(d *ActionDigest, now time.Time) *time.Time {
+	if d == nil {
+		return nil
+	}
+	return &now
+}
+
+// digestFromColumns is the inverse of digestColumnValues.
+func digestFromColumns(interval *string, maxResults *int32) *ActionDigest {
+	if interval == nil {
+		return nil
+	}
+	d := &ActionDigest{Interval: *interval}
+	if maxResults != nil {
+		d.MaxResults = *maxResults
+	}
+	return d
+}
+
+// digestActionTables lists the action tables that support digests, along with
+// the cm_action_jobs column that references them.
+var digestActionTables = []struct {
+	table  string
+	column string
+}{
+	{table: "cm_emails", column: "email"},
+	{table: "cm_webhooks", column: "webhook"},
+	{table: "cm_slack_webhooks", column: "slack_webhook"},
+}
+
+const enqueueDigestActionJobsFmtStr = `
+WITH due AS (
+	SELECT a.id, a.monitor, a.last_digest_at
+	FROM %s a
+	INNER JOIN cm_monitors cm ON cm.id = a.monitor
+	WHERE a.enabled = true
+		AND cm.enabled = true
+		AND a.digest_interval IS NOT NULL
+		AND a.last_digest_at + CASE a.digest_interval
+			WHEN 'HOURLY' THEN interval '1 hour'
+			WHEN 'DAILY' THEN interval '1 day'
+			WHEN 'WEEKLY' THEN interval '1 week'
+		END <= %s
+		AND NOT EXISTS (
+			SELECT 1 FROM cm_action_jobs caj
+			WHERE caj.%s = a.id
+				AND (caj.state = 'queued' OR caj.state = 'processing')
+		)
+), advanced AS (
+	UPDATE %s a
+	SET last_digest_at = %s
+	FROM due
+	WHERE a.id = due.id
+), latest AS (
+	SELECT due.id, due.last_digest_at, MAX(ctj.id) AS trigger_event
+	FROM due
+	INNER JOIN cm_queries cq ON cq.monitor = due.monitor
+	INNER JOIN cm_trigger_jobs ctj ON ctj.query = cq.id
+	WHERE ctj.state = 'completed'
+		AND jsonb_array_length(ctj.search_results) > 0
+		AND ctj.finished_at > due.last_digest_at
+		AND ctj.finished_at <= %s
+	GROUP BY due.id, due.last_digest_at
+)
+INSERT INTO cm_action_jobs (%s, trigger_event, digest_start, digest_end)
+SELECT id, trigger_event, last_digest_at, %s::timestamptz FROM latest
+ORDER BY id
+RETURNING %s
+`
+
+// EnqueueDigestActionJobs enqueues a digest job for every enabled digest action
+// whose interval has elapsed since its last digest, if its monitor found matches
+// in that period. The next period of all due actions starts now, whether or not
+// a job was enqueued for them.
+func (s *codeMonitorStore) EnqueueDigestActionJobs(ctx context.Context) ([]*ActionJob, error) {
+	now := s.Now()
+	var jobs []*ActionJob
+	for _, t := range digestActionTables {
+		q := sqlf.Sprintf(
+			enqueueDigestActionJobsFmtStr,
+			sqlf.Sprintf(t.table),
+			now,
+			sqlf.Sprintf(t.column),
+			sqlf.Sprintf(t.table),
+			now,
+			now,
+			sqlf.Sprintf(t.column),
+			now,
+			sqlf.Join(ActionJobColumns, ","),
+		)
+		rows, err := s.Query(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		js, err := scanActionJobs(rows)
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, js...)
+	}
+	return jobs, nil
+}
+
+const listDigestResultsFmtStr = `
+SELECT ctj.search_results
+FROM cm_action_jobs caj
+INNER JOIN cm_trigger_jobs latest ON latest.id = caj.trigger_event
+INNER JOIN cm_trigger_jobs ctj ON ctj.query = latest.query
+WHERE caj.id = %s
+	AND ctj.state = 'completed'
+	AND jsonb_array_length(ctj.search_results) > 0
+	AND ctj.finished_at > caj.digest_start
+	AND ctj.finished_at <= caj.digest_end
+ORDER BY ctj.id DESC
+`
+
+// ListDigestResults returns the results of all trigger runs covered by a digest
+// job, starting with the most recent run. The same commit can be returned more
+// than once if it matched in several runs.
+func (s *codeMonitorStore) ListDigestResults(ctx context.Context, jobID int32) ([]*result.CommitMatch, error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(listDigestResultsFmtStr, jobID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*result.CommitMatch
+	for rows.Next() {
+		var resultsJSON []byte
+		if err := rows.Scan(&resultsJSON); err != nil {
+			return nil, err
+		}
+		var runResults []*result.CommitMatch
+		if err := json.Unmarshal(resultsJSON, &runResults); err != nil {
+			return nil, err
+		}
+		results = append(results, runResults...)
+	}
+	return results, rows.Err()
+}
@@ -0,0 +1,127 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestDigestActionJobs(t *testing.T) {
+	ctx, db, s := newTestStore(t)
+	_, _, userCTX := newTestUser(ctx, t, db)
+	fixtures := s.insertTestMonitor(userCTX, t)
+
+	createdAt := s.Now()
+	digest := &ActionDigest{Interval: DigestIntervalDaily, MaxResults: 10}
+	webhook, err := s.CreateWebhookAction(userCTX, fixtures.monitor.ID, true, true, "https://example.com", digest)
+	require.NoError(t, err)
+	require.Equal(t, digest, webhook.Digest)
+
+	newResults := func(commits ...api.CommitID) []*result.CommitMatch {
+		results := make([]*result.CommitMatch, 0, len(commits))
+		for _, c := range commits {
+			results = append(results, &result.CommitMatch{
+				Repo:   types.MinimalRepo{ID: 1, Name: "github.com/test/test"},
+				Commit: gitdomain.Commit{ID: c},
+			})
+		}
+		return results
+	}
+
+	// runTrigger runs the trigger of the monitor at the current time, and
+	// enqueues the jobs of the actions that do not send digests.
+	runTrigger := func(results []*result.CommitMatch) []*ActionJob {
+		t.Helper()
+		triggerJobs, err := s.EnqueueQueryTriggerJobs(ctx)
+		require.NoError(t, err)
+		require.Len(t, triggerJobs, 1)
+
+		err = s.UpdateTriggerJobWithResults(ctx, triggerJobs[0].ID, testQuery, results)
+		require.NoError(t, err)
+		err = s.Exec(ctx, sqlf.Sprintf(setToCompletedFmtStr, s.Now(), s.Now(), triggerJobs[0].ID))
+		require.NoError(t, err)
+
+		actionJobs, err := s.EnqueueActionJobsForMonitor(ctx, fixtures.monitor.ID, triggerJobs[0].ID)
+		require.NoError(t, err)
+		return actionJobs
+	}
+
+	advance := func(d time.Duration) {
+		now := s.Now().Add(d)
+		s.now = func() time.Time { return now }
+	}
+
+	advance(time.Hour)
+	actionJobs := runTrigger(newResults("a", "b"))
+	// Only the two email actions send a notification per run.
+	require.Len(t, actionJobs, 2)
+	for _, j := range actionJobs {
+		require.Nil(t, j.Webhook)
+		require.Nil(t, j.DigestStart)
+	}
+	completeActionJobs(t, s, actionJobs)
+
+	advance(time.Hour)
+	completeActionJobs(t, s, runTrigger(newResults("b", "c")))
+
+	// The digest is not due before a day has passed since the action was created.
+	digestJobs, err := s.EnqueueDigestActionJobs(ctx)
+	require.NoError(t, err)
+	require.Empty(t, digestJobs)
+
+	advance(23 * time.Hour)
+	digestJobs, err = s.EnqueueDigestActionJobs(ctx)
+	require.NoError(t, err)
+	require.Len(t, digestJobs, 1)
+	require.Equal(t, &webhook.ID, digestJobs[0].Webhook)
+	require.Equal(t, createdAt.UTC(), digestJobs[0].DigestStart.UTC())
+	require.Equal(t, s.Now().UTC(), digestJobs[0].DigestEnd.UTC())
+
+	results, err := s.ListDigestResults(ctx, digestJobs[0].ID)
+	require.NoError(t, err)
+	var got []api.CommitID
+	for _, r := range results {
+		got = append(got, r.Commit.ID)
+	}
+	// Most recent run first.
+	require.Equal(t, []api.CommitID{"b", "c", "a", "b"}, got)
+
+	metadata, err := s.GetActionJobMetadata(ctx, digestJobs[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, testQuery, metadata.Query)
+	completeActionJobs(t, s, digestJobs)
+
+	// The next digest covers the next day.
+	advance(time.Hour)
+	digestJobs, err = s.EnqueueDigestActionJobs(ctx)
+	require.NoError(t, err)
+	require.Empty(t, digestJobs)
+
+	// Once the period has elapsed, no digest is sent if there were no matches.
+	advance(24 * time.Hour)
+	digestJobs, err = s.EnqueueDigestActionJobs(ctx)
+	require.NoError(t, err)
+	require.Empty(t, digestJobs)
+
+	// Switching back to a notification per run clears the digest.
+	webhook, err = s.UpdateWebhookAction(userCTX, webhook.ID, true, true, "https://example.com", nil)
+	require.NoError(t, err)
+	require.Nil(t, webhook.Digest)
+	require.Len(t, runTrigger(newResults("d")), 3)
+}
+
+func completeActionJobs(t *testing.T, s *codeMonitorStore, jobs []*ActionJob) {
+	t.Helper()
+	for _, j := range jobs {
+		err := s.Exec(context.Background(), sqlf.Sprintf("UPDATE cm_action_jobs SET state = 'completed' WHERE id = %s", j.ID))
+		require.NoError(t, err)
+	}
+}
@@ -18,6 +18,7 @@ type EmailAction struct {
 	Priority       string
 	Header         string
 	IncludeResults bool
+	Digest         *ActionDigest
 	CreatedBy      int32
 	CreatedAt      time.Time
 	ChangedBy      int32
@@ -30,6 +31,9 @@ SET enabled = %s,
     include_results = %s,
 	priority = %s,
 	header = %s,
+	digest_interval = %s,
+	digest_max_results = %s,
+	last_digest_at = CASE WHEN %s THEN COALESCE(cm_emails.last_digest_at, %s) END,
 	changed_by = %s,
 	changed_at = %s
 WHERE
@@ -47,6 +51,10 @@ type EmailActionArgs struct {
 	IncludeResults bool
 	Priority       string
 	Header         string
+
+	// Digest, if set, makes the action send periodic digests rather than a
+	// notification per trigger run.
+	Digest *ActionDigest
 }
 
 func (s *codeMonitorStore) UpdateEmailAction(ctx context.Context, id int64, args *EmailActionArgs) (*EmailAction, error) {
@@ -57,14 +65,20 @@ func (s *codeMonitorStore) UpdateEmailAction(ctx context.Context, id int64, args
 		return nil, err
 	}
 
+	now := s.Now()
+	digestInterval, digestMaxResults := digestColumnValues(args.Digest)
 	q := sqlf.Sprintf(
 		updateActionEmailFmtStr,
 		args.Enabled,
 		args.IncludeResults,
 		args.Priority,
 		args.Header,
+		digestInterval,
+		digestMaxResults,
+		args.Digest != nil,
+		now,
 		a.UID,
-		s.Now(),
+		now,
 		id,
 		namespaceScopeQuery(user),
 		sqlf.Join(emailsColumns, ", "),
@@ -76,14 +90,15 @@ func (s *codeMonitorStore) UpdateEmailAction(ctx context.Context, id int64, args
 
 const createActionEmailFmtStr = `
 INSERT INTO cm_emails
-(monitor, enabled, include_results, priority, header, created_by, created_at, changed_by, changed_at)
-VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s)
+(monitor, enabled, include_results, priority, header, digest_interval, digest_max_results, last_digest_at, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s)
 RETURNING %s;
 `
 
 func (s *codeMonitorStore) CreateEmailAction(ctx context.Context, monitorID int64, args *EmailActionArgs) (*EmailAction, error) {
 	now := s.Now()
 	a := actor.FromContext(ctx)
+	digestInterval, digestMaxResults := digestColumnValues(args.Digest)
 	q := sqlf.Sprintf(
 		createActionEmailFmtStr,
 		monitorID,
@@ -91,6 +106,9 @@ func (s *codeMonitorStore) CreateEmailAction(ctx context.Context, monitorID int6
 		args.IncludeResults,
 		args.Priority,
 		args.Header,
+		digestInterval,
+		digestMaxResults,
+		digestStartValue(args.Digest, now),
 		a.UID,
 		now,
 		a.UID,
@@ -208,6 +226,8 @@ var emailsColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_emails.priority"),
 	sqlf.Sprintf("cm_emails.header"),
 	sqlf.Sprintf("cm_emails.include_results"),
+	sqlf.Sprintf("cm_emails.digest_interval"),
+	sqlf.Sprintf("cm_emails.digest_max_results"),
 	sqlf.Sprintf("cm_emails.created_by"),
 	sqlf.Sprintf("cm_emails.created_at"),
 	sqlf.Sprintf("cm_emails.changed_by"),
@@ -229,7 +249,11 @@ func scanEmails(rows *sql.Rows) ([]*EmailAction, error) {
 // scanEmail scans a MonitorEmail from a *sql.Row or *sql.Rows.
 // It must be kept in sync with emailsColumns.
 func scanEmail(scanner dbutil.Scanner) (*EmailAction, error) {
-	m := &EmailAction{}
+	var (
+		m                = &EmailAction{}
+		digestInterval   *string
+		digestMaxResults *int32
+	)
 	err := scanner.Scan(
 		&m.ID,
 		&m.Monitor,
@@ -237,10 +261,13 @@ func scanEmail(scanner dbutil.Scanner) (*EmailAction, error) {
 		&m.Priority,
 		&m.Header,
 		&m.IncludeResults,
+		&digestInterval,
+		&digestMaxResults,
 		&m.CreatedBy,
 		&m.CreatedAt,
 		&m.ChangedBy,
 		&m.ChangedAt,
 	)
+	m.Digest = digestFromColumns(digestInterval, digestMaxResults)
 	return m, err
 }
@@ -0,0 +1,215 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+type IncidentWebhookAction struct {
+	ID      int64
+	Monitor int64
+	Enabled bool
+	URL     string
+
+	// PayloadTemplate is a Go text/template that renders the JSON body sent
+	// to URL from the trigger results.
+	PayloadTemplate string
+
+	CreatedBy int32
+	CreatedAt time.Time
+	ChangedBy int32
+	ChangedAt time.Time
+}
+
+const updateIncidentWebhookActionQuery = `
+UPDATE cm_incident_webhooks
+SET enabled = %s,
+	url = %s,
+	payload_template = %s,
+	changed_by = %s,
+	changed_at = %s
+WHERE
+	id = %s
+	AND EXISTS (
+		SELECT 1 FROM cm_monitors
+		WHERE cm_monitors.id = cm_incident_webhooks.monitor
+			AND %s
+	)
+RETURNING %s;
+`
+
+func (s *codeMonitorStore) UpdateIncidentWebhookAction(ctx context.Context, id int64, enabled bool, url, payloadTemplate string) (*IncidentWebhookAction, error) {
+	a := actor.FromContext(ctx)
+
+	user, err := a.User(ctx, s.userStore)
+	if err != nil {
+		return nil, err
+	}
+
+	q := sqlf.Sprintf(
+		updateIncidentWebhookActionQuery,
+		enabled,
+		url,
+		payloadTemplate,
+		a.UID,
+		s.Now(),
+		id,
+		namespaceScopeQuery(user),
+		sqlf.Join(incidentWebhookActionColumns, ","),
+	)
+
+	row := s.QueryRow(ctx, q)
+	return scanIncidentWebhookAction(row)
+}
+
+const createIncidentWebhookActionQuery = `
+INSERT INTO cm_incident_webhooks
+(monitor, enabled, url, payload_template, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s)
+RETURNING %s;
+`
+
+func (s *codeMonitorStore) CreateIncidentWebhookAction(ctx context.Context, monitorID int64, enabled bool, url, payloadTemplate string) (*IncidentWebhookAction, error) {
+	now := s.Now()
+	a := actor.FromContext(ctx)
+	q := sqlf.Sprintf(
+		createIncidentWebhookActionQuery,
+		monitorID,
+		enabled,
+		url,
+		payloadTemplate,
+		a.UID,
+		now,
+		a.UID,
+		now,
+		sqlf.Join(incidentWebhookActionColumns, ","),
+	)
+
+	row := s.QueryRow(ctx, q)
+	return scanIncidentWebhookAction(row)
+}
+
+const deleteIncidentWebhookActionQuery = `
+DELETE FROM cm_incident_webhooks
+WHERE id in (%s)
+	AND MONITOR = %s
+`
+
+func (s *codeMonitorStore) DeleteIncidentWebhookActions(ctx context.Context, monitorID int64, webhookIDs ...int64) error {
+	if len(webhookIDs) == 0 {
+		return nil
+	}
+
+	deleteIDs := make([]*sqlf.Query, 0, len(webhookIDs))
+	for _, ids := range webhookIDs {
+		deleteIDs = append(deleteIDs, sqlf.Sprintf("%d", ids))
+	}
+	q := sqlf.Sprintf(
+		deleteIncidentWebhookActionQuery,
+		sqlf.Join(deleteIDs, ","),
+		monitorID,
+	)
+
+	return s.Exec(ctx, q)
+}
+
+const countIncidentWebhookActionsQuery = `
+SELECT COUNT(*)
+FROM cm_incident_webhooks
+WHERE monitor = %s;
+`
+
+func (s *codeMonitorStore) CountIncidentWebhookActions(ctx context.Context, monitorID int64) (int, error) {
+	var count int
+	err := s.QueryRow(ctx, sqlf.Sprintf(countIncidentWebhookActionsQuery, monitorID)).Scan(&count)
+	return count, err
+}
+
+const getIncidentWebhookActionQuery = `
+SELECT %s -- IncidentWebhookActionColumns
+FROM cm_incident_webhooks
+WHERE id = %s
+`
+
+func (s *codeMonitorStore) GetIncidentWebhookAction(ctx context.Context, id int64) (*IncidentWebhookAction, error) {
+	q := sqlf.Sprintf(
+		getIncidentWebhookActionQuery,
+		sqlf.Join(incidentWebhookActionColumns, ","),
+		id,
+	)
+	row := s.QueryRow(ctx, q)
+	return scanIncidentWebhookAction(row)
+}
+
+const listIncidentWebhookActionsQuery = `
+SELECT %s -- IncidentWebhookActionColumns
+FROM cm_incident_webhooks
+WHERE %s
+ORDER BY id ASC
+LIMIT %s;
+`
+
+func (s *codeMonitorStore) ListIncidentWebhookActions(ctx context.Context, opts ListActionsOpts) ([]*IncidentWebhookAction, error) {
+	q := sqlf.Sprintf(
+		listIncidentWebhookActionsQuery,
+		sqlf.Join(incidentWebhookActionColumns, ","),
+		opts.Conds(),
+		opts.Limit(),
+	)
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanIncidentWebhookActions(rows)
+}
+
+// incidentWebhookActionColumns is the set of columns in the cm_incident_webhooks table
+// This must be kept in sync with scanIncidentWebhook
+var incidentWebhookActionColumns = []*sqlf.Query{
+	sqlf.Sprintf("cm_incident_webhooks.id"),
+	sqlf.Sprintf("cm_incident_webhooks.monitor"),
+	sqlf.Sprintf("cm_incident_webhooks.enabled"),
+	sqlf.Sprintf("cm_incident_webhooks.url"),
+	sqlf.Sprintf("cm_incident_webhooks.payload_template"),
+	sqlf.Sprintf("cm_incident_webhooks.created_by"),
+	sqlf.Sprintf("cm_incident_webhooks.created_at"),
+	sqlf.Sprintf("cm_incident_webhooks.changed_by"),
+	sqlf.Sprintf("cm_incident_webhooks.changed_at"),
+}
+
+func scanIncidentWebhookActions(rows *sql.Rows) ([]*IncidentWebhookAction, error) {
+	var ws []*IncidentWebhookAction
+	for rows.Next() {
+		w, err := scanIncidentWebhookAction(rows)
+		if err != nil {
+			return nil, err
+		}
+		ws = append(ws, w)
+	}
+	return ws, rows.Err()
+}
+
+// scanIncidentWebhookAction scans a IncidentWebhookAction from a *sql.Row or *sql.Rows.
+// It must be kept in sync with incidentWebhookActionColumns.
+func scanIncidentWebhookAction(scanner dbutil.Scanner) (*IncidentWebhookAction, error) {
+	var w IncidentWebhookAction
+	err := scanner.Scan(
+		&w.ID,
+		&w.Monitor,
+		&w.Enabled,
+		&w.URL,
+		&w.PayloadTemplate,
+		&w.CreatedBy,
+		&w.CreatedAt,
+		&w.ChangedBy,
+		&w.ChangedAt,
+	)
+	return &w, err
+}
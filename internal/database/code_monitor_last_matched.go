@@ -0,0 +1,61 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func (s *codeMonitorStore) UpsertLastMatched(ctx context.Context, monitorID int64, repoID api.RepoID, matchKeys []string) error {
+	rawQuery := `
+	INSERT INTO cm_last_matched (monitor_id, repo_id, match_keys)
+	VALUES (%s, %s, %s)
+	ON CONFLICT (monitor_id, repo_id) DO UPDATE
+	SET match_keys = %s
+	`
+
+	// Appease non-null constraint on column
+	if matchKeys == nil {
+		matchKeys = []string{}
+	}
+	q := sqlf.Sprintf(rawQuery, monitorID, int64(repoID), pq.StringArray(matchKeys), pq.StringArray(matchKeys))
+	return s.Exec(ctx, q)
+}
+
+func (s *codeMonitorStore) GetLastMatched(ctx context.Context, monitorID int64, repoID api.RepoID) ([]string, error) {
+	rawQuery := `
+	SELECT match_keys
+	FROM cm_last_matched
+	WHERE monitor_id = %s
+		AND repo_id = %s
+	LIMIT 1
+	`
+
+	q := sqlf.Sprintf(rawQuery, monitorID, int64(repoID))
+	var matchKeys []string
+	err := s.QueryRow(ctx, q).Scan((*pq.StringArray)(&matchKeys))
+	if err != nil && errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return matchKeys, err
+}
+
+func (s *codeMonitorStore) DeleteLastMatchedExcept(ctx context.Context, monitorID int64, repoIDs []api.RepoID) error {
+	rawQuery := `
+	DELETE FROM cm_last_matched
+	WHERE monitor_id = %s
+		AND NOT repo_id = ANY(%s)
+	`
+
+	ids := make([]int64, 0, len(repoIDs))
+	for _, id := range repoIDs {
+		ids = append(ids, int64(id))
+	}
+	q := sqlf.Sprintf(rawQuery, monitorID, pq.Int64Array(ids))
+	return s.Exec(ctx, q)
+}
@@ -0,0 +1,83 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestCodeMonitorStoreLastMatched(t *testing.T) {
+	t.Parallel()
+
+	logger := logtest.Scoped(t)
+	t.Run("insert get upsert get", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := NewDB(logger, dbtest.NewDB(t))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		cm := db.CodeMonitors()
+
+		// Insert
+		insertLastMatched := []string{"key1", "key2"}
+		err := cm.UpsertLastMatched(ctx, fixtures.Monitor.ID, fixtures.Repo.ID, insertLastMatched)
+		require.NoError(t, err)
+
+		// Get
+		lastMatched, err := cm.GetLastMatched(ctx, fixtures.Monitor.ID, fixtures.Repo.ID)
+		require.NoError(t, err)
+		require.Equal(t, insertLastMatched, lastMatched)
+
+		// Update
+		updateLastMatched := []string{"key3"}
+		err = cm.UpsertLastMatched(ctx, fixtures.Monitor.ID, fixtures.Repo.ID, updateLastMatched)
+		require.NoError(t, err)
+
+		// Get
+		lastMatched, err = cm.GetLastMatched(ctx, fixtures.Monitor.ID, fixtures.Repo.ID)
+		require.NoError(t, err)
+		require.Equal(t, updateLastMatched, lastMatched)
+	})
+
+	t.Run("no error for missing get", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := NewDB(logger, dbtest.NewDB(t))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		cm := db.CodeMonitors()
+
+		lastMatched, err := cm.GetLastMatched(ctx, fixtures.Monitor.ID+1, 19793)
+		require.NoError(t, err)
+		require.Empty(t, lastMatched)
+	})
+
+	t.Run("delete except", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := NewDB(logger, dbtest.NewDB(t))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		cm := db.CodeMonitors()
+
+		err := cm.UpsertLastMatched(ctx, fixtures.Monitor.ID, fixtures.Repo.ID, []string{"key1"})
+		require.NoError(t, err)
+
+		// The repository still has matches, so its matches are kept.
+		err = cm.DeleteLastMatchedExcept(ctx, fixtures.Monitor.ID, []api.RepoID{fixtures.Repo.ID})
+		require.NoError(t, err)
+		lastMatched, err := cm.GetLastMatched(ctx, fixtures.Monitor.ID, fixtures.Repo.ID)
+		require.NoError(t, err)
+		require.Equal(t, []string{"key1"}, lastMatched)
+
+		// No repository has matches anymore.
+		err = cm.DeleteLastMatchedExcept(ctx, fixtures.Monitor.ID, nil)
+		require.NoError(t, err)
+		lastMatched, err = cm.GetLastMatched(ctx, fixtures.Monitor.ID, fixtures.Repo.ID)
+		require.NoError(t, err)
+		require.Empty(t, lastMatched)
+	})
+}
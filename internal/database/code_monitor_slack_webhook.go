@@ -17,6 +17,7 @@ type SlackWebhookAction struct {
 	Enabled        bool
 	URL            string
 	IncludeResults bool
+	Digest         *ActionDigest
 
 	CreatedBy int32
 	CreatedAt time.Time
@@ -29,6 +30,9 @@ UPDATE cm_slack_webhooks
 SET enabled = %s,
 	include_results = %s,
 	url = %s,
+	digest_interval = %s,
+	digest_max_results = %s,
+	last_digest_at = CASE WHEN %s THEN COALESCE(cm_slack_webhooks.last_digest_at, %s) END,
 	changed_by = %s,
 	changed_at = %s
 WHERE
@@ -41,7 +45,7 @@ WHERE
 RETURNING %s;
 `
 
-func (s *codeMonitorStore) UpdateSlackWebhookAction(ctx context.Context, id int64, enabled, includeResults bool, url string) (*SlackWebhookAction, error) {
+func (s *codeMonitorStore) UpdateSlackWebhookAction(ctx context.Context, id int64, enabled, includeResults bool, url string, digest *ActionDigest) (*SlackWebhookAction, error) {
 	a := actor.FromContext(ctx)
 
 	user, err := a.User(ctx, s.userStore)
@@ -49,13 +53,19 @@ func (s *codeMonitorStore) UpdateSlackWebhookAction(ctx context.Context, id int6
 		return nil, err
 	}
 
+	now := s.Now()
+	digestInterval, digestMaxResults := digestColumnValues(digest)
 	q := sqlf.Sprintf(
 		updateSlackWebhookActionQuery,
 		enabled,
 		includeResults,
 		url,
+		digestInterval,
+		digestMaxResults,
+		digest != nil,
+		now,
 		a.UID,
-		s.Now(),
+		now,
 		id,
 		namespaceScopeQuery(user),
 		sqlf.Join(slackWebhookActionColumns, ","),
@@ -67,20 +77,24 @@ func (s *codeMonitorStore) UpdateSlackWebhookAction(ctx context.Context, id int6
 
 const createSlackWebhookActionQuery = `
 INSERT INTO cm_slack_webhooks
-(monitor, enabled, include_results, url, created_by, created_at, changed_by, changed_at)
-VALUES (%s,%s,%s,%s,%s,%s,%s,%s)
+(monitor, enabled, include_results, url, digest_interval, digest_max_results, last_digest_at, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s)
 RETURNING %s;
 `
 
-func (s *codeMonitorStore) CreateSlackWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string) (*SlackWebhookAction, error) {
+func (s *codeMonitorStore) CreateSlackWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string, digest *ActionDigest) (*SlackWebhookAction, error) {
 	now := s.Now()
 	a := actor.FromContext(ctx)
+	digestInterval, digestMaxResults := digestColumnValues(digest)
 	q := sqlf.Sprintf(
 		createSlackWebhookActionQuery,
 		monitorID,
 		enabled,
 		includeResults,
 		url,
+		digestInterval,
+		digestMaxResults,
+		digestStartValue(digest, now),
 		a.UID,
 		now,
 		a.UID,
@@ -175,6 +189,8 @@ var slackWebhookActionColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_slack_webhooks.enabled"),
 	sqlf.Sprintf("cm_slack_webhooks.url"),
 	sqlf.Sprintf("cm_slack_webhooks.include_results"),
+	sqlf.Sprintf("cm_slack_webhooks.digest_interval"),
+	sqlf.Sprintf("cm_slack_webhooks.digest_max_results"),
 	sqlf.Sprintf("cm_slack_webhooks.created_by"),
 	sqlf.Sprintf("cm_slack_webhooks.created_at"),
 	sqlf.Sprintf("cm_slack_webhooks.changed_by"),
@@ -196,17 +212,24 @@ func scanSlackWebhookActions(rows *sql.Rows) ([]*SlackWebhookAction, error) {
 // scanSlackWebhookAction scans a SlackWebhookAction from a *sql.Row or *sql.Rows.
 // It must be kept in sync with slackWebhookActionColumns.
 func scanSlackWebhookAction(scanner dbutil.Scanner) (*SlackWebhookAction, error) {
-	var w SlackWebhookAction
+	var (
+		w                SlackWebhookAction
+		digestInterval   *string
+		digestMaxResults *int32
+	)
 	err := scanner.Scan(
 		&w.ID,
 		&w.Monitor,
 		&w.Enabled,
 		&w.URL,
 		&w.IncludeResults,
+		&digestInterval,
+		&digestMaxResults,
 		&w.CreatedBy,
 		&w.CreatedAt,
 		&w.ChangedBy,
 		&w.ChangedAt,
 	)
+	w.Digest = digestFromColumns(digestInterval, digestMaxResults)
 	return &w, err
 }
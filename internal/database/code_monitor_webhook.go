@@ -17,6 +17,7 @@ type WebhookAction struct {
 	Enabled        bool
 	URL            string
 	IncludeResults bool
+	Digest         *ActionDigest
 
 	CreatedBy int32
 	CreatedAt time.Time
@@ -29,6 +30,9 @@ UPDATE cm_webhooks
 SET enabled = %s,
     include_results = %s,
 	url = %s,
+	digest_interval = %s,
+	digest_max_results = %s,
+	last_digest_at = CASE WHEN %s THEN COALESCE(cm_webhooks.last_digest_at, %s) END,
 	changed_by = %s,
 	changed_at = %s
 WHERE
@@ -41,7 +45,7 @@ WHERE
 RETURNING %s;
 `
 
-func (s *codeMonitorStore) UpdateWebhookAction(ctx context.Context, id int64, enabled, includeResults bool, url string) (*WebhookAction, error) {
+func (s *codeMonitorStore) UpdateWebhookAction(ctx context.Context, id int64, enabled, includeResults bool, url string, digest *ActionDigest) (*WebhookAction, error) {
 	a := actor.FromContext(ctx)
 
 	user, err := a.User(ctx, s.userStore)
@@ -49,13 +53,19 @@ func (s *codeMonitorStore) UpdateWebhookAction(ctx context.Context, id int64, en
 		return nil, err
 	}
 
+	now := s.Now()
+	digestInterval, digestMaxResults := digestColumnValues(digest)
 	q := sqlf.Sprintf(
 		updateWebhookActionQuery,
 		enabled,
 		includeResults,
 		url,
+		digestInterval,
+		digestMaxResults,
+		digest != nil,
+		now,
 		a.UID,
-		s.Now(),
+		now,
 		id,
 		namespaceScopeQuery(user),
 		sqlf.Join(webhookActionColumns, ","),
@@ -67,20 +77,24 @@ func (s *codeMonitorStore) UpdateWebhookAction(ctx context.Context, id int64, en
 
 const createWebhookActionQuery = `
 INSERT INTO cm_webhooks
-(monitor, enabled, include_results, url, created_by, created_at, changed_by, changed_at)
-VALUES (%s,%s,%s,%s,%s,%s,%s,%s)
+(monitor, enabled, include_results, url, digest_interval, digest_max_results, last_digest_at, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s)
 RETURNING %s;
 `
 
-func (s *codeMonitorStore) CreateWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string) (*WebhookAction, error) {
+func (s *codeMonitorStore) CreateWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string, digest *ActionDigest) (*WebhookAction, error) {
 	now := s.Now()
 	a := actor.FromContext(ctx)
+	digestInterval, digestMaxResults := digestColumnValues(digest)
 	q := sqlf.Sprintf(
 		createWebhookActionQuery,
 		monitorID,
 		enabled,
 		includeResults,
 		url,
+		digestInterval,
+		digestMaxResults,
+		digestStartValue(digest, now),
 		a.UID,
 		now,
 		a.UID,
@@ -175,6 +189,8 @@ var webhookActionColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_webhooks.enabled"),
 	sqlf.Sprintf("cm_webhooks.url"),
 	sqlf.Sprintf("cm_webhooks.include_results"),
+	sqlf.Sprintf("cm_webhooks.digest_interval"),
+	sqlf.Sprintf("cm_webhooks.digest_max_results"),
 	sqlf.Sprintf("cm_webhooks.created_by"),
 	sqlf.Sprintf("cm_webhooks.created_at"),
 	sqlf.Sprintf("cm_webhooks.changed_by"),
@@ -196,17 +212,24 @@ func scanWebhookActions(rows *sql.Rows) ([]*WebhookAction, error) {
 // scanWebhookAction scans a WebhookAction from a *sql.Row or *sql.Rows.
 // It must be kept in sync with webhookActionColumns.
 func scanWebhookAction(scanner dbutil.Scanner) (*WebhookAction, error) {
-	var w WebhookAction
+	var (
+		w                WebhookAction
+		digestInterval   *string
+		digestMaxResults *int32
+	)
 	err := scanner.Scan(
 		&w.ID,
 		&w.Monitor,
 		&w.Enabled,
 		&w.URL,
 		&w.IncludeResults,
+		&digestInterval,
+		&digestMaxResults,
 		&w.CreatedBy,
 		&w.CreatedAt,
 		&w.ChangedBy,
 		&w.ChangedAt,
 	)
+	w.Digest = digestFromColumns(digestInterval, digestMaxResults)
 	return &w, err
 }
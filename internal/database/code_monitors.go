@@ -59,20 +59,34 @@ type CodeMonitorStore interface {
 	GetEmailAction(ctx context.Context, emailID int64) (*EmailAction, error)
 	ListEmailActions(context.Context, ListActionsOpts) ([]*EmailAction, error)
 
-	UpdateWebhookAction(_ context.Context, id int64, enabled, includeResults bool, url string) (*WebhookAction, error)
-	CreateWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string) (*WebhookAction, error)
+	UpdateWebhookAction(_ context.Context, id int64, enabled, includeResults bool, url string, digest *ActionDigest) (*WebhookAction, error)
+	CreateWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string, digest *ActionDigest) (*WebhookAction, error)
 	DeleteWebhookActions(ctx context.Context, monitorID int64, ids ...int64) error
 	CountWebhookActions(ctx context.Context, monitorID int64) (int, error)
 	GetWebhookAction(ctx context.Context, id int64) (*WebhookAction, error)
 	ListWebhookActions(context.Context, ListActionsOpts) ([]*WebhookAction, error)
 
-	UpdateSlackWebhookAction(_ context.Context, id int64, enabled, includeResults bool, url string) (*SlackWebhookAction, error)
-	CreateSlackWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string) (*SlackWebhookAction, error)
+	UpdateSlackWebhookAction(_ context.Context, id int64, enabled, includeResults bool, url string, digest *ActionDigest) (*SlackWebhookAction, error)
+	CreateSlackWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string, digest *ActionDigest) (*SlackWebhookAction, error)
 	DeleteSlackWebhookActions(ctx context.Context, monitorID int64, ids ...int64) error
 	CountSlackWebhookActions(ctx context.Context, monitorID int64) (int, error)
 	GetSlackWebhookAction(ctx context.Context, id int64) (*SlackWebhookAction, error)
 	ListSlackWebhookActions(context.Context, ListActionsOpts) ([]*SlackWebhookAction, error)
 
+	UpdateTeamsWebhookAction(_ context.Context, id int64, enabled, includeResults bool, url string) (*TeamsWebhookAction, error)
+	CreateTeamsWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string) (*TeamsWebhookAction, error)
+	DeleteTeamsWebhookActions(ctx context.Context, monitorID int64, ids ...int64) error
+	CountTeamsWebhookActions(ctx context.Context, monitorID int64) (int, error)
+	GetTeamsWebhookAction(ctx context.Context, id int64) (*TeamsWebhookAction, error)
+	ListTeamsWebhookActions(context.Context, ListActionsOpts) ([]*TeamsWebhookAction, error)
+
+	UpdateIncidentWebhookAction(_ context.Context, id int64, enabled bool, url, payloadTemplate string) (*IncidentWebhookAction, error)
+	CreateIncidentWebhookAction(ctx context.Context, monitorID int64, enabled bool, url, payloadTemplate string) (*IncidentWebhookAction, error)
+	DeleteIncidentWebhookActions(ctx context.Context, monitorID int64, ids ...int64) error
+	CountIncidentWebhookActions(ctx context.Context, monitorID int64) (int, error)
+	GetIncidentWebhookAction(ctx context.Context, id int64) (*IncidentWebhookAction, error)
+	ListIncidentWebhookActions(context.Context, ListActionsOpts) ([]*IncidentWebhookAction, error)
+
 	CreateRecipient(ctx context.Context, emailID int64, userID, orgID *int32) (*Recipient, error)
 	DeleteRecipients(ctx context.Context, emailID int64) error
 	ListRecipients(context.Context, ListRecipientsOpts) ([]*Recipient, error)
@@ -84,6 +98,11 @@ type CodeMonitorStore interface {
 	GetActionJob(ctx context.Context, jobID int32) (*ActionJob, error)
 	EnqueueActionJobsForMonitor(ctx context.Context, monitorID int64, triggerJob int32) ([]*ActionJob, error)
 
+	// EnqueueDigestActionJobs enqueues the digest jobs of all actions whose digest is due, and
+	// ListDigestResults returns the results of all trigger runs that a digest job covers.
+	EnqueueDigestActionJobs(ctx context.Context) ([]*ActionJob, error)
+	ListDigestResults(ctx context.Context, jobID int32) ([]*result.CommitMatch, error)
+
 	// HasAnyLastSearched returns whether there have ever been any repo-aware code monitor
 	// searches executed for this code monitor. This should only be needed during the transition
 	// version so that we don't detect every repo as a new repo and search their entire history
@@ -91,6 +110,14 @@ type CodeMonitorStore interface {
 	HasAnyLastSearched(ctx context.Context, monitorID int64) (bool, error)
 	UpsertLastSearched(ctx context.Context, monitorID int64, repoID api.RepoID, lastSearched []string) error
 	GetLastSearched(ctx context.Context, monitorID int64, repoID api.RepoID) ([]string, error)
+
+	// UpsertLastMatched and GetLastMatched store the keys of the file content matches found
+	// in a repository by the last run of a code monitor that searches file contents.
+	UpsertLastMatched(ctx context.Context, monitorID int64, repoID api.RepoID, matchKeys []string) error
+	GetLastMatched(ctx context.Context, monitorID int64, repoID api.RepoID) ([]string, error)
+	// DeleteLastMatchedExcept deletes the stored matches of all repositories of the code
+	// monitor except the given ones, which had matches on the last run.
+	DeleteLastMatchedExcept(ctx context.Context, monitorID int64, repoIDs []api.RepoID) error
 }
 
 // codeMonitorStore exposes methods to read and write codemonitors domain models
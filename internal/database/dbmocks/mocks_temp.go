@@ -6349,6 +6349,10 @@ type MockCodeMonitorStore struct {
 	// CountActionJobsFunc is an instance of a mock function object
 	// controlling the behavior of the method CountActionJobs.
 	CountActionJobsFunc *CodeMonitorStoreCountActionJobsFunc
+	// CountIncidentWebhookActionsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// CountIncidentWebhookActions.
+	CountIncidentWebhookActionsFunc *CodeMonitorStoreCountIncidentWebhookActionsFunc
 	// CountMonitorsFunc is an instance of a mock function object
 	// controlling the behavior of the method CountMonitors.
 	CountMonitorsFunc *CodeMonitorStoreCountMonitorsFunc
@@ -6361,12 +6365,19 @@ type MockCodeMonitorStore struct {
 	// CountSlackWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method CountSlackWebhookActions.
 	CountSlackWebhookActionsFunc *CodeMonitorStoreCountSlackWebhookActionsFunc
+	// CountTeamsWebhookActionsFunc is an instance of a mock function object
+	// controlling the behavior of the method CountTeamsWebhookActions.
+	CountTeamsWebhookActionsFunc *CodeMonitorStoreCountTeamsWebhookActionsFunc
 	// CountWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method CountWebhookActions.
 	CountWebhookActionsFunc *CodeMonitorStoreCountWebhookActionsFunc
 	// CreateEmailActionFunc is an instance of a mock function object
 	// controlling the behavior of the method CreateEmailAction.
 	CreateEmailActionFunc *CodeMonitorStoreCreateEmailActionFunc
+	// CreateIncidentWebhookActionFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// CreateIncidentWebhookAction.
+	CreateIncidentWebhookActionFunc *CodeMonitorStoreCreateIncidentWebhookActionFunc
 	// CreateMonitorFunc is an instance of a mock function object
 	// controlling the behavior of the method CreateMonitor.
 	CreateMonitorFunc *CodeMonitorStoreCreateMonitorFunc
@@ -6379,12 +6390,22 @@ type MockCodeMonitorStore struct {
 	// CreateSlackWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method CreateSlackWebhookAction.
 	CreateSlackWebhookActionFunc *CodeMonitorStoreCreateSlackWebhookActionFunc
+	// CreateTeamsWebhookActionFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateTeamsWebhookAction.
+	CreateTeamsWebhookActionFunc *CodeMonitorStoreCreateTeamsWebhookActionFunc
 	// CreateWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method CreateWebhookAction.
 	CreateWebhookActionFunc *CodeMonitorStoreCreateWebhookActionFunc
 	// DeleteEmailActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteEmailActions.
 	DeleteEmailActionsFunc *CodeMonitorStoreDeleteEmailActionsFunc
+	// DeleteIncidentWebhookActionsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// DeleteIncidentWebhookActions.
+	DeleteIncidentWebhookActionsFunc *CodeMonitorStoreDeleteIncidentWebhookActionsFunc
+	// DeleteLastMatchedExceptFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteLastMatchedExcept.
+	DeleteLastMatchedExceptFunc *CodeMonitorStoreDeleteLastMatchedExceptFunc
 	// DeleteMonitorFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteMonitor.
 	DeleteMonitorFunc *CodeMonitorStoreDeleteMonitorFunc
@@ -6398,6 +6419,10 @@ type MockCodeMonitorStore struct {
 	// object controlling the behavior of the method
 	// DeleteSlackWebhookActions.
 	DeleteSlackWebhookActionsFunc *CodeMonitorStoreDeleteSlackWebhookActionsFunc
+	// DeleteTeamsWebhookActionsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// DeleteTeamsWebhookActions.
+	DeleteTeamsWebhookActionsFunc *CodeMonitorStoreDeleteTeamsWebhookActionsFunc
 	// DeleteWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteWebhookActions.
 	DeleteWebhookActionsFunc *CodeMonitorStoreDeleteWebhookActionsFunc
@@ -6408,6 +6433,9 @@ type MockCodeMonitorStore struct {
 	// object controlling the behavior of the method
 	// EnqueueActionJobsForMonitor.
 	EnqueueActionJobsForMonitorFunc *CodeMonitorStoreEnqueueActionJobsForMonitorFunc
+	// EnqueueDigestActionJobsFunc is an instance of a mock function object
+	// controlling the behavior of the method EnqueueDigestActionJobs.
+	EnqueueDigestActionJobsFunc *CodeMonitorStoreEnqueueDigestActionJobsFunc
 	// EnqueueQueryTriggerJobsFunc is an instance of a mock function object
 	// controlling the behavior of the method EnqueueQueryTriggerJobs.
 	EnqueueQueryTriggerJobsFunc *CodeMonitorStoreEnqueueQueryTriggerJobsFunc
@@ -6423,6 +6451,12 @@ type MockCodeMonitorStore struct {
 	// GetEmailActionFunc is an instance of a mock function object
 	// controlling the behavior of the method GetEmailAction.
 	GetEmailActionFunc *CodeMonitorStoreGetEmailActionFunc
+	// GetIncidentWebhookActionFunc is an instance of a mock function object
+	// controlling the behavior of the method GetIncidentWebhookAction.
+	GetIncidentWebhookActionFunc *CodeMonitorStoreGetIncidentWebhookActionFunc
+	// GetLastMatchedFunc is an instance of a mock function object
+	// controlling the behavior of the method GetLastMatched.
+	GetLastMatchedFunc *CodeMonitorStoreGetLastMatchedFunc
 	// GetLastSearchedFunc is an instance of a mock function object
 	// controlling the behavior of the method GetLastSearched.
 	GetLastSearchedFunc *CodeMonitorStoreGetLastSearchedFunc
@@ -6439,6 +6473,9 @@ type MockCodeMonitorStore struct {
 	// GetSlackWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method GetSlackWebhookAction.
 	GetSlackWebhookActionFunc *CodeMonitorStoreGetSlackWebhookActionFunc
+	// GetTeamsWebhookActionFunc is an instance of a mock function object
+	// controlling the behavior of the method GetTeamsWebhookAction.
+	GetTeamsWebhookActionFunc *CodeMonitorStoreGetTeamsWebhookActionFunc
 	// GetWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method GetWebhookAction.
 	GetWebhookActionFunc *CodeMonitorStoreGetWebhookActionFunc
@@ -6451,9 +6488,16 @@ type MockCodeMonitorStore struct {
 	// ListActionJobsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListActionJobs.
 	ListActionJobsFunc *CodeMonitorStoreListActionJobsFunc
+	// ListDigestResultsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListDigestResults.
+	ListDigestResultsFunc *CodeMonitorStoreListDigestResultsFunc
 	// ListEmailActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListEmailActions.
 	ListEmailActionsFunc *CodeMonitorStoreListEmailActionsFunc
+	// ListIncidentWebhookActionsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// ListIncidentWebhookActions.
+	ListIncidentWebhookActionsFunc *CodeMonitorStoreListIncidentWebhookActionsFunc
 	// ListMonitorsFunc is an instance of a mock function object controlling
 	// the behavior of the method ListMonitors.
 	ListMonitorsFunc *CodeMonitorStoreListMonitorsFunc
@@ -6466,6 +6510,9 @@ type MockCodeMonitorStore struct {
 	// ListSlackWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListSlackWebhookActions.
 	ListSlackWebhookActionsFunc *CodeMonitorStoreListSlackWebhookActionsFunc
+	// ListTeamsWebhookActionsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListTeamsWebhookActions.
+	ListTeamsWebhookActionsFunc *CodeMonitorStoreListTeamsWebhookActionsFunc
 	// ListWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListWebhookActions.
 	ListWebhookActionsFunc *CodeMonitorStoreListWebhookActionsFunc
@@ -6485,6 +6532,10 @@ type MockCodeMonitorStore struct {
 	// UpdateEmailActionFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateEmailAction.
 	UpdateEmailActionFunc *CodeMonitorStoreUpdateEmailActionFunc
+	// UpdateIncidentWebhookActionFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// UpdateIncidentWebhookAction.
+	UpdateIncidentWebhookActionFunc *CodeMonitorStoreUpdateIncidentWebhookActionFunc
 	// UpdateMonitorFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateMonitor.
 	UpdateMonitorFunc *CodeMonitorStoreUpdateMonitorFunc
@@ -6497,6 +6548,9 @@ type MockCodeMonitorStore struct {
 	// UpdateSlackWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateSlackWebhookAction.
 	UpdateSlackWebhookActionFunc *CodeMonitorStoreUpdateSlackWebhookActionFunc
+	// UpdateTeamsWebhookActionFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateTeamsWebhookAction.
+	UpdateTeamsWebhookActionFunc *CodeMonitorStoreUpdateTeamsWebhookActionFunc
 	// UpdateTriggerJobWithResultsFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// UpdateTriggerJobWithResults.
@@ -6504,6 +6558,9 @@ type MockCodeMonitorStore struct {
 	// UpdateWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateWebhookAction.
 	UpdateWebhookActionFunc *CodeMonitorStoreUpdateWebhookActionFunc
+	// UpsertLastMatchedFunc is an instance of a mock function object
+	// controlling the behavior of the method UpsertLastMatched.
+	UpsertLastMatchedFunc *CodeMonitorStoreUpsertLastMatchedFunc
 	// UpsertLastSearchedFunc is an instance of a mock function object
 	// controlling the behavior of the method UpsertLastSearched.
 	UpsertLastSearchedFunc *CodeMonitorStoreUpsertLastSearchedFunc
@@ -6524,6 +6581,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		CountIncidentWebhookActionsFunc: &CodeMonitorStoreCountIncidentWebhookActionsFunc{
+			defaultHook: func(context.Context, int64) (r0 int, r1 error) {
+				return
+			},
+		},
 		CountMonitorsFunc: &CodeMonitorStoreCountMonitorsFunc{
 			defaultHook: func(context.Context, *int32) (r0 int32, r1 error) {
 				return
@@ -6544,6 +6606,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		CountTeamsWebhookActionsFunc: &CodeMonitorStoreCountTeamsWebhookActionsFunc{
+			defaultHook: func(context.Context, int64) (r0 int, r1 error) {
+				return
+			},
+		},
 		CountWebhookActionsFunc: &CodeMonitorStoreCountWebhookActionsFunc{
 			defaultHook: func(context.Context, int64) (r0 int, r1 error) {
 				return
@@ -6554,6 +6621,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		CreateIncidentWebhookActionFunc: &CodeMonitorStoreCreateIncidentWebhookActionFunc{
+			defaultHook: func(context.Context, int64, bool, string, string) (r0 *database.IncidentWebhookAction, r1 error) {
+				return
+			},
+		},
 		CreateMonitorFunc: &CodeMonitorStoreCreateMonitorFunc{
 			defaultHook: func(context.Context, database.MonitorArgs) (r0 *database.Monitor, r1 error) {
 				return
@@ -6570,12 +6642,17 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 			},
 		},
 		CreateSlackWebhookActionFunc: &CodeMonitorStoreCreateSlackWebhookActionFunc{
-			defaultHook: func(context.Context, int64, bool, bool, string) (r0 *database.SlackWebhookAction, r1 error) {
+			defaultHook: func(context.Context, int64, bool, bool, string, *database.ActionDigest) (r0 *database.SlackWebhookAction, r1 error) {
+				return
+			},
+		},
+		CreateTeamsWebhookActionFunc: &CodeMonitorStoreCreateTeamsWebhookActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, string) (r0 *database.TeamsWebhookAction, r1 error) {
 				return
 			},
 		},
 		CreateWebhookActionFunc: &CodeMonitorStoreCreateWebhookActionFunc{
-			defaultHook: func(context.Context, int64, bool, bool, string) (r0 *database.WebhookAction, r1 error) {
+			defaultHook: func(context.Context, int64, bool, bool, string, *database.ActionDigest) (r0 *database.WebhookAction, r1 error) {
 				return
 			},
 		},
@@ -6584,6 +6661,16 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		DeleteIncidentWebhookActionsFunc: &CodeMonitorStoreDeleteIncidentWebhookActionsFunc{
+			defaultHook: func(context.Context, int64, ...int64) (r0 error) {
+				return
+			},
+		},
+		DeleteLastMatchedExceptFunc: &CodeMonitorStoreDeleteLastMatchedExceptFunc{
+			defaultHook: func(context.Context, int64, []api.RepoID) (r0 error) {
+				return
+			},
+		},
 		DeleteMonitorFunc: &CodeMonitorStoreDeleteMonitorFunc{
 			defaultHook: func(context.Context, int64) (r0 error) {
 				return
@@ -6604,6 +6691,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		DeleteTeamsWebhookActionsFunc: &CodeMonitorStoreDeleteTeamsWebhookActionsFunc{
+			defaultHook: func(context.Context, int64, ...int64) (r0 error) {
+				return
+			},
+		},
 		DeleteWebhookActionsFunc: &CodeMonitorStoreDeleteWebhookActionsFunc{
 			defaultHook: func(context.Context, int64, ...int64) (r0 error) {
 				return
@@ -6619,6 +6711,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		EnqueueDigestActionJobsFunc: &CodeMonitorStoreEnqueueDigestActionJobsFunc{
+			defaultHook: func(context.Context) (r0 []*database.ActionJob, r1 error) {
+				return
+			},
+		},
 		EnqueueQueryTriggerJobsFunc: &CodeMonitorStoreEnqueueQueryTriggerJobsFunc{
 			defaultHook: func(context.Context) (r0 []*database.TriggerJob, r1 error) {
 				return
@@ -6644,6 +6741,16 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		GetIncidentWebhookActionFunc: &CodeMonitorStoreGetIncidentWebhookActionFunc{
+			defaultHook: func(context.Context, int64) (r0 *database.IncidentWebhookAction, r1 error) {
+				return
+			},
+		},
+		GetLastMatchedFunc: &CodeMonitorStoreGetLastMatchedFunc{
+			defaultHook: func(context.Context, int64, api.RepoID) (r0 []string, r1 error) {
+				return
+			},
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID) (r0 []string, r1 error) {
 				return
@@ -6669,6 +6776,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		GetTeamsWebhookActionFunc: &CodeMonitorStoreGetTeamsWebhookActionFunc{
+			defaultHook: func(context.Context, int64) (r0 *database.TeamsWebhookAction, r1 error) {
+				return
+			},
+		},
 		GetWebhookActionFunc: &CodeMonitorStoreGetWebhookActionFunc{
 			defaultHook: func(context.Context, int64) (r0 *database.WebhookAction, r1 error) {
 				return
@@ -6689,11 +6801,21 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		ListDigestResultsFunc: &CodeMonitorStoreListDigestResultsFunc{
+			defaultHook: func(context.Context, int32) (r0 []*result.CommitMatch, r1 error) {
+				return
+			},
+		},
 		ListEmailActionsFunc: &CodeMonitorStoreListEmailActionsFunc{
 			defaultHook: func(context.Context, database.ListActionsOpts) (r0 []*database.EmailAction, r1 error) {
 				return
 			},
 		},
+		ListIncidentWebhookActionsFunc: &CodeMonitorStoreListIncidentWebhookActionsFunc{
+			defaultHook: func(context.Context, database.ListActionsOpts) (r0 []*database.IncidentWebhookAction, r1 error) {
+				return
+			},
+		},
 		ListMonitorsFunc: &CodeMonitorStoreListMonitorsFunc{
 			defaultHook: func(context.Context, database.ListMonitorsOpts) (r0 []*database.Monitor, r1 error) {
 				return
@@ -6714,6 +6836,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		ListTeamsWebhookActionsFunc: &CodeMonitorStoreListTeamsWebhookActionsFunc{
+			defaultHook: func(context.Context, database.ListActionsOpts) (r0 []*database.TeamsWebhookAction, r1 error) {
+				return
+			},
+		},
 		ListWebhookActionsFunc: &CodeMonitorStoreListWebhookActionsFunc{
 			defaultHook: func(context.Context, database.ListActionsOpts) (r0 []*database.WebhookAction, r1 error) {
 				return
@@ -6744,6 +6871,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		UpdateIncidentWebhookActionFunc: &CodeMonitorStoreUpdateIncidentWebhookActionFunc{
+			defaultHook: func(context.Context, int64, bool, string, string) (r0 *database.IncidentWebhookAction, r1 error) {
+				return
+			},
+		},
 		UpdateMonitorFunc: &CodeMonitorStoreUpdateMonitorFunc{
 			defaultHook: func(context.Context, int64, database.MonitorArgs) (r0 *database.Monitor, r1 error) {
 				return
@@ -6760,7 +6892,12 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 			},
 		},
 		UpdateSlackWebhookActionFunc: &CodeMonitorStoreUpdateSlackWebhookActionFunc{
-			defaultHook: func(context.Context, int64, bool, bool, string) (r0 *database.SlackWebhookAction, r1 error) {
+			defaultHook: func(context.Context, int64, bool, bool, string, *database.ActionDigest) (r0 *database.SlackWebhookAction, r1 error) {
+				return
+			},
+		},
+		UpdateTeamsWebhookActionFunc: &CodeMonitorStoreUpdateTeamsWebhookActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, string) (r0 *database.TeamsWebhookAction, r1 error) {
 				return
 			},
 		},
@@ -6770,7 +6907,12 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 			},
 		},
 		UpdateWebhookActionFunc: &CodeMonitorStoreUpdateWebhookActionFunc{
-			defaultHook: func(context.Context, int64, bool, bool, string) (r0 *database.WebhookAction, r1 error) {
+			defaultHook: func(context.Context, int64, bool, bool, string, *database.ActionDigest) (r0 *database.WebhookAction, r1 error) {
+				return
+			},
+		},
+		UpsertLastMatchedFunc: &CodeMonitorStoreUpsertLastMatchedFunc{
+			defaultHook: func(context.Context, int64, api.RepoID, []string) (r0 error) {
 				return
 			},
 		},
@@ -6796,6 +6938,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.CountActionJobs")
 			},
 		},
+		CountIncidentWebhookActionsFunc: &CodeMonitorStoreCountIncidentWebhookActionsFunc{
+			defaultHook: func(context.Context, int64) (int, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.CountIncidentWebhookActions")
+			},
+		},
 		CountMonitorsFunc: &CodeMonitorStoreCountMonitorsFunc{
 			defaultHook: func(context.Context, *int32) (int32, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CountMonitors")
@@ -6816,6 +6963,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.CountSlackWebhookActions")
 			},
 		},
+		CountTeamsWebhookActionsFunc: &CodeMonitorStoreCountTeamsWebhookActionsFunc{
+			defaultHook: func(context.Context, int64) (int, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.CountTeamsWebhookActions")
+			},
+		},
 		CountWebhookActionsFunc: &CodeMonitorStoreCountWebhookActionsFunc{
 			defaultHook: func(context.Context, int64) (int, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CountWebhookActions")
@@ -6826,6 +6978,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.CreateEmailAction")
 			},
 		},
+		CreateIncidentWebhookActionFunc: &CodeMonitorStoreCreateIncidentWebhookActionFunc{
+			defaultHook: func(context.Context, int64, bool, string, string) (*database.IncidentWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.CreateIncidentWebhookAction")
+			},
+		},
 		CreateMonitorFunc: &CodeMonitorStoreCreateMonitorFunc{
 			defaultHook: func(context.Context, database.MonitorArgs) (*database.Monitor, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CreateMonitor")
@@ -6842,12 +6999,17 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 			},
 		},
 		CreateSlackWebhookActionFunc: &CodeMonitorStoreCreateSlackWebhookActionFunc{
-			defaultHook: func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error) {
+			defaultHook: func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.SlackWebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CreateSlackWebhookAction")
 			},
 		},
+		CreateTeamsWebhookActionFunc: &CodeMonitorStoreCreateTeamsWebhookActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.CreateTeamsWebhookAction")
+			},
+		},
 		CreateWebhookActionFunc: &CodeMonitorStoreCreateWebhookActionFunc{
-			defaultHook: func(context.Context, int64, bool, bool, string) (*database.WebhookAction, error) {
+			defaultHook: func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.WebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CreateWebhookAction")
 			},
 		},
@@ -6856,6 +7018,16 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteEmailActions")
 			},
 		},
+		DeleteIncidentWebhookActionsFunc: &CodeMonitorStoreDeleteIncidentWebhookActionsFunc{
+			defaultHook: func(context.Context, int64, ...int64) error {
+				panic("unexpected invocation of MockCodeMonitorStore.DeleteIncidentWebhookActions")
+			},
+		},
+		DeleteLastMatchedExceptFunc: &CodeMonitorStoreDeleteLastMatchedExceptFunc{
+			defaultHook: func(context.Context, int64, []api.RepoID) error {
+				panic("unexpected invocation of MockCodeMonitorStore.DeleteLastMatchedExcept")
+			},
+		},
 		DeleteMonitorFunc: &CodeMonitorStoreDeleteMonitorFunc{
 			defaultHook: func(context.Context, int64) error {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteMonitor")
@@ -6876,6 +7048,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteSlackWebhookActions")
 			},
 		},
+		DeleteTeamsWebhookActionsFunc: &CodeMonitorStoreDeleteTeamsWebhookActionsFunc{
+			defaultHook: func(context.Context, int64, ...int64) error {
+				panic("unexpected invocation of MockCodeMonitorStore.DeleteTeamsWebhookActions")
+			},
+		},
 		DeleteWebhookActionsFunc: &CodeMonitorStoreDeleteWebhookActionsFunc{
 			defaultHook: func(context.Context, int64, ...int64) error {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteWebhookActions")
@@ -6891,6 +7068,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.EnqueueActionJobsForMonitor")
 			},
 		},
+		EnqueueDigestActionJobsFunc: &CodeMonitorStoreEnqueueDigestActionJobsFunc{
+			defaultHook: func(context.Context) ([]*database.ActionJob, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.EnqueueDigestActionJobs")
+			},
+		},
 		EnqueueQueryTriggerJobsFunc: &CodeMonitorStoreEnqueueQueryTriggerJobsFunc{
 			defaultHook: func(context.Context) ([]*database.TriggerJob, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.EnqueueQueryTriggerJobs")
@@ -6916,6 +7098,16 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.GetEmailAction")
 			},
 		},
+		GetIncidentWebhookActionFunc: &CodeMonitorStoreGetIncidentWebhookActionFunc{
+			defaultHook: func(context.Context, int64) (*database.IncidentWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.GetIncidentWebhookAction")
+			},
+		},
+		GetLastMatchedFunc: &CodeMonitorStoreGetLastMatchedFunc{
+			defaultHook: func(context.Context, int64, api.RepoID) ([]string, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.GetLastMatched")
+			},
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID) ([]string, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.GetLastSearched")
@@ -6941,6 +7133,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.GetSlackWebhookAction")
 			},
 		},
+		GetTeamsWebhookActionFunc: &CodeMonitorStoreGetTeamsWebhookActionFunc{
+			defaultHook: func(context.Context, int64) (*database.TeamsWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.GetTeamsWebhookAction")
+			},
+		},
 		GetWebhookActionFunc: &CodeMonitorStoreGetWebhookActionFunc{
 			defaultHook: func(context.Context, int64) (*database.WebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.GetWebhookAction")
@@ -6961,11 +7158,21 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.ListActionJobs")
 			},
 		},
+		ListDigestResultsFunc: &CodeMonitorStoreListDigestResultsFunc{
+			defaultHook: func(context.Context, int32) ([]*result.CommitMatch, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.ListDigestResults")
+			},
+		},
 		ListEmailActionsFunc: &CodeMonitorStoreListEmailActionsFunc{
 			defaultHook: func(context.Context, database.ListActionsOpts) ([]*database.EmailAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.ListEmailActions")
 			},
 		},
+		ListIncidentWebhookActionsFunc: &CodeMonitorStoreListIncidentWebhookActionsFunc{
+			defaultHook: func(context.Context, database.ListActionsOpts) ([]*database.IncidentWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.ListIncidentWebhookActions")
+			},
+		},
 		ListMonitorsFunc: &CodeMonitorStoreListMonitorsFunc{
 			defaultHook: func(context.Context, database.ListMonitorsOpts) ([]*database.Monitor, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.ListMonitors")
@@ -6986,6 +7193,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.ListSlackWebhookActions")
 			},
 		},
+		ListTeamsWebhookActionsFunc: &CodeMonitorStoreListTeamsWebhookActionsFunc{
+			defaultHook: func(context.Context, database.ListActionsOpts) ([]*database.TeamsWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.ListTeamsWebhookActions")
+			},
+		},
 		ListWebhookActionsFunc: &CodeMonitorStoreListWebhookActionsFunc{
 			defaultHook: func(context.Context, database.ListActionsOpts) ([]*database.WebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.ListWebhookActions")
@@ -7016,6 +7228,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateEmailAction")
 			},
 		},
+		UpdateIncidentWebhookActionFunc: &CodeMonitorStoreUpdateIncidentWebhookActionFunc{
+			defaultHook: func(context.Context, int64, bool, string, string) (*database.IncidentWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.UpdateIncidentWebhookAction")
+			},
+		},
 		UpdateMonitorFunc: &CodeMonitorStoreUpdateMonitorFunc{
 			defaultHook: func(context.Context, int64, database.MonitorArgs) (*database.Monitor, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateMonitor")
@@ -7032,20 +7249,30 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 			},
 		},
 		UpdateSlackWebhookActionFunc: &CodeMonitorStoreUpdateSlackWebhookActionFunc{
-			defaultHook: func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error) {
+			defaultHook: func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.SlackWebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateSlackWebhookAction")
 			},
 		},
+		UpdateTeamsWebhookActionFunc: &CodeMonitorStoreUpdateTeamsWebhookActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.UpdateTeamsWebhookAction")
+			},
+		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
 			defaultHook: func(context.Context, int32, string, []*result.CommitMatch) error {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateTriggerJobWithResults")
 			},
 		},
 		UpdateWebhookActionFunc: &CodeMonitorStoreUpdateWebhookActionFunc{
-			defaultHook: func(context.Context, int64, bool, bool, string) (*database.WebhookAction, error) {
+			defaultHook: func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.WebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateWebhookAction")
 			},
 		},
+		UpsertLastMatchedFunc: &CodeMonitorStoreUpsertLastMatchedFunc{
+			defaultHook: func(context.Context, int64, api.RepoID, []string) error {
+				panic("unexpected invocation of MockCodeMonitorStore.UpsertLastMatched")
+			},
+		},
 		UpsertLastSearchedFunc: &CodeMonitorStoreUpsertLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID, []string) error {
 				panic("unexpected invocation of MockCodeMonitorStore.UpsertLastSearched")
@@ -7065,6 +7292,9 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		CountActionJobsFunc: &CodeMonitorStoreCountActionJobsFunc{
 			defaultHook: i.CountActionJobs,
 		},
+		CountIncidentWebhookActionsFunc: &CodeMonitorStoreCountIncidentWebhookActionsFunc{
+			defaultHook: i.CountIncidentWebhookActions,
+		},
 		CountMonitorsFunc: &CodeMonitorStoreCountMonitorsFunc{
 			defaultHook: i.CountMonitors,
 		},
@@ -7077,12 +7307,18 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		CountSlackWebhookActionsFunc: &CodeMonitorStoreCountSlackWebhookActionsFunc{
 			defaultHook: i.CountSlackWebhookActions,
 		},
+		CountTeamsWebhookActionsFunc: &CodeMonitorStoreCountTeamsWebhookActionsFunc{
+			defaultHook: i.CountTeamsWebhookActions,
+		},
 		CountWebhookActionsFunc: &CodeMonitorStoreCountWebhookActionsFunc{
 			defaultHook: i.CountWebhookActions,
 		},
 		CreateEmailActionFunc: &CodeMonitorStoreCreateEmailActionFunc{
 			defaultHook: i.CreateEmailAction,
 		},
+		CreateIncidentWebhookActionFunc: &CodeMonitorStoreCreateIncidentWebhookActionFunc{
+			defaultHook: i.CreateIncidentWebhookAction,
+		},
 		CreateMonitorFunc: &CodeMonitorStoreCreateMonitorFunc{
 			defaultHook: i.CreateMonitor,
 		},
@@ -7095,12 +7331,21 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		CreateSlackWebhookActionFunc: &CodeMonitorStoreCreateSlackWebhookActionFunc{
 			defaultHook: i.CreateSlackWebhookAction,
 		},
+		CreateTeamsWebhookActionFunc: &CodeMonitorStoreCreateTeamsWebhookActionFunc{
+			defaultHook: i.CreateTeamsWebhookAction,
+		},
 		CreateWebhookActionFunc: &CodeMonitorStoreCreateWebhookActionFunc{
 			defaultHook: i.CreateWebhookAction,
 		},
 		DeleteEmailActionsFunc: &CodeMonitorStoreDeleteEmailActionsFunc{
 			defaultHook: i.DeleteEmailActions,
 		},
+		DeleteIncidentWebhookActionsFunc: &CodeMonitorStoreDeleteIncidentWebhookActionsFunc{
+			defaultHook: i.DeleteIncidentWebhookActions,
+		},
+		DeleteLastMatchedExceptFunc: &CodeMonitorStoreDeleteLastMatchedExceptFunc{
+			defaultHook: i.DeleteLastMatchedExcept,
+		},
 		DeleteMonitorFunc: &CodeMonitorStoreDeleteMonitorFunc{
 			defaultHook: i.DeleteMonitor,
 		},
@@ -7113,6 +7358,9 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		DeleteSlackWebhookActionsFunc: &CodeMonitorStoreDeleteSlackWebhookActionsFunc{
 			defaultHook: i.DeleteSlackWebhookActions,
 		},
+		DeleteTeamsWebhookActionsFunc: &CodeMonitorStoreDeleteTeamsWebhookActionsFunc{
+			defaultHook: i.DeleteTeamsWebhookActions,
+		},
 		DeleteWebhookActionsFunc: &CodeMonitorStoreDeleteWebhookActionsFunc{
 			defaultHook: i.DeleteWebhookActions,
 		},
@@ -7122,6 +7370,9 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		EnqueueActionJobsForMonitorFunc: &CodeMonitorStoreEnqueueActionJobsForMonitorFunc{
 			defaultHook: i.EnqueueActionJobsForMonitor,
 		},
+		EnqueueDigestActionJobsFunc: &CodeMonitorStoreEnqueueDigestActionJobsFunc{
+			defaultHook: i.EnqueueDigestActionJobs,
+		},
 		EnqueueQueryTriggerJobsFunc: &CodeMonitorStoreEnqueueQueryTriggerJobsFunc{
 			defaultHook: i.EnqueueQueryTriggerJobs,
 		},
@@ -7137,6 +7388,12 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		GetEmailActionFunc: &CodeMonitorStoreGetEmailActionFunc{
 			defaultHook: i.GetEmailAction,
 		},
+		GetIncidentWebhookActionFunc: &CodeMonitorStoreGetIncidentWebhookActionFunc{
+			defaultHook: i.GetIncidentWebhookAction,
+		},
+		GetLastMatchedFunc: &CodeMonitorStoreGetLastMatchedFunc{
+			defaultHook: i.GetLastMatched,
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: i.GetLastSearched,
 		},
@@ -7152,6 +7409,9 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		GetSlackWebhookActionFunc: &CodeMonitorStoreGetSlackWebhookActionFunc{
 			defaultHook: i.GetSlackWebhookAction,
 		},
+		GetTeamsWebhookActionFunc: &CodeMonitorStoreGetTeamsWebhookActionFunc{
+			defaultHook: i.GetTeamsWebhookAction,
+		},
 		GetWebhookActionFunc: &CodeMonitorStoreGetWebhookActionFunc{
 			defaultHook: i.GetWebhookAction,
 		},
@@ -7164,9 +7424,15 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		ListActionJobsFunc: &CodeMonitorStoreListActionJobsFunc{
 			defaultHook: i.ListActionJobs,
 		},
+		ListDigestResultsFunc: &CodeMonitorStoreListDigestResultsFunc{
+			defaultHook: i.ListDigestResults,
+		},
 		ListEmailActionsFunc: &CodeMonitorStoreListEmailActionsFunc{
 			defaultHook: i.ListEmailActions,
 		},
+		ListIncidentWebhookActionsFunc: &CodeMonitorStoreListIncidentWebhookActionsFunc{
+			defaultHook: i.ListIncidentWebhookActions,
+		},
 		ListMonitorsFunc: &CodeMonitorStoreListMonitorsFunc{
 			defaultHook: i.ListMonitors,
 		},
@@ -7179,6 +7445,9 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		ListSlackWebhookActionsFunc: &CodeMonitorStoreListSlackWebhookActionsFunc{
 			defaultHook: i.ListSlackWebhookActions,
 		},
+		ListTeamsWebhookActionsFunc: &CodeMonitorStoreListTeamsWebhookActionsFunc{
+			defaultHook: i.ListTeamsWebhookActions,
+		},
 		ListWebhookActionsFunc: &CodeMonitorStoreListWebhookActionsFunc{
 			defaultHook: i.ListWebhookActions,
 		},
@@ -7197,6 +7466,9 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		UpdateEmailActionFunc: &CodeMonitorStoreUpdateEmailActionFunc{
 			defaultHook: i.UpdateEmailAction,
 		},
+		UpdateIncidentWebhookActionFunc: &CodeMonitorStoreUpdateIncidentWebhookActionFunc{
+			defaultHook: i.UpdateIncidentWebhookAction,
+		},
 		UpdateMonitorFunc: &CodeMonitorStoreUpdateMonitorFunc{
 			defaultHook: i.UpdateMonitor,
 		},
@@ -7209,12 +7481,18 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		UpdateSlackWebhookActionFunc: &CodeMonitorStoreUpdateSlackWebhookActionFunc{
 			defaultHook: i.UpdateSlackWebhookAction,
 		},
+		UpdateTeamsWebhookActionFunc: &CodeMonitorStoreUpdateTeamsWebhookActionFunc{
+			defaultHook: i.UpdateTeamsWebhookAction,
+		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
 			defaultHook: i.UpdateTriggerJobWithResults,
 		},
 		UpdateWebhookActionFunc: &CodeMonitorStoreUpdateWebhookActionFunc{
 			defaultHook: i.UpdateWebhookAction,
 		},
+		UpsertLastMatchedFunc: &CodeMonitorStoreUpsertLastMatchedFunc{
+			defaultHook: i.UpsertLastMatched,
+		},
 		UpsertLastSearchedFunc: &CodeMonitorStoreUpsertLastSearchedFunc{
 			defaultHook: i.UpsertLastSearched,
 		},
@@ -7431,6 +7709,118 @@ func (c CodeMonitorStoreCountActionJobsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreCountIncidentWebhookActionsFunc describes the behavior
+// when the CountIncidentWebhookActions method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreCountIncidentWebhookActionsFunc struct {
+	defaultHook func(context.Context, int64) (int, error)
+	hooks       []func(context.Context, int64) (int, error)
+	history     []CodeMonitorStoreCountIncidentWebhookActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// CountIncidentWebhookActions delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CountIncidentWebhookActions(v0 context.Context, v1 int64) (int, error) {
+	r0, r1 := m.CountIncidentWebhookActionsFunc.nextHook()(v0, v1)
+	m.CountIncidentWebhookActionsFunc.appendCall(CodeMonitorStoreCountIncidentWebhookActionsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// CountIncidentWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreCountIncidentWebhookActionsFunc) SetDefaultHook(hook func(context.Context, int64) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CountIncidentWebhookActions method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreCountIncidentWebhookActionsFunc) PushHook(hook func(context.Context, int64) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreCountIncidentWebhookActionsFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreCountIncidentWebhookActionsFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, int64) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreCountIncidentWebhookActionsFunc) nextHook() func(context.Context, int64) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreCountIncidentWebhookActionsFunc) appendCall(r0 CodeMonitorStoreCountIncidentWebhookActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreCountIncidentWebhookActionsFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreCountIncidentWebhookActionsFunc) History() []CodeMonitorStoreCountIncidentWebhookActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreCountIncidentWebhookActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreCountIncidentWebhookActionsFuncCall is an object that
+// describes an invocation of method CountIncidentWebhookActions on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreCountIncidentWebhookActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreCountIncidentWebhookActionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreCountIncidentWebhookActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreCountMonitorsFunc describes the behavior when the
 // CountMonitors method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -7876,37 +8266,37 @@ func (c CodeMonitorStoreCountSlackWebhookActionsFuncCall) Results() []interface{
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreCountWebhookActionsFunc describes the behavior when the
-// CountWebhookActions method of the parent MockCodeMonitorStore instance is
-// invoked.
-type CodeMonitorStoreCountWebhookActionsFunc struct {
+// CodeMonitorStoreCountTeamsWebhookActionsFunc describes the behavior when
+// the CountTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreCountTeamsWebhookActionsFunc struct {
 	defaultHook func(context.Context, int64) (int, error)
 	hooks       []func(context.Context, int64) (int, error)
-	history     []CodeMonitorStoreCountWebhookActionsFuncCall
+	history     []CodeMonitorStoreCountTeamsWebhookActionsFuncCall
 	mutex       sync.Mutex
 }
 
-// CountWebhookActions delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) CountWebhookActions(v0 context.Context, v1 int64) (int, error) {
-	r0, r1 := m.CountWebhookActionsFunc.nextHook()(v0, v1)
-	m.CountWebhookActionsFunc.appendCall(CodeMonitorStoreCountWebhookActionsFuncCall{v0, v1, r0, r1})
+// CountTeamsWebhookActions delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CountTeamsWebhookActions(v0 context.Context, v1 int64) (int, error) {
+	r0, r1 := m.CountTeamsWebhookActionsFunc.nextHook()(v0, v1)
+	m.CountTeamsWebhookActionsFunc.appendCall(CodeMonitorStoreCountTeamsWebhookActionsFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the CountWebhookActions
-// method of the parent MockCodeMonitorStore instance is invoked and the
-// hook queue is empty.
-func (f *CodeMonitorStoreCountWebhookActionsFunc) SetDefaultHook(hook func(context.Context, int64) (int, error)) {
+// SetDefaultHook sets function that is called when the
+// CountTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) SetDefaultHook(hook func(context.Context, int64) (int, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// CountWebhookActions method of the parent MockCodeMonitorStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *CodeMonitorStoreCountWebhookActionsFunc) PushHook(hook func(context.Context, int64) (int, error)) {
+// CountTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) PushHook(hook func(context.Context, int64) (int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -7914,20 +8304,20 @@ func (f *CodeMonitorStoreCountWebhookActionsFunc) PushHook(hook func(context.Con
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreCountWebhookActionsFunc) SetDefaultReturn(r0 int, r1 error) {
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) SetDefaultReturn(r0 int, r1 error) {
 	f.SetDefaultHook(func(context.Context, int64) (int, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreCountWebhookActionsFunc) PushReturn(r0 int, r1 error) {
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) PushReturn(r0 int, r1 error) {
 	f.PushHook(func(context.Context, int64) (int, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreCountWebhookActionsFunc) nextHook() func(context.Context, int64) (int, error) {
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) nextHook() func(context.Context, int64) (int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -7940,27 +8330,28 @@ func (f *CodeMonitorStoreCountWebhookActionsFunc) nextHook() func(context.Contex
 	return hook
 }
 
-func (f *CodeMonitorStoreCountWebhookActionsFunc) appendCall(r0 CodeMonitorStoreCountWebhookActionsFuncCall) {
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) appendCall(r0 CodeMonitorStoreCountTeamsWebhookActionsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreCountWebhookActionsFuncCall
-// objects describing the invocations of this function.
-func (f *CodeMonitorStoreCountWebhookActionsFunc) History() []CodeMonitorStoreCountWebhookActionsFuncCall {
+// History returns a sequence of
+// CodeMonitorStoreCountTeamsWebhookActionsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreCountTeamsWebhookActionsFunc) History() []CodeMonitorStoreCountTeamsWebhookActionsFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreCountWebhookActionsFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreCountTeamsWebhookActionsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreCountWebhookActionsFuncCall is an object that describes
-// an invocation of method CountWebhookActions on an instance of
-// MockCodeMonitorStore.
-type CodeMonitorStoreCountWebhookActionsFuncCall struct {
+// CodeMonitorStoreCountTeamsWebhookActionsFuncCall is an object that
+// describes an invocation of method CountTeamsWebhookActions on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreCountTeamsWebhookActionsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -7977,20 +8368,131 @@ type CodeMonitorStoreCountWebhookActionsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreCountWebhookActionsFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreCountTeamsWebhookActionsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreCountWebhookActionsFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreCountTeamsWebhookActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreCreateEmailActionFunc describes the behavior when the
-// CreateEmailAction method of the parent MockCodeMonitorStore instance is
+// CodeMonitorStoreCountWebhookActionsFunc describes the behavior when the
+// CountWebhookActions method of the parent MockCodeMonitorStore instance is
 // invoked.
-type CodeMonitorStoreCreateEmailActionFunc struct {
+type CodeMonitorStoreCountWebhookActionsFunc struct {
+	defaultHook func(context.Context, int64) (int, error)
+	hooks       []func(context.Context, int64) (int, error)
+	history     []CodeMonitorStoreCountWebhookActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// CountWebhookActions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CountWebhookActions(v0 context.Context, v1 int64) (int, error) {
+	r0, r1 := m.CountWebhookActionsFunc.nextHook()(v0, v1)
+	m.CountWebhookActionsFunc.appendCall(CodeMonitorStoreCountWebhookActionsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CountWebhookActions
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreCountWebhookActionsFunc) SetDefaultHook(hook func(context.Context, int64) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CountWebhookActions method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreCountWebhookActionsFunc) PushHook(hook func(context.Context, int64) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreCountWebhookActionsFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreCountWebhookActionsFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, int64) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreCountWebhookActionsFunc) nextHook() func(context.Context, int64) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreCountWebhookActionsFunc) appendCall(r0 CodeMonitorStoreCountWebhookActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreCountWebhookActionsFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreCountWebhookActionsFunc) History() []CodeMonitorStoreCountWebhookActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreCountWebhookActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreCountWebhookActionsFuncCall is an object that describes
+// an invocation of method CountWebhookActions on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreCountWebhookActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreCountWebhookActionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreCountWebhookActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreCreateEmailActionFunc describes the behavior when the
+// CreateEmailAction method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreCreateEmailActionFunc struct {
 	defaultHook func(context.Context, int64, *database.EmailActionArgs) (*database.EmailAction, error)
 	hooks       []func(context.Context, int64, *database.EmailActionArgs) (*database.EmailAction, error)
 	history     []CodeMonitorStoreCreateEmailActionFuncCall
@@ -8101,6 +8603,127 @@ func (c CodeMonitorStoreCreateEmailActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreCreateIncidentWebhookActionFunc describes the behavior
+// when the CreateIncidentWebhookAction method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreCreateIncidentWebhookActionFunc struct {
+	defaultHook func(context.Context, int64, bool, string, string) (*database.IncidentWebhookAction, error)
+	hooks       []func(context.Context, int64, bool, string, string) (*database.IncidentWebhookAction, error)
+	history     []CodeMonitorStoreCreateIncidentWebhookActionFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateIncidentWebhookAction delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CreateIncidentWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 string, v4 string) (*database.IncidentWebhookAction, error) {
+	r0, r1 := m.CreateIncidentWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.CreateIncidentWebhookActionFunc.appendCall(CodeMonitorStoreCreateIncidentWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// CreateIncidentWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreCreateIncidentWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, string, string) (*database.IncidentWebhookAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateIncidentWebhookAction method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreCreateIncidentWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, string, string) (*database.IncidentWebhookAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreCreateIncidentWebhookActionFunc) SetDefaultReturn(r0 *database.IncidentWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, string, string) (*database.IncidentWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreCreateIncidentWebhookActionFunc) PushReturn(r0 *database.IncidentWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, int64, bool, string, string) (*database.IncidentWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreCreateIncidentWebhookActionFunc) nextHook() func(context.Context, int64, bool, string, string) (*database.IncidentWebhookAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreCreateIncidentWebhookActionFunc) appendCall(r0 CodeMonitorStoreCreateIncidentWebhookActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreCreateIncidentWebhookActionFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreCreateIncidentWebhookActionFunc) History() []CodeMonitorStoreCreateIncidentWebhookActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreCreateIncidentWebhookActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreCreateIncidentWebhookActionFuncCall is an object that
+// describes an invocation of method CreateIncidentWebhookAction on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreCreateIncidentWebhookActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *database.IncidentWebhookAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreCreateIncidentWebhookActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreCreateIncidentWebhookActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreCreateMonitorFunc describes the behavior when the
 // CreateMonitor method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -8446,24 +9069,24 @@ func (c CodeMonitorStoreCreateRecipientFuncCall) Results() []interface{} {
 // the CreateSlackWebhookAction method of the parent MockCodeMonitorStore
 // instance is invoked.
 type CodeMonitorStoreCreateSlackWebhookActionFunc struct {
-	defaultHook func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error)
-	hooks       []func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error)
+	defaultHook func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.SlackWebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.SlackWebhookAction, error)
 	history     []CodeMonitorStoreCreateSlackWebhookActionFuncCall
 	mutex       sync.Mutex
 }
 
 // CreateSlackWebhookAction delegates to the next hook function in the queue
 // and stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) CreateSlackWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*database.SlackWebhookAction, error) {
-	r0, r1 := m.CreateSlackWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.CreateSlackWebhookActionFunc.appendCall(CodeMonitorStoreCreateSlackWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+func (m *MockCodeMonitorStore) CreateSlackWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string, v5 *database.ActionDigest) (*database.SlackWebhookAction, error) {
+	r0, r1 := m.CreateSlackWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.CreateSlackWebhookActionFunc.appendCall(CodeMonitorStoreCreateSlackWebhookActionFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
 	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the
 // CreateSlackWebhookAction method of the parent MockCodeMonitorStore
 // instance is invoked and the hook queue is empty.
-func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error)) {
+func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.SlackWebhookAction, error)) {
 	f.defaultHook = hook
 }
 
@@ -8472,7 +9095,7 @@ func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) SetDefaultHook(hook func(
 // instance invokes the hook at the front of the queue and discards it.
 // After the queue is empty, the default hook function is invoked for any
 // future action.
-func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error)) {
+func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.SlackWebhookAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -8481,19 +9104,19 @@ func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) PushHook(hook func(contex
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) SetDefaultReturn(r0 *database.SlackWebhookAction, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.SlackWebhookAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) PushReturn(r0 *database.SlackWebhookAction, r1 error) {
-	f.PushHook(func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error) {
+	f.PushHook(func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.SlackWebhookAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error) {
+func (f *CodeMonitorStoreCreateSlackWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.SlackWebhookAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -8543,6 +9166,9 @@ type CodeMonitorStoreCreateSlackWebhookActionFuncCall struct {
 	// Arg4 is the value of the 5th argument passed to this method
 	// invocation.
 	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 *database.ActionDigest
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 *database.SlackWebhookAction
@@ -8554,7 +9180,7 @@ type CodeMonitorStoreCreateSlackWebhookActionFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c CodeMonitorStoreCreateSlackWebhookActionFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
 }
 
 // Results returns an interface slice containing the results of this
@@ -8563,37 +9189,37 @@ func (c CodeMonitorStoreCreateSlackWebhookActionFuncCall) Results() []interface{
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreCreateWebhookActionFunc describes the behavior when the
-// CreateWebhookAction method of the parent MockCodeMonitorStore instance is
-// invoked.
-type CodeMonitorStoreCreateWebhookActionFunc struct {
-	defaultHook func(context.Context, int64, bool, bool, string) (*database.WebhookAction, error)
-	hooks       []func(context.Context, int64, bool, bool, string) (*database.WebhookAction, error)
-	history     []CodeMonitorStoreCreateWebhookActionFuncCall
+// CodeMonitorStoreCreateTeamsWebhookActionFunc describes the behavior when
+// the CreateTeamsWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreCreateTeamsWebhookActionFunc struct {
+	defaultHook func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error)
+	history     []CodeMonitorStoreCreateTeamsWebhookActionFuncCall
 	mutex       sync.Mutex
 }
 
-// CreateWebhookAction delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) CreateWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*database.WebhookAction, error) {
-	r0, r1 := m.CreateWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.CreateWebhookActionFunc.appendCall(CodeMonitorStoreCreateWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+// CreateTeamsWebhookAction delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CreateTeamsWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*database.TeamsWebhookAction, error) {
+	r0, r1 := m.CreateTeamsWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.CreateTeamsWebhookActionFunc.appendCall(CodeMonitorStoreCreateTeamsWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the CreateWebhookAction
-// method of the parent MockCodeMonitorStore instance is invoked and the
-// hook queue is empty.
-func (f *CodeMonitorStoreCreateWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*database.WebhookAction, error)) {
+// SetDefaultHook sets function that is called when the
+// CreateTeamsWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// CreateWebhookAction method of the parent MockCodeMonitorStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *CodeMonitorStoreCreateWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*database.WebhookAction, error)) {
+// CreateTeamsWebhookAction method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -8601,20 +9227,20 @@ func (f *CodeMonitorStoreCreateWebhookActionFunc) PushHook(hook func(context.Con
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreCreateWebhookActionFunc) SetDefaultReturn(r0 *database.WebhookAction, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64, bool, bool, string) (*database.WebhookAction, error) {
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) SetDefaultReturn(r0 *database.TeamsWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreCreateWebhookActionFunc) PushReturn(r0 *database.WebhookAction, r1 error) {
-	f.PushHook(func(context.Context, int64, bool, bool, string) (*database.WebhookAction, error) {
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) PushReturn(r0 *database.TeamsWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreCreateWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string) (*database.WebhookAction, error) {
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -8627,27 +9253,28 @@ func (f *CodeMonitorStoreCreateWebhookActionFunc) nextHook() func(context.Contex
 	return hook
 }
 
-func (f *CodeMonitorStoreCreateWebhookActionFunc) appendCall(r0 CodeMonitorStoreCreateWebhookActionFuncCall) {
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) appendCall(r0 CodeMonitorStoreCreateTeamsWebhookActionFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreCreateWebhookActionFuncCall
-// objects describing the invocations of this function.
-func (f *CodeMonitorStoreCreateWebhookActionFunc) History() []CodeMonitorStoreCreateWebhookActionFuncCall {
+// History returns a sequence of
+// CodeMonitorStoreCreateTeamsWebhookActionFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreCreateTeamsWebhookActionFunc) History() []CodeMonitorStoreCreateTeamsWebhookActionFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreCreateWebhookActionFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreCreateTeamsWebhookActionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreCreateWebhookActionFuncCall is an object that describes
-// an invocation of method CreateWebhookAction on an instance of
-// MockCodeMonitorStore.
-type CodeMonitorStoreCreateWebhookActionFuncCall struct {
+// CodeMonitorStoreCreateTeamsWebhookActionFuncCall is an object that
+// describes an invocation of method CreateTeamsWebhookAction on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreCreateTeamsWebhookActionFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -8665,7 +9292,7 @@ type CodeMonitorStoreCreateWebhookActionFuncCall struct {
 	Arg4 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *database.WebhookAction
+	Result0 *database.TeamsWebhookAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -8673,23 +9300,146 @@ type CodeMonitorStoreCreateWebhookActionFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreCreateWebhookActionFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreCreateTeamsWebhookActionFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreCreateWebhookActionFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreCreateTeamsWebhookActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreDeleteEmailActionsFunc describes the behavior when the
-// DeleteEmailActions method of the parent MockCodeMonitorStore instance is
+// CodeMonitorStoreCreateWebhookActionFunc describes the behavior when the
+// CreateWebhookAction method of the parent MockCodeMonitorStore instance is
 // invoked.
-type CodeMonitorStoreDeleteEmailActionsFunc struct {
-	defaultHook func(context.Context, []int64, int64) error
-	hooks       []func(context.Context, []int64, int64) error
-	history     []CodeMonitorStoreDeleteEmailActionsFuncCall
+type CodeMonitorStoreCreateWebhookActionFunc struct {
+	defaultHook func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.WebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.WebhookAction, error)
+	history     []CodeMonitorStoreCreateWebhookActionFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateWebhookAction delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CreateWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string, v5 *database.ActionDigest) (*database.WebhookAction, error) {
+	r0, r1 := m.CreateWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.CreateWebhookActionFunc.appendCall(CodeMonitorStoreCreateWebhookActionFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CreateWebhookAction
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreCreateWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.WebhookAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateWebhookAction method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreCreateWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.WebhookAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreCreateWebhookActionFunc) SetDefaultReturn(r0 *database.WebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.WebhookAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreCreateWebhookActionFunc) PushReturn(r0 *database.WebhookAction, r1 error) {
+	f.PushHook(func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.WebhookAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreCreateWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.WebhookAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreCreateWebhookActionFunc) appendCall(r0 CodeMonitorStoreCreateWebhookActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreCreateWebhookActionFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreCreateWebhookActionFunc) History() []CodeMonitorStoreCreateWebhookActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreCreateWebhookActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreCreateWebhookActionFuncCall is an object that describes
+// an invocation of method CreateWebhookAction on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreCreateWebhookActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 *database.ActionDigest
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *database.WebhookAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreCreateWebhookActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreCreateWebhookActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreDeleteEmailActionsFunc describes the behavior when the
+// DeleteEmailActions method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreDeleteEmailActionsFunc struct {
+	defaultHook func(context.Context, []int64, int64) error
+	hooks       []func(context.Context, []int64, int64) error
+	history     []CodeMonitorStoreDeleteEmailActionsFuncCall
 	mutex       sync.Mutex
 }
 
@@ -8794,6 +9544,237 @@ func (c CodeMonitorStoreDeleteEmailActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// CodeMonitorStoreDeleteIncidentWebhookActionsFunc describes the behavior
+// when the DeleteIncidentWebhookActions method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreDeleteIncidentWebhookActionsFunc struct {
+	defaultHook func(context.Context, int64, ...int64) error
+	hooks       []func(context.Context, int64, ...int64) error
+	history     []CodeMonitorStoreDeleteIncidentWebhookActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteIncidentWebhookActions delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) DeleteIncidentWebhookActions(v0 context.Context, v1 int64, v2 ...int64) error {
+	r0 := m.DeleteIncidentWebhookActionsFunc.nextHook()(v0, v1, v2...)
+	m.DeleteIncidentWebhookActionsFunc.appendCall(CodeMonitorStoreDeleteIncidentWebhookActionsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// DeleteIncidentWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreDeleteIncidentWebhookActionsFunc) SetDefaultHook(hook func(context.Context, int64, ...int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteIncidentWebhookActions method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreDeleteIncidentWebhookActionsFunc) PushHook(hook func(context.Context, int64, ...int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreDeleteIncidentWebhookActionsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, ...int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreDeleteIncidentWebhookActionsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, ...int64) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreDeleteIncidentWebhookActionsFunc) nextHook() func(context.Context, int64, ...int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreDeleteIncidentWebhookActionsFunc) appendCall(r0 CodeMonitorStoreDeleteIncidentWebhookActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreDeleteIncidentWebhookActionsFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreDeleteIncidentWebhookActionsFunc) History() []CodeMonitorStoreDeleteIncidentWebhookActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreDeleteIncidentWebhookActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreDeleteIncidentWebhookActionsFuncCall is an object that
+// describes an invocation of method DeleteIncidentWebhookActions on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreDeleteIncidentWebhookActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg2 []int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c CodeMonitorStoreDeleteIncidentWebhookActionsFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg2 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0, c.Arg1}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreDeleteIncidentWebhookActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// CodeMonitorStoreDeleteLastMatchedExceptFunc describes the behavior when
+// the DeleteLastMatchedExcept method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreDeleteLastMatchedExceptFunc struct {
+	defaultHook func(context.Context, int64, []api.RepoID) error
+	hooks       []func(context.Context, int64, []api.RepoID) error
+	history     []CodeMonitorStoreDeleteLastMatchedExceptFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteLastMatchedExcept delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) DeleteLastMatchedExcept(v0 context.Context, v1 int64, v2 []api.RepoID) error {
+	r0 := m.DeleteLastMatchedExceptFunc.nextHook()(v0, v1, v2)
+	m.DeleteLastMatchedExceptFunc.appendCall(CodeMonitorStoreDeleteLastMatchedExceptFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// DeleteLastMatchedExcept method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreDeleteLastMatchedExceptFunc) SetDefaultHook(hook func(context.Context, int64, []api.RepoID) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteLastMatchedExcept method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreDeleteLastMatchedExceptFunc) PushHook(hook func(context.Context, int64, []api.RepoID) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreDeleteLastMatchedExceptFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, []api.RepoID) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreDeleteLastMatchedExceptFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, []api.RepoID) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreDeleteLastMatchedExceptFunc) nextHook() func(context.Context, int64, []api.RepoID) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreDeleteLastMatchedExceptFunc) appendCall(r0 CodeMonitorStoreDeleteLastMatchedExceptFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreDeleteLastMatchedExceptFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreDeleteLastMatchedExceptFunc) History() []CodeMonitorStoreDeleteLastMatchedExceptFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreDeleteLastMatchedExceptFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreDeleteLastMatchedExceptFuncCall is an object that
+// describes an invocation of method DeleteLastMatchedExcept on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreDeleteLastMatchedExceptFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreDeleteLastMatchedExceptFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreDeleteLastMatchedExceptFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // CodeMonitorStoreDeleteMonitorFunc describes the behavior when the
 // DeleteMonitor method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -9237,6 +10218,125 @@ func (c CodeMonitorStoreDeleteSlackWebhookActionsFuncCall) Results() []interface
 	return []interface{}{c.Result0}
 }
 
+// CodeMonitorStoreDeleteTeamsWebhookActionsFunc describes the behavior when
+// the DeleteTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreDeleteTeamsWebhookActionsFunc struct {
+	defaultHook func(context.Context, int64, ...int64) error
+	hooks       []func(context.Context, int64, ...int64) error
+	history     []CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteTeamsWebhookActions delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) DeleteTeamsWebhookActions(v0 context.Context, v1 int64, v2 ...int64) error {
+	r0 := m.DeleteTeamsWebhookActionsFunc.nextHook()(v0, v1, v2...)
+	m.DeleteTeamsWebhookActionsFunc.appendCall(CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// DeleteTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) SetDefaultHook(hook func(context.Context, int64, ...int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) PushHook(hook func(context.Context, int64, ...int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, ...int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, ...int64) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) nextHook() func(context.Context, int64, ...int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) appendCall(r0 CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreDeleteTeamsWebhookActionsFunc) History() []CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall is an object that
+// describes an invocation of method DeleteTeamsWebhookActions on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg2 []int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg2 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0, c.Arg1}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreDeleteTeamsWebhookActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // CodeMonitorStoreDeleteWebhookActionsFunc describes the behavior when the
 // DeleteWebhookActions method of the parent MockCodeMonitorStore instance
 // is invoked.
@@ -9468,27 +10568,142 @@ type CodeMonitorStoreEnqueueActionJobsForMonitorFunc struct {
 	mutex       sync.Mutex
 }
 
-// EnqueueActionJobsForMonitor delegates to the next hook function in the
-// queue and stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) EnqueueActionJobsForMonitor(v0 context.Context, v1 int64, v2 int32) ([]*database.ActionJob, error) {
-	r0, r1 := m.EnqueueActionJobsForMonitorFunc.nextHook()(v0, v1, v2)
-	m.EnqueueActionJobsForMonitorFunc.appendCall(CodeMonitorStoreEnqueueActionJobsForMonitorFuncCall{v0, v1, v2, r0, r1})
+// EnqueueActionJobsForMonitor delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) EnqueueActionJobsForMonitor(v0 context.Context, v1 int64, v2 int32) ([]*database.ActionJob, error) {
+	r0, r1 := m.EnqueueActionJobsForMonitorFunc.nextHook()(v0, v1, v2)
+	m.EnqueueActionJobsForMonitorFunc.appendCall(CodeMonitorStoreEnqueueActionJobsForMonitorFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// EnqueueActionJobsForMonitor method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreEnqueueActionJobsForMonitorFunc) SetDefaultHook(hook func(context.Context, int64, int32) ([]*database.ActionJob, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// EnqueueActionJobsForMonitor method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreEnqueueActionJobsForMonitorFunc) PushHook(hook func(context.Context, int64, int32) ([]*database.ActionJob, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreEnqueueActionJobsForMonitorFunc) SetDefaultReturn(r0 []*database.ActionJob, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int32) ([]*database.ActionJob, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreEnqueueActionJobsForMonitorFunc) PushReturn(r0 []*database.ActionJob, r1 error) {
+	f.PushHook(func(context.Context, int64, int32) ([]*database.ActionJob, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreEnqueueActionJobsForMonitorFunc) nextHook() func(context.Context, int64, int32) ([]*database.ActionJob, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreEnqueueActionJobsForMonitorFunc) appendCall(r0 CodeMonitorStoreEnqueueActionJobsForMonitorFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreEnqueueActionJobsForMonitorFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreEnqueueActionJobsForMonitorFunc) History() []CodeMonitorStoreEnqueueActionJobsForMonitorFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreEnqueueActionJobsForMonitorFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreEnqueueActionJobsForMonitorFuncCall is an object that
+// describes an invocation of method EnqueueActionJobsForMonitor on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreEnqueueActionJobsForMonitorFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*database.ActionJob
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreEnqueueActionJobsForMonitorFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreEnqueueActionJobsForMonitorFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreEnqueueDigestActionJobsFunc describes the behavior when
+// the EnqueueDigestActionJobs method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreEnqueueDigestActionJobsFunc struct {
+	defaultHook func(context.Context) ([]*database.ActionJob, error)
+	hooks       []func(context.Context) ([]*database.ActionJob, error)
+	history     []CodeMonitorStoreEnqueueDigestActionJobsFuncCall
+	mutex       sync.Mutex
+}
+
+// EnqueueDigestActionJobs delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) EnqueueDigestActionJobs(v0 context.Context) ([]*database.ActionJob, error) {
+	r0, r1 := m.EnqueueDigestActionJobsFunc.nextHook()(v0)
+	m.EnqueueDigestActionJobsFunc.appendCall(CodeMonitorStoreEnqueueDigestActionJobsFuncCall{v0, r0, r1})
 	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the
-// EnqueueActionJobsForMonitor method of the parent MockCodeMonitorStore
+// EnqueueDigestActionJobs method of the parent MockCodeMonitorStore
 // instance is invoked and the hook queue is empty.
-func (f *CodeMonitorStoreEnqueueActionJobsForMonitorFunc) SetDefaultHook(hook func(context.Context, int64, int32) ([]*database.ActionJob, error)) {
+func (f *CodeMonitorStoreEnqueueDigestActionJobsFunc) SetDefaultHook(hook func(context.Context) ([]*database.ActionJob, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// EnqueueActionJobsForMonitor method of the parent MockCodeMonitorStore
+// EnqueueDigestActionJobs method of the parent MockCodeMonitorStore
 // instance invokes the hook at the front of the queue and discards it.
 // After the queue is empty, the default hook function is invoked for any
 // future action.
-func (f *CodeMonitorStoreEnqueueActionJobsForMonitorFunc) PushHook(hook func(context.Context, int64, int32) ([]*database.ActionJob, error)) {
+func (f *CodeMonitorStoreEnqueueDigestActionJobsFunc) PushHook(hook func(context.Context) ([]*database.ActionJob, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -9496,20 +10711,20 @@ func (f *CodeMonitorStoreEnqueueActionJobsForMonitorFunc) PushHook(hook func(con
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreEnqueueActionJobsForMonitorFunc) SetDefaultReturn(r0 []*database.ActionJob, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64, int32) ([]*database.ActionJob, error) {
+func (f *CodeMonitorStoreEnqueueDigestActionJobsFunc) SetDefaultReturn(r0 []*database.ActionJob, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]*database.ActionJob, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreEnqueueActionJobsForMonitorFunc) PushReturn(r0 []*database.ActionJob, r1 error) {
-	f.PushHook(func(context.Context, int64, int32) ([]*database.ActionJob, error) {
+func (f *CodeMonitorStoreEnqueueDigestActionJobsFunc) PushReturn(r0 []*database.ActionJob, r1 error) {
+	f.PushHook(func(context.Context) ([]*database.ActionJob, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreEnqueueActionJobsForMonitorFunc) nextHook() func(context.Context, int64, int32) ([]*database.ActionJob, error) {
+func (f *CodeMonitorStoreEnqueueDigestActionJobsFunc) nextHook() func(context.Context) ([]*database.ActionJob, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -9522,37 +10737,31 @@ func (f *CodeMonitorStoreEnqueueActionJobsForMonitorFunc) nextHook() func(contex
 	return hook
 }
 
-func (f *CodeMonitorStoreEnqueueActionJobsForMonitorFunc) appendCall(r0 CodeMonitorStoreEnqueueActionJobsForMonitorFuncCall) {
+func (f *CodeMonitorStoreEnqueueDigestActionJobsFunc) appendCall(r0 CodeMonitorStoreEnqueueDigestActionJobsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
 // History returns a sequence of
-// CodeMonitorStoreEnqueueActionJobsForMonitorFuncCall objects describing
-// the invocations of this function.
-func (f *CodeMonitorStoreEnqueueActionJobsForMonitorFunc) History() []CodeMonitorStoreEnqueueActionJobsForMonitorFuncCall {
+// CodeMonitorStoreEnqueueDigestActionJobsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreEnqueueDigestActionJobsFunc) History() []CodeMonitorStoreEnqueueDigestActionJobsFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreEnqueueActionJobsForMonitorFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreEnqueueDigestActionJobsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreEnqueueActionJobsForMonitorFuncCall is an object that
-// describes an invocation of method EnqueueActionJobsForMonitor on an
-// instance of MockCodeMonitorStore.
-type CodeMonitorStoreEnqueueActionJobsForMonitorFuncCall struct {
+// CodeMonitorStoreEnqueueDigestActionJobsFuncCall is an object that
+// describes an invocation of method EnqueueDigestActionJobs on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreEnqueueDigestActionJobsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 int64
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 int32
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 []*database.ActionJob
@@ -9563,13 +10772,13 @@ type CodeMonitorStoreEnqueueActionJobsForMonitorFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreEnqueueActionJobsForMonitorFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c CodeMonitorStoreEnqueueDigestActionJobsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreEnqueueActionJobsForMonitorFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreEnqueueDigestActionJobsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
@@ -9797,26 +11006,247 @@ type CodeMonitorStoreGetActionJobFunc struct {
 	mutex       sync.Mutex
 }
 
-// GetActionJob delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) GetActionJob(v0 context.Context, v1 int32) (*database.ActionJob, error) {
-	r0, r1 := m.GetActionJobFunc.nextHook()(v0, v1)
-	m.GetActionJobFunc.appendCall(CodeMonitorStoreGetActionJobFuncCall{v0, v1, r0, r1})
+// GetActionJob delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) GetActionJob(v0 context.Context, v1 int32) (*database.ActionJob, error) {
+	r0, r1 := m.GetActionJobFunc.nextHook()(v0, v1)
+	m.GetActionJobFunc.appendCall(CodeMonitorStoreGetActionJobFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetActionJob method
+// of the parent MockCodeMonitorStore instance is invoked and the hook queue
+// is empty.
+func (f *CodeMonitorStoreGetActionJobFunc) SetDefaultHook(hook func(context.Context, int32) (*database.ActionJob, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetActionJob method of the parent MockCodeMonitorStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *CodeMonitorStoreGetActionJobFunc) PushHook(hook func(context.Context, int32) (*database.ActionJob, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreGetActionJobFunc) SetDefaultReturn(r0 *database.ActionJob, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (*database.ActionJob, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreGetActionJobFunc) PushReturn(r0 *database.ActionJob, r1 error) {
+	f.PushHook(func(context.Context, int32) (*database.ActionJob, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreGetActionJobFunc) nextHook() func(context.Context, int32) (*database.ActionJob, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreGetActionJobFunc) appendCall(r0 CodeMonitorStoreGetActionJobFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreGetActionJobFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreGetActionJobFunc) History() []CodeMonitorStoreGetActionJobFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreGetActionJobFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreGetActionJobFuncCall is an object that describes an
+// invocation of method GetActionJob on an instance of MockCodeMonitorStore.
+type CodeMonitorStoreGetActionJobFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *database.ActionJob
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreGetActionJobFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreGetActionJobFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreGetActionJobMetadataFunc describes the behavior when the
+// GetActionJobMetadata method of the parent MockCodeMonitorStore instance
+// is invoked.
+type CodeMonitorStoreGetActionJobMetadataFunc struct {
+	defaultHook func(context.Context, int32) (*database.ActionJobMetadata, error)
+	hooks       []func(context.Context, int32) (*database.ActionJobMetadata, error)
+	history     []CodeMonitorStoreGetActionJobMetadataFuncCall
+	mutex       sync.Mutex
+}
+
+// GetActionJobMetadata delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) GetActionJobMetadata(v0 context.Context, v1 int32) (*database.ActionJobMetadata, error) {
+	r0, r1 := m.GetActionJobMetadataFunc.nextHook()(v0, v1)
+	m.GetActionJobMetadataFunc.appendCall(CodeMonitorStoreGetActionJobMetadataFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetActionJobMetadata
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreGetActionJobMetadataFunc) SetDefaultHook(hook func(context.Context, int32) (*database.ActionJobMetadata, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetActionJobMetadata method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreGetActionJobMetadataFunc) PushHook(hook func(context.Context, int32) (*database.ActionJobMetadata, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreGetActionJobMetadataFunc) SetDefaultReturn(r0 *database.ActionJobMetadata, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (*database.ActionJobMetadata, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreGetActionJobMetadataFunc) PushReturn(r0 *database.ActionJobMetadata, r1 error) {
+	f.PushHook(func(context.Context, int32) (*database.ActionJobMetadata, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreGetActionJobMetadataFunc) nextHook() func(context.Context, int32) (*database.ActionJobMetadata, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreGetActionJobMetadataFunc) appendCall(r0 CodeMonitorStoreGetActionJobMetadataFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreGetActionJobMetadataFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreGetActionJobMetadataFunc) History() []CodeMonitorStoreGetActionJobMetadataFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreGetActionJobMetadataFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreGetActionJobMetadataFuncCall is an object that describes
+// an invocation of method GetActionJobMetadata on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreGetActionJobMetadataFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *database.ActionJobMetadata
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreGetActionJobMetadataFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreGetActionJobMetadataFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreGetEmailActionFunc describes the behavior when the
+// GetEmailAction method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreGetEmailActionFunc struct {
+	defaultHook func(context.Context, int64) (*database.EmailAction, error)
+	hooks       []func(context.Context, int64) (*database.EmailAction, error)
+	history     []CodeMonitorStoreGetEmailActionFuncCall
+	mutex       sync.Mutex
+}
+
+// GetEmailAction delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) GetEmailAction(v0 context.Context, v1 int64) (*database.EmailAction, error) {
+	r0, r1 := m.GetEmailActionFunc.nextHook()(v0, v1)
+	m.GetEmailActionFunc.appendCall(CodeMonitorStoreGetEmailActionFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetActionJob method
-// of the parent MockCodeMonitorStore instance is invoked and the hook queue
-// is empty.
-func (f *CodeMonitorStoreGetActionJobFunc) SetDefaultHook(hook func(context.Context, int32) (*database.ActionJob, error)) {
+// SetDefaultHook sets function that is called when the GetEmailAction
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreGetEmailActionFunc) SetDefaultHook(hook func(context.Context, int64) (*database.EmailAction, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetActionJob method of the parent MockCodeMonitorStore instance invokes
+// GetEmailAction method of the parent MockCodeMonitorStore instance invokes
 // the hook at the front of the queue and discards it. After the queue is
 // empty, the default hook function is invoked for any future action.
-func (f *CodeMonitorStoreGetActionJobFunc) PushHook(hook func(context.Context, int32) (*database.ActionJob, error)) {
+func (f *CodeMonitorStoreGetEmailActionFunc) PushHook(hook func(context.Context, int64) (*database.EmailAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -9824,20 +11254,20 @@ func (f *CodeMonitorStoreGetActionJobFunc) PushHook(hook func(context.Context, i
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreGetActionJobFunc) SetDefaultReturn(r0 *database.ActionJob, r1 error) {
-	f.SetDefaultHook(func(context.Context, int32) (*database.ActionJob, error) {
+func (f *CodeMonitorStoreGetEmailActionFunc) SetDefaultReturn(r0 *database.EmailAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*database.EmailAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreGetActionJobFunc) PushReturn(r0 *database.ActionJob, r1 error) {
-	f.PushHook(func(context.Context, int32) (*database.ActionJob, error) {
+func (f *CodeMonitorStoreGetEmailActionFunc) PushReturn(r0 *database.EmailAction, r1 error) {
+	f.PushHook(func(context.Context, int64) (*database.EmailAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreGetActionJobFunc) nextHook() func(context.Context, int32) (*database.ActionJob, error) {
+func (f *CodeMonitorStoreGetEmailActionFunc) nextHook() func(context.Context, int64) (*database.EmailAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -9850,35 +11280,36 @@ func (f *CodeMonitorStoreGetActionJobFunc) nextHook() func(context.Context, int3
 	return hook
 }
 
-func (f *CodeMonitorStoreGetActionJobFunc) appendCall(r0 CodeMonitorStoreGetActionJobFuncCall) {
+func (f *CodeMonitorStoreGetEmailActionFunc) appendCall(r0 CodeMonitorStoreGetEmailActionFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreGetActionJobFuncCall
+// History returns a sequence of CodeMonitorStoreGetEmailActionFuncCall
 // objects describing the invocations of this function.
-func (f *CodeMonitorStoreGetActionJobFunc) History() []CodeMonitorStoreGetActionJobFuncCall {
+func (f *CodeMonitorStoreGetEmailActionFunc) History() []CodeMonitorStoreGetEmailActionFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreGetActionJobFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreGetEmailActionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreGetActionJobFuncCall is an object that describes an
-// invocation of method GetActionJob on an instance of MockCodeMonitorStore.
-type CodeMonitorStoreGetActionJobFuncCall struct {
+// CodeMonitorStoreGetEmailActionFuncCall is an object that describes an
+// invocation of method GetEmailAction on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreGetEmailActionFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int32
+	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *database.ActionJob
+	Result0 *database.EmailAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -9886,47 +11317,47 @@ type CodeMonitorStoreGetActionJobFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreGetActionJobFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreGetEmailActionFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreGetActionJobFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreGetEmailActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreGetActionJobMetadataFunc describes the behavior when the
-// GetActionJobMetadata method of the parent MockCodeMonitorStore instance
-// is invoked.
-type CodeMonitorStoreGetActionJobMetadataFunc struct {
-	defaultHook func(context.Context, int32) (*database.ActionJobMetadata, error)
-	hooks       []func(context.Context, int32) (*database.ActionJobMetadata, error)
-	history     []CodeMonitorStoreGetActionJobMetadataFuncCall
+// CodeMonitorStoreGetIncidentWebhookActionFunc describes the behavior when
+// the GetIncidentWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreGetIncidentWebhookActionFunc struct {
+	defaultHook func(context.Context, int64) (*database.IncidentWebhookAction, error)
+	hooks       []func(context.Context, int64) (*database.IncidentWebhookAction, error)
+	history     []CodeMonitorStoreGetIncidentWebhookActionFuncCall
 	mutex       sync.Mutex
 }
 
-// GetActionJobMetadata delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) GetActionJobMetadata(v0 context.Context, v1 int32) (*database.ActionJobMetadata, error) {
-	r0, r1 := m.GetActionJobMetadataFunc.nextHook()(v0, v1)
-	m.GetActionJobMetadataFunc.appendCall(CodeMonitorStoreGetActionJobMetadataFuncCall{v0, v1, r0, r1})
+// GetIncidentWebhookAction delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) GetIncidentWebhookAction(v0 context.Context, v1 int64) (*database.IncidentWebhookAction, error) {
+	r0, r1 := m.GetIncidentWebhookActionFunc.nextHook()(v0, v1)
+	m.GetIncidentWebhookActionFunc.appendCall(CodeMonitorStoreGetIncidentWebhookActionFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetActionJobMetadata
-// method of the parent MockCodeMonitorStore instance is invoked and the
-// hook queue is empty.
-func (f *CodeMonitorStoreGetActionJobMetadataFunc) SetDefaultHook(hook func(context.Context, int32) (*database.ActionJobMetadata, error)) {
+// SetDefaultHook sets function that is called when the
+// GetIncidentWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreGetIncidentWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64) (*database.IncidentWebhookAction, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetActionJobMetadata method of the parent MockCodeMonitorStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *CodeMonitorStoreGetActionJobMetadataFunc) PushHook(hook func(context.Context, int32) (*database.ActionJobMetadata, error)) {
+// GetIncidentWebhookAction method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreGetIncidentWebhookActionFunc) PushHook(hook func(context.Context, int64) (*database.IncidentWebhookAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -9934,20 +11365,20 @@ func (f *CodeMonitorStoreGetActionJobMetadataFunc) PushHook(hook func(context.Co
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreGetActionJobMetadataFunc) SetDefaultReturn(r0 *database.ActionJobMetadata, r1 error) {
-	f.SetDefaultHook(func(context.Context, int32) (*database.ActionJobMetadata, error) {
+func (f *CodeMonitorStoreGetIncidentWebhookActionFunc) SetDefaultReturn(r0 *database.IncidentWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*database.IncidentWebhookAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreGetActionJobMetadataFunc) PushReturn(r0 *database.ActionJobMetadata, r1 error) {
-	f.PushHook(func(context.Context, int32) (*database.ActionJobMetadata, error) {
+func (f *CodeMonitorStoreGetIncidentWebhookActionFunc) PushReturn(r0 *database.IncidentWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, int64) (*database.IncidentWebhookAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreGetActionJobMetadataFunc) nextHook() func(context.Context, int32) (*database.ActionJobMetadata, error) {
+func (f *CodeMonitorStoreGetIncidentWebhookActionFunc) nextHook() func(context.Context, int64) (*database.IncidentWebhookAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -9960,37 +11391,37 @@ func (f *CodeMonitorStoreGetActionJobMetadataFunc) nextHook() func(context.Conte
 	return hook
 }
 
-func (f *CodeMonitorStoreGetActionJobMetadataFunc) appendCall(r0 CodeMonitorStoreGetActionJobMetadataFuncCall) {
+func (f *CodeMonitorStoreGetIncidentWebhookActionFunc) appendCall(r0 CodeMonitorStoreGetIncidentWebhookActionFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
 // History returns a sequence of
-// CodeMonitorStoreGetActionJobMetadataFuncCall objects describing the
+// CodeMonitorStoreGetIncidentWebhookActionFuncCall objects describing the
 // invocations of this function.
-func (f *CodeMonitorStoreGetActionJobMetadataFunc) History() []CodeMonitorStoreGetActionJobMetadataFuncCall {
+func (f *CodeMonitorStoreGetIncidentWebhookActionFunc) History() []CodeMonitorStoreGetIncidentWebhookActionFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreGetActionJobMetadataFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreGetIncidentWebhookActionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreGetActionJobMetadataFuncCall is an object that describes
-// an invocation of method GetActionJobMetadata on an instance of
-// MockCodeMonitorStore.
-type CodeMonitorStoreGetActionJobMetadataFuncCall struct {
+// CodeMonitorStoreGetIncidentWebhookActionFuncCall is an object that
+// describes an invocation of method GetIncidentWebhookAction on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreGetIncidentWebhookActionFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int32
+	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *database.ActionJobMetadata
+	Result0 *database.IncidentWebhookAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -9998,46 +11429,46 @@ type CodeMonitorStoreGetActionJobMetadataFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreGetActionJobMetadataFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreGetIncidentWebhookActionFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreGetActionJobMetadataFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreGetIncidentWebhookActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreGetEmailActionFunc describes the behavior when the
-// GetEmailAction method of the parent MockCodeMonitorStore instance is
+// CodeMonitorStoreGetLastMatchedFunc describes the behavior when the
+// GetLastMatched method of the parent MockCodeMonitorStore instance is
 // invoked.
-type CodeMonitorStoreGetEmailActionFunc struct {
-	defaultHook func(context.Context, int64) (*database.EmailAction, error)
-	hooks       []func(context.Context, int64) (*database.EmailAction, error)
-	history     []CodeMonitorStoreGetEmailActionFuncCall
+type CodeMonitorStoreGetLastMatchedFunc struct {
+	defaultHook func(context.Context, int64, api.RepoID) ([]string, error)
+	hooks       []func(context.Context, int64, api.RepoID) ([]string, error)
+	history     []CodeMonitorStoreGetLastMatchedFuncCall
 	mutex       sync.Mutex
 }
 
-// GetEmailAction delegates to the next hook function in the queue and
+// GetLastMatched delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) GetEmailAction(v0 context.Context, v1 int64) (*database.EmailAction, error) {
-	r0, r1 := m.GetEmailActionFunc.nextHook()(v0, v1)
-	m.GetEmailActionFunc.appendCall(CodeMonitorStoreGetEmailActionFuncCall{v0, v1, r0, r1})
+func (m *MockCodeMonitorStore) GetLastMatched(v0 context.Context, v1 int64, v2 api.RepoID) ([]string, error) {
+	r0, r1 := m.GetLastMatchedFunc.nextHook()(v0, v1, v2)
+	m.GetLastMatchedFunc.appendCall(CodeMonitorStoreGetLastMatchedFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetEmailAction
+// SetDefaultHook sets function that is called when the GetLastMatched
 // method of the parent MockCodeMonitorStore instance is invoked and the
 // hook queue is empty.
-func (f *CodeMonitorStoreGetEmailActionFunc) SetDefaultHook(hook func(context.Context, int64) (*database.EmailAction, error)) {
+func (f *CodeMonitorStoreGetLastMatchedFunc) SetDefaultHook(hook func(context.Context, int64, api.RepoID) ([]string, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetEmailAction method of the parent MockCodeMonitorStore instance invokes
+// GetLastMatched method of the parent MockCodeMonitorStore instance invokes
 // the hook at the front of the queue and discards it. After the queue is
 // empty, the default hook function is invoked for any future action.
-func (f *CodeMonitorStoreGetEmailActionFunc) PushHook(hook func(context.Context, int64) (*database.EmailAction, error)) {
+func (f *CodeMonitorStoreGetLastMatchedFunc) PushHook(hook func(context.Context, int64, api.RepoID) ([]string, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -10045,20 +11476,20 @@ func (f *CodeMonitorStoreGetEmailActionFunc) PushHook(hook func(context.Context,
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreGetEmailActionFunc) SetDefaultReturn(r0 *database.EmailAction, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64) (*database.EmailAction, error) {
+func (f *CodeMonitorStoreGetLastMatchedFunc) SetDefaultReturn(r0 []string, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, api.RepoID) ([]string, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreGetEmailActionFunc) PushReturn(r0 *database.EmailAction, r1 error) {
-	f.PushHook(func(context.Context, int64) (*database.EmailAction, error) {
+func (f *CodeMonitorStoreGetLastMatchedFunc) PushReturn(r0 []string, r1 error) {
+	f.PushHook(func(context.Context, int64, api.RepoID) ([]string, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreGetEmailActionFunc) nextHook() func(context.Context, int64) (*database.EmailAction, error) {
+func (f *CodeMonitorStoreGetLastMatchedFunc) nextHook() func(context.Context, int64, api.RepoID) ([]string, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -10071,36 +11502,39 @@ func (f *CodeMonitorStoreGetEmailActionFunc) nextHook() func(context.Context, in
 	return hook
 }
 
-func (f *CodeMonitorStoreGetEmailActionFunc) appendCall(r0 CodeMonitorStoreGetEmailActionFuncCall) {
+func (f *CodeMonitorStoreGetLastMatchedFunc) appendCall(r0 CodeMonitorStoreGetLastMatchedFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreGetEmailActionFuncCall
+// History returns a sequence of CodeMonitorStoreGetLastMatchedFuncCall
 // objects describing the invocations of this function.
-func (f *CodeMonitorStoreGetEmailActionFunc) History() []CodeMonitorStoreGetEmailActionFuncCall {
+func (f *CodeMonitorStoreGetLastMatchedFunc) History() []CodeMonitorStoreGetLastMatchedFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreGetEmailActionFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreGetLastMatchedFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreGetEmailActionFuncCall is an object that describes an
-// invocation of method GetEmailAction on an instance of
+// CodeMonitorStoreGetLastMatchedFuncCall is an object that describes an
+// invocation of method GetLastMatched on an instance of
 // MockCodeMonitorStore.
-type CodeMonitorStoreGetEmailActionFuncCall struct {
+type CodeMonitorStoreGetLastMatchedFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoID
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *database.EmailAction
+	Result0 []string
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -10108,13 +11542,13 @@ type CodeMonitorStoreGetEmailActionFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreGetEmailActionFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c CodeMonitorStoreGetLastMatchedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreGetEmailActionFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreGetLastMatchedFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
@@ -10490,20 +11924,132 @@ func (f *CodeMonitorStoreGetQueryTriggerForMonitorFunc) PushHook(hook func(conte
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreGetQueryTriggerForMonitorFunc) SetDefaultReturn(r0 *database.QueryTrigger, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64) (*database.QueryTrigger, error) {
+func (f *CodeMonitorStoreGetQueryTriggerForMonitorFunc) SetDefaultReturn(r0 *database.QueryTrigger, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*database.QueryTrigger, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreGetQueryTriggerForMonitorFunc) PushReturn(r0 *database.QueryTrigger, r1 error) {
+	f.PushHook(func(context.Context, int64) (*database.QueryTrigger, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreGetQueryTriggerForMonitorFunc) nextHook() func(context.Context, int64) (*database.QueryTrigger, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreGetQueryTriggerForMonitorFunc) appendCall(r0 CodeMonitorStoreGetQueryTriggerForMonitorFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreGetQueryTriggerForMonitorFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreGetQueryTriggerForMonitorFunc) History() []CodeMonitorStoreGetQueryTriggerForMonitorFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreGetQueryTriggerForMonitorFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreGetQueryTriggerForMonitorFuncCall is an object that
+// describes an invocation of method GetQueryTriggerForMonitor on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreGetQueryTriggerForMonitorFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *database.QueryTrigger
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreGetQueryTriggerForMonitorFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreGetQueryTriggerForMonitorFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreGetSlackWebhookActionFunc describes the behavior when the
+// GetSlackWebhookAction method of the parent MockCodeMonitorStore instance
+// is invoked.
+type CodeMonitorStoreGetSlackWebhookActionFunc struct {
+	defaultHook func(context.Context, int64) (*database.SlackWebhookAction, error)
+	hooks       []func(context.Context, int64) (*database.SlackWebhookAction, error)
+	history     []CodeMonitorStoreGetSlackWebhookActionFuncCall
+	mutex       sync.Mutex
+}
+
+// GetSlackWebhookAction delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) GetSlackWebhookAction(v0 context.Context, v1 int64) (*database.SlackWebhookAction, error) {
+	r0, r1 := m.GetSlackWebhookActionFunc.nextHook()(v0, v1)
+	m.GetSlackWebhookActionFunc.appendCall(CodeMonitorStoreGetSlackWebhookActionFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetSlackWebhookAction method of the parent MockCodeMonitorStore instance
+// is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreGetSlackWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64) (*database.SlackWebhookAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetSlackWebhookAction method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreGetSlackWebhookActionFunc) PushHook(hook func(context.Context, int64) (*database.SlackWebhookAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreGetSlackWebhookActionFunc) SetDefaultReturn(r0 *database.SlackWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*database.SlackWebhookAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreGetQueryTriggerForMonitorFunc) PushReturn(r0 *database.QueryTrigger, r1 error) {
-	f.PushHook(func(context.Context, int64) (*database.QueryTrigger, error) {
+func (f *CodeMonitorStoreGetSlackWebhookActionFunc) PushReturn(r0 *database.SlackWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, int64) (*database.SlackWebhookAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreGetQueryTriggerForMonitorFunc) nextHook() func(context.Context, int64) (*database.QueryTrigger, error) {
+func (f *CodeMonitorStoreGetSlackWebhookActionFunc) nextHook() func(context.Context, int64) (*database.SlackWebhookAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -10516,28 +12062,28 @@ func (f *CodeMonitorStoreGetQueryTriggerForMonitorFunc) nextHook() func(context.
 	return hook
 }
 
-func (f *CodeMonitorStoreGetQueryTriggerForMonitorFunc) appendCall(r0 CodeMonitorStoreGetQueryTriggerForMonitorFuncCall) {
+func (f *CodeMonitorStoreGetSlackWebhookActionFunc) appendCall(r0 CodeMonitorStoreGetSlackWebhookActionFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
 // History returns a sequence of
-// CodeMonitorStoreGetQueryTriggerForMonitorFuncCall objects describing the
+// CodeMonitorStoreGetSlackWebhookActionFuncCall objects describing the
 // invocations of this function.
-func (f *CodeMonitorStoreGetQueryTriggerForMonitorFunc) History() []CodeMonitorStoreGetQueryTriggerForMonitorFuncCall {
+func (f *CodeMonitorStoreGetSlackWebhookActionFunc) History() []CodeMonitorStoreGetSlackWebhookActionFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreGetQueryTriggerForMonitorFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreGetSlackWebhookActionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreGetQueryTriggerForMonitorFuncCall is an object that
-// describes an invocation of method GetQueryTriggerForMonitor on an
-// instance of MockCodeMonitorStore.
-type CodeMonitorStoreGetQueryTriggerForMonitorFuncCall struct {
+// CodeMonitorStoreGetSlackWebhookActionFuncCall is an object that describes
+// an invocation of method GetSlackWebhookAction on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreGetSlackWebhookActionFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -10546,7 +12092,7 @@ type CodeMonitorStoreGetQueryTriggerForMonitorFuncCall struct {
 	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *database.QueryTrigger
+	Result0 *database.SlackWebhookAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -10554,47 +12100,47 @@ type CodeMonitorStoreGetQueryTriggerForMonitorFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreGetQueryTriggerForMonitorFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreGetSlackWebhookActionFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreGetQueryTriggerForMonitorFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreGetSlackWebhookActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreGetSlackWebhookActionFunc describes the behavior when the
-// GetSlackWebhookAction method of the parent MockCodeMonitorStore instance
+// CodeMonitorStoreGetTeamsWebhookActionFunc describes the behavior when the
+// GetTeamsWebhookAction method of the parent MockCodeMonitorStore instance
 // is invoked.
-type CodeMonitorStoreGetSlackWebhookActionFunc struct {
-	defaultHook func(context.Context, int64) (*database.SlackWebhookAction, error)
-	hooks       []func(context.Context, int64) (*database.SlackWebhookAction, error)
-	history     []CodeMonitorStoreGetSlackWebhookActionFuncCall
+type CodeMonitorStoreGetTeamsWebhookActionFunc struct {
+	defaultHook func(context.Context, int64) (*database.TeamsWebhookAction, error)
+	hooks       []func(context.Context, int64) (*database.TeamsWebhookAction, error)
+	history     []CodeMonitorStoreGetTeamsWebhookActionFuncCall
 	mutex       sync.Mutex
 }
 
-// GetSlackWebhookAction delegates to the next hook function in the queue
+// GetTeamsWebhookAction delegates to the next hook function in the queue
 // and stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) GetSlackWebhookAction(v0 context.Context, v1 int64) (*database.SlackWebhookAction, error) {
-	r0, r1 := m.GetSlackWebhookActionFunc.nextHook()(v0, v1)
-	m.GetSlackWebhookActionFunc.appendCall(CodeMonitorStoreGetSlackWebhookActionFuncCall{v0, v1, r0, r1})
+func (m *MockCodeMonitorStore) GetTeamsWebhookAction(v0 context.Context, v1 int64) (*database.TeamsWebhookAction, error) {
+	r0, r1 := m.GetTeamsWebhookActionFunc.nextHook()(v0, v1)
+	m.GetTeamsWebhookActionFunc.appendCall(CodeMonitorStoreGetTeamsWebhookActionFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the
-// GetSlackWebhookAction method of the parent MockCodeMonitorStore instance
+// GetTeamsWebhookAction method of the parent MockCodeMonitorStore instance
 // is invoked and the hook queue is empty.
-func (f *CodeMonitorStoreGetSlackWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64) (*database.SlackWebhookAction, error)) {
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64) (*database.TeamsWebhookAction, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetSlackWebhookAction method of the parent MockCodeMonitorStore instance
+// GetTeamsWebhookAction method of the parent MockCodeMonitorStore instance
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *CodeMonitorStoreGetSlackWebhookActionFunc) PushHook(hook func(context.Context, int64) (*database.SlackWebhookAction, error)) {
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) PushHook(hook func(context.Context, int64) (*database.TeamsWebhookAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -10602,20 +12148,20 @@ func (f *CodeMonitorStoreGetSlackWebhookActionFunc) PushHook(hook func(context.C
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreGetSlackWebhookActionFunc) SetDefaultReturn(r0 *database.SlackWebhookAction, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64) (*database.SlackWebhookAction, error) {
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) SetDefaultReturn(r0 *database.TeamsWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*database.TeamsWebhookAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreGetSlackWebhookActionFunc) PushReturn(r0 *database.SlackWebhookAction, r1 error) {
-	f.PushHook(func(context.Context, int64) (*database.SlackWebhookAction, error) {
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) PushReturn(r0 *database.TeamsWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, int64) (*database.TeamsWebhookAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreGetSlackWebhookActionFunc) nextHook() func(context.Context, int64) (*database.SlackWebhookAction, error) {
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) nextHook() func(context.Context, int64) (*database.TeamsWebhookAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -10628,28 +12174,28 @@ func (f *CodeMonitorStoreGetSlackWebhookActionFunc) nextHook() func(context.Cont
 	return hook
 }
 
-func (f *CodeMonitorStoreGetSlackWebhookActionFunc) appendCall(r0 CodeMonitorStoreGetSlackWebhookActionFuncCall) {
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) appendCall(r0 CodeMonitorStoreGetTeamsWebhookActionFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
 // History returns a sequence of
-// CodeMonitorStoreGetSlackWebhookActionFuncCall objects describing the
+// CodeMonitorStoreGetTeamsWebhookActionFuncCall objects describing the
 // invocations of this function.
-func (f *CodeMonitorStoreGetSlackWebhookActionFunc) History() []CodeMonitorStoreGetSlackWebhookActionFuncCall {
+func (f *CodeMonitorStoreGetTeamsWebhookActionFunc) History() []CodeMonitorStoreGetTeamsWebhookActionFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreGetSlackWebhookActionFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreGetTeamsWebhookActionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreGetSlackWebhookActionFuncCall is an object that describes
-// an invocation of method GetSlackWebhookAction on an instance of
+// CodeMonitorStoreGetTeamsWebhookActionFuncCall is an object that describes
+// an invocation of method GetTeamsWebhookAction on an instance of
 // MockCodeMonitorStore.
-type CodeMonitorStoreGetSlackWebhookActionFuncCall struct {
+type CodeMonitorStoreGetTeamsWebhookActionFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -10658,7 +12204,7 @@ type CodeMonitorStoreGetSlackWebhookActionFuncCall struct {
 	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *database.SlackWebhookAction
+	Result0 *database.TeamsWebhookAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -10666,13 +12212,13 @@ type CodeMonitorStoreGetSlackWebhookActionFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreGetSlackWebhookActionFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreGetTeamsWebhookActionFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreGetSlackWebhookActionFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreGetTeamsWebhookActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
@@ -11080,16 +12626,238 @@ func (f *CodeMonitorStoreListActionJobsFunc) History() []CodeMonitorStoreListAct
 // CodeMonitorStoreListActionJobsFuncCall is an object that describes an
 // invocation of method ListActionJobs on an instance of
 // MockCodeMonitorStore.
-type CodeMonitorStoreListActionJobsFuncCall struct {
+type CodeMonitorStoreListActionJobsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 database.ListActionJobsOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*database.ActionJob
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreListActionJobsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreListActionJobsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreListDigestResultsFunc describes the behavior when the
+// ListDigestResults method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreListDigestResultsFunc struct {
+	defaultHook func(context.Context, int32) ([]*result.CommitMatch, error)
+	hooks       []func(context.Context, int32) ([]*result.CommitMatch, error)
+	history     []CodeMonitorStoreListDigestResultsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListDigestResults delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ListDigestResults(v0 context.Context, v1 int32) ([]*result.CommitMatch, error) {
+	r0, r1 := m.ListDigestResultsFunc.nextHook()(v0, v1)
+	m.ListDigestResultsFunc.appendCall(CodeMonitorStoreListDigestResultsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListDigestResults
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreListDigestResultsFunc) SetDefaultHook(hook func(context.Context, int32) ([]*result.CommitMatch, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListDigestResults method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreListDigestResultsFunc) PushHook(hook func(context.Context, int32) ([]*result.CommitMatch, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreListDigestResultsFunc) SetDefaultReturn(r0 []*result.CommitMatch, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) ([]*result.CommitMatch, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreListDigestResultsFunc) PushReturn(r0 []*result.CommitMatch, r1 error) {
+	f.PushHook(func(context.Context, int32) ([]*result.CommitMatch, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreListDigestResultsFunc) nextHook() func(context.Context, int32) ([]*result.CommitMatch, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreListDigestResultsFunc) appendCall(r0 CodeMonitorStoreListDigestResultsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreListDigestResultsFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreListDigestResultsFunc) History() []CodeMonitorStoreListDigestResultsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreListDigestResultsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreListDigestResultsFuncCall is an object that describes an
+// invocation of method ListDigestResults on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreListDigestResultsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*result.CommitMatch
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreListDigestResultsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreListDigestResultsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreListEmailActionsFunc describes the behavior when the
+// ListEmailActions method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreListEmailActionsFunc struct {
+	defaultHook func(context.Context, database.ListActionsOpts) ([]*database.EmailAction, error)
+	hooks       []func(context.Context, database.ListActionsOpts) ([]*database.EmailAction, error)
+	history     []CodeMonitorStoreListEmailActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListEmailActions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ListEmailActions(v0 context.Context, v1 database.ListActionsOpts) ([]*database.EmailAction, error) {
+	r0, r1 := m.ListEmailActionsFunc.nextHook()(v0, v1)
+	m.ListEmailActionsFunc.appendCall(CodeMonitorStoreListEmailActionsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListEmailActions
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreListEmailActionsFunc) SetDefaultHook(hook func(context.Context, database.ListActionsOpts) ([]*database.EmailAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListEmailActions method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreListEmailActionsFunc) PushHook(hook func(context.Context, database.ListActionsOpts) ([]*database.EmailAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreListEmailActionsFunc) SetDefaultReturn(r0 []*database.EmailAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, database.ListActionsOpts) ([]*database.EmailAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreListEmailActionsFunc) PushReturn(r0 []*database.EmailAction, r1 error) {
+	f.PushHook(func(context.Context, database.ListActionsOpts) ([]*database.EmailAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreListEmailActionsFunc) nextHook() func(context.Context, database.ListActionsOpts) ([]*database.EmailAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreListEmailActionsFunc) appendCall(r0 CodeMonitorStoreListEmailActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreListEmailActionsFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreListEmailActionsFunc) History() []CodeMonitorStoreListEmailActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreListEmailActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreListEmailActionsFuncCall is an object that describes an
+// invocation of method ListEmailActions on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreListEmailActionsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 database.ListActionJobsOpts
+	Arg1 database.ListActionsOpts
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*database.ActionJob
+	Result0 []*database.EmailAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -11097,47 +12865,47 @@ type CodeMonitorStoreListActionJobsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreListActionJobsFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreListEmailActionsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreListActionJobsFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreListEmailActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreListEmailActionsFunc describes the behavior when the
-// ListEmailActions method of the parent MockCodeMonitorStore instance is
-// invoked.
-type CodeMonitorStoreListEmailActionsFunc struct {
-	defaultHook func(context.Context, database.ListActionsOpts) ([]*database.EmailAction, error)
-	hooks       []func(context.Context, database.ListActionsOpts) ([]*database.EmailAction, error)
-	history     []CodeMonitorStoreListEmailActionsFuncCall
+// CodeMonitorStoreListIncidentWebhookActionsFunc describes the behavior
+// when the ListIncidentWebhookActions method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreListIncidentWebhookActionsFunc struct {
+	defaultHook func(context.Context, database.ListActionsOpts) ([]*database.IncidentWebhookAction, error)
+	hooks       []func(context.Context, database.ListActionsOpts) ([]*database.IncidentWebhookAction, error)
+	history     []CodeMonitorStoreListIncidentWebhookActionsFuncCall
 	mutex       sync.Mutex
 }
 
-// ListEmailActions delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) ListEmailActions(v0 context.Context, v1 database.ListActionsOpts) ([]*database.EmailAction, error) {
-	r0, r1 := m.ListEmailActionsFunc.nextHook()(v0, v1)
-	m.ListEmailActionsFunc.appendCall(CodeMonitorStoreListEmailActionsFuncCall{v0, v1, r0, r1})
+// ListIncidentWebhookActions delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ListIncidentWebhookActions(v0 context.Context, v1 database.ListActionsOpts) ([]*database.IncidentWebhookAction, error) {
+	r0, r1 := m.ListIncidentWebhookActionsFunc.nextHook()(v0, v1)
+	m.ListIncidentWebhookActionsFunc.appendCall(CodeMonitorStoreListIncidentWebhookActionsFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the ListEmailActions
-// method of the parent MockCodeMonitorStore instance is invoked and the
-// hook queue is empty.
-func (f *CodeMonitorStoreListEmailActionsFunc) SetDefaultHook(hook func(context.Context, database.ListActionsOpts) ([]*database.EmailAction, error)) {
+// SetDefaultHook sets function that is called when the
+// ListIncidentWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreListIncidentWebhookActionsFunc) SetDefaultHook(hook func(context.Context, database.ListActionsOpts) ([]*database.IncidentWebhookAction, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListEmailActions method of the parent MockCodeMonitorStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *CodeMonitorStoreListEmailActionsFunc) PushHook(hook func(context.Context, database.ListActionsOpts) ([]*database.EmailAction, error)) {
+// ListIncidentWebhookActions method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreListIncidentWebhookActionsFunc) PushHook(hook func(context.Context, database.ListActionsOpts) ([]*database.IncidentWebhookAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -11145,20 +12913,20 @@ func (f *CodeMonitorStoreListEmailActionsFunc) PushHook(hook func(context.Contex
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreListEmailActionsFunc) SetDefaultReturn(r0 []*database.EmailAction, r1 error) {
-	f.SetDefaultHook(func(context.Context, database.ListActionsOpts) ([]*database.EmailAction, error) {
+func (f *CodeMonitorStoreListIncidentWebhookActionsFunc) SetDefaultReturn(r0 []*database.IncidentWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, database.ListActionsOpts) ([]*database.IncidentWebhookAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreListEmailActionsFunc) PushReturn(r0 []*database.EmailAction, r1 error) {
-	f.PushHook(func(context.Context, database.ListActionsOpts) ([]*database.EmailAction, error) {
+func (f *CodeMonitorStoreListIncidentWebhookActionsFunc) PushReturn(r0 []*database.IncidentWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, database.ListActionsOpts) ([]*database.IncidentWebhookAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreListEmailActionsFunc) nextHook() func(context.Context, database.ListActionsOpts) ([]*database.EmailAction, error) {
+func (f *CodeMonitorStoreListIncidentWebhookActionsFunc) nextHook() func(context.Context, database.ListActionsOpts) ([]*database.IncidentWebhookAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -11171,27 +12939,28 @@ func (f *CodeMonitorStoreListEmailActionsFunc) nextHook() func(context.Context,
 	return hook
 }
 
-func (f *CodeMonitorStoreListEmailActionsFunc) appendCall(r0 CodeMonitorStoreListEmailActionsFuncCall) {
+func (f *CodeMonitorStoreListIncidentWebhookActionsFunc) appendCall(r0 CodeMonitorStoreListIncidentWebhookActionsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreListEmailActionsFuncCall
-// objects describing the invocations of this function.
-func (f *CodeMonitorStoreListEmailActionsFunc) History() []CodeMonitorStoreListEmailActionsFuncCall {
+// History returns a sequence of
+// CodeMonitorStoreListIncidentWebhookActionsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreListIncidentWebhookActionsFunc) History() []CodeMonitorStoreListIncidentWebhookActionsFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreListEmailActionsFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreListIncidentWebhookActionsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreListEmailActionsFuncCall is an object that describes an
-// invocation of method ListEmailActions on an instance of
-// MockCodeMonitorStore.
-type CodeMonitorStoreListEmailActionsFuncCall struct {
+// CodeMonitorStoreListIncidentWebhookActionsFuncCall is an object that
+// describes an invocation of method ListIncidentWebhookActions on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreListIncidentWebhookActionsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -11200,7 +12969,7 @@ type CodeMonitorStoreListEmailActionsFuncCall struct {
 	Arg1 database.ListActionsOpts
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*database.EmailAction
+	Result0 []*database.IncidentWebhookAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -11208,13 +12977,13 @@ type CodeMonitorStoreListEmailActionsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreListEmailActionsFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreListIncidentWebhookActionsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreListEmailActionsFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreListIncidentWebhookActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
@@ -11661,6 +13430,118 @@ func (c CodeMonitorStoreListSlackWebhookActionsFuncCall) Results() []interface{}
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreListTeamsWebhookActionsFunc describes the behavior when
+// the ListTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreListTeamsWebhookActionsFunc struct {
+	defaultHook func(context.Context, database.ListActionsOpts) ([]*database.TeamsWebhookAction, error)
+	hooks       []func(context.Context, database.ListActionsOpts) ([]*database.TeamsWebhookAction, error)
+	history     []CodeMonitorStoreListTeamsWebhookActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListTeamsWebhookActions delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ListTeamsWebhookActions(v0 context.Context, v1 database.ListActionsOpts) ([]*database.TeamsWebhookAction, error) {
+	r0, r1 := m.ListTeamsWebhookActionsFunc.nextHook()(v0, v1)
+	m.ListTeamsWebhookActionsFunc.appendCall(CodeMonitorStoreListTeamsWebhookActionsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) SetDefaultHook(hook func(context.Context, database.ListActionsOpts) ([]*database.TeamsWebhookAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListTeamsWebhookActions method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) PushHook(hook func(context.Context, database.ListActionsOpts) ([]*database.TeamsWebhookAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) SetDefaultReturn(r0 []*database.TeamsWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, database.ListActionsOpts) ([]*database.TeamsWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) PushReturn(r0 []*database.TeamsWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, database.ListActionsOpts) ([]*database.TeamsWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) nextHook() func(context.Context, database.ListActionsOpts) ([]*database.TeamsWebhookAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) appendCall(r0 CodeMonitorStoreListTeamsWebhookActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreListTeamsWebhookActionsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreListTeamsWebhookActionsFunc) History() []CodeMonitorStoreListTeamsWebhookActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreListTeamsWebhookActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreListTeamsWebhookActionsFuncCall is an object that
+// describes an invocation of method ListTeamsWebhookActions on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreListTeamsWebhookActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 database.ListActionsOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*database.TeamsWebhookAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreListTeamsWebhookActionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreListTeamsWebhookActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreListWebhookActionsFunc describes the behavior when the
 // ListWebhookActions method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -12182,7 +14063,121 @@ type CodeMonitorStoreTransactFuncCall struct {
 	Arg0 context.Context
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 database.CodeMonitorStore
+	Result0 database.CodeMonitorStore
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreTransactFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreTransactFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreUpdateEmailActionFunc describes the behavior when the
+// UpdateEmailAction method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreUpdateEmailActionFunc struct {
+	defaultHook func(context.Context, int64, *database.EmailActionArgs) (*database.EmailAction, error)
+	hooks       []func(context.Context, int64, *database.EmailActionArgs) (*database.EmailAction, error)
+	history     []CodeMonitorStoreUpdateEmailActionFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateEmailAction delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpdateEmailAction(v0 context.Context, v1 int64, v2 *database.EmailActionArgs) (*database.EmailAction, error) {
+	r0, r1 := m.UpdateEmailActionFunc.nextHook()(v0, v1, v2)
+	m.UpdateEmailActionFunc.appendCall(CodeMonitorStoreUpdateEmailActionFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the UpdateEmailAction
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreUpdateEmailActionFunc) SetDefaultHook(hook func(context.Context, int64, *database.EmailActionArgs) (*database.EmailAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateEmailAction method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreUpdateEmailActionFunc) PushHook(hook func(context.Context, int64, *database.EmailActionArgs) (*database.EmailAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpdateEmailActionFunc) SetDefaultReturn(r0 *database.EmailAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, *database.EmailActionArgs) (*database.EmailAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpdateEmailActionFunc) PushReturn(r0 *database.EmailAction, r1 error) {
+	f.PushHook(func(context.Context, int64, *database.EmailActionArgs) (*database.EmailAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreUpdateEmailActionFunc) nextHook() func(context.Context, int64, *database.EmailActionArgs) (*database.EmailAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpdateEmailActionFunc) appendCall(r0 CodeMonitorStoreUpdateEmailActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreUpdateEmailActionFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreUpdateEmailActionFunc) History() []CodeMonitorStoreUpdateEmailActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpdateEmailActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpdateEmailActionFuncCall is an object that describes an
+// invocation of method UpdateEmailAction on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreUpdateEmailActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 *database.EmailActionArgs
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *database.EmailAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -12190,47 +14185,47 @@ type CodeMonitorStoreTransactFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreTransactFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c CodeMonitorStoreUpdateEmailActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreTransactFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreUpdateEmailActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreUpdateEmailActionFunc describes the behavior when the
-// UpdateEmailAction method of the parent MockCodeMonitorStore instance is
-// invoked.
-type CodeMonitorStoreUpdateEmailActionFunc struct {
-	defaultHook func(context.Context, int64, *database.EmailActionArgs) (*database.EmailAction, error)
-	hooks       []func(context.Context, int64, *database.EmailActionArgs) (*database.EmailAction, error)
-	history     []CodeMonitorStoreUpdateEmailActionFuncCall
+// CodeMonitorStoreUpdateIncidentWebhookActionFunc describes the behavior
+// when the UpdateIncidentWebhookAction method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreUpdateIncidentWebhookActionFunc struct {
+	defaultHook func(context.Context, int64, bool, string, string) (*database.IncidentWebhookAction, error)
+	hooks       []func(context.Context, int64, bool, string, string) (*database.IncidentWebhookAction, error)
+	history     []CodeMonitorStoreUpdateIncidentWebhookActionFuncCall
 	mutex       sync.Mutex
 }
 
-// UpdateEmailAction delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) UpdateEmailAction(v0 context.Context, v1 int64, v2 *database.EmailActionArgs) (*database.EmailAction, error) {
-	r0, r1 := m.UpdateEmailActionFunc.nextHook()(v0, v1, v2)
-	m.UpdateEmailActionFunc.appendCall(CodeMonitorStoreUpdateEmailActionFuncCall{v0, v1, v2, r0, r1})
+// UpdateIncidentWebhookAction delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpdateIncidentWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 string, v4 string) (*database.IncidentWebhookAction, error) {
+	r0, r1 := m.UpdateIncidentWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.UpdateIncidentWebhookActionFunc.appendCall(CodeMonitorStoreUpdateIncidentWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the UpdateEmailAction
-// method of the parent MockCodeMonitorStore instance is invoked and the
-// hook queue is empty.
-func (f *CodeMonitorStoreUpdateEmailActionFunc) SetDefaultHook(hook func(context.Context, int64, *database.EmailActionArgs) (*database.EmailAction, error)) {
+// SetDefaultHook sets function that is called when the
+// UpdateIncidentWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreUpdateIncidentWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, string, string) (*database.IncidentWebhookAction, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// UpdateEmailAction method of the parent MockCodeMonitorStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *CodeMonitorStoreUpdateEmailActionFunc) PushHook(hook func(context.Context, int64, *database.EmailActionArgs) (*database.EmailAction, error)) {
+// UpdateIncidentWebhookAction method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreUpdateIncidentWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, string, string) (*database.IncidentWebhookAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -12238,20 +14233,20 @@ func (f *CodeMonitorStoreUpdateEmailActionFunc) PushHook(hook func(context.Conte
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreUpdateEmailActionFunc) SetDefaultReturn(r0 *database.EmailAction, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64, *database.EmailActionArgs) (*database.EmailAction, error) {
+func (f *CodeMonitorStoreUpdateIncidentWebhookActionFunc) SetDefaultReturn(r0 *database.IncidentWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, string, string) (*database.IncidentWebhookAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreUpdateEmailActionFunc) PushReturn(r0 *database.EmailAction, r1 error) {
-	f.PushHook(func(context.Context, int64, *database.EmailActionArgs) (*database.EmailAction, error) {
+func (f *CodeMonitorStoreUpdateIncidentWebhookActionFunc) PushReturn(r0 *database.IncidentWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, int64, bool, string, string) (*database.IncidentWebhookAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreUpdateEmailActionFunc) nextHook() func(context.Context, int64, *database.EmailActionArgs) (*database.EmailAction, error) {
+func (f *CodeMonitorStoreUpdateIncidentWebhookActionFunc) nextHook() func(context.Context, int64, bool, string, string) (*database.IncidentWebhookAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -12264,27 +14259,28 @@ func (f *CodeMonitorStoreUpdateEmailActionFunc) nextHook() func(context.Context,
 	return hook
 }
 
-func (f *CodeMonitorStoreUpdateEmailActionFunc) appendCall(r0 CodeMonitorStoreUpdateEmailActionFuncCall) {
+func (f *CodeMonitorStoreUpdateIncidentWebhookActionFunc) appendCall(r0 CodeMonitorStoreUpdateIncidentWebhookActionFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreUpdateEmailActionFuncCall
-// objects describing the invocations of this function.
-func (f *CodeMonitorStoreUpdateEmailActionFunc) History() []CodeMonitorStoreUpdateEmailActionFuncCall {
+// History returns a sequence of
+// CodeMonitorStoreUpdateIncidentWebhookActionFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreUpdateIncidentWebhookActionFunc) History() []CodeMonitorStoreUpdateIncidentWebhookActionFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreUpdateEmailActionFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreUpdateIncidentWebhookActionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreUpdateEmailActionFuncCall is an object that describes an
-// invocation of method UpdateEmailAction on an instance of
-// MockCodeMonitorStore.
-type CodeMonitorStoreUpdateEmailActionFuncCall struct {
+// CodeMonitorStoreUpdateIncidentWebhookActionFuncCall is an object that
+// describes an invocation of method UpdateIncidentWebhookAction on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreUpdateIncidentWebhookActionFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -12293,10 +14289,16 @@ type CodeMonitorStoreUpdateEmailActionFuncCall struct {
 	Arg1 int64
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 *database.EmailActionArgs
+	Arg2 bool
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *database.EmailAction
+	Result0 *database.IncidentWebhookAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -12304,13 +14306,13 @@ type CodeMonitorStoreUpdateEmailActionFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreUpdateEmailActionFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c CodeMonitorStoreUpdateIncidentWebhookActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreUpdateEmailActionFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreUpdateIncidentWebhookActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
@@ -12657,24 +14659,24 @@ func (c CodeMonitorStoreUpdateQueryTriggerFuncCall) Results() []interface{} {
 // the UpdateSlackWebhookAction method of the parent MockCodeMonitorStore
 // instance is invoked.
 type CodeMonitorStoreUpdateSlackWebhookActionFunc struct {
-	defaultHook func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error)
-	hooks       []func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error)
+	defaultHook func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.SlackWebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.SlackWebhookAction, error)
 	history     []CodeMonitorStoreUpdateSlackWebhookActionFuncCall
 	mutex       sync.Mutex
 }
 
 // UpdateSlackWebhookAction delegates to the next hook function in the queue
 // and stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) UpdateSlackWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*database.SlackWebhookAction, error) {
-	r0, r1 := m.UpdateSlackWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.UpdateSlackWebhookActionFunc.appendCall(CodeMonitorStoreUpdateSlackWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+func (m *MockCodeMonitorStore) UpdateSlackWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string, v5 *database.ActionDigest) (*database.SlackWebhookAction, error) {
+	r0, r1 := m.UpdateSlackWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.UpdateSlackWebhookActionFunc.appendCall(CodeMonitorStoreUpdateSlackWebhookActionFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
 	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the
 // UpdateSlackWebhookAction method of the parent MockCodeMonitorStore
 // instance is invoked and the hook queue is empty.
-func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error)) {
+func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.SlackWebhookAction, error)) {
 	f.defaultHook = hook
 }
 
@@ -12683,7 +14685,7 @@ func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) SetDefaultHook(hook func(
 // instance invokes the hook at the front of the queue and discards it.
 // After the queue is empty, the default hook function is invoked for any
 // future action.
-func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error)) {
+func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.SlackWebhookAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -12692,19 +14694,19 @@ func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) PushHook(hook func(contex
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) SetDefaultReturn(r0 *database.SlackWebhookAction, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.SlackWebhookAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) PushReturn(r0 *database.SlackWebhookAction, r1 error) {
-	f.PushHook(func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error) {
+	f.PushHook(func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.SlackWebhookAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string) (*database.SlackWebhookAction, error) {
+func (f *CodeMonitorStoreUpdateSlackWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.SlackWebhookAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -12754,6 +14756,9 @@ type CodeMonitorStoreUpdateSlackWebhookActionFuncCall struct {
 	// Arg4 is the value of the 5th argument passed to this method
 	// invocation.
 	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 *database.ActionDigest
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 *database.SlackWebhookAction
@@ -12765,7 +14770,7 @@ type CodeMonitorStoreUpdateSlackWebhookActionFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c CodeMonitorStoreUpdateSlackWebhookActionFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
 }
 
 // Results returns an interface slice containing the results of this
@@ -12774,6 +14779,127 @@ func (c CodeMonitorStoreUpdateSlackWebhookActionFuncCall) Results() []interface{
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreUpdateTeamsWebhookActionFunc describes the behavior when
+// the UpdateTeamsWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreUpdateTeamsWebhookActionFunc struct {
+	defaultHook func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error)
+	history     []CodeMonitorStoreUpdateTeamsWebhookActionFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateTeamsWebhookAction delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpdateTeamsWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*database.TeamsWebhookAction, error) {
+	r0, r1 := m.UpdateTeamsWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.UpdateTeamsWebhookActionFunc.appendCall(CodeMonitorStoreUpdateTeamsWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateTeamsWebhookAction method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateTeamsWebhookAction method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) SetDefaultReturn(r0 *database.TeamsWebhookAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) PushReturn(r0 *database.TeamsWebhookAction, r1 error) {
+	f.PushHook(func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string) (*database.TeamsWebhookAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) appendCall(r0 CodeMonitorStoreUpdateTeamsWebhookActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreUpdateTeamsWebhookActionFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreUpdateTeamsWebhookActionFunc) History() []CodeMonitorStoreUpdateTeamsWebhookActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpdateTeamsWebhookActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpdateTeamsWebhookActionFuncCall is an object that
+// describes an invocation of method UpdateTeamsWebhookAction on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreUpdateTeamsWebhookActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *database.TeamsWebhookAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpdateTeamsWebhookActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpdateTeamsWebhookActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreUpdateTriggerJobWithResultsFunc describes the behavior
 // when the UpdateTriggerJobWithResults method of the parent
 // MockCodeMonitorStore instance is invoked.
@@ -12893,24 +15019,24 @@ func (c CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall) Results() []interfa
 // UpdateWebhookAction method of the parent MockCodeMonitorStore instance is
 // invoked.
 type CodeMonitorStoreUpdateWebhookActionFunc struct {
-	defaultHook func(context.Context, int64, bool, bool, string) (*database.WebhookAction, error)
-	hooks       []func(context.Context, int64, bool, bool, string) (*database.WebhookAction, error)
+	defaultHook func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.WebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.WebhookAction, error)
 	history     []CodeMonitorStoreUpdateWebhookActionFuncCall
 	mutex       sync.Mutex
 }
 
 // UpdateWebhookAction delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) UpdateWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*database.WebhookAction, error) {
-	r0, r1 := m.UpdateWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.UpdateWebhookActionFunc.appendCall(CodeMonitorStoreUpdateWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+func (m *MockCodeMonitorStore) UpdateWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string, v5 *database.ActionDigest) (*database.WebhookAction, error) {
+	r0, r1 := m.UpdateWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.UpdateWebhookActionFunc.appendCall(CodeMonitorStoreUpdateWebhookActionFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
 	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the UpdateWebhookAction
 // method of the parent MockCodeMonitorStore instance is invoked and the
 // hook queue is empty.
-func (f *CodeMonitorStoreUpdateWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*database.WebhookAction, error)) {
+func (f *CodeMonitorStoreUpdateWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.WebhookAction, error)) {
 	f.defaultHook = hook
 }
 
@@ -12919,7 +15045,7 @@ func (f *CodeMonitorStoreUpdateWebhookActionFunc) SetDefaultHook(hook func(conte
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *CodeMonitorStoreUpdateWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*database.WebhookAction, error)) {
+func (f *CodeMonitorStoreUpdateWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.WebhookAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -12928,19 +15054,19 @@ func (f *CodeMonitorStoreUpdateWebhookActionFunc) PushHook(hook func(context.Con
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *CodeMonitorStoreUpdateWebhookActionFunc) SetDefaultReturn(r0 *database.WebhookAction, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64, bool, bool, string) (*database.WebhookAction, error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.WebhookAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *CodeMonitorStoreUpdateWebhookActionFunc) PushReturn(r0 *database.WebhookAction, r1 error) {
-	f.PushHook(func(context.Context, int64, bool, bool, string) (*database.WebhookAction, error) {
+	f.PushHook(func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.WebhookAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreUpdateWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string) (*database.WebhookAction, error) {
+func (f *CodeMonitorStoreUpdateWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string, *database.ActionDigest) (*database.WebhookAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -12989,6 +15115,9 @@ type CodeMonitorStoreUpdateWebhookActionFuncCall struct {
 	// Arg4 is the value of the 5th argument passed to this method
 	// invocation.
 	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 *database.ActionDigest
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 *database.WebhookAction
@@ -13000,7 +15129,7 @@ type CodeMonitorStoreUpdateWebhookActionFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c CodeMonitorStoreUpdateWebhookActionFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
 }
 
 // Results returns an interface slice containing the results of this
@@ -13009,6 +15138,120 @@ func (c CodeMonitorStoreUpdateWebhookActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreUpsertLastMatchedFunc describes the behavior when the
+// UpsertLastMatched method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreUpsertLastMatchedFunc struct {
+	defaultHook func(context.Context, int64, api.RepoID, []string) error
+	hooks       []func(context.Context, int64, api.RepoID, []string) error
+	history     []CodeMonitorStoreUpsertLastMatchedFuncCall
+	mutex       sync.Mutex
+}
+
+// UpsertLastMatched delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpsertLastMatched(v0 context.Context, v1 int64, v2 api.RepoID, v3 []string) error {
+	r0 := m.UpsertLastMatchedFunc.nextHook()(v0, v1, v2, v3)
+	m.UpsertLastMatchedFunc.appendCall(CodeMonitorStoreUpsertLastMatchedFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the UpsertLastMatched
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreUpsertLastMatchedFunc) SetDefaultHook(hook func(context.Context, int64, api.RepoID, []string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpsertLastMatched method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreUpsertLastMatchedFunc) PushHook(hook func(context.Context, int64, api.RepoID, []string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpsertLastMatchedFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, api.RepoID, []string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpsertLastMatchedFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, api.RepoID, []string) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreUpsertLastMatchedFunc) nextHook() func(context.Context, int64, api.RepoID, []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpsertLastMatchedFunc) appendCall(r0 CodeMonitorStoreUpsertLastMatchedFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreUpsertLastMatchedFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreUpsertLastMatchedFunc) History() []CodeMonitorStoreUpsertLastMatchedFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpsertLastMatchedFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpsertLastMatchedFuncCall is an object that describes an
+// invocation of method UpsertLastMatched on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreUpsertLastMatchedFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoID
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpsertLastMatchedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpsertLastMatchedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // CodeMonitorStoreUpsertLastSearchedFunc describes the behavior when the
 // UpsertLastSearched method of the parent MockCodeMonitorStore instance is
 // invoked.
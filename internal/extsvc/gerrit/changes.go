@@ -243,6 +243,37 @@ func (c *client) GetChangeReviews(ctx context.Context, changeID string) (*[]Revi
 	return &reviewers, nil
 }
 
+// AddChangeReviewer adds a reviewer to a Gerrit change. The reviewer can be an
+// account, given by its username or email, or a group.
+func (c *client) AddChangeReviewer(ctx context.Context, changeID string, input AddReviewerPayload) error {
+	pathStr, err := url.JoinPath("a/changes", url.PathEscape(changeID), "reviewers")
+	if err != nil {
+		return err
+	}
+	reqURL := url.URL{Path: pathStr}
+	data, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", reqURL.String(), bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errors.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
 // MoveChange moves a Gerrit change to a different destination branch.
 func (c *client) MoveChange(ctx context.Context, changeID string, input MoveChangePayload) (*Change, error) {
 
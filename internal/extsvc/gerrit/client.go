@@ -48,6 +48,7 @@ type Client interface {
 	RestoreChange(ctx context.Context, changeID string) (*Change, error)
 	WriteReviewComment(ctx context.Context, changeID string, comment ChangeReviewComment) error
 	GetChangeReviews(ctx context.Context, changeID string) (*[]Reviewer, error)
+	AddChangeReviewer(ctx context.Context, changeID string, input AddReviewerPayload) error
 	SetWIP(ctx context.Context, changeID string) error
 	SetReadyForReview(ctx context.Context, changeID string) error
 	MoveChange(ctx context.Context, changeID string, input MoveChangePayload) (*Change, error)
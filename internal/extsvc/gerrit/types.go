@@ -164,6 +164,10 @@ type SetCommitMessagePayload struct {
 	Message string `json:"message"`
 }
 
+type AddReviewerPayload struct {
+	Reviewer string `json:"reviewer"`
+}
+
 type Pagination struct {
 	PerPage int
 	// Either Skip or Page should be set. If Skip is non-zero, it takes precedence.
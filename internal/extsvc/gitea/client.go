@@ -0,0 +1,354 @@
+//nolint:bodyclose // Body is closed in Client.do, but the response is still returned to provide access to the headers
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// DefaultPerPage is the page size requested from list endpoints. Gitea caps
+// page sizes at the instance's MAX_RESPONSE_ITEMS setting, which defaults to
+// 50.
+const DefaultPerPage = 50
+
+// ErrPullRequestAlreadyExists is returned by CreatePullRequest if a pull
+// request between the given head and base already exists.
+var ErrPullRequestAlreadyExists = errors.New("pull request already exists")
+
+// ErrPullRequestNotMergeable is returned by MergePullRequest if Gitea refuses
+// to merge the pull request, for example because it has conflicts or required
+// checks haven't passed yet.
+var ErrPullRequestNotMergeable = errors.New("pull request is not mergeable")
+
+// Client accesses a Gitea or Forgejo instance via its REST API.
+type Client struct {
+	// URL is the base URL of the Gitea instance.
+	URL *url.URL
+
+	urn        string
+	httpClient httpcli.Doer
+	auth       auth.Authenticator
+
+	// rateLimit is the self-imposed rate limiter (since Gitea does not expose
+	// rate limits in HTTP response headers).
+	rateLimit *ratelimit.InstrumentedLimiter
+}
+
+// NewClient returns a Gitea API client for the instance at baseURL,
+// authenticated with the given authenticator. If a nil httpClient is provided,
+// httpcli.ExternalDoer will be used.
+func NewClient(urn string, baseURL *url.URL, a auth.Authenticator, httpClient httpcli.Doer) (*Client, error) {
+	if httpClient == nil {
+		httpClient = httpcli.ExternalDoer
+	}
+
+	return &Client{
+		URL:        baseURL,
+		urn:        urn,
+		httpClient: httpClient,
+		auth:       a,
+		rateLimit:  ratelimit.NewInstrumentedLimiter(urn, ratelimit.NewGlobalRateLimiter(log.Scoped("GiteaClient"), urn)),
+	}, nil
+}
+
+// WithAuthenticator returns a new Client that uses the same configuration,
+// HTTP client, and rate limiter as the current Client, except authenticated
+// with the given authenticator. Only OAuth bearer tokens (which includes Gitea
+// access tokens) are supported.
+func (c *Client) WithAuthenticator(a auth.Authenticator) (*Client, error) {
+	switch a.(type) {
+	case *auth.OAuthBearerToken, *auth.OAuthBearerTokenWithSSH:
+		break
+	default:
+		return nil, errors.Errorf("authenticator type unsupported for Gitea clients: %T", a)
+	}
+
+	return &Client{
+		URL:        c.URL,
+		urn:        c.urn,
+		httpClient: c.httpClient,
+		auth:       a,
+		rateLimit:  c.rateLimit,
+	}, nil
+}
+
+// Authenticator returns the authenticator used by the client.
+func (c *Client) Authenticator() auth.Authenticator {
+	return c.auth
+}
+
+// GetAuthenticatedUser returns the user the client is authenticated as.
+func (c *Client) GetAuthenticatedUser(ctx context.Context) (*User, error) {
+	var user User
+	if _, err := c.get(ctx, "user", nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// ListUserRepositories returns a page of the repositories the authenticated
+// user has access to, and whether there are more pages. Pages start at 1.
+func (c *Client) ListUserRepositories(ctx context.Context, page int) ([]*Repository, bool, error) {
+	return c.listRepositories(ctx, "user/repos", page)
+}
+
+// ListOrgRepositories returns a page of the repositories of the given
+// organization, and whether there are more pages. Pages start at 1.
+func (c *Client) ListOrgRepositories(ctx context.Context, org string, page int) ([]*Repository, bool, error) {
+	return c.listRepositories(ctx, "orgs/"+url.PathEscape(org)+"/repos", page)
+}
+
+func (c *Client) listRepositories(ctx context.Context, path string, page int) ([]*Repository, bool, error) {
+	qs := make(url.Values)
+	qs.Set("page", strconv.Itoa(page))
+	qs.Set("limit", strconv.Itoa(DefaultPerPage))
+
+	var repos []*Repository
+	resp, err := c.get(ctx, path, qs, &repos)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return repos, hasNextPage(resp.Header), nil
+}
+
+// GetRepository returns the repository with the given owner and name.
+func (c *Client) GetRepository(ctx context.Context, owner, name string) (*Repository, error) {
+	var repo Repository
+	if _, err := c.get(ctx, repoPath(owner, name), nil, &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// ForkRepository forks the given repository. Gitea creates forks
+// synchronously, so the returned repository can be pushed to immediately.
+func (c *Client) ForkRepository(ctx context.Context, owner, name string, input ForkRepositoryInput) (*Repository, error) {
+	var fork Repository
+	if _, err := c.send(ctx, http.MethodPost, repoPath(owner, name)+"/forks", input, &fork); err != nil {
+		return nil, err
+	}
+	return &fork, nil
+}
+
+// DeleteBranch deletes the given branch of the given repository.
+func (c *Client) DeleteBranch(ctx context.Context, owner, name, branch string) error {
+	_, err := c.send(ctx, http.MethodDelete, repoPath(owner, name)+"/branches/"+url.PathEscape(branch), nil, nil)
+	return err
+}
+
+// CreatePullRequest opens a new pull request on the given repository. If a
+// pull request for the same head and base already exists,
+// ErrPullRequestAlreadyExists is returned.
+func (c *Client) CreatePullRequest(ctx context.Context, owner, name string, input CreatePullRequestInput) (*PullRequest, error) {
+	var pr PullRequest
+	if _, err := c.send(ctx, http.MethodPost, repoPath(owner, name)+"/pulls", input, &pr); err != nil {
+		var e *HTTPError
+		if errors.As(err, &e) && e.StatusCode == http.StatusConflict {
+			return nil, ErrPullRequestAlreadyExists
+		}
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// GetPullRequest returns the pull request with the given number.
+func (c *Client) GetPullRequest(ctx context.Context, owner, name string, number int64) (*PullRequest, error) {
+	var pr PullRequest
+	if _, err := c.get(ctx, pullPath(owner, name, number), nil, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// GetPullRequestByBaseHead returns the pull request that merges head into
+// base. Head has to be given as "owner:branch" for pull requests opened from a
+// fork.
+func (c *Client) GetPullRequestByBaseHead(ctx context.Context, owner, name, base, head string) (*PullRequest, error) {
+	var pr PullRequest
+	if _, err := c.get(ctx, repoPath(owner, name)+"/pulls/"+url.PathEscape(base)+"/"+url.PathEscape(head), nil, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// EditPullRequest updates the given pull request and returns it.
+func (c *Client) EditPullRequest(ctx context.Context, owner, name string, number int64, input EditPullRequestInput) (*PullRequest, error) {
+	var pr PullRequest
+	if _, err := c.send(ctx, http.MethodPatch, pullPath(owner, name, number), input, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// MergePullRequest merges the given pull request. If Gitea refuses to merge
+// it, an error wrapping ErrPullRequestNotMergeable is returned.
+func (c *Client) MergePullRequest(ctx context.Context, owner, name string, number int64, input MergePullRequestInput) error {
+	if _, err := c.send(ctx, http.MethodPost, pullPath(owner, name, number)+"/merge", input, nil); err != nil {
+		var e *HTTPError
+		if errors.As(err, &e) && (e.StatusCode == http.StatusMethodNotAllowed || e.StatusCode == http.StatusConflict) {
+			return errors.Wrap(ErrPullRequestNotMergeable, string(e.Body))
+		}
+		return err
+	}
+	return nil
+}
+
+// ListPullReviews returns the reviews submitted or requested on the given
+// pull request.
+func (c *Client) ListPullReviews(ctx context.Context, owner, name string, number int64) ([]*PullReview, error) {
+	var reviews []*PullReview
+	for page := 1; ; page++ {
+		qs := make(url.Values)
+		qs.Set("page", strconv.Itoa(page))
+		qs.Set("limit", strconv.Itoa(DefaultPerPage))
+
+		var pageReviews []*PullReview
+		resp, err := c.get(ctx, pullPath(owner, name, number)+"/reviews", qs, &pageReviews)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, pageReviews...)
+
+		if !hasNextPage(resp.Header) {
+			return reviews, nil
+		}
+	}
+}
+
+// RequestReviewers requests reviews on the given pull request from the given
+// users and teams.
+func (c *Client) RequestReviewers(ctx context.Context, owner, name string, number int64, input RequestReviewersInput) error {
+	_, err := c.send(ctx, http.MethodPost, pullPath(owner, name, number)+"/requested_reviewers", input, nil)
+	return err
+}
+
+// GetCombinedStatus returns the combined commit status of the given ref.
+func (c *Client) GetCombinedStatus(ctx context.Context, owner, name, ref string) (*CombinedStatus, error) {
+	var status CombinedStatus
+	if _, err := c.get(ctx, repoPath(owner, name)+"/commits/"+url.PathEscape(ref)+"/status", nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// CreateIssueComment posts a comment on the given issue or pull request.
+func (c *Client) CreateIssueComment(ctx context.Context, owner, name string, number int64, body string) error {
+	path := repoPath(owner, name) + "/issues/" + strconv.FormatInt(number, 10) + "/comments"
+	_, err := c.send(ctx, http.MethodPost, path, struct {
+		Body string `json:"body"`
+	}{Body: body}, nil)
+	return err
+}
+
+func (c *Client) get(ctx context.Context, path string, qs url.Values, result any) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.apiURL(path, qs), nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, result)
+}
+
+func (c *Client) send(ctx context.Context, method, path string, payload, result any) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		bs, err := json.Marshal(payload)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshalling request")
+		}
+		body = bytes.NewReader(bs)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.apiURL(path, nil), body)
+	if err != nil {
+		return nil, err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.do(ctx, req, result)
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.Response, error) {
+	req.Header.Set("Accept", "application/json")
+
+	if c.auth != nil {
+		if err := c.auth.Authenticate(req); err != nil {
+			return nil, errors.Wrap(err, "authenticating request")
+		}
+	}
+
+	if err := c.rateLimit.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, errors.WithStack(&HTTPError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       bs,
+		})
+	}
+
+	if result == nil || len(bs) == 0 {
+		return resp, nil
+	}
+	return resp, json.Unmarshal(bs, result)
+}
+
+// apiURL returns the URL of the given API endpoint. The path is expected to
+// be escaped already, so that path segments containing slashes (such as
+// branch names) survive.
+func (c *Client) apiURL(path string, qs url.Values) string {
+	u := strings.TrimSuffix(c.URL.String(), "/") + "/api/v1/" + path
+	if len(qs) > 0 {
+		u += "?" + qs.Encode()
+	}
+	return u
+}
+
+// hasNextPage returns true if the Link header of a list response contains a
+// link to the next page.
+func hasNextPage(h http.Header) bool {
+	for _, link := range h.Values("Link") {
+		for _, part := range strings.Split(link, ",") {
+			if strings.Contains(part, `rel="next"`) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func repoPath(owner, name string) string {
+	return "repos/" + url.PathEscape(owner) + "/" + url.PathEscape(name)
+}
+
+func pullPath(owner, name string, number int64) string {
+	return repoPath(owner, name) + "/pulls/" + strconv.FormatInt(number, 10)
+}
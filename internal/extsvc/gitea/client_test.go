@@ -0,0 +1,147 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func newTestClient(t *testing.T, h http.Handler) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL + "/gitea")
+	require.NoError(t, err)
+
+	cli, err := NewClient("urn", u, &auth.OAuthBearerToken{Token: "secret"}, httpcli.TestExternalDoer)
+	require.NoError(t, err)
+	cli.rateLimit = ratelimit.NewInstrumentedLimiter("gitea", rate.NewLimiter(100, 10))
+
+	return cli
+}
+
+func TestClient_ListOrgRepositories(t *testing.T) {
+	cli := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/gitea/api/v1/orgs/sourcegraph/repos", r.URL.Path)
+		assert.Equal(t, "50", r.URL.Query().Get("limit"))
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		switch r.URL.Query().Get("page") {
+		case "1":
+			w.Header().Set("Link", `<https://gitea.test/api/v1/orgs/sourcegraph/repos?page=2>; rel="next",<https://gitea.test/api/v1/orgs/sourcegraph/repos?page=2>; rel="last"`)
+			w.Write([]byte(`[{"id":1,"name":"a","full_name":"sourcegraph/a","owner":{"login":"sourcegraph"}}]`))
+		default:
+			w.Write([]byte(`[{"id":2,"name":"b","full_name":"sourcegraph/b","owner":{"login":"sourcegraph"},"fork":true}]`))
+		}
+	}))
+
+	repos, next, err := cli.ListOrgRepositories(context.Background(), "sourcegraph", 1)
+	require.NoError(t, err)
+	assert.True(t, next)
+	require.Len(t, repos, 1)
+	assert.Equal(t, "sourcegraph/a", repos[0].FullName)
+	assert.Equal(t, "sourcegraph", repos[0].Namespace())
+
+	repos, next, err = cli.ListOrgRepositories(context.Background(), "sourcegraph", 2)
+	require.NoError(t, err)
+	assert.False(t, next)
+	require.Len(t, repos, 1)
+	assert.True(t, repos[0].Fork)
+}
+
+func TestClient_PullRequests(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/gitea/api/v1/repos/owner/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		var input CreatePullRequestInput
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		if input.Head == "existing" {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"message":"pull request already exists for these targets"}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(PullRequest{Number: 7, Title: input.Title, State: PullRequestStateOpen})
+	})
+	mux.HandleFunc("/gitea/api/v1/repos/owner/repo/pulls/main/fork:feature/x", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/gitea/api/v1/repos/owner/repo/pulls/main/fork:feature%2Fx", r.URL.EscapedPath())
+		json.NewEncoder(w).Encode(PullRequest{Number: 8})
+	})
+	mux.HandleFunc("/gitea/api/v1/repos/owner/repo/pulls/7", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"state":"closed"}`, string(body))
+		json.NewEncoder(w).Encode(PullRequest{Number: 7, State: PullRequestStateClosed})
+	})
+	mux.HandleFunc("/gitea/api/v1/repos/owner/repo/pulls/7/requested_reviewers", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"reviewers":["alice"],"team_reviewers":["owners"]}`, string(body))
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`[]`))
+	})
+	mux.HandleFunc("/gitea/api/v1/repos/owner/repo/pulls/7/merge", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		w.Write([]byte(`{"message":"Please try again later"}`))
+	})
+
+	cli := newTestClient(t, mux)
+	ctx := context.Background()
+
+	pr, err := cli.CreatePullRequest(ctx, "owner", "repo", CreatePullRequestInput{Head: "feature", Base: "main", Title: "Hello"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), pr.Number)
+	assert.Equal(t, "Hello", pr.Title)
+
+	_, err = cli.CreatePullRequest(ctx, "owner", "repo", CreatePullRequestInput{Head: "existing", Base: "main"})
+	assert.ErrorIs(t, err, ErrPullRequestAlreadyExists)
+
+	pr, err = cli.GetPullRequestByBaseHead(ctx, "owner", "repo", "main", "fork:feature/x")
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), pr.Number)
+
+	closed := PullRequestStateClosed
+	pr, err = cli.EditPullRequest(ctx, "owner", "repo", 7, EditPullRequestInput{State: &closed})
+	require.NoError(t, err)
+	assert.Equal(t, PullRequestStateClosed, pr.State)
+
+	err = cli.RequestReviewers(ctx, "owner", "repo", 7, RequestReviewersInput{Reviewers: []string{"alice"}, TeamReviewers: []string{"owners"}})
+	require.NoError(t, err)
+
+	err = cli.MergePullRequest(ctx, "owner", "repo", 7, MergePullRequestInput{Do: MergeStyleSquash})
+	assert.True(t, errors.Is(err, ErrPullRequestNotMergeable))
+
+	_, err = cli.GetPullRequest(ctx, "owner", "repo", 9)
+	assert.True(t, errcode.IsNotFound(err))
+}
+
+func TestClient_WithAuthenticator(t *testing.T) {
+	cli := newTestClient(t, http.NotFoundHandler())
+
+	_, err := cli.WithAuthenticator(&auth.BasicAuth{Username: "u", Password: "p"})
+	assert.Error(t, err)
+
+	other, err := cli.WithAuthenticator(&auth.OAuthBearerToken{Token: "other"})
+	require.NoError(t, err)
+	assert.Equal(t, &auth.OAuthBearerToken{Token: "other"}, other.Authenticator())
+	assert.Equal(t, &auth.OAuthBearerToken{Token: "secret"}, cli.Authenticator())
+}
@@ -0,0 +1,221 @@
+package gitea
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// User is a Gitea user or organization account.
+type User struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	FullName  string `json:"full_name"`
+	Email     string `json:"email"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// Repository is a Gitea repository, as returned by the repository API
+// endpoints.
+type Repository struct {
+	ID            int64       `json:"id"`
+	Owner         *User       `json:"owner"`
+	Name          string      `json:"name"`
+	FullName      string      `json:"full_name"`
+	Description   string      `json:"description"`
+	Empty         bool        `json:"empty"`
+	Private       bool        `json:"private"`
+	Fork          bool        `json:"fork"`
+	Parent        *Repository `json:"parent,omitempty"`
+	Mirror        bool        `json:"mirror"`
+	Archived      bool        `json:"archived"`
+	HTMLURL       string      `json:"html_url"`
+	SSHURL        string      `json:"ssh_url"`
+	CloneURL      string      `json:"clone_url"`
+	DefaultBranch string      `json:"default_branch"`
+	Stars         int         `json:"stars_count"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+// Namespace returns the login of the user or organization owning the
+// repository.
+func (r *Repository) Namespace() string {
+	if r.Owner == nil {
+		return ""
+	}
+	return r.Owner.Login
+}
+
+// Label is a label that can be attached to issues and pull requests.
+type Label struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+// PullRequestState is the state of a pull request. Merged pull requests are
+// closed, and have HasMerged set.
+type PullRequestState string
+
+const (
+	PullRequestStateOpen   PullRequestState = "open"
+	PullRequestStateClosed PullRequestState = "closed"
+)
+
+// PullRequestBranch describes the head or base of a pull request.
+type PullRequestBranch struct {
+	Label      string      `json:"label"`
+	Ref        string      `json:"ref"`
+	SHA        string      `json:"sha"`
+	RepoID     int64       `json:"repo_id"`
+	Repository *Repository `json:"repo"`
+}
+
+// PullRequest is a Gitea pull request.
+type PullRequest struct {
+	ID             int64              `json:"id"`
+	Number         int64              `json:"number"`
+	User           *User              `json:"user"`
+	Title          string             `json:"title"`
+	Body           string             `json:"body"`
+	Labels         []*Label           `json:"labels"`
+	State          PullRequestState   `json:"state"`
+	Mergeable      bool               `json:"mergeable"`
+	HasMerged      bool               `json:"merged"`
+	MergedAt       *time.Time         `json:"merged_at"`
+	MergeCommitSHA *string            `json:"merge_commit_sha"`
+	HTMLURL        string             `json:"html_url"`
+	Head           *PullRequestBranch `json:"head"`
+	Base           *PullRequestBranch `json:"base"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+	ClosedAt       *time.Time         `json:"closed_at"`
+}
+
+// ReviewState is the state of a single pull request review.
+type ReviewState string
+
+const (
+	ReviewStateApproved       ReviewState = "APPROVED"
+	ReviewStatePending        ReviewState = "PENDING"
+	ReviewStateComment        ReviewState = "COMMENT"
+	ReviewStateRequestChanges ReviewState = "REQUEST_CHANGES"
+	ReviewStateRequestReview  ReviewState = "REQUEST_REVIEW"
+)
+
+// PullReview is a review submitted (or requested) on a pull request.
+type PullReview struct {
+	ID          int64       `json:"id"`
+	Reviewer    *User       `json:"user"`
+	State       ReviewState `json:"state"`
+	Body        string      `json:"body"`
+	CommitID    string      `json:"commit_id"`
+	Stale       bool        `json:"stale"`
+	Official    bool        `json:"official"`
+	Dismissed   bool        `json:"dismissed"`
+	SubmittedAt time.Time   `json:"submitted_at"`
+}
+
+// CommitStatusState is the state of a commit status, or the combined state of
+// all statuses of a commit.
+type CommitStatusState string
+
+const (
+	CommitStatusPending CommitStatusState = "pending"
+	CommitStatusSuccess CommitStatusState = "success"
+	CommitStatusError   CommitStatusState = "error"
+	CommitStatusFailure CommitStatusState = "failure"
+	CommitStatusWarning CommitStatusState = "warning"
+)
+
+// CommitStatus is a status reported by a CI system against a commit.
+type CommitStatus struct {
+	ID          int64             `json:"id"`
+	State       CommitStatusState `json:"status"`
+	TargetURL   string            `json:"target_url"`
+	Description string            `json:"description"`
+	Context     string            `json:"context"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// CombinedStatus is the combined status of all commit statuses of a commit.
+type CombinedStatus struct {
+	State      CommitStatusState `json:"state"`
+	SHA        string            `json:"sha"`
+	TotalCount int               `json:"total_count"`
+	Statuses   []*CommitStatus   `json:"statuses"`
+}
+
+// CreatePullRequestInput is the payload used to open a pull request. When the
+// pull request is opened from a fork, Head has to be given as "owner:branch".
+type CreatePullRequestInput struct {
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// EditPullRequestInput is the payload used to update a pull request. Fields
+// that are nil or empty are left untouched.
+type EditPullRequestInput struct {
+	Title *string           `json:"title,omitempty"`
+	Body  *string           `json:"body,omitempty"`
+	Base  string            `json:"base,omitempty"`
+	State *PullRequestState `json:"state,omitempty"`
+}
+
+// RequestReviewersInput is the payload used to request reviews on a pull
+// request. Teams are given by their name within the organization that owns
+// the repository.
+type RequestReviewersInput struct {
+	Reviewers     []string `json:"reviewers,omitempty"`
+	TeamReviewers []string `json:"team_reviewers,omitempty"`
+}
+
+// MergeStyle is the strategy used to merge a pull request.
+type MergeStyle string
+
+const (
+	MergeStyleMerge  MergeStyle = "merge"
+	MergeStyleRebase MergeStyle = "rebase"
+	MergeStyleSquash MergeStyle = "squash"
+)
+
+// MergePullRequestInput is the payload used to merge a pull request.
+type MergePullRequestInput struct {
+	Do                     MergeStyle `json:"Do"`
+	HeadCommitID           string     `json:"head_commit_id,omitempty"`
+	DeleteBranchAfterMerge bool       `json:"delete_branch_after_merge,omitempty"`
+}
+
+// ForkRepositoryInput is the payload used to fork a repository. If
+// Organization is nil, the repository is forked into the namespace of the
+// authenticated user.
+type ForkRepositoryInput struct {
+	Organization *string `json:"organization,omitempty"`
+	Name         *string `json:"name,omitempty"`
+}
+
+// HTTPError is returned for any response of the Gitea API with a non-2xx
+// status code.
+type HTTPError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("Gitea API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *HTTPError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+func (e *HTTPError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
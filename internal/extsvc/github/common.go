@@ -97,11 +97,14 @@ func (c *CheckSuite) Key() string {
 
 // CheckRun represents the status of a checkrun
 type CheckRun struct {
-	ID string
+	ID   string
+	Name string
 	// One of COMPLETED, IN_PROGRESS, QUEUED, REQUESTED
 	Status string
 	// One of ACTION_REQUIRED, CANCELLED, FAILURE, NEUTRAL, SUCCESS, TIMED_OUT
 	Conclusion string
+	// The URL of the integrator's site with the full details of the run
+	DetailsURL string
 	// When the run was received via a webhook
 	ReceivedAt time.Time
 }
@@ -134,6 +137,7 @@ type CommitStatus struct {
 	SHA        string
 	Context    string
 	State      string
+	TargetURL  string
 	ReceivedAt time.Time
 }
 
@@ -211,6 +215,7 @@ type Context struct {
 	Context     string
 	Description string
 	State       string
+	TargetURL   string
 }
 
 type Label struct {
@@ -1418,6 +1423,7 @@ fragment commitWithChecks on Commit {
       context
       state
       description
+      targetUrl
     }
   }
   checkSuites(last: 20) {
@@ -1428,8 +1434,10 @@ fragment commitWithChecks on Commit {
       checkRuns(last: 20) {
         nodes {
           id
+          name
           status
           conclusion
+          detailsUrl
         }
       }
     }
@@ -839,6 +839,21 @@ func (c *V3Client) DeleteBranch(ctx context.Context, owner, repo, branch string)
 	return nil
 }
 
+// RequestReviewers requests reviews of the given pull request from the given
+// users and teams. Teams are identified by their slug, without the
+// organization.
+//
+// API docs: https://docs.github.com/en/rest/pulls/review-requests#request-reviewers-for-a-pull-request
+func (c *V3Client) RequestReviewers(ctx context.Context, owner, repo string, number int64, reviewers, teamReviewers []string) error {
+	payload := struct {
+		Reviewers     []string `json:"reviewers,omitempty"`
+		TeamReviewers []string `json:"team_reviewers,omitempty"`
+	}{Reviewers: reviewers, TeamReviewers: teamReviewers}
+
+	_, err := c.post(ctx, "repos/"+owner+"/"+repo+"/pulls/"+strconv.FormatInt(number, 10)+"/requested_reviewers", payload, nil)
+	return err
+}
+
 // GetRef gets the contents of a single commit reference in a repository. The ref should
 // be supplied in a fully qualified format, such as `refs/heads/branch` or
 // `refs/tags/tag`.
@@ -674,6 +674,16 @@ func (c *V4Client) DeleteBranch(ctx context.Context, owner, repo, branch string)
 	return NewV3Client(logger, c.urn, c.apiURL, c.auth, c.httpClient).DeleteBranch(ctx, owner, repo, branch)
 }
 
+// RequestReviewers requests reviews of the given pull request from the given
+// users and teams. Teams are identified by their slug, without the
+// organization.
+func (c *V4Client) RequestReviewers(ctx context.Context, owner, repo string, number int64, reviewers, teamReviewers []string) error {
+	// The GraphQL API requires the node IDs of the users and teams, so we use
+	// the REST API, which accepts their logins and slugs.
+	logger := c.log.Scoped("RequestReviewers")
+	return NewV3Client(logger, c.urn, c.apiURL, c.auth, c.httpClient).RequestReviewers(ctx, owner, repo, number, reviewers, teamReviewers)
+}
+
 // GetRef gets the contents of a single commit reference in a repository. The ref should
 // be supplied in a fully qualified format, such as `refs/heads/branch` or
 // `refs/tags/tag`.
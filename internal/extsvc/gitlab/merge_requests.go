@@ -55,6 +55,12 @@ type MergeRequest struct {
 
 	DiffRefs DiffRefs `json:"diff_refs"`
 
+	// MergeCommitSHA is the merge commit created when the merge request was
+	// merged, if any. SquashCommitSHA is set if the commits of the merge
+	// request were squashed into a single commit when merging.
+	MergeCommitSHA  string `json:"merge_commit_sha"`
+	SquashCommitSHA string `json:"squash_commit_sha"`
+
 	// The fields below are computed from other REST API requests when getting a
 	// Merge Request. Once our minimum version is GitLab 12.0, we can use the
 	// GraphQL API to retrieve all of this data at once, but until then, we have
@@ -62,6 +68,8 @@ type MergeRequest struct {
 	Notes               []*Note
 	Pipelines           []*Pipeline
 	ResourceStateEvents []*ResourceStateEvent
+	// Jobs are the jobs of the latest pipeline.
+	Jobs []*Job
 }
 
 // IsWIPOrDraft returns true if the given title would result in GitLab rendering the MR as 'work in progress'.
@@ -223,6 +231,8 @@ type UpdateMergeRequestOpts struct {
 	Description        string                       `json:"description,omitempty"`
 	StateEvent         UpdateMergeRequestStateEvent `json:"state_event,omitempty"`
 	RemoveSourceBranch bool                         `json:"remove_source_branch,omitempty"`
+	// ReviewerIDs replaces the reviewers of the merge request, if set.
+	ReviewerIDs []int32 `json:"reviewer_ids,omitempty"`
 }
 
 type UpdateMergeRequestStateEvent string
@@ -56,6 +56,45 @@ func (c *Client) GetMergeRequestPipelines(ctx context.Context, project *Project,
 	}
 }
 
+// GetPipelineJobs retrieves the jobs of the given pipeline. Retried jobs are
+// not included, so every job is only returned with its latest run. All pages
+// of jobs are requested.
+func (c *Client) GetPipelineJobs(ctx context.Context, project *Project, pipelineID ID) ([]*Job, error) {
+	if MockGetPipelineJobs != nil {
+		return MockGetPipelineJobs(c, ctx, project, pipelineID)
+	}
+
+	baseURL := fmt.Sprintf("projects/%d/pipelines/%d/jobs", project.ID, pipelineID)
+	var jobs []*Job
+	for currentPage := "1"; currentPage != ""; {
+		parsedUrl, err := url.Parse(baseURL)
+		if err != nil {
+			return nil, err
+		}
+		q := parsedUrl.Query()
+		q.Add("per_page", "100")
+		q.Add("page", currentPage)
+		parsedUrl.RawQuery = q.Encode()
+
+		req, err := http.NewRequest("GET", parsedUrl.String(), nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "creating pipeline jobs request")
+		}
+
+		var page []*Job
+		header, _, err := c.do(ctx, req, &page)
+		if err != nil {
+			return nil, errors.Wrap(err, "requesting pipeline jobs")
+		}
+		jobs = append(jobs, page...)
+
+		// If there's another page, this will be a page number. If there's
+		// not, this will be an empty string and we're done.
+		currentPage = header.Get("X-Next-Page")
+	}
+	return jobs, nil
+}
+
 type Pipeline struct {
 	ID        ID             `json:"id"`
 	SHA       string         `json:"sha"`
@@ -82,3 +121,14 @@ const (
 func (p *Pipeline) Key() string {
 	return fmt.Sprintf("Pipeline:%d", p.ID)
 }
+
+// Job is a single job of a pipeline.
+type Job struct {
+	ID    ID     `json:"id"`
+	Name  string `json:"name"`
+	Stage string `json:"stage"`
+	// Jobs share their statuses with pipelines.
+	Status       PipelineStatus `json:"status"`
+	AllowFailure bool           `json:"allow_failure"`
+	WebURL       string         `json:"web_url"`
+}
@@ -2,7 +2,10 @@ package gitlab
 
 import (
 	"context"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -147,3 +150,82 @@ func TestPipelineKey(t *testing.T) {
 		t.Errorf("incorrect pipeline key: have %s; want %s", have, want)
 	}
 }
+
+func TestGetPipelineJobs(t *testing.T) {
+	ctx := context.Background()
+	project := &Project{}
+
+	t.Run("error status code", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPEmptyResponse{http.StatusNotFound}
+
+		jobs, err := client.GetPipelineJobs(ctx, project, 42)
+		if jobs != nil {
+			t.Errorf("unexpected non-nil jobs: %+v", jobs)
+		}
+		if err == nil {
+			t.Error("unexpected nil error")
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{
+			responseBody: `[{"id":1,"name":"lint","stage":"test","status":"failed","web_url":"https://gitlab.com/jobs/1"}]`,
+		}
+
+		jobs, err := client.GetPipelineJobs(ctx, project, 42)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		want := []*Job{{ID: 1, Name: "lint", Stage: "test", Status: PipelineStatusFailed, WebURL: "https://gitlab.com/jobs/1"}}
+		if diff := cmp.Diff(want, jobs); diff != "" {
+			t.Errorf("unexpected jobs: %s", diff)
+		}
+	})
+
+	t.Run("multiple pages", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPPages{pages: []string{
+			`[{"id":1,"name":"lint"}]`,
+			`[{"id":2,"name":"test"}]`,
+		}}
+
+		jobs, err := client.GetPipelineJobs(ctx, project, 42)
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		want := []*Job{{ID: 1, Name: "lint"}, {ID: 2, Name: "test"}}
+		if diff := cmp.Diff(want, jobs); diff != "" {
+			t.Errorf("unexpected jobs: %s", diff)
+		}
+	})
+}
+
+// mockHTTPPages returns the page requested with the page query parameter,
+// setting the X-Next-Page header if there are further pages.
+type mockHTTPPages struct {
+	pages []string
+}
+
+func (s *mockHTTPPages) Do(req *http.Request) (*http.Response, error) {
+	page, err := strconv.Atoi(req.URL.Query().Get("page"))
+	if err != nil || page < 1 || page > len(s.pages) {
+		return &http.Response{
+			Request:    req,
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}, nil
+	}
+
+	header := make(http.Header)
+	if page < len(s.pages) {
+		header.Set("X-Next-Page", strconv.Itoa(page+1))
+	}
+	return &http.Response{
+		Request:    req,
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(s.pages[page-1])),
+		Header:     header,
+	}, nil
+}
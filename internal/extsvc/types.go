@@ -209,6 +209,10 @@ const (
 
 	// VariantOther is the (api.ExternalRepoSpec).ServiceType value for other projects.
 	VariantOther
+
+	// VariantGitea is the (api.ExternalRepoSpec).ServiceType value for Gitea and Forgejo
+	// repositories. The ServiceID value is the base URL to the Gitea instance.
+	VariantGitea
 )
 
 type variantValues struct {
@@ -225,6 +229,7 @@ var variantValuesMap = map[Variant]variantValues{
 	VariantBitbucketCloud:  {AsKind: "BITBUCKETCLOUD", AsType: "bitbucketCloud", ConfigPrototype: func() any { return &schema.BitbucketCloudConnection{} }, WebhookURLPath: "bitbucket-cloud-webhooks", SupportsRepoExclusion: true},
 	VariantBitbucketServer: {AsKind: "BITBUCKETSERVER", AsType: "bitbucketServer", ConfigPrototype: func() any { return &schema.BitbucketServerConnection{} }, WebhookURLPath: "bitbucket-server-webhooks", SupportsRepoExclusion: true},
 	VariantGerrit:          {AsKind: "GERRIT", AsType: "gerrit", ConfigPrototype: func() any { return &schema.GerritConnection{} }},
+	VariantGitea:           {AsKind: "GITEA", AsType: "gitea", ConfigPrototype: func() any { return &schema.GiteaConnection{} }},
 	VariantGitHub:          {AsKind: "GITHUB", AsType: "github", ConfigPrototype: func() any { return &schema.GitHubConnection{} }, WebhookURLPath: "github-webhooks", SupportsRepoExclusion: true},
 	VariantGitLab:          {AsKind: "GITLAB", AsType: "gitlab", ConfigPrototype: func() any { return &schema.GitLabConnection{} }, WebhookURLPath: "gitlab-webhooks", SupportsRepoExclusion: true},
 	VariantGitolite:        {AsKind: "GITOLITE", AsType: "gitolite", ConfigPrototype: func() any { return &schema.GitoliteConnection{} }, SupportsRepoExclusion: true},
@@ -294,6 +299,7 @@ var (
 	KindGitHub          = VariantGitHub.AsKind()
 	KindGitLab          = VariantGitLab.AsKind()
 	KindGitolite        = VariantGitolite.AsKind()
+	KindGitea           = VariantGitea.AsKind()
 	KindPerforce        = VariantPerforce.AsKind()
 	KindPhabricator     = VariantPhabricator.AsKind()
 	KindGoPackages      = VariantGoPackages.AsKind()
@@ -339,6 +345,10 @@ var (
 	// TypeGitolite is the (api.ExternalRepoSpec).ServiceType value for Gitolite projects.
 	TypeGitolite = VariantGitolite.AsType()
 
+	// TypeGitea is the (api.ExternalRepoSpec).ServiceType value for Gitea and Forgejo repositories. The
+	// ServiceID value is the base URL to the Gitea instance.
+	TypeGitea = VariantGitea.AsType()
+
 	// TypePerforce is the (api.ExternalRepoSpec).ServiceType value for Perforce projects.
 	TypePerforce = VariantPerforce.AsType()
 
@@ -558,6 +568,8 @@ func extractToken(parsed any, kind string) (string, error) {
 		return c.Token, nil
 	case *schema.PagureConnection:
 		return c.Token, nil
+	case *schema.GiteaConnection:
+		return c.Token, nil
 	default:
 		return "", errors.Errorf("unable to extract token for service kind %q", kind)
 	}
@@ -638,6 +650,12 @@ func GetLimitFromConfig(config any, kind string) (limit rate.Limit, isDefault bo
 			isDefault = false
 			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
 		}
+	case *schema.GiteaConnection:
+		limit = GetDefaultRateLimit(KindGitea)
+		if c != nil && c.RateLimit != nil {
+			isDefault = false
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
 	case *schema.NpmPackagesConnection:
 		limit = GetDefaultRateLimit(KindNpmPackages)
 		if c != nil && c.RateLimit != nil {
@@ -695,6 +713,10 @@ func GetDefaultRateLimit(kind string) rate.Limit {
 	case KindPagure:
 		// 8/s is the default limit we enforce
 		return rate.Limit(8)
+	case KindGitea:
+		// Gitea does not rate limit its API by default, but we still want to be
+		// gentle with self-hosted instances. 8/s is the default limit we enforce.
+		return rate.Limit(8)
 	case KindNpmPackages:
 		return rate.Limit(6000 / 3600.0)
 	case KindGoPackages:
@@ -841,6 +863,8 @@ func uniqueCodeHostIdentifier(kind string, cfg any) (string, error) {
 		return VariantRubyPackages.AsKind(), nil
 	case *schema.PagureConnection:
 		rawURL = c.Url
+	case *schema.GiteaConnection:
+		rawURL = c.Url
 	default:
 		return "", errors.Errorf("unknown external service kind: %s", kind)
 	}
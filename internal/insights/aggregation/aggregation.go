@@ -13,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/insights/query/querybuilder"
 	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/own"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	sApi "github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
@@ -168,6 +169,30 @@ func countRepoMetadata(r result.Match, repo *sTypes.Repo) (map[MatchKey]int, err
 	return matches, nil
 }
 
+// NewOwnerCountFunc returns the counting function for the owner aggregation mode. The results in a file are
+// attributed to each of the owners of the file, so the counts of all owners can add up to more than the number
+// of results.
+func NewOwnerCountFunc(ctx context.Context, owners *own.FileOwners) AggregationCountFunc {
+	return func(r result.Match, _ *sTypes.Repo) (map[MatchKey]int, error) {
+		match, ok := r.(*result.FileMatch)
+		if !ok {
+			return nil, nil
+		}
+		labels, err := owners.Owners(ctx, match.Repo.Name, match.Repo.ID, match.CommitID, match.Path)
+		if err != nil {
+			return nil, errors.Wrap(err, "FileOwners.Owners")
+		}
+		if len(labels) == 0 {
+			labels = []string{types.NO_OWNER_TEXT}
+		}
+		matches := make(map[MatchKey]int, len(labels))
+		for _, label := range labels {
+			matches[MatchKey{Repo: string(r.RepoName().Name), RepoID: int32(r.RepoName().ID), Group: label}] = r.ResultCount()
+		}
+		return matches, nil
+	}
+}
+
 func GetCountFuncForMode(query, patternType string, mode types.SearchAggregationMode) (AggregationCountFunc, error) {
 	modeCountTypes := map[types.SearchAggregationMode]AggregationCountFunc{
 		types.REPO_AGGREGATION_MODE:          countRepo,
@@ -277,12 +302,12 @@ func (r *searchAggregationResults) Send(event streaming.SearchEvent) {
 			return
 		default:
 			groups, err := r.countFunc(match, repos[match.RepoName().ID])
-			for groupKey, count := range groups {
+			if err != nil {
 				// delegate error handling to the passed in tabulator
-				if err != nil {
-					r.tabulator(nil, err)
-					continue
-				}
+				r.tabulator(nil, err)
+				continue
+			}
+			for groupKey, count := range groups {
 				current := combined[groupKey]
 				combined[groupKey] = current + count
 			}
@@ -2,6 +2,9 @@ package aggregation
 
 import (
 	"context"
+	"io"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,8 +13,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/own"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	dTypes "github.com/sourcegraph/sourcegraph/internal/types"
@@ -711,6 +716,60 @@ func TestRepoMetadataAggregation(t *testing.T) {
 	}
 }
 
+func TestOwnerAggregation(t *testing.T) {
+	testCases := []struct {
+		name        string
+		searchEvent streaming.SearchEvent
+		want        autogold.Value
+	}{
+		{"No results", streaming.SearchEvent{}, autogold.Expect(map[string]int{})},
+		{
+			"No owner for repo match",
+			streaming.SearchEvent{
+				Results: []result.Match{repoMatch("myRepo", 1)},
+			},
+			autogold.Expect(map[string]int{}),
+		},
+		{
+			"counts by owner",
+			streaming.SearchEvent{
+				Results: []result.Match{
+					contentMatch("myRepo", "cmd/main.go", 1, "a", "b"),
+					contentMatch("myRepo", "lib/types.go", 1, "a"),
+					pathMatch("myRepo", "README.md", 1),
+				},
+			},
+			autogold.Expect(map[string]int{"@sourcegraph/cmd": 2, "@sourcegraph/go": 3, "No owner": 1}),
+		},
+	}
+	git := gitserver.NewMockClient()
+	git.GetDefaultBranchFunc.SetDefaultReturn("main", "HEAD", nil)
+	git.NewFileReaderFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, _ api.CommitID, path string) (io.ReadCloser, error) {
+		if path != "CODEOWNERS" {
+			return nil, os.ErrNotExist
+		}
+		return io.NopCloser(strings.NewReader("*.go @sourcegraph/go\n/cmd/ @sourcegraph/go @sourcegraph/cmd\n")), nil
+	})
+	db := dbmocks.NewMockDB()
+	codeowners := dbmocks.NewMockCodeownersStore()
+	codeowners.GetCodeownersForRepoFunc.SetDefaultReturn(nil, database.CodeownersFileNotFoundError{})
+	db.CodeownersFunc.SetDefaultReturn(codeowners)
+	repos := dbmocks.NewMockRepoStore()
+	repos.GetFunc.SetDefaultReturn(&dTypes.Repo{Name: "myRepo", ID: 1}, nil)
+	db.ReposFunc.SetDefaultReturn(repos)
+	db.AssignedOwnersFunc.SetDefaultReturn(dbmocks.NewMockAssignedOwnersStore())
+	db.AssignedTeamsFunc.SetDefaultReturn(dbmocks.NewMockAssignedTeamsStore())
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc := NewOwnerCountFunc(context.Background(), own.NewFileOwners(git, db))
+			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc, types.OWNER_AGGREGATION_MODE, db)
+			sra.Send(tc.searchEvent)
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+}
+
 func TestAggregationCancelation(t *testing.T) {
 	testCases := []struct {
 		name        string
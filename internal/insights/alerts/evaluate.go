@@ -0,0 +1,87 @@
+// Package alerts evaluates the alert rules of Code Insights series after new data points have been
+// recorded and notifies the configured recipients when a rule starts firing.
+package alerts
+
+import (
+	"math"
+
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+)
+
+// Evaluate evaluates the given rule against the values of a series, ordered from oldest to newest. It
+// returns the value that was compared against the threshold of the rule, and whether the rule matches.
+//
+// Rules that need more data points than are available, or that cannot be evaluated meaningfully (such
+// as a percent change from zero), never match.
+func Evaluate(rule types.InsightSeriesAlertRule, values []float64) (value float64, matched bool) {
+	if len(values) == 0 {
+		return 0, false
+	}
+	latest := values[len(values)-1]
+
+	switch rule.Kind {
+	case types.AlertRuleThreshold:
+		value = latest
+
+	case types.AlertRulePercentChange:
+		window := windowSize(rule)
+		if len(values) < window+1 {
+			return 0, false
+		}
+		baseline := values[len(values)-1-window]
+		if baseline == 0 {
+			return 0, false
+		}
+		value = (latest - baseline) / math.Abs(baseline) * 100
+		if rule.Direction == types.AlertRuleBelow {
+			// The threshold of a percent change rule is the magnitude of the change, so a rule that
+			// fires on a drop of 10% has a threshold of 10 rather than -10.
+			return value, value <= -rule.Threshold
+		}
+
+	case types.AlertRuleAnomaly:
+		window := windowSize(rule)
+		if len(values) < window+1 {
+			return 0, false
+		}
+		mean, stddev := meanAndStddev(values[len(values)-1-window : len(values)-1])
+		if stddev == 0 {
+			return 0, false
+		}
+		value = (latest - mean) / stddev
+		if rule.Direction == types.AlertRuleBelow {
+			// Like percent change rules, anomaly rules treat the threshold as a magnitude.
+			return value, value <= -rule.Threshold
+		}
+
+	default:
+		return 0, false
+	}
+
+	if rule.Direction == types.AlertRuleBelow {
+		return value, value <= rule.Threshold
+	}
+	return value, value >= rule.Threshold
+}
+
+func windowSize(rule types.InsightSeriesAlertRule) int {
+	if rule.WindowSize < 1 {
+		return 1
+	}
+	return rule.WindowSize
+}
+
+func meanAndStddev(values []float64) (mean, stddev float64) {
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= float64(len(values))
+
+	return mean, math.Sqrt(variance)
+}
@@ -0,0 +1,110 @@
+package alerts
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+)
+
+func TestEvaluate(t *testing.T) {
+	testCases := []struct {
+		name      string
+		rule      types.InsightSeriesAlertRule
+		values    []float64
+		wantValue float64
+		wantMatch bool
+	}{
+		{
+			name:   "no values",
+			rule:   types.InsightSeriesAlertRule{Kind: types.AlertRuleThreshold, Direction: types.AlertRuleAbove, Threshold: 10},
+			values: nil,
+		},
+		{
+			name:      "threshold above matches",
+			rule:      types.InsightSeriesAlertRule{Kind: types.AlertRuleThreshold, Direction: types.AlertRuleAbove, Threshold: 10},
+			values:    []float64{1, 5, 10},
+			wantValue: 10,
+			wantMatch: true,
+		},
+		{
+			name:      "threshold above does not match",
+			rule:      types.InsightSeriesAlertRule{Kind: types.AlertRuleThreshold, Direction: types.AlertRuleAbove, Threshold: 10},
+			values:    []float64{20, 9},
+			wantValue: 9,
+		},
+		{
+			name:      "threshold below matches",
+			rule:      types.InsightSeriesAlertRule{Kind: types.AlertRuleThreshold, Direction: types.AlertRuleBelow, Threshold: 10},
+			values:    []float64{20, 3},
+			wantValue: 3,
+			wantMatch: true,
+		},
+		{
+			name:      "percent change above matches",
+			rule:      types.InsightSeriesAlertRule{Kind: types.AlertRulePercentChange, Direction: types.AlertRuleAbove, Threshold: 50, WindowSize: 2},
+			values:    []float64{10, 100, 12, 150},
+			wantValue: 50,
+			wantMatch: true,
+		},
+		{
+			name:      "percent change below matches",
+			rule:      types.InsightSeriesAlertRule{Kind: types.AlertRulePercentChange, Direction: types.AlertRuleBelow, Threshold: 25, WindowSize: 1},
+			values:    []float64{100, 70},
+			wantValue: -30,
+			wantMatch: true,
+		},
+		{
+			name:      "percent change below does not match an increase",
+			rule:      types.InsightSeriesAlertRule{Kind: types.AlertRulePercentChange, Direction: types.AlertRuleBelow, Threshold: 25, WindowSize: 1},
+			values:    []float64{100, 150},
+			wantValue: 50,
+		},
+		{
+			name:   "percent change without enough values",
+			rule:   types.InsightSeriesAlertRule{Kind: types.AlertRulePercentChange, Direction: types.AlertRuleAbove, Threshold: 25, WindowSize: 3},
+			values: []float64{1, 100},
+		},
+		{
+			name:   "percent change from zero",
+			rule:   types.InsightSeriesAlertRule{Kind: types.AlertRulePercentChange, Direction: types.AlertRuleAbove, Threshold: 25, WindowSize: 1},
+			values: []float64{0, 100},
+		},
+		{
+			name:      "anomaly above matches",
+			rule:      types.InsightSeriesAlertRule{Kind: types.AlertRuleAnomaly, Direction: types.AlertRuleAbove, Threshold: 3, WindowSize: 4},
+			values:    []float64{8, 12, 8, 12, 20},
+			wantValue: 5,
+			wantMatch: true,
+		},
+		{
+			name:      "anomaly below matches",
+			rule:      types.InsightSeriesAlertRule{Kind: types.AlertRuleAnomaly, Direction: types.AlertRuleBelow, Threshold: 3, WindowSize: 4},
+			values:    []float64{8, 12, 8, 12, 0},
+			wantValue: -5,
+			wantMatch: true,
+		},
+		{
+			name:      "anomaly within threshold",
+			rule:      types.InsightSeriesAlertRule{Kind: types.AlertRuleAnomaly, Direction: types.AlertRuleAbove, Threshold: 3, WindowSize: 4},
+			values:    []float64{8, 12, 8, 12, 12},
+			wantValue: 1,
+		},
+		{
+			name:   "anomaly without variance",
+			rule:   types.InsightSeriesAlertRule{Kind: types.AlertRuleAnomaly, Direction: types.AlertRuleAbove, Threshold: 3, WindowSize: 2},
+			values: []float64{5, 5, 100},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, matched := Evaluate(tc.rule, tc.values)
+			if value != tc.wantValue {
+				t.Errorf("unexpected value: want %v, got %v", tc.wantValue, value)
+			}
+			if matched != tc.wantMatch {
+				t.Errorf("unexpected match: want %v, got %v", tc.wantMatch, matched)
+			}
+		})
+	}
+}
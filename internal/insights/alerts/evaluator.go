@@ -0,0 +1,172 @@
+package alerts
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/codemonitors/background"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// AlertRuleStore is the subset of the store.AlertRuleStore methods used by the Evaluator.
+type AlertRuleStore interface {
+	ListAlertRules(ctx context.Context, args store.ListAlertRulesArgs) ([]types.InsightSeriesAlertRule, error)
+	MarkAlertRuleEvaluated(ctx context.Context, id int, firing, triggered bool) error
+}
+
+// Notifier delivers an alert to the recipients of an alert rule.
+type Notifier interface {
+	Notify(ctx context.Context, rule types.InsightSeriesAlertRule, alert background.InsightAlert) error
+}
+
+// Evaluator evaluates the alert rules of insight series.
+type Evaluator struct {
+	alertStore  AlertRuleStore
+	seriesStore store.Interface
+	notifier    Notifier
+	logger      log.Logger
+}
+
+func NewEvaluator(alertStore AlertRuleStore, seriesStore store.Interface, notifier Notifier, logger log.Logger) *Evaluator {
+	return &Evaluator{
+		alertStore:  alertStore,
+		seriesStore: seriesStore,
+		notifier:    notifier,
+		logger:      logger,
+	}
+}
+
+// EvaluateSeries evaluates every alert rule of the given series against its recorded data points.
+// Recipients are only notified when a rule starts firing, so a rule that keeps matching notifies once
+// until it stops matching again.
+func (e *Evaluator) EvaluateSeries(ctx context.Context, series *types.InsightSeries) error {
+	rules, err := e.alertStore.ListAlertRules(ctx, store.ListAlertRulesArgs{SeriesID: series.ID})
+	if err != nil {
+		return errors.Wrap(err, "ListAlertRules")
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	var errs error
+	for _, rule := range rules {
+		if err := e.evaluateRule(ctx, series, rule); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "alert rule %d", rule.ID))
+		}
+	}
+	return errs
+}
+
+func (e *Evaluator) evaluateRule(ctx context.Context, series *types.InsightSeries, rule types.InsightSeriesAlertRule) error {
+	if rule.CreatedBy == nil {
+		e.logger.Warn("skipping insight series alert rule without a creator", log.Int("ruleID", rule.ID))
+		return nil
+	}
+
+	// 🚨 SECURITY: The series points are loaded as the creator of the rule, so that an alert never
+	// includes data from repositories its creator cannot access. 🚨
+	userCtx := actor.WithActor(ctx, actor.FromUser(*rule.CreatedBy))
+	points, err := e.seriesStore.SeriesPoints(userCtx, store.SeriesPointsOpts{
+		SeriesID:             &series.SeriesID,
+		SupportsAugmentation: series.SupportsAugmentation,
+	})
+	if err != nil {
+		return errors.Wrap(err, "SeriesPoints")
+	}
+	times, values := totalsByTime(points)
+
+	value, matched := Evaluate(rule, values)
+	triggered := matched && !rule.Firing
+
+	var notifyErr error
+	if triggered {
+		alert := background.InsightAlert{
+			SeriesID:    series.SeriesID,
+			InsightsURL: insightsURL(),
+			Query:       series.Query,
+			Kind:        string(rule.Kind),
+			Direction:   string(rule.Direction),
+			Threshold:   rule.Threshold,
+			Value:       value,
+			RecordedAt:  times[len(times)-1],
+		}
+		if notifyErr = e.notifier.Notify(ctx, rule, alert); notifyErr != nil {
+			// Leave the rule in its previous state so that the notification is retried after the
+			// next recording of the series.
+			matched, triggered = rule.Firing, false
+		}
+	}
+
+	if err := e.alertStore.MarkAlertRuleEvaluated(ctx, rule.ID, matched, triggered); err != nil {
+		return errors.Append(notifyErr, errors.Wrap(err, "MarkAlertRuleEvaluated"))
+	}
+	return notifyErr
+}
+
+// totalsByTime sums the values of the given points, which include one point per captured value for
+// series generated from capture groups, by time. The totals are ordered from oldest to newest.
+func totalsByTime(points []store.SeriesPoint) ([]time.Time, []float64) {
+	totals := make(map[time.Time]float64, len(points))
+	for _, point := range points {
+		totals[point.Time] += point.Value
+	}
+
+	times := make([]time.Time, 0, len(totals))
+	for t := range totals {
+		times = append(times, t)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+
+	values := make([]float64, len(times))
+	for i, t := range times {
+		values[i] = totals[t]
+	}
+	return times, values
+}
+
+func insightsURL() string {
+	externalURL, err := url.Parse(conf.Get().ExternalURL)
+	if err != nil || externalURL == nil {
+		return "/insights/all"
+	}
+	return externalURL.ResolveReference(&url.URL{Path: "/insights/all"}).String()
+}
+
+// NewNotifier returns a Notifier that delivers alerts through the email, Slack and webhook mechanisms
+// used by code monitors.
+func NewNotifier(db database.DB) Notifier {
+	return &notifier{db: db}
+}
+
+type notifier struct {
+	db database.DB
+}
+
+func (n *notifier) Notify(ctx context.Context, rule types.InsightSeriesAlertRule, alert background.InsightAlert) error {
+	var errs error
+	if rule.EmailUserID != nil {
+		if err := background.SendInsightAlertEmail(ctx, n.db, *rule.EmailUserID, alert); err != nil {
+			errs = errors.Append(errs, errors.Wrap(err, "SendInsightAlertEmail"))
+		}
+	}
+	if rule.SlackWebhookURL != nil {
+		if err := background.SendInsightAlertSlack(ctx, *rule.SlackWebhookURL, alert); err != nil {
+			errs = errors.Append(errs, errors.Wrap(err, "SendInsightAlertSlack"))
+		}
+	}
+	if rule.WebhookURL != nil {
+		if err := background.SendInsightAlertWebhook(ctx, *rule.WebhookURL, alert); err != nil {
+			errs = errors.Append(errs, errors.Wrap(err, "SendInsightAlertWebhook"))
+		}
+	}
+	return errs
+}
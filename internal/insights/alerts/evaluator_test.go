@@ -0,0 +1,120 @@
+package alerts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/codemonitors/background"
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+type fakeAlertRuleStore struct {
+	rules     []types.InsightSeriesAlertRule
+	evaluated map[int][2]bool
+}
+
+func (s *fakeAlertRuleStore) ListAlertRules(_ context.Context, args store.ListAlertRulesArgs) ([]types.InsightSeriesAlertRule, error) {
+	var rules []types.InsightSeriesAlertRule
+	for _, rule := range s.rules {
+		if rule.SeriesID == args.SeriesID {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func (s *fakeAlertRuleStore) MarkAlertRuleEvaluated(_ context.Context, id int, firing, triggered bool) error {
+	s.evaluated[id] = [2]bool{firing, triggered}
+	return nil
+}
+
+type fakeNotifier struct {
+	alerts map[int]background.InsightAlert
+	err    error
+}
+
+func (n *fakeNotifier) Notify(_ context.Context, rule types.InsightSeriesAlertRule, alert background.InsightAlert) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.alerts[rule.ID] = alert
+	return nil
+}
+
+func TestEvaluateSeries(t *testing.T) {
+	ctx := context.Background()
+	series := &types.InsightSeries{ID: 1, SeriesID: "series1", Query: "TODO"}
+	first := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	second := first.AddDate(0, 1, 0)
+
+	seriesStore := store.NewMockInterface()
+	seriesStore.SeriesPointsFunc.SetDefaultHook(func(ctx context.Context, opts store.SeriesPointsOpts) ([]store.SeriesPoint, error) {
+		if a := actor.FromContext(ctx); a.UID != 7 {
+			t.Errorf("expected series points to be loaded as the rule creator, got actor %v", a)
+		}
+		return []store.SeriesPoint{
+			{SeriesID: "series1", Time: second, Value: 8, Capture: pointers.Ptr("a")},
+			{SeriesID: "series1", Time: first, Value: 5},
+			{SeriesID: "series1", Time: second, Value: 7, Capture: pointers.Ptr("b")},
+		}, nil
+	})
+
+	newRules := func() []types.InsightSeriesAlertRule {
+		return []types.InsightSeriesAlertRule{
+			// Starts firing.
+			{ID: 1, SeriesID: 1, Kind: types.AlertRuleThreshold, Direction: types.AlertRuleAbove, Threshold: 10, CreatedBy: pointers.Ptr(int32(7))},
+			// Keeps firing.
+			{ID: 2, SeriesID: 1, Kind: types.AlertRulePercentChange, Direction: types.AlertRuleAbove, Threshold: 100, WindowSize: 1, CreatedBy: pointers.Ptr(int32(7)), Firing: true},
+			// Stops firing.
+			{ID: 3, SeriesID: 1, Kind: types.AlertRuleThreshold, Direction: types.AlertRuleBelow, Threshold: 10, CreatedBy: pointers.Ptr(int32(7)), Firing: true},
+			// Belongs to another series.
+			{ID: 4, SeriesID: 2, Kind: types.AlertRuleThreshold, Direction: types.AlertRuleAbove, Threshold: 0, CreatedBy: pointers.Ptr(int32(7))},
+		}
+	}
+
+	t.Run("notifies rules that start firing", func(t *testing.T) {
+		alertStore := &fakeAlertRuleStore{rules: newRules(), evaluated: map[int][2]bool{}}
+		notifier := &fakeNotifier{alerts: map[int]background.InsightAlert{}}
+
+		if err := NewEvaluator(alertStore, seriesStore, notifier, logtest.Scoped(t)).EvaluateSeries(ctx, series); err != nil {
+			t.Fatal(err)
+		}
+
+		want := map[int][2]bool{1: {true, true}, 2: {true, false}, 3: {false, false}}
+		for id, state := range want {
+			if alertStore.evaluated[id] != state {
+				t.Errorf("unexpected state for rule %d: want %v, got %v", id, state, alertStore.evaluated[id])
+			}
+		}
+		if _, ok := alertStore.evaluated[4]; ok {
+			t.Error("expected rule of another series not to be evaluated")
+		}
+
+		if len(notifier.alerts) != 1 {
+			t.Fatalf("expected exactly one alert, got %d", len(notifier.alerts))
+		}
+		alert := notifier.alerts[1]
+		if alert.Value != 15 || !alert.RecordedAt.Equal(second) || alert.SeriesID != "series1" {
+			t.Errorf("unexpected alert %+v", alert)
+		}
+	})
+
+	t.Run("failed notifications are retried", func(t *testing.T) {
+		alertStore := &fakeAlertRuleStore{rules: newRules()[:1], evaluated: map[int][2]bool{}}
+		notifier := &fakeNotifier{err: errors.New("boom")}
+
+		if err := NewEvaluator(alertStore, seriesStore, notifier, logtest.Scoped(t)).EvaluateSeries(ctx, series); err == nil {
+			t.Fatal("expected error")
+		}
+		if state := alertStore.evaluated[1]; state != [2]bool{false, false} {
+			t.Errorf("expected rule to not be marked as firing, got %v", state)
+		}
+	})
+}
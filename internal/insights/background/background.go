@@ -17,6 +17,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	internalGitserver "github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/insights/alerts"
+	"github.com/sourcegraph/sourcegraph/internal/insights/background/historicalquery"
 	"github.com/sourcegraph/sourcegraph/internal/insights/background/limiter"
 	"github.com/sourcegraph/sourcegraph/internal/insights/background/pings"
 	"github.com/sourcegraph/sourcegraph/internal/insights/background/queryrunner"
@@ -40,8 +42,9 @@ type RepoStore interface {
 }
 
 // GetBackgroundJobs is the main entrypoint which starts background jobs for code insights. It is
-// called from the worker service.
-func GetBackgroundJobs(ctx context.Context, logger log.Logger, mainAppDB database.DB, insightsDB edb.InsightsDB) []goroutine.BackgroundRoutine {
+// called from the worker service. The codeIntel client may be nil, in which case series generated from
+// precise code intelligence are not backfilled.
+func GetBackgroundJobs(ctx context.Context, logger log.Logger, mainAppDB database.DB, insightsDB edb.InsightsDB, codeIntel queryrunner.CodeIntelClient) []goroutine.BackgroundRoutine {
 	insightPermStore := store.NewInsightPermissionStore(mainAppDB)
 	insightsStore := store.New(insightsDB, insightPermStore)
 
@@ -72,6 +75,9 @@ func GetBackgroundJobs(ctx context.Context, logger log.Logger, mainAppDB databas
 
 	gitserverClient := internalGitserver.NewClient("insights")
 
+	// Discovers new tags of series sampled at tags and enqueues their recording.
+	routines = append(routines, newRevisionSeriesRecorder(ctx, workerBaseStore, insightsMetadataStore, insightsStore, gitserverClient.Scoped("revisionrecorder"), logger.Scoped("background-revision-series-recorder")))
+
 	// Register the background goroutine which discovers historical gaps in data and enqueues
 	// work to fill them - if not disabled.
 	disableHistorical, _ := strconv.ParseBool(os.Getenv("DISABLE_CODE_INSIGHTS_HISTORICAL"))
@@ -80,7 +86,7 @@ func GetBackgroundJobs(ctx context.Context, logger log.Logger, mainAppDB databas
 		historicRateLimiter := limiter.HistoricalWorkRate()
 		backfillConfig := pipeline.BackfillerConfig{
 			CompressionPlan:         compression.NewGitserverFilter(logger, gitserverClient.Scoped("compressionfilter")),
-			SearchHandlers:          queryrunner.GetSearchHandlers(),
+			SearchHandlers:          queryrunner.GetSearchHandlers(codeIntel, mainAppDB),
 			InsightStore:            insightsStore,
 			CommitClient:            gitserver.NewGitCommitClient(gitserverClient.Scoped("commitclient")),
 			SearchPlanWorkerLimit:   1,
@@ -107,19 +113,31 @@ func GetBackgroundJobs(ctx context.Context, logger log.Logger, mainAppDB databas
 				}),
 			CostAnalyzer:      priority.DefaultQueryAnalyzer(),
 			RepoQueryExecutor: query.NewStreamingRepoQueryExecutor(logger.Scoped("StreamingRepoExecutor")),
+			GitserverClient:   gitserverClient.Scoped("backfillnew"),
+			AlertEvaluator:    alerts.NewEvaluator(store.NewAlertRuleStore(insightsDB), insightsStore, alerts.NewNotifier(mainAppDB), logger.Scoped("alerts")),
 		}
 
 		// Add the backfill v2 workers
 		monitor := scheduler.NewBackgroundJobMonitor(ctx, config)
 		routines = append(routines, monitor.Routines()...)
+
+		// One-off historical queries run the same searches as backfills, but do not record their results.
+		historicalQueryStore := historicalquery.CreateDBWorkerStore(observationCtx, workerInsightsBaseStore)
+		historicalQueryMetrics, historicalQueryResetterMetrics := newWorkerMetrics(observationCtx, "insights_historical_query")
+		routines = append(routines,
+			historicalquery.NewWorker(ctx, historicalQueryStore, workerInsightsBaseStore, mainAppDB.Repos(), backfillConfig, historicalQueryMetrics),
+			historicalquery.NewResetter(ctx, logger.Scoped("historicalquery.Resetter"), historicalQueryStore, historicalQueryResetterMetrics),
+			historicalquery.NewCleaner(ctx, observationCtx, workerInsightsBaseStore),
+		)
 	}
 
 	return routines
 }
 
 // GetBackgroundQueryRunnerJob is the main entrypoint for starting the background jobs for code
-// insights query runner. It is called from the worker service.
-func GetBackgroundQueryRunnerJob(ctx context.Context, logger log.Logger, mainAppDB database.DB, insightsDB edb.InsightsDB) []goroutine.BackgroundRoutine {
+// insights query runner. It is called from the worker service. The codeIntel client may be nil, in which
+// case series generated from precise code intelligence are not recorded.
+func GetBackgroundQueryRunnerJob(ctx context.Context, logger log.Logger, mainAppDB database.DB, insightsDB edb.InsightsDB, codeIntel queryrunner.CodeIntelClient) []goroutine.BackgroundRoutine {
 	insightPermStore := store.NewInsightPermissionStore(mainAppDB)
 	insightsStore := store.New(insightsDB, insightPermStore)
 
@@ -134,11 +152,12 @@ func GetBackgroundQueryRunnerJob(ctx context.Context, logger log.Logger, mainApp
 
 	workerStore := queryrunner.CreateDBWorkerStore(observationCtx, workerBaseStore)
 	seachQueryLimiter := limiter.SearchQueryRate()
+	alertEvaluator := alerts.NewEvaluator(store.NewAlertRuleStore(insightsDB), insightsStore, alerts.NewNotifier(mainAppDB), logger.Scoped("alerts"))
 
 	return []goroutine.BackgroundRoutine{
 		// Register the query-runner worker and resetter, which executes search queries and records
 		// results to the insights DB.
-		queryrunner.NewWorker(ctx, logger.Scoped("queryrunner.Worker"), workerStore, insightsStore, repoStore, queryRunnerWorkerMetrics, seachQueryLimiter),
+		queryrunner.NewWorker(ctx, logger.Scoped("queryrunner.Worker"), workerStore, insightsStore, repoStore, queryRunnerWorkerMetrics, seachQueryLimiter, codeIntel, mainAppDB, alertEvaluator),
 		queryrunner.NewResetter(ctx, logger.Scoped("queryrunner.Resetter"), workerStore, queryRunnerResetterMetrics),
 		queryrunner.NewCleaner(ctx, observationCtx, workerBaseStore),
 	}
@@ -0,0 +1,50 @@
+package historicalquery
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// NewCleaner returns a routine that deletes historical query jobs and their results once they expire,
+// regardless of their state.
+func NewCleaner(ctx context.Context, observationCtx *observation.Context, workerBaseStore *basestore.Store) goroutine.BackgroundRoutine {
+	operation := observationCtx.Operation(observation.Op{
+		Name: "HistoricalQuery.Cleaner.Run",
+		Metrics: metrics.NewREDMetrics(
+			observationCtx.Registerer,
+			"insights_historical_query_job_cleaner",
+			metrics.WithCountHelp("Total number of insights historical query cleaner executions"),
+		),
+	})
+
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		goroutine.HandlerFunc(
+			func(ctx context.Context) error {
+				return cleanJobs(ctx, workerBaseStore)
+			},
+		),
+		goroutine.WithName("insights.historical_query_job_cleaner"),
+		goroutine.WithDescription("removes expired historical query jobs"),
+		goroutine.WithInterval(1*time.Hour),
+		goroutine.WithOperation(operation),
+	)
+}
+
+func cleanJobs(ctx context.Context, workerBaseStore *basestore.Store) error {
+	return workerBaseStore.Exec(
+		ctx,
+		sqlf.Sprintf(cleanJobsFmtStr, time.Now().Add(-JobTTL)),
+	)
+}
+
+const cleanJobsFmtStr = `
+DELETE FROM insights_historical_query_jobs WHERE queued_at <= %s
+`
@@ -0,0 +1,184 @@
+// Package historicalquery runs one-off historical queries, which count the matches of a search query at
+// past points in time across a list of repositories. The queries are computed by the insights backfill
+// pipeline, but do not create insight series, and their results are deleted once they expire.
+package historicalquery
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/executor"
+	"github.com/sourcegraph/sourcegraph/internal/insights/timeseries"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+)
+
+// JobTTL is how long a job and its results are kept after the job was queued.
+const JobTTL = 24 * time.Hour
+
+type Job struct {
+	ID              int
+	State           string
+	FailureMessage  *string
+	QueuedAt        time.Time
+	StartedAt       *time.Time
+	FinishedAt      *time.Time
+	ProcessAfter    *time.Time
+	NumResets       int
+	NumFailures     int
+	LastHeartbeatAt time.Time
+	ExecutionLogs   []executor.ExecutionLogEntry
+	WorkerHostname  string
+	Cancel          bool
+
+	UserID                int32
+	Query                 string
+	Repositories          []string
+	SampleIntervalUnit    types.IntervalUnit
+	SampleIntervalValue   int
+	NumSamples            int
+	RepositoriesCompleted int
+	// Results are the number of matches at each sample time, summed over the completed repositories.
+	Results []Point
+}
+
+type Point struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+}
+
+func (j *Job) RecordID() int {
+	return j.ID
+}
+
+func (j *Job) RecordUID() string {
+	return strconv.Itoa(j.ID)
+}
+
+// SampleTimes returns the points in time the query is counted at. The most recent sample time is the
+// time the job was queued, so that retries of the job count at the same points in time.
+func (j *Job) SampleTimes() []time.Time {
+	return timeseries.BuildSampleTimes(j.NumSamples, timeseries.TimeInterval{
+		Unit:  j.SampleIntervalUnit,
+		Value: j.SampleIntervalValue,
+	}, j.QueuedAt.UTC().Truncate(time.Minute))
+}
+
+// ExpiresAt returns the time after which the job and its results are deleted.
+func (j *Job) ExpiresAt() time.Time {
+	return j.QueuedAt.Add(JobTTL)
+}
+
+var jobColumns = []*sqlf.Query{
+	sqlf.Sprintf("insights_historical_query_jobs.user_id"),
+	sqlf.Sprintf("insights_historical_query_jobs.query"),
+	sqlf.Sprintf("insights_historical_query_jobs.repositories"),
+	sqlf.Sprintf("insights_historical_query_jobs.sample_interval_unit"),
+	sqlf.Sprintf("insights_historical_query_jobs.sample_interval_value"),
+	sqlf.Sprintf("insights_historical_query_jobs.num_samples"),
+	sqlf.Sprintf("insights_historical_query_jobs.repositories_completed"),
+	sqlf.Sprintf("insights_historical_query_jobs.results"),
+
+	sqlf.Sprintf("id"),
+	sqlf.Sprintf("state"),
+	sqlf.Sprintf("failure_message"),
+	sqlf.Sprintf("queued_at"),
+	sqlf.Sprintf("started_at"),
+	sqlf.Sprintf("finished_at"),
+	sqlf.Sprintf("process_after"),
+	sqlf.Sprintf("num_resets"),
+	sqlf.Sprintf("num_failures"),
+	sqlf.Sprintf("execution_logs"),
+}
+
+func scanJob(s dbutil.Scanner) (*Job, error) {
+	var job Job
+	var results []byte
+
+	if err := s.Scan(
+		&job.UserID,
+		&job.Query,
+		pq.Array(&job.Repositories),
+		&job.SampleIntervalUnit,
+		&job.SampleIntervalValue,
+		&job.NumSamples,
+		&job.RepositoriesCompleted,
+		&results,
+
+		&job.ID,
+		&job.State,
+		&job.FailureMessage,
+		&job.QueuedAt,
+		&job.StartedAt,
+		&job.FinishedAt,
+		&job.ProcessAfter,
+		&job.NumResets,
+		&job.NumFailures,
+		pq.Array(&job.ExecutionLogs),
+	); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(results, &job.Results); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// EnqueueJob queues the given job and sets its ID.
+func EnqueueJob(ctx context.Context, workerBaseStore *basestore.Store, job *Job) (id int, err error) {
+	id, _, err = basestore.ScanFirstInt(workerBaseStore.Query(
+		ctx,
+		sqlf.Sprintf(
+			enqueueJobFmtStr,
+			job.UserID,
+			job.Query,
+			pq.Array(job.Repositories),
+			job.SampleIntervalUnit,
+			job.SampleIntervalValue,
+			job.NumSamples,
+		),
+	))
+	if err != nil {
+		return 0, err
+	}
+	job.ID = id
+	return id, nil
+}
+
+const enqueueJobFmtStr = `
+INSERT INTO insights_historical_query_jobs (user_id, query, repositories, sample_interval_unit, sample_interval_value, num_samples)
+VALUES (%s, %s, %s, %s, %s, %s)
+RETURNING id
+`
+
+// GetJob returns the job with the given ID, or nil if it does not exist or has expired.
+func GetJob(ctx context.Context, workerBaseStore *basestore.Store, id int) (*Job, error) {
+	job, _, err := basestore.NewFirstScanner(scanJob)(workerBaseStore.Query(
+		ctx,
+		sqlf.Sprintf(getJobFmtStr, sqlf.Join(jobColumns, ", "), id, time.Now().Add(-JobTTL)),
+	))
+	return job, err
+}
+
+const getJobFmtStr = `
+SELECT %s FROM insights_historical_query_jobs WHERE id = %s AND queued_at > %s
+`
+
+func updateProgress(ctx context.Context, workerBaseStore *basestore.Store, id int, repositoriesCompleted int, results []Point) error {
+	raw, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+	return workerBaseStore.Exec(ctx, sqlf.Sprintf(updateProgressFmtStr, repositoriesCompleted, string(raw), id))
+}
+
+const updateProgressFmtStr = `
+UPDATE insights_historical_query_jobs SET repositories_completed = %s, results = %s WHERE id = %s
+`
@@ -0,0 +1,147 @@
+package historicalquery
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/insights/pipeline"
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	itypes "github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type RepoStore interface {
+	GetByName(ctx context.Context, name api.RepoName) (*itypes.Repo, error)
+}
+
+var _ workerutil.Handler[*Job] = &historicalQueryHandler{}
+
+type historicalQueryHandler struct {
+	workerBaseStore *basestore.Store
+	repoStore       RepoStore
+	newBackfiller   func(collect pipeline.PointsCollector) pipeline.Backfiller
+}
+
+func (h *historicalQueryHandler) Handle(ctx context.Context, logger log.Logger, record *Job) error {
+	// 🚨 SECURITY: The repositories are looked up as the user who ran the query, so that repositories the
+	// user lost access to since the query was queued have no matches. The repositories visible to the
+	// user are searched without authentication, otherwise private repositories would silently have no
+	// matches.
+	userCtx := actor.WithActor(ctx, actor.FromUser(record.UserID))
+	ctx = actor.WithInternalActor(ctx)
+
+	sampleTimes := record.SampleTimes()
+	results := make([]Point, 0, len(sampleTimes))
+	for _, t := range sampleTimes {
+		results = append(results, Point{Time: t})
+	}
+	// Reset the progress, so that a retried job does not count any repository twice.
+	if err := updateProgress(ctx, h.workerBaseStore, record.ID, 0, results); err != nil {
+		return errors.Wrap(err, "updateProgress")
+	}
+
+	// The series only exists for the duration of the job, the backfiller does not record its points.
+	series := &types.InsightSeries{
+		SeriesID:            fmt.Sprintf("historical-query-%d", record.ID),
+		Query:               record.Query,
+		Repositories:        record.Repositories,
+		SampleIntervalUnit:  string(record.SampleIntervalUnit),
+		SampleIntervalValue: record.SampleIntervalValue,
+		GenerationMethod:    types.Search,
+	}
+	backfiller := h.newBackfiller(func(ctx context.Context, points []store.RecordSeriesPointArgs) error {
+		addPoints(results, points)
+		return nil
+	})
+
+	for i, name := range record.Repositories {
+		repo, err := h.repoStore.GetByName(userCtx, api.RepoName(name))
+		if err != nil && !errcode.IsNotFound(err) {
+			return errors.Wrap(err, "GetByName")
+		}
+		// Repositories that were deleted since the job was queued, or that the user can no longer see,
+		// have no matches.
+		if repo != nil {
+			request := pipeline.BackfillRequest{Series: series, Repo: &itypes.MinimalRepo{ID: repo.ID, Name: repo.Name}, SampleTimes: sampleTimes}
+			if err := backfiller.Run(ctx, request); err != nil {
+				return errors.Wrapf(err, "backfill of repository %s", name)
+			}
+		}
+
+		if err := updateProgress(ctx, h.workerBaseStore, record.ID, i+1, results); err != nil {
+			return errors.Wrap(err, "updateProgress")
+		}
+	}
+	logger.Debug("historical query completed", log.Int("id", record.ID), log.Int("repositories", len(record.Repositories)))
+	return nil
+}
+
+// addPoints adds the values of the given points to the results at the same time.
+func addPoints(results []Point, points []store.RecordSeriesPointArgs) {
+	for _, p := range points {
+		for i := range results {
+			if results[i].Time.Equal(p.Point.Time) {
+				results[i].Value += p.Point.Value
+				break
+			}
+		}
+	}
+}
+
+// NewWorker returns a worker that computes one-off historical queries.
+func NewWorker(ctx context.Context, workerStore dbworkerstore.Store[*Job], workerBaseStore *basestore.Store, repoStore RepoStore, backfillConfig pipeline.BackfillerConfig, metrics workerutil.WorkerObservability) *workerutil.Worker[*Job] {
+	options := workerutil.WorkerOptions{
+		Name:              "insights_historical_query_worker",
+		Description:       "computes one-off historical queries of code insights",
+		NumHandlers:       1,
+		Interval:          5 * time.Second,
+		HeartbeatInterval: 15 * time.Second,
+		Metrics:           metrics,
+	}
+
+	return dbworker.NewWorker[*Job](ctx, workerStore, &historicalQueryHandler{
+		workerBaseStore: workerBaseStore,
+		repoStore:       repoStore,
+		newBackfiller: func(collect pipeline.PointsCollector) pipeline.Backfiller {
+			return pipeline.NewEphemeralBackfiller(backfillConfig, collect)
+		},
+	}, options)
+}
+
+// NewResetter returns a resetter that will reset historical query jobs if they take too long to complete.
+func NewResetter(ctx context.Context, logger log.Logger, workerStore dbworkerstore.Store[*Job], metrics dbworker.ResetterMetrics) *dbworker.Resetter[*Job] {
+	options := dbworker.ResetterOptions{
+		Name:     "insights_historical_query_worker_resetter",
+		Interval: 1 * time.Minute,
+		Metrics:  metrics,
+	}
+	return dbworker.NewResetter(logger, workerStore, options)
+}
+
+func CreateDBWorkerStore(observationCtx *observation.Context, store *basestore.Store) dbworkerstore.Store[*Job] {
+	return dbworkerstore.New(observationCtx, store.Handle(), dbworkerstore.Options[*Job]{
+		Name:              "insights_historical_query_worker_store",
+		TableName:         "insights_historical_query_jobs",
+		ColumnExpressions: jobColumns,
+		Scan:              dbworkerstore.BuildWorkerScan(scanJob),
+		OrderByExpression: sqlf.Sprintf("queued_at, id"),
+		RetryAfter:        1 * time.Minute,
+		MaxNumRetries:     2,
+		MaxNumResets:      3,
+		StalledMaxAge:     time.Second * 60,
+	})
+}
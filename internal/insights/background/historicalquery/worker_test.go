@@ -0,0 +1,151 @@
+package historicalquery
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/insights/pipeline"
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	itypes "github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSampleTimes(t *testing.T) {
+	job := &Job{
+		QueuedAt:            time.Date(2023, time.March, 15, 10, 30, 45, 0, time.UTC),
+		SampleIntervalUnit:  types.Month,
+		SampleIntervalValue: 6,
+		NumSamples:          3,
+	}
+
+	want := []time.Time{
+		time.Date(2022, time.March, 15, 10, 30, 0, 0, time.UTC),
+		time.Date(2022, time.September, 15, 10, 30, 0, 0, time.UTC),
+		time.Date(2023, time.March, 15, 10, 30, 0, 0, time.UTC),
+	}
+	if diff := cmp.Diff(want, job.SampleTimes()); diff != "" {
+		t.Errorf("unexpected sample times (-want +got):\n%s", diff)
+	}
+}
+
+func TestAddPoints(t *testing.T) {
+	first := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	second := time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC)
+	results := []Point{{Time: first}, {Time: second}}
+
+	// Points of two repositories, including one at a time that is not a sample time.
+	addPoints(results, []store.RecordSeriesPointArgs{
+		{Point: store.SeriesPoint{Time: first, Value: 1}},
+		{Point: store.SeriesPoint{Time: second, Value: 2}},
+	})
+	addPoints(results, []store.RecordSeriesPointArgs{
+		{Point: store.SeriesPoint{Time: second.In(time.FixedZone("UTC+1", 3600)), Value: 5}},
+		{Point: store.SeriesPoint{Time: second.Add(time.Hour), Value: 100}},
+	})
+
+	want := []Point{{Time: first, Value: 1}, {Time: second, Value: 7}}
+	if diff := cmp.Diff(want, results); diff != "" {
+		t.Errorf("unexpected results (-want +got):\n%s", diff)
+	}
+}
+
+func TestHandle_PrivateRepository(t *testing.T) {
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	insightsDB := database.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
+	workerBaseStore := basestore.NewWithHandle(insightsDB.Handle())
+
+	id, err := EnqueueJob(ctx, workerBaseStore, &Job{
+		UserID:              1,
+		Query:               "errors.Newf",
+		Repositories:        []string{"github.com/sourcegraph/public", "github.com/sourcegraph/private", "github.com/sourcegraph/secret"},
+		SampleIntervalUnit:  types.Month,
+		SampleIntervalValue: 1,
+		NumSamples:          2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	job, err := GetJob(ctx, workerBaseStore, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler := &historicalQueryHandler{
+		workerBaseStore: workerBaseStore,
+		repoStore: fakeRepoStore{
+			"github.com/sourcegraph/public":  {Repo: &itypes.Repo{ID: 1, Name: "github.com/sourcegraph/public"}},
+			"github.com/sourcegraph/private": {Repo: &itypes.Repo{ID: 2, Name: "github.com/sourcegraph/private", Private: true}, users: []int32{1}},
+			// The user lost access to this repository after queueing the query.
+			"github.com/sourcegraph/secret": {Repo: &itypes.Repo{ID: 3, Name: "github.com/sourcegraph/secret", Private: true}},
+		},
+		newBackfiller: func(collect pipeline.PointsCollector) pipeline.Backfiller {
+			return fakeBackfiller(func(ctx context.Context, req pipeline.BackfillRequest) error {
+				// Searches without an actor have no matches in private repositories.
+				value := 1.0
+				if !actor.FromContext(ctx).IsInternal() {
+					value = 0
+				}
+				points := make([]store.RecordSeriesPointArgs, 0, len(req.SampleTimes))
+				for _, t := range req.SampleTimes {
+					points = append(points, store.RecordSeriesPointArgs{Point: store.SeriesPoint{Time: t, Value: value}})
+				}
+				return collect(ctx, points)
+			})
+		},
+	}
+	if err := handler.Handle(ctx, logger, job); err != nil {
+		t.Fatal(err)
+	}
+
+	job, err = GetJob(ctx, workerBaseStore, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.RepositoriesCompleted != 3 {
+		t.Fatalf("unexpected number of completed repositories %d", job.RepositoriesCompleted)
+	}
+	for _, point := range job.Results {
+		if point.Value != 2 {
+			t.Errorf("expected matches in the two visible repositories at %s, got %v", point.Time, point.Value)
+		}
+	}
+}
+
+// fakeRepoStore only returns private repositories to internal actors and to the users that have access
+// to them, like the repo store does.
+type fakeRepoStore map[api.RepoName]fakeRepo
+
+type fakeRepo struct {
+	*itypes.Repo
+	users []int32
+}
+
+func (s fakeRepoStore) GetByName(ctx context.Context, name api.RepoName) (*itypes.Repo, error) {
+	repo, ok := s[name]
+	if !ok {
+		return nil, &database.RepoNotFoundErr{Name: name}
+	}
+	a := actor.FromContext(ctx)
+	if repo.Private && !a.IsInternal() && !slices.Contains(repo.users, a.UID) {
+		return nil, &database.RepoNotFoundErr{Name: name}
+	}
+	return repo.Repo, nil
+}
+
+type fakeBackfiller func(ctx context.Context, req pipeline.BackfillRequest) error
+
+func (f fakeBackfiller) Run(ctx context.Context, req pipeline.BackfillRequest) error {
+	return f(ctx, req)
+}
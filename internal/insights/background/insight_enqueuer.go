@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
 )
 
 // newInsightEnqueuer returns a background goroutine which will periodically find all of the search
@@ -81,7 +82,8 @@ func (ie *InsightEnqueuer) discoverAndEnqueueInsights(
 
 	ie.logger.Info("enqueuing indexed insight recordings")
 	// this job will do the work of both recording (permanent) queries, and snapshot (ephemeral) queries. We want to try both, so if either has a soft-failure we will attempt both.
-	recordingArgs := store.GetDataSeriesArgs{NextRecordingBefore: ie.now(), ExcludeJustInTime: true}
+	// Series sampled at tags are recorded when new tags are created, see newRevisionSeriesRecorder.
+	recordingArgs := store.GetDataSeriesArgs{NextRecordingBefore: ie.now(), ExcludeJustInTime: true, RevisionSeries: pointers.Ptr(false)}
 	recordingSeries, err := insightStore.GetDataSeries(ctx, recordingArgs)
 	if err != nil {
 		return errors.Wrap(err, "indexed insight recorder: unable to fetch series for recordings")
@@ -92,7 +94,7 @@ func (ie *InsightEnqueuer) discoverAndEnqueueInsights(
 	}
 
 	ie.logger.Info("enqueuing indexed insight snapshots")
-	snapshotArgs := store.GetDataSeriesArgs{NextSnapshotBefore: ie.now(), ExcludeJustInTime: true}
+	snapshotArgs := store.GetDataSeriesArgs{NextSnapshotBefore: ie.now(), ExcludeJustInTime: true, RevisionSeries: pointers.Ptr(false)}
 	snapshotSeries, err := insightStore.GetDataSeries(ctx, snapshotArgs)
 	if err != nil {
 		return errors.Wrap(err, "indexed insight recorder: unable to fetch series for snapshots")
@@ -148,7 +150,11 @@ func (ie *InsightEnqueuer) EnqueueSingle(
 	var modifiedQuery querybuilder.BasicQuery
 	var finalQuery string
 
-	if series.RepositoryCriteria != nil {
+	if series.GenerationMethod == types.PreciseCodeIntel {
+		// Series generated from precise code intelligence are not search queries. They are counted
+		// at the tip of every repository and filtered to the series repositories when recorded.
+		modifiedQuery = basicQuery
+	} else if series.RepositoryCriteria != nil {
 		modifiedQuery, err = querybuilder.MakeQueryWithRepoFilters(*series.RepositoryCriteria, basicQuery, true, querybuilder.CodeInsightsQueryDefaults(true)...)
 	} else if len(series.Repositories) > 0 {
 		modifiedQuery, err = querybuilder.MultiRepoQuery(basicQuery, series.Repositories, defaultQueryParams)
@@ -0,0 +1,175 @@
+package queryrunner
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/insights/query/querybuilder"
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// SymbolOccurrenceCount is the number of occurrences of a symbol in the precise code intelligence
+// data of a single repository.
+type SymbolOccurrenceCount struct {
+	RepoID   api.RepoID
+	RepoName string
+	Count    int
+}
+
+// CodeIntelClient counts the occurrences of symbols in the precise code intelligence data of
+// repositories. It generates the data of insight series with the PreciseCodeIntel generation method.
+type CodeIntelClient interface {
+	// CountAtCommit returns the number of occurrences of the symbol in the given repository at the
+	// given commit. nil is returned if there is no precise code intelligence data for the commit.
+	CountAtCommit(ctx context.Context, repo api.RepoName, commit, kind, symbol string) (*SymbolOccurrenceCount, error)
+	// CountAtTip returns the number of occurrences of the symbol at the tip of the default branch of
+	// every repository with precise code intelligence data.
+	CountAtTip(ctx context.Context, kind, symbol string) ([]SymbolOccurrenceCount, error)
+}
+
+type symbolOccurrenceCounter interface {
+	CountSymbolOccurrences(ctx context.Context, repositoryID int, commit, kind, symbol string) (int, bool, error)
+}
+
+type uploadLister interface {
+	GetUploads(ctx context.Context, opts uploadsshared.GetUploadsOptions) ([]uploadsshared.Upload, int, error)
+}
+
+type codeIntelClient struct {
+	counter         symbolOccurrenceCounter
+	uploads         uploadLister
+	repoStore       database.RepoStore
+	gitserverClient gitserver.Client
+}
+
+// NewCodeIntelClient returns a CodeIntelClient backed by the code navigation and uploads services.
+func NewCodeIntelClient(counter symbolOccurrenceCounter, uploads uploadLister, repoStore database.RepoStore, gitserverClient gitserver.Client) CodeIntelClient {
+	return &codeIntelClient{
+		counter:         counter,
+		uploads:         uploads,
+		repoStore:       repoStore,
+		gitserverClient: gitserverClient,
+	}
+}
+
+func (c *codeIntelClient) CountAtCommit(ctx context.Context, repoName api.RepoName, commit, kind, symbol string) (*SymbolOccurrenceCount, error) {
+	repo, err := c.repoStore.GetByName(ctx, repoName)
+	if err != nil {
+		return nil, errors.Wrap(err, "repoStore.GetByName")
+	}
+	return c.countAtCommit(ctx, repo.ID, string(repo.Name), commit, kind, symbol)
+}
+
+func (c *codeIntelClient) countAtCommit(ctx context.Context, repoID api.RepoID, repoName, commit, kind, symbol string) (*SymbolOccurrenceCount, error) {
+	count, found, err := c.counter.CountSymbolOccurrences(ctx, int(repoID), commit, kind, symbol)
+	if err != nil {
+		return nil, errors.Wrap(err, "CountSymbolOccurrences")
+	}
+	if !found {
+		return nil, nil
+	}
+	return &SymbolOccurrenceCount{RepoID: repoID, RepoName: repoName, Count: count}, nil
+}
+
+const codeIntelUploadsPageSize = 100
+
+func (c *codeIntelClient) CountAtTip(ctx context.Context, kind, symbol string) ([]SymbolOccurrenceCount, error) {
+	repoNames := map[api.RepoID]string{}
+	for offset := 0; ; offset += codeIntelUploadsPageSize {
+		uploads, totalCount, err := c.uploads.GetUploads(ctx, uploadsshared.GetUploadsOptions{
+			State:        "completed",
+			VisibleAtTip: true,
+			Limit:        codeIntelUploadsPageSize,
+			Offset:       offset,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "GetUploads")
+		}
+		for _, upload := range uploads {
+			repoNames[api.RepoID(upload.RepositoryID)] = upload.RepositoryName
+		}
+		if len(uploads) == 0 || offset+len(uploads) >= totalCount {
+			break
+		}
+	}
+
+	counts := make([]SymbolOccurrenceCount, 0, len(repoNames))
+	for repoID, repoName := range repoNames {
+		commit, err := c.gitserverClient.ResolveRevision(ctx, api.RepoName(repoName), "HEAD", gitserver.ResolveRevisionOptions{NoEnsureRevision: true})
+		if err != nil {
+			if errors.HasType(err, &gitdomain.RevisionNotFoundError{}) || gitdomain.IsRepoNotExist(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "resolving default branch of %s", repoName)
+		}
+		count, err := c.countAtCommit(ctx, repoID, repoName, string(commit), kind, symbol)
+		if err != nil {
+			return nil, err
+		}
+		if count != nil {
+			counts = append(counts, *count)
+		}
+	}
+	return counts, nil
+}
+
+func generateCodeIntelRecordings(ctx context.Context, job *SearchJob, recordTime time.Time, client CodeIntelClient, logger log.Logger) ([]store.RecordSeriesPointArgs, error) {
+	q, err := querybuilder.ParseCodeIntelQuery(job.SearchQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	var counts []SymbolOccurrenceCount
+	if q.Repo != "" {
+		count, err := client.CountAtCommit(ctx, api.RepoName(q.Repo), q.Revision, q.Kind, q.Symbol)
+		if err != nil {
+			return nil, err
+		}
+		if count != nil {
+			counts = append(counts, *count)
+		}
+	} else {
+		counts, err = client.CountAtTip(ctx, q.Kind, q.Symbol)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	checker := authz.DefaultSubRepoPermsChecker
+	var recordings []store.RecordSeriesPointArgs
+
+	for _, count := range counts {
+		// sub-repo permissions filtering. If the repo supports it, then it should be excluded from the results
+		subRepoEnabled, subRepoErr := authz.SubRepoEnabledForRepoID(ctx, checker, count.RepoID)
+		if subRepoErr != nil {
+			logger.Error("sub-repo permissions check errored", log.String("seriesID", job.SeriesID), log.String("repo", count.RepoName), log.Error(subRepoErr))
+			continue
+		}
+		if subRepoEnabled {
+			continue
+		}
+		recordings = append(recordings, toRecording(job, float64(count.Count), recordTime, count.RepoName, count.RepoID, nil)...)
+	}
+
+	return recordings, nil
+}
+
+func makeCodeIntelHandler(client CodeIntelClient) InsightsHandler {
+	return func(ctx context.Context, job *SearchJob, series *types.InsightSeries, recordTime time.Time) ([]store.RecordSeriesPointArgs, error) {
+		recordings, err := generateCodeIntelRecordings(ctx, job, recordTime, client, log.Scoped("CodeIntelRecordingsGenerator"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "codeIntelHandler")
+		}
+		return recordings, nil
+	}
+}
@@ -0,0 +1,103 @@
+package queryrunner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hexops/autogold/v2"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+type fakeCodeIntelClient struct {
+	atCommit map[string]*SymbolOccurrenceCount
+	atTip    []SymbolOccurrenceCount
+}
+
+func (c *fakeCodeIntelClient) CountAtCommit(_ context.Context, repo api.RepoName, commit, kind, symbol string) (*SymbolOccurrenceCount, error) {
+	return c.atCommit[string(repo)+"@"+commit+" "+kind+":"+symbol], nil
+}
+
+func (c *fakeCodeIntelClient) CountAtTip(_ context.Context, kind, symbol string) ([]SymbolOccurrenceCount, error) {
+	return c.atTip, nil
+}
+
+func TestGenerateCodeIntelRecordings(t *testing.T) {
+	date := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	client := &fakeCodeIntelClient{
+		atCommit: map[string]*SymbolOccurrenceCount{
+			"github.com/sourcegraph/sourcegraph@abc123 references:scip-go gomod example v1 Foo().": {
+				RepoID:   11,
+				RepoName: "github.com/sourcegraph/sourcegraph",
+				Count:    7,
+			},
+		},
+		atTip: []SymbolOccurrenceCount{
+			{RepoID: 11, RepoName: "github.com/sourcegraph/sourcegraph", Count: 9},
+			{RepoID: 12, RepoName: "github.com/sourcegraph/about", Count: 2},
+		},
+	}
+
+	t.Run("backfill job scoped to a repository", func(t *testing.T) {
+		job := SearchJob{
+			SeriesID:        "testseries1",
+			SearchQuery:     "repo:github.com/sourcegraph/sourcegraph@abc123 references:scip-go gomod example v1 Foo().",
+			RecordTime:      &date,
+			PersistMode:     "record",
+			DependentFrames: []time.Time{date.AddDate(0, -1, 0)},
+		}
+
+		recordings, err := generateCodeIntelRecordings(context.Background(), &job, date, client, logtest.Scoped(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		autogold.Expect([]string{
+			"github.com/sourcegraph/sourcegraph 11 2021-11-01 00:00:00 +0000 UTC  7.000000",
+			"github.com/sourcegraph/sourcegraph 11 2021-12-01 00:00:00 +0000 UTC  7.000000",
+		}).Equal(t, stringify(recordings))
+	})
+
+	t.Run("repository without precise data", func(t *testing.T) {
+		job := SearchJob{
+			SeriesID:    "testseries1",
+			SearchQuery: "repo:github.com/sourcegraph/about@def456 references:scip-go gomod example v1 Foo().",
+			RecordTime:  &date,
+			PersistMode: "record",
+		}
+
+		recordings, err := generateCodeIntelRecordings(context.Background(), &job, date, client, logtest.Scoped(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(recordings) != 0 {
+			t.Errorf("expected no recordings, got %v", stringify(recordings))
+		}
+	})
+
+	t.Run("snapshot job at tip", func(t *testing.T) {
+		job := SearchJob{
+			SeriesID:    "testseries1",
+			SearchQuery: "references:scip-go gomod example v1 Foo().",
+			PersistMode: "snapshot",
+		}
+
+		recordings, err := generateCodeIntelRecordings(context.Background(), &job, date, client, logtest.Scoped(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		autogold.Expect([]string{
+			"github.com/sourcegraph/about 12 2021-12-01 00:00:00 +0000 UTC  2.000000",
+			"github.com/sourcegraph/sourcegraph 11 2021-12-01 00:00:00 +0000 UTC  9.000000",
+		}).Equal(t, stringify(recordings))
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		job := SearchJob{SeriesID: "testseries1", SearchQuery: "content:foo"}
+
+		if _, err := generateCodeIntelRecordings(context.Background(), &job, date, client, logtest.Scoped(t)); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
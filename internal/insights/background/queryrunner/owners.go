@@ -0,0 +1,103 @@
+package queryrunner
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/insights/query/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// fileOwnersResolver returns the owners of a file, see own.FileOwners.
+type fileOwnersResolver interface {
+	Owners(ctx context.Context, repoName api.RepoName, repoID api.RepoID, commitID api.CommitID, path string) ([]string, error)
+}
+
+type streamFilesProvider func(context.Context, string) (*streaming.FileTabulationResult, error)
+
+// generateOwnerRecordingsStream records the number of matches of the search query per repository
+// and owner of the matched files. Matches in files without an owner are recorded under
+// types.NO_OWNER_TEXT.
+func generateOwnerRecordingsStream(ctx context.Context, job *SearchJob, recordTime time.Time, provider streamFilesProvider, owners fileOwnersResolver, logger log.Logger) ([]store.RecordSeriesPointArgs, error) {
+	tabulationResult, err := provider(ctx, job.SearchQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	tr := *tabulationResult
+	if len(tr.SkippedReasons) > 0 {
+		logger.Error("search encountered skipped events", log.String("seriesID", job.SeriesID), log.String("reasons", fmt.Sprintf("%v", tr.SkippedReasons)), log.String("query", job.SearchQuery))
+	}
+	if len(tr.Errors) > 0 {
+		return nil, classifiedError(tr.Errors, types.SearchOwners)
+	}
+	if tr.DidTimeout {
+		return nil, SearchTimeoutError
+	}
+	if len(tr.Alerts) > 0 {
+		return nil, errors.Errorf("streaming search: alerts: %v", tr.Alerts)
+	}
+
+	type repoOwner struct {
+		repoID api.RepoID
+		owner  string
+	}
+	counts := map[repoOwner]int{}
+	repoNames := map[api.RepoID]string{}
+	// excluded tracks the repositories whose matches are dropped because of sub-repo permissions.
+	excluded := map[api.RepoID]bool{}
+
+	checker := authz.DefaultSubRepoPermsChecker
+	for _, file := range tr.Files {
+		repoID := api.RepoID(file.RepositoryID)
+		if _, ok := repoNames[repoID]; !ok {
+			// sub-repo permissions filtering. If the repo supports it, then it should be excluded from search results
+			subRepoEnabled, subRepoErr := authz.SubRepoEnabledForRepoID(ctx, checker, repoID)
+			if subRepoErr != nil {
+				logger.Error("sub-repo permissions check errored", log.String("seriesID", job.SeriesID), log.String("repo", file.RepositoryName), log.Error(subRepoErr))
+			}
+			excluded[repoID] = subRepoErr != nil || subRepoEnabled
+			repoNames[repoID] = file.RepositoryName
+		}
+		if excluded[repoID] {
+			continue
+		}
+
+		labels, err := owners.Owners(ctx, api.RepoName(file.RepositoryName), repoID, api.CommitID(file.Commit), file.Path)
+		if err != nil {
+			return nil, errors.Wrap(err, "Owners")
+		}
+		if len(labels) == 0 {
+			labels = []string{types.NO_OWNER_TEXT}
+		}
+		for _, label := range labels {
+			counts[repoOwner{repoID: repoID, owner: label}] += file.MatchCount
+		}
+	}
+
+	var recordings []store.RecordSeriesPointArgs
+	for key, count := range counts {
+		owner := key.owner
+		recordings = append(recordings, toRecording(job, float64(count), recordTime, repoNames[key.repoID], key.repoID, &owner)...)
+	}
+	return recordings, nil
+}
+
+func makeOwnersHandler(provider streamFilesProvider, newOwners func() fileOwnersResolver) InsightsHandler {
+	return func(ctx context.Context, job *SearchJob, series *types.InsightSeries, recordTime time.Time) ([]store.RecordSeriesPointArgs, error) {
+		// Ownership data is cached by the resolver, so a new one is used for every job to pick
+		// up changes to CODEOWNERS files and assigned owners.
+		recordings, err := generateOwnerRecordingsStream(ctx, job, recordTime, provider, newOwners(), log.Scoped("OwnerRecordingsGenerator"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "ownersHandler")
+		}
+		return recordings, nil
+	}
+}
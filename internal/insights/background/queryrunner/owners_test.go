@@ -0,0 +1,57 @@
+package queryrunner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hexops/autogold/v2"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/insights/query/streaming"
+)
+
+type mockFileOwners map[string][]string
+
+func (m mockFileOwners) Owners(_ context.Context, repoName api.RepoName, _ api.RepoID, _ api.CommitID, path string) ([]string, error) {
+	return m[string(repoName)+"/"+path], nil
+}
+
+func TestGenerateOwnerRecordingsStream(t *testing.T) {
+	date := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	job := SearchJob{
+		SeriesID:    "testseries1",
+		SearchQuery: "searchit",
+		RecordTime:  &date,
+		PersistMode: "record",
+	}
+
+	mocked := func(context.Context, string) (*streaming.FileTabulationResult, error) {
+		return &streaming.FileTabulationResult{
+			Files: []streaming.FileMatch{
+				{RepositoryID: 11, RepositoryName: "github.com/sourcegraph/sourcegraph", Path: "cmd/main.go", MatchCount: 3},
+				{RepositoryID: 11, RepositoryName: "github.com/sourcegraph/sourcegraph", Path: "lib/lib.go", MatchCount: 2},
+				{RepositoryID: 11, RepositoryName: "github.com/sourcegraph/sourcegraph", Path: "README.md", MatchCount: 1},
+				{RepositoryID: 12, RepositoryName: "github.com/sourcegraph/handbook", Path: "index.md", MatchCount: 4},
+			},
+			TotalCount: 10,
+		}, nil
+	}
+	owners := mockFileOwners{
+		"github.com/sourcegraph/sourcegraph/cmd/main.go": {"@alice", "@sourcegraph/search"},
+		"github.com/sourcegraph/sourcegraph/lib/lib.go":  {"@sourcegraph/search"},
+		"github.com/sourcegraph/handbook/index.md":       {"@alice"},
+	}
+
+	recordings, err := generateOwnerRecordingsStream(context.Background(), &job, date, mocked, owners, logtest.Scoped(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Expect([]string{
+		"github.com/sourcegraph/handbook 12 2021-12-01 00:00:00 +0000 UTC @alice 4.000000",
+		"github.com/sourcegraph/sourcegraph 11 2021-12-01 00:00:00 +0000 UTC @alice 3.000000",
+		"github.com/sourcegraph/sourcegraph 11 2021-12-01 00:00:00 +0000 UTC @sourcegraph/search 5.000000",
+		"github.com/sourcegraph/sourcegraph 11 2021-12-01 00:00:00 +0000 UTC No owner 1.000000",
+	}).Equal(t, stringify(recordings))
+}
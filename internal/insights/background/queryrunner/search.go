@@ -11,16 +11,21 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/insights/discovery"
 	"github.com/sourcegraph/sourcegraph/internal/insights/query/streaming"
 	"github.com/sourcegraph/sourcegraph/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/own"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 )
 
-func GetSearchHandlers() map[types.GenerationMethod]InsightsHandler {
+// GetSearchHandlers returns the handlers for each generation method of insight series. Series generated
+// from precise code intelligence are only handled if codeIntel is non-nil, series grouped by file owner
+// only if db is non-nil.
+func GetSearchHandlers(codeIntel CodeIntelClient, db database.DB) map[types.GenerationMethod]InsightsHandler {
 	searchStream := func(ctx context.Context, query string) (*streaming.TabulationResult, error) {
 		tr, ctx := trace.New(ctx, "CodeInsightsSearch.searchStream")
 		defer tr.End()
@@ -34,6 +39,19 @@ func GetSearchHandlers() map[types.GenerationMethod]InsightsHandler {
 		return streamResults, nil
 	}
 
+	fileSearchStream := func(ctx context.Context, query string) (*streaming.FileTabulationResult, error) {
+		tr, ctx := trace.New(ctx, "CodeInsightsSearch.fileSearchStream")
+		defer tr.End()
+
+		decoder, streamResults := streaming.FileTabulationDecoder()
+		err := streaming.Search(ctx, query, nil, decoder)
+		if err != nil {
+			return nil, errors.Wrap(err, "streaming.Search")
+		}
+		tr.AddEvent("search results", attribute.Int("count", streamResults.TotalCount), attribute.Bool("timeout", streamResults.DidTimeout), attribute.Int("file_count", len(streamResults.Files)))
+		return streamResults, nil
+	}
+
 	computeSearchStream := func(ctx context.Context, query string) (*streaming.ComputeTabulationResult, error) {
 		decoder, streamResults := streaming.MatchContextComputeDecoder()
 		tr, ctx := trace.New(ctx, "CodeInsightsSearch.computeMatchContextSearchStream")
@@ -56,12 +74,21 @@ func GetSearchHandlers() map[types.GenerationMethod]InsightsHandler {
 		return streamResults, nil
 	}
 
-	return map[types.GenerationMethod]InsightsHandler{
+	handlers := map[types.GenerationMethod]InsightsHandler{
 		types.MappingCompute: makeMappingComputeHandler(computeTextExtraSearch),
 		types.SearchCompute:  makeComputeHandler(computeSearchStream),
 		types.Search:         makeSearchHandler(searchStream),
 	}
-
+	if codeIntel != nil {
+		handlers[types.PreciseCodeIntel] = makeCodeIntelHandler(codeIntel)
+	}
+	if db != nil {
+		gitserverClient := gitserver.NewClient("insights.owners")
+		handlers[types.SearchOwners] = makeOwnersHandler(fileSearchStream, func() fileOwnersResolver {
+			return own.NewFileOwners(gitserverClient, db)
+		})
+	}
+	return handlers
 }
 
 func toRecording(record *SearchJob, value float64, recordTime time.Time, repoName string, repoID api.RepoID, capture *string) []store.RecordSeriesPointArgs {
@@ -224,7 +251,7 @@ func (r *workHandler) persistRecordings(ctx context.Context, job *SearchJob, ser
 		}
 		snapshot = true
 	}
-	seriesRecordingTimes.RecordingTimes = append(seriesRecordingTimes.RecordingTimes, types.RecordingTime{recordTime, snapshot})
+	seriesRecordingTimes.RecordingTimes = append(seriesRecordingTimes.RecordingTimes, types.RecordingTime{Timestamp: recordTime, Snapshot: snapshot})
 
 	// Newly queued queries should be scoped to correct repos however leaving filtering
 	// in place to ensure any older queued jobs get filtered properly. It's a noop for global insights.
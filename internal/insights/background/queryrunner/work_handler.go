@@ -11,6 +11,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/locker"
 	"github.com/sourcegraph/sourcegraph/internal/insights/discovery"
 	"github.com/sourcegraph/sourcegraph/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/internal/insights/types"
@@ -36,6 +37,12 @@ type workHandler struct {
 	seriesCache map[string]*types.InsightSeries
 
 	searchHandlers map[types.GenerationMethod]InsightsHandler
+	alertEvaluator AlertEvaluator
+}
+
+// AlertEvaluator evaluates the alert rules of an insight series after a new data point was recorded.
+type AlertEvaluator interface {
+	EvaluateSeries(ctx context.Context, series *types.InsightSeries) error
 }
 
 type InsightsHandler func(ctx context.Context, job *SearchJob, series *types.InsightSeries, recordTime time.Time) ([]store.RecordSeriesPointArgs, error)
@@ -142,9 +149,71 @@ func (r *workHandler) Handle(ctx context.Context, logger log.Logger, record *Job
 		return err
 	}
 
-	return r.persistRecordings(ctx, &job.SearchJob, series, recordings, recordTime)
+	if err := r.persistRecordings(ctx, &job.SearchJob, series, recordings, recordTime); err != nil {
+		return err
+	}
+
+	// Failing to evaluate the alert rules must not fail the job, as the data point was recorded
+	// successfully.
+	if err := r.evaluateAlertRules(ctx, ss, job, series, isGlobal); err != nil {
+		logger.Error("failed to evaluate insight series alert rules", log.String("seriesId", series.SeriesID), log.Error(err))
+	}
+	return nil
+}
+
+// evaluateAlertRules evaluates the alert rules of the series after the job recorded a data point.
+// Snapshots are not persisted, so they are never evaluated. Jobs with a record time, like the
+// recordings at new tags of a repository, may only record a part of a data point, so the rules are
+// evaluated once the last pending job of the series has recorded its part.
+func (r *workHandler) evaluateAlertRules(ctx context.Context, ss *basestore.Store, job *Job, series *types.InsightSeries, isGlobal bool) error {
+	if r.alertEvaluator == nil || job.PersistMode != string(store.RecordMode) {
+		return nil
+	}
+	if !isGlobal {
+		last, err := markJobRecorded(ctx, ss, job)
+		if err != nil {
+			return errors.Wrap(err, "markJobRecorded")
+		}
+		if !last {
+			return nil
+		}
+	}
+	return r.alertEvaluator.EvaluateSeries(ctx, series)
+}
+
+// markJobRecorded marks the job as recorded and returns true if no other job of its series is still
+// pending. Jobs that finish at the same time are still processing while they are marked, so the jobs
+// of a series are marked one at a time under an advisory lock. This way, exactly one of them sees no
+// other pending jobs.
+func markJobRecorded(ctx context.Context, ss *basestore.Store, job *Job) (last bool, err error) {
+	l := locker.NewWith(ss, "insights_query_runner_jobs_recorded")
+	_, unlock, err := l.Lock(ctx, locker.StringKey(job.SeriesID), true)
+	if err != nil {
+		return false, err
+	}
+	defer func() { err = unlock(err) }()
+
+	if err := ss.Exec(ctx, sqlf.Sprintf(markJobRecordedSql, job.ID)); err != nil {
+		return false, err
+	}
+	pending, _, err := basestore.ScanFirstBool(ss.Query(ctx, sqlf.Sprintf(hasPendingJobsSql, job.SeriesID, job.ID)))
+	if err != nil {
+		return false, err
+	}
+	return !pending, nil
 }
 
+const markJobRecordedSql = `
+UPDATE insights_query_runner_jobs SET recorded_at = NOW() WHERE id = %s
+`
+
+const hasPendingJobsSql = `
+SELECT EXISTS (
+	SELECT 1 FROM insights_query_runner_jobs
+	WHERE series_id = %s AND id != %s AND persist_mode = 'record' AND recorded_at IS NULL AND state IN ('queued', 'processing', 'errored')
+)
+`
+
 func TranslateIncompleteReasons(err error) store.IncompleteReason {
 	if errors.Is(err, SearchTimeoutError) {
 		return store.ReasonTimeout
@@ -180,3 +180,116 @@ func Test_HandleWithTerminalError(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+type fakeAlertEvaluator struct {
+	evaluated []string
+}
+
+func (f *fakeAlertEvaluator) EvaluateSeries(_ context.Context, series *types.InsightSeries) error {
+	f.evaluated = append(f.evaluated, series.SeriesID)
+	return nil
+}
+
+func Test_HandleEvaluatesAlertRules(t *testing.T) {
+	logger := logtest.Scoped(t)
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
+	postgres := database.NewDB(logger, dbtest.NewDB(t))
+	metadataStore := store.NewInsightStore(insightsDB)
+	ctx := context.Background()
+
+	tss := store.New(insightsDB, store.NewInsightPermissionStore(postgres))
+	workerStore := CreateDBWorkerStore(observation.TestContextTB(t), basestore.NewWithHandle(postgres.Handle()))
+
+	handlers := map[types.GenerationMethod]InsightsHandler{
+		types.Search: func(ctx context.Context, job *SearchJob, series *types.InsightSeries, recordTime time.Time) ([]store.RecordSeriesPointArgs, error) {
+			return nil, nil
+		},
+	}
+	evaluator := &fakeAlertEvaluator{}
+	handler := &workHandler{
+		insightsStore:   tss,
+		baseWorkerStore: workerStore,
+		metadadataStore: metadataStore,
+		limiter:         ratelimit.NewInstrumentedLimiter("asdf", rate.NewLimiter(10, 5)),
+		logger:          logger,
+		mu:              sync.RWMutex{},
+		seriesCache:     make(map[string]*types.InsightSeries),
+		searchHandlers:  handlers,
+		alertEvaluator:  evaluator,
+	}
+
+	setUp := func(t *testing.T, seriesID string) {
+		_, err := metadataStore.CreateSeries(ctx, types.InsightSeries{
+			SeriesID:            seriesID,
+			Query:               "findme",
+			SampleIntervalUnit:  string(types.Month),
+			SampleIntervalValue: 1,
+			GenerationMethod:    types.Search,
+		})
+		require.NoError(t, err)
+	}
+	queueIt := func(t *testing.T, seriesID string, recordTime *time.Time, mode store.PersistMode) *Job {
+		job := &Job{
+			SearchJob: SearchJob{
+				SeriesID:    seriesID,
+				SearchQuery: "findme",
+				RecordTime:  recordTime,
+				PersistMode: string(mode),
+			},
+			State:    "queued",
+			Cost:     10,
+			Priority: 10,
+		}
+		id, err := EnqueueJob(ctx, basestore.NewWithHandle(workerStore.Handle()), job)
+		require.NoError(t, err)
+		job.ID = id
+		return job
+	}
+	handle := func(t *testing.T, job *Job) {
+		require.NoError(t, handler.Handle(ctx, logger, job))
+		_, err := workerStore.MarkComplete(ctx, job.ID, store2.MarkFinalOptions{})
+		require.NoError(t, err)
+	}
+
+	t.Run("global recording", func(t *testing.T) {
+		evaluator.evaluated = nil
+		setUp(t, "global")
+		handle(t, queueIt(t, "global", nil, store.RecordMode))
+		require.Equal(t, []string{"global"}, evaluator.evaluated)
+	})
+
+	t.Run("snapshot", func(t *testing.T) {
+		evaluator.evaluated = nil
+		setUp(t, "snapshot")
+		handle(t, queueIt(t, "snapshot", nil, store.SnapshotMode))
+		require.Empty(t, evaluator.evaluated)
+	})
+
+	t.Run("recordings at a record time", func(t *testing.T) {
+		evaluator.evaluated = nil
+		setUp(t, "repo")
+		recordTime := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+		first := queueIt(t, "repo", &recordTime, store.RecordMode)
+		second := queueIt(t, "repo", &recordTime, store.RecordMode)
+
+		// The data point is only complete once the last job recorded its part.
+		handle(t, first)
+		require.Empty(t, evaluator.evaluated)
+		handle(t, second)
+		require.Equal(t, []string{"repo"}, evaluator.evaluated)
+	})
+
+	t.Run("recordings finishing together", func(t *testing.T) {
+		evaluator.evaluated = nil
+		setUp(t, "together")
+		recordTime := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+		first := queueIt(t, "together", &recordTime, store.RecordMode)
+		second := queueIt(t, "together", &recordTime, store.RecordMode)
+
+		// Neither job is completed before the other one recorded its part, so the rules are
+		// evaluated by whichever job records its part last.
+		require.NoError(t, handler.Handle(ctx, logger, first))
+		require.NoError(t, handler.Handle(ctx, logger, second))
+		require.Equal(t, []string{"together"}, evaluator.evaluated)
+	})
+}
@@ -0,0 +1,171 @@
+package background
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	internalGitserver "github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/insights/background/queryrunner"
+	"github.com/sourcegraph/sourcegraph/internal/insights/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/insights/priority"
+	"github.com/sourcegraph/sourcegraph/internal/insights/query/querybuilder"
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/timeseries"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+// newRevisionSeriesRecorder returns a background goroutine which periodically looks for new tags
+// in the repositories of series that are sampled at tags, and enqueues work for the query runner
+// to record the series at those tags. How often a series is checked for new tags depends on its
+// sample interval.
+func newRevisionSeriesRecorder(ctx context.Context, workerBaseStore *basestore.Store, insightStore store.DataSeriesStore, seriesStore store.Interface, gitserverClient internalGitserver.Client, logger log.Logger) goroutine.BackgroundRoutine {
+	recorder := &revisionSeriesRecorder{
+		logger:       logger,
+		now:          time.Now,
+		insightStore: insightStore,
+		seriesStore:  seriesStore,
+		matchingTags: func(ctx context.Context, repoName api.RepoName, pattern string) ([]*gitdomain.Tag, error) {
+			return gitserver.MatchingTags(ctx, gitserverClient, repoName, pattern)
+		},
+		enqueueQueryRunnerJobs: func(ctx context.Context, jobs []*queryrunner.Job, beforeCommit func(context.Context) error) (err error) {
+			tx, err := workerBaseStore.Transact(ctx)
+			if err != nil {
+				return err
+			}
+			defer func() { err = tx.Done(err) }()
+
+			for _, job := range jobs {
+				if _, err := queryrunner.EnqueueJob(ctx, tx, job); err != nil {
+					return err
+				}
+			}
+			return beforeCommit(ctx)
+		},
+	}
+
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		goroutine.HandlerFunc(recorder.recordNewRevisions),
+		goroutine.WithName("insights.revision_series_recorder"),
+		goroutine.WithDescription("enqueues recording query jobs for series sampled at tags when new tags are created"),
+		goroutine.WithInterval(1*time.Hour),
+	)
+}
+
+type revisionSeriesRecorder struct {
+	logger log.Logger
+
+	now          func() time.Time
+	insightStore store.DataSeriesStore
+	seriesStore  store.Interface
+	matchingTags func(ctx context.Context, repoName api.RepoName, pattern string) ([]*gitdomain.Tag, error)
+	// enqueueQueryRunnerJobs enqueues the jobs in a transaction that calls beforeCommit before it
+	// is committed, so that no job is visible to the query runner before beforeCommit succeeded.
+	enqueueQueryRunnerJobs func(ctx context.Context, jobs []*queryrunner.Job, beforeCommit func(context.Context) error) error
+}
+
+func (r *revisionSeriesRecorder) recordNewRevisions(ctx context.Context) error {
+	// Series are only recorded once their backfill completed, which records their first tags.
+	args := store.GetDataSeriesArgs{NextRecordingBefore: r.now(), ExcludeJustInTime: true, BackfillComplete: true, RevisionSeries: pointers.Ptr(true)}
+	dataSeries, err := r.insightStore.GetDataSeries(ctx, args)
+	if err != nil {
+		return errors.Wrap(err, "revision series recorder: unable to fetch series")
+	}
+
+	var multi error
+	for _, series := range dataSeries {
+		if err := r.recordSeries(ctx, series); err != nil {
+			multi = errors.Append(multi, errors.Wrapf(err, "series_id: %s", series.SeriesID))
+		}
+	}
+	return multi
+}
+
+// recordSeries enqueues a recording of the series at each tag that follows the tags it was
+// recorded at so far.
+func (r *revisionSeriesRecorder) recordSeries(ctx context.Context, series types.InsightSeries) error {
+	if series.RevisionPattern == nil || len(series.Repositories) != 1 {
+		return errors.New("series sampled at tags must have exactly one repository")
+	}
+	repoName := series.Repositories[0]
+
+	recorded, err := r.seriesStore.GetInsightSeriesRecordingTimes(ctx, series.ID, store.SeriesPointsOpts{})
+	if err != nil {
+		return errors.Wrap(err, "GetInsightSeriesRecordingTimes")
+	}
+
+	tags, err := r.matchingTags(ctx, api.RepoName(repoName), *series.RevisionPattern)
+	if err != nil {
+		return errors.Wrap(err, "MatchingTags")
+	}
+	var after time.Time
+	if len(recorded.RecordingTimes) > 0 {
+		after = recorded.RecordingTimes[len(recorded.RecordingTimes)-1].Timestamp
+	}
+	recordings := timeseries.MakeRecordingsFromRevisions(tagsAfter(tags, recorded.RecordingTimes), after)
+
+	jobs := make([]*queryrunner.Job, 0, len(recordings))
+	for _, recording := range recordings {
+		query, err := querybuilder.SingleRepoQuery(querybuilder.BasicQuery(series.Query), repoName, recording.Revision, querybuilder.CodeInsightsQueryDefaults(false))
+		if err != nil {
+			return errors.Wrap(err, "SingleRepoQuery")
+		}
+		recordTime := recording.Timestamp
+		jobs = append(jobs, &queryrunner.Job{
+			SearchJob: queryrunner.SearchJob{
+				SeriesID:    series.SeriesID,
+				SearchQuery: query.String(),
+				RecordTime:  &recordTime,
+				PersistMode: string(store.RecordMode),
+			},
+			State:    "queued",
+			Priority: int(priority.High),
+			Cost:     int(priority.Indexed),
+		})
+	}
+
+	if len(jobs) > 0 {
+		// The recording times have to be stored before the jobs run, otherwise the query runner
+		// stores them without their tag. They are stored before the jobs are committed, so that
+		// the jobs are rolled back if they can't be stored.
+		err = r.enqueueQueryRunnerJobs(ctx, jobs, func(ctx context.Context) error {
+			return r.seriesStore.SetInsightSeriesRecordingTimes(ctx, []types.InsightSeriesRecordingTimes{
+				{InsightSeriesID: series.ID, RecordingTimes: recordings},
+			})
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to enqueue recordings")
+		}
+	}
+
+	if _, err := r.insightStore.StampRecording(ctx, series); err != nil {
+		return errors.Wrap(err, "StampRecording")
+	}
+	if len(recordings) > 0 {
+		r.logger.Info("queued recordings at new tags", log.String("seriesID", series.SeriesID), log.Int("tags", len(recordings)))
+	}
+	return nil
+}
+
+// tagsAfter returns the tags that follow the last of the tags that a series was recorded at. All
+// tags are returned if none of the recorded tags exist anymore, or if none were recorded yet.
+func tagsAfter(tags []*gitdomain.Tag, recorded []types.RecordingTime) []*gitdomain.Tag {
+	revisions := make(map[string]struct{}, len(recorded))
+	for _, r := range recorded {
+		revisions[r.Revision] = struct{}{}
+	}
+	for i := len(tags) - 1; i >= 0; i-- {
+		if _, ok := revisions[tags[i].Name]; ok {
+			return tags[i+1:]
+		}
+	}
+	return tags
+}
@@ -0,0 +1,138 @@
+package background
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/insights/background/queryrunner"
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+func TestRevisionSeriesRecorder(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 12, 30, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	series := types.InsightSeries{
+		ID:              1,
+		SeriesID:        "series1",
+		Query:           "TODO",
+		Repositories:    []string{"github.com/sourcegraph/sourcegraph"},
+		RevisionPattern: pointers.Ptr("v*"),
+	}
+	insightStore := store.NewMockDataSeriesStore()
+	insightStore.GetDataSeriesFunc.SetDefaultReturn([]types.InsightSeries{series}, nil)
+
+	seriesStore := store.NewMockInterface()
+	seriesStore.GetInsightSeriesRecordingTimesFunc.SetDefaultReturn(types.InsightSeriesRecordingTimes{
+		InsightSeriesID: 1,
+		RecordingTimes: []types.RecordingTime{
+			{Timestamp: now.Add(-10 * day), Revision: "v1.0.0"},
+			{Timestamp: now.Add(-5 * day), Revision: "v1.1.0"},
+		},
+	}, nil)
+
+	var enqueued []*queryrunner.Job
+	var recordingTimesStored bool
+	recorder := &revisionSeriesRecorder{
+		logger:       logtest.Scoped(t),
+		now:          func() time.Time { return now },
+		insightStore: insightStore,
+		seriesStore:  seriesStore,
+		matchingTags: func(ctx context.Context, repoName api.RepoName, pattern string) ([]*gitdomain.Tag, error) {
+			return []*gitdomain.Tag{
+				{Name: "v1.0.0", CreatorDate: now.Add(-10 * day)},
+				{Name: "v1.1.0", CreatorDate: now.Add(-5 * day)},
+				{Name: "v1.2.0", CreatorDate: now.Add(-1 * day)},
+			}, nil
+		},
+		enqueueQueryRunnerJobs: func(ctx context.Context, jobs []*queryrunner.Job, beforeCommit func(context.Context) error) error {
+			if err := beforeCommit(ctx); err != nil {
+				return err
+			}
+			enqueued = append(enqueued, jobs...)
+			recordingTimesStored = true
+			return nil
+		},
+	}
+
+	require.NoError(t, recorder.recordNewRevisions(ctx))
+
+	require.Equal(t, pointers.Ptr(true), insightStore.GetDataSeriesFunc.History()[0].Arg1.RevisionSeries)
+	require.True(t, insightStore.GetDataSeriesFunc.History()[0].Arg1.BackfillComplete)
+	require.True(t, recordingTimesStored)
+	require.Len(t, seriesStore.SetInsightSeriesRecordingTimesFunc.History(), 1)
+	require.Equal(t, []types.InsightSeriesRecordingTimes{{
+		InsightSeriesID: 1,
+		RecordingTimes:  []types.RecordingTime{{Timestamp: now.Add(-1 * day), Revision: "v1.2.0"}},
+	}}, seriesStore.SetInsightSeriesRecordingTimesFunc.History()[0].Arg1)
+
+	require.Len(t, enqueued, 1)
+	require.Equal(t, "fork:yes archived:yes patterntype:literal count:99999999 TODO repo:^github\\.com/sourcegraph/sourcegraph$@v1.2.0", enqueued[0].SearchQuery)
+	require.Equal(t, now.Add(-1*day), *enqueued[0].RecordTime)
+	require.Len(t, insightStore.StampRecordingFunc.History(), 1)
+
+	t.Run("no recorded tags", func(t *testing.T) {
+		seriesStore.GetInsightSeriesRecordingTimesFunc.SetDefaultReturn(types.InsightSeriesRecordingTimes{InsightSeriesID: 1}, nil)
+		enqueued = nil
+
+		require.NoError(t, recorder.recordNewRevisions(ctx))
+
+		require.Equal(t, []types.InsightSeriesRecordingTimes{{
+			InsightSeriesID: 1,
+			RecordingTimes: []types.RecordingTime{
+				{Timestamp: now.Add(-10 * day), Revision: "v1.0.0"},
+				{Timestamp: now.Add(-5 * day), Revision: "v1.1.0"},
+				{Timestamp: now.Add(-1 * day), Revision: "v1.2.0"},
+			},
+		}}, seriesStore.SetInsightSeriesRecordingTimesFunc.History()[1].Arg1)
+		require.Len(t, enqueued, 3)
+	})
+
+	t.Run("recorded tags deleted", func(t *testing.T) {
+		seriesStore.GetInsightSeriesRecordingTimesFunc.SetDefaultReturn(types.InsightSeriesRecordingTimes{
+			InsightSeriesID: 1,
+			RecordingTimes:  []types.RecordingTime{{Timestamp: now.Add(-7 * day), Revision: "v0.9.0"}},
+		}, nil)
+		enqueued = nil
+
+		require.NoError(t, recorder.recordNewRevisions(ctx))
+
+		require.Equal(t, []types.InsightSeriesRecordingTimes{{
+			InsightSeriesID: 1,
+			RecordingTimes: []types.RecordingTime{
+				{Timestamp: now.Add(-7*day + time.Second), Revision: "v1.0.0"},
+				{Timestamp: now.Add(-5 * day), Revision: "v1.1.0"},
+				{Timestamp: now.Add(-1 * day), Revision: "v1.2.0"},
+			},
+		}}, seriesStore.SetInsightSeriesRecordingTimesFunc.History()[2].Arg1)
+		require.Len(t, enqueued, 3)
+	})
+
+	t.Run("recording times not stored", func(t *testing.T) {
+		seriesStore.SetInsightSeriesRecordingTimesFunc.SetDefaultReturn(errors.New("boom"))
+		enqueued = nil
+
+		require.Error(t, recorder.recordNewRevisions(ctx))
+		require.Empty(t, enqueued)
+		require.Len(t, insightStore.StampRecordingFunc.History(), 3)
+	})
+}
+
+func TestTagsAfter(t *testing.T) {
+	tags := []*gitdomain.Tag{{Name: "v1"}, {Name: "v2"}, {Name: "v3"}}
+
+	require.Equal(t, tags[1:], tagsAfter(tags, []types.RecordingTime{{Revision: "v1"}, {Revision: "v1.5"}}))
+	require.Empty(t, tagsAfter(tags, []types.RecordingTime{{Revision: "v3"}}))
+	require.Equal(t, tags, tagsAfter(tags, []types.RecordingTime{{Revision: "v0"}}))
+	require.Equal(t, tags, tagsAfter(tags, nil))
+}
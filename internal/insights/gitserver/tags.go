@@ -0,0 +1,68 @@
+package gitserver
+
+import (
+	"context"
+	"path"
+	"sort"
+
+	"github.com/Masterminds/semver"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// MatchingTags returns the tags of a repository whose names match the glob pattern, in the
+// order described by SortTags.
+func MatchingTags(ctx context.Context, client gitserver.Client, repoName api.RepoName, pattern string) ([]*gitdomain.Tag, error) {
+	tags, err := client.ListTags(ctx, repoName)
+	if err != nil {
+		return nil, errors.Wrap(err, "ListTags")
+	}
+	return FilterTags(tags, pattern)
+}
+
+// FilterTags returns the tags whose names match the glob pattern, in the order described by
+// SortTags. The pattern syntax is the one of path.Match.
+func FilterTags(tags []*gitdomain.Tag, pattern string) ([]*gitdomain.Tag, error) {
+	matching := make([]*gitdomain.Tag, 0, len(tags))
+	for _, tag := range tags {
+		ok, err := path.Match(pattern, tag.Name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid tag pattern %q", pattern)
+		}
+		if ok {
+			matching = append(matching, tag)
+		}
+	}
+	SortTags(matching)
+	return matching, nil
+}
+
+// SortTags sorts tags in release order. Tags whose names are semantic versions, optionally
+// prefixed with "v", are sorted by version and come after all other tags, which are sorted by
+// creation date. Ties are broken by name.
+func SortTags(tags []*gitdomain.Tag) {
+	versions := make(map[*gitdomain.Tag]*semver.Version, len(tags))
+	for _, tag := range tags {
+		if v, err := semver.NewVersion(tag.Name); err == nil {
+			versions[tag] = v
+		}
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		vi, vj := versions[tags[i]], versions[tags[j]]
+		switch {
+		case vi != nil && vj != nil:
+			if !vi.Equal(vj) {
+				return vi.LessThan(vj)
+			}
+		case vi != nil || vj != nil:
+			return vj != nil
+		case !tags[i].CreatorDate.Equal(tags[j].CreatorDate):
+			return tags[i].CreatorDate.Before(tags[j].CreatorDate)
+		}
+		return tags[i].Name < tags[j].Name
+	})
+}
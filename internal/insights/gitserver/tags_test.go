@@ -0,0 +1,48 @@
+package gitserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+)
+
+func TestFilterTags(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2023, 1, d, 0, 0, 0, 0, time.UTC) }
+	tags := []*gitdomain.Tag{
+		{Name: "v1.10.0", CreatorDate: day(1)},
+		{Name: "v1.2.0", CreatorDate: day(2)},
+		{Name: "v1.2.0-rc.1", CreatorDate: day(3)},
+		{Name: "v2.0.0", CreatorDate: day(4)},
+		{Name: "vnext", CreatorDate: day(6)},
+		{Name: "vlegacy", CreatorDate: day(5)},
+		{Name: "release-1", CreatorDate: day(7)},
+	}
+
+	names := func(tags []*gitdomain.Tag) []string {
+		var names []string
+		for _, tag := range tags {
+			names = append(names, tag.Name)
+		}
+		return names
+	}
+
+	t.Run("versions", func(t *testing.T) {
+		got, err := FilterTags(tags, "v1.*")
+		require.NoError(t, err)
+		require.Equal(t, []string{"v1.2.0-rc.1", "v1.2.0", "v1.10.0"}, names(got))
+	})
+
+	t.Run("mixed", func(t *testing.T) {
+		got, err := FilterTags(tags, "v*")
+		require.NoError(t, err)
+		require.Equal(t, []string{"vlegacy", "vnext", "v1.2.0-rc.1", "v1.2.0", "v1.10.0", "v2.0.0"}, names(got))
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := FilterTags(tags, "v[")
+		require.Error(t, err)
+	})
+}
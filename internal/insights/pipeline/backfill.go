@@ -32,6 +32,9 @@ type BackfillRequest struct {
 	Series      *types.InsightSeries
 	Repo        *itypes.MinimalRepo
 	SampleTimes []time.Time
+	// SampleRevisions maps the sample times of series sampled at tags to their tag. Each sample
+	// time is searched at its tag, without compression.
+	SampleRevisions map[time.Time]string
 }
 
 type requestContext struct {
@@ -75,6 +78,19 @@ func NewDefaultBackfiller(config BackfillerConfig) Backfiller {
 
 }
 
+// PointsCollector receives the points computed by an ephemeral backfill of a single repository.
+type PointsCollector func(ctx context.Context, points []store.RecordSeriesPointArgs) error
+
+// NewEphemeralBackfiller returns a backfiller that runs the same searches as the default backfiller, but
+// hands the resulting points to collect instead of recording them in the insights DB. It is used to
+// answer historical queries for series that are not saved, and does not use the InsightStore of the config.
+func NewEphemeralBackfiller(config BackfillerConfig, collect PointsCollector) Backfiller {
+	logger := log.Scoped("insightsEphemeralBackfiller")
+	searchJobGenerator := makeSearchJobsFunc(logger, config.CommitClient, config.CompressionPlan, config.SearchPlanWorkerLimit, config.HistoricRateLimiter)
+	searchRunner := makeRunSearchFunc(config.SearchHandlers, config.SearchRunnerWorkerLimit, config.SearchRateLimiter)
+	return newBackfiller(searchJobGenerator, searchRunner, makeCollectResultsFunc(collect), glock.NewRealClock())
+}
+
 func newBackfiller(jobGenerator SearchJobGenerator, searchRunner SearchRunner, resultsPersister ResultsPersister, clock glock.Clock) Backfiller {
 	return &backfiller{
 		searchJobGenerator: jobGenerator,
@@ -157,7 +173,12 @@ func makeSearchJobsFunc(logger log.Logger, commitClient GitCommitClient, compres
 		if err != nil {
 			return &reqContext, jobs, err
 		}
-		searchPlan := compressionPlan.Filter(ctx, req.SampleTimes, req.Repo.Name)
+		var searchPlan compression.BackfillPlan
+		if len(req.SampleRevisions) > 0 {
+			searchPlan = revisionSearchPlan(req.SampleTimes, req.SampleRevisions)
+		} else {
+			searchPlan = compressionPlan.Filter(ctx, req.SampleTimes, req.Repo.Name)
+		}
 		ratio := 1.0
 		if numberOfSamples > 0 {
 			ratio = float64(len(searchPlan.Executions)) / float64(numberOfSamples)
@@ -216,17 +237,32 @@ type buildSeriesContext struct {
 
 type searchJobFunc func(ctx context.Context, bctx *buildSeriesContext) (err error, job *queryrunner.SearchJob, preempted []store.RecordSeriesPointArgs)
 
+// revisionSearchPlan returns a plan that searches each sample time at its revision.
+func revisionSearchPlan(sampleTimes []time.Time, revisions map[time.Time]string) compression.BackfillPlan {
+	executions := make([]compression.QueryExecution, 0, len(sampleTimes))
+	for _, sampleTime := range sampleTimes {
+		executions = append(executions, compression.QueryExecution{
+			Revision:      revisions[sampleTime],
+			RecordingTime: sampleTime,
+		})
+	}
+	return compression.BackfillPlan{Executions: executions, RecordCount: len(executions)}
+}
+
 func makeHistoricalSearchJobFunc(logger log.Logger, commitClient GitCommitClient) searchJobFunc {
 	return func(ctx context.Context, bctx *buildSeriesContext) (err error, job *queryrunner.SearchJob, preempted []store.RecordSeriesPointArgs) {
 		logger.Debug("making search job")
 		rawQuery := bctx.series.Query
-		containsRepo, err := querybuilder.ContainsField(rawQuery, query.FieldRepo)
-		if err != nil {
-			return err, nil, nil
-		}
-		if containsRepo {
-			// This maintains existing behavior that searches with a repo filter are ignored
-			return nil, nil, nil
+		isCodeIntel := bctx.series.GenerationMethod == types.PreciseCodeIntel
+		if !isCodeIntel {
+			containsRepo, err := querybuilder.ContainsField(rawQuery, query.FieldRepo)
+			if err != nil {
+				return err, nil, nil
+			}
+			if containsRepo {
+				// This maintains existing behavior that searches with a repo filter are ignored
+				return nil, nil, nil
+			}
 		}
 
 		// Optimization: If the timeframe we're building data for starts (or ends) before the first commit in the
@@ -262,6 +298,23 @@ func makeHistoricalSearchJobFunc(logger log.Logger, commitClient GitCommitClient
 			revision = string(nearestCommit.ID)
 		}
 
+		// Series generated from precise code intelligence are not search queries, we only scope them to
+		// the repository and revision at which to count the occurrences of the symbol.
+		if isCodeIntel {
+			codeIntelQuery, parseErr := querybuilder.ParseCodeIntelQuery(rawQuery)
+			if parseErr != nil {
+				return errors.Wrap(parseErr, "ParseCodeIntelQuery"), nil, nil
+			}
+			job = &queryrunner.SearchJob{
+				SeriesID:        bctx.seriesID,
+				SearchQuery:     codeIntelQuery.WithRepo(repoName, revision).String(),
+				RecordTime:      &bctx.execution.RecordingTime,
+				PersistMode:     string(store.RecordMode),
+				DependentFrames: bctx.execution.SharedRecordings,
+			}
+			return err, job, preempted
+		}
+
 		// Construct the search query that will generate data for this repository and time (revision) tuple.
 		var newQueryStr string
 		modifiedQuery, err := querybuilder.SingleRepoQuery(querybuilder.BasicQuery(rawQuery), repoName, revision, querybuilder.CodeInsightsQueryDefaults(len(bctx.series.Repositories) == 0))
@@ -301,7 +354,10 @@ func makeRunSearchFunc(searchHandlers map[types.GenerationMethod]queryrunner.Ins
 		for i := 0; i < len(jobs); i++ {
 			job := jobs[i]
 			p.Go(func(ctx context.Context) error {
-				h := searchHandlers[series.GenerationMethod]
+				h, ok := searchHandlers[series.GenerationMethod]
+				if !ok {
+					return errors.Newf("unable to handle generation method %s", series.GenerationMethod)
+				}
 				err := rateLimiter.Wait(ctx)
 				if err != nil {
 					return errors.Wrap(err, "rateLimiter.Wait")
@@ -336,3 +392,12 @@ func makeSaveResultsFunc(logger log.Logger, insightStore store.Interface) Result
 	}
 
 }
+
+func makeCollectResultsFunc(collect PointsCollector) ResultsPersister {
+	return func(ctx context.Context, reqContext *requestContext, points []store.RecordSeriesPointArgs) (*requestContext, error) {
+		if ctx.Err() != nil {
+			return reqContext, ctx.Err()
+		}
+		return reqContext, collect(ctx, points)
+	}
+}
@@ -105,6 +105,32 @@ func TestBackfillStepsConnected(t *testing.T) {
 	}
 }
 
+func TestCollectResults(t *testing.T) {
+	var collected []store.RecordSeriesPointArgs
+	collect := func(ctx context.Context, points []store.RecordSeriesPointArgs) error {
+		collected = append(collected, points...)
+		return nil
+	}
+
+	backfiller := newBackfiller(makeTestJobGenerator(3), testSearchRunnerStep, makeCollectResultsFunc(collect), glock.NewMockClock())
+	if err := backfiller.Run(context.Background(), BackfillRequest{Series: &types.InsightSeries{SeriesID: "1"}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(collected) != 3 {
+		t.Errorf("expected 3 collected points, got %d", len(collected))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	collected = nil
+	if err := backfiller.Run(ctx, BackfillRequest{Series: &types.InsightSeries{SeriesID: "1"}}); err == nil {
+		t.Error("expected error for canceled context")
+	}
+	if len(collected) != 0 {
+		t.Errorf("expected no collected points for canceled context, got %d", len(collected))
+	}
+}
+
 type fakeCommitClient struct {
 	firstCommit   func(ctx context.Context, repoName api.RepoName) (*gitdomain.Commit, error)
 	recentCommits func(ctx context.Context, repoName api.RepoName, target time.Time, revision string) ([]*gitdomain.Commit, error)
@@ -189,6 +215,16 @@ func TestMakeSearchJobs(t *testing.T) {
 		Repo:        &itypes.MinimalRepo{ID: api.RepoID(1), Name: api.RepoName("testrepo")},
 	}
 
+	backfillReqRevisions := &BackfillRequest{
+		Series:      series,
+		SampleTimes: sampleTimes[len(sampleTimes)-2:],
+		SampleRevisions: map[time.Time]string{
+			sampleTimes[len(sampleTimes)-2]: "v1.0.0",
+			sampleTimes[len(sampleTimes)-1]: "v1.1.0",
+		},
+		Repo: &itypes.MinimalRepo{ID: api.RepoID(1), Name: api.RepoName("testrepo")},
+	}
+
 	basicCommitClient := newFakeCommitClient(&firstCommit, recentCommits)
 	// used to simulate a single call to recent commits failing
 	recentsErrorAfter := func(times int, commits []*gitdomain.Commit) func(ctx context.Context, repoName api.RepoName, target time.Time, revision string) ([]*gitdomain.Commit, error) {
@@ -307,6 +343,13 @@ func TestMakeSearchJobs(t *testing.T) {
 		{
 			name:         "Query with repo: in it",
 			commitClient: basicCommitClient, backfillReq: backfillReqRepoQuery, workers: 1, want: autogold.Expect([]string{"error occurred: false"})},
+		{
+			name:         "Sampled at revisions",
+			commitClient: basicCommitClient, backfillReq: backfillReqRevisions, workers: 1, want: autogold.Expect([]string{
+				"job recordtime:2022-04-01T01:00:00Z query:fork:no archived:no patterntype:literal count:99999999 test query repo:^testrepo$@v1.1.0",
+				"job recordtime:2022-03-25T01:00:00Z query:fork:no archived:no patterntype:literal count:99999999 test query repo:^testrepo$@v1.0.0",
+				"error occurred: false",
+			})},
 	}
 
 	for _, tc := range testCases {
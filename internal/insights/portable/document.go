@@ -0,0 +1,302 @@
+// Package portable defines a versioned document format for Code Insights dashboards, so that
+// dashboards, their insights and series definitions can be moved between instances or kept in git.
+package portable
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// CurrentVersion is the version of the document format written by Marshal. Documents with a newer
+// version are rejected by Parse.
+const CurrentVersion = 1
+
+// Document is a portable representation of a set of Code Insights dashboards.
+type Document struct {
+	Version    int         `json:"version"`
+	Dashboards []Dashboard `json:"dashboards"`
+}
+
+// Dashboard is a dashboard and the insights on it, in the order they are displayed.
+type Dashboard struct {
+	Title    string    `json:"title"`
+	Insights []Insight `json:"insights,omitempty"`
+}
+
+type InsightType string
+
+const (
+	LineChart InsightType = "line"
+	PieChart  InsightType = "pie"
+)
+
+// Insight is the definition of an insight view and its series. The ID is the unique ID of the
+// insight view, and is used to find an existing insight when the document is imported again.
+type Insight struct {
+	ID                   string                `json:"id"`
+	Type                 InsightType           `json:"type"`
+	Title                string                `json:"title"`
+	Description          string                `json:"description,omitempty"`
+	Filters              *Filters              `json:"filters,omitempty"`
+	SeriesDisplayOptions *SeriesDisplayOptions `json:"seriesDisplayOptions,omitempty"`
+	// OtherThreshold is only used by pie charts.
+	OtherThreshold *float64 `json:"otherThreshold,omitempty"`
+	Series         []Series `json:"series"`
+}
+
+type Filters struct {
+	IncludeRepoRegex string   `json:"includeRepoRegex,omitempty"`
+	ExcludeRepoRegex string   `json:"excludeRepoRegex,omitempty"`
+	SearchContexts   []string `json:"searchContexts,omitempty"`
+}
+
+type SeriesDisplayOptions struct {
+	SortMode      types.SeriesSortMode      `json:"sortMode,omitempty"`
+	SortDirection types.SeriesSortDirection `json:"sortDirection,omitempty"`
+	Limit         *int32                    `json:"limit,omitempty"`
+	NumSamples    *int32                    `json:"numSamples,omitempty"`
+}
+
+// Series is the definition of a single insight series. The fields mirror the inputs of the
+// GraphQL mutations that create insights.
+type Series struct {
+	Query                         string     `json:"query"`
+	Label                         string     `json:"label,omitempty"`
+	Color                         string     `json:"color,omitempty"`
+	Repositories                  []string   `json:"repositories,omitempty"`
+	RepositoryCriteria            string     `json:"repositoryCriteria,omitempty"`
+	TimeScope                     *TimeScope `json:"timeScope,omitempty"`
+	GeneratedFromCaptureGroups    bool       `json:"generatedFromCaptureGroups,omitempty"`
+	GroupBy                       string     `json:"groupBy,omitempty"`
+	GeneratedFromPreciseCodeIntel bool       `json:"generatedFromPreciseCodeIntel,omitempty"`
+}
+
+type TimeScope struct {
+	Unit  types.IntervalUnit `json:"unit"`
+	Value int32              `json:"value"`
+	// Revisions is the glob pattern of the tags that the series is sampled at, if it is sampled at
+	// tags rather than at time intervals.
+	Revisions string `json:"revisions,omitempty"`
+}
+
+// Equal returns true if both insights have the same definition. The order of the series is not
+// significant.
+func (i Insight) Equal(other Insight) bool {
+	a, err := i.canonical()
+	if err != nil {
+		return false
+	}
+	b, err := other.canonical()
+	if err != nil {
+		return false
+	}
+	return slices.Equal(a, b)
+}
+
+// canonical returns the serialized insight followed by its sorted, serialized series. Comparing
+// serialized values treats nil and empty values the same way the document format does.
+func (i Insight) canonical() ([]string, error) {
+	series := i.Series
+	i.Series = nil
+	raw, err := json.Marshal(i)
+	if err != nil {
+		return nil, err
+	}
+
+	serialized := make([]string, 0, len(series))
+	for _, s := range series {
+		raw, err := json.Marshal(s)
+		if err != nil {
+			return nil, err
+		}
+		serialized = append(serialized, string(raw))
+	}
+	sort.Strings(serialized)
+
+	return append([]string{string(raw)}, serialized...), nil
+}
+
+type Format string
+
+const (
+	JSON Format = "JSON"
+	YAML Format = "YAML"
+)
+
+// Marshal encodes the given document in the given format.
+func Marshal(doc *Document, format Format) ([]byte, error) {
+	raw, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case JSON:
+		return raw, nil
+	case YAML:
+		return yaml.JSONToYAML(raw)
+	default:
+		return nil, errors.Newf("unsupported document format %q", format)
+	}
+}
+
+// Parse decodes and validates a document, which can be YAML or JSON.
+func Parse(data []byte) (*Document, error) {
+	normalized, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse document")
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(normalized))
+	dec.DisallowUnknownFields()
+	var doc Document
+	if err := dec.Decode(&doc); err != nil {
+		return nil, errors.Wrap(err, "failed to parse document")
+	}
+
+	if err := doc.Validate(); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+var validIntervalUnits = map[types.IntervalUnit]struct{}{
+	types.Hour:  {},
+	types.Day:   {},
+	types.Week:  {},
+	types.Month: {},
+	types.Year:  {},
+}
+
+// Validate checks that the document is structurally valid. It does not validate the search queries
+// or repositories of the series, which depend on the instance the document is imported into.
+func (d *Document) Validate() error {
+	if d.Version == 0 {
+		return errors.New("document is missing a version")
+	}
+	if d.Version > CurrentVersion {
+		return errors.Newf("unsupported document version %d, the latest supported version is %d", d.Version, CurrentVersion)
+	}
+
+	var errs error
+	insights := make(map[string]Insight)
+	for i, dashboard := range d.Dashboards {
+		path := fmt.Sprintf("dashboards[%d]", i)
+		if dashboard.Title == "" {
+			errs = errors.Append(errs, errors.Newf("%s: title is required", path))
+		}
+
+		for j, insight := range dashboard.Insights {
+			insightPath := fmt.Sprintf("%s.insights[%d]", path, j)
+			if err := insight.validate(insightPath); err != nil {
+				errs = errors.Append(errs, err)
+				continue
+			}
+
+			// The same insight can be placed on multiple dashboards, but it has to be defined the
+			// same way everywhere.
+			if existing, ok := insights[insight.ID]; ok && !existing.Equal(insight) {
+				errs = errors.Append(errs, errors.Newf("%s: insight %q has conflicting definitions", insightPath, insight.ID))
+			}
+			insights[insight.ID] = insight
+		}
+	}
+	return errs
+}
+
+func (i Insight) validate(path string) error {
+	var errs error
+	if i.ID == "" {
+		errs = errors.Append(errs, errors.Newf("%s: id is required", path))
+	}
+	if i.Title == "" {
+		errs = errors.Append(errs, errors.Newf("%s: title is required", path))
+	}
+	if len(i.Series) == 0 {
+		errs = errors.Append(errs, errors.Newf("%s: at least one series is required", path))
+	}
+
+	switch i.Type {
+	case LineChart:
+		if i.OtherThreshold != nil {
+			errs = errors.Append(errs, errors.Newf("%s: otherThreshold is only supported by pie charts", path))
+		}
+		for k, series := range i.Series {
+			errs = errors.Append(errs, series.validateLineChart(fmt.Sprintf("%s.series[%d]", path, k)))
+		}
+
+	case PieChart:
+		if i.OtherThreshold == nil {
+			errs = errors.Append(errs, errors.Newf("%s: otherThreshold is required for pie charts", path))
+		}
+		if i.Filters != nil || i.SeriesDisplayOptions != nil {
+			errs = errors.Append(errs, errors.Newf("%s: filters and seriesDisplayOptions are only supported by line charts", path))
+		}
+		if len(i.Series) > 1 {
+			errs = errors.Append(errs, errors.Newf("%s: pie charts have exactly one series", path))
+		}
+		for k, series := range i.Series {
+			errs = errors.Append(errs, series.validatePieChart(fmt.Sprintf("%s.series[%d]", path, k)))
+		}
+
+	default:
+		errs = errors.Append(errs, errors.Newf("%s: unsupported insight type %q", path, i.Type))
+	}
+
+	if opts := i.SeriesDisplayOptions; opts != nil {
+		if (opts.SortMode == "") != (opts.SortDirection == "") {
+			errs = errors.Append(errs, errors.Newf("%s: sortMode and sortDirection have to be set together", path))
+		}
+		switch opts.SortMode {
+		case "", types.ResultCount, types.DateAdded, types.Lexicographical:
+		default:
+			errs = errors.Append(errs, errors.Newf("%s: unsupported sortMode %q", path, opts.SortMode))
+		}
+		switch opts.SortDirection {
+		case "", types.Asc, types.Desc:
+		default:
+			errs = errors.Append(errs, errors.Newf("%s: unsupported sortDirection %q", path, opts.SortDirection))
+		}
+	}
+
+	return errs
+}
+
+func (s Series) validateLineChart(path string) error {
+	var errs error
+	if s.Query == "" {
+		errs = errors.Append(errs, errors.Newf("%s: query is required", path))
+	}
+	if s.TimeScope == nil {
+		errs = errors.Append(errs, errors.Newf("%s: timeScope is required", path))
+	} else {
+		if _, ok := validIntervalUnits[s.TimeScope.Unit]; !ok {
+			errs = errors.Append(errs, errors.Newf("%s: unsupported timeScope unit %q", path, s.TimeScope.Unit))
+		}
+		if s.TimeScope.Value < 1 {
+			errs = errors.Append(errs, errors.Newf("%s: timeScope value has to be positive", path))
+		}
+	}
+	if len(s.Repositories) > 0 && s.RepositoryCriteria != "" {
+		errs = errors.Append(errs, errors.Newf("%s: repositories and repositoryCriteria can not be set together", path))
+	}
+	return errs
+}
+
+func (s Series) validatePieChart(path string) error {
+	var errs error
+	if s.Query == "" {
+		errs = errors.Append(errs, errors.Newf("%s: query is required", path))
+	}
+	if s.TimeScope != nil || s.RepositoryCriteria != "" || s.GeneratedFromCaptureGroups || s.GroupBy != "" || s.GeneratedFromPreciseCodeIntel {
+		errs = errors.Append(errs, errors.Newf("%s: pie chart series only support a query and a list of repositories", path))
+	}
+	return errs
+}
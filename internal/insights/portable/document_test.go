@@ -0,0 +1,187 @@
+package portable
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+func testDocument() *Document {
+	todos := Insight{
+		ID:    "2Mw9wQ3ZrFYnvl0rYNKO1mKhDJw",
+		Type:  LineChart,
+		Title: "TODOs",
+		Filters: &Filters{
+			IncludeRepoRegex: "^github.com/sourcegraph/",
+		},
+		SeriesDisplayOptions: &SeriesDisplayOptions{
+			SortMode:      types.ResultCount,
+			SortDirection: types.Desc,
+			Limit:         pointers.Ptr(int32(10)),
+		},
+		Series: []Series{{
+			Query:     "TODO",
+			Label:     "TODOs",
+			Color:     "#ff0000",
+			TimeScope: &TimeScope{Unit: types.Month, Value: 1},
+		}},
+	}
+
+	return &Document{
+		Version: CurrentVersion,
+		Dashboards: []Dashboard{
+			{
+				Title: "Code health",
+				Insights: []Insight{
+					todos,
+					{
+						ID:             "2Mw9wPv9IdPs5bKTnAu6Bt0Bj6p",
+						Type:           PieChart,
+						Title:          "Languages",
+						OtherThreshold: pointers.Ptr(0.03),
+						Series:         []Series{{Query: "repo:^github.com/sourcegraph/sourcegraph$"}},
+					},
+				},
+			},
+			{
+				Title:    "Team",
+				Insights: []Insight{todos},
+			},
+		},
+	}
+}
+
+func TestMarshalParse(t *testing.T) {
+	doc := testDocument()
+
+	for _, format := range []Format{JSON, YAML} {
+		t.Run(string(format), func(t *testing.T) {
+			raw, err := Marshal(doc, format)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			parsed, err := Parse(raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(doc, parsed); diff != "" {
+				t.Errorf("unexpected document (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   string
+		wantErr []string
+	}{
+		{
+			name:    "missing version",
+			input:   `dashboards: []`,
+			wantErr: []string{"document is missing a version"},
+		},
+		{
+			name:    "newer version",
+			input:   `{"version": 2, "dashboards": []}`,
+			wantErr: []string{"unsupported document version 2"},
+		},
+		{
+			name:    "unknown field",
+			input:   "version: 1\ndashboards: []\npanels: []",
+			wantErr: []string{`unknown field "panels"`},
+		},
+		{
+			name: "invalid insights",
+			input: `
+version: 1
+dashboards:
+  - title: ""
+    insights:
+      - id: a
+        type: line
+        title: A
+        series:
+          - query: TODO
+            timeScope: {unit: FORTNIGHT, value: 0}
+      - id: b
+        type: pie
+        title: B
+        series:
+          - query: TODO
+      - type: bar
+        title: C
+        series: []
+`,
+			wantErr: []string{
+				"dashboards[0]: title is required",
+				`dashboards[0].insights[0].series[0]: unsupported timeScope unit "FORTNIGHT"`,
+				"dashboards[0].insights[0].series[0]: timeScope value has to be positive",
+				"dashboards[0].insights[1]: otherThreshold is required for pie charts",
+				"dashboards[0].insights[2]: id is required",
+				"dashboards[0].insights[2]: at least one series is required",
+				`dashboards[0].insights[2]: unsupported insight type "bar"`,
+			},
+		},
+		{
+			name: "conflicting insight definitions",
+			input: `
+version: 1
+dashboards:
+  - title: A
+    insights:
+      - {id: a, type: line, title: A, series: [{query: TODO, timeScope: {unit: MONTH, value: 1}}]}
+  - title: B
+    insights:
+      - {id: a, type: line, title: A, series: [{query: FIXME, timeScope: {unit: MONTH, value: 1}}]}
+`,
+			wantErr: []string{`dashboards[1].insights[0]: insight "a" has conflicting definitions`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse([]byte(tc.input))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			for _, want := range tc.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("expected error to contain %q, got %q", want, err.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestInsightEqual(t *testing.T) {
+	a := testDocument().Dashboards[0].Insights[0]
+	b := testDocument().Dashboards[0].Insights[0]
+	if !a.Equal(b) {
+		t.Error("expected insights to be equal")
+	}
+
+	// Empty and nil values are equivalent.
+	b.Filters.SearchContexts = []string{}
+	if !a.Equal(b) {
+		t.Error("expected insights with empty and nil search contexts to be equal")
+	}
+
+	// The order of the series is not significant.
+	a.Series = append(a.Series, Series{Query: "FIXME", TimeScope: &TimeScope{Unit: types.Week, Value: 2}})
+	b.Series = append([]Series{{Query: "FIXME", TimeScope: &TimeScope{Unit: types.Week, Value: 2}}}, b.Series...)
+	if !a.Equal(b) {
+		t.Error("expected insights with reordered series to be equal")
+	}
+
+	b.Series[1].Query = "XXX"
+	if a.Equal(b) {
+		t.Error("expected insights with different queries to differ")
+	}
+}
@@ -428,6 +428,35 @@ func AddRepoMetadataFilter(query BasicQuery, repoMeta string) (BasicQuery, error
 	return BasicQuery(searchquery.StringHuman(mutatedQuery.ToQ())), nil
 }
 
+// AddOwnerFilter restricts the query to files owned by owner, or to files without any owner if owner is
+// types.NO_OWNER_TEXT.
+func AddOwnerFilter(query BasicQuery, owner string) (BasicQuery, error) {
+	plan, err := searchquery.Pipeline(searchquery.Init(string(query), searchquery.SearchTypeLiteral))
+	if err != nil {
+		return "", err
+	}
+
+	mutatedQuery := searchquery.MapPlan(plan, func(basic searchquery.Basic) searchquery.Basic {
+		modified := make([]searchquery.Parameter, 0, len(basic.Parameters)+1)
+		modified = append(modified, basic.Parameters...)
+		parameter := searchquery.Parameter{
+			Field:      searchquery.FieldFile,
+			Value:      fmt.Sprint("has.owner(", owner, ")"),
+			Negated:    false,
+			Annotation: searchquery.Annotation{},
+		}
+		if owner == types.NO_OWNER_TEXT {
+			// An empty has.owner predicate matches files with any owner.
+			parameter.Value = "has.owner()"
+			parameter.Negated = true
+		}
+		modified = append(modified, parameter)
+		return basic.MapParameters(modified)
+	})
+
+	return BasicQuery(searchquery.StringHuman(mutatedQuery.ToQ())), nil
+}
+
 func buildFilterText(raw string) string {
 	quoted := regexp.QuoteMeta(raw)
 	if strings.Contains(raw, " ") {
@@ -0,0 +1,82 @@
+package querybuilder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Occurrence kinds that can be counted by insight series generated from precise code intelligence.
+const (
+	CodeIntelDefinitions     = "definitions"
+	CodeIntelReferences      = "references"
+	CodeIntelImplementations = "implementations"
+)
+
+// CodeIntelQuery is the query of an insight series generated from precise code intelligence. Series
+// store it in the form `<kind>:<symbol>`, e.g. `references:scip-go gomod example v1 example/Foo().`.
+// Backfill jobs additionally scope it to a single repository and revision with a leading
+// `repo:<name>@<revision>` field.
+type CodeIntelQuery struct {
+	Kind   string
+	Symbol string
+
+	Repo     string
+	Revision string
+}
+
+// ParseCodeIntelQuery parses the query of an insight series generated from precise code intelligence.
+func ParseCodeIntelQuery(q string) (CodeIntelQuery, error) {
+	var result CodeIntelQuery
+
+	q = strings.TrimSpace(q)
+	if strings.HasPrefix(q, "repo:") {
+		repoField, rest, ok := strings.Cut(q, " ")
+		if !ok {
+			return CodeIntelQuery{}, errors.Newf("missing symbol in code intelligence query %q", q)
+		}
+		result.Repo, result.Revision, _ = strings.Cut(strings.TrimPrefix(repoField, "repo:"), "@")
+		if result.Repo == "" {
+			return CodeIntelQuery{}, errors.Newf("missing repository in code intelligence query %q", q)
+		}
+		q = strings.TrimSpace(rest)
+	}
+
+	kind, symbol, ok := strings.Cut(q, ":")
+	if !ok {
+		return CodeIntelQuery{}, errors.Newf("code intelligence query %q must be of the form <kind>:<symbol>", q)
+	}
+	switch kind {
+	case CodeIntelDefinitions, CodeIntelReferences, CodeIntelImplementations:
+	default:
+		return CodeIntelQuery{}, errors.Newf("unsupported occurrence kind %q, expected one of %s, %s, or %s", kind, CodeIntelDefinitions, CodeIntelReferences, CodeIntelImplementations)
+	}
+	symbol = strings.TrimSpace(symbol)
+	if symbol == "" {
+		return CodeIntelQuery{}, errors.Newf("missing symbol in code intelligence query %q", q)
+	}
+
+	result.Kind = kind
+	result.Symbol = symbol
+	return result, nil
+}
+
+// WithRepo returns a copy of the query scoped to the given repository and revision.
+func (q CodeIntelQuery) WithRepo(repo, revision string) CodeIntelQuery {
+	q.Repo = repo
+	q.Revision = revision
+	return q
+}
+
+func (q CodeIntelQuery) String() string {
+	s := fmt.Sprintf("%s:%s", q.Kind, q.Symbol)
+	if q.Repo == "" {
+		return s
+	}
+	repo := "repo:" + q.Repo
+	if q.Revision != "" {
+		repo += "@" + q.Revision
+	}
+	return repo + " " + s
+}
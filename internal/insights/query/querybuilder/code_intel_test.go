@@ -0,0 +1,83 @@
+package querybuilder
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseCodeIntelQuery(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query string
+		want  CodeIntelQuery
+		fail  bool
+	}{
+		{
+			name:  "references",
+			query: "references:scip-go gomod example v1 `example`/Foo().",
+			want:  CodeIntelQuery{Kind: "references", Symbol: "scip-go gomod example v1 `example`/Foo()."},
+		},
+		{
+			name:  "scoped to repository and revision",
+			query: "repo:github.com/sourcegraph/sourcegraph@abc123 definitions:scip-go gomod example v1 `example`/Foo().",
+			want: CodeIntelQuery{
+				Kind:     "definitions",
+				Symbol:   "scip-go gomod example v1 `example`/Foo().",
+				Repo:     "github.com/sourcegraph/sourcegraph",
+				Revision: "abc123",
+			},
+		},
+		{
+			name:  "unsupported kind",
+			query: "hovers:foo",
+			fail:  true,
+		},
+		{
+			name:  "missing kind",
+			query: "foo",
+			fail:  true,
+		},
+		{
+			name:  "missing symbol",
+			query: "references:",
+			fail:  true,
+		},
+		{
+			name:  "missing symbol after repository",
+			query: "repo:github.com/sourcegraph/sourcegraph",
+			fail:  true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseCodeIntelQuery(tc.query)
+			if tc.fail {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected query (-want +got):\n%s", diff)
+			}
+			if got.String() != tc.query {
+				t.Errorf("unexpected string. want=%q have=%q", tc.query, got.String())
+			}
+		})
+	}
+}
+
+func TestCodeIntelQueryWithRepo(t *testing.T) {
+	q, err := ParseCodeIntelQuery("implementations:scip-java maven example 1.0 Foo#")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "repo:github.com/sourcegraph/sourcegraph@abc123 implementations:scip-java maven example 1.0 Foo#"
+	if got := q.WithRepo("github.com/sourcegraph/sourcegraph", "abc123").String(); got != want {
+		t.Errorf("unexpected query. want=%q have=%q", want, got)
+	}
+}
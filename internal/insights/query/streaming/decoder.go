@@ -32,6 +32,20 @@ type TabulationResult struct {
 	TotalCount int
 }
 
+type FileMatch struct {
+	RepositoryID   int32
+	RepositoryName string
+	Commit         string
+	Path           string
+	MatchCount     int
+}
+
+type FileTabulationResult struct {
+	StreamDecoderEvents
+	Files      []FileMatch
+	TotalCount int
+}
+
 type RepoResult struct {
 	StreamDecoderEvents
 	Repos []itypes.MinimalRepo
@@ -125,6 +139,54 @@ func TabulationDecoder() (streamhttp.FrontendStreamDecoder, *TabulationResult) {
 	}, tr
 }
 
+// FileTabulationDecoder will tabulate the result counts per file. Results that do not belong to a
+// file, like repository and commit matches, are not counted.
+func FileTabulationDecoder() (streamhttp.FrontendStreamDecoder, *FileTabulationResult) {
+	fr := &FileTabulationResult{}
+
+	addFile := func(repo string, repoID int32, commit, path string, count int) {
+		fr.TotalCount += count
+		fr.Files = append(fr.Files, FileMatch{
+			RepositoryID:   repoID,
+			RepositoryName: repo,
+			Commit:         commit,
+			Path:           path,
+			MatchCount:     count,
+		})
+	}
+
+	return streamhttp.FrontendStreamDecoder{
+		OnProgress: fr.onProgress,
+		OnMatches: func(matches []streamhttp.EventMatch) {
+			for _, match := range matches {
+				switch match := match.(type) {
+				case *streamhttp.EventContentMatch:
+					count := 0
+					for _, chunkMatch := range match.ChunkMatches {
+						count += len(chunkMatch.Ranges)
+					}
+					addFile(match.Repository, match.RepositoryID, match.Commit, match.Path, count)
+				case *streamhttp.EventPathMatch:
+					addFile(match.Repository, match.RepositoryID, match.Commit, match.Path, 1)
+				case *streamhttp.EventSymbolMatch:
+					addFile(match.Repository, match.RepositoryID, match.Commit, match.Path, len(match.Symbols))
+				}
+			}
+		},
+		OnAlert: func(ea *streamhttp.EventAlert) {
+			if ea.Title == "No repositories found" {
+				// If we hit a case where we don't find a repository we don't want to error, just
+				// complete our search.
+			} else {
+				fr.Alerts = append(fr.Alerts, fmt.Sprintf("%s: %s", ea.Title, ea.Description))
+			}
+		},
+		OnError: func(eventError *streamhttp.EventError) {
+			fr.Errors = append(fr.Errors, eventError.Message)
+		},
+	}, fr
+}
+
 // ComputeMatch is our internal representation of a match retrieved from a Compute Streaming Search.
 // It is internally different from the `ComputeMatch` returned by the Compute GraphQL query but they
 // serve the same end goal.
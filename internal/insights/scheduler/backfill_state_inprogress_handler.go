@@ -65,6 +65,7 @@ func makeInProgressWorker(ctx context.Context, config JobMonitorConfig) (*worker
 		insightsStore:      config.InsightStore,
 		backfillRunner:     config.BackfillRunner,
 		repoStore:          config.RepoStore,
+		alertEvaluator:     config.AlertEvaluator,
 		clock:              glock.NewRealClock(),
 		config:             handlerConfig,
 	}
@@ -116,6 +117,7 @@ type inProgressHandler struct {
 	repoStore          database.RepoStore
 	insightsStore      store.Interface
 	backfillRunner     pipeline.Backfiller
+	alertEvaluator     queryrunner.AlertEvaluator
 	config             handlerConfig
 
 	clock glock.Clock
@@ -224,7 +226,7 @@ func (h *inProgressHandler) doExecution(ctx context.Context, execution *backfill
 							return nil
 						}
 						execution.logger.Debug("doing iteration work", log.Int("repo_id", int(repoId)))
-						runErr := h.backfillRunner.Run(ctx, pipeline.BackfillRequest{Series: execution.series, Repo: &types.MinimalRepo{ID: repo.ID, Name: repo.Name}, SampleTimes: execution.sampleTimes})
+						runErr := h.backfillRunner.Run(ctx, pipeline.BackfillRequest{Series: execution.series, Repo: &types.MinimalRepo{ID: repo.ID, Name: repo.Name}, SampleTimes: execution.sampleTimes, SampleRevisions: execution.sampleRevisions})
 						if runErr != nil {
 							execution.logger.Error("error during backfill execution", execution.logFields(log.Error(runErr))...)
 							mu.Lock()
@@ -271,7 +273,11 @@ func (h *inProgressHandler) doExecution(ctx context.Context, execution *backfill
 	}
 
 	if !execution.itr.HasMore() && !execution.itr.HasErrors() {
-		return false, h.finish(ctx, execution)
+		if err := h.finish(ctx, execution); err != nil {
+			return false, err
+		}
+		h.evaluateAlertRules(ctx, execution)
+		return false, nil
 	} else {
 		// in this state we have some errors that will need reprocessing, we will place this job back in queue
 		return true, nil
@@ -313,6 +319,17 @@ func (h *inProgressHandler) finish(ctx context.Context, ex *backfillExecution) (
 	return nil
 }
 
+// evaluateAlertRules evaluates the alert rules of the series against the data points recorded by
+// its backfill. Failing to evaluate them doesn't fail the backfill, which has completed already.
+func (h *inProgressHandler) evaluateAlertRules(ctx context.Context, ex *backfillExecution) {
+	if h.alertEvaluator == nil {
+		return
+	}
+	if err := h.alertEvaluator.EvaluateSeries(ctx, ex.series); err != nil {
+		ex.logger.Error("failed to evaluate insight series alert rules", ex.logFields(log.Error(err))...)
+	}
+}
+
 func (h *inProgressHandler) disableBackfill(ctx context.Context, ex *backfillExecution) (err error) {
 	tx, err := h.backfillStore.Transact(ctx)
 	if err != nil {
@@ -357,6 +374,29 @@ func (h *inProgressHandler) load(ctx context.Context, logger log.Logger, backfil
 		return nil, errors.Wrap(err, "repoIterator")
 	}
 
+	if series.RevisionPattern != nil {
+		// Series sampled at tags are backfilled at the recording times that were chosen for
+		// their tags when the backfill was created.
+		recordingTimes, err := h.insightsStore.GetInsightSeriesRecordingTimes(ctx, series.ID, store.SeriesPointsOpts{})
+		if err != nil {
+			return nil, errors.Wrap(err, "GetInsightSeriesRecordingTimes")
+		}
+		sampleTimes := make([]time.Time, 0, len(recordingTimes.RecordingTimes))
+		sampleRevisions := make(map[time.Time]string, len(recordingTimes.RecordingTimes))
+		for _, recordingTime := range recordingTimes.RecordingTimes {
+			sampleTimes = append(sampleTimes, recordingTime.Timestamp)
+			sampleRevisions[recordingTime.Timestamp] = recordingTime.Revision
+		}
+		return &backfillExecution{
+			series:          series,
+			backfill:        backfillJob,
+			itr:             itr,
+			logger:          logger,
+			sampleTimes:     sampleTimes,
+			sampleRevisions: sampleRevisions,
+		}, nil
+	}
+
 	sampleTimes := timeseries.BuildSampleTimes(12, timeseries.TimeInterval{
 		Unit:  itypes.IntervalUnit(series.SampleIntervalUnit),
 		Value: series.SampleIntervalValue,
@@ -377,7 +417,9 @@ type backfillExecution struct {
 	itr         *iterator.PersistentRepoIterator
 	logger      log.Logger
 	sampleTimes []time.Time
-	config      handlerConfig
+	// sampleRevisions maps the sample times of series sampled at tags to their tag.
+	sampleRevisions map[time.Time]string
+	config          handlerConfig
 }
 
 func (b *backfillExecution) logFields(extra ...log.Field) []log.Field {
@@ -45,6 +45,15 @@ func (e *delegateBackfillRunner) Run(ctx context.Context, req pipeline.BackfillR
 	return e.doSomething(ctx, req)
 }
 
+type fakeAlertEvaluator struct {
+	evaluated []string
+}
+
+func (f *fakeAlertEvaluator) EvaluateSeries(_ context.Context, series *types.InsightSeries) error {
+	f.evaluated = append(f.evaluated, series.SeriesID)
+	return nil
+}
+
 func Test_MovesBackfillFromProcessingToComplete(t *testing.T) {
 	logger := logtest.Scoped(t)
 	ctx := context.Background()
@@ -90,6 +99,7 @@ func Test_MovesBackfillFromProcessingToComplete(t *testing.T) {
 	require.NoError(t, err)
 
 	dequeue, _, _ := monitor.inProgressStore.Dequeue(ctx, "test", nil)
+	alertEvaluator := &fakeAlertEvaluator{}
 	handler := inProgressHandler{
 		workerStore:        monitor.newBackfillStore,
 		backfillStore:      bfs,
@@ -97,12 +107,14 @@ func Test_MovesBackfillFromProcessingToComplete(t *testing.T) {
 		repoStore:          repos,
 		insightsStore:      seriesStore,
 		backfillRunner:     &noopBackfillRunner{},
+		alertEvaluator:     alertEvaluator,
 		config:             newHandlerConfig(),
 
 		clock: clock,
 	}
 	err = handler.Handle(ctx, logger, dequeue)
 	require.NoError(t, err)
+	require.Equal(t, []string{"series1"}, alertEvaluator.evaluated, "alert rules should be evaluated once the backfill completed")
 
 	_, found, err := monitor.inProgressStore.Dequeue(ctx, "test", nil)
 	require.NoError(t, err)
@@ -12,7 +12,9 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/compute"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/insights/discovery"
+	insightsgitserver "github.com/sourcegraph/sourcegraph/internal/insights/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/insights/priority"
 	"github.com/sourcegraph/sourcegraph/internal/insights/query/querybuilder"
 	"github.com/sourcegraph/sourcegraph/internal/insights/store"
@@ -35,6 +37,7 @@ type newBackfillHandler struct {
 	repoIterator    discovery.SeriesRepoIterator
 	costAnalyzer    priority.QueryAnalyzer
 	timeseriesStore store.Interface
+	gitserverClient gitserver.Client
 }
 
 // makeNewBackfillWorker makes a new Worker, Resetter and Store to handle the queue of Backfill jobs that are in the state of "New"
@@ -64,6 +67,7 @@ func makeNewBackfillWorker(ctx context.Context, config JobMonitorConfig) (*worke
 		repoIterator:    discovery.NewSeriesRepoIterator(config.AllRepoIterator, config.RepoStore, config.RepoQueryExecutor),
 		costAnalyzer:    *config.CostAnalyzer,
 		timeseriesStore: config.InsightStore,
+		gitserverClient: config.GitserverClient,
 	}
 
 	worker := dbworker.NewWorker(ctx, workerStore, workerutil.Handler[*BaseJob](&task), workerutil.WorkerOptions{
@@ -138,15 +142,15 @@ func (h *newBackfillHandler) Handle(ctx context.Context, logger log.Logger, job
 		return errors.Wrap(err, "backfill.SetScope")
 	}
 
-	sampleTimes := timeseries.BuildSampleTimes(12, timeseries.TimeInterval{
-		Unit:  types.IntervalUnit(series.SampleIntervalUnit),
-		Value: series.SampleIntervalValue,
-	}, series.CreatedAt.Truncate(time.Minute))
+	recordingTimes, err := h.recordingTimes(ctx, *series)
+	if err != nil {
+		return errors.Wrap(err, "recordingTimes")
+	}
 
 	if err := h.timeseriesStore.SetInsightSeriesRecordingTimes(ctx, []types.InsightSeriesRecordingTimes{
 		{
 			InsightSeriesID: series.ID,
-			RecordingTimes:  timeseries.MakeRecordingsFromTimes(sampleTimes, false),
+			RecordingTimes:  recordingTimes,
 		},
 	}); err != nil {
 		return errors.Wrap(err, "NewBackfillHandler.SetInsightSeriesRecordingTimes")
@@ -173,8 +177,45 @@ func (h *newBackfillHandler) Handle(ctx context.Context, logger log.Logger, job
 	return err
 }
 
+// numRevisionSamples is the number of tags that a series sampled at tags is backfilled at. It is
+// the same as the number of points that other series are backfilled with.
+const numRevisionSamples = 12
+
+// recordingTimes returns the times at which a series is backfilled. Series with a revision pattern
+// are backfilled at the most recent matching tags of their repository.
+func (h *newBackfillHandler) recordingTimes(ctx context.Context, series types.InsightSeries) ([]types.RecordingTime, error) {
+	if series.RevisionPattern == nil {
+		sampleTimes := timeseries.BuildSampleTimes(12, timeseries.TimeInterval{
+			Unit:  types.IntervalUnit(series.SampleIntervalUnit),
+			Value: series.SampleIntervalValue,
+		}, series.CreatedAt.Truncate(time.Minute))
+		return timeseries.MakeRecordingsFromTimes(sampleTimes, false), nil
+	}
+
+	if len(series.Repositories) != 1 {
+		return nil, errors.Newf("series sampled at tags must have exactly one repository, found %d", len(series.Repositories))
+	}
+	tags, err := insightsgitserver.MatchingTags(ctx, h.gitserverClient, api.RepoName(series.Repositories[0]), *series.RevisionPattern)
+	if err != nil {
+		return nil, errors.Wrap(err, "MatchingTags")
+	}
+	if len(tags) > numRevisionSamples {
+		tags = tags[len(tags)-numRevisionSamples:]
+	}
+	return timeseries.MakeRecordingsFromRevisions(tags, time.Time{}), nil
+}
+
 func parseQuery(series types.InsightSeries) (query.Plan, error) {
-	if series.GeneratedFromCaptureGroups {
+	if series.GenerationMethod == types.PreciseCodeIntel {
+		// Series generated from precise code intelligence do not run a search, so the cost of
+		// their backfill only depends on the repositories.
+		if _, err := querybuilder.ParseCodeIntelQuery(series.Query); err != nil {
+			return nil, errors.Wrap(err, "ParseCodeIntelQuery")
+		}
+		return nil, nil
+	}
+	// Series grouped by owner are dynamic, but their query is a regular search query.
+	if series.GeneratedFromCaptureGroups && series.GenerationMethod != types.SearchOwners {
 		seriesQuery, err := compute.Parse(series.Query)
 		if err != nil {
 			return nil, errors.Wrap(err, "compute.Parse")
@@ -14,7 +14,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/executor"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/insights/background/queryrunner"
 	"github.com/sourcegraph/sourcegraph/internal/insights/discovery"
 	"github.com/sourcegraph/sourcegraph/internal/insights/pipeline"
 	"github.com/sourcegraph/sourcegraph/internal/insights/priority"
@@ -118,6 +120,9 @@ type JobMonitorConfig struct {
 	AllRepoIterator   *discovery.AllReposIterator
 	CostAnalyzer      *priority.QueryAnalyzer
 	RepoQueryExecutor query.RepoQueryExecutor
+	GitserverClient   gitserver.Client
+	// AlertEvaluator evaluates the alert rules of a series once its backfill completed. It may be nil.
+	AlertEvaluator queryrunner.AlertEvaluator
 }
 
 func NewBackgroundJobMonitor(ctx context.Context, config JobMonitorConfig) *BackgroundJobMonitor {
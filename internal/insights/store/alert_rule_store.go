@@ -0,0 +1,185 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	edb "github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// AlertRuleStore stores the alert rules of insight series.
+type AlertRuleStore struct {
+	*basestore.Store
+	Now func() time.Time
+}
+
+// NewAlertRuleStore returns a new AlertRuleStore backed by the given Postgres db.
+func NewAlertRuleStore(db edb.InsightsDB) *AlertRuleStore {
+	return &AlertRuleStore{Store: basestore.NewWithHandle(db.Handle()), Now: time.Now}
+}
+
+// With creates a new AlertRuleStore with the given basestore. Shareable store as the underlying basestore.Store.
+// Needed to implement the basestore.Store interface
+func (s *AlertRuleStore) With(other basestore.ShareableStore) *AlertRuleStore {
+	return &AlertRuleStore{Store: s.Store.With(other), Now: s.Now}
+}
+
+func (s *AlertRuleStore) Transact(ctx context.Context) (*AlertRuleStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &AlertRuleStore{Store: txBase, Now: s.Now}, err
+}
+
+// ErrAlertRuleNotFound is returned by GetAlertRule if no alert rule with the given ID exists.
+var ErrAlertRuleNotFound = errors.New("alert rule not found")
+
+// CreateAlertRule creates the given alert rule and returns it with its ID set.
+func (s *AlertRuleStore) CreateAlertRule(ctx context.Context, rule types.InsightSeriesAlertRule) (types.InsightSeriesAlertRule, error) {
+	if rule.CreatedAt.IsZero() {
+		rule.CreatedAt = s.Now()
+	}
+	row := s.QueryRow(ctx, sqlf.Sprintf(
+		createAlertRuleSql,
+		rule.SeriesID,
+		rule.Kind,
+		rule.Direction,
+		rule.Threshold,
+		rule.WindowSize,
+		rule.EmailUserID,
+		rule.SlackWebhookURL,
+		rule.WebhookURL,
+		rule.CreatedBy,
+		rule.CreatedAt,
+	))
+	created, err := scanAlertRule(row)
+	if err != nil {
+		return types.InsightSeriesAlertRule{}, errors.Wrap(err, "CreateAlertRule")
+	}
+	return created, nil
+}
+
+// GetAlertRule returns the alert rule with the given ID.
+func (s *AlertRuleStore) GetAlertRule(ctx context.Context, id int) (types.InsightSeriesAlertRule, error) {
+	rules, err := s.listAlertRules(ctx, sqlf.Sprintf("id = %s", id))
+	if err != nil {
+		return types.InsightSeriesAlertRule{}, err
+	}
+	if len(rules) == 0 {
+		return types.InsightSeriesAlertRule{}, ErrAlertRuleNotFound
+	}
+	return rules[0], nil
+}
+
+type ListAlertRulesArgs struct {
+	// SeriesID restricts the rules to the insight series with the given ID, if non-zero.
+	SeriesID int
+	// CreatedBy restricts the rules to the ones created by the user with the given ID, if non-zero.
+	CreatedBy int32
+}
+
+// ListAlertRules returns the alert rules matching the given arguments, ordered by ID.
+func (s *AlertRuleStore) ListAlertRules(ctx context.Context, args ListAlertRulesArgs) ([]types.InsightSeriesAlertRule, error) {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if args.SeriesID != 0 {
+		preds = append(preds, sqlf.Sprintf("series_id = %s", args.SeriesID))
+	}
+	if args.CreatedBy != 0 {
+		preds = append(preds, sqlf.Sprintf("created_by = %s", args.CreatedBy))
+	}
+	return s.listAlertRules(ctx, sqlf.Join(preds, "AND"))
+}
+
+func (s *AlertRuleStore) listAlertRules(ctx context.Context, where *sqlf.Query) (_ []types.InsightSeriesAlertRule, err error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(listAlertRulesSql, where))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var rules []types.InsightSeriesAlertRule
+	for rows.Next() {
+		rule, err := scanAlertRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// DeleteAlertRule deletes the alert rule with the given ID.
+func (s *AlertRuleStore) DeleteAlertRule(ctx context.Context, id int) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteAlertRuleSql, id))
+}
+
+// MarkAlertRuleEvaluated records the outcome of an evaluation of the alert rule with the given ID. If
+// triggered is true, the rule is also marked as having sent its notifications.
+func (s *AlertRuleStore) MarkAlertRuleEvaluated(ctx context.Context, id int, firing, triggered bool) error {
+	now := s.Now()
+	var triggeredAt *time.Time
+	if triggered {
+		triggeredAt = &now
+	}
+	return s.Exec(ctx, sqlf.Sprintf(markAlertRuleEvaluatedSql, firing, now, triggeredAt, id))
+}
+
+func scanAlertRule(sc dbutil.Scanner) (rule types.InsightSeriesAlertRule, err error) {
+	var lastEvaluatedAt, lastTriggeredAt sql.NullTime
+	err = sc.Scan(
+		&rule.ID,
+		&rule.SeriesID,
+		&rule.Kind,
+		&rule.Direction,
+		&rule.Threshold,
+		&rule.WindowSize,
+		&rule.EmailUserID,
+		&rule.SlackWebhookURL,
+		&rule.WebhookURL,
+		&rule.CreatedBy,
+		&rule.CreatedAt,
+		&rule.Firing,
+		&lastEvaluatedAt,
+		&lastTriggeredAt,
+	)
+	if lastEvaluatedAt.Valid {
+		rule.LastEvaluatedAt = &lastEvaluatedAt.Time
+	}
+	if lastTriggeredAt.Valid {
+		rule.LastTriggeredAt = &lastTriggeredAt.Time
+	}
+	return rule, err
+}
+
+const alertRuleColumns = `
+id, series_id, kind, direction, threshold, window_size, email_user_id, slack_webhook_url, webhook_url,
+created_by, created_at, firing, last_evaluated_at, last_triggered_at
+`
+
+const createAlertRuleSql = `
+INSERT INTO insight_series_alert_rules (series_id, kind, direction, threshold, window_size, email_user_id,
+	slack_webhook_url, webhook_url, created_by, created_at)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING ` + alertRuleColumns
+
+const listAlertRulesSql = `
+SELECT ` + alertRuleColumns + `
+FROM insight_series_alert_rules
+WHERE %s
+ORDER BY id
+`
+
+const deleteAlertRuleSql = `
+DELETE FROM insight_series_alert_rules WHERE id = %s
+`
+
+const markAlertRuleEvaluatedSql = `
+UPDATE insight_series_alert_rules
+SET firing = %s, last_evaluated_at = %s, last_triggered_at = COALESCE(%s, last_triggered_at)
+WHERE id = %s
+`
@@ -0,0 +1,140 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/log/logtest"
+
+	edb "github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestAlertRuleStore(t *testing.T) {
+	logger := logtest.Scoped(t)
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	insightStore := NewInsightStore(insightsDB)
+	series, err := insightStore.CreateSeries(ctx, types.InsightSeries{
+		SeriesID:           "series1",
+		Query:              "query1",
+		CreatedAt:          now,
+		OldestHistoricalAt: now,
+		LastRecordedAt:     now,
+		NextRecordingAfter: now,
+		LastSnapshotAt:     now,
+		NextSnapshotAfter:  now,
+		BackfillQueuedAt:   now,
+		SampleIntervalUnit: string(types.Month),
+		GenerationMethod:   types.Search,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewAlertRuleStore(insightsDB)
+	store.Now = func() time.Time { return now }
+
+	userID := int32(1)
+	webhookURL := "https://example.com/webhook"
+	rule, err := store.CreateAlertRule(ctx, types.InsightSeriesAlertRule{
+		SeriesID:    series.ID,
+		Kind:        types.AlertRulePercentChange,
+		Direction:   types.AlertRuleAbove,
+		Threshold:   25,
+		WindowSize:  3,
+		EmailUserID: &userID,
+		WebhookURL:  &webhookURL,
+		CreatedBy:   &userID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule.ID == 0 {
+		t.Fatal("expected alert rule to have an ID")
+	}
+
+	got, err := store.GetAlertRule(ctx, rule.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(rule, got); diff != "" {
+		t.Errorf("unexpected alert rule (-want +got):\n%s", diff)
+	}
+
+	t.Run("list by series", func(t *testing.T) {
+		rules, err := store.ListAlertRules(ctx, ListAlertRulesArgs{SeriesID: series.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]types.InsightSeriesAlertRule{rule}, rules); diff != "" {
+			t.Errorf("unexpected alert rules (-want +got):\n%s", diff)
+		}
+
+		rules, err = store.ListAlertRules(ctx, ListAlertRulesArgs{SeriesID: series.ID + 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rules) != 0 {
+			t.Errorf("expected no alert rules, got %d", len(rules))
+		}
+	})
+
+	t.Run("list by creator", func(t *testing.T) {
+		rules, err := store.ListAlertRules(ctx, ListAlertRulesArgs{SeriesID: series.ID, CreatedBy: userID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]types.InsightSeriesAlertRule{rule}, rules); diff != "" {
+			t.Errorf("unexpected alert rules (-want +got):\n%s", diff)
+		}
+
+		rules, err = store.ListAlertRules(ctx, ListAlertRulesArgs{SeriesID: series.ID, CreatedBy: userID + 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rules) != 0 {
+			t.Errorf("expected no alert rules, got %d", len(rules))
+		}
+	})
+
+	t.Run("mark evaluated", func(t *testing.T) {
+		if err := store.MarkAlertRuleEvaluated(ctx, rule.ID, true, true); err != nil {
+			t.Fatal(err)
+		}
+		store.Now = func() time.Time { return now.Add(time.Hour) }
+		if err := store.MarkAlertRuleEvaluated(ctx, rule.ID, true, false); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := store.GetAlertRule(ctx, rule.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Firing {
+			t.Error("expected alert rule to be firing")
+		}
+		if got.LastEvaluatedAt == nil || !got.LastEvaluatedAt.Equal(now.Add(time.Hour)) {
+			t.Errorf("unexpected last evaluated at %v", got.LastEvaluatedAt)
+		}
+		if got.LastTriggeredAt == nil || !got.LastTriggeredAt.Equal(now) {
+			t.Errorf("unexpected last triggered at %v", got.LastTriggeredAt)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if err := store.DeleteAlertRule(ctx, rule.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := store.GetAlertRule(ctx, rule.ID); !errors.Is(err, ErrAlertRuleNotFound) {
+			t.Errorf("expected ErrAlertRuleNotFound, got %v", err)
+		}
+	})
+}
@@ -281,9 +281,13 @@ type GetDataSeriesArgs struct {
 	IncludeDeleted      bool
 	BackfillNotQueued   bool
 	BackfillNotComplete bool
+	BackfillComplete    bool
 	SeriesID            string
 	GlobalOnly          bool
 	ExcludeJustInTime   bool
+	// RevisionSeries filters for series that are sampled at tags if true, and for series that
+	// are sampled at time intervals if false.
+	RevisionSeries *bool
 }
 
 func (s *InsightStore) GetDataSeries(ctx context.Context, args GetDataSeriesArgs) ([]types.InsightSeries, error) {
@@ -307,6 +311,9 @@ func (s *InsightStore) GetDataSeries(ctx context.Context, args GetDataSeriesArgs
 	if args.BackfillNotComplete {
 		preds = append(preds, sqlf.Sprintf("backfill_completed_at IS NULL"))
 	}
+	if args.BackfillComplete {
+		preds = append(preds, sqlf.Sprintf("backfill_completed_at IS NOT NULL"))
+	}
 	if len(args.SeriesID) > 0 {
 		preds = append(preds, sqlf.Sprintf("series_id = %s", args.SeriesID))
 	}
@@ -319,6 +326,13 @@ func (s *InsightStore) GetDataSeries(ctx context.Context, args GetDataSeriesArgs
 	if args.ExcludeJustInTime {
 		preds = append(preds, sqlf.Sprintf("just_in_time = false"))
 	}
+	if args.RevisionSeries != nil {
+		if *args.RevisionSeries {
+			preds = append(preds, sqlf.Sprintf("revision_pattern IS NOT NULL"))
+		} else {
+			preds = append(preds, sqlf.Sprintf("revision_pattern IS NULL"))
+		}
+	}
 
 	q := sqlf.Sprintf(getInsightDataSeriesSql, sqlf.Join(preds, "\n AND"))
 	return scanDataSeries(s.Query(ctx, q))
@@ -414,6 +428,7 @@ func scanDataSeries(rows *sql.Rows, queryErr error) (_ []types.InsightSeries, er
 			&temp.BackfillAttempts,
 			&temp.SupportsAugmentation,
 			&temp.RepositoryCriteria,
+			&temp.RevisionPattern,
 		); err != nil {
 			return []types.InsightSeries{}, err
 		}
@@ -488,6 +503,7 @@ func scanInsightViewSeries(rows *sql.Rows, queryErr error) (_ []types.InsightVie
 			&temp.BackfillAttempts,
 			&temp.SupportsAugmentation,
 			&temp.RepositoryCriteria,
+			&temp.RevisionPattern,
 		); err != nil {
 			return []types.InsightViewSeries{}, err
 		}
@@ -734,6 +750,7 @@ func (s *InsightStore) CreateSeries(ctx context.Context, series types.InsightSer
 		series.GenerationMethod,
 		series.GroupBy,
 		series.RepositoryCriteria,
+		series.RevisionPattern,
 	))
 	var id int
 	err := row.Scan(&id)
@@ -820,6 +837,8 @@ type MatchSeriesArgs struct {
 	StepIntervalValue         int
 	GenerateFromCaptureGroups bool
 	GroupBy                   *string
+	// GenerationMethod, if set, restricts the matches to series with the given generation method.
+	GenerationMethod types.GenerationMethod
 }
 
 func (s *InsightStore) FindMatchingSeries(ctx context.Context, args MatchSeriesArgs) (_ types.InsightSeries, found bool, _ error) {
@@ -827,9 +846,13 @@ func (s *InsightStore) FindMatchingSeries(ctx context.Context, args MatchSeriesA
 	if args.GroupBy != nil {
 		groupByClause = sqlf.Sprintf("group_by = %s", *args.GroupBy)
 	}
+	generationMethodClause := sqlf.Sprintf("TRUE")
+	if args.GenerationMethod != "" {
+		generationMethodClause = sqlf.Sprintf("generation_method = %s", args.GenerationMethod)
+	}
 	where := sqlf.Sprintf(
-		"(repositories = '{}' OR repositories is NULL) AND query = %s AND sample_interval_unit = %s AND sample_interval_value = %s AND generated_from_capture_groups = %s AND %s",
-		args.Query, args.StepIntervalUnit, args.StepIntervalValue, args.GenerateFromCaptureGroups, groupByClause,
+		"(repositories = '{}' OR repositories is NULL) AND query = %s AND sample_interval_unit = %s AND sample_interval_value = %s AND generated_from_capture_groups = %s AND %s AND %s",
+		args.Query, args.StepIntervalUnit, args.StepIntervalValue, args.GenerateFromCaptureGroups, groupByClause, generationMethodClause,
 	)
 
 	q := sqlf.Sprintf(getInsightDataSeriesSql, where)
@@ -969,8 +992,9 @@ const createInsightSeriesSql = `
 INSERT INTO insight_series (series_id, query, created_at, oldest_historical_at, last_recorded_at,
                             next_recording_after, last_snapshot_at, next_snapshot_after, repositories,
 							sample_interval_unit, sample_interval_value, generated_from_capture_groups,
-							just_in_time, generation_method, group_by, needs_migration, repository_criteria)
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, false, %s)
+							just_in_time, generation_method, group_by, needs_migration, repository_criteria,
+							revision_pattern)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, false, %s, %s)
 RETURNING id;`
 
 const getInsightByViewSql = `
@@ -980,7 +1004,7 @@ i.next_recording_after, i.backfill_queued_at, i.last_snapshot_at, i.next_snapsho
 i.sample_interval_unit, i.sample_interval_value, iv.default_filter_include_repo_regex, iv.default_filter_exclude_repo_regex,
 iv.other_threshold, iv.presentation_type, i.generated_from_capture_groups, i.just_in_time, i.generation_method, iv.is_frozen,
 default_filter_search_contexts, iv.series_sort_mode, iv.series_sort_direction, iv.series_limit, iv.series_num_samples,
-i.group_by, i.backfill_attempts, i.supports_augmentation, i.repository_criteria
+i.group_by, i.backfill_attempts, i.supports_augmentation, i.repository_criteria, i.revision_pattern
 FROM (%s) iv
          JOIN insight_view_series ivs ON iv.id = ivs.insight_view_id
          JOIN insight_series i ON ivs.insight_series_id = i.id
@@ -995,7 +1019,7 @@ i.next_recording_after, i.backfill_queued_at, i.last_snapshot_at, i.next_snapsho
 i.sample_interval_unit, i.sample_interval_value, iv.default_filter_include_repo_regex, iv.default_filter_exclude_repo_regex,
 iv.other_threshold, iv.presentation_type, i.generated_from_capture_groups, i.just_in_time, i.generation_method, iv.is_frozen,
 default_filter_search_contexts, iv.series_sort_mode, iv.series_sort_direction, iv.series_limit, iv.series_num_samples,
-i.group_by, i.backfill_attempts, i.supports_augmentation, i.repository_criteria
+i.group_by, i.backfill_attempts, i.supports_augmentation, i.repository_criteria, i.revision_pattern
 FROM dashboard_insight_view as dbiv
 		 JOIN insight_view iv ON iv.id = dbiv.insight_view_id
          JOIN insight_view_series ivs ON iv.id = ivs.insight_view_id
@@ -1009,7 +1033,8 @@ const getInsightDataSeriesSql = `
 SELECT id, series_id, query, created_at, oldest_historical_at, last_recorded_at, next_recording_after,
 last_snapshot_at, next_snapshot_after, (CASE WHEN deleted_at IS NULL THEN TRUE ELSE FALSE END) AS enabled,
 sample_interval_unit, sample_interval_value, generated_from_capture_groups,
-just_in_time, generation_method, repositories, group_by, backfill_attempts, supports_augmentation, repository_criteria
+just_in_time, generation_method, repositories, group_by, backfill_attempts, supports_augmentation, repository_criteria,
+revision_pattern
 FROM insight_series
 WHERE %s
 `
@@ -1036,7 +1061,7 @@ SELECT iv.id, 0 as dashboard_insight_id, iv.unique_id, iv.title, iv.description,
        i.sample_interval_unit, i.sample_interval_value, iv.default_filter_include_repo_regex, iv.default_filter_exclude_repo_regex,
 	   iv.other_threshold, iv.presentation_type, i.generated_from_capture_groups, i.just_in_time, i.generation_method, iv.is_frozen,
 	   default_filter_search_contexts, iv.series_sort_mode, iv.series_sort_direction, iv.series_limit, iv.series_num_samples,
-	   i.group_by, i.backfill_attempts, i.supports_augmentation, i.repository_criteria
+	   i.group_by, i.backfill_attempts, i.supports_augmentation, i.repository_criteria, i.revision_pattern
 
 FROM insight_view iv
 JOIN insight_view_series ivs ON iv.id = ivs.insight_view_id
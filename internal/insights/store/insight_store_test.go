@@ -2213,6 +2213,13 @@ func TestFindMatchingSeries(t *testing.T) {
 		autogold.ExpectFile(t, gotSeries, autogold.ExportedOnly())
 		autogold.Expect(true).Equal(t, gotFound)
 	})
+	t.Run("find no matching series with a different generation method", func(t *testing.T) {
+		_, gotFound, err := store.FindMatchingSeries(ctx, MatchSeriesArgs{Query: "query 1", StepIntervalUnit: string(types.Week), StepIntervalValue: 1, GenerationMethod: types.PreciseCodeIntel})
+		if err != nil {
+			t.Fatal(err)
+		}
+		autogold.Expect(false).Equal(t, gotFound)
+	})
 }
 
 func TestUpdateFrontendSeries(t *testing.T) {
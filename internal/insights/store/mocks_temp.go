@@ -1364,6 +1364,12 @@ type MockInterface struct {
 	// function object controlling the behavior of the method
 	// RecordSeriesPointsAndRecordingTimes.
 	RecordSeriesPointsAndRecordingTimesFunc *InterfaceRecordSeriesPointsAndRecordingTimesFunc
+	// RepoSeriesBreakdownFunc is an instance of a mock function object
+	// controlling the behavior of the method RepoSeriesBreakdown.
+	RepoSeriesBreakdownFunc *InterfaceRepoSeriesBreakdownFunc
+	// RepoSeriesPointsFunc is an instance of a mock function object
+	// controlling the behavior of the method RepoSeriesPoints.
+	RepoSeriesPointsFunc *InterfaceRepoSeriesPointsFunc
 	// SeriesPointsFunc is an instance of a mock function object controlling
 	// the behavior of the method SeriesPoints.
 	SeriesPointsFunc *InterfaceSeriesPointsFunc
@@ -1415,6 +1421,16 @@ func NewMockInterface() *MockInterface {
 				return
 			},
 		},
+		RepoSeriesBreakdownFunc: &InterfaceRepoSeriesBreakdownFunc{
+			defaultHook: func(context.Context, SeriesPointsOpts, RepoSeriesBreakdownOpts) (r0 []RepoSeriesSummary, r1 int, r2 error) {
+				return
+			},
+		},
+		RepoSeriesPointsFunc: &InterfaceRepoSeriesPointsFunc{
+			defaultHook: func(context.Context, SeriesPointsOpts) (r0 []RepoSeries, r1 error) {
+				return
+			},
+		},
 		SeriesPointsFunc: &InterfaceSeriesPointsFunc{
 			defaultHook: func(context.Context, SeriesPointsOpts) (r0 []SeriesPoint, r1 error) {
 				return
@@ -1472,6 +1488,16 @@ func NewStrictMockInterface() *MockInterface {
 				panic("unexpected invocation of MockInterface.RecordSeriesPointsAndRecordingTimes")
 			},
 		},
+		RepoSeriesBreakdownFunc: &InterfaceRepoSeriesBreakdownFunc{
+			defaultHook: func(context.Context, SeriesPointsOpts, RepoSeriesBreakdownOpts) ([]RepoSeriesSummary, int, error) {
+				panic("unexpected invocation of MockInterface.RepoSeriesBreakdown")
+			},
+		},
+		RepoSeriesPointsFunc: &InterfaceRepoSeriesPointsFunc{
+			defaultHook: func(context.Context, SeriesPointsOpts) ([]RepoSeries, error) {
+				panic("unexpected invocation of MockInterface.RepoSeriesPoints")
+			},
+		},
 		SeriesPointsFunc: &InterfaceSeriesPointsFunc{
 			defaultHook: func(context.Context, SeriesPointsOpts) ([]SeriesPoint, error) {
 				panic("unexpected invocation of MockInterface.SeriesPoints")
@@ -1515,6 +1541,12 @@ func NewMockInterfaceFrom(i Interface) *MockInterface {
 		RecordSeriesPointsAndRecordingTimesFunc: &InterfaceRecordSeriesPointsAndRecordingTimesFunc{
 			defaultHook: i.RecordSeriesPointsAndRecordingTimes,
 		},
+		RepoSeriesBreakdownFunc: &InterfaceRepoSeriesBreakdownFunc{
+			defaultHook: i.RepoSeriesBreakdown,
+		},
+		RepoSeriesPointsFunc: &InterfaceRepoSeriesPointsFunc{
+			defaultHook: i.RepoSeriesPoints,
+		},
 		SeriesPointsFunc: &InterfaceSeriesPointsFunc{
 			defaultHook: i.SeriesPoints,
 		},
@@ -2299,6 +2331,229 @@ func (c InterfaceRecordSeriesPointsAndRecordingTimesFuncCall) Results() []interf
 	return []interface{}{c.Result0}
 }
 
+// InterfaceRepoSeriesBreakdownFunc describes the behavior when the
+// RepoSeriesBreakdown method of the parent MockInterface instance is
+// invoked.
+type InterfaceRepoSeriesBreakdownFunc struct {
+	defaultHook func(context.Context, SeriesPointsOpts, RepoSeriesBreakdownOpts) ([]RepoSeriesSummary, int, error)
+	hooks       []func(context.Context, SeriesPointsOpts, RepoSeriesBreakdownOpts) ([]RepoSeriesSummary, int, error)
+	history     []InterfaceRepoSeriesBreakdownFuncCall
+	mutex       sync.Mutex
+}
+
+// RepoSeriesBreakdown delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockInterface) RepoSeriesBreakdown(v0 context.Context, v1 SeriesPointsOpts, v2 RepoSeriesBreakdownOpts) ([]RepoSeriesSummary, int, error) {
+	r0, r1, r2 := m.RepoSeriesBreakdownFunc.nextHook()(v0, v1, v2)
+	m.RepoSeriesBreakdownFunc.appendCall(InterfaceRepoSeriesBreakdownFuncCall{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the RepoSeriesBreakdown
+// method of the parent MockInterface instance is invoked and the hook queue
+// is empty.
+func (f *InterfaceRepoSeriesBreakdownFunc) SetDefaultHook(hook func(context.Context, SeriesPointsOpts, RepoSeriesBreakdownOpts) ([]RepoSeriesSummary, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RepoSeriesBreakdown method of the parent MockInterface instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *InterfaceRepoSeriesBreakdownFunc) PushHook(hook func(context.Context, SeriesPointsOpts, RepoSeriesBreakdownOpts) ([]RepoSeriesSummary, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *InterfaceRepoSeriesBreakdownFunc) SetDefaultReturn(r0 []RepoSeriesSummary, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, SeriesPointsOpts, RepoSeriesBreakdownOpts) ([]RepoSeriesSummary, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *InterfaceRepoSeriesBreakdownFunc) PushReturn(r0 []RepoSeriesSummary, r1 int, r2 error) {
+	f.PushHook(func(context.Context, SeriesPointsOpts, RepoSeriesBreakdownOpts) ([]RepoSeriesSummary, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *InterfaceRepoSeriesBreakdownFunc) nextHook() func(context.Context, SeriesPointsOpts, RepoSeriesBreakdownOpts) ([]RepoSeriesSummary, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *InterfaceRepoSeriesBreakdownFunc) appendCall(r0 InterfaceRepoSeriesBreakdownFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of InterfaceRepoSeriesBreakdownFuncCall
+// objects describing the invocations of this function.
+func (f *InterfaceRepoSeriesBreakdownFunc) History() []InterfaceRepoSeriesBreakdownFuncCall {
+	f.mutex.Lock()
+	history := make([]InterfaceRepoSeriesBreakdownFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// InterfaceRepoSeriesBreakdownFuncCall is an object that describes an
+// invocation of method RepoSeriesBreakdown on an instance of MockInterface.
+type InterfaceRepoSeriesBreakdownFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 SeriesPointsOpts
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 RepoSeriesBreakdownOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []RepoSeriesSummary
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c InterfaceRepoSeriesBreakdownFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c InterfaceRepoSeriesBreakdownFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// InterfaceRepoSeriesPointsFunc describes the behavior when the
+// RepoSeriesPoints method of the parent MockInterface instance is invoked.
+type InterfaceRepoSeriesPointsFunc struct {
+	defaultHook func(context.Context, SeriesPointsOpts) ([]RepoSeries, error)
+	hooks       []func(context.Context, SeriesPointsOpts) ([]RepoSeries, error)
+	history     []InterfaceRepoSeriesPointsFuncCall
+	mutex       sync.Mutex
+}
+
+// RepoSeriesPoints delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockInterface) RepoSeriesPoints(v0 context.Context, v1 SeriesPointsOpts) ([]RepoSeries, error) {
+	r0, r1 := m.RepoSeriesPointsFunc.nextHook()(v0, v1)
+	m.RepoSeriesPointsFunc.appendCall(InterfaceRepoSeriesPointsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the RepoSeriesPoints
+// method of the parent MockInterface instance is invoked and the hook queue
+// is empty.
+func (f *InterfaceRepoSeriesPointsFunc) SetDefaultHook(hook func(context.Context, SeriesPointsOpts) ([]RepoSeries, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RepoSeriesPoints method of the parent MockInterface instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *InterfaceRepoSeriesPointsFunc) PushHook(hook func(context.Context, SeriesPointsOpts) ([]RepoSeries, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *InterfaceRepoSeriesPointsFunc) SetDefaultReturn(r0 []RepoSeries, r1 error) {
+	f.SetDefaultHook(func(context.Context, SeriesPointsOpts) ([]RepoSeries, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *InterfaceRepoSeriesPointsFunc) PushReturn(r0 []RepoSeries, r1 error) {
+	f.PushHook(func(context.Context, SeriesPointsOpts) ([]RepoSeries, error) {
+		return r0, r1
+	})
+}
+
+func (f *InterfaceRepoSeriesPointsFunc) nextHook() func(context.Context, SeriesPointsOpts) ([]RepoSeries, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *InterfaceRepoSeriesPointsFunc) appendCall(r0 InterfaceRepoSeriesPointsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of InterfaceRepoSeriesPointsFuncCall objects
+// describing the invocations of this function.
+func (f *InterfaceRepoSeriesPointsFunc) History() []InterfaceRepoSeriesPointsFuncCall {
+	f.mutex.Lock()
+	history := make([]InterfaceRepoSeriesPointsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// InterfaceRepoSeriesPointsFuncCall is an object that describes an
+// invocation of method RepoSeriesPoints on an instance of MockInterface.
+type InterfaceRepoSeriesPointsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 SeriesPointsOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []RepoSeries
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c InterfaceRepoSeriesPointsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c InterfaceRepoSeriesPointsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // InterfaceSeriesPointsFunc describes the behavior when the SeriesPoints
 // method of the parent MockInterface instance is invoked.
 type InterfaceSeriesPointsFunc struct {
@@ -15,6 +15,8 @@ import (
 	edb "github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/insights/timeseries"
 	"github.com/sourcegraph/sourcegraph/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -25,6 +27,8 @@ import (
 type Interface interface {
 	WithOther(other basestore.ShareableStore) Interface
 	SeriesPoints(ctx context.Context, opts SeriesPointsOpts) ([]SeriesPoint, error)
+	RepoSeriesPoints(ctx context.Context, opts SeriesPointsOpts) ([]RepoSeries, error)
+	RepoSeriesBreakdown(ctx context.Context, opts SeriesPointsOpts, breakdownOpts RepoSeriesBreakdownOpts) ([]RepoSeriesSummary, int, error)
 	CountData(ctx context.Context, opts CountDataOpts) (int, error)
 	RecordSeriesPoints(ctx context.Context, pts []RecordSeriesPointArgs) error
 	RecordSeriesPointsAndRecordingTimes(ctx context.Context, pts []RecordSeriesPointArgs, recordingTimes types.InsightSeriesRecordingTimes) error
@@ -95,6 +99,8 @@ type SeriesPoint struct {
 	Time     time.Time
 	Value    float64
 	Capture  *string
+	// Revision is the tag that the point was sampled at, for series sampled at tags.
+	Revision *string
 }
 
 func (s *SeriesPoint) String() string {
@@ -122,6 +128,9 @@ type SeriesPointsOpts struct {
 	IncludeRepoRegex []string
 	ExcludeRepoRegex []string
 
+	// Capture, if non-nil, indicates to filter results to only points recorded with this capture value.
+	Capture *string
+
 	// Time ranges to query from/to (inclusive) or after (exclusive), if non-nil, in UTC.
 	From, To, After *time.Time
 
@@ -300,6 +309,174 @@ func (s *Store) LoadSeriesInMem(ctx context.Context, opts SeriesPointsOpts) (poi
 	return points, err
 }
 
+// RepoSeries is the time series of a single repository that makes up part of an insights' series.
+type RepoSeries struct {
+	RepoID api.RepoID
+	// RepoName is the name that the repository was most recently recorded with.
+	RepoName string
+	// Points are ordered by time.
+	Points []SeriesPoint
+}
+
+// RepoSeriesPoints queries data points over time for a specific insights' series, per repository.
+// The points of all capture values are summed for each repository, unless opts.Capture is set.
+// Repositories are ordered by ID.
+func (s *Store) RepoSeriesPoints(ctx context.Context, opts SeriesPointsOpts) ([]RepoSeries, error) {
+	// 🚨 SECURITY: This is the same double-negative repo permission enforcement as SeriesPoints. 🚨
+	denylist, err := s.permStore.GetUnauthorizedRepoIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts.Excluded = append(opts.Excluded, denylist...)
+
+	var series []RepoSeries
+	byRepo := make(map[api.RepoID]map[time.Time]float64)
+	q := seriesPointsQuery(repoSeriesAggregation, opts)
+	err = s.query(ctx, q, func(sc scanner) error {
+		var (
+			repoID   api.RepoID
+			repoName string
+			point    SeriesPoint
+		)
+		if err := sc.Scan(&repoID, &repoName, &point.Time, &point.Value); err != nil {
+			return err
+		}
+		if opts.SeriesID != nil {
+			point.SeriesID = *opts.SeriesID
+		}
+		point.Capture = opts.Capture
+		// Rows are ordered by repository and time, so the last row of a repository holds its latest name.
+		if len(series) == 0 || series[len(series)-1].RepoID != repoID {
+			series = append(series, RepoSeries{RepoID: repoID})
+			byRepo[repoID] = make(map[time.Time]float64)
+		}
+		current := &series[len(series)-1]
+		current.RepoName = repoName
+		current.Points = append(current.Points, point)
+		byRepo[repoID][point.Time.UTC()] = point.Value
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.ID == nil || opts.SeriesID == nil || !opts.SupportsAugmentation || len(series) == 0 {
+		return series, nil
+	}
+	recordingsData, err := s.GetInsightSeriesRecordingTimes(ctx, *opts.ID, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetInsightSeriesRecordingTimes")
+	}
+	if len(recordingsData.RecordingTimes) == 0 {
+		return series, nil
+	}
+	// A repository has no point at the times that its search had no results, so these are filled
+	// in with zero values.
+	for i := range series {
+		values := byRepo[series[i].RepoID]
+		points := make([]SeriesPoint, 0, len(recordingsData.RecordingTimes))
+		for _, recordingTime := range recordingsData.RecordingTimes {
+			point := SeriesPoint{
+				SeriesID: *opts.SeriesID,
+				Time:     recordingTime.Timestamp,
+				Value:    values[recordingTime.Timestamp.UTC()],
+				Capture:  opts.Capture,
+			}
+			if recordingTime.Revision != "" {
+				revision := recordingTime.Revision
+				point.Revision = &revision
+			}
+			points = append(points, point)
+		}
+		series[i].Points = points
+	}
+	return series, nil
+}
+
+// RepoSeriesBreakdownOpts orders and paginates the repositories of an insights' series.
+type RepoSeriesBreakdownOpts struct {
+	// OrderByChange orders the repositories by their change instead of their latest value.
+	OrderByChange bool
+	Descending    bool
+
+	// ChangeWindow, if non-nil, is the window that the change is computed over, ending at the
+	// latest point. The change is computed over all points otherwise.
+	ChangeWindow *timeseries.TimeInterval
+
+	Limit, Offset int
+}
+
+// RepoSeriesSummary is the latest value and change of the time series of a single repository.
+type RepoSeriesSummary struct {
+	RepoID      api.RepoID
+	RepoName    string
+	LatestValue float64
+	Change      float64
+}
+
+// RepoSeriesBreakdown returns a page of the repositories of a specific insights' series, ordered
+// as requested, along with the total number of repositories. Repositories with the same value are
+// ordered by name. The points of all capture values are summed for each repository, unless
+// opts.Capture is set.
+//
+// The latest value of a repository is its value at the latest time that the series has a point,
+// and its change is the difference to its value at the last time at or before the start of the
+// change window. A repository without a point at one of these times has the value zero at that
+// time, as its search had no results.
+func (s *Store) RepoSeriesBreakdown(ctx context.Context, opts SeriesPointsOpts, breakdownOpts RepoSeriesBreakdownOpts) (_ []RepoSeriesSummary, totalCount int, err error) {
+	// 🚨 SECURITY: This is the same double-negative repo permission enforcement as SeriesPoints. 🚨
+	denylist, err := s.permStore.GetUnauthorizedRepoIDs(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	opts.Excluded = append(opts.Excluded, denylist...)
+
+	window := sqlf.Sprintf("NULL::interval")
+	if breakdownOpts.ChangeWindow != nil {
+		window = sqlf.Sprintf("%s::interval", fmt.Sprintf("%d %s", breakdownOpts.ChangeWindow.Value, strings.ToLower(string(breakdownOpts.ChangeWindow.Unit))))
+	}
+	orderBy := "latest_value"
+	if breakdownOpts.OrderByChange {
+		orderBy = "change"
+	}
+	direction := "ASC"
+	if breakdownOpts.Descending {
+		direction = "DESC"
+	}
+	repoValues := seriesPointsQuery(repoSeriesValues, opts)
+
+	var summaries []RepoSeriesSummary
+	q := sqlf.Sprintf(
+		repoSeriesBreakdownQuery,
+		repoValues,
+		window,
+		sqlf.Sprintf(fmt.Sprintf("%s %s", orderBy, direction)),
+		breakdownOpts.Limit,
+		breakdownOpts.Offset,
+	)
+	err = s.query(ctx, q, func(sc scanner) error {
+		var summary RepoSeriesSummary
+		if err := sc.Scan(&summary.RepoID, &summary.RepoName, &summary.LatestValue, &summary.Change, &totalCount); err != nil {
+			return err
+		}
+		summaries = append(summaries, summary)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(summaries) > 0 {
+		return summaries, totalCount, nil
+	}
+
+	// The total is returned with every row, so it has to be counted separately for empty pages.
+	totalCount, _, err = basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(countRepoSeriesQuery, repoValues)))
+	if err != nil {
+		return nil, 0, err
+	}
+	return nil, totalCount, nil
+}
+
 // Delete will delete the time series data for a particular series_id. This will hard (permanently) delete the data.
 func (s *Store) Delete(ctx context.Context, seriesId string) (err error) {
 	tx, err := s.Transact(ctx)
@@ -348,6 +525,62 @@ GROUP BY sub.series_id, sub.interval_time, sub.capture
 ORDER BY sub.series_id, sub.interval_time ASC
 `
 
+// repoSeriesValues is fullVectorSeriesAggregation without the sum over repositories. A repository
+// is recorded under more than one name if it was renamed, so its points are grouped by repository ID
+// instead of name.
+const repoSeriesValues = `
+SELECT sub.repo_id, MAX(sub.repo_name_id) AS repo_name_id, sub.interval_time, SUM(sub.value) as value FROM (
+	SELECT sp.repo_id, MAX(sp.repo_name_id) AS repo_name_id, date_trunc('seconds', sp.time) AS interval_time, MAX(value) as value, capture
+	FROM (  select * from series_points
+			union all
+			select * from series_points_snapshots
+	) AS sp
+	%s
+	WHERE sp.repo_id IS NOT NULL AND %s
+	GROUP BY sp.repo_id, interval_time, capture
+) sub
+GROUP BY sub.repo_id, sub.interval_time
+`
+
+const repoSeriesAggregation = `
+SELECT agg.repo_id, rn.name, agg.interval_time, agg.value FROM (` + repoSeriesValues + `) agg
+JOIN repo_names rn ON agg.repo_name_id = rn.id
+ORDER BY agg.repo_id, agg.interval_time ASC
+`
+
+// repoSeriesBreakdownQuery computes the latest value and change of each repository from the
+// values of repoSeriesValues. The latest name of a repository is the name of its latest point.
+const repoSeriesBreakdownQuery = `
+WITH agg AS (%s),
+latest AS (
+	SELECT MAX(interval_time) AS interval_time FROM agg
+),
+window_start AS (
+	SELECT COALESCE(
+		(SELECT MAX(agg.interval_time) FROM agg, latest WHERE agg.interval_time <= latest.interval_time - %s),
+		(SELECT MIN(interval_time) FROM agg)
+	) AS interval_time
+),
+repos AS (
+	SELECT
+		agg.repo_id,
+		(ARRAY_AGG(agg.repo_name_id ORDER BY agg.interval_time DESC))[1] AS repo_name_id,
+		COALESCE(SUM(agg.value) FILTER (WHERE agg.interval_time = latest.interval_time), 0) AS latest_value,
+		COALESCE(SUM(agg.value) FILTER (WHERE agg.interval_time = window_start.interval_time), 0) AS start_value
+	FROM agg, latest, window_start
+	GROUP BY agg.repo_id
+)
+SELECT repos.repo_id, rn.name, repos.latest_value, repos.latest_value - repos.start_value AS change, COUNT(*) OVER () AS total_count
+FROM repos
+JOIN repo_names rn ON repos.repo_name_id = rn.id
+ORDER BY %s, rn.name ASC, repos.repo_id ASC
+LIMIT %s OFFSET %s
+`
+
+const countRepoSeriesQuery = `
+SELECT COUNT(DISTINCT repo_id) FROM (%s) agg
+`
+
 // Note that the series_points table may contain duplicate points, or points recorded at irregular
 // intervals. In specific:
 //
@@ -396,6 +629,9 @@ func seriesPointsPredicates(opts SeriesPointsOpts) []*sqlf.Query {
 	if opts.RepoID != nil {
 		preds = append(preds, sqlf.Sprintf("repo_id = %d", int32(*opts.RepoID)))
 	}
+	if opts.Capture != nil {
+		preds = append(preds, sqlf.Sprintf("capture = %s", *opts.Capture))
+	}
 	if opts.From != nil {
 		preds = append(preds, sqlf.Sprintf("time >= %s", *opts.From))
 	}
@@ -632,17 +868,17 @@ func (s *Store) SetInsightSeriesRecordingTimes(ctx context.Context, seriesRecord
 	if len(seriesRecordingTimes) == 0 {
 		return nil
 	}
-	inserter := batch.NewInserterWithConflict(ctx, s.Handle(), "insight_series_recording_times", batch.MaxNumPostgresParameters, "ON CONFLICT DO NOTHING", "insight_series_id", "recording_time", "snapshot")
+	inserter := batch.NewInserterWithConflict(ctx, s.Handle(), "insight_series_recording_times", batch.MaxNumPostgresParameters, "ON CONFLICT DO NOTHING", "insight_series_id", "recording_time", "snapshot", "revision")
 
 	for _, series := range seriesRecordingTimes {
 		id := series.InsightSeriesID
 		for _, record := range series.RecordingTimes {
 			if err := inserter.Insert(
 				ctx,
-				id,                     // insight_series_id
-				record.Timestamp.UTC(), // recording_time
-				record.Snapshot,        // snapshot
-
+				id,                                       // insight_series_id
+				record.Timestamp.UTC(),                   // recording_time
+				record.Snapshot,                          // snapshot
+				dbutil.NullStringColumn(record.Revision), // revision
 			); err != nil {
 				return errors.Wrap(err, "Insert")
 			}
@@ -674,15 +910,16 @@ func (s *Store) GetInsightSeriesRecordingTimes(ctx context.Context, id int, opts
 
 	recordingTimes := []types.RecordingTime{}
 	err = s.query(ctx, timesQuery, func(sc scanner) (err error) {
-		var recordingTime time.Time
+		var recordingTime types.RecordingTime
 		err = sc.Scan(
-			&recordingTime,
+			&recordingTime.Timestamp,
+			&dbutil.NullString{S: &recordingTime.Revision},
 		)
 		if err != nil {
 			return err
 		}
 
-		recordingTimes = append(recordingTimes, types.RecordingTime{Timestamp: recordingTime})
+		recordingTimes = append(recordingTimes, recordingTime)
 		return nil
 	})
 	if err != nil {
@@ -756,6 +993,10 @@ func coalesceZeroValues(seriesID string, pointsMap map[string]*SeriesPoint, capt
 	augmentedPoints := []SeriesPoint{}
 	for _, recordingTime := range recordingTimes {
 		timestamp := recordingTime.Timestamp
+		var revision *string
+		if recordingTime.Revision != "" {
+			revision = &recordingTime.Revision
+		}
 		// We have to pivot on potential capture values as well. This is because for capture group data we need to know
 		// which capture group values to attach zero data to. Take points [{oct 20, "a"}, {oct 24 "a"}, {oct 24 "b"}]
 		// and recording times [oct 20, oct 24]. Without the capture value data we would not be able to know we have a
@@ -763,7 +1004,9 @@ func coalesceZeroValues(seriesID string, pointsMap map[string]*SeriesPoint, capt
 		for captureValue := range captureValues {
 			captureValue := captureValue
 			if point, ok := pointsMap[timestamp.String()+captureValue]; ok {
-				augmentedPoints = append(augmentedPoints, *point)
+				augmentedPoint := *point
+				augmentedPoint.Revision = revision
+				augmentedPoints = append(augmentedPoints, augmentedPoint)
 			} else {
 				var capture *string
 				if captureValue != "" {
@@ -774,6 +1017,7 @@ func coalesceZeroValues(seriesID string, pointsMap map[string]*SeriesPoint, capt
 					Time:     timestamp,
 					Value:    0,
 					Capture:  capture,
+					Revision: revision,
 				})
 			}
 		}
@@ -794,7 +1038,7 @@ UNION
 `
 
 const getInsightSeriesRecordingTimesStr = `
-SELECT date_trunc('seconds', recording_time) FROM insight_series_recording_times
+SELECT date_trunc('seconds', recording_time), revision FROM insight_series_recording_times
 WHERE %s
 ORDER BY recording_time ASC;
 `
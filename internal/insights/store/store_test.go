@@ -17,6 +17,7 @@ import (
 	edb "github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/insights/timeseries"
 	"github.com/sourcegraph/sourcegraph/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -128,6 +129,183 @@ SELECT time,
 	})
 }
 
+func TestRepoSeriesPoints(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
+	postgres := database.NewDB(logger, dbtest.NewDB(t))
+	permStore := NewInsightPermissionStore(postgres)
+	insightStore := NewInsightStore(insightsDB)
+	store := NewWithClock(insightsDB, permStore, timeutil.Now)
+
+	now := time.Date(2021, time.September, 10, 10, 0, 0, 0, time.UTC)
+	earlier := now.Add(-time.Hour * 24 * 14)
+
+	series, err := insightStore.CreateSeries(ctx, types.InsightSeries{
+		SeriesID:            "one",
+		Query:               "query-1",
+		SampleIntervalUnit:  string(types.Week),
+		SampleIntervalValue: 2,
+		GenerationMethod:    types.Search,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.SetInsightSeriesRecordingTimes(ctx, []types.InsightSeriesRecordingTimes{{
+		InsightSeriesID: series.ID,
+		RecordingTimes:  []types.RecordingTime{{Timestamp: earlier}, {Timestamp: now}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record := func(repoID api.RepoID, repoName string, at time.Time, value float64, capture *string) RecordSeriesPointArgs {
+		return RecordSeriesPointArgs{
+			SeriesID:    "one",
+			Point:       SeriesPoint{Time: at, Value: value, Capture: capture},
+			RepoName:    pointers.Ptr(repoName),
+			RepoID:      pointers.Ptr(repoID),
+			PersistMode: RecordMode,
+		}
+	}
+	err = store.RecordSeriesPoints(ctx, []RecordSeriesPointArgs{
+		record(1, "repo1", earlier, 1, pointers.Ptr("a")),
+		record(1, "repo1", earlier, 2, pointers.Ptr("b")),
+		record(1, "repo1-renamed", now, 4, pointers.Ptr("a")),
+		record(2, "repo2", now, 3, pointers.Ptr("a")),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	point := func(at time.Time, value float64, capture *string) SeriesPoint {
+		return SeriesPoint{SeriesID: "one", Time: at, Value: value, Capture: capture}
+	}
+
+	t.Run("recorded points", func(t *testing.T) {
+		got, err := store.RepoSeriesPoints(ctx, SeriesPointsOpts{SeriesID: pointers.Ptr("one")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []RepoSeries{
+			{RepoID: 1, RepoName: "repo1-renamed", Points: []SeriesPoint{point(earlier, 3, nil), point(now, 4, nil)}},
+			{RepoID: 2, RepoName: "repo2", Points: []SeriesPoint{point(now, 3, nil)}},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("unexpected repo series (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("augmented capture value", func(t *testing.T) {
+		capture := pointers.Ptr("a")
+		got, err := store.RepoSeriesPoints(ctx, SeriesPointsOpts{
+			SeriesID:             pointers.Ptr("one"),
+			ID:                   &series.ID,
+			SupportsAugmentation: true,
+			Capture:              capture,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []RepoSeries{
+			{RepoID: 1, RepoName: "repo1-renamed", Points: []SeriesPoint{point(earlier, 1, capture), point(now, 4, capture)}},
+			{RepoID: 2, RepoName: "repo2", Points: []SeriesPoint{point(earlier, 0, capture), point(now, 3, capture)}},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("unexpected repo series (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("included repositories", func(t *testing.T) {
+		got, err := store.RepoSeriesPoints(ctx, SeriesPointsOpts{SeriesID: pointers.Ptr("one"), Included: []api.RepoID{2}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []RepoSeries{
+			{RepoID: 2, RepoName: "repo2", Points: []SeriesPoint{point(now, 3, nil)}},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("unexpected repo series (-want +got):\n%s", diff)
+		}
+	})
+
+	breakdownTests := []struct {
+		name          string
+		capture       *string
+		breakdownOpts RepoSeriesBreakdownOpts
+		want          []RepoSeriesSummary
+		wantTotal     int
+	}{
+		{
+			name:          "breakdown by latest value",
+			breakdownOpts: RepoSeriesBreakdownOpts{Descending: true, Limit: 10},
+			want: []RepoSeriesSummary{
+				{RepoID: 1, RepoName: "repo1-renamed", LatestValue: 4, Change: 1},
+				{RepoID: 2, RepoName: "repo2", LatestValue: 3, Change: 3},
+			},
+			wantTotal: 2,
+		},
+		{
+			name:          "breakdown by change",
+			breakdownOpts: RepoSeriesBreakdownOpts{OrderByChange: true, Descending: true, Limit: 10},
+			want: []RepoSeriesSummary{
+				{RepoID: 2, RepoName: "repo2", LatestValue: 3, Change: 3},
+				{RepoID: 1, RepoName: "repo1-renamed", LatestValue: 4, Change: 1},
+			},
+			wantTotal: 2,
+		},
+		{
+			name:          "breakdown by change within window",
+			breakdownOpts: RepoSeriesBreakdownOpts{OrderByChange: true, ChangeWindow: &timeseries.TimeInterval{Unit: types.Day, Value: 1}, Limit: 10},
+			want: []RepoSeriesSummary{
+				{RepoID: 1, RepoName: "repo1-renamed", LatestValue: 4, Change: 1},
+				{RepoID: 2, RepoName: "repo2", LatestValue: 3, Change: 3},
+			},
+			wantTotal: 2,
+		},
+		{
+			name:          "breakdown of capture value ordered by name",
+			capture:       pointers.Ptr("a"),
+			breakdownOpts: RepoSeriesBreakdownOpts{OrderByChange: true, Limit: 10},
+			want: []RepoSeriesSummary{
+				{RepoID: 1, RepoName: "repo1-renamed", LatestValue: 4, Change: 3},
+				{RepoID: 2, RepoName: "repo2", LatestValue: 3, Change: 3},
+			},
+			wantTotal: 2,
+		},
+		{
+			name:          "breakdown page",
+			breakdownOpts: RepoSeriesBreakdownOpts{Descending: true, Limit: 1, Offset: 1},
+			want: []RepoSeriesSummary{
+				{RepoID: 2, RepoName: "repo2", LatestValue: 3, Change: 3},
+			},
+			wantTotal: 2,
+		},
+		{
+			name:          "breakdown page after the end",
+			breakdownOpts: RepoSeriesBreakdownOpts{Limit: 1, Offset: 5},
+			wantTotal:     2,
+		},
+	}
+	for _, tc := range breakdownTests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, total, err := store.RepoSeriesBreakdown(ctx, SeriesPointsOpts{SeriesID: pointers.Ptr("one"), Capture: tc.capture}, tc.breakdownOpts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected breakdown (-want +got):\n%s", diff)
+			}
+			if total != tc.wantTotal {
+				t.Errorf("unexpected total count: want %d, got %d", tc.wantTotal, total)
+			}
+		})
+	}
+}
+
 func TestCountData(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -862,7 +1040,7 @@ func Test_coalesceZeroValues(t *testing.T) {
 		{
 			"empty recording times returns empty",
 			map[string]*SeriesPoint{
-				"2020-01-01 00:00:00 +0000 UTC": {"seriesID", testTime, 12, nil},
+				"2020-01-01 00:00:00 +0000 UTC": {"seriesID", testTime, 12, nil, nil},
 			},
 			[]time.Time{},
 			map[string]struct{}{"": {}},
@@ -871,7 +1049,7 @@ func Test_coalesceZeroValues(t *testing.T) {
 		{
 			"augment one data point",
 			map[string]*SeriesPoint{
-				"2020-01-01 00:00:00 +0000 UTC": {"seriesID", testTime, 1, nil},
+				"2020-01-01 00:00:00 +0000 UTC": {"seriesID", testTime, 1, nil, nil},
 			},
 			generateTimes(2),
 			map[string]struct{}{"": {}},
@@ -883,10 +1061,10 @@ func Test_coalesceZeroValues(t *testing.T) {
 		{
 			"augment capture data points",
 			map[string]*SeriesPoint{
-				"2020-01-01 00:00:00 +0000 UTCone":   {"1", testTime, 1, capture("one")},
-				"2020-01-01 00:00:00 +0000 UTCtwo":   {"1", testTime, 2, capture("two")},
-				"2020-01-01 00:00:00 +0000 UTCthree": {"1", testTime, 3, capture("three")},
-				"2020-01-02 00:00:00 +0000 UTCone":   {"1", testTime.AddDate(0, 0, 1), 1, capture("one")},
+				"2020-01-01 00:00:00 +0000 UTCone":   {"1", testTime, 1, capture("one"), nil},
+				"2020-01-01 00:00:00 +0000 UTCtwo":   {"1", testTime, 2, capture("two"), nil},
+				"2020-01-01 00:00:00 +0000 UTCthree": {"1", testTime, 3, capture("three"), nil},
+				"2020-01-02 00:00:00 +0000 UTCone":   {"1", testTime.AddDate(0, 0, 1), 1, capture("one"), nil},
 			},
 			generateTimes(2),
 			map[string]struct{}{"one": {}, "two": {}, "three": {}},
@@ -902,8 +1080,8 @@ func Test_coalesceZeroValues(t *testing.T) {
 		{
 			"augment data point in the past",
 			map[string]*SeriesPoint{
-				"2020-01-01 00:00:00 +0000 UTC": {"1", testTime, 11, nil},
-				"2020-01-02 00:00:00 +0000 UTC": {"1", testTime.AddDate(0, 0, 1), 22, nil},
+				"2020-01-01 00:00:00 +0000 UTC": {"1", testTime, 11, nil, nil},
+				"2020-01-02 00:00:00 +0000 UTC": {"1", testTime.AddDate(0, 0, 1), 22, nil, nil},
 			},
 			append([]time.Time{testTime.AddDate(0, 0, -1)}, generateTimes(2)...),
 			map[string]struct{}{"": {}},
@@ -944,7 +1122,7 @@ func TestGetOffsetNRecordingTime(t *testing.T) {
 	recordingTimes := types.InsightSeriesRecordingTimes{
 		InsightSeriesID: 1,
 		RecordingTimes: []types.RecordingTime{
-			{newTime, true},
+			{Timestamp: newTime, Snapshot: true},
 		},
 	}
 	for i := 1; i <= 11; i++ {
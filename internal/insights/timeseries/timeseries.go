@@ -4,6 +4,7 @@ import (
 	"sort"
 	"time"
 
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/insights/types"
 )
 
@@ -31,3 +32,20 @@ func MakeRecordingsFromTimes(times []time.Time, snapshot bool) []types.Recording
 	}
 	return recordings
 }
+
+// MakeRecordingsFromRevisions returns a recording for each of the tags, which are in the order in
+// which a series is sampled at them. A tag is recorded at its creation date, moved forwards where
+// needed so that recording times are strictly increasing and after the given time.
+func MakeRecordingsFromRevisions(tags []*gitdomain.Tag, after time.Time) []types.RecordingTime {
+	recordings := make([]types.RecordingTime, 0, len(tags))
+	previous := after.UTC().Truncate(time.Second)
+	for _, tag := range tags {
+		t := tag.CreatorDate.UTC().Truncate(time.Second)
+		if !t.After(previous) {
+			t = previous.Add(time.Second)
+		}
+		recordings = append(recordings, types.RecordingTime{Timestamp: t, Revision: tag.Name})
+		previous = t
+	}
+	return recordings
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hexops/autogold/v2"
 
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/insights/types"
 )
 
@@ -84,3 +85,24 @@ func TestBuildSampleTimes(t *testing.T) {
 		}).Equal(t, buildSampleTimeTest(6, TimeInterval{Unit: types.Year, Value: 1}, startTime))
 	})
 }
+
+func TestMakeRecordingsFromRevisions(t *testing.T) {
+	startTime := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	tags := []*gitdomain.Tag{
+		{Name: "v1.0.0", CreatorDate: startTime.Add(time.Hour + 500*time.Millisecond)},
+		// Created before the previous tag, but released after it.
+		{Name: "v1.1.0", CreatorDate: startTime},
+		{Name: "v2.0.0", CreatorDate: startTime.Add(48 * time.Hour)},
+	}
+
+	var got []string
+	for _, r := range MakeRecordingsFromRevisions(tags, startTime.Add(time.Hour)) {
+		got = append(got, r.Revision+" "+r.Timestamp.String())
+	}
+	autogold.Expect([]string{
+		"v1.0.0 2021-12-01 01:00:01 +0000 UTC",
+		"v1.1.0 2021-12-01 01:00:02 +0000 UTC",
+		"v2.0.0 2021-12-03 00:00:00 +0000 UTC",
+	}).Equal(t, got)
+}
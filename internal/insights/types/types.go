@@ -43,6 +43,7 @@ type InsightViewSeries struct {
 	SupportsAugmentation          bool
 	RepositoryCriteria            *string
 	SeriesNumSamples              *int32
+	RevisionPattern               *string
 }
 
 type Insight struct {
@@ -111,6 +112,49 @@ type InsightSeries struct {
 	BackfillAttempts           int32
 	SupportsAugmentation       bool
 	RepositoryCriteria         *string
+	// RevisionPattern is a glob pattern of tag names. Series with a revision pattern are sampled
+	// at the matching tags of their single repository, rather than at time intervals.
+	RevisionPattern *string
+}
+
+// AlertRuleKind represents how an alert rule evaluates the values of an insight series.
+type AlertRuleKind string
+
+const (
+	// AlertRuleThreshold rules match if the latest value is above or below the threshold.
+	AlertRuleThreshold AlertRuleKind = "threshold"
+	// AlertRulePercentChange rules match if the latest value changed by at least threshold percent
+	// compared to the value window size data points before it.
+	AlertRulePercentChange AlertRuleKind = "percent_change"
+	// AlertRuleAnomaly rules match if the z-score of the latest value compared to the window size
+	// data points before it is at least the threshold.
+	AlertRuleAnomaly AlertRuleKind = "anomaly"
+)
+
+// AlertRuleDirection represents the direction in which a value has to move for an alert rule to match.
+type AlertRuleDirection string
+
+const (
+	AlertRuleAbove AlertRuleDirection = "above"
+	AlertRuleBelow AlertRuleDirection = "below"
+)
+
+// InsightSeriesAlertRule is a rule that notifies users when the values of an insight series match it.
+type InsightSeriesAlertRule struct {
+	ID              int
+	SeriesID        int
+	Kind            AlertRuleKind
+	Direction       AlertRuleDirection
+	Threshold       float64
+	WindowSize      int
+	EmailUserID     *int32
+	SlackWebhookURL *string
+	WebhookURL      *string
+	CreatedBy       *int32
+	CreatedAt       time.Time
+	Firing          bool
+	LastEvaluatedAt *time.Time
+	LastTriggeredAt *time.Time
 }
 
 type IntervalUnit string
@@ -131,6 +175,12 @@ const (
 	SearchCompute  GenerationMethod = "search-compute"
 	LanguageStats  GenerationMethod = "language-stats"
 	MappingCompute GenerationMethod = "mapping-compute"
+	// PreciseCodeIntel series count the occurrences of a symbol in the precise code intelligence
+	// (SCIP) data uploaded for a repository rather than running a search query.
+	PreciseCodeIntel GenerationMethod = "precise-code-intel"
+	// SearchOwners series run a search query and attribute the results in each file to the
+	// owners of the file, so that there is one dynamic series per owner.
+	SearchOwners GenerationMethod = "search-owners"
 )
 
 type Dashboard struct {
@@ -204,6 +254,9 @@ type InsightSeriesRecordingTimes struct {
 type RecordingTime struct {
 	Timestamp time.Time
 	Snapshot  bool
+	// Revision is the tag that the series is sampled at, for series sampled at tags rather than
+	// at time intervals.
+	Revision string
 }
 
 type SearchAggregationMode string
@@ -214,9 +267,10 @@ const (
 	AUTHOR_AGGREGATION_MODE        SearchAggregationMode = "AUTHOR"
 	CAPTURE_GROUP_AGGREGATION_MODE SearchAggregationMode = "CAPTURE_GROUP"
 	REPO_METADATA_AGGREGATION_MODE SearchAggregationMode = "REPO_METADATA"
+	OWNER_AGGREGATION_MODE         SearchAggregationMode = "OWNER"
 )
 
-var SearchAggregationModes = []SearchAggregationMode{REPO_AGGREGATION_MODE, PATH_AGGREGATION_MODE, AUTHOR_AGGREGATION_MODE, CAPTURE_GROUP_AGGREGATION_MODE, REPO_METADATA_AGGREGATION_MODE}
+var SearchAggregationModes = []SearchAggregationMode{REPO_AGGREGATION_MODE, PATH_AGGREGATION_MODE, AUTHOR_AGGREGATION_MODE, CAPTURE_GROUP_AGGREGATION_MODE, REPO_METADATA_AGGREGATION_MODE, OWNER_AGGREGATION_MODE}
 
 type AggregationNotAvailableReasonType string
 
@@ -230,4 +284,5 @@ const (
 
 const (
 	NO_REPO_METADATA_TEXT = "No metadata"
+	NO_OWNER_TEXT         = "No owner"
 )
@@ -0,0 +1,175 @@
+package own
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/own/codeowners"
+)
+
+// FileOwners resolves the owners of files from CODEOWNERS and from owners assigned
+// within Sourcegraph. It caches the ownership data of every repository and commit
+// it has seen, so it is meant to be used for the duration of a single search or
+// aggregation.
+type FileOwners struct {
+	service         Service
+	db              database.DB
+	gitserverClient gitserver.Client
+
+	mu    sync.Mutex
+	repos map[fileOwnersKey]*repoOwners
+	users map[int32]string
+	teams map[int32]string
+}
+
+type fileOwnersKey struct {
+	repoID   api.RepoID
+	commitID api.CommitID
+}
+
+type repoOwners struct {
+	ruleset       *codeowners.Ruleset
+	assigned      AssignedOwners
+	assignedTeams AssignedTeams
+}
+
+func NewFileOwners(g gitserver.Client, db database.DB) *FileOwners {
+	return &FileOwners{
+		service:         NewService(g, db),
+		db:              db,
+		gitserverClient: g,
+		repos:           make(map[fileOwnersKey]*repoOwners),
+		users:           make(map[int32]string),
+		teams:           make(map[int32]string),
+	}
+}
+
+// Owners returns the owners of the file at path in the given repository and commit,
+// or the commit of the default branch if commitID is empty. Owners from CODEOWNERS
+// are returned as written in the file (`@handle` or email), assigned users and teams
+// as `@name`. The result is sorted and free of duplicates, and empty if the file has
+// no owners.
+func (f *FileOwners) Owners(ctx context.Context, repoName api.RepoName, repoID api.RepoID, commitID api.CommitID, path string) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	repo, err := f.repoOwners(ctx, repoName, repoID, commitID)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := map[string]struct{}{}
+	if repo.ruleset != nil {
+		for _, o := range repo.ruleset.Match(path).GetOwner() {
+			if h := o.GetHandle(); h != "" {
+				owners["@"+h] = struct{}{}
+			} else if e := o.GetEmail(); e != "" {
+				owners[e] = struct{}{}
+			}
+		}
+	}
+	for _, o := range repo.assigned.Match(path) {
+		name, err := f.userName(ctx, o.OwnerUserID)
+		if err != nil {
+			return nil, err
+		}
+		if name != "" {
+			owners["@"+name] = struct{}{}
+		}
+	}
+	for _, o := range repo.assignedTeams.Match(path) {
+		name, err := f.teamName(ctx, o.OwnerTeamID)
+		if err != nil {
+			return nil, err
+		}
+		if name != "" {
+			owners["@"+name] = struct{}{}
+		}
+	}
+
+	labels := make([]string, 0, len(owners))
+	for o := range owners {
+		labels = append(labels, o)
+	}
+	sort.Strings(labels)
+	return labels, nil
+}
+
+func (f *FileOwners) repoOwners(ctx context.Context, repoName api.RepoName, repoID api.RepoID, commitID api.CommitID) (*repoOwners, error) {
+	if commitID == "" {
+		_, head, err := f.gitserverClient.GetDefaultBranch(ctx, repoName, true)
+		if err != nil {
+			return nil, err
+		}
+		commitID = head
+	}
+
+	key := fileOwnersKey{repoID: repoID, commitID: commitID}
+	if repo, ok := f.repos[key]; ok {
+		return repo, nil
+	}
+
+	repo := &repoOwners{}
+	// An empty repository has no default branch commit and therefore no CODEOWNERS file.
+	if commitID != "" {
+		ruleset, err := f.service.RulesetForRepo(ctx, repoName, repoID, commitID)
+		if err != nil {
+			return nil, err
+		}
+		repo.ruleset = ruleset
+	}
+	assigned, err := f.service.AssignedOwnership(ctx, repoID, commitID)
+	if err != nil {
+		return nil, err
+	}
+	repo.assigned = assigned
+	assignedTeams, err := f.service.AssignedTeams(ctx, repoID, commitID)
+	if err != nil {
+		return nil, err
+	}
+	repo.assignedTeams = assignedTeams
+
+	f.repos[key] = repo
+	return repo, nil
+}
+
+// userName returns the username of the user with the given ID, or an empty string
+// if the user no longer exists.
+func (f *FileOwners) userName(ctx context.Context, id int32) (string, error) {
+	if name, ok := f.users[id]; ok {
+		return name, nil
+	}
+	user, err := f.db.Users().GetByID(ctx, id)
+	if err != nil && !errcode.IsNotFound(err) {
+		return "", err
+	}
+	var name string
+	if user != nil {
+		name = user.Username
+	}
+	f.users[id] = name
+	return name, nil
+}
+
+// teamName returns the name of the team with the given ID, or an empty string if the
+// team no longer exists.
+func (f *FileOwners) teamName(ctx context.Context, id int32) (string, error) {
+	if name, ok := f.teams[id]; ok {
+		return name, nil
+	}
+	team, err := f.db.Teams().GetTeamByID(ctx, id)
+	if err != nil && !errcode.IsNotFound(err) {
+		return "", err
+	}
+	var name string
+	if team != nil {
+		name = team.Name
+	}
+	f.teams[id] = name
+	return name, nil
+}
@@ -0,0 +1,84 @@
+package own
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/own/codeowners"
+	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/v1"
+	itypes "github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestFileOwners(t *testing.T) {
+	codeownersText := codeowners.NewRuleset(
+		codeowners.IngestedRulesetSource{},
+		&codeownerspb.File{
+			Rule: []*codeownerspb.Rule{
+				{
+					Pattern: "*.go",
+					Owner:   []*codeownerspb.Owner{{Handle: "sourcegraph/search"}, {Email: "owner@example.com"}},
+				},
+			},
+		},
+	).Repr()
+	repo := repoFiles{{"repo", "SHA", "CODEOWNERS"}: codeownersText}
+	git := gitserver.NewMockClient()
+	git.NewFileReaderFunc.SetDefaultHook(repo.NewFileReader)
+	git.GetDefaultBranchFunc.SetDefaultReturn("main", "SHA", nil)
+
+	codeownersStore := dbmocks.NewMockCodeownersStore()
+	codeownersStore.GetCodeownersForRepoFunc.SetDefaultReturn(nil, database.CodeownersFileNotFoundError{})
+	reposStore := dbmocks.NewMockRepoStore()
+	reposStore.GetFunc.SetDefaultReturn(&itypes.Repo{ExternalRepo: api.ExternalRepoSpec{ServiceType: "github"}}, nil)
+	assignedOwnersStore := dbmocks.NewMockAssignedOwnersStore()
+	assignedOwnersStore.ListAssignedOwnersForRepoFunc.SetDefaultReturn([]*database.AssignedOwnerSummary{
+		{OwnerUserID: 1, FilePath: "cmd", RepoID: 1},
+		// A deleted user is not an owner.
+		{OwnerUserID: 2, FilePath: "", RepoID: 1},
+	}, nil)
+	assignedTeamsStore := dbmocks.NewMockAssignedTeamsStore()
+	assignedTeamsStore.ListAssignedTeamsForRepoFunc.SetDefaultReturn([]*database.AssignedTeamSummary{
+		{OwnerTeamID: 1, FilePath: "", RepoID: 1},
+	}, nil)
+	usersStore := dbmocks.NewMockUserStore()
+	usersStore.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int32) (*itypes.User, error) {
+		if id == 1 {
+			return &itypes.User{ID: 1, Username: "alice"}, nil
+		}
+		return nil, database.NewUserNotFoundError(id)
+	})
+	teamsStore := dbmocks.NewMockTeamStore()
+	teamsStore.GetTeamByIDFunc.SetDefaultReturn(&itypes.Team{ID: 1, Name: "everyone"}, nil)
+
+	db := dbmocks.NewMockDB()
+	db.CodeownersFunc.SetDefaultReturn(codeownersStore)
+	db.ReposFunc.SetDefaultReturn(reposStore)
+	db.AssignedOwnersFunc.SetDefaultReturn(assignedOwnersStore)
+	db.AssignedTeamsFunc.SetDefaultReturn(assignedTeamsStore)
+	db.UsersFunc.SetDefaultReturn(usersStore)
+	db.TeamsFunc.SetDefaultReturn(teamsStore)
+
+	ctx := context.Background()
+	owners := NewFileOwners(git, db)
+	for path, want := range map[string][]string{
+		"cmd/main.go":  {"@alice", "@everyone", "@sourcegraph/search", "owner@example.com"},
+		"cmd/README":   {"@alice", "@everyone"},
+		"lib/types.go": {"@everyone", "@sourcegraph/search", "owner@example.com"},
+	} {
+		got, err := owners.Owners(ctx, "repo", 1, "", path)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, path)
+	}
+
+	// The ownership data of the repository is only fetched once.
+	assert.Len(t, git.GetDefaultBranchFunc.History(), 3)
+	assert.Len(t, codeownersStore.GetCodeownersForRepoFunc.History(), 1)
+	assert.Len(t, usersStore.GetByIDFunc.History(), 2)
+}
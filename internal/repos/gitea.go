@@ -0,0 +1,216 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A GiteaSource yields repositories from a single Gitea or Forgejo connection
+// configured in Sourcegraph via the external services configuration.
+type GiteaSource struct {
+	svc       *types.ExternalService
+	config    *schema.GiteaConnection
+	cli       *gitea.Client
+	serviceID string
+	excluder  repoExcluder
+}
+
+// NewGiteaSource returns a new GiteaSource from the given external service.
+func NewGiteaSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GiteaSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.GiteaConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d config error", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	httpCli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := gitea.NewClient(svc.URN(), u, &auth.OAuthBearerToken{Token: c.Token}, httpCli)
+	if err != nil {
+		return nil, err
+	}
+
+	var ex repoExcluder
+	excludeArchived := func(repo any) bool {
+		if r, ok := repo.(*gitea.Repository); ok {
+			return r.Archived
+		}
+		return false
+	}
+	excludeFork := func(repo any) bool {
+		if r, ok := repo.(*gitea.Repository); ok {
+			return r.Fork
+		}
+		return false
+	}
+	for _, r := range c.Exclude {
+		rule := ex.AddRule().Exact(r.Name)
+		if r.Archived {
+			rule.Generic(excludeArchived)
+		}
+		if r.Forks {
+			rule.Generic(excludeFork)
+		}
+	}
+
+	return &GiteaSource{
+		svc:       svc,
+		config:    &c,
+		cli:       cli,
+		serviceID: extsvc.NormalizeBaseURL(u).String(),
+		excluder:  ex,
+	}, nil
+}
+
+// CheckConnection checks that the configured token can be used to access the
+// Gitea API.
+func (s *GiteaSource) CheckConnection(ctx context.Context) error {
+	if _, err := s.cli.GetAuthenticatedUser(ctx); err != nil {
+		return errors.Wrap(err, "connection check failed. could not fetch authenticated user")
+	}
+	return nil
+}
+
+// ListRepos returns all Gitea repositories configured with this GiteaSource's
+// config. Repositories listed in "repos" are fetched individually, and all
+// repositories of the organizations listed in "orgs" are added. If neither is
+// set, all repositories accessible to the configured token are returned.
+func (s *GiteaSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	seen := make(map[int64]struct{})
+	emit := func(r *gitea.Repository) {
+		if _, ok := seen[r.ID]; ok || s.excludes(r) {
+			return
+		}
+		seen[r.ID] = struct{}{}
+
+		repo, err := s.makeRepo(r)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: err}
+			return
+		}
+		results <- SourceResult{Source: s, Repo: repo}
+	}
+
+	for _, nameWithOwner := range s.config.Repos {
+		owner, name, ok := strings.Cut(nameWithOwner, "/")
+		if !ok {
+			results <- SourceResult{Source: s, Err: errors.Errorf("invalid Gitea repository name %q", nameWithOwner)}
+			continue
+		}
+
+		r, err := s.cli.GetRepository(ctx, owner, name)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "getting Gitea repository %q", nameWithOwner)}
+			continue
+		}
+		emit(r)
+	}
+
+	for _, org := range s.config.Orgs {
+		org := org
+		s.listPages(ctx, results, emit, func(page int) ([]*gitea.Repository, bool, error) {
+			return s.cli.ListOrgRepositories(ctx, org, page)
+		})
+	}
+
+	if len(s.config.Repos) == 0 && len(s.config.Orgs) == 0 {
+		s.listPages(ctx, results, emit, func(page int) ([]*gitea.Repository, bool, error) {
+			return s.cli.ListUserRepositories(ctx, page)
+		})
+	}
+}
+
+func (s *GiteaSource) listPages(ctx context.Context, results chan SourceResult, emit func(*gitea.Repository), list func(page int) ([]*gitea.Repository, bool, error)) {
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			results <- SourceResult{Source: s, Err: err}
+			return
+		}
+
+		repos, hasNext, err := list(page)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: err}
+			return
+		}
+		for _, r := range repos {
+			emit(r)
+		}
+		if !hasNext {
+			return
+		}
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s *GiteaSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s *GiteaSource) excludes(r *gitea.Repository) bool {
+	return s.excluder.ShouldExclude(r.FullName) || s.excluder.ShouldExclude(r)
+}
+
+func (s *GiteaSource) makeRepo(r *gitea.Repository) (*types.Repo, error) {
+	urn := s.svc.URN()
+
+	htmlURL, err := url.Parse(r.HTMLURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing URL of Gitea repository %q", r.FullName)
+	}
+	name := path.Join(htmlURL.Host, htmlURL.Path)
+
+	cloneURL := r.CloneURL
+	if s.config.GitURLType == "ssh" {
+		cloneURL = r.SSHURL
+	}
+
+	return &types.Repo{
+		Name:        api.RepoName(name),
+		URI:         name,
+		Description: r.Description,
+		Fork:        r.Fork,
+		Archived:    r.Archived,
+		Private:     r.Private,
+		Stars:       r.Stars,
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          strconv.FormatInt(r.ID, 10),
+			ServiceType: extsvc.TypeGitea,
+			ServiceID:   s.serviceID,
+		},
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: cloneURL,
+			},
+		},
+		Metadata: r,
+	}, nil
+}
@@ -0,0 +1,93 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/internal/types/typestest"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGiteaSource_ListRepos(t *testing.T) {
+	ratelimit.SetupForTest(t)
+
+	repo := func(id int, fullName string, fork, archived bool) string {
+		return fmt.Sprintf(
+			`{"id":%d,"full_name":%[2]q,"fork":%t,"archived":%t,"html_url":"https://gitea.test/%[2]s","clone_url":"https://gitea.test/%[2]s.git","ssh_url":"git@gitea.test:%[2]s.git"}`,
+			id, fullName, fork, archived,
+		)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/repos/alice/dotfiles", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(repo(1, "alice/dotfiles", false, false)))
+	})
+	mux.HandleFunc("/api/v1/orgs/acme/repos", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "1":
+			w.Header().Set("Link", `<https://gitea.test/api/v1/orgs/acme/repos?page=2>; rel="next"`)
+			w.Write([]byte(`[` + repo(2, "acme/api", false, false) + `,` + repo(3, "acme/legacy", false, true) + `]`))
+		default:
+			w.Write([]byte(`[` + repo(4, "acme/api-fork", true, false) + `,` + repo(5, "acme/secret", false, false) + `]`))
+		}
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	svc := typestest.MakeExternalService(t, extsvc.VariantGitea, &schema.GiteaConnection{
+		Url:        srv.URL,
+		Token:      "secret",
+		GitURLType: "ssh",
+		Repos:      []string{"alice/dotfiles"},
+		Orgs:       []string{"acme"},
+		Exclude: []*schema.ExcludedGiteaRepo{
+			{Name: "acme/secret"},
+			{Archived: true},
+			{Forks: true},
+		},
+	})
+
+	ctx := context.Background()
+	src, err := NewGiteaSource(ctx, svc, httpcli.NewFactory(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repos, err := ListAll(ctx, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Slice(repos, func(i, j int) bool { return repos[i].Name < repos[j].Name })
+
+	var names []api.RepoName
+	var cloneURLs []string
+	for _, r := range repos {
+		names = append(names, r.Name)
+		cloneURLs = append(cloneURLs, r.Sources[svc.URN()].CloneURL)
+
+		if r.ExternalRepo.ServiceType != extsvc.TypeGitea {
+			t.Errorf("unexpected service type %q", r.ExternalRepo.ServiceType)
+		}
+		if _, ok := r.Metadata.(*gitea.Repository); !ok {
+			t.Errorf("unexpected metadata type %T", r.Metadata)
+		}
+	}
+
+	if diff := cmp.Diff([]api.RepoName{"gitea.test/acme/api", "gitea.test/alice/dotfiles"}, names); diff != "" {
+		t.Errorf("unexpected repos (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"git@gitea.test:acme/api.git", "git@gitea.test:alice/dotfiles.git"}, cloneURLs); diff != "" {
+		t.Errorf("unexpected clone URLs (-want +got):\n%s", diff)
+	}
+}
@@ -59,6 +59,8 @@ func NewSource(ctx context.Context, logger log.Logger, db database.DB, svc *type
 		return NewBitbucketServerSource(ctx, logger.Scoped("BitbucketServerSource"), svc, cf)
 	case extsvc.KindBitbucketCloud:
 		return NewBitbucketCloudSource(ctx, logger.Scoped("BitbucketCloudSource"), svc, cf)
+	case extsvc.KindGitea:
+		return NewGiteaSource(ctx, svc, cf)
 	case extsvc.KindGitolite:
 		return NewGitoliteSource(ctx, svc, gc)
 	case extsvc.KindPhabricator:
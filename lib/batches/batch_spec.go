@@ -39,13 +39,44 @@ type BatchSpec struct {
 	ChangesetTemplate *ChangesetTemplate       `json:"changesetTemplate,omitempty" yaml:"changesetTemplate"`
 }
 
+// Containerless returns true if the batch spec has steps and none of them run
+// in a container. Such batch specs can be executed without an executor.
+func (spec *BatchSpec) Containerless() bool {
+	if len(spec.Steps) == 0 {
+		return false
+	}
+	for _, step := range spec.Steps {
+		if !step.IsRewrite() {
+			return false
+		}
+	}
+	return true
+}
+
 type ChangesetTemplate struct {
 	Title     string                       `json:"title,omitempty" yaml:"title"`
 	Body      string                       `json:"body,omitempty" yaml:"body"`
 	Branch    string                       `json:"branch,omitempty" yaml:"branch"`
 	Fork      *bool                        `json:"fork,omitempty" yaml:"fork"`
+	Stages    []ChangesetTemplateStage     `json:"stages,omitempty" yaml:"stages,omitempty"`
 	Commit    ExpandedGitCommitDescription `json:"commit,omitempty" yaml:"commit"`
 	Published *overridable.BoolOrString    `json:"published" yaml:"published"`
+	// RequestReviewsFromOwners requests reviews from the code owners of the
+	// files touched by each changeset once it is published.
+	RequestReviewsFromOwners bool `json:"requestReviewsFromOwners,omitempty" yaml:"requestReviewsFromOwners"`
+}
+
+// ChangesetTemplateStage describes one changeset in a stack of dependent
+// changesets that is created per workspace. Empty fields fall back to the
+// values of the ChangesetTemplate.
+type ChangesetTemplateStage struct {
+	Title         string `json:"title,omitempty" yaml:"title"`
+	Body          string `json:"body,omitempty" yaml:"body"`
+	Branch        string `json:"branch,omitempty" yaml:"branch"`
+	CommitMessage string `json:"commitMessage,omitempty" yaml:"commitMessage"`
+	// UntilStep is the 1-based index of the last step whose changes are part
+	// of the stage. Zero means all remaining steps.
+	UntilStep int `json:"untilStep,omitempty" yaml:"untilStep"`
 }
 
 type GitCommitAuthor struct {
@@ -91,6 +122,7 @@ func (oqor *OnQueryOrRepository) GetBranches() ([]string, error) {
 type Step struct {
 	Run       string            `json:"run,omitempty" yaml:"run"`
 	Container string            `json:"container,omitempty" yaml:"container"`
+	Rewrite   *RewriteStep      `json:"rewrite,omitempty" yaml:"rewrite,omitempty"`
 	Env       env.Environment   `json:"env,omitempty" yaml:"env"`
 	Files     map[string]string `json:"files,omitempty" yaml:"files,omitempty"`
 	Outputs   Outputs           `json:"outputs,omitempty" yaml:"outputs,omitempty"`
@@ -98,6 +130,24 @@ type Step struct {
 	If        any               `json:"if,omitempty" yaml:"if,omitempty"`
 }
 
+// RewriteStep is a search-and-replace that is applied to the files of a
+// workspace without running a container.
+type RewriteStep struct {
+	Pattern string `json:"pattern" yaml:"pattern"`
+	Rewrite string `json:"rewrite" yaml:"rewrite"`
+	// Matcher is the Comby matcher, given as a file extension, or
+	// RewriteMatcherRegexp.
+	Matcher string `json:"matcher,omitempty" yaml:"matcher,omitempty"`
+}
+
+// RewriteMatcherRegexp is the matcher of rewrite steps whose pattern is a
+// regular expression.
+const RewriteMatcherRegexp = "regexp"
+
+// IsRewrite returns true if the step is a rewrite step, which doesn't run in a
+// container.
+func (s *Step) IsRewrite() bool { return s.Rewrite != nil }
+
 func (s *Step) IfCondition() string {
 	switch v := s.If.(type) {
 	case bool:
@@ -166,6 +216,18 @@ func parseBatchSpec(schema string, data []byte) (*BatchSpec, error) {
 		errs = errors.Append(errs, NewValidationError(errors.New("batch spec includes steps but no changesetTemplate")))
 	}
 
+	if spec.ChangesetTemplate != nil && len(spec.ChangesetTemplate.Stages) != 0 {
+		errs = errors.Append(errs, validateStages(&spec))
+	}
+
+	if len(spec.Steps) != 0 && !spec.Containerless() {
+		for i, step := range spec.Steps {
+			if step.IsRewrite() {
+				errs = errors.Append(errs, NewValidationError(errors.Newf("step %d is a rewrite step, which cannot be combined with steps that run in a container", i+1)))
+			}
+		}
+	}
+
 	for i, step := range spec.Steps {
 		for _, mount := range step.Mount {
 			if strings.Contains(mount.Path, invalidMountCharacters) {
@@ -182,6 +244,44 @@ func parseBatchSpec(schema string, data []byte) (*BatchSpec, error) {
 
 const invalidMountCharacters = ","
 
+func validateStages(spec *BatchSpec) (errs error) {
+	if len(spec.Steps) == 0 {
+		errs = errors.Append(errs, NewValidationError(errors.New("changesetTemplate.stages requires steps")))
+	}
+	if spec.TransformChanges != nil && len(spec.TransformChanges.Group) != 0 {
+		errs = errors.Append(errs, NewValidationError(errors.New("changesetTemplate.stages cannot be combined with transformChanges.group")))
+	}
+	if fork := spec.ChangesetTemplate.Fork; fork != nil && *fork {
+		errs = errors.Append(errs, NewValidationError(errors.New("changesetTemplate.stages cannot be combined with changesetTemplate.fork")))
+	}
+
+	stages := spec.ChangesetTemplate.Stages
+	branches := make(map[string]struct{}, len(stages))
+	prev := 0
+	for i, stage := range stages {
+		if _, ok := branches[stage.Branch]; ok {
+			errs = errors.Append(errs, NewValidationError(errors.Newf("changesetTemplate.stages[%d] uses branch %q of a previous stage", i, stage.Branch)))
+		}
+		branches[stage.Branch] = struct{}{}
+
+		if stage.UntilStep == 0 {
+			if i != len(stages)-1 {
+				errs = errors.Append(errs, NewValidationError(errors.Newf("changesetTemplate.stages[%d] is missing untilStep, which can only be omitted on the last stage", i)))
+			}
+			continue
+		}
+		if stage.UntilStep <= prev {
+			errs = errors.Append(errs, NewValidationError(errors.Newf("changesetTemplate.stages[%d].untilStep must be greater than the untilStep of the previous stage", i)))
+		}
+		if stage.UntilStep > len(spec.Steps) {
+			errs = errors.Append(errs, NewValidationError(errors.Newf("changesetTemplate.stages[%d].untilStep is out of range: batch spec has %d steps", i, len(spec.Steps))))
+		}
+		prev = stage.UntilStep
+	}
+
+	return errs
+}
+
 func (on *OnQueryOrRepository) String() string {
 	if on.RepositoriesMatchingQuery != "" {
 		return on.RepositoriesMatchingQuery
@@ -0,0 +1,306 @@
+package batches
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/sourcegraph/sourcegraph/lib/batches/jsonschema"
+	"github.com/sourcegraph/sourcegraph/lib/batches/schema"
+	"github.com/sourcegraph/sourcegraph/lib/batches/yaml"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// BatchSpecTemplate is a reusable batch spec with typed input parameters. The
+// spec references the values of the parameters as ${{ params.<name> }}.
+type BatchSpecTemplate struct {
+	Name        string                       `json:"name,omitempty" yaml:"name"`
+	Description string                       `json:"description,omitempty" yaml:"description"`
+	Parameters  []BatchSpecTemplateParameter `json:"parameters,omitempty" yaml:"parameters"`
+	Spec        string                       `json:"spec,omitempty" yaml:"spec"`
+}
+
+type BatchSpecTemplateParameter struct {
+	Name        string                         `json:"name,omitempty" yaml:"name"`
+	Type        BatchSpecTemplateParameterType `json:"type,omitempty" yaml:"type"`
+	Description string                         `json:"description,omitempty" yaml:"description"`
+	Required    bool                           `json:"required,omitempty" yaml:"required"`
+	Default     any                            `json:"default,omitempty" yaml:"default"`
+}
+
+// BatchSpecTemplateParameterType is the type of a parameter of a batch spec
+// template, which determines the valid values of the parameter.
+type BatchSpecTemplateParameterType string
+
+const (
+	// BatchSpecTemplateParameterTypeString accepts any single line string.
+	BatchSpecTemplateParameterTypeString BatchSpecTemplateParameterType = "string"
+	// BatchSpecTemplateParameterTypeVersion accepts version strings, like
+	// 1.2.3 or v2.0.0-rc.1.
+	BatchSpecTemplateParameterTypeVersion BatchSpecTemplateParameterType = "version"
+	// BatchSpecTemplateParameterTypeRepositoryQuery accepts a non-empty
+	// search query that is used to find the repositories to change.
+	BatchSpecTemplateParameterTypeRepositoryQuery BatchSpecTemplateParameterType = "repositoryQuery"
+	// BatchSpecTemplateParameterTypeUsers accepts a list of usernames, such
+	// as the reviewers of the changesets. It is rendered as a YAML flow
+	// sequence.
+	BatchSpecTemplateParameterTypeUsers BatchSpecTemplateParameterType = "users"
+)
+
+// valueSchema returns the JSON schema of the values of the parameter type.
+func (t BatchSpecTemplateParameterType) valueSchema() map[string]any {
+	switch t {
+	case BatchSpecTemplateParameterTypeVersion:
+		return map[string]any{
+			"type":    "string",
+			"pattern": `^v?[0-9]+(\.[0-9]+){0,2}(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`,
+		}
+	case BatchSpecTemplateParameterTypeRepositoryQuery:
+		return map[string]any{
+			"type":      "string",
+			"minLength": 1,
+			"pattern":   `^[^\n]*$`,
+		}
+	case BatchSpecTemplateParameterTypeUsers:
+		return map[string]any{
+			"type":        "array",
+			"uniqueItems": true,
+			"items": map[string]any{
+				"type":    "string",
+				"pattern": `^[A-Za-z0-9_.@-]+$`,
+			},
+		}
+	default:
+		return map[string]any{
+			"type":    "string",
+			"pattern": `^[^\n]*$`,
+		}
+	}
+}
+
+// ParseBatchSpecTemplate parses and validates the given batch spec template,
+// which can be given as YAML or JSON.
+func ParseBatchSpecTemplate(data []byte) (*BatchSpecTemplate, error) {
+	var tmpl BatchSpecTemplate
+	if err := yaml.UnmarshalValidate(schema.BatchSpecTemplateJSON, data, &tmpl); err != nil {
+		var multiErr errors.MultiError
+		if errors.As(err, &multiErr) {
+			var errs error
+			for _, e := range multiErr.Errors() {
+				errs = errors.Append(errs, NewValidationError(e))
+			}
+			return nil, errs
+		}
+		return nil, err
+	}
+
+	var errs error
+	params := make(map[string]struct{}, len(tmpl.Parameters))
+	for i, p := range tmpl.Parameters {
+		if _, ok := params[p.Name]; ok {
+			errs = errors.Append(errs, NewValidationError(errors.Newf("parameters[%d] uses name %q of a previous parameter", i, p.Name)))
+		}
+		params[p.Name] = struct{}{}
+
+		if p.Default != nil {
+			if err := validateParameterValues([]BatchSpecTemplateParameter{p}, map[string]any{p.Name: p.Default}); err != nil {
+				errs = errors.Append(errs, NewValidationError(errors.Wrapf(err, "parameters[%d].default is invalid", i)))
+			}
+		}
+	}
+
+	for _, name := range referencedParameters(tmpl.Spec) {
+		if _, ok := params[name]; !ok {
+			errs = errors.Append(errs, NewValidationError(errors.Newf("spec references undefined parameter %q", name)))
+		}
+	}
+
+	return &tmpl, errs
+}
+
+// Render renders the spec of the template with the given parameter values and
+// returns the resulting raw batch spec. The values are validated against the
+// types of the parameters and the rendered batch spec is validated against the
+// batch spec schema.
+func (t *BatchSpecTemplate) Render(values map[string]any) (string, error) {
+	if err := validateParameterValues(t.Parameters, values); err != nil {
+		return "", err
+	}
+
+	rendered := make(map[string]renderParameter, len(t.Parameters))
+	for _, p := range t.Parameters {
+		v, ok := values[p.Name]
+		if !ok {
+			v = p.Default
+		}
+		s, err := renderParameterValue(p.Type, v)
+		if err != nil {
+			return "", NewValidationError(errors.Wrapf(err, "rendering parameter %q", p.Name))
+		}
+		rendered[p.Name] = s
+	}
+
+	var root yamlv3.Node
+	if err := yamlv3.Unmarshal([]byte(t.Spec), &root); err != nil {
+		return "", NewValidationError(errors.Wrap(err, "spec is not valid YAML"))
+	}
+	if err := renderNode(&root, rendered); err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	enc := yamlv3.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&root); err != nil {
+		return "", errors.Wrap(err, "encoding rendered batch spec")
+	}
+	if err := enc.Close(); err != nil {
+		return "", errors.Wrap(err, "encoding rendered batch spec")
+	}
+	raw := buf.String()
+
+	if _, err := ParseBatchSpec([]byte(raw)); err != nil {
+		return "", errors.Wrap(err, "rendered batch spec is invalid")
+	}
+	return raw, nil
+}
+
+// renderParameter is the rendered value of a parameter. Lists are inserted as
+// a YAML sequence when a reference makes up a whole scalar and as their JSON
+// encoding when the reference is part of a larger string.
+type renderParameter struct {
+	text string
+	list []string
+}
+
+// renderNode replaces the parameter references in the scalars of the parsed
+// spec. Since the values are inserted into the decoded scalars, they are
+// escaped when the spec is encoded again and can't change its structure.
+func renderNode(node *yamlv3.Node, rendered map[string]renderParameter) error {
+	if node.Kind != yamlv3.ScalarNode {
+		var errs error
+		for _, child := range node.Content {
+			if err := renderNode(child, rendered); err != nil {
+				errs = errors.Append(errs, err)
+			}
+		}
+		return errs
+	}
+	if !paramReference.MatchString(node.Value) {
+		return nil
+	}
+
+	if m := paramReference.FindStringSubmatch(node.Value); m[0] == strings.TrimSpace(node.Value) {
+		if p, ok := rendered[m[1]]; ok && p.list != nil {
+			items := make([]*yamlv3.Node, 0, len(p.list))
+			for _, item := range p.list {
+				items = append(items, &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: item})
+			}
+			*node = yamlv3.Node{Kind: yamlv3.SequenceNode, Tag: "!!seq", Style: yamlv3.FlowStyle, Content: items}
+			return nil
+		}
+	}
+
+	var errs error
+	node.Value = paramReference.ReplaceAllStringFunc(node.Value, func(ref string) string {
+		name := paramReference.FindStringSubmatch(ref)[1]
+		p, ok := rendered[name]
+		if !ok {
+			errs = errors.Append(errs, NewValidationError(errors.Newf("spec references undefined parameter %q", name)))
+		}
+		return p.text
+	})
+	node.Tag = "!!str"
+	if node.Style&(yamlv3.LiteralStyle|yamlv3.FoldedStyle) == 0 {
+		node.Style = 0
+	}
+	return errs
+}
+
+// paramReference matches the references to parameters in the spec of a batch
+// spec template. All other template expressions are left untouched, so that
+// they can be evaluated when the steps run.
+var paramReference = regexp.MustCompile(`\$\{\{\s*params\.([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+func referencedParameters(spec string) []string {
+	var names []string
+	seen := make(map[string]struct{})
+	for _, m := range paramReference.FindAllStringSubmatch(spec, -1) {
+		if _, ok := seen[m[1]]; ok {
+			continue
+		}
+		seen[m[1]] = struct{}{}
+		names = append(names, m[1])
+	}
+	return names
+}
+
+// validateParameterValues validates the given values against a JSON schema
+// that is built from the parameters.
+func validateParameterValues(params []BatchSpecTemplateParameter, values map[string]any) error {
+	properties := make(map[string]any, len(params))
+	required := []string{}
+	for _, p := range params {
+		properties[p.Name] = p.Type.valueSchema()
+		if p.Required && p.Default == nil {
+			required = append(required, p.Name)
+		}
+	}
+	valuesSchema, err := json.Marshal(map[string]any{
+		"$schema":              "http://json-schema.org/draft-07/schema#",
+		"type":                 "object",
+		"additionalProperties": false,
+		"required":             required,
+		"properties":           properties,
+	})
+	if err != nil {
+		return err
+	}
+
+	if values == nil {
+		values = map[string]any{}
+	}
+	input, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	if err := jsonschema.Validate(string(valuesSchema), input); err != nil {
+		var multiErr errors.MultiError
+		if errors.As(err, &multiErr) {
+			var errs error
+			for _, e := range multiErr.Errors() {
+				errs = errors.Append(errs, NewValidationError(e))
+			}
+			return errs
+		}
+		return err
+	}
+	return nil
+}
+
+func renderParameterValue(typ BatchSpecTemplateParameterType, v any) (renderParameter, error) {
+	if typ == BatchSpecTemplateParameterTypeUsers {
+		users := []string{}
+		if list, ok := v.([]any); ok {
+			for _, u := range list {
+				users = append(users, u.(string))
+			}
+		} else if list, ok := v.([]string); ok {
+			users = list
+		}
+		b, err := json.Marshal(users)
+		return renderParameter{text: string(b), list: users}, err
+	}
+
+	s, _ := v.(string)
+	// Values must not smuggle template expressions into the batch spec, which
+	// would be evaluated when the steps run.
+	if strings.Contains(s, "${{") {
+		return renderParameter{}, errors.New("value must not contain template expressions")
+	}
+	return renderParameter{text: strings.TrimSpace(s)}, nil
+}
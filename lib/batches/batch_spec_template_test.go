@@ -0,0 +1,245 @@
+package batches
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testBatchSpecTemplate = `
+name: bump-dependency
+description: Bump a Go dependency
+parameters:
+  - name: repositories
+    type: repositoryQuery
+    required: true
+  - name: version
+    type: version
+    required: true
+  - name: reviewers
+    type: users
+    default: [alice]
+spec: |
+  name: bump-dependency
+  on:
+    - repositoriesMatchingQuery: ${{ params.repositories }}
+  steps:
+    - run: go get example.com/dep@${{ params.version }} && echo ${{ repository.name }}
+      container: golang
+  changesetTemplate:
+    title: Bump dependency to ${{params.version}}
+    body: Reviewers ${{ params.reviewers }}
+    branch: bump-dependency
+    commit:
+      message: Bump dependency
+`
+
+func TestParseBatchSpecTemplate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		tmpl, err := ParseBatchSpecTemplate([]byte(testBatchSpecTemplate))
+		if err != nil {
+			t.Fatalf("parsing valid template returned error: %s", err)
+		}
+		if tmpl.Name != "bump-dependency" {
+			t.Fatalf("wrong name %q", tmpl.Name)
+		}
+		if len(tmpl.Parameters) != 3 {
+			t.Fatalf("wrong number of parameters %d", len(tmpl.Parameters))
+		}
+	})
+
+	for name, tc := range map[string]struct {
+		template string
+		wantErr  string
+	}{
+		"missing spec": {
+			template: "name: foo\n",
+			wantErr:  "spec is required",
+		},
+		"unknown parameter type": {
+			template: "name: foo\nspec: foo\nparameters:\n  - name: x\n    type: number\n",
+			wantErr:  "parameters.0.type",
+		},
+		"duplicate parameter": {
+			template: "name: foo\nspec: foo\nparameters:\n  - name: x\n    type: string\n  - name: x\n    type: version\n",
+			wantErr:  `parameters[1] uses name "x" of a previous parameter`,
+		},
+		"undefined parameter": {
+			template: "name: foo\nspec: ${{ params.missing }}\n",
+			wantErr:  `spec references undefined parameter "missing"`,
+		},
+		"invalid default": {
+			template: "name: foo\nspec: ${{ params.v }}\nparameters:\n  - name: v\n    type: version\n    default: latest\n",
+			wantErr:  "parameters[0].default is invalid",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseBatchSpecTemplate([]byte(tc.template))
+			if err == nil {
+				t.Fatal("no error returned")
+			}
+			if !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("wrong error %q, want it to contain %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestBatchSpecTemplate_Render(t *testing.T) {
+	tmpl, err := ParseBatchSpecTemplate([]byte(testBatchSpecTemplate))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("valid values", func(t *testing.T) {
+		raw, err := tmpl.Render(map[string]any{
+			"repositories": "repo:^github\\.com/sourcegraph/ file:go.mod",
+			"version":      "v1.2.3",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		spec, err := ParseBatchSpec([]byte(raw))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff("repo:^github\\.com/sourcegraph/ file:go.mod", spec.On[0].RepositoriesMatchingQuery); diff != "" {
+			t.Fatalf("wrong query (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff("go get example.com/dep@v1.2.3 && echo ${{ repository.name }}", spec.Steps[0].Run); diff != "" {
+			t.Fatalf("wrong step (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff("Bump dependency to v1.2.3", spec.ChangesetTemplate.Title); diff != "" {
+			t.Fatalf("wrong title (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff(`Reviewers ["alice"]`, spec.ChangesetTemplate.Body); diff != "" {
+			t.Fatalf("wrong body (-want +got):\n%s", diff)
+		}
+	})
+
+	for name, tc := range map[string]struct {
+		values  map[string]any
+		wantErr string
+	}{
+		"missing required value": {
+			values:  map[string]any{"version": "1.0.0"},
+			wantErr: "repositories is required",
+		},
+		"invalid version": {
+			values:  map[string]any{"repositories": "r", "version": "latest"},
+			wantErr: "version: Does not match pattern",
+		},
+		"invalid users": {
+			values:  map[string]any{"repositories": "r", "version": "1", "reviewers": "alice"},
+			wantErr: "reviewers: Invalid type",
+		},
+		"multi-line value": {
+			values:  map[string]any{"repositories": "r\nsteps: []", "version": "1"},
+			wantErr: "repositories: Does not match pattern",
+		},
+		"template expression": {
+			values:  map[string]any{"repositories": "${{ repository.name }}", "version": "1"},
+			wantErr: "value must not contain template expressions",
+		},
+		"unknown parameter": {
+			values:  map[string]any{"repositories": "r", "version": "1", "other": "x"},
+			wantErr: "Additional property other is not allowed",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := tmpl.Render(tc.values)
+			if err == nil {
+				t.Fatal("no error returned")
+			}
+			if !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("wrong error %q, want it to contain %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestBatchSpecTemplate_Render_Escaping(t *testing.T) {
+	tmpl, err := ParseBatchSpecTemplate([]byte(`
+name: escaping
+parameters:
+  - name: query
+    type: repositoryQuery
+    required: true
+  - name: message
+    type: string
+    required: true
+  - name: reviewers
+    type: users
+    default: [alice, bob]
+spec: |
+  name: escaping
+  on:
+    - repositoriesMatchingQuery: ${{ params.query }}
+  steps:
+    - run: echo "${{ params.message }}"
+      container: alpine
+  changesetTemplate:
+    title: ${{ params.message }}
+    body: |
+      Message: ${{ params.message }}
+      Reviewers: ${{ params.reviewers }}
+    branch: escaping
+    commit:
+      message: ${{ params.message }}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, value := range map[string]string{
+		"comment":      "repo:foo # not a comment",
+		"double quote": `repo:"foo" file:"bar`,
+		"mapping":      "repo:foo steps: []",
+		"key":          "steps: [{run: rm -rf /}]",
+		"flow":         "[repo:foo, {a: b}]",
+		"number":       "1",
+	} {
+		t.Run(name, func(t *testing.T) {
+			raw, err := tmpl.Render(map[string]any{"query": value, "message": value})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			spec, err := ParseBatchSpec([]byte(raw))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(spec.On) != 1 {
+				t.Fatalf("wrong number of on entries %d", len(spec.On))
+			}
+			if diff := cmp.Diff(value, spec.On[0].RepositoriesMatchingQuery); diff != "" {
+				t.Fatalf("wrong query (-want +got):\n%s", diff)
+			}
+			if len(spec.Steps) != 1 {
+				t.Fatalf("wrong number of steps %d", len(spec.Steps))
+			}
+			if diff := cmp.Diff(`echo "`+value+`"`, spec.Steps[0].Run); diff != "" {
+				t.Fatalf("wrong step (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(value, spec.ChangesetTemplate.Title); diff != "" {
+				t.Fatalf("wrong title (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff("Message: "+value+"\nReviewers: [\"alice\",\"bob\"]\n", spec.ChangesetTemplate.Body); diff != "" {
+				t.Fatalf("wrong body (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	for name, value := range map[string]string{
+		"newline":             "repo:foo\nsteps: []",
+		"template expression": "${{ outputs.secret }}",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := tmpl.Render(map[string]any{"query": "repo:foo", "message": value}); err == nil {
+				t.Fatal("no error returned")
+			}
+		})
+	}
+}
@@ -211,6 +211,156 @@ changesetTemplate:
 		_, err := ParseBatchSpec([]byte(spec))
 		assert.Equal(t, "step 1 mount mountpoint contains invalid characters", err.Error())
 	})
+
+	t.Run("stages", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+steps:
+  - run: ./add-api.sh
+    container: alpine:3
+  - run: ./migrate-callers.sh
+    container: alpine:3
+  - run: ./delete-old-api.sh
+    container: alpine:3
+changesetTemplate:
+  title: Migrate API
+  branch: migrate-api
+  commit:
+    message: Migrate API
+  stages:
+    - branch: migrate-api-1-add
+      untilStep: 1
+    - branch: migrate-api-2-migrate
+      untilStep: 2
+    - branch: migrate-api-3-delete
+`
+		batchSpec, err := ParseBatchSpec([]byte(spec))
+		if err != nil {
+			t.Fatalf("parsing valid spec returned error: %s", err)
+		}
+		assert.Equal(t, []ChangesetTemplateStage{
+			{Branch: "migrate-api-1-add", UntilStep: 1},
+			{Branch: "migrate-api-2-migrate", UntilStep: 2},
+			{Branch: "migrate-api-3-delete"},
+		}, batchSpec.ChangesetTemplate.Stages)
+	})
+
+	t.Run("invalid stages", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+steps:
+  - run: ./add-api.sh
+    container: alpine:3
+  - run: ./migrate-callers.sh
+    container: alpine:3
+transformChanges:
+  group:
+    - directory: client
+      branch: client
+changesetTemplate:
+  title: Migrate API
+  branch: migrate-api
+  fork: true
+  commit:
+    message: Migrate API
+  stages:
+    - branch: migrate-api-1
+    - branch: migrate-api-1
+      untilStep: 3
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		if err == nil {
+			t.Fatal("no error returned")
+		}
+
+		wantErr := `5 errors occurred:
+	* changesetTemplate.stages cannot be combined with transformChanges.group
+	* changesetTemplate.stages cannot be combined with changesetTemplate.fork
+	* changesetTemplate.stages[0] is missing untilStep, which can only be omitted on the last stage
+	* changesetTemplate.stages[1] uses branch "migrate-api-1" of a previous stage
+	* changesetTemplate.stages[1].untilStep is out of range: batch spec has 2 steps`
+		assert.Equal(t, wantErr, err.Error())
+	})
+
+	t.Run("rewrite steps", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+steps:
+  - rewrite:
+      pattern: fmt.Sprintf(":[x]")
+      rewrite: fmt.Sprint(":[x]")
+      matcher: .go
+  - rewrite:
+      pattern: 'http://(\S+)'
+      rewrite: 'https://$1'
+      matcher: regexp
+changesetTemplate:
+  title: Rewrite
+  branch: rewrite
+  commit:
+    message: Rewrite
+`
+		batchSpec, err := ParseBatchSpec([]byte(spec))
+		if err != nil {
+			t.Fatalf("parsing valid spec returned error: %s", err)
+		}
+		assert.True(t, batchSpec.Containerless())
+		assert.Equal(t, &RewriteStep{
+			Pattern: `fmt.Sprintf(":[x]")`,
+			Rewrite: `fmt.Sprint(":[x]")`,
+			Matcher: ".go",
+		}, batchSpec.Steps[0].Rewrite)
+		assert.Equal(t, RewriteMatcherRegexp, batchSpec.Steps[1].Rewrite.Matcher)
+	})
+
+	t.Run("rewrite steps combined with container steps", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+steps:
+  - run: echo hello
+    container: alpine:3
+  - rewrite:
+      pattern: foo
+      rewrite: bar
+changesetTemplate:
+  title: Rewrite
+  branch: rewrite
+  commit:
+    message: Rewrite
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		if err == nil {
+			t.Fatal("no error returned")
+		}
+
+		wantErr := "step 2 is a rewrite step, which cannot be combined with steps that run in a container"
+		assert.Equal(t, wantErr, err.Error())
+	})
+
+	t.Run("rewrite step with run", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+steps:
+  - run: echo hello
+    rewrite:
+      pattern: foo
+      rewrite: bar
+changesetTemplate:
+  title: Rewrite
+  branch: rewrite
+  commit:
+    message: Rewrite
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		if err == nil {
+			t.Fatal("no error returned")
+		}
+	})
 }
 
 func TestOnQueryOrRepository_Branches(t *testing.T) {
@@ -81,6 +81,17 @@ type ChangesetSpec struct {
 	Commits []GitCommitDescription `json:"commits,omitempty"`
 
 	Published PublishedValue `json:"published,omitempty"`
+
+	// StackStage is the 1-based position of the changeset in a stack of
+	// dependent changesets, or zero if it is not part of a stack.
+	StackStage int `json:"stackStage,omitempty"`
+	// StackParentHeadRef is the head ref of the previous changeset in the
+	// stack.
+	StackParentHeadRef string `json:"stackParentHeadRef,omitempty"`
+
+	// RequestReviewsFromOwners is true if reviews should be requested from
+	// the code owners of the changed files once the changeset is published.
+	RequestReviewsFromOwners bool `json:"requestReviewsFromOwners,omitempty"`
 }
 
 // MarshalJSON overwrites the default behavior of the json lib while unmarshalling
@@ -104,6 +115,11 @@ func (c *ChangesetSpec) MarshalJSON() ([]byte, error) {
 		Commits        []GitCommitDescription `json:"commits,omitempty"`
 		Published      *PublishedValue        `json:"published,omitempty"`
 		Fork           *bool                  `json:"fork,omitempty"`
+
+		StackStage         int    `json:"stackStage,omitempty"`
+		StackParentHeadRef string `json:"stackParentHeadRef,omitempty"`
+
+		RequestReviewsFromOwners bool `json:"requestReviewsFromOwners,omitempty"`
 	}{
 		BaseRepository: c.BaseRepository,
 		ExternalID:     c.ExternalID,
@@ -115,6 +131,11 @@ func (c *ChangesetSpec) MarshalJSON() ([]byte, error) {
 		Body:           c.Body,
 		Commits:        c.Commits,
 		Fork:           c.Fork,
+
+		StackStage:         c.StackStage,
+		StackParentHeadRef: c.StackParentHeadRef,
+
+		RequestReviewsFromOwners: c.RequestReviewsFromOwners,
 	}
 	if !c.Published.Nil() {
 		v.Published = &c.Published
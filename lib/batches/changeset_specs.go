@@ -33,6 +33,9 @@ type ChangesetSpecInput struct {
 	Path                  string
 
 	Result execution.AfterStepResult
+	// StepResults are the results of all steps that are available. They are
+	// used to split the changes into the stages of the changeset template.
+	StepResults []execution.AfterStepResult `json:"-"`
 }
 
 type ChangesetSpecAuthor struct {
@@ -41,6 +44,10 @@ type ChangesetSpecAuthor struct {
 }
 
 func BuildChangesetSpecs(input *ChangesetSpecInput, binaryDiffs bool, fallbackAuthor *ChangesetSpecAuthor) ([]*ChangesetSpec, error) {
+	if len(input.Template.Stages) != 0 {
+		return buildStackedChangesetSpecs(input, binaryDiffs, fallbackAuthor)
+	}
+
 	tmplCtx := &template.ChangesetTemplateContext{
 		BatchChangeAttributes: *input.BatchChangeAttributes,
 		Steps: template.StepsContext{
@@ -135,6 +142,8 @@ func BuildChangesetSpecs(input *ChangesetSpecInput, binaryDiffs bool, fallbackAu
 				},
 			},
 			Published: PublishedValue{Val: published},
+
+			RequestReviewsFromOwners: input.Template.RequestReviewsFromOwners,
 		}
 	}
 
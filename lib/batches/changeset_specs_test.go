@@ -158,6 +158,19 @@ func TestCreateChangesetSpecs(t *testing.T) {
 			},
 			wantErr: "",
 		},
+		{
+			name: "request reviews from owners",
+			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
+				input.Template.Published = parsePublishedFieldString(t, "false")
+				input.Template.RequestReviewsFromOwners = true
+			}),
+			want: []*ChangesetSpec{
+				specWith(defaultChangesetSpec, func(s *ChangesetSpec) {
+					s.RequestReviewsFromOwners = true
+				}),
+			},
+			wantErr: "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,553 @@
+package batches
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	godiff "github.com/sourcegraph/go-diff/diff"
+
+	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// buildStackedChangesetSpecs builds one changeset spec per non-empty stage of
+// the changeset template. Each spec only contains the changes made since the
+// previous stage and references the head ref of the previous spec as its
+// stack parent.
+//
+// The changes of each stage are computed from the results of the individual
+// steps, which are only available when the batch spec is executed server-side.
+func buildStackedChangesetSpecs(input *ChangesetSpecInput, binaryDiffs bool, fallbackAuthor *ChangesetSpecAuthor) ([]*ChangesetSpec, error) {
+	results := input.StepResults
+	if len(results) == 0 {
+		return nil, ErrStagesWithoutStepResults
+	}
+
+	var (
+		specs    []*ChangesetSpec
+		prevDiff []byte
+	)
+	for i, stage := range input.Template.Stages {
+		result, ok := stageResult(results, stage.UntilStep)
+		if !ok {
+			continue
+		}
+
+		diff, err := stageDiff(prevDiff, result.Diff)
+		if err != nil {
+			return nil, NewValidationError(errors.Wrapf(err, "computing changes of changeset stage %d", i+1))
+		}
+		prevDiff = result.Diff
+		if len(diff) == 0 {
+			continue
+		}
+		result.Diff = diff
+
+		tmpl := *input.Template
+		tmpl.Stages = nil
+		tmpl.Branch = stage.Branch
+		if stage.Title != "" {
+			tmpl.Title = stage.Title
+		}
+		if stage.Body != "" {
+			tmpl.Body = stage.Body
+		}
+		if stage.CommitMessage != "" {
+			tmpl.Commit.Message = stage.CommitMessage
+		}
+
+		stageInput := *input
+		stageInput.Template = &tmpl
+		stageInput.TransformChanges = nil
+		stageInput.Result = result
+
+		stageSpecs, err := BuildChangesetSpecs(&stageInput, binaryDiffs, fallbackAuthor)
+		if err != nil {
+			return nil, err
+		}
+		for _, spec := range stageSpecs {
+			spec.StackStage = len(specs) + 1
+			if len(specs) > 0 {
+				spec.StackParentHeadRef = specs[len(specs)-1].HeadRef
+			}
+			specs = append(specs, spec)
+		}
+	}
+
+	return specs, nil
+}
+
+// ErrStagesWithoutStepResults is returned when changeset specs with stages are
+// built without the results of the individual steps, for example when the
+// batch spec is executed client-side.
+var ErrStagesWithoutStepResults = NewValidationError(errors.New("changesetTemplate.stages requires the results of each step, which are only available when the batch spec is executed server-side"))
+
+// stageResult returns the latest result at or before the 1-based step
+// untilStep. Zero means the latest result overall.
+func stageResult(results []execution.AfterStepResult, untilStep int) (execution.AfterStepResult, bool) {
+	var (
+		latest execution.AfterStepResult
+		found  bool
+	)
+	for _, r := range results {
+		if untilStep != 0 && r.StepIndex > untilStep-1 {
+			continue
+		}
+		if !found || r.StepIndex > latest.StepIndex {
+			latest = r
+			found = true
+		}
+	}
+	return latest, found
+}
+
+// stageContextLines is the number of context lines emitted around the changes
+// of a stage, matching the default of git diff.
+const stageContextLines = 3
+
+// maxStageDiffCells bounds the size of the table used to diff the lines of a
+// single hunk cluster. Larger clusters are replaced as a whole.
+const maxStageDiffCells = 4_000_000
+
+// stageDiff returns a diff that transforms the result of applying the
+// cumulative diff prev into the result of applying the cumulative diff next.
+// Both diffs must have been created against the same base revision.
+func stageDiff(prev, next []byte) ([]byte, error) {
+	if len(prev) == 0 {
+		return next, nil
+	}
+
+	prevFiles, err := godiff.ParseMultiFileDiff(prev)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing diff of previous stage")
+	}
+	nextFiles, err := godiff.ParseMultiFileDiff(next)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing diff of stage")
+	}
+
+	prevByName := make(map[string]*godiff.FileDiff, len(prevFiles))
+	for _, fd := range prevFiles {
+		prevByName[fileDiffName(fd)] = fd
+	}
+
+	var out []*godiff.FileDiff
+	seen := make(map[string]struct{}, len(nextFiles))
+	for _, fd := range nextFiles {
+		name := fileDiffName(fd)
+		seen[name] = struct{}{}
+
+		p, ok := prevByName[name]
+		if !ok {
+			out = append(out, fd)
+			continue
+		}
+
+		delta, err := fileDiffDelta(p, fd)
+		if err != nil {
+			return nil, errors.Wrapf(err, "file %q", name)
+		}
+		if delta != nil {
+			out = append(out, delta)
+		}
+	}
+
+	for _, fd := range prevFiles {
+		name := fileDiffName(fd)
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		inverse, err := invertFileDiff(fd)
+		if err != nil {
+			return nil, errors.Wrapf(err, "file %q", name)
+		}
+		out = append(out, inverse)
+	}
+
+	if len(out) == 0 {
+		return nil, nil
+	}
+	return godiff.PrintMultiFileDiff(out)
+}
+
+func fileDiffName(fd *godiff.FileDiff) string {
+	if fd.NewName == "/dev/null" {
+		return fd.OrigName
+	}
+	return fd.NewName
+}
+
+func isCreation(fd *godiff.FileDiff) bool { return fd.OrigName == "/dev/null" }
+func isDeletion(fd *godiff.FileDiff) bool { return fd.NewName == "/dev/null" }
+
+// fileDiffDelta returns the diff between the results of applying prev and
+// next to the same file, or nil if both lead to the same content.
+func fileDiffDelta(prev, next *godiff.FileDiff) (*godiff.FileDiff, error) {
+	prevPrinted, err := godiff.PrintFileDiff(prev)
+	if err != nil {
+		return nil, err
+	}
+	nextPrinted, err := godiff.PrintFileDiff(next)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(prevPrinted, nextPrinted) {
+		return nil, nil
+	}
+
+	if len(prev.Hunks) == 0 || len(next.Hunks) == 0 {
+		return nil, errors.New("binary or mode-only changes cannot be split across stages")
+	}
+
+	switch {
+	case isCreation(prev) && isDeletion(next):
+		return invertFileDiff(prev)
+
+	case isCreation(prev) && isCreation(next):
+		hunks, err := hunkDelta(prev.Hunks, next.Hunks)
+		if err != nil {
+			return nil, err
+		}
+		if len(hunks) == 0 {
+			return nil, nil
+		}
+		var extended []string
+		for _, h := range next.Extended {
+			if strings.HasPrefix(h, "diff --git ") {
+				extended = append(extended, h)
+			}
+		}
+		return &godiff.FileDiff{
+			OrigName: next.NewName,
+			NewName:  next.NewName,
+			Extended: extended,
+			Hunks:    hunks,
+		}, nil
+
+	case !isCreation(prev) && !isDeletion(prev) && !isCreation(next) && !isDeletion(next) &&
+		prev.OrigName == next.OrigName && prev.NewName == next.NewName:
+		hunks, err := hunkDelta(prev.Hunks, next.Hunks)
+		if err != nil {
+			return nil, err
+		}
+		if len(hunks) == 0 {
+			return nil, nil
+		}
+		var extended []string
+		for _, h := range next.Extended {
+			// The blob hashes of the index header refer to the base revision.
+			if !strings.HasPrefix(h, "index ") {
+				extended = append(extended, h)
+			}
+		}
+		return &godiff.FileDiff{
+			OrigName: next.OrigName,
+			NewName:  next.NewName,
+			Extended: extended,
+			Hunks:    hunks,
+		}, nil
+	}
+
+	return nil, errors.New("file is created, deleted or renamed differently by consecutive stages")
+}
+
+// invertFileDiff returns a diff that reverts the changes of fd.
+func invertFileDiff(fd *godiff.FileDiff) (*godiff.FileDiff, error) {
+	if len(fd.Hunks) == 0 {
+		return nil, errors.New("binary or mode-only changes cannot be reverted by a later stage")
+	}
+
+	extended := make([]string, 0, len(fd.Extended))
+	for _, h := range fd.Extended {
+		switch {
+		case strings.HasPrefix(h, "new file mode "):
+			h = "deleted file mode " + strings.TrimPrefix(h, "new file mode ")
+		case strings.HasPrefix(h, "deleted file mode "):
+			h = "new file mode " + strings.TrimPrefix(h, "deleted file mode ")
+		case strings.HasPrefix(h, "index "):
+			continue
+		}
+		extended = append(extended, h)
+	}
+
+	inverse := &godiff.FileDiff{
+		OrigName: fd.NewName,
+		NewName:  fd.OrigName,
+		Extended: extended,
+	}
+	for _, h := range fd.Hunks {
+		if hasNoNewlineMarker(h) {
+			return nil, errors.New("changes to the last line of a file without trailing newline cannot be reverted by a later stage")
+		}
+		body := make([]byte, 0, len(h.Body))
+		for _, line := range hunkLines(h) {
+			switch line[0] {
+			case '+':
+				line = "-" + line[1:]
+			case '-':
+				line = "+" + line[1:]
+			}
+			body = append(body, line...)
+		}
+		inverse.Hunks = append(inverse.Hunks, &godiff.Hunk{
+			OrigStartLine: h.NewStartLine,
+			OrigLines:     h.NewLines,
+			NewStartLine:  h.OrigStartLine,
+			NewLines:      h.OrigLines,
+			Section:       h.Section,
+			Body:          body,
+		})
+	}
+	return inverse, nil
+}
+
+func hasNoNewlineMarker(h *godiff.Hunk) bool {
+	return h.OrigNoNewlineAt != 0 || !bytes.HasSuffix(h.Body, []byte{'\n'})
+}
+
+// hunkLines splits the body of a hunk into its lines, including the prefix and
+// the trailing newline.
+func hunkLines(h *godiff.Hunk) []string {
+	lines := strings.SplitAfter(string(h.Body), "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// stageHunk is a hunk of one of the two diffs together with the range of base
+// lines it covers. An empty range (hi < lo) is an insertion before lo.
+type stageHunk struct {
+	hunk   *godiff.Hunk
+	next   bool
+	lo, hi int
+}
+
+// hunkDelta computes the hunks transforming the result of applying prev into
+// the result of applying next, where both apply to the same base file.
+//
+// The base file is only known within the ranges covered by hunks. Hunks of
+// both diffs whose base ranges overlap or touch are grouped into clusters.
+// Within a cluster the base lines are reconstructed from the hunks, both diffs
+// are applied, and the two results are diffed line by line. Outside of
+// clusters both results are identical.
+func hunkDelta(prev, next []*godiff.Hunk) ([]*godiff.Hunk, error) {
+	var all []stageHunk
+	for i, hunks := range [][]*godiff.Hunk{prev, next} {
+		for _, h := range hunks {
+			if hasNoNewlineMarker(h) {
+				return nil, errors.New("changes to the last line of a file without trailing newline cannot be split across stages")
+			}
+			lo := int(h.OrigStartLine)
+			if h.OrigLines == 0 {
+				lo++
+			}
+			all = append(all, stageHunk{hunk: h, next: i == 1, lo: lo, hi: lo + int(h.OrigLines) - 1})
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].lo < all[j].lo })
+
+	var (
+		out       []*godiff.Hunk
+		offPrev   int
+		offNext   int
+		cluster   []stageHunk
+		clusterLo int
+		clusterHi int
+		flush     = func() error {
+			if len(cluster) == 0 {
+				return nil
+			}
+			h, dPrev, dNext, err := clusterDelta(cluster, clusterLo, clusterHi, offPrev, offNext)
+			if err != nil {
+				return err
+			}
+			if h != nil {
+				out = append(out, h)
+			}
+			offPrev += dPrev
+			offNext += dNext
+			cluster = nil
+			return nil
+		}
+	)
+	for _, h := range all {
+		if len(cluster) > 0 && h.lo <= clusterHi+1 {
+			cluster = append(cluster, h)
+			if h.hi > clusterHi {
+				clusterHi = h.hi
+			}
+			continue
+		}
+		if err := flush(); err != nil {
+			return nil, err
+		}
+		cluster = []stageHunk{h}
+		clusterLo, clusterHi = h.lo, h.hi
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// clusterDelta diffs the results of applying the hunks of a single cluster
+// covering the base lines [lo, hi]. offPrev and offNext are the line offsets
+// introduced by the hunks of earlier clusters. It returns the resulting hunk,
+// which is nil if both results are equal, and the offsets introduced by the
+// cluster.
+func clusterDelta(cluster []stageHunk, lo, hi, offPrev, offNext int) (*godiff.Hunk, int, int, error) {
+	base := make(map[int]string, hi-lo+1)
+	for _, h := range cluster {
+		n := h.lo
+		for _, line := range hunkLines(h.hunk) {
+			if line[0] == '+' {
+				continue
+			}
+			content := line[1:]
+			if existing, ok := base[n]; ok && existing != content {
+				return nil, 0, 0, errors.Newf("stages disagree about the content of line %d", n)
+			}
+			base[n] = content
+			n++
+		}
+	}
+	for n := lo; n <= hi; n++ {
+		if _, ok := base[n]; !ok {
+			return nil, 0, 0, errors.Newf("content of line %d is unknown", n)
+		}
+	}
+
+	apply := func(next bool) ([]string, int) {
+		var (
+			lines []string
+			delta int
+			pos   = lo
+		)
+		for _, h := range cluster {
+			if h.next != next {
+				continue
+			}
+			for ; pos < h.lo; pos++ {
+				lines = append(lines, base[pos])
+			}
+			for _, line := range hunkLines(h.hunk) {
+				if line[0] != '-' {
+					lines = append(lines, line[1:])
+				}
+			}
+			pos = h.hi + 1
+			delta += int(h.hunk.NewLines - h.hunk.OrigLines)
+		}
+		for ; pos <= hi; pos++ {
+			lines = append(lines, base[pos])
+		}
+		return lines, delta
+	}
+	a, dPrev := apply(false)
+	b, dNext := apply(true)
+
+	hunk := diffLines(a, b, lo+offPrev, lo+offNext)
+	return hunk, dPrev, dNext, nil
+}
+
+// diffLines returns a single hunk transforming a into b, where a starts at
+// line aStart and b at line bStart of their files. It returns nil if a and b
+// are equal.
+func diffLines(a, b []string, aStart, bStart int) *godiff.Hunk {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	if prefix == len(a) && prefix == len(b) {
+		return nil
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	lead, trail := prefix, suffix
+	if lead > stageContextLines {
+		lead = stageContextLines
+	}
+	if trail > stageContextLines {
+		trail = stageContextLines
+	}
+
+	var body bytes.Buffer
+	for _, line := range a[prefix-lead : prefix] {
+		body.WriteString(" " + line)
+	}
+	writeLineEdits(&body, a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])
+	for _, line := range a[len(a)-suffix : len(a)-suffix+trail] {
+		body.WriteString(" " + line)
+	}
+
+	h := &godiff.Hunk{
+		OrigStartLine: int32(aStart + prefix - lead),
+		OrigLines:     int32(lead + len(a) - prefix - suffix + trail),
+		NewStartLine:  int32(bStart + prefix - lead),
+		NewLines:      int32(lead + len(b) - prefix - suffix + trail),
+		Body:          body.Bytes(),
+	}
+	// An empty range refers to the line before the change.
+	if h.OrigLines == 0 {
+		h.OrigStartLine--
+	}
+	if h.NewLines == 0 {
+		h.NewStartLine--
+	}
+	return h
+}
+
+// writeLineEdits writes the lines of a longest common subsequence diff of a
+// and b. If the inputs are too large, all lines of a are replaced by b.
+func writeLineEdits(w *bytes.Buffer, a, b []string) {
+	if len(a)*len(b) > maxStageDiffCells {
+		for _, line := range a {
+			w.WriteString("-" + line)
+		}
+		for _, line := range b {
+			w.WriteString("+" + line)
+		}
+		return
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and
+	// b[j:].
+	lcs := make([][]int32, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			w.WriteString(" " + a[i])
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			w.WriteString("-" + a[i])
+			i++
+		default:
+			w.WriteString("+" + b[j])
+			j++
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package batches
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
+)
+
+const (
+	stageDiff1 = `diff --git main.go main.go
+index 71ac1b5..007f726 100644
+--- main.go
++++ main.go
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+diff --git new.go new.go
+new file mode 100644
+index 0000000..5626abf
+--- /dev/null
++++ new.go
+@@ -0,0 +1 @@
++one
+`
+	stageDiff2 = `diff --git main.go main.go
+index 71ac1b5..e00739d 100644
+--- main.go
++++ main.go
+@@ -1,8 +1,8 @@
+ a
+-b
++B
+ c
+ d
+ e
+ f
+-g
++G
+ h
+diff --git new.go new.go
+new file mode 100644
+index 0000000..814f4a4
+--- /dev/null
++++ new.go
+@@ -0,0 +1,2 @@
++one
++two
+`
+	stageDiff1To2 = `diff --git main.go main.go
+--- main.go
++++ main.go
+@@ -4,5 +4,5 @@
+ d
+ e
+ f
+-g
++G
+ h
+diff --git new.go new.go
+--- new.go
++++ new.go
+@@ -1,1 +1,2 @@
+ one
++two
+`
+)
+
+func TestStageDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		prev    string
+		next    string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "first stage",
+			next: stageDiff1,
+			want: stageDiff1,
+		},
+		{
+			name: "no changes",
+			prev: stageDiff1,
+			next: stageDiff1,
+			want: "",
+		},
+		{
+			name: "overlapping hunks and created file",
+			prev: stageDiff1,
+			next: stageDiff2,
+			want: stageDiff1To2,
+		},
+		{
+			name: "reverted changes",
+			prev: stageDiff2,
+			next: `diff --git main.go main.go
+index 71ac1b5..007f726 100644
+--- main.go
++++ main.go
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+`,
+			want: `diff --git main.go main.go
+--- main.go
++++ main.go
+@@ -4,5 +4,5 @@
+ d
+ e
+ f
+-G
++g
+ h
+diff --git new.go new.go
+deleted file mode 100644
+--- new.go
++++ /dev/null
+@@ -1,2 +0,0 @@
+-one
+-two
+`,
+		},
+		{
+			name: "binary file",
+			prev: `diff --git logo.png logo.png
+index 71ac1b5..007f726 100644
+Binary files logo.png and logo.png differ
+`,
+			next: `diff --git logo.png logo.png
+index 71ac1b5..e00739d 100644
+Binary files logo.png and logo.png differ
+`,
+			wantErr: `file "logo.png": binary or mode-only changes cannot be split across stages`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			have, err := stageDiff([]byte(tt.prev), []byte(tt.next))
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("wrong error. want=%q, got=%v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(have)); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildStackedChangesetSpecs(t *testing.T) {
+	input := &ChangesetSpecInput{
+		Repository: Repository{
+			ID:      "base-repo-id",
+			Name:    "github.com/sourcegraph/src-cli",
+			BaseRef: "refs/heads/main",
+			BaseRev: "f00b4r",
+		},
+		BatchChangeAttributes: &template.BatchChangeAttributes{Name: "the name"},
+		Template: &ChangesetTemplate{
+			Title:  "Migrate API",
+			Branch: "migrate-api",
+			Commit: ExpandedGitCommitDescription{Message: "Migrate API"},
+			Stages: []ChangesetTemplateStage{
+				{Branch: "migrate-api-1", Title: "Add new API", UntilStep: 1},
+				// The second step doesn't change anything, so this stage is
+				// dropped from the stack.
+				{Branch: "migrate-api-2", UntilStep: 2},
+				{Branch: "migrate-api-3", CommitMessage: "Delete old API"},
+			},
+		},
+		StepResults: []execution.AfterStepResult{
+			{StepIndex: 0, Diff: []byte(stageDiff1)},
+			{StepIndex: 1, Diff: []byte(stageDiff1)},
+			{StepIndex: 2, Diff: []byte(stageDiff2)},
+		},
+	}
+
+	have, err := BuildChangesetSpecs(input, true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newSpec := func(headRef, title, message, diff string) *ChangesetSpec {
+		return &ChangesetSpec{
+			BaseRepository: "base-repo-id",
+			BaseRef:        "refs/heads/main",
+			BaseRev:        "f00b4r",
+			HeadRepository: "base-repo-id",
+			HeadRef:        headRef,
+			Title:          title,
+			Commits: []GitCommitDescription{{
+				Version:     2,
+				Message:     message,
+				Diff:        []byte(diff),
+				AuthorName:  "Sourcegraph",
+				AuthorEmail: "batch-changes@sourcegraph.com",
+			}},
+		}
+	}
+	first := newSpec("refs/heads/migrate-api-1", "Add new API", "Migrate API", stageDiff1)
+	first.StackStage = 1
+	second := newSpec("refs/heads/migrate-api-3", "Migrate API", "Delete old API", stageDiff1To2)
+	second.StackStage = 2
+	second.StackParentHeadRef = "refs/heads/migrate-api-1"
+
+	if diff := cmp.Diff([]*ChangesetSpec{first, second}, have); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBuildStackedChangesetSpecs_WithoutStepResults(t *testing.T) {
+	// Changeset specs built client-side only have the result of the last step,
+	// which can't be split into stages.
+	input := &ChangesetSpecInput{
+		Repository: Repository{
+			ID:      "base-repo-id",
+			Name:    "github.com/sourcegraph/src-cli",
+			BaseRef: "refs/heads/main",
+			BaseRev: "f00b4r",
+		},
+		BatchChangeAttributes: &template.BatchChangeAttributes{Name: "the name"},
+		Template: &ChangesetTemplate{
+			Title:  "Migrate API",
+			Branch: "migrate-api",
+			Commit: ExpandedGitCommitDescription{Message: "Migrate API"},
+			Stages: []ChangesetTemplateStage{
+				{Branch: "migrate-api-1", UntilStep: 1},
+				{Branch: "migrate-api-2"},
+			},
+		},
+		Result: execution.AfterStepResult{StepIndex: 1, Diff: []byte(stageDiff2)},
+	}
+
+	_, err := BuildChangesetSpecs(input, true, nil)
+	if err != ErrStagesWithoutStepResults {
+		t.Fatalf("unexpected error. want=%v have=%v", ErrStagesWithoutStepResults, err)
+	}
+}
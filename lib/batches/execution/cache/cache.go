@@ -151,7 +151,33 @@ func KeyForWorkspace(batchChangeAttributes *template.BatchChangeAttributes, r ba
 }
 
 // ChangesetSpecsFromCache takes the execution.Result and generates all changeset specs from it.
+//
+// The results of earlier steps are not available, so batch specs that split
+// the changes into stages are rejected.
 func ChangesetSpecsFromCache(spec *batches.BatchSpec, r batches.Repository, result execution.AfterStepResult, path string, binaryDiffs bool, fallbackAuthor *batches.ChangesetSpecAuthor) ([]*batches.ChangesetSpec, error) {
+	return changesetSpecsFromResult(spec, r, result, nil, path, binaryDiffs, fallbackAuthor)
+}
+
+// ChangesetSpecsFromStepResults generates all changeset specs from the results
+// of the steps of a workspace. The result of the last step is used to build
+// the changeset specs, and the results of earlier steps are used to split the
+// changes into the stages of the changeset template.
+func ChangesetSpecsFromStepResults(spec *batches.BatchSpec, r batches.Repository, results []execution.AfterStepResult, path string, binaryDiffs bool, fallbackAuthor *batches.ChangesetSpecAuthor) ([]*batches.ChangesetSpec, error) {
+	if len(results) == 0 {
+		return []*batches.ChangesetSpec{}, nil
+	}
+
+	result := results[0]
+	for _, res := range results {
+		if res.StepIndex > result.StepIndex {
+			result = res
+		}
+	}
+
+	return changesetSpecsFromResult(spec, r, result, results, path, binaryDiffs, fallbackAuthor)
+}
+
+func changesetSpecsFromResult(spec *batches.BatchSpec, r batches.Repository, result execution.AfterStepResult, stepResults []execution.AfterStepResult, path string, binaryDiffs bool, fallbackAuthor *batches.ChangesetSpecAuthor) ([]*batches.ChangesetSpec, error) {
 	if len(result.Diff) == 0 {
 		return []*batches.ChangesetSpec{}, nil
 	}
@@ -167,6 +193,7 @@ func ChangesetSpecsFromCache(spec *batches.BatchSpec, r batches.Repository, resu
 		Template:         spec.ChangesetTemplate,
 		TransformChanges: spec.TransformChanges,
 		Result:           result,
+		StepResults:      stepResults,
 		Path:             path,
 	}
 
@@ -123,8 +123,49 @@ const BatchSpecJSON = `{
         "type": "object",
         "description": "A command to run (as part of a sequence) in a repository branch to produce the required changes.",
         "additionalProperties": false,
-        "required": ["run", "container"],
+        "oneOf": [
+          {
+            "required": ["run", "container"],
+            "not": { "required": ["rewrite"] }
+          },
+          {
+            "required": ["rewrite"],
+            "not": {
+              "anyOf": [
+                { "required": ["run"] },
+                { "required": ["container"] },
+                { "required": ["env"] },
+                { "required": ["files"] },
+                { "required": ["mount"] }
+              ]
+            }
+          }
+        ],
         "properties": {
+          "rewrite": {
+            "title": "RewriteStep",
+            "type": "object",
+            "description": "A search-and-replace that is applied to the files in the workspace without running a container. Batch specs whose steps are all rewrite steps are executed by Sourcegraph directly, without executors.",
+            "additionalProperties": false,
+            "required": ["pattern", "rewrite"],
+            "properties": {
+              "pattern": {
+                "type": "string",
+                "description": "The pattern to match. A Comby match template, or a regular expression if the matcher is ` + "`" + `regexp` + "`" + `.",
+                "examples": ["fmt.Sprintf(\":[format]\")", "errors\\.New\\((\"[^\"]*\")\\)"]
+              },
+              "rewrite": {
+                "type": "string",
+                "description": "The replacement for each match. A Comby rewrite template, or a replacement string that can reference submatches such as ` + "`" + `$1` + "`" + ` if the matcher is ` + "`" + `regexp` + "`" + `.",
+                "examples": [":[format]", "errors.Newf($1)"]
+              },
+              "matcher": {
+                "type": "string",
+                "description": "The Comby matcher to use, given as a file extension. Only files with that extension are rewritten. Set to ` + "`" + `regexp` + "`" + ` to match a regular expression in all files instead. Defaults to ` + "`" + `.generic` + "`" + `, which rewrites all files.",
+                "examples": [".go", ".ts", "regexp"]
+              }
+            }
+          },
           "run": {
             "type": "string",
             "description": "The shell command to run in the container. It can also be a multi-line shell script. The working directory is the root directory of the repository checkout."
@@ -328,6 +369,45 @@ const BatchSpecJSON = `{
           "type": "boolean",
           "description": "Whether to publish the changeset to a fork of the target repository. If omitted, the changeset will be published to a branch directly on the target repository, unless the global ` + "`" + `batches.enforceFork` + "`" + ` setting is enabled. If set, this property will override any global setting."
         },
+        "requestReviewsFromOwners": {
+          "type": "boolean",
+          "description": "Whether to request reviews from the code owners of the files changed by each changeset once it is published. Owners are resolved from CODEOWNERS files and owners assigned in Sourcegraph, and mapped to code host users and teams through their external accounts.",
+          "default": false
+        },
+        "stages": {
+          "type": "array",
+          "description": "Splits the changes produced by the steps into a stack of dependent changesets per repository. Each stage contains the changes made by the steps up to and including its ` + "`" + `untilStep` + "`" + `, minus the changes of the previous stage. A stage is only published once the previous stage has been merged, and is then rebased onto the base branch.",
+          "minItems": 2,
+          "items": {
+            "title": "ChangesetTemplateStage",
+            "type": "object",
+            "additionalProperties": false,
+            "required": ["branch"],
+            "properties": {
+              "title": {
+                "type": "string",
+                "description": "The title of the changeset for this stage. Defaults to the title of the changeset template."
+              },
+              "body": {
+                "type": "string",
+                "description": "The body (description) of the changeset for this stage. Defaults to the body of the changeset template."
+              },
+              "branch": {
+                "type": "string",
+                "description": "The name of the Git branch to create or update on each repository with the changes of this stage."
+              },
+              "commitMessage": {
+                "type": "string",
+                "description": "The Git commit message for this stage. Defaults to the commit message of the changeset template."
+              },
+              "untilStep": {
+                "type": "integer",
+                "description": "The 1-based index of the last step whose changes are part of this stage. Can be omitted on the last stage, in which case it includes all remaining steps.",
+                "minimum": 1
+              }
+            }
+          }
+        },
         "commit": {
           "title": "ExpandedGitCommitDescription",
           "type": "object",
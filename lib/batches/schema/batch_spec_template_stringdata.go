@@ -0,0 +1,67 @@
+// Code generated by stringdata. DO NOT EDIT.
+
+package schema
+
+// BatchSpecTemplateJSON is the content of the file "schema/batch_spec_template.schema.json".
+const BatchSpecTemplateJSON = `{
+  "$id": "batch_spec_template.schema.json#",
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "title": "BatchSpecTemplate",
+  "description": "A reusable batch spec with typed input parameters, from which batch changes can be created.",
+  "type": "object",
+  "additionalProperties": false,
+  "required": ["name", "spec"],
+  "properties": {
+    "name": {
+      "type": "string",
+      "description": "The name of the template, which identifies it in the templates library.",
+      "pattern": "^[\\w.-]+$"
+    },
+    "description": {
+      "type": "string",
+      "description": "The description of the template, which explains what batch changes created from it do."
+    },
+    "parameters": {
+      "type": "array",
+      "description": "The input parameters of the template. Their values are referenced in the spec as ${{ params.<name> }}.",
+      "items": {
+        "title": "BatchSpecTemplateParameter",
+        "type": "object",
+        "additionalProperties": false,
+        "required": ["name", "type"],
+        "properties": {
+          "name": {
+            "type": "string",
+            "description": "The name of the parameter.",
+            "pattern": "^[A-Za-z_][A-Za-z0-9_]*$"
+          },
+          "type": {
+            "type": "string",
+            "description": "The type of the parameter, which determines which values are valid for it.",
+            "enum": ["string", "version", "repositoryQuery", "users"]
+          },
+          "description": {
+            "type": "string",
+            "description": "The description of the parameter, which is shown to users that create a batch change from the template."
+          },
+          "required": {
+            "type": "boolean",
+            "description": "Whether a value has to be given for the parameter. Parameters with a default value are never required.",
+            "default": false
+          },
+          "default": {
+            "description": "The value of the parameter if none is given.",
+            "type": ["string", "array"],
+            "items": { "type": "string" }
+          }
+        }
+      }
+    },
+    "spec": {
+      "type": "string",
+      "description": "The batch spec YAML. It is rendered with the values of the parameters and then validated like any other batch spec.",
+      "minLength": 1
+    }
+  }
+}
+`
@@ -70,6 +70,21 @@ const ChangesetSpecJSON = `{
           "pattern": "^refs\\/heads\\/\\S+$",
           "examples": ["refs/heads/fix-foo"]
         },
+        "stackStage": {
+          "type": "integer",
+          "description": "The 1-based position of this changeset in a stack of dependent changesets in the same repository. Omitted if the changeset is not part of a stack.",
+          "minimum": 1
+        },
+        "stackParentHeadRef": {
+          "type": "string",
+          "description": "The full name of the head ref of the previous changeset in the stack. This changeset is only published once that changeset has been merged.",
+          "pattern": "^refs\\/heads\\/\\S+$",
+          "examples": ["refs/heads/add-new-api"]
+        },
+        "requestReviewsFromOwners": {
+          "type": "boolean",
+          "description": "Whether to request reviews from the code owners of the files changed by this changeset once it is published."
+        },
         "title": { "type": "string", "description": "The title of the changeset on the code host." },
         "body": { "type": "string", "description": "The body (description) of the changeset on the code host." },
         "commits": {
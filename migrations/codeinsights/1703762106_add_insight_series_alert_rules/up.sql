@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS insight_series_alert_rules (
+    id SERIAL PRIMARY KEY,
+    series_id INTEGER NOT NULL REFERENCES insight_series(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL,
+    direction TEXT NOT NULL,
+    threshold DOUBLE PRECISION NOT NULL,
+    window_size INTEGER NOT NULL DEFAULT 1,
+    email_user_id INTEGER,
+    slack_webhook_url TEXT,
+    webhook_url TEXT,
+    created_by INTEGER,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    firing BOOLEAN NOT NULL DEFAULT FALSE,
+    last_evaluated_at TIMESTAMP WITHOUT TIME ZONE,
+    last_triggered_at TIMESTAMP WITHOUT TIME ZONE,
+    CONSTRAINT insight_series_alert_rules_kind_check CHECK (kind IN ('threshold', 'percent_change', 'anomaly')),
+    CONSTRAINT insight_series_alert_rules_direction_check CHECK (direction IN ('above', 'below')),
+    CONSTRAINT insight_series_alert_rules_window_size_check CHECK (window_size > 0)
+);
+
+CREATE INDEX IF NOT EXISTS insight_series_alert_rules_series_id_idx ON insight_series_alert_rules(series_id);
+
+COMMENT ON TABLE insight_series_alert_rules IS 'Rules that notify users when the values of an insight series cross a threshold, change by a percentage or deviate from their recent values.';
+COMMENT ON COLUMN insight_series_alert_rules.kind IS 'One of threshold, percent_change or anomaly.';
+COMMENT ON COLUMN insight_series_alert_rules.window_size IS 'The number of preceding data points the latest data point is compared against for percent_change and anomaly rules.';
+COMMENT ON COLUMN insight_series_alert_rules.email_user_id IS 'The ID of the user in the main database who is notified by email.';
+COMMENT ON COLUMN insight_series_alert_rules.firing IS 'Whether the rule matched the latest evaluation. Notifications are only sent when a rule starts firing.';
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS insights_historical_query_jobs;
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS insights_historical_query_jobs (
+    id                SERIAL PRIMARY KEY,
+    state             text DEFAULT 'queued',
+    failure_message   text,
+    queued_at         timestamp with time zone DEFAULT NOW(),
+    started_at        timestamp with time zone,
+    finished_at       timestamp with time zone,
+    process_after     timestamp with time zone,
+    num_resets        integer not null default 0,
+    num_failures      integer not null default 0,
+    last_heartbeat_at timestamp with time zone,
+    execution_logs    json[],
+    worker_hostname   text not null default '',
+    cancel            boolean not null default false,
+
+    user_id                integer not null,
+    query                  text not null,
+    repositories           text[] not null,
+    sample_interval_unit   text not null,
+    sample_interval_value  integer not null,
+    num_samples            integer not null,
+    repositories_completed integer not null default 0,
+    results                jsonb not null default '[]'
+);
+
+COMMENT ON TABLE insights_historical_query_jobs IS 'One-off historical queries that count the matches of a search query at past points in time. Jobs and their results are deleted after a day.';
+COMMENT ON COLUMN insights_historical_query_jobs.user_id IS 'The ID of the user in the main database who ran the query.';
+COMMENT ON COLUMN insights_historical_query_jobs.results IS 'The number of matches at each sample time, summed over the completed repositories.';
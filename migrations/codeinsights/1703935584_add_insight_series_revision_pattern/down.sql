@@ -0,0 +1,2 @@
+ALTER TABLE insight_series_recording_times DROP COLUMN IF EXISTS revision;
+ALTER TABLE insight_series DROP COLUMN IF EXISTS revision_pattern;
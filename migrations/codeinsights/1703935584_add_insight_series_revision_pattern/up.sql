@@ -0,0 +1,5 @@
+ALTER TABLE insight_series ADD COLUMN IF NOT EXISTS revision_pattern text;
+ALTER TABLE insight_series_recording_times ADD COLUMN IF NOT EXISTS revision text;
+
+COMMENT ON COLUMN insight_series.revision_pattern IS 'A glob pattern of tag names. Series with a revision pattern are sampled at the matching tags of their repository, rather than at time intervals.';
+COMMENT ON COLUMN insight_series_recording_times.revision IS 'The tag that the series was sampled at, for series with a revision pattern.';
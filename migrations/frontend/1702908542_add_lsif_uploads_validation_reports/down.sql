@@ -0,0 +1 @@
+DROP TABLE IF EXISTS lsif_uploads_validation_reports;
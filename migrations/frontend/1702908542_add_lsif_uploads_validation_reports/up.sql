@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS lsif_uploads_validation_reports (
+    upload_id integer NOT NULL PRIMARY KEY,
+    error_count integer NOT NULL DEFAULT 0,
+    warning_count integer NOT NULL DEFAULT 0,
+    problems jsonb NOT NULL DEFAULT '[]'::jsonb,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT lsif_uploads_validation_reports_upload_id_fkey FOREIGN KEY (upload_id) REFERENCES lsif_uploads(id) ON DELETE CASCADE
+);
+
+COMMENT ON TABLE lsif_uploads_validation_reports IS 'Problems found while validating the contents of a precise code intelligence upload during processing.';
+COMMENT ON COLUMN lsif_uploads_validation_reports.problems IS 'A list of problems, each with a code, severity, description, count, and a bounded list of sample instances.';
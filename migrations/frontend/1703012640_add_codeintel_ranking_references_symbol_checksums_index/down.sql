@@ -0,0 +1 @@
+DROP INDEX IF EXISTS codeintel_ranking_references_symbol_checksums;
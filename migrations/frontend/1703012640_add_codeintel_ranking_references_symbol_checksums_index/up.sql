@@ -0,0 +1,2 @@
+CREATE INDEX CONCURRENTLY IF NOT EXISTS codeintel_ranking_references_symbol_checksums
+ON codeintel_ranking_references USING GIN(symbol_checksums);
@@ -0,0 +1,3 @@
+ALTER TABLE changeset_specs
+    DROP COLUMN IF EXISTS stack_parent_head_ref,
+    DROP COLUMN IF EXISTS stack_stage;
@@ -0,0 +1,3 @@
+ALTER TABLE changeset_specs
+    ADD COLUMN IF NOT EXISTS stack_parent_head_ref text,
+    ADD COLUMN IF NOT EXISTS stack_stage integer;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS changeset_rebases;
+
+ALTER TABLE batch_changes DROP COLUMN IF EXISTS auto_rebase;
@@ -0,0 +1,14 @@
+ALTER TABLE batch_changes ADD COLUMN IF NOT EXISTS auto_rebase boolean NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS changeset_rebases (
+    changeset_id bigint NOT NULL PRIMARY KEY REFERENCES changesets(id) ON DELETE CASCADE,
+    changeset_spec_id bigint NOT NULL REFERENCES changeset_specs(id) ON DELETE CASCADE,
+    base_rev text NOT NULL,
+    conflicting_files text[] NOT NULL DEFAULT '{}'::text[],
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE changeset_rebases IS 'The last attempt to re-apply the diff of a changeset onto the latest commit of its base branch.';
+COMMENT ON COLUMN changeset_rebases.base_rev IS 'The base branch commit the diff was last re-applied onto.';
+COMMENT ON COLUMN changeset_rebases.conflicting_files IS 'The files whose changes did not apply onto base_rev. Empty if the rebase succeeded.';
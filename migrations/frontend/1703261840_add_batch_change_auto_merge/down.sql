@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS changeset_auto_merges;
+
+ALTER TABLE batch_changes
+    DROP COLUMN IF EXISTS auto_merge,
+    DROP COLUMN IF EXISTS auto_merge_max_per_hour,
+    DROP COLUMN IF EXISTS auto_merge_squash;
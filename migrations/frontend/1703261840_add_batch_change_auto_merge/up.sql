@@ -0,0 +1,19 @@
+ALTER TABLE batch_changes
+    ADD COLUMN IF NOT EXISTS auto_merge boolean NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS auto_merge_max_per_hour integer NOT NULL DEFAULT 10,
+    ADD COLUMN IF NOT EXISTS auto_merge_squash boolean NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS changeset_auto_merges (
+    changeset_id bigint NOT NULL PRIMARY KEY REFERENCES changesets(id) ON DELETE CASCADE,
+    batch_change_id bigint NOT NULL REFERENCES batch_changes(id) ON DELETE CASCADE,
+    state text NOT NULL,
+    message text,
+    merged_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS changeset_auto_merges_batch_change_id_merged_at ON changeset_auto_merges (batch_change_id, merged_at);
+
+COMMENT ON TABLE changeset_auto_merges IS 'The progress of automatically merging a changeset of a batch change with an auto-merge policy.';
+COMMENT ON COLUMN changeset_auto_merges.state IS 'What the changeset is waiting for before it can be merged, or the outcome of merging it.';
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS batch_spec_templates (
+    id bigserial PRIMARY KEY,
+    name text NOT NULL,
+    description text NOT NULL DEFAULT '',
+    parameters jsonb NOT NULL DEFAULT '[]'::jsonb,
+    spec text NOT NULL,
+    raw_template text NOT NULL,
+    creator_id integer REFERENCES users(id) ON DELETE SET NULL DEFERRABLE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS batch_spec_templates_name_unique ON batch_spec_templates (name);
+
+COMMENT ON TABLE batch_spec_templates IS 'Reusable batch specs with typed input parameters, published by site admins, from which users can create batch changes.';
+COMMENT ON COLUMN batch_spec_templates.parameters IS 'The input parameters of the template, as objects with name, type, description, required and default.';
+COMMENT ON COLUMN batch_spec_templates.raw_template IS 'The template as it was published, in YAML or JSON.';
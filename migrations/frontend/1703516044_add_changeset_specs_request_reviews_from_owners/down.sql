@@ -0,0 +1,2 @@
+ALTER TABLE changeset_specs
+    DROP COLUMN IF EXISTS request_reviews_from_owners;
@@ -0,0 +1,2 @@
+ALTER TABLE changeset_specs
+    ADD COLUMN IF NOT EXISTS request_reviews_from_owners boolean DEFAULT false NOT NULL;
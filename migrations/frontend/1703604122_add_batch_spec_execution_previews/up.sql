@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS batch_spec_execution_previews (
+    batch_spec_id bigint PRIMARY KEY REFERENCES batch_specs(id) ON DELETE CASCADE DEFERRABLE,
+    sample_size integer NOT NULL,
+    workspaces integer NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE batch_spec_execution_previews IS 'Batch specs that have only been executed on a random sample of their workspaces, to preview the changes before executing all workspaces.';
+COMMENT ON COLUMN batch_spec_execution_previews.workspaces IS 'The number of workspaces that would be executed in a full execution of the batch spec.';
@@ -0,0 +1,3 @@
+ALTER TABLE changeset_specs ADD COLUMN IF NOT EXISTS reverted_changeset_id bigint REFERENCES changesets(id) ON DELETE SET NULL DEFERRABLE;
+
+COMMENT ON COLUMN changeset_specs.reverted_changeset_id IS 'The merged changeset that the changeset spec reverts, if it was created by rolling back a batch change.';
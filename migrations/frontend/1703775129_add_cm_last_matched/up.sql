@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS cm_last_matched (
+    monitor_id bigint NOT NULL REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    match_keys text[] NOT NULL,
+    PRIMARY KEY (monitor_id, repo_id)
+);
+
+COMMENT ON TABLE cm_last_matched IS 'The file content matches found by the last run of a code monitor that searches file contents rather than commits';
+
+COMMENT ON COLUMN cm_last_matched.match_keys IS 'Hashes identifying the matched lines and paths in the repository, used to detect new matches on the next run';
@@ -0,0 +1,64 @@
+CREATE TABLE IF NOT EXISTS cm_teams_webhooks (
+    id bigserial PRIMARY KEY,
+    monitor bigint NOT NULL REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    url text NOT NULL,
+    enabled boolean NOT NULL,
+    include_results boolean NOT NULL DEFAULT false,
+    created_by integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    changed_by integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    changed_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS cm_teams_webhooks_monitor ON cm_teams_webhooks USING btree (monitor);
+
+COMMENT ON TABLE cm_teams_webhooks IS 'Microsoft Teams webhook actions configured on code monitors';
+
+COMMENT ON COLUMN cm_teams_webhooks.monitor IS 'The code monitor that the action is defined on';
+
+COMMENT ON COLUMN cm_teams_webhooks.url IS 'The Microsoft Teams incoming webhook URL we send the code monitor event to';
+
+CREATE TABLE IF NOT EXISTS cm_incident_webhooks (
+    id bigserial PRIMARY KEY,
+    monitor bigint NOT NULL REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    url text NOT NULL,
+    enabled boolean NOT NULL,
+    payload_template text NOT NULL,
+    created_by integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    changed_by integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    changed_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS cm_incident_webhooks_monitor ON cm_incident_webhooks USING btree (monitor);
+
+COMMENT ON TABLE cm_incident_webhooks IS 'Incident webhook actions configured on code monitors, which post a templated payload to an incident management API';
+
+COMMENT ON COLUMN cm_incident_webhooks.monitor IS 'The code monitor that the action is defined on';
+
+COMMENT ON COLUMN cm_incident_webhooks.url IS 'The incident API endpoint we send the code monitor event to';
+
+COMMENT ON COLUMN cm_incident_webhooks.payload_template IS 'The Go template that renders the JSON request body from the code monitor event';
+
+ALTER TABLE cm_action_jobs
+    ADD COLUMN IF NOT EXISTS teams_webhook bigint REFERENCES cm_teams_webhooks(id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS incident_webhook bigint REFERENCES cm_incident_webhooks(id) ON DELETE CASCADE;
+
+COMMENT ON COLUMN cm_action_jobs.email IS 'The ID of the cm_emails action to execute if this is an email job. Mutually exclusive with the other action columns';
+
+COMMENT ON COLUMN cm_action_jobs.webhook IS 'The ID of the cm_webhooks action to execute if this is a webhook job. Mutually exclusive with the other action columns';
+
+COMMENT ON COLUMN cm_action_jobs.slack_webhook IS 'The ID of the cm_slack_webhook action to execute if this is a slack webhook job. Mutually exclusive with the other action columns';
+
+COMMENT ON COLUMN cm_action_jobs.teams_webhook IS 'The ID of the cm_teams_webhooks action to execute if this is a Microsoft Teams webhook job. Mutually exclusive with the other action columns';
+
+COMMENT ON COLUMN cm_action_jobs.incident_webhook IS 'The ID of the cm_incident_webhooks action to execute if this is an incident webhook job. Mutually exclusive with the other action columns';
+
+ALTER TABLE cm_action_jobs DROP CONSTRAINT IF EXISTS cm_action_jobs_only_one_action_type;
+ALTER TABLE cm_action_jobs ADD CONSTRAINT cm_action_jobs_only_one_action_type CHECK ((
+    CASE WHEN email IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN webhook IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN slack_webhook IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN teams_webhook IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN incident_webhook IS NULL THEN 0 ELSE 1 END
+) = 1);
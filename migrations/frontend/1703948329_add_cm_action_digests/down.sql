@@ -0,0 +1,21 @@
+ALTER TABLE cm_action_jobs
+    DROP COLUMN IF EXISTS digest_start,
+    DROP COLUMN IF EXISTS digest_end;
+
+ALTER TABLE cm_emails
+    DROP CONSTRAINT IF EXISTS cm_emails_digest_interval_valid,
+    DROP COLUMN IF EXISTS digest_interval,
+    DROP COLUMN IF EXISTS digest_max_results,
+    DROP COLUMN IF EXISTS last_digest_at;
+
+ALTER TABLE cm_webhooks
+    DROP CONSTRAINT IF EXISTS cm_webhooks_digest_interval_valid,
+    DROP COLUMN IF EXISTS digest_interval,
+    DROP COLUMN IF EXISTS digest_max_results,
+    DROP COLUMN IF EXISTS last_digest_at;
+
+ALTER TABLE cm_slack_webhooks
+    DROP CONSTRAINT IF EXISTS cm_slack_webhooks_digest_interval_valid,
+    DROP COLUMN IF EXISTS digest_interval,
+    DROP COLUMN IF EXISTS digest_max_results,
+    DROP COLUMN IF EXISTS last_digest_at;
@@ -0,0 +1,58 @@
+ALTER TABLE cm_emails
+    ADD COLUMN IF NOT EXISTS digest_interval text,
+    ADD COLUMN IF NOT EXISTS digest_max_results integer,
+    ADD COLUMN IF NOT EXISTS last_digest_at timestamp with time zone;
+
+ALTER TABLE cm_emails
+    DROP CONSTRAINT IF EXISTS cm_emails_digest_interval_valid,
+    ADD CONSTRAINT cm_emails_digest_interval_valid CHECK (
+        digest_interval = ANY('{"HOURLY","DAILY","WEEKLY"}')
+    );
+
+COMMENT ON COLUMN cm_emails.digest_interval IS 'If set, the action sends a digest of the matches found in this interval instead of a notification per trigger run';
+
+COMMENT ON COLUMN cm_emails.digest_max_results IS 'The maximum number of matches included in a digest';
+
+COMMENT ON COLUMN cm_emails.last_digest_at IS 'The end of the period covered by the last digest, and the start of the next one';
+
+ALTER TABLE cm_webhooks
+    ADD COLUMN IF NOT EXISTS digest_interval text,
+    ADD COLUMN IF NOT EXISTS digest_max_results integer,
+    ADD COLUMN IF NOT EXISTS last_digest_at timestamp with time zone;
+
+ALTER TABLE cm_webhooks
+    DROP CONSTRAINT IF EXISTS cm_webhooks_digest_interval_valid,
+    ADD CONSTRAINT cm_webhooks_digest_interval_valid CHECK (
+        digest_interval = ANY('{"HOURLY","DAILY","WEEKLY"}')
+    );
+
+COMMENT ON COLUMN cm_webhooks.digest_interval IS 'If set, the action sends a digest of the matches found in this interval instead of a notification per trigger run';
+
+COMMENT ON COLUMN cm_webhooks.digest_max_results IS 'The maximum number of matches included in a digest';
+
+COMMENT ON COLUMN cm_webhooks.last_digest_at IS 'The end of the period covered by the last digest, and the start of the next one';
+
+ALTER TABLE cm_slack_webhooks
+    ADD COLUMN IF NOT EXISTS digest_interval text,
+    ADD COLUMN IF NOT EXISTS digest_max_results integer,
+    ADD COLUMN IF NOT EXISTS last_digest_at timestamp with time zone;
+
+ALTER TABLE cm_slack_webhooks
+    DROP CONSTRAINT IF EXISTS cm_slack_webhooks_digest_interval_valid,
+    ADD CONSTRAINT cm_slack_webhooks_digest_interval_valid CHECK (
+        digest_interval = ANY('{"HOURLY","DAILY","WEEKLY"}')
+    );
+
+COMMENT ON COLUMN cm_slack_webhooks.digest_interval IS 'If set, the action sends a digest of the matches found in this interval instead of a notification per trigger run';
+
+COMMENT ON COLUMN cm_slack_webhooks.digest_max_results IS 'The maximum number of matches included in a digest';
+
+COMMENT ON COLUMN cm_slack_webhooks.last_digest_at IS 'The end of the period covered by the last digest, and the start of the next one';
+
+ALTER TABLE cm_action_jobs
+    ADD COLUMN IF NOT EXISTS digest_start timestamp with time zone,
+    ADD COLUMN IF NOT EXISTS digest_end timestamp with time zone;
+
+COMMENT ON COLUMN cm_action_jobs.digest_start IS 'If set, the job sends a digest of the trigger runs of the monitor that finished after this time';
+
+COMMENT ON COLUMN cm_action_jobs.digest_end IS 'The end of the period covered by a digest job, inclusive';
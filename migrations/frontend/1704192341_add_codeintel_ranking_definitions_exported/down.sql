@@ -0,0 +1 @@
+ALTER TABLE codeintel_ranking_definitions DROP COLUMN IF EXISTS exported;
@@ -0,0 +1 @@
+ALTER TABLE codeintel_ranking_definitions ADD COLUMN IF NOT EXISTS exported boolean;
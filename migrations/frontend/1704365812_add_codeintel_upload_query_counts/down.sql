@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS codeintel_upload_query_counts;
+
+ALTER TABLE lsif_uploads_visible_at_tip DROP COLUMN IF EXISTS is_tag;
@@ -0,0 +1,11 @@
+ALTER TABLE lsif_uploads_visible_at_tip ADD COLUMN IF NOT EXISTS is_tag boolean NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN lsif_uploads_visible_at_tip.is_tag IS 'Whether the upload is visible from the tip of a tag.';
+
+CREATE TABLE IF NOT EXISTS codeintel_upload_query_counts (
+    upload_id integer NOT NULL PRIMARY KEY REFERENCES lsif_uploads(id) ON DELETE CASCADE,
+    query_count bigint NOT NULL DEFAULT 0,
+    last_queried_at timestamp with time zone NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE codeintel_upload_query_counts IS 'Tracks how often each upload is used to answer code navigation requests.';
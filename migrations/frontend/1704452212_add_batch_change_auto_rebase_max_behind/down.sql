@@ -0,0 +1 @@
+ALTER TABLE batch_changes DROP COLUMN IF EXISTS auto_rebase_max_behind;
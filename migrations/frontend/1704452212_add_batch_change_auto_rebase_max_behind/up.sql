@@ -0,0 +1,3 @@
+ALTER TABLE batch_changes ADD COLUMN IF NOT EXISTS auto_rebase_max_behind integer NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN batch_changes.auto_rebase_max_behind IS 'The number of commits the base branch of a changeset can move on before the changeset is rebased, even without conflicts. Zero only rebases changesets that may be in conflict.';
@@ -0,0 +1 @@
+ALTER TABLE insights_query_runner_jobs DROP COLUMN IF EXISTS recorded_at;
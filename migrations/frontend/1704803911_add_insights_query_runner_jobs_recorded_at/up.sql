@@ -0,0 +1,3 @@
+ALTER TABLE insights_query_runner_jobs ADD COLUMN IF NOT EXISTS recorded_at timestamp with time zone;
+
+COMMENT ON COLUMN insights_query_runner_jobs.recorded_at IS 'The time the job recorded its data point. The alert rules of the series are evaluated once every job of the series has recorded its data point.';